@@ -0,0 +1,170 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/googleapis/genai-toolbox/internal/bench"
+	"github.com/googleapis/genai-toolbox/internal/log"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/telemetry"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/spf13/cobra"
+)
+
+// benchOptions are the flags of the bench command.
+type benchOptions struct {
+	url         string
+	toolsFile   string
+	tool        string
+	params      string
+	headers     []string
+	concurrency int
+	requests    int
+	duration    time.Duration
+	timeout     time.Duration
+	output      string
+}
+
+// newBenchCommand returns the command driving concurrent invocations of a
+// tool, to size deployments.
+func newBenchCommand() *cobra.Command {
+	var opts benchOptions
+	benchCmd := &cobra.Command{
+		Use:   "bench",
+		Short: "Load test a tool with concurrent invocations.",
+		Long: "Load test a tool with concurrent invocations, against a running server (--url) or in-process from a tools file " +
+			"(--tools-file), and report their latencies and errors. Errors such as timeouts show when the connection pool " +
+			"of a source is exhausted.",
+		Args: cobra.NoArgs,
+		RunE: func(c *cobra.Command, _ []string) error {
+			return runBench(c, opts)
+		},
+	}
+	flags := benchCmd.Flags()
+	flags.StringVar(&opts.url, "url", "", "URL of a running Toolbox server to invoke the tool on (e.g. 'http://127.0.0.1:5000').")
+	flags.StringVar(&opts.toolsFile, "tools-file", "", "File path of a tool configuration to invoke the tool in-process, without auth services, quotas and policies.")
+	flags.StringVar(&opts.tool, "tool", "", "Name of the tool to invoke.")
+	flags.StringVar(&opts.params, "params", "{}", "Parameters of the invocations, as a JSON object.")
+	flags.StringArrayVarP(&opts.headers, "header", "H", nil, "Header of the requests to the server, as 'Name: value' (e.g. 'Authorization: Bearer ...'). Can be repeated.")
+	flags.IntVarP(&opts.concurrency, "concurrency", "c", 10, "Number of invocations in flight at once.")
+	flags.IntVarP(&opts.requests, "requests", "n", 100, "Number of invocations to make, unless --duration is set.")
+	flags.DurationVarP(&opts.duration, "duration", "d", 0, "Duration to make invocations for (e.g. '30s'), instead of a number of them.")
+	flags.DurationVar(&opts.timeout, "timeout", 30*time.Second, "Maximum duration of an invocation, beyond which it counts as a timeout. Disabled if 0.")
+	flags.StringVarP(&opts.output, "output", "o", "text", "Format of the report. Allowed: 'text' or 'json'.")
+	_ = benchCmd.MarkFlagRequired("tool")
+	benchCmd.MarkFlagsMutuallyExclusive("url", "tools-file")
+	benchCmd.MarkFlagsOneRequired("url", "tools-file")
+	return benchCmd
+}
+
+func runBench(c *cobra.Command, opts benchOptions) error {
+	if opts.output != "text" && opts.output != "json" {
+		return fmt.Errorf("output must be 'text' or 'json', got %q", opts.output)
+	}
+	var params map[string]any
+	// parameters are decoded as the server does, so that integers are kept
+	if err := util.DecodeJSON(strings.NewReader(opts.params), &params); err != nil {
+		return fmt.Errorf("unable to parse parameters: %w", err)
+	}
+
+	logger, err := log.NewStdLogger(c.ErrOrStderr(), c.ErrOrStderr(), "WARN")
+	if err != nil {
+		return fmt.Errorf("unable to initialize logger: %w", err)
+	}
+	ctx := util.WithLogger(c.Context(), logger)
+
+	var invoke bench.Invoker
+	if opts.url != "" {
+		header := make(http.Header)
+		for _, h := range opts.headers {
+			name, value, ok := strings.Cut(h, ":")
+			if !ok {
+				return fmt.Errorf("invalid header %q: must be 'Name: value'", h)
+			}
+			header.Add(strings.TrimSpace(name), strings.TrimSpace(value))
+		}
+		invoke, err = bench.HTTPInvoker(http.DefaultClient, opts.url, opts.tool, params, header)
+	} else {
+		invoke, err = localInvoker(ctx, opts.toolsFile, opts.tool, params)
+	}
+	if err != nil {
+		return err
+	}
+
+	report, err := bench.Run(ctx, bench.Config{
+		Concurrency: opts.concurrency,
+		Requests:    opts.requests,
+		Duration:    opts.duration,
+		Timeout:     opts.timeout,
+	}, invoke)
+	if err != nil {
+		return err
+	}
+	if opts.output == "json" {
+		enc := json.NewEncoder(c.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+	fmt.Fprintf(c.OutOrStdout(), "Tool:         %s\n", opts.tool)
+	return report.WriteText(c.OutOrStdout())
+}
+
+// localInvoker initializes the sources and tools of the tools file, and
+// returns an invoker of tool.
+func localInvoker(ctx context.Context, toolsFilePath, tool string, params map[string]any) (bench.Invoker, error) {
+	buf, err := os.ReadFile(toolsFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read tool file at %q: %w", toolsFilePath, err)
+	}
+	toolsFile, err := parseToolsFile(ctx, buf)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse tool file at %q: %w", toolsFilePath, err)
+	}
+	instrumentation, err := telemetry.CreateTelemetryInstrumentation(versionString)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create telemetry instrumentation: %w", err)
+	}
+	ctx = util.WithInstrumentation(ctx, instrumentation)
+
+	cfg := server.ServerConfig{
+		Version:            versionString,
+		SourceConfigs:      toolsFile.Sources,
+		AuthServiceConfigs: toolsFile.AuthServices,
+		ToolConfigs:        toolsFile.Tools,
+		ToolsetConfigs:     toolsFile.Toolsets,
+		// tools invoked in-process can't be reached by anyone else
+		AllowUnauthenticated: true,
+	}
+	if toolsFile.AuthSources != nil {
+		cfg.AuthServiceConfigs = toolsFile.AuthSources
+	}
+	_, _, toolsMap, _, err := server.InitializeConfigs(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	t, ok := toolsMap[tool]
+	if !ok {
+		return nil, fmt.Errorf("no tool named %q configured in %q", tool, toolsFilePath)
+	}
+	return bench.ToolInvoker(t, params)
+}
@@ -0,0 +1,113 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBench(t *testing.T) {
+	dir := t.TempDir()
+	toolsFile := filepath.Join(dir, "tools.yaml")
+	toolsYaml := fmt.Sprintf(`sources:
+  my-sqlite:
+    kind: sqlite
+    database: %s
+tools:
+  my-tool:
+    kind: sqlite-sql
+    source: my-sqlite
+    description: Add one to a number.
+    statement: SELECT ? + 1 AS n
+    parameters:
+      - name: n
+        type: integer
+        description: A number.
+`, filepath.Join(dir, "test.db"))
+	if err := os.WriteFile(toolsFile, []byte(toolsYaml), 0o644); err != nil {
+		t.Fatalf("unable to write tools file: %s", err)
+	}
+
+	t.Run("in-process", func(t *testing.T) {
+		c := NewCommand()
+		var out, errOut strings.Builder
+		c.SetOut(&out)
+		c.SetErr(&errOut)
+		c.SetArgs([]string{"bench", "--tools-file", toolsFile, "--tool", "my-tool", "--params", `{"n": 1}`, "-c", "2", "-n", "20", "-o", "json"})
+		if err := c.Execute(); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if errOut.Len() > 0 {
+			t.Fatalf("unexpected logs: %s", errOut.String())
+		}
+		var report struct {
+			Requests int `json:"requests"`
+			Errors   int `json:"errors"`
+		}
+		if err := json.Unmarshal([]byte(out.String()), &report); err != nil {
+			t.Fatalf("unable to parse report %q: %s", out.String(), err)
+		}
+		if report.Requests != 20 || report.Errors != 0 {
+			t.Fatalf("unexpected report: %s", out.String())
+		}
+	})
+
+	tcs := []struct {
+		desc string
+		args []string
+		err  string
+	}{
+		{
+			desc: "invalid parameters",
+			args: []string{"--tools-file", toolsFile, "--params", `{"n": "one"}`},
+			err:  "invalid parameters",
+		},
+		{
+			desc: "unknown tool",
+			args: []string{"--tools-file", toolsFile, "--tool", "other-tool"},
+			err:  `no tool named "other-tool"`,
+		},
+		{
+			desc: "no target",
+			args: []string{},
+			err:  "at least one of the flags in the group [url tools-file] is required",
+		},
+		{
+			desc: "both targets",
+			args: []string{"--tools-file", toolsFile, "--url", "http://127.0.0.1:5000"},
+			err:  "if any flags in the group [url tools-file] are set none of the others can be",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			c := NewCommand()
+			c.SilenceUsage = true
+			c.SilenceErrors = true
+			c.SetOut(&strings.Builder{})
+			c.SetErr(&strings.Builder{})
+			args := []string{"bench", "--tool", "my-tool"}
+			c.SetArgs(append(args, tc.args...))
+			err := c.Execute()
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("expected error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
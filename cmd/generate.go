@@ -0,0 +1,124 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/googleapis/genai-toolbox/internal/generate"
+	"github.com/googleapis/genai-toolbox/internal/log"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// generateOptions are the flags of the generate command.
+type generateOptions struct {
+	toolsFile string
+	source    string
+	output    string
+	schemas   []string
+	tables    []string
+}
+
+// newGenerateCommand returns the command generating tools from the schema of
+// a source.
+func newGenerateCommand() *cobra.Command {
+	var opts generateOptions
+	generateCmd := &cobra.Command{
+		Use:   "generate",
+		Short: "Generate tools from the schema of a database source.",
+		Long: "Generate tools from the schema of a database source. For each table, a tool getting a row by primary key, " +
+			"a tool listing rows filtered by column values and a tool inserting a row are written as a tools file, " +
+			"with a toolset named after the source.",
+		Args: cobra.NoArgs,
+		RunE: func(c *cobra.Command, _ []string) error {
+			return runGenerate(c, opts)
+		},
+	}
+	flags := generateCmd.Flags()
+	flags.StringVar(&opts.toolsFile, "tools-file", "tools.yaml", "File path of the tool configuration defining the source.")
+	flags.StringVar(&opts.source, "source", "", "Name of the source to generate tools for.")
+	flags.StringVarP(&opts.output, "output", "o", "", "File path to write the generated tools to. Writes to stdout if empty.")
+	flags.StringSliceVar(&opts.schemas, "schemas", nil, "Only generate tools for the tables in these schemas.")
+	flags.StringSliceVar(&opts.tables, "tables", nil, "Only generate tools for these tables, which may be qualified with a schema.")
+	_ = generateCmd.MarkFlagRequired("source")
+	return generateCmd
+}
+
+func runGenerate(c *cobra.Command, opts generateOptions) error {
+	logger, err := log.NewStdLogger(c.ErrOrStderr(), c.ErrOrStderr(), "INFO")
+	if err != nil {
+		return fmt.Errorf("unable to initialize logger: %w", err)
+	}
+	ctx := util.WithLogger(c.Context(), logger)
+
+	buf, err := os.ReadFile(opts.toolsFile)
+	if err != nil {
+		return fmt.Errorf("unable to read tool file at %q: %w", opts.toolsFile, err)
+	}
+	toolsFile, err := parseToolsFile(ctx, buf)
+	if err != nil {
+		return fmt.Errorf("unable to parse tool file at %q: %w", opts.toolsFile, err)
+	}
+	sc, ok := toolsFile.Sources[opts.source]
+	if !ok {
+		return fmt.Errorf("no source named %q configured in %q", opts.source, opts.toolsFile)
+	}
+
+	s, err := sc.Initialize(ctx, noop.NewTracerProvider().Tracer("toolbox"))
+	if err != nil {
+		return fmt.Errorf("unable to initialize source %q: %w", opts.source, err)
+	}
+	lister, ok := s.(sources.SchemaLister)
+	if !ok {
+		return fmt.Errorf("source kind %q is not supported; must be one of %q", s.SourceKind(), generate.SupportedKinds())
+	}
+	tables, err := lister.ListSchema(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to list the tables of source %q: %w", opts.source, err)
+	}
+
+	result, err := generate.Generate(generate.Config{
+		Source:     opts.source,
+		SourceKind: s.SourceKind(),
+		Schemas:    opts.schemas,
+		Tables:     opts.tables,
+	}, tables)
+	if err != nil {
+		return err
+	}
+	for _, w := range result.Warnings {
+		logger.WarnContext(ctx, w)
+	}
+	out, err := result.Marshal(opts.source)
+	if err != nil {
+		return fmt.Errorf("unable to marshal tools: %w", err)
+	}
+	header := fmt.Sprintf("# Generated by 'toolbox generate' from the tables of source %q.\n# Review the tools before use, and serve them along with the tool file defining the source.\n", opts.source)
+	out = append([]byte(header), out...)
+
+	if opts.output == "" {
+		_, err = c.OutOrStdout().Write(out)
+		return err
+	}
+	if err := os.WriteFile(opts.output, out, 0o644); err != nil {
+		return fmt.Errorf("unable to write tools to %q: %w", opts.output, err)
+	}
+	logger.InfoContext(ctx, fmt.Sprintf("Generated %d tools for source %q in %q", len(result.Toolset), opts.source, opts.output))
+	return nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools/sqlitesql"
+)
+
+func TestGenerate(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "test.db")
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("unable to open database: %s", err)
+	}
+	defer db.Close()
+	if _, err := db.Exec(`CREATE TABLE customers (id INTEGER PRIMARY KEY, name TEXT NOT NULL);
+CREATE TABLE tags (name TEXT)`); err != nil {
+		t.Fatalf("unable to create tables: %s", err)
+	}
+
+	toolsFile := filepath.Join(dir, "tools.yaml")
+	sourcesYaml := fmt.Sprintf("sources:\n  my-sqlite:\n    kind: sqlite\n    database: %s\n", dbPath)
+	if err := os.WriteFile(toolsFile, []byte(sourcesYaml), 0o644); err != nil {
+		t.Fatalf("unable to write tools file: %s", err)
+	}
+
+	tcs := []struct {
+		desc string
+		args []string
+		want []string
+	}{
+		{
+			desc: "all tables",
+			args: []string{},
+			want: []string{"get_customers", "list_customers", "insert_customers", "list_tags", "insert_tags"},
+		},
+		{
+			desc: "selected tables",
+			args: []string{"--tables", "tags"},
+			want: []string{"list_tags", "insert_tags"},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			output := filepath.Join(t.TempDir(), "generated.yaml")
+			args := append([]string{"generate", "--tools-file", toolsFile, "--source", "my-sqlite", "--output", output}, tc.args...)
+			if _, _, err := invokeCommand(args); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			buf, err := os.ReadFile(output)
+			if err != nil {
+				t.Fatalf("unable to read output: %s", err)
+			}
+			ctx, err := testutils.ContextWithNewLogger()
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			generated, err := parseToolsFile(ctx, buf)
+			if err != nil {
+				t.Fatalf("unable to parse generated tools: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, []string(generated.Toolsets["my-sqlite"].ToolNames)); diff != "" {
+				t.Fatalf("incorrect toolset: diff %v", diff)
+			}
+			for _, name := range tc.want {
+				cfg, ok := generated.Tools[name].(sqlitesql.Config)
+				if !ok || cfg.Source != "my-sqlite" {
+					t.Fatalf("tool %q is not a sqlite-sql tool using my-sqlite: %+v", name, generated.Tools[name])
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	toolsFile := filepath.Join(t.TempDir(), "tools.yaml")
+	sourcesYaml := "sources:\n  my-http:\n    kind: http\n    baseUrl: http://example.com\n"
+	if err := os.WriteFile(toolsFile, []byte(sourcesYaml), 0o644); err != nil {
+		t.Fatalf("unable to write tools file: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		args []string
+		want string
+	}{
+		{
+			desc: "missing source flag",
+			args: []string{"generate", "--tools-file", toolsFile},
+			want: `required flag(s) "source" not set`,
+		},
+		{
+			desc: "unknown source",
+			args: []string{"generate", "--tools-file", toolsFile, "--source", "missing"},
+			want: `no source named "missing" configured`,
+		},
+		{
+			desc: "unsupported source",
+			args: []string{"generate", "--tools-file", toolsFile, "--source", "my-http"},
+			want: `source kind "http" is not supported`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, _, err := invokeCommand(tc.args)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected error containing %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
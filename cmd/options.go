@@ -16,6 +16,9 @@ package cmd
 
 import (
 	"io"
+
+	"github.com/googleapis/genai-toolbox/internal/auth"
+	"github.com/googleapis/genai-toolbox/internal/util"
 )
 
 // Option is a function that configures a Command.
@@ -28,3 +31,28 @@ func WithStreams(out, err io.Writer) Option {
 		c.errStream = err
 	}
 }
+
+// WithClock overrides the clock of the timestamps the server returns, so
+// that tests of its responses are stable.
+func WithClock(clock util.Clock) Option {
+	return func(c *Command) {
+		c.clock = clock
+	}
+}
+
+// WithIDGenerator overrides the generator of the IDs the server returns, such
+// as request and session IDs, so that tests of its responses are stable.
+func WithIDGenerator(ids util.IDGenerator) Option {
+	return func(c *Command) {
+		c.ids = ids
+	}
+}
+
+// WithClaimsEnricher adds a ClaimsEnricher to the server, enriching the
+// claims verified by auth services, such as with roles looked up in a
+// database, before they're bound to parameters.
+func WithClaimsEnricher(e auth.ClaimsEnricher) Option {
+	return func(c *Command) {
+		c.claimsEnrichers = append(c.claimsEnrichers, e)
+	}
+}
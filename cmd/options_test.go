@@ -18,7 +18,9 @@ import (
 	"errors"
 	"io"
 	"testing"
+	"time"
 
+	"github.com/googleapis/genai-toolbox/internal/util"
 	"github.com/spf13/cobra"
 )
 
@@ -39,6 +41,26 @@ func TestCommandOptions(t *testing.T) {
 			},
 			option: WithStreams(w, w),
 		},
+		{
+			desc: "with clock",
+			isValid: func(c *Command) error {
+				if c.clock == nil || !c.clock().Equal(time.Unix(0, 0)) {
+					return errors.New("clock does not match")
+				}
+				return nil
+			},
+			option: WithClock(util.FixedClock(time.Unix(0, 0))),
+		},
+		{
+			desc: "with ID generator",
+			isValid: func(c *Command) error {
+				if c.ids == nil || c.ids() != util.SeededIDs(1)() {
+					return errors.New("ID generator does not match")
+				}
+				return nil
+			},
+			option: WithIDGenerator(util.SeededIDs(1)),
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -15,26 +15,33 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
 	_ "embed"
+	"errors"
 	"fmt"
 	"io"
 	"maps"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"reflect"
 	"regexp"
 	"runtime"
 	"slices"
 	"strings"
 	"syscall"
 	"time"
+	"unicode/utf8"
 
 	"github.com/fsnotify/fsnotify"
 	yaml "github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
 	"github.com/googleapis/genai-toolbox/internal/auth"
 	"github.com/googleapis/genai-toolbox/internal/log"
 	"github.com/googleapis/genai-toolbox/internal/prebuiltconfigs"
+	"github.com/googleapis/genai-toolbox/internal/quota"
 	"github.com/googleapis/genai-toolbox/internal/server"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/telemetry"
@@ -43,25 +50,62 @@ import (
 
 	// Import tool packages for side effect of registration
 	_ "github.com/googleapis/genai-toolbox/internal/tools/alloydbainl"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/athena/athenasql"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/bigquery/bigquerycreatedataset"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/bigquery/bigquerycreatetable"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/bigquery/bigquerydeletetable"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/bigquery/bigqueryexecutesql"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/bigquery/bigqueryexporttogcs"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/bigquery/bigquerygetdatasetinfo"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/bigquery/bigquerygettableinfo"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/bigquery/bigquerylistdatasetids"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/bigquery/bigquerylisttableids"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/bigquery/bigqueryloadfromgcs"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/bigquery/bigquerysql"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/bigtable"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/bigtablewrite"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/cassandra/cassandracql"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/clickhouse/clickhousesql"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/cloudsql/cloudsqlcreatedatabase"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/cloudsql/cloudsqlgetinstance"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/cloudsql/cloudsqllistinstances"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/couchbase"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/couchbasesearch"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/dataplex/dataplexlookupentry"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/dataplex/dataplexsearchaspecttypes"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/dataplex/dataplexsearchentries"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/db2/db2sql"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/dgraph"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/duckdb/duckdbsql"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/filesystem/fsglob"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/filesystem/fslistdir"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/filesystem/fsreadfile"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/firestore/firestoredeletedocuments"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/firestore/firestoregetdocuments"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/firestore/firestoregetrules"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/firestore/firestorelistcollections"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/firestore/firestorequerycollection"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/firestore/firestorevalidaterules"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/github/githubcreateissue"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/github/githubgetfilecontents"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/github/githublistissues"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/github/githubsearchcode"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/googlechat/chatpostmessage"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/googledrive/googledriveexportdocument"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/googledrive/googledrivesearchfiles"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/googlesheets/googlesheetsappendrows"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/googlesheets/googlesheetsreadrange"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/googlesheets/googlesheetsupdatecells"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/graphql"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/greenplum/greenplumsql"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/grpc"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/hive/hivesql"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/http"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/influxdb/influxdbflux"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/jira/jiracreateissue"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/jira/jirasearchissues"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/kafka/kafkaconsume"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/kafka/kafkapublish"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/looker/lookeradddashboardelement"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/looker/lookergetdashboards"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/looker/lookergetdimensions"
@@ -95,43 +139,92 @@ import (
 	_ "github.com/googleapis/genai-toolbox/internal/tools/neo4j/neo4jschema"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/oceanbase/oceanbaseexecutesql"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/oceanbase/oceanbasesql"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/oracle/oracleexecutesql"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/oracle/oraclesql"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/postgres/postgresexecutesql"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/postgres/postgressql"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/prometheus/prometheusquery"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/prometheus/prometheusqueryrange"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/redis"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/s3/s3getobject"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/s3/s3listobjects"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/s3/s3putobject"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/saphana/saphanasql"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/schemasearch"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/scratchpad/scratchpadexecutesql"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/scratchpad/scratchpadinsertrows"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/servicenow/servicenowcreaterecord"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/servicenow/servicenowqueryrecords"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/shellcommand"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/slack/slackpostmessage"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/snowflake/snowflakeexecutesql"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/snowflake/snowflakesql"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/spanner/spannerexecutesql"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/spanner/spannersql"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/sqlitesql"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/ssh/sshcommand"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/teradata/teradatasql"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/tidb/tidbexecutesql"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/tidb/tidbsql"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/utility/alloydbwaitforoperation"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/utility/crosssourcejoin"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/utility/wait"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/valkey"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/vertica/verticasql"
 
 	"github.com/spf13/cobra"
 
 	_ "github.com/googleapis/genai-toolbox/internal/sources/alloydbpg"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/athena"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/azuresql"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/bigquery"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/bigtable"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/cassandra"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/clickhouse"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/cloudsqlmssql"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/cloudsqlmysql"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/cloudsqlpg"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/couchbase"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/dataplex"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/db2"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/dgraph"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/duckdb"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/filesystem"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/firestore"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/github"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/googlechat"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/googledrive"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/googlesheets"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/greenplum"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/grpc"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/hive"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/http"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/influxdb"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/jira"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/kafka"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/looker"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/mongodb"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/mssql"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/mysql"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/neo4j"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/oceanbase"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/oracle"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/postgres"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/prometheus"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/redis"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/s3"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/saphana"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/scratchpad"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/servicenow"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/slack"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/snowflake"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/spanner"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/sqlite"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/ssh"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/teradata"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/tidb"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/valkey"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/vertica"
 )
 
 var (
@@ -179,9 +272,21 @@ type Command struct {
 	tools_files    []string
 	tools_folder   string
 	prebuiltConfig string
-	inStream       io.Reader
-	outStream      io.Writer
-	errStream      io.Writer
+	// candidateToolsFile is the file with the candidate definitions of tools.
+	candidateToolsFile string
+	// strictConfig fails tools files with unknown fields, rather than
+	// ignoring them with a warning.
+	strictConfig bool
+	inStream     io.Reader
+	outStream    io.Writer
+	errStream    io.Writer
+	// clock and ids are the providers of the timestamps and IDs returned by
+	// the server, if overridden.
+	clock util.Clock
+	ids   util.IDGenerator
+	// claimsEnrichers enrich the claims verified by auth services. See
+	// WithClaimsEnricher.
+	claimsEnrichers []auth.ClaimsEnricher
 }
 
 // NewCommand returns a Command object representing an invocation of the CLI.
@@ -217,6 +322,7 @@ func NewCommand(opts ...Option) *Command {
 	flags := cmd.Flags()
 	flags.StringVarP(&cmd.cfg.Address, "address", "a", "127.0.0.1", "Address of the interface the server will listen on.")
 	flags.IntVarP(&cmd.cfg.Port, "port", "p", 5000, "Port the server will listen on.")
+	flags.StringSliceVar(&cmd.cfg.ListenAddresses, "listen", nil, "Further addresses the server will listen on, besides --address and --port, as 'host:port' or 'unix:/path/to/socket'.")
 
 	flags.StringVar(&cmd.tools_file, "tools_file", "", "File path specifying the tool configuration. Cannot be used with --prebuilt.")
 	// deprecate tools_file
@@ -229,14 +335,38 @@ func NewCommand(opts ...Option) *Command {
 	flags.BoolVar(&cmd.cfg.TelemetryGCP, "telemetry-gcp", false, "Enable exporting directly to Google Cloud Monitoring.")
 	flags.StringVar(&cmd.cfg.TelemetryOTLP, "telemetry-otlp", "", "Enable exporting using OpenTelemetry Protocol (OTLP) to the specified endpoint (e.g. 'http://127.0.0.1:4318')")
 	flags.StringVar(&cmd.cfg.TelemetryServiceName, "telemetry-service-name", "toolbox", "Sets the value of the service.name resource attribute for telemetry data.")
-	flags.StringVar(&cmd.prebuiltConfig, "prebuilt", "", "Use a prebuilt tool configuration by source type. Cannot be used with --tools-file. Allowed: 'alloydb-postgres-admin', alloydb-postgres', 'bigquery', 'cloud-sql-mysql', 'cloud-sql-postgres', 'cloud-sql-mssql', 'dataplex', 'firestore', 'looker', 'mssql', 'mysql', 'oceanbase', 'postgres', 'spanner', 'spanner-postgres'.")
+	flags.StringToStringVar(&cmd.cfg.TelemetryResourceAttributes, "telemetry-resource-attributes", nil, "Further resource attributes of telemetry data, as comma-separated 'key=value' pairs (e.g. 'deployment.environment=prod,service.namespace=agents'). Takes precedence over OTEL_RESOURCE_ATTRIBUTES.")
+	flags.StringVar(&cmd.prebuiltConfig, "prebuilt", "", "Use a prebuilt tool configuration by source type. Cannot be used with --tools-file. Allowed: 'alloydb-postgres-admin', alloydb-postgres', 'bigquery', 'cloud-sql-admin', 'cloud-sql-mysql', 'cloud-sql-postgres', 'cloud-sql-mssql', 'dataplex', 'db2', 'firestore', 'greenplum', 'looker', 'mssql', 'mysql', 'oceanbase', 'postgres', 'spanner', 'spanner-postgres', 'vertica'.")
 	flags.BoolVar(&cmd.cfg.Stdio, "stdio", false, "Listens via MCP STDIO instead of acting as a remote HTTP server.")
+	flags.IntVar(&cmd.cfg.StdioWorkers, "stdio-workers", 4, "Number of MCP tool calls processed at once with --stdio. Further calls wait for one of them to finish.")
 	flags.BoolVar(&cmd.cfg.DisableReload, "disable-reload", false, "Disables dynamic reloading of tools file.")
 	flags.BoolVar(&cmd.cfg.UI, "ui", false, "Launches the Toolbox UI web server.")
+	flags.BoolVar(&cmd.cfg.EnableShellTools, "enable-shell-tools", false, "Allows tools that run commands on the host, such as 'shell-command'.")
+	flags.BoolVar(&cmd.cfg.AllowUnauthenticated, "allow-unauthenticated", false, "Acknowledges that tools without 'authRequired' can be invoked without authentication. Otherwise Toolbox warns about them when it starts.")
+	flags.BoolVar(&cmd.cfg.UnauthenticatedLoopbackOnly, "unauthenticated-loopback-only", false, "Refuses to start, or reload, with tools without 'authRequired' on an --address or --listen address that isn't a loopback address or a unix socket, unless --allow-unauthenticated is set.")
+	flags.StringVar(&cmd.cfg.Locale, "locale", "", "Locale of the tool descriptions served when clients don't request one with an Accept-Language header (e.g. 'ja').")
+	flags.IntVar(&cmd.cfg.ToolsPageSize, "tools-page-size", 0, "Number of tools listed per page by MCP 'tools/list' and the toolset API. Lists all tools at once if 0.")
+	flags.IntVar(&cmd.cfg.ResultTokenBudget, "result-token-budget", 0, "Estimated number of tokens beyond which the results of MCP tool calls are summarized by the LLM of clients that support sampling, with the full result readable as a resource. Never summarizes results if 0.")
+	flags.Var(&cmd.cfg.ResultEnvelope, "result-envelope", "Specify how the HTTP API returns the results of tool invocations: as a JSON-encoded string under 'result' (legacy), or as JSON under 'data' along with the metadata of the execution, which MCP results return as well. Allowed: 'result' or 'data'.")
+	flags.Var(&cmd.cfg.NumberFormat, "number-format", "Specify how tools return decimals and integers JSON clients can't represent exactly, unless a tool sets 'numberFormat'. Allowed: 'string' or 'number'.")
+	flags.BoolVar(&cmd.cfg.RejectUnknownParameters, "reject-unknown-parameters", false, "Rejects tool invocations with parameters the tool doesn't declare, unless the tool sets 'rejectUnknownParameters'.")
+	flags.BoolVar(&cmd.cfg.SQLComment, "sql-comments", false, "Tags the SQL statements of tools with a comment naming the tool, caller and request, unless the tool sets 'sqlComment'.")
+	flags.DurationVar(&cmd.cfg.SlowQueryThreshold, "slow-query-threshold", 0, "Duration beyond which tool invocations are tagged as slow on their trace span and logged with their SQL statement (e.g. '2s'). Invocations are never slow if 0.")
+	flags.StringVar(&cmd.cfg.AdminToken, "admin-token", "", "Enables the admin endpoints (/admin), to switch tools, sources and maintenance mode at runtime, for requests with this token in an 'Authorization: Bearer' header.")
+	flags.StringVar(&cmd.cfg.AdminAddress, "admin-address", "", "Serves the admin endpoints only on this loopback address ('host:port') or unix socket ('unix:/path/to/socket'), instead of with the other endpoints. Requires --admin-token.")
+	flags.StringVar(&cmd.cfg.QuotaStore, "quota-store", "", "Where the usage of quotas is counted: 'memory' (default), or a Redis URL (e.g. 'redis://127.0.0.1:6379/0') to share it between servers.")
+	flags.StringVar(&cmd.candidateToolsFile, "candidate-tools-file", "", "File path with candidate definitions of tools, which serve a share of their invocations. See --candidate-percent and --candidate-callers.")
+	flags.Float64Var(&cmd.cfg.CandidatePercent, "candidate-percent", 0, "Percentage of the invocations of tools with a candidate definition that it serves.")
+	flags.StringSliceVar(&cmd.cfg.CandidateCallers, "candidate-callers", nil, "Callers (emails or subjects verified by auth services) whose invocations are all served by candidate definitions of tools.")
+	flags.StringSliceVar(&cmd.cfg.InvocationHeaders, "invocation-headers", nil, "Request headers passed to tools in their invocation context (e.g. 'X-Tenant-Id').")
+	flags.BoolVar(&cmd.strictConfig, "strict-config", true, "Fails to load tools files with unknown fields. If false, unknown fields are ignored and logged as warnings.")
 
 	// wrap RunE command so that we have access to original Command object
 	cmd.RunE = func(*cobra.Command, []string) error { return run(cmd) }
 
+	baseCmd.AddCommand(newGenerateCommand())
+	baseCmd.AddCommand(newBenchCommand())
+
 	return cmd
 }
 
@@ -246,6 +376,9 @@ type ToolsFile struct {
 	AuthServices server.AuthServiceConfigs `yaml:"authServices"`
 	Tools        server.ToolConfigs        `yaml:"tools"`
 	Toolsets     server.ToolsetConfigs     `yaml:"toolsets"`
+	Views        server.ViewConfigs        `yaml:"views"`
+	Quotas       server.QuotaConfigs       `yaml:"quotas"`
+	Policies     server.PolicyConfigs      `yaml:"policies"`
 }
 
 // parseEnv replaces environment variables ${ENV_NAME} with their values.
@@ -268,17 +401,206 @@ func parseEnv(input string) string {
 	})
 }
 
-// parseToolsFile parses the provided yaml into appropriate configs.
+type lenientConfigKey struct{}
+
+// withLenientConfig returns a context in which the unknown fields of tools
+// files are ignored, and logged as warnings.
+func withLenientConfig(ctx context.Context) context.Context {
+	return context.WithValue(ctx, lenientConfigKey{}, true)
+}
+
+type sharedViewsKey struct{}
+
+// withSharedViews returns a context in which the tools of a tools file can
+// also reference views, defined in the other tools files loaded with it.
+func withSharedViews(ctx context.Context, views server.ViewConfigs) context.Context {
+	return context.WithValue(ctx, sharedViewsKey{}, views)
+}
+
+// utf8BOM is the byte order mark that editors on Windows may start UTF-8
+// files with.
+var utf8BOM = []byte("\xef\xbb\xbf")
+
+// normalizeToolsFile returns raw without a UTF-8 byte order mark and with
+// CRLF line endings replaced by LF, as files saved on Windows may have them.
+// Files that aren't UTF-8 are rejected, rather than parsed as garbled YAML.
+func normalizeToolsFile(raw []byte) ([]byte, error) {
+	if bytes.HasPrefix(raw, []byte{0xff, 0xfe}) || bytes.HasPrefix(raw, []byte{0xfe, 0xff}) {
+		return nil, fmt.Errorf("file is encoded as UTF-16, save it as UTF-8 instead")
+	}
+	raw = bytes.TrimPrefix(raw, utf8BOM)
+	if !utf8.Valid(raw) {
+		i := 0
+		for {
+			r, size := utf8.DecodeRune(raw[i:])
+			if r == utf8.RuneError && size == 1 {
+				break
+			}
+			i += size
+		}
+		line := bytes.Count(raw[:i], []byte("\n")) + 1
+		return nil, fmt.Errorf("line %d isn't valid UTF-8, save the file as UTF-8 instead", line)
+	}
+	return bytes.ReplaceAll(raw, []byte("\r\n"), []byte("\n")), nil
+}
+
+// tabIndentError returns err, the error parsing raw, explaining that YAML
+// can't be indented with tabs if the line it's on is.
+func tabIndentError(raw []byte, err error) error {
+	var yerr yaml.Error
+	if !errors.As(err, &yerr) || yerr.GetToken() == nil {
+		return err
+	}
+	lines := bytes.Split(raw, []byte("\n"))
+	n := yerr.GetToken().Position.Line
+	if n < 1 || n > len(lines) {
+		return err
+	}
+	line := lines[n-1]
+	indent := line[:len(line)-len(bytes.TrimLeft(line, " \t"))]
+	if !bytes.ContainsRune(indent, '\t') {
+		return err
+	}
+	return fmt.Errorf("line %d is indented with a tab, but YAML only allows spaces: replace the tabs at the start of the line with spaces: %w", n, err)
+}
+
+// unknownToolsFileKeys returns ConfigErrors for the top level keys of file
+// that aren't sections of a tools file.
+func unknownToolsFileKeys(file *ast.File) []error {
+	if len(file.Docs) == 0 {
+		return nil
+	}
+	var values []*ast.MappingValueNode
+	switch n := file.Docs[0].Body.(type) {
+	case *ast.MappingNode:
+		values = n.Values
+	case *ast.MappingValueNode:
+		values = []*ast.MappingValueNode{n}
+	}
+	known := make(map[string]bool)
+	for _, f := range reflect.VisibleFields(reflect.TypeFor[ToolsFile]()) {
+		known[f.Tag.Get("yaml")] = true
+	}
+	var errs []error
+	for _, mv := range values {
+		tk := mv.Key.GetToken()
+		if tk == nil || known[tk.Value] {
+			continue
+		}
+		errs = append(errs, &server.ConfigError{
+			Path: []string{tk.Value},
+			Err:  fmt.Errorf("ignoring unknown field %q", tk.Value),
+		})
+	}
+	return errs
+}
+
+// parseToolsFile parses the provided yaml into appropriate configs. The errors
+// of all resources are reported at once, with their line and column. With a
+// context from withLenientConfig, unknown fields are logged as warnings
+// instead.
 func parseToolsFile(ctx context.Context, raw []byte) (ToolsFile, error) {
-	var toolsFile ToolsFile
+	raw, err := normalizeToolsFile(raw)
+	if err != nil {
+		return ToolsFile{}, err
+	}
 	// Replace environment variables if found
 	raw = []byte(parseEnv(string(raw)))
-	// Parse contents
-	err := yaml.UnmarshalContext(ctx, raw, &toolsFile, yaml.Strict())
+	file, err := parser.ParseBytes(raw, 0)
 	if err != nil {
-		return toolsFile, err
+		return ToolsFile{}, tabIndentError(raw, err)
 	}
-	return toolsFile, nil
+	var warnings *server.ConfigWarnings
+	if lenient, _ := ctx.Value(lenientConfigKey{}).(bool); lenient {
+		warnings = &server.ConfigWarnings{}
+		ctx = server.WithConfigWarnings(ctx, warnings)
+	}
+	// Check the top level keys, so that each section can be parsed on its own
+	var keys struct {
+		Sources      any `yaml:"sources"`
+		AuthSources  any `yaml:"authSources"`
+		AuthServices any `yaml:"authServices"`
+		Tools        any `yaml:"tools"`
+		Toolsets     any `yaml:"toolsets"`
+		Views        any `yaml:"views"`
+		Quotas       any `yaml:"quotas"`
+		Policies     any `yaml:"policies"`
+	}
+	opts := []yaml.DecodeOption{yaml.Strict()}
+	if warnings != nil {
+		opts = nil
+		for _, err := range unknownToolsFileKeys(file) {
+			warnings.Add(err)
+		}
+	}
+	if err := yaml.UnmarshalContext(ctx, raw, &keys, opts...); err != nil {
+		return ToolsFile{}, err
+	}
+
+	var errs []error
+	// Parse views first, so that they can be expanded in the tools
+	var views struct {
+		Views server.ViewConfigs `yaml:"views"`
+	}
+	if err := yaml.UnmarshalContext(ctx, raw, &views); err != nil {
+		errs = append(errs, err)
+	}
+	shared, _ := ctx.Value(sharedViewsKey{}).(server.ViewConfigs)
+	available := maps.Clone(shared)
+	if available == nil {
+		available = make(server.ViewConfigs)
+	}
+	maps.Copy(available, views.Views)
+	ctx = server.WithViewConfigs(ctx, available)
+
+	// Parse each section on its own, since decoding stops at the first
+	// section with errors
+	var srcs struct {
+		Sources server.SourceConfigs `yaml:"sources"`
+	}
+	var authSources struct {
+		AuthSources server.AuthServiceConfigs `yaml:"authSources"`
+	}
+	var authServices struct {
+		AuthServices server.AuthServiceConfigs `yaml:"authServices"`
+	}
+	var toolCfgs struct {
+		Tools server.ToolConfigs `yaml:"tools"`
+	}
+	var toolsetCfgs struct {
+		Toolsets server.ToolsetConfigs `yaml:"toolsets"`
+	}
+	var quotaCfgs struct {
+		Quotas server.QuotaConfigs `yaml:"quotas"`
+	}
+	var policyCfgs struct {
+		Policies server.PolicyConfigs `yaml:"policies"`
+	}
+	for _, section := range []any{&srcs, &authSources, &authServices, &toolCfgs, &toolsetCfgs, &quotaCfgs, &policyCfgs} {
+		if err := yaml.UnmarshalContext(ctx, raw, section); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return ToolsFile{}, server.LocateConfigErrors(file, errors.Join(errs...))
+	}
+	if warnings != nil {
+		if logger, err := util.LoggerFromContext(ctx); err == nil {
+			for _, w := range warnings.Locate(file) {
+				logger.WarnContext(ctx, w.Error())
+			}
+		}
+	}
+	return ToolsFile{
+		Sources:      srcs.Sources,
+		AuthSources:  authSources.AuthSources,
+		AuthServices: authServices.AuthServices,
+		Tools:        toolCfgs.Tools,
+		Toolsets:     toolsetCfgs.Toolsets,
+		Views:        views.Views,
+		Quotas:       quotaCfgs.Quotas,
+		Policies:     policyCfgs.Policies,
+	}, nil
 }
 
 // mergeToolsFiles merges multiple ToolsFile structs into one.
@@ -290,6 +612,9 @@ func mergeToolsFiles(files ...ToolsFile) (ToolsFile, error) {
 		AuthServices: make(server.AuthServiceConfigs),
 		Tools:        make(server.ToolConfigs),
 		Toolsets:     make(server.ToolsetConfigs),
+		Views:        make(server.ViewConfigs),
+		Quotas:       make(server.QuotaConfigs),
+		Policies:     make(server.PolicyConfigs),
 	}
 
 	var conflicts []string
@@ -339,27 +664,84 @@ func mergeToolsFiles(files ...ToolsFile) (ToolsFile, error) {
 				merged.Toolsets[name] = toolset
 			}
 		}
+
+		// Check for conflicts and merge views
+		for name, v := range file.Views {
+			if _, exists := merged.Views[name]; exists {
+				conflicts = append(conflicts, fmt.Sprintf("view '%s' (file #%d)", name, fileIndex+1))
+			} else {
+				merged.Views[name] = v
+			}
+		}
+
+		// Check for conflicts and merge quotas
+		for name, q := range file.Quotas {
+			if _, exists := merged.Quotas[name]; exists {
+				conflicts = append(conflicts, fmt.Sprintf("quota '%s' (file #%d)", name, fileIndex+1))
+			} else {
+				merged.Quotas[name] = q
+			}
+		}
+
+		// Check for conflicts and merge policies
+		for name, p := range file.Policies {
+			if _, exists := merged.Policies[name]; exists {
+				conflicts = append(conflicts, fmt.Sprintf("policy '%s' (file #%d)", name, fileIndex+1))
+			} else {
+				merged.Policies[name] = p
+			}
+		}
 	}
 
 	// If conflicts were detected, return an error
 	if len(conflicts) > 0 {
-		return ToolsFile{}, fmt.Errorf("resource conflicts detected:\n  - %s\n\nPlease ensure each source, authService, tool, and toolset has a unique name across all files", strings.Join(conflicts, "\n  - "))
+		return ToolsFile{}, fmt.Errorf("resource conflicts detected:\n  - %s\n\nPlease ensure each source, authService, tool, toolset, view, quota, and policy has a unique name across all files", strings.Join(conflicts, "\n  - "))
 	}
 
 	return merged, nil
 }
 
-// loadAndMergeToolsFiles loads multiple YAML files and merges them
+// parseViews returns the views of a tools file, or none if they can't be
+// parsed, in which case parseToolsFile reports the errors.
+func parseViews(ctx context.Context, raw []byte) server.ViewConfigs {
+	raw, err := normalizeToolsFile(raw)
+	if err != nil {
+		return nil
+	}
+	var views struct {
+		Views server.ViewConfigs `yaml:"views"`
+	}
+	if err := yaml.UnmarshalContext(ctx, []byte(parseEnv(string(raw))), &views); err != nil {
+		return nil
+	}
+	return views.Views
+}
+
+// loadAndMergeToolsFiles loads multiple YAML files and merges them. The views
+// of every file are parsed first, so that tools can reference the views of
+// other files.
 func loadAndMergeToolsFiles(ctx context.Context, filePaths []string) (ToolsFile, error) {
 	var toolsFiles []ToolsFile
 
-	for _, filePath := range filePaths {
+	bufs := make([][]byte, len(filePaths))
+	shared := make(server.ViewConfigs)
+	for i, filePath := range filePaths {
 		buf, err := os.ReadFile(filePath)
 		if err != nil {
 			return ToolsFile{}, fmt.Errorf("unable to read tool file at %q: %w", filePath, err)
 		}
+		bufs[i] = buf
+		// conflicting views are reported by mergeToolsFiles
+		for name, v := range parseViews(ctx, buf) {
+			if _, exists := shared[name]; !exists {
+				shared[name] = v
+			}
+		}
+	}
+	ctx = withSharedViews(ctx, shared)
 
-		toolsFile, err := parseToolsFile(ctx, buf)
+	for i, filePath := range filePaths {
+		toolsFile, err := parseToolsFile(ctx, bufs[i])
 		if err != nil {
 			return ToolsFile{}, fmt.Errorf("unable to parse tool file at %q: %w", filePath, err)
 		}
@@ -411,13 +793,30 @@ func loadAndMergeToolsFolder(ctx context.Context, folderPath string) (ToolsFile,
 	return loadAndMergeToolsFiles(ctx, allFiles)
 }
 
-func handleDynamicReload(ctx context.Context, toolsFile ToolsFile, s *server.Server) error {
+// loadCandidateToolsFile returns the tools of the candidate tools file at
+// path, which can only define tools, and views they use.
+func loadCandidateToolsFile(ctx context.Context, path string) (server.ToolConfigs, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read candidate tools file at %q: %w", path, err)
+	}
+	toolsFile, err := parseToolsFile(ctx, buf)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse candidate tools file at %q: %w", path, err)
+	}
+	if len(toolsFile.Sources) > 0 || len(toolsFile.AuthSources) > 0 || len(toolsFile.AuthServices) > 0 || len(toolsFile.Toolsets) > 0 || len(toolsFile.Quotas) > 0 || len(toolsFile.Policies) > 0 {
+		return nil, fmt.Errorf("candidate tools file at %q can only define tools and views", path)
+	}
+	return toolsFile.Tools, nil
+}
+
+func handleDynamicReload(ctx context.Context, toolsFile ToolsFile, cfg server.ServerConfig, s *server.Server) error {
 	logger, err := util.LoggerFromContext(ctx)
 	if err != nil {
 		panic(err)
 	}
 
-	sourcesMap, authServicesMap, toolsMap, toolsetsMap, err := validateReloadEdits(ctx, toolsFile)
+	sourcesMap, authServicesMap, toolsMap, toolsetsMap, err := validateReloadEdits(ctx, toolsFile, cfg)
 	if err != nil {
 		errMsg := fmt.Errorf("unable to validate reloaded edits: %w", err)
 		logger.WarnContext(ctx, errMsg.Error())
@@ -429,9 +828,10 @@ func handleDynamicReload(ctx context.Context, toolsFile ToolsFile, s *server.Ser
 	return nil
 }
 
-// validateReloadEdits checks that the reloaded tools file configs can initialized without failing
+// validateReloadEdits checks that the reloaded tools file configs can initialized without failing.
+// The other settings of the server are those of cfg.
 func validateReloadEdits(
-	ctx context.Context, toolsFile ToolsFile,
+	ctx context.Context, toolsFile ToolsFile, cfg server.ServerConfig,
 ) (map[string]sources.Source, map[string]auth.AuthService, map[string]tools.Tool, map[string]tools.Toolset, error,
 ) {
 	logger, err := util.LoggerFromContext(ctx)
@@ -449,13 +849,14 @@ func validateReloadEdits(
 	ctx, span := instrumentation.Tracer.Start(ctx, "toolbox/server/reload")
 	defer span.End()
 
-	reloadedConfig := server.ServerConfig{
-		Version:            versionString,
-		SourceConfigs:      toolsFile.Sources,
-		AuthServiceConfigs: toolsFile.AuthServices,
-		ToolConfigs:        toolsFile.Tools,
-		ToolsetConfigs:     toolsFile.Toolsets,
-	}
+	reloadedConfig := cfg
+	reloadedConfig.Version = versionString
+	reloadedConfig.SourceConfigs = toolsFile.Sources
+	reloadedConfig.AuthServiceConfigs = toolsFile.AuthServices
+	reloadedConfig.ToolConfigs = toolsFile.Tools
+	reloadedConfig.ToolsetConfigs = toolsFile.Toolsets
+	reloadedConfig.QuotaConfigs = toolsFile.Quotas
+	reloadedConfig.PolicyConfigs = toolsFile.Policies
 
 	sourcesMap, authServicesMap, toolsMap, toolsetsMap, err := server.InitializeConfigs(ctx, reloadedConfig)
 	if err != nil {
@@ -468,7 +869,7 @@ func validateReloadEdits(
 }
 
 // watchChanges checks for changes in the provided yaml tools file(s) or folder.
-func watchChanges(ctx context.Context, watchDirs map[string]bool, watchedFiles map[string]bool, s *server.Server) {
+func watchChanges(ctx context.Context, watchDirs map[string]bool, watchedFiles map[string]bool, cfg server.ServerConfig, s *server.Server) {
 	logger, err := util.LoggerFromContext(ctx)
 	if err != nil {
 		panic(err)
@@ -573,7 +974,7 @@ func watchChanges(ctx context.Context, watchDirs map[string]bool, watchedFiles m
 				}
 			}
 
-			err = handleDynamicReload(ctx, reloadedToolsFile, s)
+			err = handleDynamicReload(ctx, reloadedToolsFile, cfg, s)
 			if err != nil {
 				errMsg := fmt.Errorf("unable to parse reloaded tools file at %q: %w", reloadedToolsFile, err)
 				logger.WarnContext(ctx, errMsg.Error())
@@ -671,9 +1072,12 @@ func run(cmd *Command) error {
 	}
 
 	ctx = util.WithLogger(ctx, cmd.logger)
+	if !cmd.strictConfig {
+		ctx = withLenientConfig(ctx)
+	}
 
 	// Set up OpenTelemetry
-	otelShutdown, err := telemetry.SetupOTel(ctx, cmd.cfg.Version, cmd.cfg.TelemetryOTLP, cmd.cfg.TelemetryGCP, cmd.cfg.TelemetryServiceName)
+	otelShutdown, err := telemetry.SetupOTel(ctx, cmd.cfg.Version, cmd.cfg.TelemetryOTLP, cmd.cfg.TelemetryGCP, cmd.cfg.TelemetryServiceName, cmd.cfg.TelemetryResourceAttributes)
 	if err != nil {
 		errMsg := fmt.Errorf("error setting up OpenTelemetry: %w", err)
 		cmd.logger.ErrorContext(ctx, errMsg.Error())
@@ -768,12 +1172,22 @@ func run(cmd *Command) error {
 	}
 
 	cmd.cfg.SourceConfigs, cmd.cfg.AuthServiceConfigs, cmd.cfg.ToolConfigs, cmd.cfg.ToolsetConfigs = toolsFile.Sources, toolsFile.AuthServices, toolsFile.Tools, toolsFile.Toolsets
+	cmd.cfg.QuotaConfigs = toolsFile.Quotas
+	cmd.cfg.PolicyConfigs = toolsFile.Policies
 	authSourceConfigs := toolsFile.AuthSources
 	if authSourceConfigs != nil {
 		cmd.logger.WarnContext(ctx, "`authSources` is deprecated, use `authServices` instead")
 		cmd.cfg.AuthServiceConfigs = authSourceConfigs
 	}
 
+	if cmd.candidateToolsFile != "" {
+		cmd.cfg.CandidateToolConfigs, err = loadCandidateToolsFile(ctx, cmd.candidateToolsFile)
+		if err != nil {
+			cmd.logger.ErrorContext(ctx, err.Error())
+			return err
+		}
+	}
+
 	instrumentation, err := telemetry.CreateTelemetryInstrumentation(versionString)
 	if err != nil {
 		errMsg := fmt.Errorf("unable to create telemetry instrumentation: %w", err)
@@ -783,8 +1197,27 @@ func run(cmd *Command) error {
 
 	ctx = util.WithInstrumentation(ctx, instrumentation)
 
+	// the usage of quotas is kept across reloads
+	quotaStore, err := quota.NewStore(cmd.cfg.QuotaStore)
+	if err != nil {
+		errMsg := fmt.Errorf("unable to create quota store: %w", err)
+		cmd.logger.ErrorContext(ctx, errMsg.Error())
+		return errMsg
+	}
+	ctx = quota.WithLimiter(ctx, quota.NewLimiter(quotaStore))
+
 	// start server
-	s, err := server.NewServer(ctx, cmd.cfg)
+	var serverOpts []server.Option
+	if cmd.clock != nil {
+		serverOpts = append(serverOpts, server.WithClock(cmd.clock))
+	}
+	if cmd.ids != nil {
+		serverOpts = append(serverOpts, server.WithIDGenerator(cmd.ids))
+	}
+	for _, e := range cmd.claimsEnrichers {
+		serverOpts = append(serverOpts, server.WithClaimsEnricher(e))
+	}
+	s, err := server.NewServer(ctx, cmd.cfg, serverOpts...)
 	if err != nil {
 		errMsg := fmt.Errorf("toolbox failed to initialize: %w", err)
 		cmd.logger.ErrorContext(ctx, errMsg.Error())
@@ -826,7 +1259,7 @@ func run(cmd *Command) error {
 
 	if !cmd.cfg.DisableReload {
 		// start watching the file(s) or folder for changes to trigger dynamic reloading
-		go watchChanges(ctx, watchDirs, watchedFiles, s)
+		go watchChanges(ctx, watchDirs, watchedFiles, cmd.cfg, s)
 	}
 
 	// wait for either the server to error out or the command's context to be canceled
@@ -43,6 +43,7 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/tools/http"
 	"github.com/googleapis/genai-toolbox/internal/tools/postgres/postgressql"
 	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/googleapis/genai-toolbox/internal/util/normalize"
 	"github.com/spf13/cobra"
 )
 
@@ -60,6 +61,9 @@ func withDefaults(c server.ServerConfig) server.ServerConfig {
 	if c.TelemetryServiceName == "" {
 		c.TelemetryServiceName = "toolbox"
 	}
+	if c.StdioWorkers == 0 {
+		c.StdioWorkers = 4
+	}
 	return c
 }
 
@@ -177,6 +181,16 @@ func TestServerConfigFlags(t *testing.T) {
 				TelemetryServiceName: "toolbox-custom",
 			}),
 		},
+		{
+			desc: "telemetry resource attributes",
+			args: []string{"--telemetry-resource-attributes", "deployment.environment=prod,service.namespace=agents"},
+			want: withDefaults(server.ServerConfig{
+				TelemetryResourceAttributes: map[string]string{
+					"deployment.environment": "prod",
+					"service.namespace":      "agents",
+				},
+			}),
+		},
 		{
 			desc: "stdio",
 			args: []string{"--stdio"},
@@ -191,6 +205,129 @@ func TestServerConfigFlags(t *testing.T) {
 				DisableReload: true,
 			}),
 		},
+		{
+			desc: "enable shell tools",
+			args: []string{"--enable-shell-tools"},
+			want: withDefaults(server.ServerConfig{
+				EnableShellTools: true,
+			}),
+		},
+		{
+			desc: "unauthenticated tools",
+			args: []string{"--allow-unauthenticated", "--unauthenticated-loopback-only"},
+			want: withDefaults(server.ServerConfig{
+				AllowUnauthenticated:        true,
+				UnauthenticatedLoopbackOnly: true,
+			}),
+		},
+		{
+			desc: "locale",
+			args: []string{"--locale", "ja"},
+			want: withDefaults(server.ServerConfig{
+				Locale: "ja",
+			}),
+		},
+		{
+			desc: "stdio workers",
+			args: []string{"--stdio", "--stdio-workers", "8"},
+			want: withDefaults(server.ServerConfig{
+				Stdio:        true,
+				StdioWorkers: 8,
+			}),
+		},
+		{
+			desc: "tools page size",
+			args: []string{"--tools-page-size", "50"},
+			want: withDefaults(server.ServerConfig{
+				ToolsPageSize: 50,
+			}),
+		},
+		{
+			desc: "result token budget",
+			args: []string{"--result-token-budget", "4000"},
+			want: withDefaults(server.ServerConfig{
+				ResultTokenBudget: 4000,
+			}),
+		},
+		{
+			desc: "number format",
+			args: []string{"--number-format", "number"},
+			want: withDefaults(server.ServerConfig{
+				NumberFormat: normalize.NumbersAsNumbers,
+			}),
+		},
+		{
+			desc: "result envelope",
+			args: []string{"--result-envelope", "data"},
+			want: withDefaults(server.ServerConfig{
+				ResultEnvelope: server.ResultAsData,
+			}),
+		},
+		{
+			desc: "reject unknown parameters",
+			args: []string{"--reject-unknown-parameters"},
+			want: withDefaults(server.ServerConfig{
+				RejectUnknownParameters: true,
+			}),
+		},
+		{
+			desc: "sql comments",
+			args: []string{"--sql-comments"},
+			want: withDefaults(server.ServerConfig{
+				SQLComment: true,
+			}),
+		},
+		{
+			desc: "slow query threshold",
+			args: []string{"--slow-query-threshold", "1500ms"},
+			want: withDefaults(server.ServerConfig{
+				SlowQueryThreshold: 1500 * time.Millisecond,
+			}),
+		},
+		{
+			desc: "invocation headers",
+			args: []string{"--invocation-headers", "X-Tenant-Id,X-Region"},
+			want: withDefaults(server.ServerConfig{
+				InvocationHeaders: []string{"X-Tenant-Id", "X-Region"},
+			}),
+		},
+		{
+			desc: "candidate rollout",
+			args: []string{"--candidate-percent", "12.5", "--candidate-callers", "me@example.com"},
+			want: withDefaults(server.ServerConfig{
+				CandidatePercent: 12.5,
+				CandidateCallers: []string{"me@example.com"},
+			}),
+		},
+		{
+			desc: "admin token",
+			args: []string{"--admin-token", "secret"},
+			want: withDefaults(server.ServerConfig{
+				AdminToken: "secret",
+			}),
+		},
+		{
+			desc: "listen addresses",
+			args: []string{"--listen", "[::1]:5000", "--listen", "unix:/run/toolbox.sock"},
+			want: withDefaults(server.ServerConfig{
+				ListenAddresses: []string{"[::1]:5000", "unix:/run/toolbox.sock"},
+			}),
+		},
+		{
+			desc: "admin address",
+			args: []string{"--admin-token", "secret", "--admin-address", "unix:/run/toolbox-admin.sock"},
+			want: withDefaults(server.ServerConfig{
+				AdminToken:   "secret",
+				AdminAddress: "unix:/run/toolbox-admin.sock",
+			}),
+		},
+		{
+			desc: "quota store",
+			args: []string{"--quota-store", "redis://127.0.0.1:6379/0"},
+			want: withDefaults(server.ServerConfig{
+				QuotaStore: "redis://127.0.0.1:6379/0",
+			}),
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -461,6 +598,67 @@ func TestParseToolFile(t *testing.T) {
 				},
 			},
 		},
+		{
+			description: "views",
+			in: `
+			sources:
+				my-pg-instance:
+					kind: cloud-sql-postgres
+					project: my-project
+					region: my-region
+					instance: my-instance
+					database: my_db
+					user: my_user
+					password: my_pass
+			views:
+				active_users:
+					source: my-pg-instance
+					statement: SELECT * FROM users WHERE deleted_at IS NULL
+				users_in:
+					source: my-pg-instance
+					statement: SELECT * FROM {{view "active_users"}} u WHERE u.country = {{.country}}
+					parameters:
+						- country
+			tools:
+				example_tool:
+					kind: postgres-sql
+					source: my-pg-instance
+					description: some description
+					statement: SELECT count(*) FROM {{view "users_in" "$1"}} AS u;
+					parameters:
+						- name: country
+							type: string
+							description: some description
+			`,
+			wantToolsFile: ToolsFile{
+				Sources: server.SourceConfigs{
+					"my-pg-instance": cloudsqlpgsrc.Config{
+						Name:     "my-pg-instance",
+						Kind:     cloudsqlpgsrc.SourceKind,
+						Project:  "my-project",
+						Region:   "my-region",
+						Instance: "my-instance",
+						IPType:   "public",
+						Database: "my_db",
+						User:     "my_user",
+						Password: "my_pass",
+					},
+				},
+				Tools: server.ToolConfigs{
+					"example_tool": postgressql.Config{
+						Name:        "example_tool",
+						Kind:        "postgres-sql",
+						Source:      "my-pg-instance",
+						Description: "some description",
+						Statement:   "SELECT count(*) FROM (SELECT * FROM (SELECT * FROM users WHERE deleted_at IS NULL) u WHERE u.country = $1) AS u;",
+						Parameters: []tools.Parameter{
+							tools.NewStringParameter("country", "some description"),
+						},
+						AuthRequired: []string{},
+					},
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.description, func(t *testing.T) {
@@ -1132,7 +1330,7 @@ func TestSingleEdit(t *testing.T) {
 	watchedFiles := map[string]bool{cleanFileToWatch: true}
 	watchDirs := map[string]bool{watchDir: true}
 
-	go watchChanges(ctx, watchDirs, watchedFiles, mockServer)
+	go watchChanges(ctx, watchDirs, watchedFiles, server.ServerConfig{}, mockServer)
 
 	// escape backslash so regex doesn't fail on windows filepaths
 	regexEscapedPathFile := strings.ReplaceAll(cleanFileToWatch, `\`, `\\\\*\\`)
@@ -1164,17 +1362,21 @@ func TestPrebuiltTools(t *testing.T) {
 	alloydb_admin_config, _ := prebuiltconfigs.Get("alloydb-postgres-admin")
 	alloydb_config, _ := prebuiltconfigs.Get("alloydb-postgres")
 	bigquery_config, _ := prebuiltconfigs.Get("bigquery")
+	cloudsqladmin_config, _ := prebuiltconfigs.Get("cloud-sql-admin")
 	cloudsqlpg_config, _ := prebuiltconfigs.Get("cloud-sql-postgres")
 	cloudsqlmysql_config, _ := prebuiltconfigs.Get("cloud-sql-mysql")
 	cloudsqlmssql_config, _ := prebuiltconfigs.Get("cloud-sql-mssql")
 	dataplex_config, _ := prebuiltconfigs.Get("dataplex")
+	db2_config, _ := prebuiltconfigs.Get("db2")
 	firestoreconfig, _ := prebuiltconfigs.Get("firestore")
+	greenplum_config, _ := prebuiltconfigs.Get("greenplum")
 	mysql_config, _ := prebuiltconfigs.Get("mysql")
 	mssql_config, _ := prebuiltconfigs.Get("mssql")
 	looker_config, _ := prebuiltconfigs.Get("looker")
 	postgresconfig, _ := prebuiltconfigs.Get("postgres")
 	spanner_config, _ := prebuiltconfigs.Get("spanner")
 	spannerpg_config, _ := prebuiltconfigs.Get("spanner-postgres")
+	vertica_config, _ := prebuiltconfigs.Get("vertica")
 	ctx, err := testutils.ContextWithNewLogger()
 	if err != nil {
 		t.Fatalf("unexpected error: %s", err)
@@ -1190,7 +1392,7 @@ func TestPrebuiltTools(t *testing.T) {
 			wantToolset: server.ToolsetConfigs{
 				"alloydb-postgres-admin-tools": tools.ToolsetConfig{
 					Name:      "alloydb-postgres-admin-tools",
-					ToolNames: []string{"alloydb-create-cluster", "alloydb-operations-get", "alloydb-create-instance", "alloydb-list-clusters", "alloydb-list-instances", "alloydb-list-users", "alloydb-create-user"},
+					ToolNames: []string{"alloydb-create-cluster", "alloydb-operations-get", "alloydb-create-instance", "alloydb-list-clusters", "alloydb-list-instances", "alloydb-list-users", "alloydb-create-user", "alloydb-create-backup", "alloydb-list-backups", "alloydb-restore-cluster"},
 				},
 			},
 		},
@@ -1214,6 +1416,16 @@ func TestPrebuiltTools(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "cloudsqladmin prebuilt tools",
+			in:   cloudsqladmin_config,
+			wantToolset: server.ToolsetConfigs{
+				"cloud-sql-admin-tools": tools.ToolsetConfig{
+					Name:      "cloud-sql-admin-tools",
+					ToolNames: []string{"cloud-sql-list-instances", "cloud-sql-get-instance", "cloud-sql-create-database"},
+				},
+			},
+		},
 		{
 			name: "cloudsqlpg prebuilt tools",
 			in:   cloudsqlpg_config,
@@ -1254,6 +1466,16 @@ func TestPrebuiltTools(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "db2 prebuilt tools",
+			in:   db2_config,
+			wantToolset: server.ToolsetConfigs{
+				"db2-database-tools": tools.ToolsetConfig{
+					Name:      "db2-database-tools",
+					ToolNames: []string{"list_tables"},
+				},
+			},
+		},
 		{
 			name: "firestore prebuilt tools",
 			in:   firestoreconfig,
@@ -1284,6 +1506,16 @@ func TestPrebuiltTools(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "greenplum prebuilt tools",
+			in:   greenplum_config,
+			wantToolset: server.ToolsetConfigs{
+				"greenplum-database-tools": tools.ToolsetConfig{
+					Name:      "greenplum-database-tools",
+					ToolNames: []string{"list_tables"},
+				},
+			},
+		},
 		{
 			name: "looker prebuilt tools",
 			in:   looker_config,
@@ -1324,6 +1556,16 @@ func TestPrebuiltTools(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "vertica prebuilt tools",
+			in:   vertica_config,
+			wantToolset: server.ToolsetConfigs{
+				"vertica-database-tools": tools.ToolsetConfig{
+					Name:      "vertica-database-tools",
+					ToolNames: []string{"list_tables"},
+				},
+			},
+		},
 	}
 
 	for _, tc := range tcs {
@@ -1339,6 +1581,271 @@ func TestPrebuiltTools(t *testing.T) {
 	}
 }
 
+func TestParseToolsFileErrors(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		description string
+		in          string
+		want        string
+	}{
+		{
+			description: "errors in every section are reported at once",
+			in: `
+			sources:
+				my-pg-instance:
+					kind: cloud-sql-postgres
+					project: my-project
+					region: my-region
+					instance: my-instance
+					database: my_db
+					user: my_user
+					password: my_pass
+					foo: bar
+			authServices:
+				my-google-service:
+					kind: unknown
+			tools:
+				example_tool:
+					kind: postgres-sql
+					source: my-pg-instance
+					statement: SELECT 1;
+			`,
+			want: "3 errors:\n" +
+				"  - line 11, column 10: unable to parse source \"my-pg-instance\" as \"cloud-sql-postgres\": unknown field \"foo\"\n" +
+				"  - line 14, column 10: \"unknown\" is not a valid kind of auth source\n" +
+				"  - line 16, column 8: unable to parse tool \"example_tool\" as kind \"postgres-sql\": missing required field \"Description\"",
+		},
+		{
+			description: "unknown top level key",
+			in: `
+			tool:
+				example_tool:
+					kind: postgres-sql
+			`,
+			want: "[2:6] unknown field \"tool\"",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.description, func(t *testing.T) {
+			_, err := parseToolsFile(ctx, testutils.FormatYaml(tc.in))
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if !strings.HasPrefix(err.Error(), tc.want) {
+				t.Fatalf("incorrect error: got %q, want prefix %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseToolsFileEncoding(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	in := "\xef\xbb\xbfsources:\r\n" +
+		"  my-pg-instance:\r\n" +
+		"    kind: postgres\r\n" +
+		"    host: localhost\r\n" +
+		"    port: \"5432\"\r\n" +
+		"    database: my_db\r\n" +
+		"    user: my_user\r\n" +
+		"    password: my_pass\r\n" +
+		"tools:\r\n" +
+		"  example_tool:\r\n" +
+		"    kind: postgres-sql\r\n" +
+		"    source: my-pg-instance\r\n" +
+		"    description: some description\r\n" +
+		"    statement: |\r\n" +
+		"      SELECT 1\r\n" +
+		"      FROM t;\r\n"
+	toolsFile, err := parseToolsFile(ctx, []byte(in))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, ok := toolsFile.Sources["my-pg-instance"]; !ok {
+		t.Fatalf("source not parsed: %v", toolsFile.Sources)
+	}
+	cfg, ok := toolsFile.Tools["example_tool"].(postgressql.Config)
+	if !ok {
+		t.Fatalf("tool not parsed: %v", toolsFile.Tools)
+	}
+	if want := "SELECT 1\nFROM t;\n"; cfg.Statement != want {
+		t.Fatalf("incorrect statement: got %q, want %q", cfg.Statement, want)
+	}
+}
+
+func TestParseToolsFileEncodingErrors(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		description string
+		in          string
+		want        string
+	}{
+		{
+			description: "indented with tabs",
+			in:          "sources:\n\tmy-pg-instance:\n\t\tkind: postgres\n",
+			want:        "line 2 is indented with a tab, but YAML only allows spaces: replace the tabs at the start of the line with spaces: ",
+		},
+		{
+			description: "statement indented with tabs",
+			in:          "tools:\n  example_tool:\n    statement: |\n      SELECT 1\n\tFROM t;\n",
+			want:        "line 5 is indented with a tab, but YAML only allows spaces: ",
+		},
+		{
+			description: "UTF-16",
+			in:          "\xff\xfes\x00o\x00u\x00r\x00c\x00e\x00s\x00:\x00",
+			want:        "file is encoded as UTF-16, save it as UTF-8 instead",
+		},
+		{
+			description: "invalid UTF-8",
+			in:          "tools:\n  example_tool:\n    description: caf\xe9\n",
+			want:        "line 3 isn't valid UTF-8, save the file as UTF-8 instead",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.description, func(t *testing.T) {
+			_, err := parseToolsFile(ctx, []byte(tc.in))
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if !strings.HasPrefix(err.Error(), tc.want) {
+				t.Fatalf("incorrect error: got %q, want prefix %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseToolsFileLenient(t *testing.T) {
+	var out bytes.Buffer
+	logger, err := log.NewStdLogger(&out, &out, "warn")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	ctx := withLenientConfig(util.WithLogger(context.Background(), logger))
+	in := `
+	sources:
+		my-pg-instance:
+			kind: postgres
+			host: localhost
+			port: "5432"
+			database: my_db
+			user: my_user
+			password: my_pass
+			foo: bar
+	tool:
+		example_tool:
+			kind: postgres-sql
+	tools:
+		example_tool:
+			kind: postgres-sql
+			source: my-pg-instance
+			description: some description
+			statment: SELECT 1;
+			statement: SELECT $1;
+			parameters:
+				- name: id
+				  type: integer
+				  description: an id
+				  descripton: a typo
+	`
+	toolsFile, err := parseToolsFile(ctx, testutils.FormatYaml(in))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, ok := toolsFile.Sources["my-pg-instance"]; !ok {
+		t.Fatalf("source not parsed: %v", toolsFile.Sources)
+	}
+	if _, ok := toolsFile.Tools["example_tool"]; !ok {
+		t.Fatalf("tool not parsed: %v", toolsFile.Tools)
+	}
+	for _, want := range []string{
+		`line 10, column 6: ignoring unknown field \"foo\" of \"my-pg-instance\"`,
+		`line 11, column 2: ignoring unknown field \"tool\"`,
+		`line 19, column 6: ignoring unknown field \"statment\" of \"example_tool\"`,
+		`line 25, column 10: ignoring unknown field \"descripton\" of \"example_tool\"`,
+	} {
+		i := strings.Index(out.String(), want)
+		if i < 0 {
+			t.Fatalf("expected warning %q, got logs:\n%s", want, out.String())
+		}
+		// warnings are logged by position
+		out.Next(i + len(want))
+	}
+
+	// other errors still fail the file
+	in = `
+	tools:
+		example_tool:
+			kind: postgres-sql
+			source: my-pg-instance
+			statment: SELECT 1;
+	`
+	if _, err := parseToolsFile(ctx, testutils.FormatYaml(in)); err == nil {
+		t.Fatalf("expected an error")
+	}
+}
+
+func TestLoadAndMergeToolsFilesViews(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	dir := t.TempDir()
+	views := `
+	sources:
+		my-pg-instance:
+			kind: postgres
+			host: localhost
+			port: "5432"
+			database: my_db
+			user: my_user
+			password: my_pass
+	views:
+		active_users:
+			source: my-pg-instance
+			statement: SELECT * FROM users WHERE deleted_at IS NULL
+	`
+	tools := `
+	tools:
+		example_tool:
+			kind: postgres-sql
+			source: my-pg-instance
+			description: some description
+			statement: SELECT count(*) FROM {{view "active_users"}} AS u;
+	`
+	for name, content := range map[string]string{"views.yaml": views, "tools.yaml": tools, "more_views.yaml": views} {
+		if err := os.WriteFile(filepath.Join(dir, name), testutils.FormatYaml(content), 0o644); err != nil {
+			t.Fatalf("unable to write tools file: %s", err)
+		}
+	}
+
+	// the tools of a file can reference the views of another
+	toolsFile, err := loadAndMergeToolsFiles(ctx, []string{filepath.Join(dir, "tools.yaml"), filepath.Join(dir, "views.yaml")})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := "SELECT count(*) FROM (SELECT * FROM users WHERE deleted_at IS NULL) AS u;"
+	if got := toolsFile.Tools["example_tool"].(postgressql.Config).Statement; got != want {
+		t.Fatalf("unexpected statement: got %q, want %q", got, want)
+	}
+	if _, ok := toolsFile.Views["active_users"]; !ok {
+		t.Fatalf("views not merged: %v", toolsFile.Views)
+	}
+
+	// views must have a unique name across files
+	_, err = loadAndMergeToolsFiles(ctx, []string{filepath.Join(dir, "views.yaml"), filepath.Join(dir, "more_views.yaml")})
+	if err == nil || !strings.Contains(err.Error(), "view 'active_users' (file #2)") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
 func TestUpdateLogLevel(t *testing.T) {
 	tcs := []struct {
 		desc     string
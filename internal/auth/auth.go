@@ -31,3 +31,23 @@ type AuthService interface {
 	GetName() string
 	GetClaimsFromHeader(context.Context, http.Header) (map[string]any, error)
 }
+
+// ClaimsEnricher enriches the claims verified by an auth service, such as
+// with the roles of the user looked up in a database or a directory, so that
+// parameters can be bound to claims the token doesn't have. It returns the
+// claims to use, which may be claims itself once changed, or an error failing
+// the invocation.
+//
+// Claims are enriched on every invocation, after they are verified and before
+// they are bound to parameters and used to authorize the invocation.
+type ClaimsEnricher interface {
+	EnrichClaims(ctx context.Context, authService string, claims map[string]any) (map[string]any, error)
+}
+
+// ClaimsEnricherFunc is a function used as a ClaimsEnricher.
+type ClaimsEnricherFunc func(ctx context.Context, authService string, claims map[string]any) (map[string]any, error)
+
+// EnrichClaims calls f.
+func (f ClaimsEnricherFunc) EnrichClaims(ctx context.Context, authService string, claims map[string]any) (map[string]any, error) {
+	return f(ctx, authService, claims)
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"maps"
+	"sync"
+	"time"
+)
+
+// DefaultClaimsCacheSize is the number of verified tokens whose claims auth
+// services cache by default.
+const DefaultClaimsCacheSize = 1000
+
+// ClaimsCache is a bounded LRU cache of the claims of verified tokens, so
+// that auth services don't verify the signature of a token every time clients
+// send it. Tokens are cached until they expire, and keyed by their hash
+// rather than held in memory.
+//
+// A nil ClaimsCache caches nothing.
+type ClaimsCache struct {
+	mu   sync.Mutex
+	size int
+	// entries maps the hash of each cached token to its element of lru, which
+	// lists the least recently used token last.
+	entries map[[sha256.Size]byte]*list.Element
+	lru     *list.List
+}
+
+type cachedClaims struct {
+	key     [sha256.Size]byte
+	claims  map[string]any
+	expires time.Time
+}
+
+// NewClaimsCache returns a cache of the claims of at most size tokens.
+func NewClaimsCache(size int) *ClaimsCache {
+	return &ClaimsCache{
+		size:    max(size, 1),
+		entries: make(map[[sha256.Size]byte]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// Get returns the claims cached for token, unless it expired.
+func (c *ClaimsCache) Get(token string) (map[string]any, bool) {
+	if c == nil {
+		return nil, false
+	}
+	key := sha256.Sum256([]byte(token))
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	cc := e.Value.(*cachedClaims)
+	if !time.Now().Before(cc.expires) {
+		c.lru.Remove(e)
+		delete(c.entries, key)
+		return nil, false
+	}
+	c.lru.MoveToFront(e)
+	// callers may change the claims they're returned
+	return maps.Clone(cc.claims), true
+}
+
+// Add caches the claims of token until expires, evicting the least recently
+// used token if the cache is full.
+func (c *ClaimsCache) Add(token string, claims map[string]any, expires time.Time) {
+	if c == nil || !time.Now().Before(expires) {
+		return
+	}
+	key := sha256.Sum256([]byte(token))
+	cc := &cachedClaims{key: key, claims: maps.Clone(claims), expires: expires}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok {
+		e.Value = cc
+		c.lru.MoveToFront(e)
+		return
+	}
+	if c.lru.Len() >= c.size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cachedClaims).key)
+	}
+	c.entries[key] = c.lru.PushFront(cc)
+}
@@ -0,0 +1,73 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/auth"
+)
+
+func TestClaimsCache(t *testing.T) {
+	c := auth.NewClaimsCache(2)
+	later := time.Now().Add(time.Hour)
+	c.Add("token-a", map[string]any{"sub": "a"}, later)
+	c.Add("token-b", map[string]any{"sub": "b"}, later)
+
+	got, ok := c.Get("token-a")
+	if !ok {
+		t.Fatalf("expected token-a to be cached")
+	}
+	if diff := cmp.Diff(map[string]any{"sub": "a"}, got); diff != "" {
+		t.Fatalf("incorrect claims: diff %v", diff)
+	}
+	// the returned claims are a copy
+	got["sub"] = "changed"
+	if got, _ := c.Get("token-a"); got["sub"] != "a" {
+		t.Fatalf("cached claims were changed: %v", got)
+	}
+
+	// token-b is the least recently used, so it's evicted
+	c.Add("token-c", map[string]any{"sub": "c"}, later)
+	if _, ok := c.Get("token-b"); ok {
+		t.Fatalf("expected token-b to be evicted")
+	}
+	for _, token := range []string{"token-a", "token-c"} {
+		if _, ok := c.Get(token); !ok {
+			t.Fatalf("expected %s to be cached", token)
+		}
+	}
+
+	// expired tokens aren't returned, nor cached
+	c.Add("token-d", map[string]any{"sub": "d"}, time.Now().Add(-time.Second))
+	if _, ok := c.Get("token-d"); ok {
+		t.Fatalf("expected expired token-d not to be cached")
+	}
+	c.Add("token-e", map[string]any{"sub": "e"}, time.Now().Add(50*time.Millisecond))
+	time.Sleep(100 * time.Millisecond)
+	if _, ok := c.Get("token-e"); ok {
+		t.Fatalf("expected token-e to expire")
+	}
+}
+
+func TestNilClaimsCache(t *testing.T) {
+	var c *auth.ClaimsCache
+	c.Add("token", map[string]any{"sub": "a"}, time.Now().Add(time.Hour))
+	if _, ok := c.Get("token"); ok {
+		t.Fatalf("expected a nil cache to cache nothing")
+	}
+}
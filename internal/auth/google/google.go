@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/googleapis/genai-toolbox/internal/auth"
 	"google.golang.org/api/idtoken"
@@ -33,6 +34,10 @@ type Config struct {
 	Name     string `yaml:"name" validate:"required"`
 	Kind     string `yaml:"kind" validate:"required"`
 	ClientID string `yaml:"clientId" validate:"required"`
+	// TokenCacheSize is the number of verified tokens whose claims are cached
+	// until they expire. Defaults to auth.DefaultClaimsCacheSize, and a
+	// negative size disables the cache.
+	TokenCacheSize int `yaml:"tokenCacheSize"`
 }
 
 // Returns the auth service kind
@@ -47,6 +52,13 @@ func (cfg Config) Initialize() (auth.AuthService, error) {
 		Kind:     AuthServiceKind,
 		ClientID: cfg.ClientID,
 	}
+	size := cfg.TokenCacheSize
+	if size == 0 {
+		size = auth.DefaultClaimsCacheSize
+	}
+	if size > 0 {
+		a.cache = auth.NewClaimsCache(size)
+	}
 	return a, nil
 }
 
@@ -57,6 +69,9 @@ type AuthService struct {
 	Name     string `yaml:"name"`
 	Kind     string `yaml:"kind"`
 	ClientID string `yaml:"clientId"`
+	// cache holds the claims of verified tokens, or is nil if they aren't
+	// cached.
+	cache *auth.ClaimsCache
 }
 
 // Returns the auth service kind
@@ -72,10 +87,14 @@ func (a AuthService) GetName() string {
 // Verifies Google ID token and return claims
 func (a AuthService) GetClaimsFromHeader(ctx context.Context, h http.Header) (map[string]any, error) {
 	if token := h.Get(a.Name + "_token"); token != "" {
+		if claims, ok := a.cache.Get(token); ok {
+			return claims, nil
+		}
 		payload, err := idtoken.Validate(ctx, token, a.ClientID)
 		if err != nil {
 			return nil, fmt.Errorf("Google ID token verification failure: %w", err) //nolint:staticcheck
 		}
+		a.cache.Add(token, payload.Claims, time.Unix(payload.Expires, 0))
 		return payload.Claims, nil
 	}
 	return nil, nil
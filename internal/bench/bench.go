@@ -0,0 +1,164 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bench drives concurrent invocations of a tool and reports their
+// latencies and errors, to size deployments of Toolbox.
+package bench
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Invoker invokes the benchmarked tool once.
+type Invoker func(ctx context.Context) error
+
+// Config is how invocations are driven.
+type Config struct {
+	// Concurrency is the number of invocations in flight at once.
+	Concurrency int
+	// Requests is the number of invocations made. It is ignored if Duration
+	// is set.
+	Requests int
+	// Duration is how long invocations are made for.
+	Duration time.Duration
+	// Timeout is the maximum duration of an invocation. Invocations don't
+	// time out if it is zero.
+	Timeout time.Duration
+}
+
+func (c Config) validate() error {
+	if c.Concurrency < 1 {
+		return fmt.Errorf("concurrency must be at least 1, got %d", c.Concurrency)
+	}
+	if c.Duration < 0 {
+		return fmt.Errorf("duration can't be negative, got %s", c.Duration)
+	}
+	if c.Duration == 0 && c.Requests < 1 {
+		return fmt.Errorf("requests must be at least 1, got %d", c.Requests)
+	}
+	if c.Timeout < 0 {
+		return fmt.Errorf("timeout can't be negative, got %s", c.Timeout)
+	}
+	return nil
+}
+
+// Run invokes invoke with the concurrency of cfg, until cfg.Requests
+// invocations are made, cfg.Duration is over, or ctx is done.
+func Run(ctx context.Context, cfg Config, invoke Invoker) (*Report, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	runCtx := ctx
+	if cfg.Duration > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(ctx, cfg.Duration)
+		defer cancel()
+	}
+
+	// requests hands out the invocations to make, and is closed once they
+	// are all handed out or the run is over.
+	requests := make(chan struct{})
+	go func() {
+		defer close(requests)
+		for i := 0; cfg.Duration > 0 || i < cfg.Requests; i++ {
+			select {
+			case requests <- struct{}{}:
+			case <-runCtx.Done():
+				return
+			}
+		}
+	}()
+
+	r := newReport(cfg.Concurrency)
+	var wg sync.WaitGroup
+	start := time.Now()
+	for i := 0; i < cfg.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range requests {
+				// invocations derive from ctx rather than runCtx, so that the
+				// end of the run doesn't cut them short.
+				invokeCtx, cancel := ctx, context.CancelFunc(func() {})
+				if cfg.Timeout > 0 {
+					invokeCtx, cancel = context.WithTimeout(ctx, cfg.Timeout)
+				}
+				begin := time.Now()
+				err := invoke(invokeCtx)
+				latency := time.Since(begin)
+				if err != nil && errors.Is(invokeCtx.Err(), context.DeadlineExceeded) {
+					err = errTimeout
+				}
+				cancel()
+				r.add(latency, err)
+			}
+		}()
+	}
+	wg.Wait()
+	r.Elapsed = time.Since(start)
+	r.finish()
+	if err := ctx.Err(); err != nil && r.Requests == 0 {
+		return nil, err
+	}
+	return r, nil
+}
+
+// errTimeout groups the invocations that took longer than the timeout.
+var errTimeout = errors.New("timeout")
+
+// Report is the outcome of a run.
+type Report struct {
+	Concurrency int           `json:"concurrency"`
+	Requests    int           `json:"requests"`
+	Errors      int           `json:"errors"`
+	Elapsed     time.Duration `json:"elapsedNanos"`
+	// ErrorCounts counts the failed invocations by error message.
+	ErrorCounts map[string]int `json:"errorCounts,omitempty"`
+
+	mu        sync.Mutex
+	latencies []time.Duration
+}
+
+func newReport(concurrency int) *Report {
+	return &Report{Concurrency: concurrency, ErrorCounts: make(map[string]int)}
+}
+
+// maxErrorLength is the length error messages are truncated to, so that
+// messages differing only by a long tail are grouped.
+const maxErrorLength = 200
+
+func (r *Report) add(latency time.Duration, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Requests++
+	r.latencies = append(r.latencies, latency)
+	if err == nil {
+		return
+	}
+	r.Errors++
+	msg := err.Error()
+	if len(msg) > maxErrorLength {
+		msg = msg[:maxErrorLength] + "..."
+	}
+	r.ErrorCounts[msg]++
+}
+
+func (r *Report) finish() {
+	slices.Sort(r.latencies)
+}
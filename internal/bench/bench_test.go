@@ -0,0 +1,189 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bench_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/bench"
+)
+
+func TestRun(t *testing.T) {
+	var calls, inFlight, maxInFlight atomic.Int64
+	invoke := func(ctx context.Context) error {
+		n := calls.Add(1)
+		cur := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			prev := maxInFlight.Load()
+			if cur <= prev || maxInFlight.CompareAndSwap(prev, cur) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		switch {
+		case n%10 == 0:
+			return errors.New("too many connections")
+		case n%5 == 0:
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		return nil
+	}
+
+	report, err := bench.Run(context.Background(), bench.Config{Concurrency: 4, Requests: 100, Timeout: 20 * time.Millisecond}, invoke)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if report.Requests != 100 || calls.Load() != 100 {
+		t.Fatalf("unexpected number of requests: report %d, calls %d", report.Requests, calls.Load())
+	}
+	if maxInFlight.Load() > 4 {
+		t.Fatalf("more invocations in flight than the concurrency: %d", maxInFlight.Load())
+	}
+	want := map[string]int{"too many connections": 10, "timeout": 10}
+	if diff := cmp.Diff(want, report.ErrorCounts); diff != "" {
+		t.Fatalf("unexpected errors: diff %v", diff)
+	}
+	if report.Errors != 20 {
+		t.Fatalf("unexpected number of errors: %d", report.Errors)
+	}
+	if p50, p99 := report.Percentile(50), report.Percentile(99); p50 < time.Millisecond || p99 < 20*time.Millisecond || p50 > p99 {
+		t.Fatalf("unexpected percentiles: p50 %s, p99 %s", p50, p99)
+	}
+
+	var total int
+	for _, b := range report.Histogram() {
+		total += b.Count
+	}
+	if total != 100 {
+		t.Fatalf("unexpected histogram total: %d", total)
+	}
+
+	var out bytes.Buffer
+	if err := report.WriteText(&out); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for _, want := range []string{"Requests:     100 (80 succeeded, 20 failed)", "p99", "Latency histogram:", "10  timeout", "10  too many connections"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("report %q doesn't contain %q", out.String(), want)
+		}
+	}
+
+	b, err := json.Marshal(report)
+	if err != nil {
+		t.Fatalf("unable to marshal report: %s", err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("unable to parse report: %s", err)
+	}
+	for _, key := range []string{"requests", "errors", "errorCounts", "throughput", "latencyNanos", "histogram"} {
+		if _, ok := got[key]; !ok {
+			t.Errorf("JSON report %s has no %q", b, key)
+		}
+	}
+}
+
+func TestRunDuration(t *testing.T) {
+	report, err := bench.Run(context.Background(), bench.Config{Concurrency: 2, Duration: 50 * time.Millisecond}, func(context.Context) error {
+		time.Sleep(time.Millisecond)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if report.Requests == 0 || report.Errors != 0 {
+		t.Fatalf("unexpected report: %d requests, %d errors", report.Requests, report.Errors)
+	}
+	if report.Elapsed < 50*time.Millisecond {
+		t.Fatalf("run ended before its duration: %s", report.Elapsed)
+	}
+}
+
+func TestRunInvalidConfig(t *testing.T) {
+	tcs := []struct {
+		desc string
+		cfg  bench.Config
+		err  string
+	}{
+		{desc: "no concurrency", cfg: bench.Config{Requests: 1}, err: "concurrency must be at least 1, got 0"},
+		{desc: "no requests", cfg: bench.Config{Concurrency: 1}, err: "requests must be at least 1, got 0"},
+		{desc: "negative duration", cfg: bench.Config{Concurrency: 1, Duration: -time.Second}, err: "duration can't be negative, got -1s"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := bench.Run(context.Background(), tc.cfg, func(context.Context) error { return nil })
+			if err == nil || err.Error() != tc.err {
+				t.Fatalf("unexpected error: want %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestHTTPInvoker(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var params map[string]any
+		if r.URL.Path != "/api/tool/my-tool/invoke" || r.Header.Get("Authorization") != "Bearer token" || json.NewDecoder(r.Body).Decode(&params) != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if params["id"] == float64(2) {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"status":"Internal Server Error","error":"unable to connect: too many clients"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"result":"[]"}`))
+	}))
+	defer ts.Close()
+
+	header := http.Header{"Authorization": {"Bearer token"}}
+	tcs := []struct {
+		desc   string
+		params map[string]any
+		err    string
+	}{
+		{desc: "success", params: map[string]any{"id": 1}},
+		{desc: "error", params: map[string]any{"id": 2}, err: "HTTP 500: unable to connect: too many clients"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			invoke, err := bench.HTTPInvoker(ts.Client(), ts.URL, "my-tool", tc.params, header)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			err = invoke(context.Background())
+			if tc.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.err {
+				t.Fatalf("unexpected error: want %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
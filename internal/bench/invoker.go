@@ -0,0 +1,103 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bench
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+// HTTPInvoker returns an Invoker invoking tool with params over the API of
+// the Toolbox server at baseURL, e.g. http://127.0.0.1:5000, with header.
+func HTTPInvoker(client *http.Client, baseURL, tool string, params map[string]any, header http.Header) (Invoker, error) {
+	if params == nil {
+		params = map[string]any{}
+	}
+	body, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal parameters: %w", err)
+	}
+	u, err := url.JoinPath(baseURL, "api", "tool", tool, "invoke")
+	if err != nil {
+		return nil, fmt.Errorf("invalid server URL %q: %w", baseURL, err)
+	}
+	return func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header = header.Clone()
+		if req.Header == nil {
+			req.Header = make(http.Header)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := client.Do(req)
+		if err != nil {
+			// the URL is the same for every request, so only the cause of
+			// the error tells errors apart.
+			var urlErr *url.Error
+			if errors.As(err, &urlErr) {
+				return urlErr.Err
+			}
+			return err
+		}
+		defer resp.Body.Close()
+		respBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode == http.StatusOK {
+			return nil
+		}
+		var errResp struct {
+			Error string `json:"error"`
+		}
+		if json.Unmarshal(respBody, &errResp) == nil && errResp.Error != "" {
+			return fmt.Errorf("HTTP %d: %s", resp.StatusCode, errResp.Error)
+		}
+		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}, nil
+}
+
+// ToolInvoker returns an Invoker invoking tool with params in-process, as
+// the server does after authorization. Parameters taken from auth services
+// are not supported.
+func ToolInvoker(tool tools.Tool, params map[string]any) (Invoker, error) {
+	if params == nil {
+		params = map[string]any{}
+	}
+	// the parameters are validated once, so that invalid ones fail the
+	// benchmark rather than every invocation.
+	if _, err := tool.ParseParams(params, nil); err != nil {
+		return nil, fmt.Errorf("invalid parameters: %w", err)
+	}
+	return func(ctx context.Context) error {
+		paramValues, err := tool.ParseParams(params, nil)
+		if err != nil {
+			return err
+		}
+		_, err = tool.Invoke(ctx, paramValues)
+		return err
+	}, nil
+}
@@ -0,0 +1,199 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bench
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+)
+
+// bucketBounds are the upper bounds of the buckets of the latency histogram.
+var bucketBounds = []time.Duration{
+	time.Millisecond,
+	2 * time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	20 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	200 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2 * time.Second,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// Bucket counts the invocations with a latency up to Le, and beyond the
+// bound of the previous bucket. The last bucket has no bound.
+type Bucket struct {
+	Le    time.Duration `json:"leNanos,omitempty"`
+	Count int           `json:"count"`
+}
+
+// Percentile returns the latency under which p percent of the invocations
+// completed, or 0 if there were none.
+func (r *Report) Percentile(p float64) time.Duration {
+	if len(r.latencies) == 0 {
+		return 0
+	}
+	i := int(p/100*float64(len(r.latencies))+0.5) - 1
+	i = max(0, min(i, len(r.latencies)-1))
+	return r.latencies[i]
+}
+
+// Min returns the lowest latency, or 0 if there were no invocations.
+func (r *Report) Min() time.Duration {
+	if len(r.latencies) == 0 {
+		return 0
+	}
+	return r.latencies[0]
+}
+
+// Max returns the highest latency, or 0 if there were no invocations.
+func (r *Report) Max() time.Duration {
+	if len(r.latencies) == 0 {
+		return 0
+	}
+	return r.latencies[len(r.latencies)-1]
+}
+
+// Throughput returns the number of invocations completed per second.
+func (r *Report) Throughput() float64 {
+	if r.Elapsed <= 0 {
+		return 0
+	}
+	return float64(r.Requests) / r.Elapsed.Seconds()
+}
+
+// Histogram returns the latency histogram, from the first to the last
+// non-empty bucket.
+func (r *Report) Histogram() []Bucket {
+	buckets := make([]Bucket, len(bucketBounds)+1)
+	for i, b := range bucketBounds {
+		buckets[i].Le = b
+	}
+	for _, l := range r.latencies {
+		i, _ := slices.BinarySearch(bucketBounds, l)
+		buckets[i].Count++
+	}
+	first := slices.IndexFunc(buckets, func(b Bucket) bool { return b.Count > 0 })
+	if first < 0 {
+		return nil
+	}
+	last := len(buckets) - 1
+	for buckets[last].Count == 0 {
+		last--
+	}
+	return buckets[first : last+1]
+}
+
+// MarshalJSON encodes the report along with its latency percentiles and
+// histogram.
+func (r *Report) MarshalJSON() ([]byte, error) {
+	type report Report
+	return json.Marshal(struct {
+		*report
+		Throughput float64          `json:"throughput"`
+		Latency    map[string]int64 `json:"latencyNanos"`
+		Histogram  []Bucket         `json:"histogram"`
+	}{
+		report:     (*report)(r),
+		Throughput: r.Throughput(),
+		Latency: map[string]int64{
+			"min": r.Min().Nanoseconds(),
+			"p50": r.Percentile(50).Nanoseconds(),
+			"p90": r.Percentile(90).Nanoseconds(),
+			"p99": r.Percentile(99).Nanoseconds(),
+			"max": r.Max().Nanoseconds(),
+		},
+		Histogram: r.Histogram(),
+	})
+}
+
+// histogramWidth is the width of the bar of the largest bucket.
+const histogramWidth = 40
+
+// WriteText writes the report in a human readable form.
+func (r *Report) WriteText(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Requests:     %d (%d succeeded, %d failed)\n", r.Requests, r.Requests-r.Errors, r.Errors)
+	fmt.Fprintf(&b, "Concurrency:  %d\n", r.Concurrency)
+	fmt.Fprintf(&b, "Elapsed:      %s\n", r.Elapsed.Round(time.Millisecond))
+	fmt.Fprintf(&b, "Throughput:   %.1f requests/s\n", r.Throughput())
+
+	b.WriteString("\nLatency:\n")
+	for _, p := range []struct {
+		name string
+		d    time.Duration
+	}{
+		{"min", r.Min()},
+		{"p50", r.Percentile(50)},
+		{"p90", r.Percentile(90)},
+		{"p99", r.Percentile(99)},
+		{"max", r.Max()},
+	} {
+		fmt.Fprintf(&b, "  %s  %s\n", p.name, roundLatency(p.d))
+	}
+
+	if buckets := r.Histogram(); len(buckets) > 0 {
+		b.WriteString("\nLatency histogram:\n")
+		largest := slices.MaxFunc(buckets, func(x, y Bucket) int { return x.Count - y.Count }).Count
+		for _, bucket := range buckets {
+			bound := "    +Inf"
+			if bucket.Le > 0 {
+				bound = fmt.Sprintf("%8s", bucket.Le)
+			}
+			bar := strings.Repeat("#", bucket.Count*histogramWidth/largest)
+			fmt.Fprintf(&b, "  <= %s  %-*s  %d\n", bound, histogramWidth, bar, bucket.Count)
+		}
+	}
+
+	if len(r.ErrorCounts) > 0 {
+		b.WriteString("\nErrors:\n")
+		msgs := make([]string, 0, len(r.ErrorCounts))
+		for msg := range r.ErrorCounts {
+			msgs = append(msgs, msg)
+		}
+		// the most frequent errors first
+		slices.SortFunc(msgs, func(x, y string) int {
+			if c := r.ErrorCounts[y] - r.ErrorCounts[x]; c != 0 {
+				return c
+			}
+			return strings.Compare(x, y)
+		})
+		for _, msg := range msgs {
+			fmt.Fprintf(&b, "  %6d  %s\n", r.ErrorCounts[msg], msg)
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// roundLatency rounds d to a precision readable at a glance.
+func roundLatency(d time.Duration) time.Duration {
+	switch {
+	case d >= time.Second:
+		return d.Round(time.Millisecond)
+	case d >= time.Millisecond:
+		return d.Round(10 * time.Microsecond)
+	default:
+		return d.Round(time.Microsecond)
+	}
+}
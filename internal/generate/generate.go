@@ -0,0 +1,455 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package generate generates tools from the schema of a database, as a
+// starting point for a tools file.
+package generate
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/alloydbpg"
+	"github.com/googleapis/genai-toolbox/internal/sources/azuresql"
+	"github.com/googleapis/genai-toolbox/internal/sources/cloudsqlmssql"
+	"github.com/googleapis/genai-toolbox/internal/sources/cloudsqlmysql"
+	"github.com/googleapis/genai-toolbox/internal/sources/cloudsqlpg"
+	"github.com/googleapis/genai-toolbox/internal/sources/greenplum"
+	"github.com/googleapis/genai-toolbox/internal/sources/mssql"
+	"github.com/googleapis/genai-toolbox/internal/sources/mysql"
+	"github.com/googleapis/genai-toolbox/internal/sources/oceanbase"
+	"github.com/googleapis/genai-toolbox/internal/sources/postgres"
+	"github.com/googleapis/genai-toolbox/internal/sources/sqlite"
+	"github.com/googleapis/genai-toolbox/internal/sources/tidb"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+// DefaultLimit is the default number of rows returned by the list tools.
+const DefaultLimit = 50
+
+// limitParam is the name of the parameter limiting the rows of list tools.
+const limitParam = "limit"
+
+// dialect describes how to write the statements of the tools of a source.
+type dialect struct {
+	// toolKind is the kind of the generated tools.
+	toolKind string
+	// identifiers is how identifiers are quoted.
+	identifiers tools.Dialect
+	// top is whether rows are limited with TOP rather than LIMIT.
+	top bool
+	// returning is the clause returning the inserted row, if supported, and
+	// output is whether it goes before VALUES as in SQL Server.
+	returning string
+	output    bool
+}
+
+var (
+	postgresDialect = dialect{toolKind: "postgres-sql", identifiers: tools.DialectANSI, returning: "RETURNING *"}
+	mysqlDialect    = dialect{toolKind: "mysql-sql", identifiers: tools.DialectMySQL}
+	mssqlDialect    = dialect{toolKind: "mssql-sql", identifiers: tools.DialectMSSQL, top: true, returning: "OUTPUT INSERTED.*", output: true}
+)
+
+var dialects = map[string]dialect{
+	postgres.SourceKind:      postgresDialect,
+	alloydbpg.SourceKind:     postgresDialect,
+	cloudsqlpg.SourceKind:    postgresDialect,
+	greenplum.SourceKind:     {toolKind: "greenplum-sql", identifiers: tools.DialectANSI, returning: "RETURNING *"},
+	mysql.SourceKind:         mysqlDialect,
+	cloudsqlmysql.SourceKind: mysqlDialect,
+	tidb.SourceKind:          {toolKind: "tidb-sql", identifiers: tools.DialectMySQL},
+	oceanbase.SourceKind:     {toolKind: "oceanbase-sql", identifiers: tools.DialectMySQL},
+	mssql.SourceKind:         mssqlDialect,
+	cloudsqlmssql.SourceKind: mssqlDialect,
+	azuresql.SourceKind:      mssqlDialect,
+	sqlite.SourceKind:        {toolKind: "sqlite-sql", identifiers: tools.DialectANSI, returning: "RETURNING *"},
+}
+
+// SupportedKinds returns the sorted kinds of the sources tools can be
+// generated for.
+func SupportedKinds() []string {
+	kinds := make([]string, 0, len(dialects))
+	for k := range dialects {
+		kinds = append(kinds, k)
+	}
+	slices.Sort(kinds)
+	return kinds
+}
+
+// quotedTable is the quoted name of a table, and of each of its columns.
+type quotedTable struct {
+	name    string
+	columns []string
+}
+
+// quoteTable quotes the names of t and of its columns, or returns an error if
+// one of them can't be quoted.
+func (d dialect) quoteTable(t sources.Table) (quotedTable, error) {
+	name, err := tools.QuoteIdentifier(d.identifiers, t.Name)
+	if err != nil {
+		return quotedTable{}, err
+	}
+	if t.Schema != "" {
+		schema, err := tools.QuoteIdentifier(d.identifiers, t.Schema)
+		if err != nil {
+			return quotedTable{}, err
+		}
+		name = schema + "." + name
+	}
+	q := quotedTable{name: name, columns: make([]string, 0, len(t.Columns))}
+	for _, c := range t.Columns {
+		column, err := tools.QuoteIdentifier(d.identifiers, c.Name)
+		if err != nil {
+			return quotedTable{}, err
+		}
+		q.columns = append(q.columns, column)
+	}
+	return q, nil
+}
+
+// Config selects the tables to generate tools for and the source they use.
+type Config struct {
+	// Source is the name of the source used by the tools.
+	Source string
+	// SourceKind is the kind of the source, which determines the SQL dialect.
+	SourceKind string
+	// Schemas limits the tables to those in the given schemas.
+	Schemas []string
+	// Tables limits the tables to those with the given names, which may be
+	// qualified with a schema.
+	Tables []string
+}
+
+// Parameter is a generated tool parameter.
+type Parameter struct {
+	Name        string `yaml:"name"`
+	Type        string `yaml:"type"`
+	Description string `yaml:"description"`
+	Required    *bool  `yaml:"required,omitempty"`
+	Default     any    `yaml:"default,omitempty"`
+}
+
+// Tool is a generated tool configuration.
+type Tool struct {
+	Kind        string      `yaml:"kind"`
+	Source      string      `yaml:"source"`
+	Description string      `yaml:"description"`
+	Statement   string      `yaml:"statement"`
+	Parameters  []Parameter `yaml:"parameters,omitempty"`
+}
+
+// Result is the output of Generate.
+type Result struct {
+	// Tools are the generated tools, in order.
+	Tools yaml.MapSlice
+	// Toolset is the names of all of the generated tools.
+	Toolset []string
+	// Warnings describe the tools that couldn't be generated.
+	Warnings []string
+}
+
+// Generate returns tools to get rows by primary key, list rows filtered by
+// column values and insert rows for each of the selected tables.
+func Generate(cfg Config, tables []sources.Table) (Result, error) {
+	d, ok := dialects[cfg.SourceKind]
+	if !ok {
+		return Result{}, fmt.Errorf("source kind %q is not supported; must be one of %q", cfg.SourceKind, SupportedKinds())
+	}
+	tables = selectTables(tables, cfg.Schemas, cfg.Tables)
+	if len(tables) == 0 {
+		return Result{}, fmt.Errorf("no tables found in source %q", cfg.Source)
+	}
+
+	// tables are prefixed with their schema when their name is ambiguous
+	counts := make(map[string]int)
+	for _, t := range tables {
+		counts[identifier(t.Name, true)]++
+	}
+
+	var r Result
+	add := func(name string, tool Tool) {
+		r.Tools = append(r.Tools, yaml.MapItem{Key: name, Value: tool})
+		r.Toolset = append(r.Toolset, name)
+	}
+	for _, t := range tables {
+		base := identifier(t.Name, true)
+		if counts[base] > 1 && t.Schema != "" {
+			base = identifier(t.Schema, true) + "_" + base
+		}
+		names := paramNames(t.Columns)
+		q, err := d.quoteTable(t)
+		if err != nil {
+			r.Warnings = append(r.Warnings, fmt.Sprintf("skipping table %q: %s", t.QualifiedName(), err))
+			continue
+		}
+
+		if tool, ok := getTool(d, t, q, names); ok {
+			tool.Source = cfg.Source
+			add("get_"+base, tool)
+		}
+		tool := listTool(d, t, q, names)
+		tool.Source = cfg.Source
+		add("list_"+base, tool)
+		tool, err = insertTool(d, t, q, names)
+		if err != nil {
+			r.Warnings = append(r.Warnings, fmt.Sprintf("skipping insert tool for table %q: %s", t.QualifiedName(), err))
+			continue
+		}
+		tool.Source = cfg.Source
+		add("insert_"+base, tool)
+	}
+	return r, nil
+}
+
+// Marshal returns the tools and a toolset named after the source as the YAML
+// of a tools file.
+func (r Result) Marshal(toolset string) ([]byte, error) {
+	file := struct {
+		Tools    yaml.MapSlice `yaml:"tools"`
+		Toolsets yaml.MapSlice `yaml:"toolsets"`
+	}{
+		Tools:    r.Tools,
+		Toolsets: yaml.MapSlice{{Key: toolset, Value: r.Toolset}},
+	}
+	return yaml.MarshalWithOptions(file, yaml.UseLiteralStyleIfMultiline(true), yaml.IndentSequence(true))
+}
+
+// selectTables returns the tables in schemas named in tables, or all of them
+// when the filters are empty.
+func selectTables(all []sources.Table, schemas, tables []string) []sources.Table {
+	var selected []sources.Table
+	for _, t := range all {
+		if len(schemas) > 0 && !slices.Contains(schemas, t.Schema) {
+			continue
+		}
+		if len(tables) > 0 && !slices.Contains(tables, t.Name) && !slices.Contains(tables, t.QualifiedName()) {
+			continue
+		}
+		selected = append(selected, t)
+	}
+	return selected
+}
+
+func getTool(d dialect, t sources.Table, q quotedTable, names []string) (Tool, bool) {
+	var where []string
+	var params []Parameter
+	for i, c := range t.Columns {
+		if !c.PrimaryKey {
+			continue
+		}
+		typ, ok := paramType(c.Type)
+		if !ok {
+			return Tool{}, false
+		}
+		where = append(where, fmt.Sprintf("%s = :%s", q.columns[i], names[i]))
+		params = append(params, Parameter{Name: names[i], Type: typ, Description: columnDescription(c, "The %s of the row to get.")})
+	}
+	if len(where) == 0 {
+		return Tool{}, false
+	}
+	statement := fmt.Sprintf("SELECT * FROM %s WHERE %s", q.name, strings.Join(where, " AND "))
+	return Tool{
+		Kind:        d.toolKind,
+		Description: tableDescription(t, fmt.Sprintf("Gets the row of the %s table with the given primary key.", t.QualifiedName())),
+		Statement:   statement,
+		Parameters:  params,
+	}, true
+}
+
+func listTool(d dialect, t sources.Table, q quotedTable, names []string) Tool {
+	var filters, order []string
+	var params []Parameter
+	for i, c := range t.Columns {
+		if c.PrimaryKey {
+			order = append(order, q.columns[i])
+		}
+		typ, ok := paramType(c.Type)
+		if !ok {
+			continue
+		}
+		filters = append(filters, fmt.Sprintf("(:%s IS NULL OR %s = :%s)", names[i], q.columns[i], names[i]))
+		params = append(params, Parameter{
+			Name:        names[i],
+			Type:        typ,
+			Description: columnDescription(c, "Only list rows with this %s."),
+			Required:    new(bool),
+		})
+	}
+
+	var b strings.Builder
+	b.WriteString("SELECT ")
+	if d.top {
+		fmt.Fprintf(&b, "TOP (:%s) ", limitParam)
+	}
+	fmt.Fprintf(&b, "* FROM %s", q.name)
+	for i, f := range filters {
+		if i == 0 {
+			b.WriteString("\nWHERE ")
+		} else {
+			b.WriteString("\n  AND ")
+		}
+		b.WriteString(f)
+	}
+	if len(order) > 0 {
+		fmt.Fprintf(&b, "\nORDER BY %s", strings.Join(order, ", "))
+	}
+	if !d.top {
+		fmt.Fprintf(&b, "\nLIMIT :%s", limitParam)
+	}
+	params = append(params, Parameter{
+		Name:        limitParam,
+		Type:        "integer",
+		Description: "The maximum number of rows to list.",
+		Default:     DefaultLimit,
+	})
+	return Tool{
+		Kind:        d.toolKind,
+		Description: tableDescription(t, fmt.Sprintf("Lists rows of the %s table, optionally only those matching the given column values.", t.QualifiedName())),
+		Statement:   b.String() + "\n",
+		Parameters:  params,
+	}
+}
+
+func insertTool(d dialect, t sources.Table, q quotedTable, names []string) (Tool, error) {
+	var columns, values []string
+	var params []Parameter
+	for i, c := range t.Columns {
+		// generated columns are left for the database to set
+		if c.Generated {
+			continue
+		}
+		typ, ok := paramType(c.Type)
+		if !ok {
+			if c.Nullable {
+				continue
+			}
+			return Tool{}, fmt.Errorf("column %q has unsupported type %q", c.Name, c.Type)
+		}
+		p := Parameter{Name: names[i], Type: typ, Description: columnDescription(c, "The %s of the new row.")}
+		if c.Nullable {
+			p.Required = new(bool)
+		}
+		columns = append(columns, q.columns[i])
+		values = append(values, ":"+names[i])
+		params = append(params, p)
+	}
+	if len(columns) == 0 {
+		return Tool{}, fmt.Errorf("all columns are generated")
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "INSERT INTO %s (%s)", q.name, strings.Join(columns, ", "))
+	if d.returning != "" && d.output {
+		fmt.Fprintf(&b, "\n%s", d.returning)
+	}
+	fmt.Fprintf(&b, "\nVALUES (%s)", strings.Join(values, ", "))
+	if d.returning != "" && !d.output {
+		fmt.Fprintf(&b, "\n%s", d.returning)
+	}
+	return Tool{
+		Kind:        d.toolKind,
+		Description: tableDescription(t, fmt.Sprintf("Inserts a row into the %s table.", t.QualifiedName())),
+		Statement:   b.String() + "\n",
+		Parameters:  params,
+	}, nil
+}
+
+func tableDescription(t sources.Table, description string) string {
+	if t.Comment == "" {
+		return description
+	}
+	return description + " " + t.Comment
+}
+
+// columnDescription formats description with the name of the column, followed
+// by its comment.
+func columnDescription(c sources.Column, description string) string {
+	description = fmt.Sprintf(description, c.Name)
+	if c.Comment == "" {
+		return description
+	}
+	return description + " " + c.Comment
+}
+
+// paramNames returns unique parameter names for the columns, which are valid
+// placeholders, and never the name of the limit parameter.
+func paramNames(columns []sources.Column) []string {
+	used := map[string]bool{limitParam: true}
+	names := make([]string, len(columns))
+	for i, c := range columns {
+		name := identifier(c.Name, false)
+		for n := 2; used[name]; n++ {
+			name = fmt.Sprintf("%s_%d", identifier(c.Name, false), n)
+		}
+		used[name] = true
+		names[i] = name
+	}
+	return names
+}
+
+// identifier replaces the characters of name which aren't valid in an
+// identifier with underscores, lowercasing it if lower is set.
+func identifier(name string, lower bool) string {
+	if lower {
+		name = strings.ToLower(name)
+	}
+	var b strings.Builder
+	for i, r := range name {
+		switch {
+		case r == '_', 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z':
+			b.WriteRune(r)
+		case '0' <= r && r <= '9':
+			if i == 0 {
+				b.WriteRune('_')
+			}
+			b.WriteRune(r)
+		default:
+			b.WriteRune('_')
+		}
+	}
+	if b.Len() == 0 {
+		return "_"
+	}
+	return b.String()
+}
+
+// paramType returns the parameter type for a column of the given SQL type,
+// or false if the type can't be compared to or set from a parameter.
+func paramType(sqlType string) (string, bool) {
+	base := strings.ToLower(strings.TrimSpace(sqlType))
+	if i := strings.IndexAny(base, "( "); i >= 0 {
+		base = base[:i]
+	}
+	// arrays of PostgreSQL, e.g. integer[]
+	if strings.HasSuffix(base, "[]") {
+		return "", false
+	}
+	switch base {
+	case "int", "integer", "int2", "int4", "int8", "smallint", "mediumint", "bigint", "tinyint",
+		"serial", "smallserial", "bigserial", "serial4", "serial8":
+		return "integer", true
+	case "real", "float", "float4", "float8", "double", "numeric", "decimal", "number":
+		return "float", true
+	case "bool", "boolean", "bit":
+		return "boolean", true
+	case "bytea", "blob", "tinyblob", "mediumblob", "longblob", "binary", "varbinary", "image",
+		"json", "jsonb", "xml", "geometry", "geography", "point", "array", "user-defined",
+		"money", "smallmoney", "hierarchyid", "sql_variant", "rowversion":
+		return "", false
+	}
+	return "string", true
+}
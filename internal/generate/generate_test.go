@@ -0,0 +1,323 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate_test
+
+import (
+	"strings"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/generate"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+)
+
+var testTables = []sources.Table{
+	{
+		Schema:  "public",
+		Name:    "orders",
+		Comment: "Customer orders.",
+		Columns: []sources.Column{
+			{Name: "id", Type: "bigint", PrimaryKey: true, Generated: true},
+			{Name: "customer_id", Type: "integer", Comment: "The ordering customer."},
+			{Name: "total", Type: "numeric"},
+			{Name: "note", Type: "character varying", Nullable: true},
+			{Name: "attrs", Type: "jsonb", Nullable: true},
+		},
+	},
+	{
+		Schema: "public",
+		Name:   "events",
+		Columns: []sources.Column{
+			{Name: "payload", Type: "bytea"},
+			{Name: "limit", Type: "integer", Nullable: true},
+		},
+	},
+}
+
+func TestGenerate(t *testing.T) {
+	got, err := generate.Generate(generate.Config{Source: "my-pg", SourceKind: "postgres"}, testTables)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	wantNames := []string{"get_orders", "list_orders", "insert_orders", "list_events"}
+	if diff := cmp.Diff(wantNames, got.Toolset); diff != "" {
+		t.Fatalf("incorrect toolset: diff %v", diff)
+	}
+	wantWarnings := []string{`skipping insert tool for table "public.events": column "payload" has unsupported type "bytea"`}
+	if diff := cmp.Diff(wantWarnings, got.Warnings); diff != "" {
+		t.Fatalf("incorrect warnings: diff %v", diff)
+	}
+
+	optional := new(bool)
+	want := yaml.MapSlice{
+		{Key: "get_orders", Value: generate.Tool{
+			Kind:        "postgres-sql",
+			Source:      "my-pg",
+			Description: "Gets the row of the public.orders table with the given primary key. Customer orders.",
+			Statement:   `SELECT * FROM "public"."orders" WHERE "id" = :id`,
+			Parameters: []generate.Parameter{
+				{Name: "id", Type: "integer", Description: "The id of the row to get."},
+			},
+		}},
+		{Key: "list_orders", Value: generate.Tool{
+			Kind:        "postgres-sql",
+			Source:      "my-pg",
+			Description: "Lists rows of the public.orders table, optionally only those matching the given column values. Customer orders.",
+			Statement: `SELECT * FROM "public"."orders"
+WHERE (:id IS NULL OR "id" = :id)
+  AND (:customer_id IS NULL OR "customer_id" = :customer_id)
+  AND (:total IS NULL OR "total" = :total)
+  AND (:note IS NULL OR "note" = :note)
+ORDER BY "id"
+LIMIT :limit
+`,
+			Parameters: []generate.Parameter{
+				{Name: "id", Type: "integer", Description: "Only list rows with this id.", Required: optional},
+				{Name: "customer_id", Type: "integer", Description: "Only list rows with this customer_id. The ordering customer.", Required: optional},
+				{Name: "total", Type: "float", Description: "Only list rows with this total.", Required: optional},
+				{Name: "note", Type: "string", Description: "Only list rows with this note.", Required: optional},
+				{Name: "limit", Type: "integer", Description: "The maximum number of rows to list.", Default: generate.DefaultLimit},
+			},
+		}},
+		{Key: "insert_orders", Value: generate.Tool{
+			Kind:        "postgres-sql",
+			Source:      "my-pg",
+			Description: "Inserts a row into the public.orders table. Customer orders.",
+			Statement: `INSERT INTO "public"."orders" ("customer_id", "total", "note")
+VALUES (:customer_id, :total, :note)
+RETURNING *
+`,
+			Parameters: []generate.Parameter{
+				{Name: "customer_id", Type: "integer", Description: "The customer_id of the new row. The ordering customer."},
+				{Name: "total", Type: "float", Description: "The total of the new row."},
+				{Name: "note", Type: "string", Description: "The note of the new row.", Required: optional},
+			},
+		}},
+		{Key: "list_events", Value: generate.Tool{
+			Kind:        "postgres-sql",
+			Source:      "my-pg",
+			Description: "Lists rows of the public.events table, optionally only those matching the given column values.",
+			Statement: `SELECT * FROM "public"."events"
+WHERE (:limit_2 IS NULL OR "limit" = :limit_2)
+LIMIT :limit
+`,
+			Parameters: []generate.Parameter{
+				{Name: "limit_2", Type: "integer", Description: "Only list rows with this limit.", Required: optional},
+				{Name: "limit", Type: "integer", Description: "The maximum number of rows to list.", Default: generate.DefaultLimit},
+			},
+		}},
+	}
+	if diff := cmp.Diff(want, got.Tools); diff != "" {
+		t.Fatalf("incorrect tools: diff %v", diff)
+	}
+}
+
+func TestGenerateDialects(t *testing.T) {
+	tables := []sources.Table{{
+		Schema: "dbo",
+		Name:   "Line Items",
+		Columns: []sources.Column{
+			{Name: "OrderID", Type: "int", PrimaryKey: true},
+			{Name: "LineNo", Type: "int", PrimaryKey: true},
+			{Name: "Shipped", Type: "bit", Nullable: true},
+		},
+	}}
+	tcs := []struct {
+		kind   string
+		get    string
+		list   string
+		insert string
+	}{
+		{
+			kind: "mssql",
+			get:  "SELECT * FROM [dbo].[Line Items] WHERE [OrderID] = :OrderID AND [LineNo] = :LineNo",
+			list: "SELECT TOP (:limit) * FROM [dbo].[Line Items]\n" +
+				"WHERE (:OrderID IS NULL OR [OrderID] = :OrderID)\n" +
+				"  AND (:LineNo IS NULL OR [LineNo] = :LineNo)\n" +
+				"  AND (:Shipped IS NULL OR [Shipped] = :Shipped)\n" +
+				"ORDER BY [OrderID], [LineNo]\n",
+			insert: "INSERT INTO [dbo].[Line Items] ([OrderID], [LineNo], [Shipped])\nOUTPUT INSERTED.*\nVALUES (:OrderID, :LineNo, :Shipped)\n",
+		},
+		{
+			kind: "mysql",
+			get:  "SELECT * FROM `dbo`.`Line Items` WHERE `OrderID` = :OrderID AND `LineNo` = :LineNo",
+			list: "SELECT * FROM `dbo`.`Line Items`\n" +
+				"WHERE (:OrderID IS NULL OR `OrderID` = :OrderID)\n" +
+				"  AND (:LineNo IS NULL OR `LineNo` = :LineNo)\n" +
+				"  AND (:Shipped IS NULL OR `Shipped` = :Shipped)\n" +
+				"ORDER BY `OrderID`, `LineNo`\n" +
+				"LIMIT :limit\n",
+			insert: "INSERT INTO `dbo`.`Line Items` (`OrderID`, `LineNo`, `Shipped`)\nVALUES (:OrderID, :LineNo, :Shipped)\n",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.kind, func(t *testing.T) {
+			got, err := generate.Generate(generate.Config{Source: "my-source", SourceKind: tc.kind}, tables)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			var statements []string
+			for _, item := range got.Tools {
+				statements = append(statements, item.Value.(generate.Tool).Statement)
+			}
+			want := []string{tc.get, tc.list, tc.insert}
+			if diff := cmp.Diff(want, statements); diff != "" {
+				t.Fatalf("incorrect statements: diff %v", diff)
+			}
+			if diff := cmp.Diff([]string{"get_line_items", "list_line_items", "insert_line_items"}, got.Toolset); diff != "" {
+				t.Fatalf("incorrect toolset: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateUnquotableTable(t *testing.T) {
+	tables := []sources.Table{
+		{Name: "items", Columns: []sources.Column{{Name: "id", Type: "integer"}}},
+		{Name: "bad", Columns: []sources.Column{{Name: "na\x00me", Type: "integer"}}},
+	}
+	got, err := generate.Generate(generate.Config{Source: "my-source", SourceKind: "mysql"}, tables)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff := cmp.Diff([]string{"list_items", "insert_items"}, got.Toolset); diff != "" {
+		t.Fatalf("incorrect toolset: diff %v", diff)
+	}
+	wantWarnings := []string{`skipping table "bad": identifier "na\x00me" must not contain a null character`}
+	if diff := cmp.Diff(wantWarnings, got.Warnings); diff != "" {
+		t.Fatalf("incorrect warnings: diff %v", diff)
+	}
+}
+
+func TestGenerateSelectTables(t *testing.T) {
+	tables := []sources.Table{
+		{Schema: "sales", Name: "items", Columns: []sources.Column{{Name: "id", Type: "integer", Generated: true}}},
+		{Schema: "stock", Name: "items", Columns: []sources.Column{{Name: "id", Type: "integer", Generated: true}}},
+		{Schema: "stock", Name: "sites", Columns: []sources.Column{{Name: "id", Type: "integer", Generated: true}}},
+	}
+	tcs := []struct {
+		desc string
+		cfg  generate.Config
+		want []string
+	}{
+		{
+			desc: "ambiguous names are prefixed with the schema",
+			cfg:  generate.Config{},
+			want: []string{"list_sales_items", "list_stock_items", "list_sites"},
+		},
+		{
+			desc: "schemas",
+			cfg:  generate.Config{Schemas: []string{"stock"}},
+			want: []string{"list_items", "list_sites"},
+		},
+		{
+			desc: "tables",
+			cfg:  generate.Config{Tables: []string{"sales.items", "sites"}},
+			want: []string{"list_items", "list_sites"},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			tc.cfg.Source = "my-pg"
+			tc.cfg.SourceKind = "postgres"
+			got, err := generate.Generate(tc.cfg, tables)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Toolset); diff != "" {
+				t.Fatalf("incorrect toolset: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tcs := []struct {
+		desc string
+		cfg  generate.Config
+		want string
+	}{
+		{
+			desc: "unsupported kind",
+			cfg:  generate.Config{Source: "my-source", SourceKind: "bigquery"},
+			want: `source kind "bigquery" is not supported`,
+		},
+		{
+			desc: "no tables",
+			cfg:  generate.Config{Source: "my-source", SourceKind: "postgres", Tables: []string{"missing"}},
+			want: `no tables found in source "my-source"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := generate.Generate(tc.cfg, testTables)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected error containing %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	tables := []sources.Table{{Name: "tags", Columns: []sources.Column{{Name: "name", Type: "TEXT"}}}}
+	r, err := generate.Generate(generate.Config{Source: "my-sqlite", SourceKind: "sqlite"}, tables)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	got, err := r.Marshal("my-sqlite")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := `tools:
+  list_tags:
+    kind: sqlite-sql
+    source: my-sqlite
+    description: Lists rows of the tags table, optionally only those matching the given column values.
+    statement: |
+      SELECT * FROM "tags"
+      WHERE (:name IS NULL OR "name" = :name)
+      LIMIT :limit
+    parameters:
+      - name: name
+        type: string
+        description: Only list rows with this name.
+        required: false
+      - name: limit
+        type: integer
+        description: The maximum number of rows to list.
+        default: 50
+  insert_tags:
+    kind: sqlite-sql
+    source: my-sqlite
+    description: Inserts a row into the tags table.
+    statement: |
+      INSERT INTO "tags" ("name")
+      VALUES (:name)
+      RETURNING *
+    parameters:
+      - name: name
+        type: string
+        description: The name of the new row.
+toolsets:
+  my-sqlite:
+    - list_tags
+    - insert_tags
+`
+	if diff := cmp.Diff(want, string(got)); diff != "" {
+		t.Fatalf("incorrect yaml: diff %v", diff)
+	}
+}
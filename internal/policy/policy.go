@@ -0,0 +1,141 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package policy restricts when, and on which environments, tools can be
+// invoked.
+package policy
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Weekdays are the names of the days of time windows, by time.Weekday.
+var Weekdays = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// Policy restricts the invocations of the tools it applies to. Invocations
+// must be in one of TimeWindows, if any, and use a source in one of
+// Environments, if any.
+type Policy struct {
+	Name        string
+	TimeWindows []TimeWindow
+	// Location is the time zone of the time windows.
+	Location     *time.Location
+	Environments []string
+}
+
+// TimeWindow is a daily window of time, from Start to End after midnight. A
+// window ending before it starts ends on the next day.
+type TimeWindow struct {
+	// Days are the days the window starts on, or every day if empty.
+	Days  []time.Weekday
+	Start time.Duration
+	End   time.Duration
+}
+
+// DeniedError is returned for invocations a policy doesn't allow.
+type DeniedError struct {
+	Policy string `json:"policy"`
+	Reason string `json:"reason"`
+}
+
+func (e *DeniedError) Error() string {
+	return fmt.Sprintf("denied by policy %q: %s", e.Policy, e.Reason)
+}
+
+// ParseTimeOfDay parses a time of day in the "15:04" format, as the duration
+// since midnight.
+func ParseTimeOfDay(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q: must be formatted as HH:MM", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// ParseWeekday parses the name of a day, such as "mon".
+func ParseWeekday(s string) (time.Weekday, error) {
+	i := slices.Index(Weekdays, strings.ToLower(s))
+	if i < 0 {
+		return 0, fmt.Errorf("invalid day %q: must be one of %q", s, Weekdays)
+	}
+	return time.Weekday(i), nil
+}
+
+// contains returns whether t, in the time zone of the window, is in w.
+func (w TimeWindow) contains(t time.Time) bool {
+	sinceMidnight := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+	day := t.Weekday()
+	if w.End > w.Start {
+		return sinceMidnight >= w.Start && sinceMidnight < w.End && w.onDay(day)
+	}
+	// the window ends on the day after it starts
+	if sinceMidnight >= w.Start {
+		return w.onDay(day)
+	}
+	return sinceMidnight < w.End && w.onDay((day+6)%7)
+}
+
+func (w TimeWindow) onDay(day time.Weekday) bool {
+	return len(w.Days) == 0 || slices.Contains(w.Days, day)
+}
+
+func (w TimeWindow) String() string {
+	s := fmt.Sprintf("%s-%s", formatTimeOfDay(w.Start), formatTimeOfDay(w.End))
+	if len(w.Days) == 0 {
+		return s
+	}
+	days := make([]string, 0, len(w.Days))
+	for _, d := range w.Days {
+		days = append(days, Weekdays[d])
+	}
+	return fmt.Sprintf("%s on %s", s, strings.Join(days, ","))
+}
+
+func formatTimeOfDay(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(d.Hours()), int(d.Minutes())%60)
+}
+
+// Check returns a DeniedError if p doesn't allow an invocation at now, using
+// a source in environment ("" if it has none).
+func (p Policy) Check(now time.Time, environment string) error {
+	if len(p.Environments) > 0 && !slices.Contains(p.Environments, environment) {
+		reason := fmt.Sprintf("only allowed on sources in environments %q", p.Environments)
+		if environment != "" {
+			reason += fmt.Sprintf(", not %q", environment)
+		}
+		return &DeniedError{Policy: p.Name, Reason: reason}
+	}
+	if len(p.TimeWindows) == 0 {
+		return nil
+	}
+	loc := p.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	local := now.In(loc)
+	windows := make([]string, 0, len(p.TimeWindows))
+	for _, w := range p.TimeWindows {
+		if w.contains(local) {
+			return nil
+		}
+		windows = append(windows, w.String())
+	}
+	return &DeniedError{
+		Policy: p.Name,
+		Reason: fmt.Sprintf("only allowed %s (%s), not at %s", strings.Join(windows, " or "), loc, local.Format("Mon 15:04")),
+	}
+}
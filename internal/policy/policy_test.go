@@ -0,0 +1,131 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/googleapis/genai-toolbox/internal/policy"
+)
+
+func TestCheck(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("unable to load location: %s", err)
+	}
+	// outside business hours: 18:00 to 08:00 on weekdays, and all weekend
+	offHours := policy.Policy{
+		Name: "off-hours",
+		TimeWindows: []policy.TimeWindow{
+			{Days: []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}, Start: 18 * time.Hour, End: 8 * time.Hour},
+			{Days: []time.Weekday{time.Saturday, time.Sunday}, Start: 0, End: 0},
+		},
+		Location: newYork,
+	}
+	nonProd := policy.Policy{Name: "non-prod", Environments: []string{"dev", "staging"}}
+
+	tcs := []struct {
+		desc        string
+		policy      policy.Policy
+		now         string
+		environment string
+		want        string
+	}{
+		{
+			desc:   "weekday evening",
+			policy: offHours,
+			now:    "2025-06-04T19:00:00-04:00",
+		},
+		{
+			desc:   "weekday night ends on the next day",
+			policy: offHours,
+			now:    "2025-06-05T07:59:00-04:00",
+		},
+		{
+			desc:   "saturday after a friday evening",
+			policy: offHours,
+			now:    "2025-06-07T07:00:00-04:00",
+		},
+		{
+			desc:   "weekday business hours",
+			policy: offHours,
+			now:    "2025-06-04T12:30:00-04:00",
+			want:   `denied by policy "off-hours": only allowed 18:00-08:00 on mon,tue,wed,thu,fri or 00:00-00:00 on sat,sun (America/New_York), not at Wed 12:30`,
+		},
+		{
+			desc:   "time zone of the policy",
+			policy: offHours,
+			// 12:30 in New York
+			now:  "2025-06-04T16:30:00Z",
+			want: `denied by policy "off-hours": only allowed 18:00-08:00 on mon,tue,wed,thu,fri or 00:00-00:00 on sat,sun (America/New_York), not at Wed 12:30`,
+		},
+		{
+			desc:        "allowed environment",
+			policy:      nonProd,
+			now:         "2025-06-04T12:30:00Z",
+			environment: "dev",
+		},
+		{
+			desc:        "denied environment",
+			policy:      nonProd,
+			now:         "2025-06-04T12:30:00Z",
+			environment: "prod",
+			want:        `denied by policy "non-prod": only allowed on sources in environments ["dev" "staging"], not "prod"`,
+		},
+		{
+			desc:   "source without environment",
+			policy: nonProd,
+			now:    "2025-06-04T12:30:00Z",
+			want:   `denied by policy "non-prod": only allowed on sources in environments ["dev" "staging"]`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			now, err := time.Parse(time.RFC3339, tc.now)
+			if err != nil {
+				t.Fatalf("unable to parse time: %s", err)
+			}
+			err = tc.policy.Check(now, tc.environment)
+			if tc.want == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			var deniedErr *policy.DeniedError
+			if !errors.As(err, &deniedErr) {
+				t.Fatalf("expected a DeniedError, got %v", err)
+			}
+			if err.Error() != tc.want {
+				t.Fatalf("unexpected error: got %q, want %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseTimeOfDay(t *testing.T) {
+	got, err := policy.ParseTimeOfDay("18:30")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := 18*time.Hour + 30*time.Minute; got != want {
+		t.Fatalf("unexpected time of day: got %s, want %s", got, want)
+	}
+	if _, err := policy.ParseTimeOfDay("6pm"); err == nil {
+		t.Fatalf("expected an error for an invalid time of day")
+	}
+}
@@ -26,11 +26,14 @@ func TestLoadPrebuiltToolYAMLs(t *testing.T) {
 		"alloydb-postgres-admin",
 		"alloydb-postgres",
 		"bigquery",
+		"cloud-sql-admin",
 		"cloud-sql-mssql",
 		"cloud-sql-mysql",
 		"cloud-sql-postgres",
 		"dataplex",
+		"db2",
 		"firestore",
+		"greenplum",
 		"looker",
 		"mssql",
 		"mysql",
@@ -38,6 +41,7 @@ func TestLoadPrebuiltToolYAMLs(t *testing.T) {
 		"postgres",
 		"spanner-postgres",
 		"spanner",
+		"vertica",
 	}
 	t.Run(test_name, func(t *testing.T) {
 		configsMap, keys, err := loadPrebuiltToolYAMLs()
@@ -73,17 +77,21 @@ func TestGetPrebuiltTool(t *testing.T) {
 	alloydb_admin_config, _ := Get("alloydb-postgres-admin")
 	alloydb_config, _ := Get("alloydb-postgres")
 	bigquery_config, _ := Get("bigquery")
+	cloudsqladmin_config, _ := Get("cloud-sql-admin")
 	cloudsqlpg_config, _ := Get("cloud-sql-postgres")
 	cloudsqlmysql_config, _ := Get("cloud-sql-mysql")
 	cloudsqlmssql_config, _ := Get("cloud-sql-mssql")
 	dataplex_config, _ := Get("dataplex")
+	db2_config, _ := Get("db2")
 	firestoreconfig, _ := Get("firestore")
+	greenplum_config, _ := Get("greenplum")
 	mysql_config, _ := Get("mysql")
 	mssql_config, _ := Get("mssql")
 	oceanbase_config, _ := Get("oceanbase")
 	postgresconfig, _ := Get("postgres")
 	spanner_config, _ := Get("spanner")
 	spannerpg_config, _ := Get("spanner-postgres")
+	vertica_config, _ := Get("vertica")
 	if len(alloydb_admin_config) <= 0 {
 		t.Fatalf("unexpected error: could not fetch alloydb prebuilt tools yaml")
 	}
@@ -93,6 +101,9 @@ func TestGetPrebuiltTool(t *testing.T) {
 	if len(bigquery_config) <= 0 {
 		t.Fatalf("unexpected error: could not fetch bigquery prebuilt tools yaml")
 	}
+	if len(cloudsqladmin_config) <= 0 {
+		t.Fatalf("unexpected error: could not fetch cloud sql admin prebuilt tools yaml")
+	}
 	if len(cloudsqlpg_config) <= 0 {
 		t.Fatalf("unexpected error: could not fetch cloud sql pg prebuilt tools yaml")
 	}
@@ -105,9 +116,15 @@ func TestGetPrebuiltTool(t *testing.T) {
 	if len(dataplex_config) <= 0 {
 		t.Fatalf("unexpected error: could not fetch dataplex prebuilt tools yaml")
 	}
+	if len(db2_config) <= 0 {
+		t.Fatalf("unexpected error: could not fetch db2 prebuilt tools yaml")
+	}
 	if len(firestoreconfig) <= 0 {
 		t.Fatalf("unexpected error: could not fetch firestore prebuilt tools yaml")
 	}
+	if len(greenplum_config) <= 0 {
+		t.Fatalf("unexpected error: could not fetch greenplum prebuilt tools yaml")
+	}
 	if len(mysql_config) <= 0 {
 		t.Fatalf("unexpected error: could not fetch mysql prebuilt tools yaml")
 	}
@@ -126,6 +143,9 @@ func TestGetPrebuiltTool(t *testing.T) {
 	if len(spannerpg_config) <= 0 {
 		t.Fatalf("unexpected error: could not fetch spanner pg prebuilt tools yaml")
 	}
+	if len(vertica_config) <= 0 {
+		t.Fatalf("unexpected error: could not fetch vertica prebuilt tools yaml")
+	}
 }
 
 func TestFailGetPrebuiltTool(t *testing.T) {
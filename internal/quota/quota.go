@@ -0,0 +1,232 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package quota limits the invocations of tools, and the bytes of their
+// results, per caller and per day.
+package quota
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Quota is a daily budget of invocations and result bytes, shared by the
+// tools it applies to. Each caller has a budget of its own. A limit of zero
+// is unlimited.
+type Quota struct {
+	Name              string
+	InvocationsPerDay int64
+	ResultBytesPerDay int64
+}
+
+// ExceededError is returned for invocations by a caller that exhausted a
+// quota.
+type ExceededError struct {
+	Quota  string `json:"quota"`
+	Caller string `json:"caller"`
+	// Limit is the limit that was reached, either "invocationsPerDay" or
+	// "resultBytesPerDay".
+	Limit   string    `json:"limit"`
+	Max     int64     `json:"max"`
+	ResetAt time.Time `json:"resetAt"`
+}
+
+func (e *ExceededError) Error() string {
+	caller := e.Caller
+	if caller == "" {
+		caller = "anonymous callers"
+	}
+	return fmt.Sprintf("quota %q is exceeded for %s: %s is %d, resets at %s", e.Quota, caller, e.Limit, e.Max, e.ResetAt.Format(time.RFC3339))
+}
+
+// Limiter counts the usage of quotas in a Store. Should be initialized with
+// NewLimiter().
+type Limiter struct {
+	store Store
+	now   func() time.Time
+}
+
+// NewLimiter returns a Limiter counting usage in store.
+func NewLimiter(store Store) *Limiter {
+	return &Limiter{store: store, now: time.Now}
+}
+
+// window returns the day of t, in UTC, and when it ends.
+func window(t time.Time) (string, time.Time) {
+	day := t.UTC().Truncate(24 * time.Hour)
+	return day.Format(time.DateOnly), day.Add(24 * time.Hour)
+}
+
+func key(q Quota, day, counter, caller string) string {
+	return fmt.Sprintf("toolbox:quota:%s:%s:%s:%s", q.Name, day, counter, caller)
+}
+
+// Admit counts an invocation by caller against each of quotas. It returns an
+// ExceededError, without counting the invocation, if any of them is
+// exhausted. The remaining budget is recorded in the Status of ctx, if any.
+func (l *Limiter) Admit(ctx context.Context, quotas []Quota, caller string) error {
+	day, resetAt := window(l.now())
+	status := StatusFromContext(ctx)
+	// check the bytes first, since they aren't counted until the invocation
+	// returns
+	for _, q := range quotas {
+		if q.ResultBytesPerDay <= 0 {
+			continue
+		}
+		used, err := l.store.Add(ctx, key(q, day, "bytes", caller), 0, resetAt)
+		if err != nil {
+			return fmt.Errorf("unable to check quota %q: %w", q.Name, err)
+		}
+		if used >= q.ResultBytesPerDay {
+			status.recordBytes(0, resetAt)
+			return &ExceededError{Quota: q.Name, Caller: caller, Limit: "resultBytesPerDay", Max: q.ResultBytesPerDay, ResetAt: resetAt}
+		}
+		status.recordBytes(q.ResultBytesPerDay-used, resetAt)
+	}
+	var counted []Quota
+	for _, q := range quotas {
+		if q.InvocationsPerDay <= 0 {
+			continue
+		}
+		used, err := l.store.Add(ctx, key(q, day, "invocations", caller), 1, resetAt)
+		if err != nil {
+			l.uncount(ctx, counted, day, caller, resetAt)
+			return fmt.Errorf("unable to check quota %q: %w", q.Name, err)
+		}
+		counted = append(counted, q)
+		if used > q.InvocationsPerDay {
+			l.uncount(ctx, counted, day, caller, resetAt)
+			status.recordInvocations(0, resetAt)
+			return &ExceededError{Quota: q.Name, Caller: caller, Limit: "invocationsPerDay", Max: q.InvocationsPerDay, ResetAt: resetAt}
+		}
+		status.recordInvocations(q.InvocationsPerDay-used, resetAt)
+	}
+	return nil
+}
+
+// uncount removes an invocation that was rejected from quotas.
+func (l *Limiter) uncount(ctx context.Context, quotas []Quota, day, caller string, resetAt time.Time) {
+	for _, q := range quotas {
+		// the invocation is counted anyway if this fails, which is the
+		// safe side of the budget
+		_, _ = l.store.Add(ctx, key(q, day, "invocations", caller), -1, resetAt)
+	}
+}
+
+// AddResultBytes counts the bytes of the result of an invocation by caller
+// against each of quotas. The remaining budget is recorded in the Status of
+// ctx, if any.
+func (l *Limiter) AddResultBytes(ctx context.Context, quotas []Quota, caller string, n int64) error {
+	day, resetAt := window(l.now())
+	status := StatusFromContext(ctx)
+	for _, q := range quotas {
+		if q.ResultBytesPerDay <= 0 {
+			continue
+		}
+		used, err := l.store.Add(ctx, key(q, day, "bytes", caller), n, resetAt)
+		if err != nil {
+			return fmt.Errorf("unable to update quota %q: %w", q.Name, err)
+		}
+		status.recordBytes(max(q.ResultBytesPerDay-used, 0), resetAt)
+	}
+	return nil
+}
+
+// Status is the most constrained remaining budget of the quotas applied to a
+// request. Its methods are safe to call on a nil Status, in which case they
+// do nothing.
+type Status struct {
+	mu                   sync.Mutex
+	remainingInvocations *int64
+	remainingBytes       *int64
+	resetAt              time.Time
+}
+
+// recordInvocations lowers the remaining invocations to n.
+func (s *Status) recordInvocations(n int64, resetAt time.Time) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.remainingInvocations == nil || n < *s.remainingInvocations {
+		s.remainingInvocations = &n
+	}
+	s.resetAt = resetAt
+}
+
+// recordBytes lowers the remaining result bytes to n.
+func (s *Status) recordBytes(n int64, resetAt time.Time) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.remainingBytes == nil || n < *s.remainingBytes {
+		s.remainingBytes = &n
+	}
+	s.resetAt = resetAt
+}
+
+// Remaining returns the invocations and result bytes remaining, or -1 for
+// those no quota limits, and when the quotas reset. It returns false if no
+// quota was applied.
+func (s *Status) Remaining() (invocations, bytes int64, resetAt time.Time, ok bool) {
+	if s == nil {
+		return 0, 0, time.Time{}, false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.remainingInvocations == nil && s.remainingBytes == nil {
+		return 0, 0, time.Time{}, false
+	}
+	invocations, bytes = -1, -1
+	if s.remainingInvocations != nil {
+		invocations = *s.remainingInvocations
+	}
+	if s.remainingBytes != nil {
+		bytes = *s.remainingBytes
+	}
+	return invocations, bytes, s.resetAt, true
+}
+
+type statusKey struct{}
+
+// WithStatus returns a context recording the quota status of a request.
+func WithStatus(ctx context.Context) context.Context {
+	return context.WithValue(ctx, statusKey{}, &Status{})
+}
+
+// StatusFromContext returns the quota status of the request of ctx, or nil if
+// it isn't recorded.
+func StatusFromContext(ctx context.Context) *Status {
+	status, _ := ctx.Value(statusKey{}).(*Status)
+	return status
+}
+
+type limiterKey struct{}
+
+// WithLimiter adds the Limiter counting the usage of quotas to the context,
+// so that it is shared by every configuration the server reloads.
+func WithLimiter(ctx context.Context, l *Limiter) context.Context {
+	return context.WithValue(ctx, limiterKey{}, l)
+}
+
+// LimiterFromContext returns the Limiter of ctx, or nil if there is none.
+func LimiterFromContext(ctx context.Context) *Limiter {
+	l, _ := ctx.Value(limiterKey{}).(*Limiter)
+	return l
+}
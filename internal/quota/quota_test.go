@@ -0,0 +1,115 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quota
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLimiter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 23, 0, 0, 0, time.UTC)
+	store := NewMemoryStore().(*memoryStore)
+	l := NewLimiter(store)
+	l.now = func() time.Time { return now }
+	store.now = l.now
+	quotas := []Quota{
+		{Name: "calls", InvocationsPerDay: 2},
+		{Name: "bytes", ResultBytesPerDay: 100},
+	}
+	ctx := context.Background()
+	resetAt := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	statusCtx := WithStatus(ctx)
+	if err := l.Admit(statusCtx, quotas, "alice"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := l.AddResultBytes(statusCtx, quotas, "alice", 60); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	invocations, bytes, gotReset, ok := StatusFromContext(statusCtx).Remaining()
+	if !ok || invocations != 1 || bytes != 40 || !gotReset.Equal(resetAt) {
+		t.Fatalf("unexpected status: %d invocations, %d bytes, reset at %s, %t", invocations, bytes, gotReset, ok)
+	}
+
+	// the byte budget isn't exhausted until the result is counted
+	if err := l.Admit(ctx, quotas, "alice"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := l.AddResultBytes(ctx, quotas, "alice", 60); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var exceeded *ExceededError
+	err := l.Admit(ctx, quotas, "alice")
+	if !errors.As(err, &exceeded) || exceeded.Quota != "bytes" || exceeded.Limit != "resultBytesPerDay" || !exceeded.ResetAt.Equal(resetAt) {
+		t.Fatalf("expected the byte quota to be exceeded, got %v", err)
+	}
+
+	// other callers have budgets of their own
+	if err := l.Admit(ctx, quotas, "bob"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := l.Admit(ctx, quotas[:1], "bob"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	err = l.Admit(ctx, quotas[:1], "bob")
+	if !errors.As(err, &exceeded) || exceeded.Quota != "calls" || exceeded.Limit != "invocationsPerDay" {
+		t.Fatalf("expected the invocation quota to be exceeded, got %v", err)
+	}
+
+	// quotas reset every day
+	now = now.Add(2 * time.Hour)
+	if err := l.Admit(ctx, quotas, "alice"); err != nil {
+		t.Fatalf("unexpected error after reset: %s", err)
+	}
+}
+
+func TestAdmitUncountsRejected(t *testing.T) {
+	l := NewLimiter(NewMemoryStore())
+	quotas := []Quota{
+		{Name: "wide", InvocationsPerDay: 10},
+		{Name: "narrow", InvocationsPerDay: 1},
+	}
+	ctx := context.Background()
+	if err := l.Admit(ctx, quotas, ""); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := l.Admit(ctx, quotas, ""); err == nil {
+			t.Fatalf("expected the narrow quota to be exceeded")
+		}
+	}
+	// rejected invocations don't use the budget of the wide quota
+	statusCtx := WithStatus(ctx)
+	if err := l.Admit(statusCtx, quotas[:1], ""); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if invocations, _, _, _ := StatusFromContext(statusCtx).Remaining(); invocations != 8 {
+		t.Fatalf("unexpected remaining invocations: got %d, want 8", invocations)
+	}
+}
+
+func TestNewStore(t *testing.T) {
+	for _, url := range []string{"", "memory", "redis://localhost:6379/0"} {
+		if _, err := NewStore(url); err != nil {
+			t.Errorf("unexpected error for %q: %s", url, err)
+		}
+	}
+	if _, err := NewStore("postgres://localhost"); err == nil {
+		t.Errorf("expected an error for an unsupported store")
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quota
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Store persists the counters of quotas.
+type Store interface {
+	// Add adds n to the counter of key, which expires at expiry, and returns
+	// its new value. Counters start at zero.
+	Add(ctx context.Context, key string, n int64, expiry time.Time) (int64, error)
+}
+
+// NewStore returns the Store of a URL: a Redis URL, e.g.
+// redis://localhost:6379/0, or "memory" or an empty string for a store in
+// memory, which is lost when the server stops and isn't shared between
+// servers.
+func NewStore(url string) (Store, error) {
+	switch {
+	case url == "" || url == "memory":
+		return NewMemoryStore(), nil
+	case strings.HasPrefix(url, "redis://") || strings.HasPrefix(url, "rediss://"):
+		opts, err := redis.ParseURL(url)
+		if err != nil {
+			return nil, fmt.Errorf("invalid quota store URL: %w", err)
+		}
+		return &redisStore{client: redis.NewClient(opts)}, nil
+	default:
+		return nil, fmt.Errorf("invalid quota store %q: must be 'memory' or a redis:// or rediss:// URL", url)
+	}
+}
+
+// memoryStore is a Store in memory.
+type memoryStore struct {
+	mu       sync.Mutex
+	counters map[string]*memoryCounter
+	now      func() time.Time
+}
+
+type memoryCounter struct {
+	value  int64
+	expiry time.Time
+}
+
+// NewMemoryStore returns a Store in memory.
+func NewMemoryStore() Store {
+	return &memoryStore{counters: make(map[string]*memoryCounter), now: time.Now}
+}
+
+func (s *memoryStore) Add(_ context.Context, key string, n int64, expiry time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	c, ok := s.counters[key]
+	if !ok || !now.Before(c.expiry) {
+		// counters of past days are dropped as the first counter of a day
+		// is created
+		if !ok {
+			for k, old := range s.counters {
+				if !now.Before(old.expiry) {
+					delete(s.counters, k)
+				}
+			}
+		}
+		c = &memoryCounter{expiry: expiry}
+		s.counters[key] = c
+	}
+	c.value += n
+	return c.value, nil
+}
+
+// redisStore is a Store in Redis, shared by every server using it.
+type redisStore struct {
+	client *redis.Client
+}
+
+func (s *redisStore) Add(ctx context.Context, key string, n int64, expiry time.Time) (int64, error) {
+	pipe := s.client.TxPipeline()
+	incr := pipe.IncrBy(ctx, key, n)
+	pipe.ExpireAt(ctx, key, expiry)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
+}
@@ -0,0 +1,296 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/googleapis/genai-toolbox/internal/server/mcp/jsonrpc"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+// defaultMaintenanceMessage is returned in maintenance mode when no message
+// was set.
+const defaultMaintenanceMessage = "Toolbox is under maintenance, please retry later"
+
+// DisabledError is returned for calls to a tool that is disabled, either
+// itself or through its source.
+type DisabledError struct {
+	Tool string `json:"tool"`
+	// Source is set when the tool is disabled because its source is.
+	Source string `json:"source,omitempty"`
+}
+
+func (e *DisabledError) Error() string {
+	if e.Source != "" {
+		return fmt.Sprintf("tool %q is disabled because its source %q is disabled", e.Tool, e.Source)
+	}
+	return fmt.Sprintf("tool %q is disabled", e.Tool)
+}
+
+// switchableTool is a tool that can be disabled, along with whether the
+// configuration enables it and its source.
+type switchableTool struct {
+	tools.Tool
+	name          string
+	source        string
+	enabled       bool
+	sourceEnabled bool
+}
+
+// SourceName returns the name of the source of the tool, if any.
+func (t switchableTool) SourceName() string {
+	return t.source
+}
+
+var _ tools.SourceTool = switchableTool{}
+
+// disabledSourceConfig is the config of a source with `enabled: false`. The
+// source is initialized, so that it can be enabled at runtime.
+type disabledSourceConfig struct {
+	sources.SourceConfig
+}
+
+// toolSourceName returns the name of the source of the tool of cfg, or "" if
+// it has none. Every kind of tool using a source names it in its Source field.
+func toolSourceName(cfg tools.ToolConfig) string {
+	if oc, ok := cfg.(tools.ConfigWithOptions); ok {
+		cfg = oc.ToolConfig
+	}
+	v := reflect.Indirect(reflect.ValueOf(cfg))
+	if v.Kind() != reflect.Struct {
+		return ""
+	}
+	f := v.FieldByName("Source")
+	if !f.IsValid() || f.Kind() != reflect.String {
+		return ""
+	}
+	return f.String()
+}
+
+// switches are the states of the server that operators change at runtime,
+// with the admin endpoints, rather than in the configuration. They are kept
+// across reloads.
+type switches struct {
+	mu sync.RWMutex
+	// maintenance is the message returned to every request in maintenance
+	// mode, which is off if it's empty.
+	maintenance string
+	// tools and sources override whether the configuration enables them.
+	tools   map[string]bool
+	sources map[string]bool
+}
+
+func newSwitches() *switches {
+	return &switches{tools: make(map[string]bool), sources: make(map[string]bool)}
+}
+
+func (sw *switches) maintenanceMessage() string {
+	sw.mu.RLock()
+	defer sw.mu.RUnlock()
+	return sw.maintenance
+}
+
+func (sw *switches) setMaintenance(enabled bool, message string) {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	switch {
+	case !enabled:
+		sw.maintenance = ""
+	case message == "":
+		sw.maintenance = defaultMaintenanceMessage
+	default:
+		sw.maintenance = message
+	}
+}
+
+func (sw *switches) setTool(name string, enabled bool) {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	sw.tools[name] = enabled
+}
+
+func (sw *switches) setSource(name string, enabled bool) {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	sw.sources[name] = enabled
+}
+
+// toolDisabled returns a DisabledError if t, or its source, is disabled, or
+// nil otherwise.
+func (sw *switches) toolDisabled(t tools.Tool) error {
+	st, ok := t.(switchableTool)
+	if !ok {
+		return nil
+	}
+	sw.mu.RLock()
+	defer sw.mu.RUnlock()
+	if enabled, ok := sw.tools[st.name]; ok && !enabled || !ok && !st.enabled {
+		return &DisabledError{Tool: st.name}
+	}
+	if st.source == "" {
+		return nil
+	}
+	if enabled, ok := sw.sources[st.source]; ok && !enabled || !ok && !st.sourceEnabled {
+		return &DisabledError{Tool: st.name, Source: st.source}
+	}
+	return nil
+}
+
+// enabledTools returns the toolset with only the tools of resources that
+// aren't disabled.
+func (sw *switches) enabledTools(resources *ResourceSnapshot, toolset tools.Toolset) tools.Toolset {
+	return toolset.Filter(func(name string, _ tools.Manifest) bool {
+		t, ok := resources.GetTool(name)
+		return ok && sw.toolDisabled(t) == nil
+	})
+}
+
+// maintenanceMiddleware responds to every request with a 503 status in
+// maintenance mode, using respond to write the error in the format of the
+// router.
+func (sw *switches) maintenanceMiddleware(respond func(w http.ResponseWriter, r *http.Request, err error)) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if msg := sw.maintenanceMessage(); msg != "" {
+				respond(w, r, fmt.Errorf("%s", msg))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// adminRouter creates a router that represents the routes under /admin,
+// which require the admin token of the server.
+func adminRouter(s *Server, token string) (chi.Router, error) {
+	if token == "" {
+		return nil, fmt.Errorf("admin endpoints require a token")
+	}
+	r := chi.NewRouter()
+
+	r.Use(middleware.StripSlashes)
+	r.Use(render.SetContentType(render.ContentTypeJSON))
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+				_ = render.Render(w, r, newErrResponse(fmt.Errorf("invalid or missing admin token"), http.StatusUnauthorized))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	})
+
+	r.Get("/", func(w http.ResponseWriter, r *http.Request) { adminStateHandler(s, w, r) })
+	r.Put("/maintenance", func(w http.ResponseWriter, r *http.Request) { adminMaintenanceHandler(s, w, r) })
+	r.Put("/tool/{name}", func(w http.ResponseWriter, r *http.Request) { adminSwitchHandler(s, w, r, "tool") })
+	r.Put("/source/{name}", func(w http.ResponseWriter, r *http.Request) { adminSwitchHandler(s, w, r, "source") })
+
+	return r, nil
+}
+
+// adminState is the response of the admin endpoints.
+type adminState struct {
+	Maintenance struct {
+		Enabled bool   `json:"enabled"`
+		Message string `json:"message,omitempty"`
+	} `json:"maintenance"`
+	// Disabled lists the tools that are disabled, sorted by name.
+	Disabled []*DisabledError `json:"disabled"`
+}
+
+// adminStateHandler responds with the state of the switches for the current
+// resources.
+func adminStateHandler(s *Server, w http.ResponseWriter, r *http.Request) {
+	var state adminState
+	state.Maintenance.Message = s.switches.maintenanceMessage()
+	state.Maintenance.Enabled = state.Maintenance.Message != ""
+
+	resources := s.ResourceMgr.Snapshot()
+	state.Disabled = []*DisabledError{}
+	toolsMap := resources.GetToolsMap()
+	for _, name := range sortedKeys(toolsMap) {
+		if err := s.switches.toolDisabled(toolsMap[name]); err != nil {
+			state.Disabled = append(state.Disabled, err.(*DisabledError))
+		}
+	}
+	render.JSON(w, r, state)
+}
+
+// adminMaintenanceHandler turns maintenance mode on or off.
+func adminMaintenanceHandler(s *Server, w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Enabled *bool  `json:"enabled"`
+		Message string `json:"message"`
+	}
+	if err := util.DecodeJSON(r.Body, &body); err != nil || body.Enabled == nil {
+		err = fmt.Errorf("request body must be a JSON object with a boolean 'enabled' field")
+		_ = render.Render(w, r, newErrResponse(err, http.StatusBadRequest))
+		return
+	}
+	s.switches.setMaintenance(*body.Enabled, body.Message)
+	s.logger.InfoContext(r.Context(), fmt.Sprintf("maintenance mode enabled: %t", *body.Enabled))
+	adminStateHandler(s, w, r)
+}
+
+// adminSwitchHandler enables or disables the tool or source named in the
+// path, depending on kind.
+func adminSwitchHandler(s *Server, w http.ResponseWriter, r *http.Request, kind string) {
+	name := chi.URLParam(r, "name")
+	var body struct {
+		Enabled *bool `json:"enabled"`
+	}
+	if err := util.DecodeJSON(r.Body, &body); err != nil || body.Enabled == nil {
+		err = fmt.Errorf("request body must be a JSON object with a boolean 'enabled' field")
+		_ = render.Render(w, r, newErrResponse(err, http.StatusBadRequest))
+		return
+	}
+
+	resources := s.ResourceMgr.Snapshot()
+	switch kind {
+	case "tool":
+		if _, ok := resources.GetTool(name); !ok {
+			_ = render.Render(w, r, newErrResponse(fmt.Errorf("tool %q does not exist", name), http.StatusNotFound))
+			return
+		}
+		s.switches.setTool(name, *body.Enabled)
+	case "source":
+		if _, ok := resources.GetSource(name); !ok {
+			_ = render.Render(w, r, newErrResponse(fmt.Errorf("source %q does not exist", name), http.StatusNotFound))
+			return
+		}
+		s.switches.setSource(name, *body.Enabled)
+	}
+	s.logger.InfoContext(r.Context(), fmt.Sprintf("%s %q enabled: %t", kind, name, *body.Enabled))
+	adminStateHandler(s, w, r)
+}
+
+// maintenanceResponse writes the error of the maintenance mode to MCP
+// clients.
+func maintenanceResponse(w http.ResponseWriter, r *http.Request, err error) {
+	render.Status(r, http.StatusServiceUnavailable)
+	render.JSON(w, r, jsonrpc.NewError(nil, jsonrpc.INTERNAL_ERROR, err.Error(), nil))
+}
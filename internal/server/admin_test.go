@@ -0,0 +1,392 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server/mcp/jsonrpc"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/sqlite"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+type sourceToolConfig struct {
+	Name   string
+	Source string
+}
+
+func (c sourceToolConfig) ToolConfigKind() string {
+	return "mock"
+}
+
+func (c sourceToolConfig) Initialize(map[string]sources.Source) (tools.Tool, error) {
+	return nil, nil
+}
+
+type noSourceToolConfig struct{}
+
+func (c noSourceToolConfig) ToolConfigKind() string {
+	return "mock"
+}
+
+func (c noSourceToolConfig) Initialize(map[string]sources.Source) (tools.Tool, error) {
+	return nil, nil
+}
+
+func TestToolSourceName(t *testing.T) {
+	enabled := true
+	tcs := []struct {
+		desc string
+		cfg  tools.ToolConfig
+		want string
+	}{
+		{
+			desc: "source",
+			cfg:  sourceToolConfig{Name: "my-tool", Source: "my-source"},
+			want: "my-source",
+		},
+		{
+			desc: "source with options",
+			cfg:  tools.WithOptions(sourceToolConfig{Source: "my-source"}, tools.Options{Enabled: &enabled}),
+			want: "my-source",
+		},
+		{
+			desc: "pointer",
+			cfg:  &sourceToolConfig{Source: "my-source"},
+			want: "my-source",
+		},
+		{
+			desc: "no source",
+			cfg:  noSourceToolConfig{},
+			want: "",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := toolSourceName(tc.cfg); got != tc.want {
+				t.Fatalf("unexpected source: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+// setUpAdminServer serves the api, mcp and admin routes of a server with
+// tool1, and tool2 disabled by the configuration, both using "my-source"
+func setUpAdminServer(t *testing.T) (chi.Router, func()) {
+	toolsMap, toolsets := setUpResources(t, []MockTool{tool1, tool2})
+	toolsMap[tool1.Name] = switchableTool{Tool: toolsMap[tool1.Name], name: tool1.Name, source: "my-source", enabled: true, sourceEnabled: true}
+	toolsMap[tool2.Name] = switchableTool{Tool: toolsMap[tool2.Name], name: tool2.Name, source: "my-source", enabled: false, sourceEnabled: true}
+	resourceManager := NewResourceManager(map[string]sources.Source{"my-source": nil}, nil, toolsMap, toolsets)
+	s, shutdown := newTestServer(t, resourceManager)
+
+	r := chi.NewRouter()
+	apiR, err := apiRouter(s)
+	if err != nil {
+		t.Fatalf("unable to initialize api router: %s", err)
+	}
+	r.Mount("/api", apiR)
+	mcpR, err := mcpRouter(s)
+	if err != nil {
+		t.Fatalf("unable to initialize mcp router: %s", err)
+	}
+	r.Mount("/mcp", mcpR)
+	adminR, err := adminRouter(s, "admin-token")
+	if err != nil {
+		t.Fatalf("unable to initialize admin router: %s", err)
+	}
+	r.Mount("/admin", adminR)
+	return r, shutdown
+}
+
+func TestAdminEndpoints(t *testing.T) {
+	r, shutdown := setUpAdminServer(t)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	admin := map[string]string{"Authorization": "Bearer admin-token"}
+	steps := []struct {
+		desc     string
+		method   string
+		path     string
+		body     string
+		header   map[string]string
+		wantCode int
+		want     map[string]any
+	}{
+		{
+			desc:     "admin requires the token",
+			method:   http.MethodGet,
+			path:     "/admin",
+			header:   map[string]string{"Authorization": "Bearer nope"},
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			desc:     "tool disabled by the configuration",
+			method:   http.MethodGet,
+			path:     "/admin",
+			header:   admin,
+			wantCode: http.StatusOK,
+			want: map[string]any{
+				"maintenance": map[string]any{"enabled": false},
+				"disabled":    []any{map[string]any{"tool": tool2.Name}},
+			},
+		},
+		{
+			desc:     "disabled tool can't be invoked",
+			method:   http.MethodPost,
+			path:     "/api/tool/" + tool2.Name + "/invoke",
+			body:     `{"param1": 1, "param2": 2}`,
+			wantCode: http.StatusServiceUnavailable,
+		},
+		{
+			desc:     "enable tool",
+			method:   http.MethodPut,
+			path:     "/admin/tool/" + tool2.Name,
+			body:     `{"enabled": true}`,
+			header:   admin,
+			wantCode: http.StatusOK,
+			want: map[string]any{
+				"maintenance": map[string]any{"enabled": false},
+				"disabled":    []any{},
+			},
+		},
+		{
+			desc:     "enabled tool can be invoked",
+			method:   http.MethodPost,
+			path:     "/api/tool/" + tool2.Name + "/invoke",
+			body:     `{"param1": 1, "param2": 2}`,
+			wantCode: http.StatusOK,
+		},
+		{
+			desc:     "disable source",
+			method:   http.MethodPut,
+			path:     "/admin/source/my-source",
+			body:     `{"enabled": false}`,
+			header:   admin,
+			wantCode: http.StatusOK,
+			want: map[string]any{
+				"maintenance": map[string]any{"enabled": false},
+				"disabled": []any{
+					map[string]any{"tool": tool1.Name, "source": "my-source"},
+					map[string]any{"tool": tool2.Name, "source": "my-source"},
+				},
+			},
+		},
+		{
+			desc:     "tool of disabled source can't be invoked",
+			method:   http.MethodPost,
+			path:     "/api/tool/" + tool1.Name + "/invoke",
+			body:     `{}`,
+			wantCode: http.StatusServiceUnavailable,
+		},
+		{
+			desc:     "enable source",
+			method:   http.MethodPut,
+			path:     "/admin/source/my-source",
+			body:     `{"enabled": true}`,
+			header:   admin,
+			wantCode: http.StatusOK,
+		},
+		{
+			desc:     "unknown tool",
+			method:   http.MethodPut,
+			path:     "/admin/tool/nope",
+			body:     `{"enabled": false}`,
+			header:   admin,
+			wantCode: http.StatusNotFound,
+		},
+		{
+			desc:     "missing enabled",
+			method:   http.MethodPut,
+			path:     "/admin/tool/" + tool1.Name,
+			body:     `{}`,
+			header:   admin,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			desc:     "enable maintenance",
+			method:   http.MethodPut,
+			path:     "/admin/maintenance",
+			body:     `{"enabled": true, "message": "back at noon"}`,
+			header:   admin,
+			wantCode: http.StatusOK,
+			want: map[string]any{
+				"maintenance": map[string]any{"enabled": true, "message": "back at noon"},
+				"disabled":    []any{},
+			},
+		},
+		{
+			desc:     "api in maintenance",
+			method:   http.MethodGet,
+			path:     "/api/toolset",
+			wantCode: http.StatusServiceUnavailable,
+			want:     map[string]any{"status": "Service Unavailable", "error": "back at noon"},
+		},
+		{
+			desc:     "mcp in maintenance",
+			method:   http.MethodPost,
+			path:     "/mcp",
+			body:     `{"jsonrpc": "2.0", "id": "tools-list", "method": "tools/list"}`,
+			wantCode: http.StatusServiceUnavailable,
+			want: map[string]any{
+				"jsonrpc": "2.0",
+				"id":      nil,
+				"error":   map[string]any{"code": float64(jsonrpc.INTERNAL_ERROR), "message": "back at noon"},
+			},
+		},
+		{
+			desc:     "disable maintenance",
+			method:   http.MethodPut,
+			path:     "/admin/maintenance",
+			body:     `{"enabled": false}`,
+			header:   admin,
+			wantCode: http.StatusOK,
+		},
+		{
+			desc:     "api after maintenance",
+			method:   http.MethodGet,
+			path:     "/api/toolset",
+			wantCode: http.StatusOK,
+		},
+	}
+	for _, step := range steps {
+		resp, body, err := runRequest(ts, step.method, step.path, bytes.NewBufferString(step.body), step.header)
+		if err != nil {
+			t.Fatalf("%s: unexpected error during request: %s", step.desc, err)
+		}
+		if resp.StatusCode != step.wantCode {
+			t.Fatalf("%s: unexpected status code: got %d, want %d: %s", step.desc, resp.StatusCode, step.wantCode, string(body))
+		}
+		if step.want == nil {
+			continue
+		}
+		var got map[string]any
+		if err := json.Unmarshal(body, &got); err != nil {
+			t.Fatalf("%s: unable to parse response: %s", step.desc, err)
+		}
+		if diff := cmp.Diff(step.want, got); diff != "" {
+			t.Fatalf("%s: incorrect response: diff %v", step.desc, diff)
+		}
+	}
+}
+
+func TestDisabledToolNotListed(t *testing.T) {
+	r, shutdown := setUpAdminServer(t)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	resp, body, err := runRequest(ts, http.MethodGet, "/api/toolset", nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status code: got %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	var manifest tools.ToolsetManifest
+	if err := json.Unmarshal(body, &manifest); err != nil {
+		t.Fatalf("unable to parse manifest: %s", err)
+	}
+	if _, ok := manifest.ToolsManifest[tool2.Name]; ok {
+		t.Fatalf("disabled tool %q was listed", tool2.Name)
+	}
+	if _, ok := manifest.ToolsManifest[tool1.Name]; !ok {
+		t.Fatalf("enabled tool %q was not listed", tool1.Name)
+	}
+
+	reqMarshal, err := json.Marshal(map[string]any{
+		"jsonrpc": jsonrpcVersion,
+		"id":      "tools-call",
+		"method":  "tools/call",
+		"params":  map[string]any{"name": tool2.Name, "arguments": map[string]any{"param1": 1, "param2": 2}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error during marshaling of body")
+	}
+	_, body, err = runRequest(ts, http.MethodPost, "/mcp", bytes.NewBuffer(reqMarshal), nil)
+	if err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	var got struct {
+		Error struct {
+			Code float64 `json:"code"`
+			Data struct {
+				Disabled DisabledError `json:"disabled"`
+			} `json:"data"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatalf("unable to parse response: %s", err)
+	}
+	if got.Error.Code != jsonrpc.INVALID_REQUEST {
+		t.Fatalf("unexpected error code: got %v, want %v", got.Error.Code, jsonrpc.INVALID_REQUEST)
+	}
+	if diff := cmp.Diff(DisabledError{Tool: tool2.Name}, got.Error.Data.Disabled); diff != "" {
+		t.Fatalf("incorrect disabled error: diff %v", diff)
+	}
+}
+
+func TestParseDisabledSource(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	in := `
+	sources:
+		disabled-source:
+			kind: sqlite
+			database: my.db
+			enabled: false
+		enabled-source:
+			kind: sqlite
+			database: my.db
+			enabled: true
+	`
+	var got struct {
+		Sources SourceConfigs `yaml:"sources"`
+	}
+	if err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(in), &got); err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+	want := SourceConfigs{
+		"disabled-source": disabledSourceConfig{SourceConfig: sqlite.Config{Name: "disabled-source", Kind: "sqlite", Database: "my.db"}},
+		"enabled-source":  sqlite.Config{Name: "enabled-source", Kind: "sqlite", Database: "my.db"},
+	}
+	if diff := cmp.Diff(want, got.Sources); diff != "" {
+		t.Fatalf("incorrect parse: diff %v", diff)
+	}
+
+	invalid := `
+	sources:
+		my-source:
+			kind: sqlite
+			database: my.db
+			enabled: "no"
+	`
+	err = yaml.UnmarshalContext(ctx, testutils.FormatYaml(invalid), &got)
+	if err == nil || !strings.Contains(err.Error(), `invalid 'enabled' field for source "my-source" (must be a boolean)`) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
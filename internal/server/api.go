@@ -15,13 +15,20 @@
 package server
 
 import (
-	"encoding/json"
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"slices"
+	"strconv"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
+	"github.com/googleapis/genai-toolbox/internal/policy"
+	"github.com/googleapis/genai-toolbox/internal/quota"
 	"github.com/googleapis/genai-toolbox/internal/tools"
 	"github.com/googleapis/genai-toolbox/internal/util"
 	"go.opentelemetry.io/otel/attribute"
@@ -29,6 +36,53 @@ import (
 	"go.opentelemetry.io/otel/metric"
 )
 
+// apiVersionHeader is the request header selecting the version of the API
+// served under the unversioned /api routes, and the response header with the
+// version that served a request.
+const apiVersionHeader = "Toolbox-Api-Version"
+
+// apiV1 is the first version of the API. Its routes are served under
+// /api/v1, and under the unversioned /api routes for older clients.
+const apiV1 = "v1"
+
+// apiVersions are the supported versions of the API, oldest first. A change
+// to the responses of the API is made in a new version, so that the
+// responses of the older versions are kept.
+var apiVersions = []string{apiV1}
+
+// defaultAPIVersion is the version served under the unversioned /api routes
+// when the request has no apiVersionHeader. It is the version these routes
+// served before the API was versioned.
+const defaultAPIVersion = apiV1
+
+// apiVersionMiddleware checks the version of the API requested by the
+// apiVersionHeader, and sets the header of the response to the version
+// serving the request: pathVersion for the versioned routes, and the
+// requested version otherwise.
+func apiVersionMiddleware(pathVersion string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			version := r.Header.Get(apiVersionHeader)
+			switch {
+			case pathVersion != "" && version != "" && version != pathVersion:
+				err := fmt.Errorf("%s header %q does not match the API version %q of the path", apiVersionHeader, version, pathVersion)
+				_ = render.Render(w, r, newErrResponse(err, http.StatusBadRequest))
+				return
+			case pathVersion != "":
+				version = pathVersion
+			case version == "":
+				version = defaultAPIVersion
+			case !slices.Contains(apiVersions, version):
+				err := fmt.Errorf("unsupported API version %q: supported versions are %q", version, apiVersions)
+				_ = render.Render(w, r, newErrResponse(err, http.StatusBadRequest))
+				return
+			}
+			w.Header().Set(apiVersionHeader, version)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 // apiRouter creates a router that represents the routes under /api
 func apiRouter(s *Server) (chi.Router, error) {
 	r := chi.NewRouter()
@@ -37,17 +91,40 @@ func apiRouter(s *Server) (chi.Router, error) {
 	r.Use(middleware.StripSlashes)
 	r.Use(render.SetContentType(render.ContentTypeJSON))
 
-	r.Get("/toolset", func(w http.ResponseWriter, r *http.Request) { toolsetHandler(s, w, r) })
-	r.Get("/toolset/{toolsetName}", func(w http.ResponseWriter, r *http.Request) { toolsetHandler(s, w, r) })
-
-	r.Route("/tool/{toolName}", func(r chi.Router) {
-		r.Get("/", func(w http.ResponseWriter, r *http.Request) { toolGetHandler(s, w, r) })
-		r.Post("/invoke", func(w http.ResponseWriter, r *http.Request) { toolInvokeHandler(s, w, r) })
+	r.Group(func(r chi.Router) {
+		r.Use(apiVersionMiddleware(""))
+		apiRoutes(s, r)
 	})
+	for _, v := range apiVersions {
+		r.Route("/"+v, func(r chi.Router) {
+			r.Use(apiVersionMiddleware(v))
+			apiRoutes(s, r)
+		})
+	}
 
 	return r, nil
 }
 
+// apiRoutes adds the routes of the API to r.
+func apiRoutes(s *Server, r chi.Router) {
+	// the capabilities are served in maintenance mode, which they report
+	r.Get("/capabilities", func(w http.ResponseWriter, r *http.Request) { capabilitiesHandler(s, w, r) })
+
+	r.Group(func(r chi.Router) {
+		r.Use(s.switches.maintenanceMiddleware(func(w http.ResponseWriter, r *http.Request, err error) {
+			_ = render.Render(w, r, newErrResponse(err, http.StatusServiceUnavailable))
+		}))
+
+		r.Get("/toolset", func(w http.ResponseWriter, r *http.Request) { toolsetHandler(s, w, r) })
+		r.Get("/toolset/{toolsetName}", func(w http.ResponseWriter, r *http.Request) { toolsetHandler(s, w, r) })
+
+		r.Route("/tool/{toolName}", func(r chi.Router) {
+			r.Get("/", func(w http.ResponseWriter, r *http.Request) { toolGetHandler(s, w, r) })
+			r.Post("/invoke", func(w http.ResponseWriter, r *http.Request) { toolInvokeHandler(s, w, r) })
+		})
+	})
+}
+
 // toolsetHandler handles the request for information about a Toolset.
 func toolsetHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 	ctx, span := s.instrumentation.Tracer.Start(r.Context(), "toolbox/server/toolset/get")
@@ -75,11 +152,39 @@ func toolsetHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 		)
 	}()
 
-	toolset, ok := s.ResourceMgr.GetToolset(toolsetName)
+	resources := s.ResourceMgr.Snapshot()
+	setConfigVersion(w, resources)
+	toolset, ok := resources.GetLocalizedToolset(toolsetName, s.preferredLocales(r.Header.Get("Accept-Language")))
 	if !ok {
 		err = fmt.Errorf("toolset %q does not exist", toolsetName)
+		code := http.StatusNotFound
+		if removedErr := resources.RemovedToolset(toolsetName); removedErr != nil {
+			err, code = removedErr, http.StatusGone
+		}
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, code))
+		return
+	}
+	toolset = s.switches.enabledTools(resources, toolset).FilterByTags(tools.ParseTags(r.URL.Query().Get("tags")))
+
+	// clients may request smaller pages than the server's page size
+	pageSize := s.toolsPageSize
+	if v := r.URL.Query().Get("pageSize"); v != "" {
+		size, convErr := strconv.Atoi(v)
+		if convErr != nil || size <= 0 {
+			err = fmt.Errorf("invalid pageSize %q: must be a positive integer", v)
+			s.logger.DebugContext(ctx, err.Error())
+			_ = render.Render(w, r, newErrResponse(err, http.StatusBadRequest))
+			return
+		}
+		if pageSize <= 0 || size < pageSize {
+			pageSize = size
+		}
+	}
+	toolset, _, err = toolset.Page(r.URL.Query().Get("cursor"), pageSize)
+	if err != nil {
 		s.logger.DebugContext(ctx, err.Error())
-		_ = render.Render(w, r, newErrResponse(err, http.StatusNotFound))
+		_ = render.Render(w, r, newErrResponse(err, http.StatusBadRequest))
 		return
 	}
 	render.JSON(w, r, toolset.Manifest)
@@ -111,19 +216,28 @@ func toolGetHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 			metric.WithAttributes(attribute.String("toolbox.operation.status", status)),
 		)
 	}()
-	tool, ok := s.ResourceMgr.GetTool(toolName)
+	resources := s.ResourceMgr.Snapshot()
+	setConfigVersion(w, resources)
+	tool, ok := resources.GetTool(toolName)
 	if !ok {
 		err = fmt.Errorf("invalid tool name: tool with name %q does not exist", toolName)
+		code := http.StatusNotFound
+		if removedErr := resources.RemovedTool(toolName); removedErr != nil {
+			err, code = removedErr, http.StatusGone
+		}
 		s.logger.DebugContext(ctx, err.Error())
-		_ = render.Render(w, r, newErrResponse(err, http.StatusNotFound))
+		_ = render.Render(w, r, newErrResponse(err, code))
 		return
 	}
-	// TODO: this can be optimized later with some caching
+	if err = s.switches.toolDisabled(tool); err != nil {
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusServiceUnavailable))
+		return
+	}
+	manifest, _ := resources.GetLocalizedToolManifest(toolName, s.preferredLocales(r.Header.Get("Accept-Language")))
 	m := tools.ToolsetManifest{
 		ServerVersion: s.version,
-		ToolsManifest: map[string]tools.Manifest{
-			toolName: tool.Manifest(),
-		},
+		ToolsManifest: map[string]tools.Manifest{toolName: manifest},
 	}
 
 	render.JSON(w, r, m)
@@ -157,18 +271,29 @@ func toolInvokeHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 		)
 	}()
 
-	tool, ok := s.ResourceMgr.GetTool(toolName)
+	resources := s.ResourceMgr.Snapshot()
+	setConfigVersion(w, resources)
+	tool, ok := resources.GetTool(toolName)
 	if !ok {
 		err = fmt.Errorf("invalid tool name: tool with name %q does not exist", toolName)
+		code := http.StatusNotFound
+		if removedErr := resources.RemovedTool(toolName); removedErr != nil {
+			err, code = removedErr, http.StatusGone
+		}
 		s.logger.DebugContext(ctx, err.Error())
-		_ = render.Render(w, r, newErrResponse(err, http.StatusNotFound))
+		_ = render.Render(w, r, newErrResponse(err, code))
+		return
+	}
+	if err = s.switches.toolDisabled(tool); err != nil {
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusServiceUnavailable))
 		return
 	}
 
 	// Tool authentication
 	// claimsFromAuth maps the name of the authservice to the claims retrieved from it.
 	claimsFromAuth := make(map[string]map[string]any)
-	for _, aS := range s.ResourceMgr.GetAuthServiceMap() {
+	for _, aS := range resources.GetAuthServiceMap() {
 		claims, err := aS.GetClaimsFromHeader(ctx, r.Header)
 		if err != nil {
 			s.logger.DebugContext(ctx, err.Error())
@@ -178,9 +303,19 @@ func toolInvokeHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 			// authService not present in header
 			continue
 		}
+		claims, err = s.enrichClaims(ctx, aS.GetName(), claims)
+		if err != nil {
+			s.logger.DebugContext(ctx, err.Error())
+			_ = render.Render(w, r, newErrResponse(err, http.StatusInternalServerError))
+			return
+		}
 		claimsFromAuth[aS.GetName()] = claims
 	}
 
+	ctx = tools.WithInvocationContext(ctx, withCaller(s.invocationContext(r, ""), claimsFromAuth))
+	ctx = util.WithIDGenerator(ctx, s.ids)
+	ctx = util.WithClock(ctx, s.clock)
+
 	// Tool authorization check
 	verifiedAuthServices := make([]string, len(claimsFromAuth))
 	i := 0
@@ -199,8 +334,29 @@ func toolInvokeHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 	}
 	s.logger.DebugContext(ctx, "tool invocation authorized")
 
+	// the body is bounded before it is decoded, so that oversized or deeply
+	// nested payloads aren't allocated
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, tools.MaxRequestSize))
+	if err != nil {
+		status := http.StatusBadRequest
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		err = fmt.Errorf("unable to read request body: %w", err)
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, status))
+		return
+	}
+	// parameters are the values of the body
+	if err = tools.CheckNestingDepth(body, tools.MaxNestingDepth+1); err != nil {
+		err = fmt.Errorf("request body was invalid: %w", err)
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusBadRequest))
+		return
+	}
 	var data map[string]any
-	if err = util.DecodeJSON(r.Body, &data); err != nil {
+	if err = util.DecodeJSON(bytes.NewReader(body), &data); err != nil {
 		render.Status(r, http.StatusBadRequest)
 		err = fmt.Errorf("request body was invalid JSON: %w", err)
 		s.logger.DebugContext(ctx, err.Error())
@@ -217,30 +373,53 @@ func toolInvokeHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 	}
 	s.logger.DebugContext(ctx, fmt.Sprintf("invocation params: %s", params))
 
+	ctx = tools.WithWarnings(ctx)
+	tools.AddDefaultWarnings(ctx, tool.Manifest(), data, params)
+	ctx = quota.WithStatus(ctx)
+	start := time.Now()
 	res, err := tool.Invoke(ctx, params)
+	elapsed := time.Since(start)
+	setQuotaHeaders(ctx, w)
 	if err != nil {
 		err = fmt.Errorf("error while invoking tool: %w", err)
+		code := http.StatusBadRequest
+		var quotaErr *quota.ExceededError
+		var deniedErr *policy.DeniedError
+		switch {
+		case errors.As(err, &quotaErr):
+			code = http.StatusTooManyRequests
+		case errors.As(err, &deniedErr):
+			code = http.StatusForbidden
+		}
 		s.logger.DebugContext(ctx, err.Error())
-		_ = render.Render(w, r, newErrResponse(err, http.StatusBadRequest))
+		_ = render.Render(w, r, newErrResponse(err, code))
 		return
 	}
 
-	resMarshal, err := json.Marshal(res)
-	if err != nil {
+	// the typed envelope describes the execution of the tool as well
+	var metadata *tools.ResultMetadata
+	if s.resultEnvelope == ResultAsData {
+		m := tools.NewResultMetadata(ctx, tool, res, elapsed)
+		metadata = &m
+	}
+	if err = writeResult(w, s.resultEnvelope, res, tools.Warnings(ctx), tools.NextCursor(ctx), metadata); err != nil {
 		err = fmt.Errorf("unable to marshal result: %w", err)
 		s.logger.DebugContext(ctx, err.Error())
 		_ = render.Render(w, r, newErrResponse(err, http.StatusInternalServerError))
 		return
 	}
-
-	_ = render.Render(w, r, &resultResponse{Result: string(resMarshal)})
 }
 
 var _ render.Renderer = &resultResponse{} // Renderer interface for managing response payloads.
 
 // resultResponse is the response sent back when the tool was invocated successfully.
+// It is written by writeResult, which streams the result instead of rendering
+// it.
 type resultResponse struct {
-	Result string `json:"result"` // result of tool invocation
+	Result   string   `json:"result"`             // result of tool invocation
+	Warnings []string `json:"warnings,omitempty"` // non-fatal issues with the invocation
+	// NextCursor is the cursor of the next page of the result, if any.
+	NextCursor string `json:"nextCursor,omitempty"`
 }
 
 // Render renders a single payload and respond to the client request.
@@ -249,17 +428,58 @@ func (rr resultResponse) Render(w http.ResponseWriter, r *http.Request) error {
 	return nil
 }
 
+var _ render.Renderer = &dataResponse{} // Renderer interface for managing response payloads.
+
+// dataResponse is the response sent back when the tool was invocated
+// successfully with the ResultAsData envelope, which holds the result as is
+// instead of encoded as a string. It is written by writeResult as well.
+type dataResponse struct {
+	Data       any      `json:"data"`
+	Warnings   []string `json:"warnings,omitempty"`
+	NextCursor string   `json:"nextCursor,omitempty"`
+	// Metadata describes the execution of the tool, such as the number of
+	// rows of its result and how long it took.
+	Metadata *tools.ResultMetadata `json:"metadata,omitempty"`
+}
+
+// Render renders a single payload and respond to the client request.
+func (dr dataResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	render.Status(r, http.StatusOK)
+	return nil
+}
+
 var _ render.Renderer = &errResponse{} // Renderer interface for managing response payloads.
 
 // newErrResponse is a helper function initializing an ErrResponse
 func newErrResponse(err error, code int) *errResponse {
-	return &errResponse{
+	resp := &errResponse{
 		Err:            err,
 		HTTPStatusCode: code,
 
 		StatusText: http.StatusText(code),
 		ErrorText:  err.Error(),
 	}
+	var paramErrs tools.ParamErrors
+	if errors.As(err, &paramErrs) {
+		resp.ParamErrors = paramErrs
+	}
+	var removedErr *ResourceRemovedError
+	if errors.As(err, &removedErr) {
+		resp.Removed = removedErr
+	}
+	var quotaErr *quota.ExceededError
+	if errors.As(err, &quotaErr) {
+		resp.Quota = quotaErr
+	}
+	var disabledErr *DisabledError
+	if errors.As(err, &disabledErr) {
+		resp.Disabled = disabledErr
+	}
+	var deniedErr *policy.DeniedError
+	if errors.As(err, &deniedErr) {
+		resp.Policy = deniedErr
+	}
+	return resp
 }
 
 // errResponse is the response sent back when an error has been encountered.
@@ -269,6 +489,16 @@ type errResponse struct {
 
 	StatusText string `json:"status"`          // user-level status message
 	ErrorText  string `json:"error,omitempty"` // application-level error message, for debugging
+	// ParamErrors lists every invalid parameter when parameters were rejected
+	ParamErrors tools.ParamErrors `json:"paramErrors,omitempty"`
+	// Removed describes the tool or toolset when a reload removed it
+	Removed *ResourceRemovedError `json:"removed,omitempty"`
+	// Quota describes the quota the caller exceeded
+	Quota *quota.ExceededError `json:"quota,omitempty"`
+	// Disabled describes the tool when it is disabled
+	Disabled *DisabledError `json:"disabled,omitempty"`
+	// Policy describes the policy that denied the invocation
+	Policy *policy.DeniedError `json:"policy,omitempty"`
 }
 
 func (e *errResponse) Render(w http.ResponseWriter, r *http.Request) error {
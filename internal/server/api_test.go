@@ -16,6 +16,7 @@ package server
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -23,6 +24,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/auth"
 	"github.com/googleapis/genai-toolbox/internal/tools"
 )
 
@@ -40,11 +43,13 @@ func TestToolsetEndpoint(t *testing.T) {
 		isErr      bool
 		version    string
 		tools      []string
+		nextCursor bool
 	}
 
 	testCases := []struct {
 		name        string
 		toolsetName string
+		query       string
 		want        wantResponse
 	}{
 		{
@@ -82,11 +87,60 @@ func TestToolsetEndpoint(t *testing.T) {
 				tools:      []string{tool2.Name},
 			},
 		},
+		{
+			name:        "filtered by tags",
+			toolsetName: "",
+			query:       "?tags=reporting",
+			want: wantResponse{
+				statusCode: http.StatusOK,
+				version:    fakeVersionString,
+				tools:      []string{tool2.Name},
+			},
+		},
+		{
+			name:        "first page",
+			toolsetName: "",
+			query:       "?pageSize=1",
+			want: wantResponse{
+				statusCode: http.StatusOK,
+				version:    fakeVersionString,
+				tools:      []string{tool1.Name},
+				nextCursor: true,
+			},
+		},
+		{
+			name:        "invalid page size",
+			toolsetName: "",
+			query:       "?pageSize=0",
+			want: wantResponse{
+				statusCode: http.StatusBadRequest,
+				isErr:      true,
+			},
+		},
+		{
+			name:        "invalid cursor",
+			toolsetName: "",
+			query:       "?cursor=foo!",
+			want: wantResponse{
+				statusCode: http.StatusBadRequest,
+				isErr:      true,
+			},
+		},
+		{
+			name:        "filtered by unknown tags",
+			toolsetName: "",
+			query:       "?tags=reporting,readonly",
+			want: wantResponse{
+				statusCode: http.StatusOK,
+				version:    fakeVersionString,
+				tools:      []string{},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			resp, body, err := runRequest(ts, http.MethodGet, fmt.Sprintf("/toolset/%s", tc.toolsetName), nil, nil)
+			resp, body, err := runRequest(ts, http.MethodGet, fmt.Sprintf("/toolset/%s%s", tc.toolsetName, tc.query), nil, nil)
 			if err != nil {
 				t.Fatalf("unexpected error during request: %s", err)
 			}
@@ -112,7 +166,13 @@ func TestToolsetEndpoint(t *testing.T) {
 			if m.ServerVersion != tc.want.version {
 				t.Fatalf("unexpected ServerVersion: want %q, got %q", tc.want.version, m.ServerVersion)
 			}
+			if (m.NextCursor != "") != tc.want.nextCursor {
+				t.Fatalf("unexpected nextCursor: %q", m.NextCursor)
+			}
 			// validate that the tools in the toolset are correct
+			if len(m.ToolsManifest) != len(tc.want.tools) {
+				t.Fatalf("unexpected number of tools: want %d, got %d", len(tc.want.tools), len(m.ToolsManifest))
+			}
 			for _, name := range tc.want.tools {
 				_, ok := m.ToolsManifest[name]
 				if !ok {
@@ -281,3 +341,352 @@ func TestToolInvokeEndpoint(t *testing.T) {
 		})
 	}
 }
+
+func TestToolInvokeParamErrors(t *testing.T) {
+	toolsMap, toolsets := setUpResources(t, []MockTool{tool1, tool2})
+	r, shutdown := setUpServer(t, "api", toolsMap, toolsets)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	resp, body, err := runRequest(ts, http.MethodPost, fmt.Sprintf("/tool/%s/invoke", tool2.Name), bytes.NewBuffer([]byte(`{"param1": "one"}`)), nil)
+	if err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("unexpected status code: got %d, want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+
+	var got struct {
+		ParamErrors []tools.ParamError `json:"paramErrors"`
+	}
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatalf("unable to parse response: %s", err)
+	}
+	// every invalid parameter is reported, not just the first
+	if len(got.ParamErrors) != 2 {
+		t.Fatalf("unexpected param errors: %s", body)
+	}
+	if got.ParamErrors[0].Name != "param1" || got.ParamErrors[0].Reason != tools.ParamErrorTypeMismatch {
+		t.Errorf("unexpected first param error: %+v", got.ParamErrors[0])
+	}
+	if got.ParamErrors[1].Name != "param2" || got.ParamErrors[1].Reason != tools.ParamErrorMissing {
+		t.Errorf("unexpected second param error: %+v", got.ParamErrors[1])
+	}
+}
+
+func TestToolInvokeRequestLimits(t *testing.T) {
+	toolsMap, toolsets := setUpResources(t, []MockTool{tool1, tool2})
+	r, shutdown := setUpServer(t, "api", toolsMap, toolsets)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	tcs := []struct {
+		desc string
+		body string
+		want int
+		err  string
+	}{
+		{
+			desc: "oversized body",
+			body: `{"param1": "` + strings.Repeat("a", tools.MaxRequestSize) + `"}`,
+			want: http.StatusRequestEntityTooLarge,
+			err:  "http: request body too large",
+		},
+		{
+			desc: "deeply nested body",
+			body: `{"param1": ` + strings.Repeat("[", tools.MaxNestingDepth+1),
+			want: http.StatusBadRequest,
+			err:  "value exceeds the maximum nesting depth of 33",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			resp, body, err := runRequest(ts, http.MethodPost, fmt.Sprintf("/tool/%s/invoke", tool2.Name), strings.NewReader(tc.body), nil)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			if resp.StatusCode != tc.want {
+				t.Fatalf("unexpected status code: got %d, want %d: %s", resp.StatusCode, tc.want, body)
+			}
+			// the body is rejected before it is decoded, so it isn't reported as
+			// invalid JSON
+			if !strings.Contains(string(body), tc.err) || strings.Contains(string(body), "invalid JSON") {
+				t.Fatalf("unexpected response: %s", body)
+			}
+		})
+	}
+
+	t.Run("mcp", func(t *testing.T) {
+		r, shutdown := setUpServer(t, "mcp", toolsMap, toolsets)
+		defer shutdown()
+		ts := runServer(r, false)
+		defer ts.Close()
+
+		body := `{"jsonrpc": "2.0", "id": "call", "method": "tools/call", "params": {"name": "no_params", "arguments": {"param1": ` + strings.Repeat("[", tools.MaxNestingDepth+1)
+		_, got, err := runRequest(ts, http.MethodPost, "/", strings.NewReader(body), nil)
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		if !strings.Contains(string(got), "value exceeds the maximum nesting depth of 35") {
+			t.Fatalf("unexpected response: %s", got)
+		}
+	})
+}
+
+func TestToolInvokeWarnings(t *testing.T) {
+	defaulted := MockTool{
+		Name: "defaulted_param",
+		Params: tools.Parameters{
+			tools.NewIntParameterWithDefault("limit", 10, "The maximum number of rows."),
+		},
+	}
+	toolsMap, toolsets := setUpResources(t, []MockTool{tool1, defaulted})
+	r, shutdown := setUpServer(t, "api", toolsMap, toolsets)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	resp, body, err := runRequest(ts, http.MethodPost, fmt.Sprintf("/tool/%s/invoke", defaulted.Name), bytes.NewBuffer([]byte(`{}`)), nil)
+	if err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("response status code is not 200, got %d, %s", resp.StatusCode, string(body))
+	}
+
+	var got struct {
+		Warnings []string `json:"warnings"`
+	}
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatalf("unable to parse response: %s", err)
+	}
+	want := []string{`parameter "limit" was not provided, so its default value 10 was used`}
+	if diff := cmp.Diff(want, got.Warnings); diff != "" {
+		t.Fatalf("incorrect warnings: diff %v", diff)
+	}
+}
+
+func TestRemovedByReloadEndpoints(t *testing.T) {
+	toolsMap, toolsets := setUpResources(t, []MockTool{tool1, tool2})
+	resourceManager := NewResourceManager(nil, nil, toolsMap, toolsets)
+	r, shutdown := setUpServerWithResources(t, "api", resourceManager)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	// reload without tool2 and the toolsets that reference it
+	resourceManager.SetResources(nil, nil, map[string]tools.Tool{tool1.Name: toolsMap[tool1.Name]}, map[string]tools.Toolset{"tool1_only": toolsets["tool1_only"]})
+
+	testCases := []struct {
+		name   string
+		method string
+		path   string
+		want   ResourceRemovedError
+	}{
+		{
+			name:   "get removed tool",
+			method: http.MethodGet,
+			path:   fmt.Sprintf("/tool/%s", tool2.Name),
+			want:   ResourceRemovedError{Kind: "tool", Name: tool2.Name},
+		},
+		{
+			name:   "invoke removed tool",
+			method: http.MethodPost,
+			path:   fmt.Sprintf("/tool/%s/invoke", tool2.Name),
+			want:   ResourceRemovedError{Kind: "tool", Name: tool2.Name},
+		},
+		{
+			name:   "get removed toolset",
+			method: http.MethodGet,
+			path:   "/toolset/tool2_only",
+			want:   ResourceRemovedError{Kind: "toolset", Name: "tool2_only"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, body, err := runRequest(ts, tc.method, tc.path, bytes.NewBuffer([]byte(`{}`)), nil)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			if resp.StatusCode != http.StatusGone {
+				t.Fatalf("unexpected status code: got %d, want %d", resp.StatusCode, http.StatusGone)
+			}
+			if got := resp.Header.Get(configVersionHeader); got != "2" {
+				t.Fatalf("unexpected config version: got %q, want %q", got, "2")
+			}
+			var got struct {
+				Removed *ResourceRemovedError `json:"removed"`
+			}
+			if err := json.Unmarshal(body, &got); err != nil {
+				t.Fatalf("unable to parse response: %s", err)
+			}
+			if got.Removed == nil || got.Removed.Kind != tc.want.Kind || got.Removed.Name != tc.want.Name || got.Removed.RemovedAt.IsZero() {
+				t.Fatalf("unexpected response: %s", body)
+			}
+		})
+	}
+
+	// tools that were never configured are still reported as not found
+	resp, _, err := runRequest(ts, http.MethodGet, "/tool/unknown", nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("unexpected status code: got %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+}
+
+func TestAPIVersion(t *testing.T) {
+	toolsMap, toolsets := setUpResources(t, []MockTool{tool1, tool2})
+	r, shutdown := setUpServer(t, "api", toolsMap, toolsets)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	testCases := []struct {
+		name        string
+		path        string
+		header      map[string]string
+		wantStatus  int
+		wantVersion string
+		wantErr     string
+	}{
+		{
+			name:        "unversioned",
+			path:        "/tool/no_params/invoke",
+			wantStatus:  http.StatusOK,
+			wantVersion: "v1",
+		},
+		{
+			name:        "versioned",
+			path:        "/v1/tool/no_params/invoke",
+			wantStatus:  http.StatusOK,
+			wantVersion: "v1",
+		},
+		{
+			name:        "requested version",
+			path:        "/tool/no_params/invoke",
+			header:      map[string]string{"Toolbox-Api-Version": "v1"},
+			wantStatus:  http.StatusOK,
+			wantVersion: "v1",
+		},
+		{
+			name:       "unsupported version",
+			path:       "/tool/no_params/invoke",
+			header:     map[string]string{"Toolbox-Api-Version": "v0"},
+			wantStatus: http.StatusBadRequest,
+			wantErr:    `unsupported API version "v0": supported versions are ["v1"]`,
+		},
+		{
+			name:       "mismatched version",
+			path:       "/v1/tool/no_params/invoke",
+			header:     map[string]string{"Toolbox-Api-Version": "v2"},
+			wantStatus: http.StatusBadRequest,
+			wantErr:    `Toolbox-Api-Version header "v2" does not match the API version "v1" of the path`,
+		},
+		{
+			name:       "unknown version path",
+			path:       "/v2/tool/no_params/invoke",
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, body, err := runRequest(ts, http.MethodPost, tc.path, bytes.NewBuffer([]byte(`{}`)), tc.header)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			if resp.StatusCode != tc.wantStatus {
+				t.Fatalf("unexpected status code: got %d, want %d: %s", resp.StatusCode, tc.wantStatus, string(body))
+			}
+			if got := resp.Header.Get("Toolbox-Api-Version"); got != tc.wantVersion {
+				t.Fatalf("unexpected Toolbox-Api-Version header: got %q, want %q", got, tc.wantVersion)
+			}
+			if tc.wantErr == "" {
+				return
+			}
+			var got errResponse
+			if err := json.Unmarshal(body, &got); err != nil {
+				t.Fatalf("unable to parse response: %s", err)
+			}
+			if got.ErrorText != tc.wantErr {
+				t.Fatalf("unexpected error: got %q, want %q", got.ErrorText, tc.wantErr)
+			}
+		})
+	}
+}
+
+// headerAuthService verifies the subject in its token header as is.
+type headerAuthService struct{}
+
+func (headerAuthService) AuthServiceKind() string { return "header" }
+
+func (headerAuthService) GetName() string { return "my-auth" }
+
+func (headerAuthService) GetClaimsFromHeader(_ context.Context, h http.Header) (map[string]any, error) {
+	if sub := h.Get("my-auth_token"); sub != "" {
+		return map[string]any{"sub": sub}, nil
+	}
+	return nil, nil
+}
+
+// paramsTool returns the values of its parameters.
+type paramsTool struct {
+	MockTool
+}
+
+func (paramsTool) Invoke(_ context.Context, params tools.ParamValues) (any, error) {
+	return params.AsMap(), nil
+}
+
+func TestClaimsEnricher(t *testing.T) {
+	tool := paramsTool{MockTool{
+		Name:   "whoami",
+		Params: []tools.Parameter{tools.NewStringParameterWithAuth("role", "role of the user", []tools.ParamAuthService{{Name: "my-auth", Field: "role"}})},
+	}}
+	resources := NewResourceManager(nil, map[string]auth.AuthService{"my-auth": headerAuthService{}}, map[string]tools.Tool{"whoami": tool}, map[string]tools.Toolset{"": {}})
+	server, shutdown := newTestServer(t, resources)
+	defer shutdown()
+	roles := map[string]string{"alice": "admin"}
+	WithClaimsEnricher(auth.ClaimsEnricherFunc(func(_ context.Context, authService string, claims map[string]any) (map[string]any, error) {
+		role, ok := roles[claims["sub"].(string)]
+		if !ok {
+			return nil, fmt.Errorf("unknown user %q", claims["sub"])
+		}
+		claims["role"] = role
+		return claims, nil
+	}))(server)
+	r, err := apiRouter(server)
+	if err != nil {
+		t.Fatalf("unable to initialize api router: %s", err)
+	}
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	// the enriched claim is bound to the parameter
+	resp, body, err := runRequest(ts, http.MethodPost, "/tool/whoami/invoke", bytes.NewBufferString(`{}`), map[string]string{"my-auth_token": "alice"})
+	if err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status %d: %s", resp.StatusCode, body)
+	}
+	var got resultResponse
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatalf("unable to parse response: %s", err)
+	}
+	if diff := cmp.Diff(`{"role":"admin"}`, got.Result); diff != "" {
+		t.Fatalf("incorrect result: diff %v", diff)
+	}
+
+	// invocations fail if claims can't be enriched
+	resp, body, err = runRequest(ts, http.MethodPost, "/tool/whoami/invoke", bytes.NewBufferString(`{}`), map[string]string{"my-auth_token": "mallory"})
+	if err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	if resp.StatusCode != http.StatusInternalServerError || !strings.Contains(string(body), `unknown user \"mallory\"`) {
+		t.Fatalf("expected the claims enricher to fail the invocation, got %d: %s", resp.StatusCode, body)
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/telemetry"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// The canary variants of a tool with a candidate definition.
+const (
+	canaryActive    = "active"
+	canaryCandidate = "candidate"
+)
+
+// applyCandidates wraps the tools of toolsMap having a candidate definition
+// in cfg, so that it serves a share of their invocations. environments are
+// the environments of the sources, by name: policies check invocations
+// against the environment of the active definition, so candidates must use a
+// source in the same environment.
+func applyCandidates(ctx context.Context, cfg ServerConfig, toolsMap map[string]tools.Tool, sourcesMap map[string]sources.Source, environments map[string]string) error {
+	if len(cfg.CandidateToolConfigs) == 0 {
+		return nil
+	}
+	if cfg.CandidatePercent < 0 || cfg.CandidatePercent > 100 {
+		return fmt.Errorf("candidate percent must be between 0 and 100, got %v", cfg.CandidatePercent)
+	}
+	instrumentation, err := util.InstrumentationFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	l, err := util.LoggerFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range sortedKeys(cfg.CandidateToolConfigs) {
+		active, ok := toolsMap[name].(instrumentedTool)
+		if !ok {
+			return fmt.Errorf("candidate tool %q has no active definition", name)
+		}
+		activeEnv := environments[toolSourceName(cfg.ToolConfigs[name])]
+		if env := environments[toolSourceName(cfg.CandidateToolConfigs[name])]; env != activeEnv {
+			return fmt.Errorf("candidate tool %q must use a source in the environment %q of the active tool, got %q", name, activeEnv, env)
+		}
+		candidate, err := initializeTool(ctx, cfg, name, cfg.CandidateToolConfigs[name], sourcesMap)
+		if err != nil {
+			return fmt.Errorf("unable to initialize candidate: %w", err)
+		}
+		// invocations are parsed by the active definition, whatever the variant
+		if !sameParameters(active.Manifest().Parameters, candidate.Manifest().Parameters) {
+			return fmt.Errorf("candidate tool %q must have the same parameters as the active tool", name)
+		}
+		active.variant = canaryActive
+		toolsMap[name] = canaryTool{
+			Tool:            active,
+			candidate:       instrumentedTool{Tool: candidate, name: name, instrumentation: instrumentation, variant: canaryCandidate, slowThreshold: cfg.SlowQueryThreshold},
+			name:            name,
+			percent:         cfg.CandidatePercent,
+			callers:         cfg.CandidateCallers,
+			instrumentation: instrumentation,
+			random:          rand.Float64,
+		}
+	}
+	l.InfoContext(ctx, fmt.Sprintf("Initialized %d candidate tools.", len(cfg.CandidateToolConfigs)))
+	return nil
+}
+
+// sameParameters reports whether two lists of parameters are parsed the same
+// way, regardless of their descriptions and examples.
+func sameParameters(a, b []tools.ParameterManifest) bool {
+	return slices.EqualFunc(a, b, func(x, y tools.ParameterManifest) bool {
+		return sameParameter(&x, &y)
+	})
+}
+
+func sameParameter(x, y *tools.ParameterManifest) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	return x.Name == y.Name &&
+		x.Type == y.Type &&
+		x.Required == y.Required &&
+		slices.Equal(x.AuthServices, y.AuthServices) &&
+		reflect.DeepEqual(x.AdditionalProperties, y.AdditionalProperties) &&
+		sameParameter(x.Items, y.Items)
+}
+
+// canaryTool serves the invocations of a tool by its candidate definition
+// for the callers in callers, and for percent of the other invocations, and
+// by its active definition otherwise. The invocations of each variant are
+// counted and timed, so that they can be compared.
+type canaryTool struct {
+	tools.Tool
+	candidate       tools.Tool
+	name            string
+	percent         float64
+	callers         []string
+	instrumentation *telemetry.Instrumentation
+	// random returns a number in [0, 1), to pick the invocations served by
+	// the candidate.
+	random func() float64
+}
+
+func (t canaryTool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	variant, tool := canaryActive, t.Tool
+	if t.servedByCandidate(tools.InvocationContextFromContext(ctx).Caller) {
+		variant, tool = canaryCandidate, t.candidate
+	}
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("toolbox.canary.variant", variant))
+
+	start := time.Now()
+	res, err := tool.Invoke(ctx, params)
+	status := "success"
+	if err != nil {
+		status = "error"
+	}
+	attrs := metric.WithAttributes(
+		attribute.String("toolbox.name", t.name),
+		attribute.String("toolbox.canary.variant", variant),
+		attribute.String("toolbox.operation.status", status),
+	)
+	t.instrumentation.ToolCanaryInvoke.Add(ctx, 1, attrs)
+	t.instrumentation.ToolCanaryDuration.Record(ctx, float64(time.Since(start).Microseconds())/1000, attrs)
+	return res, err
+}
+
+// servedByCandidate reports whether an invocation by caller is served by the
+// candidate definition.
+func (t canaryTool) servedByCandidate(caller string) bool {
+	if caller != "" && slices.Contains(t.callers, caller) {
+		return true
+	}
+	return t.random()*100 < t.percent
+}
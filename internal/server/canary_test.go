@@ -0,0 +1,204 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/telemetry"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+// mockToolConfig initializes to its tool.
+type mockToolConfig struct {
+	tool   MockTool
+	Source string
+}
+
+func (c mockToolConfig) ToolConfigKind() string {
+	return "mock"
+}
+
+func (c mockToolConfig) Initialize(map[string]sources.Source) (tools.Tool, error) {
+	return c.tool, nil
+}
+
+func TestCanaryTool(t *testing.T) {
+	instrumentation, err := telemetry.CreateTelemetryInstrumentation(fakeVersionString)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc    string
+		percent float64
+		callers []string
+		caller  string
+		random  float64
+		want    any
+	}{
+		{
+			desc:    "no candidate share",
+			percent: 0,
+			random:  0,
+			want:    []any{"active"},
+		},
+		{
+			desc:    "full candidate share",
+			percent: 100,
+			random:  0.99,
+			want:    []any{"candidate"},
+		},
+		{
+			desc:    "outside the candidate share",
+			percent: 25,
+			random:  0.3,
+			want:    []any{"active"},
+		},
+		{
+			desc:    "inside the candidate share",
+			percent: 40,
+			random:  0.3,
+			want:    []any{"candidate"},
+		},
+		{
+			desc:    "candidate caller",
+			percent: 0,
+			callers: []string{"me@example.com"},
+			caller:  "me@example.com",
+			random:  0.5,
+			want:    []any{"candidate"},
+		},
+		{
+			desc:    "other caller",
+			percent: 0,
+			callers: []string{"me@example.com"},
+			caller:  "you@example.com",
+			random:  0.5,
+			want:    []any{"active"},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			tool := canaryTool{
+				Tool:            MockTool{Name: "active"},
+				candidate:       MockTool{Name: "candidate"},
+				name:            "my-tool",
+				percent:         tc.percent,
+				callers:         tc.callers,
+				instrumentation: instrumentation,
+				random:          func() float64 { return tc.random },
+			}
+			ctx := tools.WithInvocationContext(context.Background(), tools.InvocationContext{Caller: tc.caller})
+			got, err := tool.Invoke(ctx, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect variant: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestApplyCandidates(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	instrumentation, err := telemetry.CreateTelemetryInstrumentation(fakeVersionString)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	ctx = util.WithInstrumentation(ctx, instrumentation)
+
+	params := []tools.Parameter{tools.NewIntParameter("param1", "This is the first parameter.")}
+	tcs := []struct {
+		desc       string
+		percent    float64
+		candidates ToolConfigs
+		wantErr    string
+	}{
+		{
+			desc:       "candidate",
+			percent:    10,
+			candidates: ToolConfigs{"my-tool": mockToolConfig{tool: MockTool{Name: "candidate", Description: "new description", Params: params}, Source: "dev-source"}},
+		},
+		{
+			desc:       "no active definition",
+			candidates: ToolConfigs{"other-tool": mockToolConfig{tool: MockTool{Name: "candidate", Params: params}}},
+			wantErr:    `candidate tool "other-tool" has no active definition`,
+		},
+		{
+			desc:       "different parameters",
+			candidates: ToolConfigs{"my-tool": mockToolConfig{tool: MockTool{Name: "candidate"}, Source: "dev-source"}},
+			wantErr:    `candidate tool "my-tool" must have the same parameters as the active tool`,
+		},
+		{
+			desc:       "candidate in the same environment",
+			candidates: ToolConfigs{"my-tool": mockToolConfig{tool: MockTool{Name: "candidate", Params: params}, Source: "other-dev-source"}},
+		},
+		{
+			desc:       "candidate in another environment",
+			candidates: ToolConfigs{"my-tool": mockToolConfig{tool: MockTool{Name: "candidate", Params: params}, Source: "prod-source"}},
+			wantErr:    `candidate tool "my-tool" must use a source in the environment "dev" of the active tool, got "prod"`,
+		},
+		{
+			desc:       "invalid percent",
+			percent:    120,
+			candidates: ToolConfigs{"my-tool": mockToolConfig{tool: MockTool{Name: "candidate", Params: params}, Source: "dev-source"}},
+			wantErr:    "candidate percent must be between 0 and 100, got 120",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			toolsMap := map[string]tools.Tool{
+				"my-tool": instrumentedTool{Tool: MockTool{Name: "active", Params: params}, name: "my-tool", instrumentation: instrumentation},
+			}
+			cfg := ServerConfig{
+				ToolConfigs:          ToolConfigs{"my-tool": mockToolConfig{Source: "dev-source"}},
+				CandidateToolConfigs: tc.candidates,
+				CandidatePercent:     tc.percent,
+			}
+			environments := map[string]string{"dev-source": "dev", "other-dev-source": "dev", "prod-source": "prod"}
+			err := applyCandidates(ctx, cfg, toolsMap, nil, environments)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			tool, ok := toolsMap["my-tool"].(canaryTool)
+			if !ok {
+				t.Fatalf("tool was not wrapped: got %T", toolsMap["my-tool"])
+			}
+			if tool.percent != tc.percent {
+				t.Fatalf("incorrect percent: got %v, want %v", tool.percent, tc.percent)
+			}
+			// the manifest is the active one
+			if got := tool.Manifest().Description; got != "" {
+				t.Fatalf("incorrect manifest description: got %q", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/googleapis/genai-toolbox/internal/server/mcp"
+	"github.com/googleapis/genai-toolbox/internal/util/normalize"
+)
+
+// capabilitiesResponse describes the features of the server, so that clients
+// can detect them instead of parsing its version.
+type capabilitiesResponse struct {
+	ServerVersion string `json:"serverVersion"`
+	// APIVersions are the supported versions of the HTTP API.
+	APIVersions []string `json:"apiVersions"`
+	// MCPProtocolVersions are the supported versions of the MCP protocol.
+	MCPProtocolVersions []string `json:"mcpProtocolVersions"`
+	// MCPTransports are the supported MCP transports.
+	MCPTransports []string             `json:"mcpTransports"`
+	Features      capabilitiesFeatures `json:"features"`
+	ResultFormats resultFormats        `json:"resultFormats"`
+	// ResultEnvelope is the field of the responses of tool invocations
+	// holding their results.
+	ResultEnvelope ResultEnvelope `json:"resultEnvelope"`
+}
+
+// capabilitiesFeatures are the optional features of the server, and whether
+// they are enabled.
+type capabilitiesFeatures struct {
+	// Streaming is whether tool results can be streamed.
+	Streaming bool `json:"streaming"`
+	// AsyncJobs is whether tools can be invoked as jobs polled for their
+	// results.
+	AsyncJobs bool `json:"asyncJobs"`
+	// Artifacts is whether tools can return results as separate artifacts.
+	Artifacts bool `json:"artifacts"`
+	// Pagination is whether tool lists can be requested by page.
+	Pagination bool `json:"pagination"`
+	// LocalizedDescriptions is whether descriptions are returned in the
+	// language of the Accept-Language header.
+	LocalizedDescriptions bool `json:"localizedDescriptions"`
+	// Maintenance is whether the server is in maintenance mode, in which
+	// requests other than this one fail.
+	Maintenance bool `json:"maintenance"`
+}
+
+// resultFormats are the values of the tool options formatting results.
+type resultFormats struct {
+	NumberFormats []normalize.NumberFormat `json:"numberFormats"`
+	NullColumns   []normalize.NullColumns  `json:"nullColumns"`
+}
+
+func (c *capabilitiesResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	render.Status(r, http.StatusOK)
+	return nil
+}
+
+// capabilitiesHandler handles the request for the capabilities of the server.
+func capabilitiesHandler(s *Server, w http.ResponseWriter, r *http.Request) {
+	_ = render.Render(w, r, &capabilitiesResponse{
+		ServerVersion:       s.version,
+		APIVersions:         apiVersions,
+		MCPProtocolVersions: mcp.SUPPORTED_PROTOCOL_VERSIONS,
+		MCPTransports:       []string{"sse", "streamable-http"},
+		Features: capabilitiesFeatures{
+			Pagination:            true,
+			LocalizedDescriptions: true,
+			Maintenance:           s.switches.maintenanceMessage() != "",
+		},
+		ResultFormats: resultFormats{
+			NumberFormats: []normalize.NumberFormat{normalize.NumbersAsStrings, normalize.NumbersAsNumbers},
+			NullColumns:   []normalize.NullColumns{normalize.IncludeNullColumns, normalize.OmitNullColumns},
+		},
+		ResultEnvelope: ResultEnvelope(s.resultEnvelope.String()),
+	})
+}
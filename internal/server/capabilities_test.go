@@ -0,0 +1,88 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCapabilitiesEndpoint(t *testing.T) {
+	toolsMap, toolsets := setUpResources(t, []MockTool{tool1, tool2})
+	s, shutdown := newTestServer(t, NewResourceManager(nil, nil, toolsMap, toolsets))
+	defer shutdown()
+	r, err := apiRouter(s)
+	if err != nil {
+		t.Fatalf("unable to initialize api router: %s", err)
+	}
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	want := map[string]any{
+		"serverVersion":       fakeVersionString,
+		"apiVersions":         []any{"v1"},
+		"mcpProtocolVersions": []any{"2024-11-05", "2025-03-26", "2025-06-18"},
+		"mcpTransports":       []any{"sse", "streamable-http"},
+		"features": map[string]any{
+			"streaming":             false,
+			"asyncJobs":             false,
+			"artifacts":             false,
+			"pagination":            true,
+			"localizedDescriptions": true,
+			"maintenance":           false,
+		},
+		"resultFormats": map[string]any{
+			"numberFormats": []any{"string", "number"},
+			"nullColumns":   []any{"include", "omit"},
+		},
+		"resultEnvelope": "result",
+	}
+	for _, path := range []string{"/capabilities", "/v1/capabilities"} {
+		resp, body, err := runRequest(ts, http.MethodGet, path, nil, nil)
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("response status code is not 200, got %d, %s", resp.StatusCode, string(body))
+		}
+		var got map[string]any
+		if err := json.Unmarshal(body, &got); err != nil {
+			t.Fatalf("unable to parse response: %s", err)
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatalf("unexpected capabilities of %s: diff %v", path, diff)
+		}
+	}
+
+	// the capabilities are served in maintenance mode, which they report
+	s.switches.setMaintenance(true, "")
+	resp, body, err := runRequest(ts, http.MethodGet, "/capabilities", nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("response status code is not 200, got %d, %s", resp.StatusCode, string(body))
+	}
+	var got capabilitiesResponse
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatalf("unable to parse response: %s", err)
+	}
+	if !got.Features.Maintenance {
+		t.Fatalf("maintenance mode was not reported")
+	}
+}
@@ -39,6 +39,7 @@ type MockTool struct {
 	Name        string
 	Description string
 	Params      []tools.Parameter
+	Tags        []string
 	manifest    tools.Manifest
 }
 
@@ -57,7 +58,7 @@ func (t MockTool) Manifest() tools.Manifest {
 	for _, p := range t.Params {
 		pMs = append(pMs, p.Manifest())
 	}
-	return tools.Manifest{Description: t.Description, Parameters: pMs}
+	return tools.Manifest{Description: t.Description, Parameters: pMs, Tags: t.Tags}
 }
 func (t MockTool) Authorized(verifiedAuthServices []string) bool {
 	return true
@@ -93,6 +94,7 @@ var tool1 = MockTool{
 
 var tool2 = MockTool{
 	Name: "some_params",
+	Tags: []string{"reporting"},
 	Params: tools.Parameters{
 		tools.NewIntParameter("param1", "This is the first parameter."),
 		tools.NewIntParameter("param2", "This is the second parameter."),
@@ -135,6 +137,36 @@ func setUpResources(t *testing.T, mockTools []MockTool) (map[string]tools.Tool,
 
 // setUpServer create a new server with tools and toolsets that are given
 func setUpServer(t *testing.T, router string, tools map[string]tools.Tool, toolsets map[string]tools.Toolset) (chi.Router, func()) {
+	return setUpServerWithResources(t, router, NewResourceManager(nil, nil, tools, toolsets))
+}
+
+// setUpServerWithResources create a new server that serves the resources of
+// the given resource manager, so tests can update them while it runs
+func setUpServerWithResources(t *testing.T, router string, resourceManager *ResourceManager) (chi.Router, func()) {
+	server, shutdown := newTestServer(t, resourceManager)
+
+	var r chi.Router
+	var err error
+	switch router {
+	case "api":
+		r, err = apiRouter(server)
+		if err != nil {
+			t.Fatalf("unable to initialize api router: %s", err)
+		}
+	case "mcp":
+		r, err = mcpRouter(server)
+		if err != nil {
+			t.Fatalf("unable to initialize mcp router: %s", err)
+		}
+	default:
+		t.Fatalf("unknown router")
+	}
+	return r, shutdown
+}
+
+// newTestServer create a new server that serves the resources of the given
+// resource manager, without routers
+func newTestServer(t *testing.T, resourceManager *ResourceManager) (*Server, func()) {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	testLogger, err := log.NewStdLogger(os.Stdout, os.Stderr, "info")
@@ -142,7 +174,7 @@ func setUpServer(t *testing.T, router string, tools map[string]tools.Tool, tools
 		t.Fatalf("unable to initialize logger: %s", err)
 	}
 
-	otelShutdown, err := telemetry.SetupOTel(ctx, fakeVersionString, "", false, "toolbox")
+	otelShutdown, err := telemetry.SetupOTel(ctx, fakeVersionString, "", false, "toolbox", nil)
 	if err != nil {
 		t.Fatalf("unable to setup otel: %s", err)
 	}
@@ -154,31 +186,17 @@ func setUpServer(t *testing.T, router string, tools map[string]tools.Tool, tools
 
 	sseManager := newSseManager(ctx)
 
-	resourceManager := NewResourceManager(nil, nil, tools, toolsets)
-
-	server := Server{
+	server := &Server{
 		version:         fakeVersionString,
 		logger:          testLogger,
 		instrumentation: instrumentation,
 		sseManager:      sseManager,
+		mcpClients:      newMcpClientManager(ctx),
+		mcpResults:      newMcpResultStore(),
+		switches:        newSwitches(),
 		ResourceMgr:     resourceManager,
 	}
 
-	var r chi.Router
-	switch router {
-	case "api":
-		r, err = apiRouter(&server)
-		if err != nil {
-			t.Fatalf("unable to initialize api router: %s", err)
-		}
-	case "mcp":
-		r, err = mcpRouter(&server)
-		if err != nil {
-			t.Fatalf("unable to initialize mcp router: %s", err)
-		}
-	default:
-		t.Fatalf("unknown router")
-	}
 	shutdown := func() {
 		// cancel context
 		cancel()
@@ -189,7 +207,7 @@ func setUpServer(t *testing.T, router string, tools map[string]tools.Tool, tools
 		}
 	}
 
-	return r, shutdown
+	return server, shutdown
 }
 
 func runServer(r chi.Router, tls bool) *httptest.Server {
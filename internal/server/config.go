@@ -15,8 +15,11 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/auth"
@@ -24,6 +27,7 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/tools"
 	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/googleapis/genai-toolbox/internal/util/normalize"
 )
 
 type ServerConfig struct {
@@ -33,6 +37,9 @@ type ServerConfig struct {
 	Address string
 	// Port is the port the server will listen on.
 	Port int
+	// ListenAddresses are further addresses the server listens on, either
+	// "host:port" or "unix:" followed by the path of a socket.
+	ListenAddresses []string
 	// SourceConfigs defines what sources of data are available for tools.
 	SourceConfigs SourceConfigs
 	// AuthServiceConfigs defines what sources of authentication are available for tools.
@@ -41,6 +48,20 @@ type ServerConfig struct {
 	ToolConfigs ToolConfigs
 	// ToolsetConfigs defines what tools are available.
 	ToolsetConfigs ToolsetConfigs
+	// QuotaConfigs defines the daily budgets of callers.
+	QuotaConfigs QuotaConfigs
+	// PolicyConfigs defines when, and on which environments, tools can be
+	// invoked.
+	PolicyConfigs PolicyConfigs
+	// CandidateToolConfigs defines candidate definitions of tools, which serve
+	// the invocations of CandidateCallers and CandidatePercent of the others.
+	CandidateToolConfigs ToolConfigs
+	// CandidatePercent is the percentage of the invocations of tools with a
+	// candidate definition that it serves.
+	CandidatePercent float64
+	// CandidateCallers are the callers whose invocations are all served by
+	// the candidate definitions of tools.
+	CandidateCallers []string
 	// LoggingFormat defines whether structured loggings are used.
 	LoggingFormat logFormat
 	// LogLevel defines the levels to log.
@@ -51,12 +72,66 @@ type ServerConfig struct {
 	TelemetryOTLP string
 	// TelemetryServiceName defines the value of service.name resource attribute.
 	TelemetryServiceName string
+	// TelemetryResourceAttributes are further resource attributes of the
+	// telemetry data, such as deployment.environment.
+	TelemetryResourceAttributes map[string]string
 	// Stdio indicates if Toolbox is listening via MCP stdio.
 	Stdio bool
+	// StdioWorkers is the number of MCP tool calls processed at once in
+	// stdio mode. Calls are processed one at a time if it is zero.
+	StdioWorkers int
 	// DisableReload indicates if the user has disabled dynamic reloading for Toolbox.
 	DisableReload bool
 	// UI indicates if Toolbox UI endpoints (/ui) are available
 	UI bool
+	// EnableShellTools indicates if tools running commands on the host are allowed.
+	EnableShellTools bool
+	// AllowUnauthenticated acknowledges that tools without authRequired can
+	// be invoked without authentication, which is otherwise warned about.
+	AllowUnauthenticated bool
+	// UnauthenticatedLoopbackOnly refuses to serve tools without
+	// authRequired on addresses other than loopback ones, unless
+	// AllowUnauthenticated is set.
+	UnauthenticatedLoopbackOnly bool
+	// Locale is the locale of the descriptions served in manifests when the
+	// client doesn't request one with an Accept-Language header.
+	Locale string
+	// ToolsPageSize is the number of tools listed per page. Tools are listed
+	// all at once if it is zero.
+	ToolsPageSize int
+	// ResultTokenBudget is the estimated number of tokens beyond which the
+	// results of MCP tool calls are summarized by the LLM of clients that
+	// support sampling. Results are never summarized if it is zero.
+	ResultTokenBudget int
+	// RejectUnknownParameters rejects invocations with parameters a tool
+	// doesn't declare, unless the tool sets rejectUnknownParameters itself.
+	RejectUnknownParameters bool
+	// NumberFormat is how tools return decimals and big integers, unless a
+	// tool sets numberFormat itself.
+	NumberFormat normalize.NumberFormat
+	// SQLComment tags the SQL statements of tools with comments describing
+	// the invocation, unless a tool sets sqlComment itself.
+	SQLComment bool
+	// SlowQueryThreshold is the duration beyond which tool invocations are
+	// tagged as slow on their span and logged. Invocations are never slow if
+	// it is zero.
+	SlowQueryThreshold time.Duration
+	// QuotaStore is where the usage of quotas is counted: "memory", or a
+	// Redis URL to share it between servers.
+	QuotaStore string
+	// InvocationHeaders are the request headers passed to tools in their
+	// invocation context.
+	InvocationHeaders []string
+	// AdminToken is the bearer token required by the admin endpoints, which
+	// are disabled if it is empty.
+	AdminToken string
+	// AdminAddress is the address the admin endpoints are served on instead
+	// of the others, in the format of ListenAddresses. It must be a loopback
+	// address or a unix socket.
+	AdminAddress string
+	// ResultEnvelope is how the HTTP API returns the results of tool
+	// invocations.
+	ResultEnvelope ResultEnvelope
 }
 
 type logFormat string
@@ -85,6 +160,43 @@ func (f *logFormat) Type() string {
 	return "logFormat"
 }
 
+// ResultEnvelope is the field of the response of the HTTP API holding the
+// result of a tool invocation.
+type ResultEnvelope string
+
+const (
+	// ResultAsString returns the result encoded as a JSON string under
+	// "result", which clients parse again. It is the legacy envelope, kept
+	// as the default for existing clients.
+	ResultAsString ResultEnvelope = "result"
+	// ResultAsData returns the result as JSON under "data".
+	ResultAsData ResultEnvelope = "data"
+)
+
+// String is used by both fmt.Print and by Cobra in help text
+func (e *ResultEnvelope) String() string {
+	if string(*e) != "" {
+		return string(*e)
+	}
+	return string(ResultAsString)
+}
+
+// validate result envelope flag
+func (e *ResultEnvelope) Set(v string) error {
+	switch ResultEnvelope(strings.ToLower(v)) {
+	case ResultAsString, ResultAsData:
+		*e = ResultEnvelope(strings.ToLower(v))
+		return nil
+	default:
+		return fmt.Errorf(`result envelope must be one of "result", or "data"`)
+	}
+}
+
+// Type is used in Cobra help text
+func (e *ResultEnvelope) Type() string {
+	return "resultEnvelope"
+}
+
 type StringLevel string
 
 // String is used by both fmt.Print and by Cobra in help text
@@ -125,34 +237,72 @@ func (c *SourceConfigs) UnmarshalYAML(ctx context.Context, unmarshal func(interf
 		return err
 	}
 
-	for name, u := range raw {
+	// Report the errors of every source at once
+	var errs []error
+	for _, name := range sortedKeys(raw) {
+		u := raw[name]
 		// Unmarshal to a general type that ensure it capture all fields
 		var v map[string]any
 		if err := u.Unmarshal(&v); err != nil {
-			return fmt.Errorf("unable to unmarshal %q: %w", name, err)
+			errs = append(errs, newConfigError(fmt.Errorf("unable to unmarshal %q: %w", name, err), "sources", name))
+			continue
 		}
 
 		kind, ok := v["kind"]
 		if !ok {
-			return fmt.Errorf("missing 'kind' field for source %q", name)
+			errs = append(errs, newConfigError(fmt.Errorf("missing 'kind' field for source %q", name), "sources", name))
+			continue
 		}
 		kindStr, ok := kind.(string)
 		if !ok {
-			return fmt.Errorf("invalid 'kind' field for source %q (must be a string)", name)
+			errs = append(errs, newConfigError(fmt.Errorf("invalid 'kind' field for source %q (must be a string)", name), "sources", name, "kind"))
+			continue
 		}
 
-		yamlDecoder, err := util.NewStrictDecoder(v)
-		if err != nil {
-			return fmt.Errorf("error creating YAML decoder for source %q: %w", name, err)
+		// Whether the source is enabled is handled by Toolbox
+		enabled := true
+		if e, ok := v["enabled"]; ok {
+			delete(v, "enabled")
+			if enabled, ok = e.(bool); !ok {
+				errs = append(errs, newConfigError(fmt.Errorf("invalid 'enabled' field for source %q (must be a boolean)", name), "sources", name, "enabled"))
+				continue
+			}
+		}
+		// So is the environment of the source, which policies check
+		var environment string
+		if e, ok := v["environment"]; ok {
+			delete(v, "environment")
+			if environment, ok = e.(string); !ok || environment == "" {
+				errs = append(errs, newConfigError(fmt.Errorf("invalid 'environment' field for source %q (must be a non-empty string)", name), "sources", name, "environment"))
+				continue
+			}
 		}
 
-		sourceConfig, err := sources.DecodeConfig(ctx, kindStr, name, yamlDecoder)
+		var sourceConfig sources.SourceConfig
+		err := decodeResource(ctx, v, func() error {
+			yamlDecoder, err := util.NewStrictDecoder(v)
+			if err != nil {
+				return newConfigError(fmt.Errorf("error creating YAML decoder for source %q: %w", name, err), "sources", name)
+			}
+			sourceConfig, err = sources.DecodeConfig(ctx, kindStr, name, yamlDecoder)
+			if err != nil {
+				return newDecodeError("sources", name, v, err)
+			}
+			return nil
+		})
 		if err != nil {
-			return err
+			errs = append(errs, err)
+			continue
+		}
+		if environment != "" {
+			sourceConfig = environmentSourceConfig{SourceConfig: sourceConfig, environment: environment}
+		}
+		if !enabled {
+			sourceConfig = disabledSourceConfig{SourceConfig: sourceConfig}
 		}
 		(*c)[name] = sourceConfig
 	}
-	return nil
+	return errors.Join(errs...)
 }
 
 // AuthServiceConfigs is a type used to allow unmarshal of the data authService config map
@@ -169,33 +319,46 @@ func (c *AuthServiceConfigs) UnmarshalYAML(ctx context.Context, unmarshal func(i
 		return err
 	}
 
-	for name, u := range raw {
+	// Report the errors of every authService at once
+	const section = "authServices"
+	var errs []error
+	for _, name := range sortedKeys(raw) {
+		u := raw[name]
 		var v map[string]any
 		if err := u.Unmarshal(&v); err != nil {
-			return fmt.Errorf("unable to unmarshal %q: %w", name, err)
+			errs = append(errs, newConfigError(fmt.Errorf("unable to unmarshal %q: %w", name, err), section, name))
+			continue
 		}
 
 		kind, ok := v["kind"]
 		if !ok {
-			return fmt.Errorf("missing 'kind' field for %q", name)
+			errs = append(errs, newConfigError(fmt.Errorf("missing 'kind' field for %q", name), section, name))
+			continue
 		}
 
-		dec, err := util.NewStrictDecoder(v)
-		if err != nil {
-			return fmt.Errorf("error creating decoder: %w", err)
-		}
 		switch kind {
 		case google.AuthServiceKind:
 			actual := google.Config{Name: name}
-			if err := dec.DecodeContext(ctx, &actual); err != nil {
-				return fmt.Errorf("unable to parse as %q: %w", kind, err)
+			err := decodeResource(ctx, v, func() error {
+				dec, err := util.NewStrictDecoder(v)
+				if err != nil {
+					return newConfigError(fmt.Errorf("error creating decoder: %w", err), section, name)
+				}
+				if err := dec.DecodeContext(ctx, &actual); err != nil {
+					return newDecodeError(section, name, v, fmt.Errorf("unable to parse as %q: %w", kind, err))
+				}
+				return nil
+			})
+			if err != nil {
+				errs = append(errs, err)
+				continue
 			}
 			(*c)[name] = actual
 		default:
-			return fmt.Errorf("%q is not a valid kind of auth source", kind)
+			errs = append(errs, newConfigError(fmt.Errorf("%q is not a valid kind of auth source", kind), section, name, "kind"))
 		}
 	}
-	return nil
+	return errors.Join(errs...)
 }
 
 // ToolConfigs is a type used to allow unmarshal of the tool configs
@@ -212,10 +375,14 @@ func (c *ToolConfigs) UnmarshalYAML(ctx context.Context, unmarshal func(interfac
 		return err
 	}
 
-	for name, u := range raw {
+	// Report the errors of every tool at once
+	var errs []error
+	for _, name := range sortedKeys(raw) {
+		u := raw[name]
 		var v map[string]any
 		if err := u.Unmarshal(&v); err != nil {
-			return fmt.Errorf("unable to unmarshal %q: %w", name, err)
+			errs = append(errs, newConfigError(fmt.Errorf("unable to unmarshal %q: %w", name, err), "tools", name))
+			continue
 		}
 
 		// Make `authRequired` an empty list instead of nil for Tool manifest
@@ -223,27 +390,49 @@ func (c *ToolConfigs) UnmarshalYAML(ctx context.Context, unmarshal func(interfac
 			v["authRequired"] = []string{}
 		}
 
+		// Replace references to views with their statements
+		if _, err := ExpandViews(viewConfigsFromContext(ctx), v, ""); err != nil {
+			errs = append(errs, newConfigError(fmt.Errorf("unable to expand views for tool %q: %w", name, err), "tools", name))
+			continue
+		}
+
 		kindVal, ok := v["kind"]
 		if !ok {
-			return fmt.Errorf("missing 'kind' field for tool %q", name)
+			errs = append(errs, newConfigError(fmt.Errorf("missing 'kind' field for tool %q", name), "tools", name))
+			continue
 		}
 		kindStr, ok := kindVal.(string)
 		if !ok {
-			return fmt.Errorf("invalid 'kind' field for tool %q (must be a string)", name)
+			errs = append(errs, newConfigError(fmt.Errorf("invalid 'kind' field for tool %q (must be a string)", name), "tools", name, "kind"))
+			continue
 		}
 
-		yamlDecoder, err := util.NewStrictDecoder(v)
+		// Options common to every kind of tool are handled by Toolbox
+		opts, err := tools.ExtractOptions(ctx, v)
 		if err != nil {
-			return fmt.Errorf("error creating YAML decoder for tool %q: %w", name, err)
+			errs = append(errs, newConfigError(fmt.Errorf("unable to parse options of tool %q: %w", name, err), "tools", name))
+			continue
 		}
 
-		toolCfg, err := tools.DecodeConfig(ctx, kindStr, name, yamlDecoder)
+		var toolCfg tools.ToolConfig
+		err = decodeResource(ctx, v, func() error {
+			yamlDecoder, err := util.NewStrictDecoder(v)
+			if err != nil {
+				return newConfigError(fmt.Errorf("error creating YAML decoder for tool %q: %w", name, err), "tools", name)
+			}
+			toolCfg, err = tools.DecodeConfig(ctx, kindStr, name, yamlDecoder)
+			if err != nil {
+				return newDecodeError("tools", name, v, err)
+			}
+			return nil
+		})
 		if err != nil {
-			return err
+			errs = append(errs, err)
+			continue
 		}
-		(*c)[name] = toolCfg
+		(*c)[name] = tools.WithOptions(toolCfg, opts)
 	}
-	return nil
+	return errors.Join(errs...)
 }
 
 // ToolConfigs is a type used to allow unmarshal of the toolset configs
@@ -265,3 +454,14 @@ func (c *ToolsetConfigs) UnmarshalYAML(ctx context.Context, unmarshal func(inter
 	}
 	return nil
 }
+
+// sortedKeys returns the keys of m in order, so that errors are reported
+// consistently.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,328 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+	"github.com/goccy/go-yaml/token"
+)
+
+// ConfigError is an error in the configuration of a resource. It records the
+// path of the node the error is about, so that it can be located in the file.
+type ConfigError struct {
+	// Path is the keys and sequence indices leading to the node from the root
+	// of the file, e.g. ["tools", "my-tool", "parameters", "0", "name"].
+	Path []string
+	// Line and Column locate the node in the file, once known.
+	Line   int
+	Column int
+	Err    error
+}
+
+func (e *ConfigError) Error() string {
+	if e.Line == 0 {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.message())
+}
+
+func (e *ConfigError) Unwrap() error {
+	return e.Err
+}
+
+// validationRe matches the errors of the struct validator.
+var validationRe = regexp.MustCompile(`Key: '[^']*' Error:Field validation for '([^']*)' failed on the '([^']*)' tag`)
+
+// message returns the message of the error without the position and source
+// of YAML errors, which point into the resource as decoded rather than the
+// file, and with validation failures described by field.
+func (e *ConfigError) message() string {
+	msg := e.Err.Error()
+	var yerr yaml.Error
+	if errors.As(e.Err, &yerr) {
+		msg = strings.Replace(msg, yerr.Error(), yerr.GetMessage(), 1)
+	}
+	return validationRe.ReplaceAllStringFunc(msg, func(s string) string {
+		m := validationRe.FindStringSubmatch(s)
+		if m[2] == "required" {
+			return fmt.Sprintf("missing required field %q", m[1])
+		}
+		return fmt.Sprintf("invalid value for field %q: failed on the %q validation", m[1], m[2])
+	})
+}
+
+// newConfigError returns a ConfigError for the node at path.
+func newConfigError(err error, path ...string) error {
+	return &ConfigError{Path: path, Err: err}
+}
+
+// newDecodeError returns a ConfigError for err, returned decoding the fields
+// v of the resource of section named name. YAML errors point into v marshaled
+// again for decoding, so the node they point at is looked up there to find its
+// path within the resource. Nested configs are marshaled again on their own, so
+// keys are also looked up by name when the position doesn't match.
+func newDecodeError(section, name string, v map[string]any, err error) error {
+	path := []string{section, name}
+	var yerr yaml.Error
+	if !errors.As(err, &yerr) || yerr.GetToken() == nil {
+		return &ConfigError{Path: path, Err: err}
+	}
+	b, merr := yaml.Marshal(v)
+	if merr != nil {
+		return &ConfigError{Path: path, Err: err}
+	}
+	f, perr := parser.ParseBytes(b, 0)
+	if perr != nil || len(f.Docs) == 0 {
+		return &ConfigError{Path: path, Err: err}
+	}
+	tk := yerr.GetToken()
+	matches := findToken(f.Docs[0].Body, nil, func(n *token.Token) bool {
+		return n.Value == tk.Value && n.Position.Line == tk.Position.Line && n.Position.Column == tk.Position.Column
+	})
+	if len(matches) == 0 {
+		matches = findToken(f.Docs[0].Body, nil, func(n *token.Token) bool {
+			return n.Value == tk.Value
+		})
+	}
+	if len(matches) == 1 {
+		path = append(path, matches[0]...)
+	}
+	return &ConfigError{Path: path, Err: err}
+}
+
+type configWarningsKey struct{}
+
+// ConfigWarnings records the unknown fields of resources, which are ignored
+// rather than failing the resources when they're decoded with a context from
+// WithConfigWarnings.
+type ConfigWarnings struct {
+	errs []error
+}
+
+// WithConfigWarnings returns a context in which the unknown fields of
+// resources are recorded in w, and ignored.
+func WithConfigWarnings(ctx context.Context, w *ConfigWarnings) context.Context {
+	return context.WithValue(ctx, configWarningsKey{}, w)
+}
+
+// Add records err, a ConfigError about a field that's ignored.
+func (w *ConfigWarnings) Add(err error) {
+	w.errs = append(w.errs, err)
+}
+
+// Locate returns the recorded unknown fields as ConfigErrors located in the
+// file they were parsed from, sorted by position.
+func (w *ConfigWarnings) Locate(file *ast.File) []error {
+	return locateConfigErrors(file, slices.Clone(w.errs))
+}
+
+// maxIgnoredFields is the maximum number of unknown fields ignored in a
+// resource.
+const maxIgnoredFields = 100
+
+// decodeResource decodes v, the fields of a resource, with decode, which
+// returns the errors of decoding as from newDecodeError. With a context from
+// WithConfigWarnings, unknown fields are recorded and removed from v one at a
+// time, until it decodes.
+func decodeResource(ctx context.Context, v map[string]any, decode func() error) error {
+	err := decode()
+	w, ok := ctx.Value(configWarningsKey{}).(*ConfigWarnings)
+	if !ok {
+		return err
+	}
+	for i := 0; err != nil && i < maxIgnoredFields; i++ {
+		var uerr *yaml.UnknownFieldError
+		var cerr *ConfigError
+		if !errors.As(err, &uerr) || !errors.As(err, &cerr) || len(cerr.Path) <= 2 || !deleteField(v, cerr.Path[2:]) {
+			return err
+		}
+		w.errs = append(w.errs, newConfigError(fmt.Errorf("ignoring %s of %q", uerr.GetMessage(), cerr.Path[1]), cerr.Path...))
+		err = decode()
+	}
+	return err
+}
+
+// deleteField deletes the field at path from v, a decoded YAML mapping, and
+// reports whether it was found.
+func deleteField(v map[string]any, path []string) bool {
+	var node any = v
+	for i, key := range path {
+		switch n := node.(type) {
+		case map[string]any:
+			if i == len(path)-1 {
+				if _, ok := n[key]; !ok {
+					return false
+				}
+				delete(n, key)
+				return true
+			}
+			node = n[key]
+		case []any:
+			idx, err := strconv.Atoi(key)
+			if err != nil || idx < 0 || idx >= len(n) {
+				return false
+			}
+			node = n[idx]
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+// findToken returns the paths, appended to path, of the nodes under node of
+// which the key or value token matches.
+func findToken(node ast.Node, path []string, match func(*token.Token) bool) [][]string {
+	at := func(n ast.Node) bool {
+		tk := n.GetToken()
+		return tk != nil && match(tk)
+	}
+	var found [][]string
+	switch n := unwrapNode(node).(type) {
+	case *ast.MappingNode:
+		for _, mv := range n.Values {
+			found = append(found, findToken(mv, path, match)...)
+		}
+	case *ast.MappingValueNode:
+		p := append(slices.Clone(path), n.Key.GetToken().Value)
+		if at(n.Key) || at(n.Value) {
+			found = append(found, p)
+		}
+		found = append(found, findToken(n.Value, p, match)...)
+	case *ast.SequenceNode:
+		for i, item := range n.Values {
+			p := append(slices.Clone(path), strconv.Itoa(i))
+			if at(item) {
+				found = append(found, p)
+			}
+			found = append(found, findToken(item, p, match)...)
+		}
+	}
+	return found
+}
+
+// unwrapNode returns the value of anchor and tag nodes.
+func unwrapNode(node ast.Node) ast.Node {
+	for {
+		switch n := node.(type) {
+		case *ast.AnchorNode:
+			node = n.Value
+		case *ast.TagNode:
+			node = n.Value
+		default:
+			return node
+		}
+	}
+}
+
+// locate returns the token of the deepest node of path found in node. Nodes
+// reached through aliases or merge keys aren't followed, so fields defined by
+// anchors are located at the resource using them.
+func locate(node ast.Node, path []string) *token.Token {
+	tk := node.GetToken()
+	for _, key := range path {
+		var next ast.Node
+		switch n := unwrapNode(node).(type) {
+		case *ast.MappingNode:
+			for _, mv := range n.Values {
+				if mv.Key.GetToken().Value == key {
+					tk, next = mv.Key.GetToken(), mv.Value
+					break
+				}
+			}
+		case *ast.MappingValueNode:
+			if n.Key.GetToken().Value == key {
+				tk, next = n.Key.GetToken(), n.Value
+			}
+		case *ast.SequenceNode:
+			if i, err := strconv.Atoi(key); err == nil && i >= 0 && i < len(n.Values) {
+				tk, next = n.Values[i].GetToken(), n.Values[i]
+			}
+		}
+		if next == nil {
+			break
+		}
+		node = next
+	}
+	return tk
+}
+
+// LocateConfigErrors sets the line and column of the ConfigErrors in err from
+// the file they were parsed from, and returns them sorted by position. Other
+// errors are returned first, as is.
+func LocateConfigErrors(file *ast.File, err error) error {
+	errs := locateConfigErrors(file, flattenErrors(err))
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	msgs := make([]string, len(errs))
+	for i, e := range errs {
+		msgs[i] = e.Error()
+	}
+	return fmt.Errorf("%d errors:\n  - %s", len(errs), strings.Join(msgs, "\n  - "))
+}
+
+// locateConfigErrors sets the line and column of the ConfigErrors in errs
+// from file, and returns errs sorted by position.
+func locateConfigErrors(file *ast.File, errs []error) []error {
+	for _, e := range errs {
+		var cerr *ConfigError
+		if !errors.As(e, &cerr) || file == nil || len(file.Docs) == 0 || file.Docs[0].Body == nil {
+			continue
+		}
+		// authServices share their config with the deprecated authSources
+		if len(cerr.Path) > 0 && cerr.Path[0] == "authServices" && locate(file.Docs[0].Body, cerr.Path[:1]) == file.Docs[0].Body.GetToken() {
+			cerr.Path[0] = "authSources"
+		}
+		if tk := locate(file.Docs[0].Body, cerr.Path); tk != nil {
+			cerr.Line, cerr.Column = tk.Position.Line, tk.Position.Column
+		}
+	}
+	slices.SortStableFunc(errs, func(a, b error) int {
+		return line(a) - line(b)
+	})
+	return errs
+}
+
+// line returns the line of a located ConfigError, or 0.
+func line(err error) int {
+	var cerr *ConfigError
+	if errors.As(err, &cerr) {
+		return cerr.Line
+	}
+	return 0
+}
+
+// flattenErrors returns the errors joined in err.
+func flattenErrors(err error) []error {
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return []error{err}
+	}
+	var errs []error
+	for _, e := range joined.Unwrap() {
+		errs = append(errs, flattenErrors(e)...)
+	}
+	return errs
+}
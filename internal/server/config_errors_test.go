@@ -0,0 +1,121 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server_test
+
+import (
+	"errors"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/parser"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/sqlitesql"
+)
+
+func TestLocateConfigErrors(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	in := []byte(`tools:
+  base: &base
+    kind: sqlite-sql
+    source: my-sqlite
+    statement: SELECT 1
+  merged:
+    <<: *base
+    description: uses the anchor
+  merged_missing:
+    <<: *base
+  no_kind:
+    source: my-sqlite
+  bad_param:
+    kind: sqlite-sql
+    source: my-sqlite
+    description: some description
+    statement: SELECT :id
+    parameters:
+      - name: id
+        type: integer
+        description: some description
+        foo: bar
+  ok:
+    kind: sqlite-sql
+    source: my-sqlite
+    description: some description
+    statement: SELECT 1
+`)
+	var parsed struct {
+		Tools server.ToolConfigs `yaml:"tools"`
+	}
+	err = yaml.UnmarshalContext(ctx, in, &parsed)
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	file, perr := parser.ParseBytes(in, 0)
+	if perr != nil {
+		t.Fatalf("unexpected error: %s", perr)
+	}
+	got := server.LocateConfigErrors(file, err).Error()
+	want := `4 errors:
+  - line 2, column 3: unable to parse tool "base" as kind "sqlite-sql": missing required field "Description"
+  - line 9, column 3: unable to parse tool "merged_missing" as kind "sqlite-sql": missing required field "Description"
+  - line 11, column 3: missing 'kind' field for tool "no_kind"
+  - line 22, column 9: unable to parse tool "bad_param" as kind "sqlite-sql": unable to parse as "integer": unknown field "foo"`
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect errors: diff %v", diff)
+	}
+}
+
+func TestLocateConfigErrorsSingle(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	in := []byte(`tools:
+  example_tool:
+    kind: sqlite-sql
+    source: my-sqlite
+    description: some description
+    statement: SELECT 1
+    foo: bar
+`)
+	var parsed struct {
+		Tools server.ToolConfigs `yaml:"tools"`
+	}
+	err = yaml.UnmarshalContext(ctx, in, &parsed)
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	file, perr := parser.ParseBytes(in, 0)
+	if perr != nil {
+		t.Fatalf("unexpected error: %s", perr)
+	}
+	err = server.LocateConfigErrors(file, err)
+
+	var cerr *server.ConfigError
+	if !errors.As(err, &cerr) {
+		t.Fatalf("expected a ConfigError, got %T", err)
+	}
+	if diff := cmp.Diff([]string{"tools", "example_tool", "foo"}, cerr.Path); diff != "" {
+		t.Fatalf("incorrect path: diff %v", diff)
+	}
+	want := `line 7, column 5: unable to parse tool "example_tool" as kind "sqlite-sql": unknown field "foo"`
+	if diff := cmp.Diff(want, err.Error()); diff != "" {
+		t.Fatalf("incorrect error: diff %v", diff)
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/googleapis/genai-toolbox/internal/telemetry"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentedTool records the duration of the invocations of a tool, the
+// rows and size of its results, and the bytes billed it reports, as attributes
+// of the invocation span and as metrics.
+type instrumentedTool struct {
+	tools.Tool
+	name            string
+	instrumentation *telemetry.Instrumentation
+	// variant is the canary variant of the tool, if it has a candidate
+	// definition, so that the metrics of the variants can be compared.
+	variant string
+	// slowThreshold is the duration beyond which invocations are tagged as
+	// slow on their span and logged. Invocations are never slow if it is
+	// zero.
+	slowThreshold time.Duration
+}
+
+func (t instrumentedTool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	ic := tools.InvocationContextFromContext(ctx)
+	ic.ToolName = t.name
+	ctx = tools.WithInvocationContext(ctx, ic)
+
+	stats := &telemetry.InvocationStats{}
+	start := time.Now()
+	res, err := t.Tool.Invoke(telemetry.WithInvocationStats(ctx, stats), params)
+	duration := time.Since(start)
+
+	metricAttrs := []attribute.KeyValue{attribute.String("toolbox.name", t.name)}
+	if t.variant != "" {
+		metricAttrs = append(metricAttrs, attribute.String("toolbox.canary.variant", t.variant))
+	}
+	status := "success"
+	if err != nil {
+		status = "error"
+	}
+	t.instrumentation.ToolInvokeDuration.Record(ctx, float64(duration.Microseconds())/1000, metric.WithAttributes(append(metricAttrs, attribute.String("toolbox.operation.status", status))...))
+	if t.slowThreshold > 0 && duration >= t.slowThreshold {
+		t.logSlow(ctx, duration, stats.Statement())
+	}
+	if err != nil {
+		return res, err
+	}
+
+	nameAttr := metric.WithAttributes(metricAttrs...)
+	rows := resultRows(res)
+	attrs := []attribute.KeyValue{attribute.Int64("toolbox.tool.rows", rows)}
+	t.instrumentation.ToolInvokeRows.Record(ctx, rows, nameAttr)
+	if size, err := resultSize(res); err == nil {
+		attrs = append(attrs, attribute.Int64("toolbox.tool.result.size", size))
+		t.instrumentation.ToolInvokeResultSize.Record(ctx, size, nameAttr)
+	}
+	if billed, ok := stats.BytesBilled(); ok {
+		attrs = append(attrs, attribute.Int64("toolbox.tool.bytes_billed", billed))
+		t.instrumentation.ToolInvokeBytesBilled.Add(ctx, billed, nameAttr)
+	}
+	var drifted int64
+	for kind, n := range stats.ContractDrift() {
+		drifted += n
+		t.instrumentation.ToolContractDrift.Add(ctx, n, metric.WithAttributes(append(metricAttrs, attribute.String("toolbox.contract.drift", kind))...))
+	}
+	if drifted > 0 {
+		attrs = append(attrs, attribute.Int64("toolbox.tool.contract.drift", drifted))
+	}
+	trace.SpanFromContext(ctx).SetAttributes(attrs...)
+	return res, nil
+}
+
+// logSlow tags the span of an invocation which took longer than the slow
+// threshold, and logs it with its trace ID and the statement it executed, if
+// the tool records it.
+func (t instrumentedTool) logSlow(ctx context.Context, duration time.Duration, statement string) {
+	span := trace.SpanFromContext(ctx)
+	attrs := []attribute.KeyValue{
+		attribute.Bool("toolbox.tool.slow", true),
+		attribute.Float64("toolbox.tool.duration_ms", float64(duration.Microseconds())/1000),
+	}
+	if statement != "" {
+		attrs = append(attrs, attribute.String("db.query.text", statement))
+	}
+	span.SetAttributes(attrs...)
+
+	logger, err := util.LoggerFromContext(ctx)
+	if err != nil {
+		return
+	}
+	msg := fmt.Sprintf("slow invocation of tool %q: took %s, beyond the threshold of %s", t.name, duration.Round(time.Millisecond), t.slowThreshold)
+	if sc := span.SpanContext(); sc.HasTraceID() {
+		msg += fmt.Sprintf(", trace %s", sc.TraceID())
+	}
+	if statement != "" {
+		msg += fmt.Sprintf(", statement: %s", statement)
+	}
+	logger.WarnContext(ctx, msg)
+}
+
+// resultRows returns the number of rows of a tool result, which is the length
+// of the result if it is a list. Strings are messages such as "The query
+// returned 0 rows." rather than rows, and any other result is a single row.
+func resultRows(res any) int64 {
+	switch res.(type) {
+	case nil, string:
+		return 0
+	}
+	if v := reflect.ValueOf(res); v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+		return int64(v.Len())
+	}
+	return 1
+}
+
+// invocationContext returns the context of the invocations of a request, with
+// the headers the server passes to tools. The caller and client are set by
+// the handlers that know them.
+func (s *Server) invocationContext(r *http.Request, sessionID string) tools.InvocationContext {
+	requestID := r.Header.Get("X-Request-Id")
+	if requestID == "" {
+		requestID = s.newID()
+	}
+	var headers http.Header
+	for _, name := range s.invocationHeaders {
+		if values := r.Header.Values(name); len(values) > 0 {
+			if headers == nil {
+				headers = make(http.Header)
+			}
+			headers[name] = slices.Clone(values)
+		}
+	}
+	return tools.InvocationContext{RequestID: requestID, SessionID: sessionID, Headers: headers}
+}
+
+// withCaller sets the caller of ic from the claims verified by auth services.
+func withCaller(ic tools.InvocationContext, claimsFromAuth map[string]map[string]any) tools.InvocationContext {
+	ic.AuthServices = slices.Sorted(maps.Keys(claimsFromAuth))
+	ic.Claims = claimsFromAuth
+	ic.Caller = ""
+	for _, name := range ic.AuthServices {
+		for _, claim := range []string{"email", "sub"} {
+			if v, ok := claimsFromAuth[name][claim].(string); ok && v != "" {
+				ic.Caller = v
+				return ic
+			}
+		}
+	}
+	return ic
+}
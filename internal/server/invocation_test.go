@@ -0,0 +1,369 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/log"
+	"github.com/googleapis/genai-toolbox/internal/telemetry"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"go.opentelemetry.io/otel"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/exemplar"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// billingTool reports bytes billed and returns two rows.
+type billingTool struct {
+	MockTool
+}
+
+func (billingTool) Invoke(ctx context.Context, _ tools.ParamValues) (any, error) {
+	telemetry.InvocationStatsFromContext(ctx).AddBytesBilled(1024)
+	return []any{map[string]any{"a": 1}, map[string]any{"a": 2}}, nil
+}
+
+func TestInstrumentedToolInvoke(t *testing.T) {
+	instrumentation, err := telemetry.CreateTelemetryInstrumentation(fakeVersionString)
+	if err != nil {
+		t.Fatalf("unable to create instrumentation: %s", err)
+	}
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+	tool := instrumentedTool{Tool: billingTool{}, name: "billing", instrumentation: instrumentation}
+	ctx, span := tracer.Start(context.Background(), "invoke")
+	res, err := tool.Invoke(ctx, nil)
+	span.End()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if rows, ok := res.([]any); !ok || len(rows) != 2 {
+		t.Fatalf("unexpected result: %v", res)
+	}
+
+	want := map[string]int64{
+		"toolbox.tool.rows":         2,
+		"toolbox.tool.result.size":  int64(len(`[{"a":1},{"a":2}]`)),
+		"toolbox.tool.bytes_billed": 1024,
+	}
+	got := make(map[string]int64)
+	for _, attr := range recorder.Ended()[0].Attributes() {
+		got[string(attr.Key)] = attr.Value.AsInt64()
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("unexpected span attribute %q: want %d, got %d", k, v, got[k])
+		}
+	}
+}
+
+// slowTool executes a statement which takes a few milliseconds.
+type slowTool struct {
+	MockTool
+}
+
+func (slowTool) Invoke(ctx context.Context, _ tools.ParamValues) (any, error) {
+	_ = tools.TagStatement(ctx, "SELECT pg_sleep(0.005)")
+	time.Sleep(5 * time.Millisecond)
+	return []any{}, nil
+}
+
+func TestInstrumentedToolSlowInvoke(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader), sdkmetric.WithExemplarFilter(exemplar.TraceBasedFilter)))
+	instrumentation, err := telemetry.CreateTelemetryInstrumentation(fakeVersionString)
+	if err != nil {
+		t.Fatalf("unable to create instrumentation: %s", err)
+	}
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+	tcs := []struct {
+		desc      string
+		threshold time.Duration
+		wantSlow  bool
+	}{
+		{desc: "disabled", threshold: 0},
+		{desc: "below threshold", threshold: time.Hour},
+		{desc: "beyond threshold", threshold: time.Millisecond, wantSlow: true},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			var out bytes.Buffer
+			logger, err := log.NewStdLogger(&out, &out, "info")
+			if err != nil {
+				t.Fatalf("unable to create logger: %s", err)
+			}
+			tool := instrumentedTool{Tool: slowTool{}, name: "slow", instrumentation: instrumentation, slowThreshold: tc.threshold}
+			ctx, span := tracer.Start(util.WithLogger(context.Background(), logger), "invoke")
+			_, err = tool.Invoke(ctx, nil)
+			span.End()
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			ended := recorder.Ended()
+			got := make(map[string]string)
+			for _, attr := range ended[len(ended)-1].Attributes() {
+				got[string(attr.Key)] = attr.Value.Emit()
+			}
+			if !tc.wantSlow {
+				if _, ok := got["toolbox.tool.slow"]; ok || out.Len() > 0 {
+					t.Fatalf("unexpected slow invocation, attributes %v, logs %q", got, out.String())
+				}
+				return
+			}
+			if got["toolbox.tool.slow"] != "true" || got["db.query.text"] != "SELECT pg_sleep(0.005)" {
+				t.Fatalf("unexpected span attributes: %v", got)
+			}
+			for _, want := range []string{`slow invocation of tool \"slow\"`, "beyond the threshold of 1ms", span.SpanContext().TraceID().String(), "statement: SELECT pg_sleep(0.005)"} {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("log %q doesn't contain %q", out.String(), want)
+				}
+			}
+		})
+	}
+
+	// the durations link to the traces of the invocations
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("unable to collect metrics: %s", err)
+	}
+	var exemplars int
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "toolbox.server.tool.invoke.duration" {
+				continue
+			}
+			for _, dp := range m.Data.(metricdata.Histogram[float64]).DataPoints {
+				for _, e := range dp.Exemplars {
+					if len(e.TraceID) == 0 {
+						t.Errorf("exemplar without trace ID: %+v", e)
+					}
+					exemplars++
+				}
+			}
+		}
+	}
+	if exemplars == 0 {
+		t.Fatalf("no exemplars recorded on the invocation duration")
+	}
+}
+
+func TestResultRows(t *testing.T) {
+	tcs := []struct {
+		desc string
+		res  any
+		want int64
+	}{
+		{desc: "nil", res: nil, want: 0},
+		{desc: "rows", res: []any{1, 2, 3}, want: 3},
+		{desc: "typed rows", res: []map[string]any{{}, {}}, want: 2},
+		{desc: "single value", res: map[string]any{"a": 1}, want: 1},
+		{desc: "message", res: "The query returned 0 rows.", want: 0},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := resultRows(tc.res); got != tc.want {
+				t.Fatalf("unexpected rows: want %d, got %d", tc.want, got)
+			}
+		})
+	}
+}
+
+// contextTool returns the invocation context it is invoked with.
+type contextTool struct {
+	MockTool
+}
+
+func (contextTool) Invoke(ctx context.Context, _ tools.ParamValues) (any, error) {
+	return []any{tools.InvocationContextFromContext(ctx)}, nil
+}
+
+func TestInvocationContext(t *testing.T) {
+	s := &Server{invocationHeaders: []string{"X-Tenant-Id"}}
+	r := httptest.NewRequest(http.MethodPost, "/", nil)
+	r.Header.Set("X-Request-Id", "request-1")
+	r.Header.Set("X-Tenant-Id", "tenant-1")
+	r.Header.Set("Authorization", "Bearer secret")
+
+	ic := withCaller(s.invocationContext(r, "session-1"), map[string]map[string]any{
+		"b-auth": {"email": "b@example.com"},
+		"a-auth": {"sub": "1234"},
+	})
+	want := tools.InvocationContext{
+		RequestID:    "request-1",
+		SessionID:    "session-1",
+		Caller:       "1234",
+		AuthServices: []string{"a-auth", "b-auth"},
+		Claims: map[string]map[string]any{
+			"b-auth": {"email": "b@example.com"},
+			"a-auth": {"sub": "1234"},
+		},
+		Headers: http.Header{"X-Tenant-Id": {"tenant-1"}},
+	}
+	if diff := cmp.Diff(want, ic); diff != "" {
+		t.Fatalf("incorrect invocation context: diff %v", diff)
+	}
+
+	// the tool name is set by the instrumented tool
+	instrumentation, err := telemetry.CreateTelemetryInstrumentation(fakeVersionString)
+	if err != nil {
+		t.Fatalf("unable to create instrumentation: %s", err)
+	}
+	tool := instrumentedTool{Tool: contextTool{}, name: "context", instrumentation: instrumentation}
+	res, err := tool.Invoke(tools.WithInvocationContext(context.Background(), ic), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want.ToolName = "context"
+	if diff := cmp.Diff([]any{want}, res); diff != "" {
+		t.Fatalf("incorrect invocation context: diff %v", diff)
+	}
+}
+
+func TestInvocationContextDeterministicIDs(t *testing.T) {
+	requestIDs := func() []string {
+		s := &Server{}
+		WithIDGenerator(util.SeededIDs(42))(s)
+		var got []string
+		for i := 0; i < 3; i++ {
+			r := httptest.NewRequest(http.MethodPost, "/", nil)
+			got = append(got, s.invocationContext(r, "").RequestID)
+		}
+		return got
+	}
+	first, second := requestIDs(), requestIDs()
+	if diff := cmp.Diff(first, second); diff != "" {
+		t.Fatalf("request IDs differ between runs with the same seed: diff %v", diff)
+	}
+	if first[0] == first[1] {
+		t.Fatalf("request IDs repeat within a run: %v", first)
+	}
+}
+
+func TestInvocationContextEndpoints(t *testing.T) {
+	tool := contextTool{MockTool{Name: "context"}}
+	toolsMap := map[string]tools.Tool{tool.Name: tool}
+	toolset, err := tools.ToolsetConfig{Name: "", ToolNames: []string{tool.Name}}.Initialize(fakeVersionString, toolsMap)
+	if err != nil {
+		t.Fatalf("unable to initialize toolset: %s", err)
+	}
+	toolsets := map[string]tools.Toolset{"": toolset}
+
+	t.Run("api", func(t *testing.T) {
+		r, shutdown := setUpServer(t, "api", toolsMap, toolsets)
+		defer shutdown()
+		ts := runServer(r, false)
+		defer ts.Close()
+
+		_, body, err := runRequest(ts, http.MethodPost, fmt.Sprintf("/tool/%s/invoke", tool.Name), bytes.NewBuffer([]byte(`{}`)), map[string]string{"X-Request-Id": "request-1"})
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		var got struct {
+			Result string `json:"result"`
+		}
+		if err := json.Unmarshal(body, &got); err != nil {
+			t.Fatalf("unable to parse response: %s", err)
+		}
+		var ics []tools.InvocationContext
+		if err := json.Unmarshal([]byte(got.Result), &ics); err != nil {
+			t.Fatalf("unable to parse result %q: %s", got.Result, err)
+		}
+		if len(ics) != 1 || ics[0].RequestID != "request-1" {
+			t.Fatalf("unexpected invocation context: %s", got.Result)
+		}
+	})
+
+	t.Run("mcp", func(t *testing.T) {
+		r, shutdown := setUpServer(t, "mcp", toolsMap, toolsets)
+		defer shutdown()
+		ts := runServer(r, false)
+		defer ts.Close()
+
+		initialize := map[string]any{
+			"jsonrpc": jsonrpcVersion,
+			"id":      "mcp-initialize",
+			"method":  "initialize",
+			"params": map[string]any{
+				"protocolVersion": protocolVersion20250326,
+				"clientInfo":      map[string]any{"name": "test-client", "version": "1.0.0"},
+			},
+		}
+		reqMarshal, err := json.Marshal(initialize)
+		if err != nil {
+			t.Fatalf("unexpected error during marshaling of body")
+		}
+		resp, _, err := runRequest(ts, http.MethodPost, "/", bytes.NewBuffer(reqMarshal), nil)
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		sessionId := resp.Header.Get("Mcp-Session-Id")
+		if sessionId == "" {
+			t.Fatalf("Mcp-Session-Id header is expected")
+		}
+
+		call := map[string]any{
+			"jsonrpc": jsonrpcVersion,
+			"id":      "tools-call",
+			"method":  "tools/call",
+			"params":  map[string]any{"name": tool.Name, "arguments": map[string]any{}},
+		}
+		reqMarshal, err = json.Marshal(call)
+		if err != nil {
+			t.Fatalf("unexpected error during marshaling of body")
+		}
+		_, body, err := runRequest(ts, http.MethodPost, "/", bytes.NewBuffer(reqMarshal), map[string]string{"Mcp-Session-Id": sessionId})
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		var got struct {
+			Result struct {
+				Content []struct {
+					Text string `json:"text"`
+				} `json:"content"`
+			} `json:"result"`
+		}
+		if err := json.Unmarshal(body, &got); err != nil {
+			t.Fatalf("unable to parse response: %s", err)
+		}
+		if len(got.Result.Content) != 1 {
+			t.Fatalf("unexpected response: %s", body)
+		}
+		var ic tools.InvocationContext
+		if err := json.Unmarshal([]byte(got.Result.Content[0].Text), &ic); err != nil {
+			t.Fatalf("unable to parse content %q: %s", got.Result.Content[0].Text, err)
+		}
+		want := tools.ClientInfo{Name: "test-client", Version: "1.0.0"}
+		if ic.Client != want || ic.SessionID != sessionId {
+			t.Fatalf("unexpected invocation context: %+v", ic)
+		}
+	})
+}
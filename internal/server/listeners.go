@@ -0,0 +1,160 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// unixPrefix prefixes the listen addresses of unix sockets, as in
+// "unix:/run/toolbox/admin.sock".
+const unixPrefix = "unix:"
+
+// listener is an address the server listens on, with the endpoints served to
+// its clients.
+type listener struct {
+	// admin is true for the listener of AdminAddress, which only serves the
+	// admin endpoints.
+	admin   bool
+	network string
+	address string
+	srv     *http.Server
+	ln      net.Listener
+}
+
+// String returns the address of l in the format of the listen flags.
+func (l *listener) String() string {
+	if l.network == "unix" {
+		return unixPrefix + l.address
+	}
+	return l.address
+}
+
+// parseListenAddress parses a listen address, either "host:port" or
+// "unix:" followed by the path of a socket, into its network and address.
+func parseListenAddress(s string) (string, string, error) {
+	if path, ok := strings.CutPrefix(s, unixPrefix); ok {
+		if path == "" {
+			return "", "", fmt.Errorf("listen address %q is missing the path of the socket", s)
+		}
+		return "unix", path, nil
+	}
+	if _, port, err := net.SplitHostPort(s); err != nil {
+		return "", "", fmt.Errorf("listen address %q must be \"host:port\" or \"unix:/path/to/socket\": %w", s, err)
+	} else if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+		return "", "", fmt.Errorf("listen address %q has an invalid port", s)
+	}
+	return "tcp", s, nil
+}
+
+// agentAddresses returns the addresses the agent-facing endpoints of cfg are
+// served on: Address and Port, followed by ListenAddresses.
+func agentAddresses(cfg ServerConfig) []string {
+	return append([]string{net.JoinHostPort(cfg.Address, strconv.Itoa(cfg.Port))}, cfg.ListenAddresses...)
+}
+
+// isLocalAddress returns whether clients of a listen address can only be on
+// the same host, as for unix sockets and loopback addresses.
+func isLocalAddress(s string) bool {
+	if strings.HasPrefix(s, unixPrefix) {
+		return true
+	}
+	host, _, err := net.SplitHostPort(s)
+	return err == nil && isLoopback(host)
+}
+
+// newListeners returns the listeners of cfg, serving agent with the
+// agent-facing endpoints and admin, if AdminAddress is set, with the admin
+// endpoints. It returns an error if the admin endpoints could be reached
+// from the agent-facing addresses or from other hosts.
+func newListeners(cfg ServerConfig, agent, admin http.Handler) ([]*listener, error) {
+	var listeners []*listener
+	seen := make(map[string]bool)
+	add := func(s string, isAdmin bool, h http.Handler) error {
+		network, address, err := parseListenAddress(s)
+		if err != nil {
+			return err
+		}
+		if seen[network+" "+address] {
+			if isAdmin {
+				return fmt.Errorf("admin address %q must not be an agent-facing address", s)
+			}
+			return fmt.Errorf("listen address %q is set more than once", s)
+		}
+		seen[network+" "+address] = true
+		listeners = append(listeners, &listener{
+			admin:   isAdmin,
+			network: network,
+			address: address,
+			srv:     &http.Server{Addr: address, Handler: h},
+		})
+		return nil
+	}
+	for _, s := range agentAddresses(cfg) {
+		if err := add(s, false, agent); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.AdminAddress == "" {
+		return listeners, nil
+	}
+	if cfg.AdminToken == "" {
+		return nil, fmt.Errorf("admin address %q requires an admin token", cfg.AdminAddress)
+	}
+	if !isLocalAddress(cfg.AdminAddress) {
+		return nil, fmt.Errorf("admin address %q must be a loopback address or a unix socket", cfg.AdminAddress)
+	}
+	if err := add(cfg.AdminAddress, true, admin); err != nil {
+		return nil, err
+	}
+	return listeners, nil
+}
+
+// listen opens the listener of l. A socket left behind at the path of a unix
+// socket is replaced, and the socket of the admin endpoints is only
+// accessible to the user running Toolbox.
+func (l *listener) listen(ctx context.Context) error {
+	if l.network == "unix" {
+		if fi, err := os.Lstat(l.address); err == nil && fi.Mode()&fs.ModeSocket != 0 {
+			if err := os.Remove(l.address); err != nil {
+				return fmt.Errorf("unable to remove stale socket %q: %w", l.address, err)
+			}
+		} else if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("unable to check socket %q: %w", l.address, err)
+		}
+	}
+	lc := net.ListenConfig{KeepAlive: 30 * time.Second}
+	ln, err := lc.Listen(ctx, l.network, l.address)
+	if err != nil {
+		return fmt.Errorf("failed to open listener for %q: %w", l, err)
+	}
+	if l.network == "unix" && l.admin {
+		if err := os.Chmod(l.address, 0o600); err != nil {
+			ln.Close()
+			return fmt.Errorf("unable to restrict access to socket %q: %w", l.address, err)
+		}
+	}
+	l.ln = ln
+	return nil
+}
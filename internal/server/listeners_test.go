@@ -0,0 +1,209 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseListenAddress(t *testing.T) {
+	tcs := []struct {
+		in          string
+		wantNetwork string
+		wantAddress string
+		wantErr     string
+	}{
+		{in: "127.0.0.1:5000", wantNetwork: "tcp", wantAddress: "127.0.0.1:5000"},
+		{in: "[::1]:5000", wantNetwork: "tcp", wantAddress: "[::1]:5000"},
+		{in: "unix:/run/toolbox.sock", wantNetwork: "unix", wantAddress: "/run/toolbox.sock"},
+		{in: "unix:", wantErr: "missing the path"},
+		{in: "127.0.0.1", wantErr: "must be \"host:port\""},
+		{in: "127.0.0.1:http", wantErr: "invalid port"},
+		{in: "127.0.0.1:70000", wantErr: "invalid port"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.in, func(t *testing.T) {
+			network, address, err := parseListenAddress(tc.in)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("want error containing %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if network != tc.wantNetwork || address != tc.wantAddress {
+				t.Fatalf("got %q %q, want %q %q", network, address, tc.wantNetwork, tc.wantAddress)
+			}
+		})
+	}
+}
+
+func TestNewListeners(t *testing.T) {
+	tcs := []struct {
+		desc    string
+		cfg     ServerConfig
+		want    []string
+		wantErr string
+	}{
+		{
+			desc: "address and port",
+			cfg:  ServerConfig{Address: "127.0.0.1", Port: 5000},
+			want: []string{"127.0.0.1:5000"},
+		},
+		{
+			desc: "further listen addresses and admin socket",
+			cfg: ServerConfig{
+				Address:         "127.0.0.1",
+				Port:            5000,
+				ListenAddresses: []string{"[::1]:5000", "unix:/run/toolbox.sock"},
+				AdminToken:      "admin-token",
+				AdminAddress:    "unix:/run/toolbox-admin.sock",
+			},
+			want: []string{"127.0.0.1:5000", "[::1]:5000", "unix:/run/toolbox.sock", "admin unix:/run/toolbox-admin.sock"},
+		},
+		{
+			desc: "admin on loopback",
+			cfg:  ServerConfig{Address: "0.0.0.0", Port: 5000, AdminToken: "admin-token", AdminAddress: "127.0.0.1:5001"},
+			want: []string{"0.0.0.0:5000", "admin 127.0.0.1:5001"},
+		},
+		{
+			desc:    "duplicate listen address",
+			cfg:     ServerConfig{Address: "127.0.0.1", Port: 5000, ListenAddresses: []string{"127.0.0.1:5000"}},
+			wantErr: "is set more than once",
+		},
+		{
+			desc:    "invalid listen address",
+			cfg:     ServerConfig{Address: "127.0.0.1", Port: 5000, ListenAddresses: []string{"localhost"}},
+			wantErr: "must be \"host:port\"",
+		},
+		{
+			desc:    "admin on an agent-facing address",
+			cfg:     ServerConfig{Address: "127.0.0.1", Port: 5000, AdminToken: "admin-token", AdminAddress: "127.0.0.1:5000"},
+			wantErr: "must not be an agent-facing address",
+		},
+		{
+			desc:    "admin without token",
+			cfg:     ServerConfig{Address: "127.0.0.1", Port: 5000, AdminAddress: "unix:/run/toolbox-admin.sock"},
+			wantErr: "requires an admin token",
+		},
+		{
+			desc:    "admin on every interface",
+			cfg:     ServerConfig{Address: "127.0.0.1", Port: 5000, AdminToken: "admin-token", AdminAddress: "0.0.0.0:5001"},
+			wantErr: "must be a loopback address or a unix socket",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			listeners, err := newListeners(tc.cfg, http.NotFoundHandler(), http.NotFoundHandler())
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("want error containing %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			var got []string
+			for _, l := range listeners {
+				if l.admin {
+					got = append(got, "admin "+l.String())
+				} else {
+					got = append(got, l.String())
+				}
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect listeners (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestListenersServeDistinctHandlers(t *testing.T) {
+	dir := t.TempDir()
+	agentSock := filepath.Join(dir, "agent.sock")
+	adminSock := filepath.Join(dir, "admin.sock")
+	cfg := ServerConfig{
+		Address:         "127.0.0.1",
+		Port:            0,
+		ListenAddresses: []string{unixPrefix + agentSock},
+		AdminToken:      "admin-token",
+		AdminAddress:    unixPrefix + adminSock,
+	}
+	listeners, err := newListeners(cfg,
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { _, _ = io.WriteString(w, "agent") }),
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { _, _ = io.WriteString(w, "admin") }),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// a stale socket file is replaced
+	stale, err := net.Listen("unix", adminSock)
+	if err != nil {
+		t.Fatalf("unable to create stale socket: %s", err)
+	}
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	stale.Close()
+
+	ctx := context.Background()
+	for _, l := range listeners {
+		if err := l.listen(ctx); err != nil {
+			t.Fatalf("unable to listen on %s: %s", l, err)
+		}
+		go func() { _ = l.srv.Serve(l.ln) }()
+		defer l.srv.Shutdown(ctx)
+	}
+
+	fi, err := os.Stat(adminSock)
+	if err != nil {
+		t.Fatalf("unable to stat admin socket: %s", err)
+	}
+	if got := fi.Mode().Perm(); got != 0o600 {
+		t.Fatalf("admin socket has permissions %o, want 600", got)
+	}
+
+	for sock, want := range map[string]string{agentSock: "agent", adminSock: "admin"} {
+		client := &http.Client{Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", sock)
+			},
+		}}
+		resp, err := client.Get("http://toolbox/admin")
+		if err != nil {
+			t.Fatalf("unable to send request to %s: %s", sock, err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatalf("unable to read response from %s: %s", sock, err)
+		}
+		if string(body) != want {
+			t.Fatalf("got %q from %s, want %q", body, sock, want)
+		}
+	}
+}
@@ -22,18 +22,21 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
-	"github.com/google/uuid"
+	"github.com/googleapis/genai-toolbox/internal/quota"
 	"github.com/googleapis/genai-toolbox/internal/server/mcp"
 	"github.com/googleapis/genai-toolbox/internal/server/mcp/jsonrpc"
 	mcputil "github.com/googleapis/genai-toolbox/internal/server/mcp/util"
 	v20241105 "github.com/googleapis/genai-toolbox/internal/server/mcp/v20241105"
 	v20250326 "github.com/googleapis/genai-toolbox/internal/server/mcp/v20250326"
+	"github.com/googleapis/genai-toolbox/internal/tools"
 	"github.com/googleapis/genai-toolbox/internal/util"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
@@ -46,6 +49,8 @@ type sseSession struct {
 	done       chan struct{}
 	eventQueue chan string
 	lastActive time.Time
+	log        mcpSessionLog
+	requests   mcpClientRequests
 }
 
 // sseManager manages and control access to sse sessions
@@ -108,18 +113,127 @@ func (m *sseManager) cleanupRoutine(ctx context.Context) {
 	}
 }
 
+// mcpClientManager remembers the clients of MCP sessions, as they described
+// themselves when initializing the session.
+type mcpClientManager struct {
+	mu      sync.Mutex
+	clients map[string]*mcpClient
+}
+
+type mcpClient struct {
+	info       tools.ClientInfo
+	lastActive time.Time
+	log        *mcpSessionLog
+	requests   *mcpClientRequests
+}
+
+func newMcpClientManager(ctx context.Context) *mcpClientManager {
+	m := &mcpClientManager{clients: make(map[string]*mcpClient)}
+	go m.cleanupRoutine(ctx)
+	return m
+}
+
+func (m *mcpClientManager) set(sessionID string, info tools.ClientInfo, capabilities mcputil.ClientCapabilities) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	client := &mcpClient{info: info, lastActive: time.Now(), log: &mcpSessionLog{}, requests: &mcpClientRequests{}}
+	client.requests.setCapabilities(capabilities)
+	m.clients[sessionID] = client
+}
+
+// sessionLog returns the log level of a session, remembering the session if
+// it is unknown.
+func (m *mcpClientManager) sessionLog(sessionID string) *mcpSessionLog {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.client(sessionID).log
+}
+
+// sessionRequests returns the requests sent to the client of a session,
+// remembering the session if it is unknown.
+func (m *mcpClientManager) sessionRequests(sessionID string) *mcpClientRequests {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.client(sessionID).requests
+}
+
+// client returns the client of a session, remembering the session if it is
+// unknown. It must be called with mu held.
+func (m *mcpClientManager) client(sessionID string) *mcpClient {
+	client, ok := m.clients[sessionID]
+	if !ok {
+		client = &mcpClient{log: &mcpSessionLog{}, requests: &mcpClientRequests{}}
+		m.clients[sessionID] = client
+	}
+	client.lastActive = time.Now()
+	return client
+}
+
+// get returns the client of a session, or a zero ClientInfo if it is unknown.
+func (m *mcpClientManager) get(sessionID string) tools.ClientInfo {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	client, ok := m.clients[sessionID]
+	if !ok {
+		return tools.ClientInfo{}
+	}
+	client.lastActive = time.Now()
+	return client.info
+}
+
+func (m *mcpClientManager) cleanupRoutine(ctx context.Context) {
+	timeout := 10 * time.Minute
+	ticker := time.NewTicker(timeout)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.mu.Lock()
+			now := time.Now()
+			for id, client := range m.clients {
+				if now.Sub(client.lastActive) > timeout {
+					delete(m.clients, id)
+				}
+			}
+			m.mu.Unlock()
+		}
+	}
+}
+
+// initializeClientInfo returns the client of an initialize request and the
+// capabilities it declares, and false if body is another message.
+func initializeClientInfo(body []byte) (tools.ClientInfo, mcputil.ClientCapabilities, bool) {
+	var req mcputil.InitializeRequest
+	if err := json.Unmarshal(body, &req); err != nil || req.Method != mcputil.INITIALIZE {
+		return tools.ClientInfo{}, mcputil.ClientCapabilities{}, false
+	}
+	return tools.ClientInfo{Name: req.Params.ClientInfo.Name, Version: req.Params.ClientInfo.Version}, req.Params.Capabilities, true
+}
+
 type stdioSession struct {
+	id       string
 	protocol string
+	client   tools.ClientInfo
 	server   *Server
 	reader   *bufio.Reader
 	writer   io.Writer
+	log      mcpSessionLog
+	requests mcpClientRequests
+	calls    *stdioCalls
+	// mu serializes the writes of responses, log messages and requests
+	mu sync.Mutex
 }
 
 func NewStdioSession(s *Server, stdin io.Reader, stdout io.Writer) *stdioSession {
 	stdioSession := &stdioSession{
+		id:     s.newID(),
 		server: s,
 		reader: bufio.NewReader(stdin),
 		writer: stdout,
+		calls:  newStdioCalls(s.stdioWorkers),
 	}
 	return stdioSession
 }
@@ -130,35 +244,131 @@ func (s *stdioSession) Start(ctx context.Context) error {
 
 // readInputStream reads requests/notifications from MCP clients through stdin
 func (s *stdioSession) readInputStream(ctx context.Context) error {
+	ctx = util.WithSessionID(ctx, s.id)
+	// a failure to write a response ends the session
+	ctx, fail := context.WithCancelCause(ctx)
+	defer fail(nil)
+	messages, readErr, done := s.readMessages(ctx)
+	defer close(done)
 	for {
-		if err := ctx.Err(); err != nil {
-			return err
-		}
-		line, err := s.readLine(ctx)
-		if err != nil {
+		message, ok := <-messages
+		if !ok {
+			err := <-readErr
+			// the calls running are answered before the session ends
+			s.calls.wait()
 			if err == io.EOF {
 				return nil
 			}
+			if cause := context.Cause(ctx); cause != nil {
+				return cause
+			}
 			return err
 		}
-		v, res, err := processMcpMessage(ctx, []byte(line), s.server, s.protocol, "")
-		if err != nil {
-			// errors during the processing of message will generate a valid MCP Error response.
-			// server can continue to run.
-			s.server.logger.ErrorContext(ctx, err.Error())
+		line := message.line
+		if call := message.call; call != nil {
+			protocol, client := s.protocol, s.client
+			err := s.calls.start(ctx, call, func(ctx context.Context) {
+				_, res := s.process(ctx, line, protocol, client)
+				switch context.Cause(ctx) {
+				case errCallAbandoned:
+					return
+				case errCallCancelled:
+					res = jsonrpc.NewError(call.id, jsonrpc.REQUEST_CANCELLED, errCallCancelled.Error(), nil)
+				}
+				if err := s.write(ctx, res); err != nil {
+					fail(err)
+				}
+			})
+			if err != nil {
+				s.calls.wait()
+				return context.Cause(ctx)
+			}
+			continue
 		}
+		if info, capabilities, ok := initializeClientInfo([]byte(line)); ok {
+			s.client = info
+			s.requests.setCapabilities(capabilities)
+		}
+		v, res := s.process(ctx, line, s.protocol, s.client)
 		if v != "" {
 			s.protocol = v
 		}
 		// no responses for notifications
 		if res != nil {
-			if err = s.write(ctx, res); err != nil {
+			if err := s.write(ctx, res); err != nil {
 				return err
 			}
 		}
 	}
 }
 
+// process processes a message of the client, and returns the protocol
+// version it negotiates and its response.
+func (s *stdioSession) process(ctx context.Context, line, protocol string, client tools.ClientInfo) (string, any) {
+	ic := tools.InvocationContext{RequestID: s.server.newID(), SessionID: s.id, Client: client}
+	logging := &mcpLogging{session: &s.log, send: func(notification any) { _ = s.write(ctx, notification) }}
+	sampling := &mcpSampling{requests: &s.requests, send: func(request any) error { return s.write(ctx, request) }}
+	msgCtx := util.WithClock(util.WithIDGenerator(tools.WithInvocationContext(ctx, ic), s.server.ids), s.server.clock)
+	v, res, err := processMcpMessage(msgCtx, []byte(line), s.server, s.server.ResourceMgr.Snapshot(), protocol, "", nil, s.server.preferredLocales(""), logging, sampling)
+	if err != nil {
+		// errors during the processing of message will generate a valid MCP Error response.
+		// server can continue to run.
+		s.server.logger.ErrorContext(ctx, err.Error())
+	}
+	return v, res
+}
+
+// stdioMessage is a message of the client, with its call if it is a tools
+// call request.
+type stdioMessage struct {
+	line string
+	call *stdioCall
+}
+
+// readMessages reads the messages of the client until done is closed. The
+// responses to the requests sent to the client are delivered, and the
+// cancellations of calls applied, as they are read, so that they reach the
+// calls running while the next message waits for a worker, and the other
+// messages are sent on messages. Tools calls are tracked before they are
+// sent, so that the cancellations following them find them. The error ending
+// the input stream is sent on readErr once messages is closed.
+func (s *stdioSession) readMessages(ctx context.Context) (messages <-chan stdioMessage, readErr <-chan error, done chan struct{}) {
+	messagesCh := make(chan stdioMessage)
+	errCh := make(chan error, 1)
+	done = make(chan struct{})
+	go func() {
+		defer close(messagesCh)
+		for {
+			line, err := s.readLine(ctx)
+			if err != nil {
+				errCh <- err
+				return
+			}
+			if s.requests.deliver([]byte(line)) {
+				continue
+			}
+			if id, cause := cancelledRequest([]byte(line)); cause != nil {
+				if !s.calls.cancel(id, cause) {
+					s.server.logger.DebugContext(ctx, fmt.Sprintf("request to cancel is not running: %v", id))
+				}
+				continue
+			}
+			message := stdioMessage{line: line}
+			var baseMessage jsonrpc.BaseMessage
+			if err := json.Unmarshal([]byte(line), &baseMessage); err == nil && baseMessage.Method == mcputil.TOOLS_CALL && baseMessage.Id != nil {
+				message.call = s.calls.add(ctx, baseMessage.Id)
+			}
+			select {
+			case messagesCh <- message:
+			case <-done:
+				errCh <- io.EOF
+				return
+			}
+		}
+	}()
+	return messagesCh, errCh, done
+}
+
 // readLine process each line within the input stream.
 func (s *stdioSession) readLine(ctx context.Context) (string, error) {
 	readChan := make(chan string, 1)
@@ -205,6 +415,8 @@ func (s *stdioSession) readLine(ctx context.Context) (string, error) {
 // write writes to stdout with response to client
 func (s *stdioSession) write(ctx context.Context, response any) error {
 	res, _ := json.Marshal(response)
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
 	_, err := fmt.Fprintf(s.writer, "%s\n", res)
 	return err
@@ -217,6 +429,7 @@ func mcpRouter(s *Server) (chi.Router, error) {
 	r.Use(middleware.AllowContentType("application/json", "application/json-rpc", "application/jsonrequest"))
 	r.Use(middleware.StripSlashes)
 	r.Use(render.SetContentType(render.ContentTypeJSON))
+	r.Use(s.switches.maintenanceMiddleware(maintenanceResponse))
 
 	r.Get("/sse", func(w http.ResponseWriter, r *http.Request) { sseHandler(s, w, r) })
 	r.Get("/", func(w http.ResponseWriter, r *http.Request) { methodNotAllowed(s, w, r) })
@@ -238,7 +451,7 @@ func sseHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 	ctx, span := s.instrumentation.Tracer.Start(r.Context(), "toolbox/server/mcp/sse")
 	r = r.WithContext(ctx)
 
-	sessionId := uuid.New().String()
+	sessionId := s.newID()
 	toolsetName := chi.URLParam(r, "toolsetName")
 	s.logger.DebugContext(ctx, fmt.Sprintf("toolset name: %s", toolsetName))
 	span.SetAttributes(attribute.String("session_id", sessionId))
@@ -299,6 +512,10 @@ func sseHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 		toolsetURL = fmt.Sprintf("/%s", toolsetName)
 	}
 	messageEndpoint := fmt.Sprintf("%s://%s/mcp%s?sessionId=%s", proto, r.Host, toolsetURL, sessionId)
+	// carry the tags filter over to the messages of the session
+	if tags := r.URL.Query().Get("tags"); tags != "" {
+		messageEndpoint += "&tags=" + url.QueryEscape(tags)
+	}
 	s.logger.DebugContext(ctx, fmt.Sprintf("sending endpoint event: %s", messageEndpoint))
 	fmt.Fprintf(w, "event: endpoint\ndata: %s\n\n", messageEndpoint)
 	flusher.Flush()
@@ -368,6 +585,12 @@ func httpHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 		protocolVersion = headerProtocolVersion
 	}
 
+	if sessionId != "" {
+		ctx = util.WithSessionID(ctx, sessionId)
+	} else if headerSessionId != "" {
+		ctx = util.WithSessionID(ctx, headerSessionId)
+	}
+
 	toolsetName := chi.URLParam(r, "toolsetName")
 	s.logger.DebugContext(ctx, fmt.Sprintf("toolset name: %s", toolsetName))
 	span.SetAttributes(attribute.String("toolset_name", toolsetName))
@@ -391,17 +614,106 @@ func httpHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 		)
 	}()
 
-	// Read and returns a body from io.Reader
-	body, err := io.ReadAll(r.Body)
+	// Read and returns a body from io.Reader, bounded so that oversized
+	// payloads aren't allocated
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, tools.MaxRequestSize))
 	if err != nil {
 		// Generate a new uuid if unable to decode
-		id := uuid.New().String()
+		id := s.newID()
 		s.logger.DebugContext(ctx, err.Error())
 		render.JSON(w, r, jsonrpc.NewError(id, jsonrpc.PARSE_ERROR, err.Error(), nil))
 		return
 	}
 
-	v, res, err := processMcpMessage(ctx, body, s, protocolVersion, toolsetName)
+	// the clients of sessions are remembered from their initialize requests
+	clientSessionId := sessionId
+	if clientSessionId == "" {
+		clientSessionId = headerSessionId
+	}
+	info, capabilities, isInitialize := initializeClientInfo(body)
+	if !isInitialize && clientSessionId != "" {
+		info = s.mcpClients.get(clientSessionId)
+	}
+
+	// responses to the requests sent to the client of the session are passed
+	// to the requests waiting for them
+	var requests *mcpClientRequests
+	if session != nil {
+		requests = &session.requests
+		if isInitialize {
+			requests.setCapabilities(capabilities)
+		}
+	} else if headerSessionId != "" {
+		requests = s.mcpClients.sessionRequests(headerSessionId)
+	}
+	if requests.deliver(body) {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+	ic := s.invocationContext(r, clientSessionId)
+	ic.Client = info
+	ctx = tools.WithInvocationContext(ctx, ic)
+	ctx = util.WithIDGenerator(ctx, s.ids)
+	ctx = util.WithClock(ctx, s.clock)
+	ctx = quota.WithStatus(ctx)
+
+	// log messages and requests are sent as events of the sse session, or of
+	// the response in the streamable HTTP transport. Log messages are sent
+	// with the response, unless a request to the client started streaming
+	// the response already.
+	var logging *mcpLogging
+	var sampling *mcpSampling
+	acceptsEvents := strings.Contains(r.Header.Get("Accept"), "text/event-stream")
+	var notificationsMu sync.Mutex
+	var notifications []any
+	streaming := false
+	if session != nil {
+		send := func(message any) error {
+			eventData, _ := json.Marshal(message)
+			select {
+			case session.eventQueue <- fmt.Sprintf("event: message\ndata: %s\n\n", eventData):
+				return nil
+			case <-session.done:
+				return fmt.Errorf("session is closed")
+			default:
+				return fmt.Errorf("event queue is full")
+			}
+		}
+		logging = &mcpLogging{session: &session.log, send: func(notification any) { _ = send(notification) }}
+		sampling = &mcpSampling{requests: requests, send: send}
+	} else if headerSessionId != "" {
+		logging = &mcpLogging{session: s.mcpClients.sessionLog(headerSessionId), send: func(notification any) {
+			notificationsMu.Lock()
+			defer notificationsMu.Unlock()
+			if streaming {
+				writeEvent(w, notification)
+				return
+			}
+			notifications = append(notifications, notification)
+		}}
+		if acceptsEvents {
+			sampling = &mcpSampling{requests: requests, send: func(request any) error {
+				notificationsMu.Lock()
+				defer notificationsMu.Unlock()
+				if !streaming {
+					streaming = true
+					w.Header().Set("Content-Type", "text/event-stream")
+					w.Header().Set("Cache-Control", "no-cache")
+					for _, notification := range notifications {
+						writeEvent(w, notification)
+					}
+					notifications = nil
+				}
+				writeEvent(w, request)
+				return nil
+			}}
+		}
+	}
+
+	resources := s.ResourceMgr.Snapshot()
+	setConfigVersion(w, resources)
+	v, res, err := processMcpMessage(ctx, body, s, resources, protocolVersion, toolsetName, tools.ParseTags(r.URL.Query().Get("tags")), s.preferredLocales(r.Header.Get("Accept-Language")), logging, sampling)
+	setQuotaHeaders(ctx, w)
 	// notifications will return empty string
 	if res == nil {
 		// Notifications do not expect a response
@@ -415,9 +727,12 @@ func httpHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 
 	// for v20250326, add the `Mcp-Session-Id` header
 	if v == v20250326.PROTOCOL_VERSION {
-		sessionId = uuid.New().String()
+		sessionId = s.newID()
 		w.Header().Set("Mcp-Session-Id", sessionId)
 	}
+	if isInitialize && sessionId != "" {
+		s.mcpClients.set(sessionId, info, capabilities)
+	}
 
 	if session != nil {
 		// queue sse event
@@ -432,22 +747,67 @@ func httpHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// send the log messages before the response, if the client accepts an
+	// event stream
+	notificationsMu.Lock()
+	events := append(notifications, res)
+	wasStreaming := streaming
+	// log messages of late goroutines are dropped once the response is sent
+	streaming, notifications = false, nil
+	notificationsMu.Unlock()
+	if wasStreaming {
+		writeEvent(w, res)
+		return
+	}
+	if len(events) > 1 && acceptsEvents {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		for _, event := range events {
+			writeEvent(w, event)
+		}
+		return
+	}
+
 	// send HTTP response
 	render.JSON(w, r, res)
 }
 
-// processMcpMessage process the messages received from clients
-func processMcpMessage(ctx context.Context, body []byte, s *Server, protocolVersion string, toolsetName string) (string, any, error) {
+// writeEvent writes message as an event of the event stream of a response,
+// and flushes it to the client.
+func writeEvent(w http.ResponseWriter, message any) {
+	eventData, _ := json.Marshal(message)
+	fmt.Fprintf(w, "event: message\ndata: %s\n\n", eventData)
+	if flusher, ok := w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// mcpArgumentsDepth is the depth of the arguments of tool calls in MCP
+// messages, in the arguments object of their params object.
+const mcpArgumentsDepth = 3
+
+// processMcpMessage process the messages received from clients with the
+// resources of a snapshot. Only tools having all of tags are listed, and
+// descriptions in manifests are served in the first of locales available.
+// Log messages are sent to the client with logging, and oversized results are
+// summarized with sampling, which are nil if the transport has no session.
+func processMcpMessage(ctx context.Context, body []byte, s *Server, resources *ResourceSnapshot, protocolVersion string, toolsetName string, tags []string, locales []string, logging *mcpLogging, sampling *mcpSampling) (string, any, error) {
 	logger, err := util.LoggerFromContext(ctx)
 	if err != nil {
 		return "", jsonrpc.NewError("", jsonrpc.INTERNAL_ERROR, err.Error(), nil), err
 	}
 
+	// arguments are nested in the params of the message, and are rejected if
+	// they're too deep before the message is decoded
+	if err = tools.CheckNestingDepth(body, tools.MaxNestingDepth+mcpArgumentsDepth); err != nil {
+		return "", jsonrpc.NewError(s.newID(), jsonrpc.INVALID_REQUEST, err.Error(), nil), err
+	}
+
 	// Generic baseMessage could either be a JSONRPCNotification or JSONRPCRequest
 	var baseMessage jsonrpc.BaseMessage
 	if err = util.DecodeJSON(bytes.NewBuffer(body), &baseMessage); err != nil {
 		// Generate a new uuid if unable to decode
-		id := uuid.New().String()
+		id := s.newID()
 
 		// check if user is sending a batch request
 		var a []any
@@ -486,13 +846,78 @@ func processMcpMessage(ctx context.Context, body []byte, s *Server, protocolVers
 			return "", res, err
 		}
 		return v, res, err
+	case mcputil.LOGGING_SET_LEVEL:
+		res, err := logging.setLevelHandler(baseMessage.Id, body)
+		return "", res, err
+	case mcputil.RESOURCES_LIST:
+		res, err := s.mcpResults.listHandler(baseMessage.Id, util.SessionIDFromContext(ctx))
+		return "", res, err
+	case mcputil.RESOURCES_READ:
+		res, err := s.mcpResults.readHandler(baseMessage.Id, util.SessionIDFromContext(ctx), body)
+		return "", res, err
 	default:
-		toolset, ok := s.ResourceMgr.GetToolset(toolsetName)
+		// the log entries of the request are mirrored to the client
+		ctx = util.WithLogger(ctx, logging.logger(logger))
+		if summarizer := s.summarizer(sampling); summarizer != nil {
+			ctx = mcputil.WithSummarizer(ctx, summarizer)
+		}
+		toolset, ok := resources.GetLocalizedToolset(toolsetName, locales)
 		if !ok {
+			if err = resources.RemovedToolset(toolsetName); err != nil {
+				return "", jsonrpc.NewError(baseMessage.Id, jsonrpc.INVALID_REQUEST, err.Error(), removedData(err)), err
+			}
 			err = fmt.Errorf("toolset does not exist")
 			return "", jsonrpc.NewError(baseMessage.Id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
 		}
-		res, err := mcp.ProcessMethod(ctx, protocolVersion, baseMessage.Id, baseMessage.Method, toolset, s.ResourceMgr.GetToolsMap(), body)
+		if baseMessage.Method == mcputil.TOOLS_CALL {
+			toolName := calledToolName(body)
+			if err = removedToolCalled(resources, toolName); err != nil {
+				return "", jsonrpc.NewError(baseMessage.Id, jsonrpc.INVALID_PARAMS, err.Error(), removedData(err)), err
+			}
+			if tool, ok := resources.GetTool(toolName); ok {
+				if err = s.switches.toolDisabled(tool); err != nil {
+					return "", jsonrpc.NewError(baseMessage.Id, jsonrpc.INVALID_REQUEST, err.Error(), map[string]any{"disabled": err}), err
+				}
+			}
+		}
+		toolset = s.switches.enabledTools(resources, toolset).FilterByTags(tags)
+		if baseMessage.Method == mcputil.COMPLETION_COMPLETE {
+			res, err := completionHandler(ctx, baseMessage.Id, body, toolset, resources.GetToolsMap())
+			return "", res, err
+		}
+		if s.resultEnvelope == ResultAsData {
+			ctx = tools.WithResultMetadata(ctx)
+		}
+		res, err := mcp.ProcessMethod(ctx, protocolVersion, baseMessage.Id, baseMessage.Method, toolset, resources.GetToolsMap(), s.toolsPageSize, body)
 		return "", res, err
 	}
 }
+
+// calledToolName returns the name of the tool of the tools call request in
+// body, or "" if the request is invalid.
+func calledToolName(body []byte) string {
+	var req struct {
+		Params struct {
+			Name string `json:"name"`
+		} `json:"params"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		// invalid requests are rejected when processing the method
+		return ""
+	}
+	return req.Params.Name
+}
+
+// removedToolCalled returns a ResourceRemovedError if the tool named toolName
+// was called after a reload removed it from resources.
+func removedToolCalled(resources *ResourceSnapshot, toolName string) error {
+	if _, ok := resources.GetTool(toolName); ok {
+		return nil
+	}
+	return resources.RemovedTool(toolName)
+}
+
+// removedData returns the data of the JSON-RPC error for a ResourceRemovedError.
+func removedData(err error) any {
+	return map[string]any{"removed": err}
+}
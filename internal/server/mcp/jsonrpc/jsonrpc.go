@@ -24,6 +24,9 @@ const (
 	METHOD_NOT_FOUND = -32601
 	INVALID_PARAMS   = -32602
 	INTERNAL_ERROR   = -32603
+	// REQUEST_CANCELLED is the code of the requests cancelled with
+	// $/cancelRequest, as in the Language Server Protocol.
+	REQUEST_CANCELLED = -32800
 )
 
 // ProgressToken is used to associate progress notifications with the original request.
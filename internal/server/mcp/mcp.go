@@ -61,6 +61,9 @@ func InitializeResponse(ctx context.Context, id jsonrpc.RequestId, body []byte,
 	result := mcputil.InitializeResult{
 		ProtocolVersion: protocolVersion,
 		Capabilities: mcputil.ServerCapabilities{
+			Completions: &struct{}{},
+			Logging:     &struct{}{},
+			Resources:   &struct{}{},
 			Tools: &mcputil.ListChanged{
 				ListChanged: &toolsListChanged,
 			},
@@ -93,14 +96,14 @@ func NotificationHandler(ctx context.Context, body []byte) error {
 
 // ProcessMethod returns a response for the request.
 // This is the Operation phase of the lifecycle for MCP client-server connections.
-func ProcessMethod(ctx context.Context, mcpVersion string, id jsonrpc.RequestId, method string, toolset tools.Toolset, tools map[string]tools.Tool, body []byte) (any, error) {
+func ProcessMethod(ctx context.Context, mcpVersion string, id jsonrpc.RequestId, method string, toolset tools.Toolset, tools map[string]tools.Tool, toolsPageSize int, body []byte) (any, error) {
 	switch mcpVersion {
 	case v20250618.PROTOCOL_VERSION:
-		return v20250618.ProcessMethod(ctx, id, method, toolset, tools, body)
+		return v20250618.ProcessMethod(ctx, id, method, toolset, tools, toolsPageSize, body)
 	case v20250326.PROTOCOL_VERSION:
-		return v20250326.ProcessMethod(ctx, id, method, toolset, tools, body)
+		return v20250326.ProcessMethod(ctx, id, method, toolset, tools, toolsPageSize, body)
 	default:
-		return v20241105.ProcessMethod(ctx, id, method, toolset, tools, body)
+		return v20241105.ProcessMethod(ctx, id, method, toolset, tools, toolsPageSize, body)
 	}
 }
 
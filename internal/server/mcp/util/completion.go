@@ -0,0 +1,63 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import "github.com/googleapis/genai-toolbox/internal/server/mcp/jsonrpc"
+
+/* Completion */
+
+// REF_TOOL is the type of a reference to a tool. Toolbox extends the prompt
+// and resource references of MCP with it to complete tool arguments.
+const REF_TOOL = "ref/tool"
+
+// CompleteRequest is sent from the client to the server to ask for
+// completion options.
+type CompleteRequest struct {
+	jsonrpc.Request
+	Params struct {
+		// The reference to the tool, prompt or resource of the argument.
+		Ref struct {
+			Type string `json:"type"`
+			Name string `json:"name"`
+		} `json:"ref"`
+		// The argument's information.
+		Argument struct {
+			// The name of the argument.
+			Name string `json:"name"`
+			// The value of the argument to use for completion matching.
+			Value string `json:"value"`
+		} `json:"argument"`
+		// Additional, optional context for completions.
+		Context struct {
+			// Previously-resolved variables in a URI template or prompt.
+			Arguments map[string]string `json:"arguments,omitempty"`
+		} `json:"context,omitempty"`
+	} `json:"params"`
+}
+
+// CompleteResult is the server's response to a completion/complete request.
+type CompleteResult struct {
+	Completion struct {
+		// An array of completion values. Must not exceed 100 items.
+		Values []string `json:"values"`
+		// The total number of completion options available. This can exceed
+		// the number of values actually sent in the response.
+		Total int `json:"total,omitempty"`
+		// Indicates whether there are additional completion options beyond
+		// those provided in the current response, even if the exact total is
+		// unknown.
+		HasMore bool `json:"hasMore,omitempty"`
+	} `json:"completion"`
+}
@@ -20,7 +20,19 @@ const (
 	// SERVER_NAME is the server name used in Implementation.
 	SERVER_NAME = "Toolbox"
 	// methods that are supported
-	INITIALIZE = "initialize"
+	INITIALIZE          = "initialize"
+	TOOLS_CALL          = "tools/call"
+	LOGGING_SET_LEVEL   = "logging/setLevel"
+	COMPLETION_COMPLETE = "completion/complete"
+	RESOURCES_LIST      = "resources/list"
+	RESOURCES_READ      = "resources/read"
+	// notifications that are received
+	CANCEL_REQUEST          = "$/cancelRequest"
+	NOTIFICATIONS_CANCELLED = "notifications/cancelled"
+	// notifications that are sent
+	NOTIFICATIONS_MESSAGE = "notifications/message"
+	// requests that are sent
+	SAMPLING_CREATE_MESSAGE = "sampling/createMessage"
 )
 
 /* Initialization */
@@ -79,14 +91,20 @@ type ClientCapabilities struct {
 	// Present if the client supports listing roots.
 	Roots *ListChanged `json:"roots,omitempty"`
 	// Present if the client supports sampling from an LLM.
-	Sampling struct{} `json:"sampling,omitempty"`
+	Sampling *struct{} `json:"sampling,omitempty"`
 }
 
 // ServerCapabilities represents capabilities that a server may support. Known
 // capabilities are defined here, in this schema, but this is not a closed set: any
 // server can define its own, additional capabilities.
 type ServerCapabilities struct {
-	Tools *ListChanged `json:"tools,omitempty"`
+	// Present if the server supports argument autocompletion suggestions.
+	Completions *struct{} `json:"completions,omitempty"`
+	// Present if the server supports sending log messages to the client.
+	Logging *struct{} `json:"logging,omitempty"`
+	// Present if the server offers any resources to read.
+	Resources *struct{}    `json:"resources,omitempty"`
+	Tools     *ListChanged `json:"tools,omitempty"`
 }
 
 // Base interface for metadata with name (identifier) and title (display name) properties.
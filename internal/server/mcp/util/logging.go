@@ -0,0 +1,63 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import "github.com/googleapis/genai-toolbox/internal/server/mcp/jsonrpc"
+
+/* Logging */
+
+// LOGGING_LEVELS are the severities of log messages, in increasing order.
+// They map to the syslog message severities of RFC 5424.
+var LOGGING_LEVELS = []string{"debug", "info", "notice", "warning", "error", "critical", "alert", "emergency"}
+
+// SetLevelRequest is sent from the client to the server to enable or adjust
+// logging.
+type SetLevelRequest struct {
+	jsonrpc.Request
+	Params struct {
+		// The level of logging that the client wants to receive from the
+		// server. The server should send all logs at this level and higher
+		// (i.e., more severe) to the client as notifications/message.
+		Level string `json:"level"`
+	} `json:"params"`
+}
+
+// LoggingMessageParams are the params of a log message notification.
+type LoggingMessageParams struct {
+	// The severity of this log message.
+	Level string `json:"level"`
+	// An optional name of the logger issuing this message.
+	Logger string `json:"logger,omitempty"`
+	// The data to be logged, such as a string message or an object.
+	Data any `json:"data"`
+}
+
+// LoggingMessageNotification is sent from the server to the client to pass
+// a log message. If no logging/setLevel request has been sent from the
+// client, the server MAY decide which messages to send automatically.
+type LoggingMessageNotification struct {
+	Jsonrpc string               `json:"jsonrpc"`
+	Method  string               `json:"method"`
+	Params  LoggingMessageParams `json:"params"`
+}
+
+// NewLoggingMessage returns the notification of a log message.
+func NewLoggingMessage(level, logger string, data any) LoggingMessageNotification {
+	return LoggingMessageNotification{
+		Jsonrpc: jsonrpc.JSONRPC_VERSION,
+		Method:  NOTIFICATIONS_MESSAGE,
+		Params:  LoggingMessageParams{Level: level, Logger: logger, Data: data},
+	}
+}
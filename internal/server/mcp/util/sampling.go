@@ -0,0 +1,93 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+
+	"github.com/googleapis/genai-toolbox/internal/server/mcp/jsonrpc"
+)
+
+/* Sampling */
+
+// SamplingContent is the text content of a sampling message.
+type SamplingContent struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// SamplingMessage describes a message issued to or received from an LLM API.
+type SamplingMessage struct {
+	Role    string          `json:"role"`
+	Content SamplingContent `json:"content"`
+}
+
+// CreateMessageParams are the params of a request from the server to sample
+// an LLM via the client.
+type CreateMessageParams struct {
+	Messages []SamplingMessage `json:"messages"`
+	// An optional system prompt the server wants to use for sampling.
+	SystemPrompt string `json:"systemPrompt,omitempty"`
+	// A request to include context from one or more MCP servers (including
+	// the caller), to be attached to the prompt.
+	IncludeContext string `json:"includeContext,omitempty"`
+	// The maximum number of tokens to sample, as requested by the server.
+	MaxTokens int `json:"maxTokens"`
+}
+
+// CreateMessageResult is the client's response to a sampling/createMessage
+// request.
+type CreateMessageResult struct {
+	jsonrpc.Result
+	SamplingMessage
+	// The name of the model that generated the message.
+	Model string `json:"model"`
+	// The reason why sampling stopped, if known.
+	StopReason string `json:"stopReason,omitempty"`
+}
+
+// Summary is the summary of a tool result exceeding the token budget of
+// results, and the resource holding the full result.
+type Summary struct {
+	Text string
+	// URI is the URI of the resource with the full result.
+	URI string
+	// Name is the name of the resource with the full result.
+	Name string
+	// Rows is the number of rows of the full result.
+	Rows int
+}
+
+// Summarizer summarizes the rows of a result of toolName, each encoded as
+// JSON, or returns nil to keep the result as is.
+type Summarizer func(ctx context.Context, toolName string, rows []string) *Summary
+
+type summarizerKey struct{}
+
+// WithSummarizer returns a context summarizing the oversized results of the
+// tools called with it.
+func WithSummarizer(ctx context.Context, summarizer Summarizer) context.Context {
+	return context.WithValue(ctx, summarizerKey{}, summarizer)
+}
+
+// Summarize summarizes the rows of a result of toolName with the summarizer
+// of ctx, or returns nil if it has none or keeps the result as is.
+func Summarize(ctx context.Context, toolName string, rows []string) *Summary {
+	summarizer, ok := ctx.Value(summarizerKey{}).(Summarizer)
+	if !ok || summarizer == nil {
+		return nil
+	}
+	return summarizer(ctx, toolName, rows)
+}
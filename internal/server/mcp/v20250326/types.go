@@ -134,6 +134,15 @@ type CallToolResult struct {
 	// Whether the tool call ended in an error.
 	// If not set, this is assumed to be false (the call was successful).
 	IsError bool `json:"isError,omitempty"`
+	// Warnings lists non-fatal issues with the invocation, such as truncated
+	// results, which don't fail the call.
+	Warnings []string `json:"warnings,omitempty"`
+	// NextCursor is the cursor of the next page of the result, passed as the
+	// cursor argument of the next call, if any.
+	NextCursor string `json:"nextCursor,omitempty"`
+	// Metadata describes the execution of the call, such as the number of
+	// rows of its result, if the server returns it.
+	Metadata *tools.ResultMetadata `json:"metadata,omitempty"`
 }
 
 // Additional properties describing a Tool to clients.
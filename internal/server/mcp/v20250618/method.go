@@ -18,18 +18,24 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/googleapis/genai-toolbox/internal/policy"
+	"github.com/googleapis/genai-toolbox/internal/quota"
 	"github.com/googleapis/genai-toolbox/internal/server/mcp/jsonrpc"
+	mcputil "github.com/googleapis/genai-toolbox/internal/server/mcp/util"
 	"github.com/googleapis/genai-toolbox/internal/tools"
 	"github.com/googleapis/genai-toolbox/internal/util"
 )
 
-// ProcessMethod returns a response for the request.
-func ProcessMethod(ctx context.Context, id jsonrpc.RequestId, method string, toolset tools.Toolset, tools map[string]tools.Tool, body []byte) (any, error) {
+// ProcessMethod returns a response for the request. Tools are listed in pages
+// of toolsPageSize tools, or all at once if it is zero.
+func ProcessMethod(ctx context.Context, id jsonrpc.RequestId, method string, toolset tools.Toolset, tools map[string]tools.Tool, toolsPageSize int, body []byte) (any, error) {
 	switch method {
 	case TOOLS_LIST:
-		return toolsListHandler(id, toolset, body)
+		return toolsListHandler(id, toolset, toolsPageSize, body)
 	case TOOLS_CALL:
 		return toolsCallHandler(ctx, id, tools, body)
 	default:
@@ -38,15 +44,22 @@ func ProcessMethod(ctx context.Context, id jsonrpc.RequestId, method string, too
 	}
 }
 
-func toolsListHandler(id jsonrpc.RequestId, toolset tools.Toolset, body []byte) (any, error) {
+func toolsListHandler(id jsonrpc.RequestId, toolset tools.Toolset, pageSize int, body []byte) (any, error) {
 	var req ListToolsRequest
 	if err := json.Unmarshal(body, &req); err != nil {
 		err = fmt.Errorf("invalid mcp tools list request: %w", err)
 		return jsonrpc.NewError(id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
 	}
 
+	page, nextCursor, err := toolset.Page(string(req.Params.Cursor), pageSize)
+	if err != nil {
+		err = fmt.Errorf("invalid mcp tools list request: %w", err)
+		return jsonrpc.NewError(id, jsonrpc.INVALID_PARAMS, err.Error(), nil), err
+	}
+
 	result := ListToolsResult{
-		Tools: toolset.McpManifest,
+		PaginatedResult: PaginatedResult{NextCursor: Cursor(nextCursor)},
+		Tools:           page.McpManifest,
 	}
 	return jsonrpc.JSONRPCResponse{
 		Jsonrpc: jsonrpc.JSONRPC_VERSION,
@@ -97,8 +110,9 @@ func toolsCallHandler(ctx context.Context, id jsonrpc.RequestId, tools map[strin
 
 	params, err := tool.ParseParams(data, claimsFromAuth)
 	if err != nil {
+		errData := paramErrorsData(err)
 		err = fmt.Errorf("provided parameters were invalid: %w", err)
-		return jsonrpc.NewError(id, jsonrpc.INVALID_PARAMS, err.Error(), nil), err
+		return jsonrpc.NewError(id, jsonrpc.INVALID_PARAMS, err.Error(), errData), err
 	}
 	logger.DebugContext(ctx, fmt.Sprintf("invocation params: %s", params))
 
@@ -108,7 +122,16 @@ func toolsCallHandler(ctx context.Context, id jsonrpc.RequestId, tools map[strin
 	}
 
 	// run tool invocation and generate response.
+	ctx = withWarnings(ctx, tool, data, params)
+	start := time.Now()
 	results, err := tool.Invoke(ctx, params)
+	elapsed := time.Since(start)
+	if data := quotaErrorData(err); data != nil {
+		return jsonrpc.NewError(id, jsonrpc.INVALID_REQUEST, err.Error(), data), err
+	}
+	if data := policyErrorData(err); data != nil {
+		return jsonrpc.NewError(id, jsonrpc.INVALID_REQUEST, err.Error(), data), err
+	}
 	if err != nil {
 		text := TextContent{
 			Type: "text",
@@ -117,31 +140,145 @@ func toolsCallHandler(ctx context.Context, id jsonrpc.RequestId, tools map[strin
 		return jsonrpc.JSONRPCResponse{
 			Jsonrpc: jsonrpc.JSONRPC_VERSION,
 			Id:      id,
-			Result:  CallToolResult{Content: []TextContent{text}, IsError: true},
+			Result:  CallToolResult{Content: []any{text}, IsError: true, Warnings: invocationWarnings(ctx)},
 		}, nil
 	}
 
-	content := make([]TextContent, 0)
-
 	sliceRes, ok := results.([]any)
 	if !ok {
 		sliceRes = []any{results}
 	}
 
+	rows := make([]string, 0, len(sliceRes))
+	values := make([]any, 0, len(sliceRes))
 	for _, d := range sliceRes {
-		text := TextContent{Type: "text"}
 		dM, err := json.Marshal(d)
 		if err != nil {
-			text.Text = fmt.Sprintf("fail to marshal: %s, result: %s", err, d)
+			rows = append(rows, fmt.Sprintf("fail to marshal: %s, result: %s", err, d))
+			values = append(values, rows[len(rows)-1])
 		} else {
-			text.Text = string(dM)
+			rows = append(rows, string(dM))
+			values = append(values, json.RawMessage(dM))
 		}
-		content = append(content, text)
+	}
+	metadata := invocationMetadata(ctx, tool, results, elapsed)
+
+	// results exceeding the token budget are replaced by their summary, if
+	// the client can sample its LLM
+	if summary := mcputil.Summarize(ctx, toolName, rows); summary != nil {
+		return jsonrpc.JSONRPCResponse{
+			Jsonrpc: jsonrpc.JSONRPC_VERSION,
+			Id:      id,
+			Result:  CallToolResult{Content: summaryContent(summary), StructuredContent: structuredContent(metadata, nil), Warnings: invocationWarnings(ctx), NextCursor: invocationNextCursor(ctx)},
+		}, nil
+	}
+
+	content := make([]any, 0, len(rows))
+	for _, row := range rows {
+		content = append(content, TextContent{Type: "text", Text: row})
 	}
 
 	return jsonrpc.JSONRPCResponse{
 		Jsonrpc: jsonrpc.JSONRPC_VERSION,
 		Id:      id,
-		Result:  CallToolResult{Content: content},
+		Result:  CallToolResult{Content: content, StructuredContent: structuredContent(metadata, resultData(ok, values)), Warnings: invocationWarnings(ctx), NextCursor: invocationNextCursor(ctx)},
 	}, nil
 }
+
+// paramErrorsData returns the error data listing every invalid parameter, so
+// they can all be fixed in one retry.
+func paramErrorsData(err error) any {
+	var paramErrs tools.ParamErrors
+	if !errors.As(err, &paramErrs) {
+		return nil
+	}
+	return map[string]any{"errors": paramErrs}
+}
+
+// quotaErrorData returns the error data describing the quota the caller
+// exceeded, so that clients can tell when to retry.
+func quotaErrorData(err error) any {
+	var quotaErr *quota.ExceededError
+	if !errors.As(err, &quotaErr) {
+		return nil
+	}
+	return map[string]any{"quota": quotaErr}
+}
+
+// policyErrorData returns the error data describing the policy that denied
+// the invocation.
+func policyErrorData(err error) any {
+	var deniedErr *policy.DeniedError
+	if !errors.As(err, &deniedErr) {
+		return nil
+	}
+	return map[string]any{"policy": deniedErr}
+}
+
+// withWarnings returns a context collecting the warnings of an invocation of
+// tool, starting with the parameters that were set to their default values.
+func withWarnings(ctx context.Context, tool tools.Tool, data map[string]any, params tools.ParamValues) context.Context {
+	ctx = tools.WithWarnings(ctx)
+	tools.AddDefaultWarnings(ctx, tool.Manifest(), data, params)
+	return ctx
+}
+
+// invocationWarnings returns the warnings collected during an invocation.
+func invocationWarnings(ctx context.Context) []string {
+	return tools.Warnings(ctx)
+}
+
+// invocationNextCursor returns the cursor of the next page of the result of
+// an invocation, if any.
+func invocationNextCursor(ctx context.Context) string {
+	return tools.NextCursor(ctx)
+}
+
+// invocationMetadata returns the metadata of the invocation of tool that
+// returned results after elapsed, or nil if the server doesn't return it.
+func invocationMetadata(ctx context.Context, tool tools.Tool, results any, elapsed time.Duration) *tools.ResultMetadata {
+	if !tools.ReturnsResultMetadata(ctx) {
+		return nil
+	}
+	m := tools.NewResultMetadata(ctx, tool, results, elapsed)
+	return &m
+}
+
+// resultData returns the data of the structured content of a result, from
+// its marshaled rows: the list of rows, or its only element if the result
+// isn't a list.
+func resultData(isList bool, rows []any) any {
+	if !isList {
+		return rows[0]
+	}
+	return rows
+}
+
+// structuredContent returns the structured content of a result with its
+// data, unless it was summarized, and its metadata, or nil if the server
+// doesn't return the metadata of invocations.
+func structuredContent(metadata *tools.ResultMetadata, data any) map[string]any {
+	if metadata == nil {
+		return nil
+	}
+	content := map[string]any{"metadata": metadata}
+	if data != nil {
+		content["data"] = data
+	}
+	return content
+}
+
+// summaryContent returns the content of a summarized result, linking to the
+// resource with the full result.
+func summaryContent(summary *mcputil.Summary) []any {
+	return []any{
+		TextContent{Type: "text", Text: summary.Text},
+		ResourceLink{
+			Type:        "resource_link",
+			URI:         summary.URI,
+			Name:        summary.Name,
+			Description: fmt.Sprintf("The full result of %d rows.", summary.Rows),
+			MimeType:    "application/json",
+		},
+	}
+}
@@ -116,6 +116,22 @@ type TextContent struct {
 	Text string `json:"text"`
 }
 
+// ResourceLink is a resource that the server is capable of reading, included
+// in a tool call result. Resource links returned by tools are not guaranteed
+// to appear in the results of resources/list requests.
+type ResourceLink struct {
+	Annotated
+	Type string `json:"type"`
+	// The URI of this resource.
+	URI string `json:"uri"`
+	// Intended for programmatic or logical use.
+	Name string `json:"name"`
+	// A description of what this resource represents.
+	Description string `json:"description,omitempty"`
+	// The MIME type of this resource, if known.
+	MimeType string `json:"mimeType,omitempty"`
+}
+
 // The server's response to a tool call.
 //
 // Any errors that originate from the tool SHOULD be reported inside the result
@@ -128,9 +144,10 @@ type TextContent struct {
 // should be reported as an MCP error response.
 type CallToolResult struct {
 	jsonrpc.Result
-	// Could be either a TextContent, ImageContent, or EmbeddedResources
-	// For Toolbox, we will only be sending TextContent
-	Content []TextContent `json:"content"`
+	// Could be either a TextContent, ImageContent, ResourceLink or
+	// EmbeddedResources. For Toolbox, we will only be sending TextContent and
+	// ResourceLink
+	Content []any `json:"content"`
 	// Whether the tool call ended in an error.
 	// If not set, this is assumed to be false (the call was successful).
 	//
@@ -145,6 +162,12 @@ type CallToolResult struct {
 	IsError bool `json:"isError,omitempty"`
 	// An optional JSON object that represents the structured result of the tool call.
 	StructuredContent map[string]any `json:"structuredContent,omitempty"`
+	// Warnings lists non-fatal issues with the invocation, such as truncated
+	// results, which don't fail the call.
+	Warnings []string `json:"warnings,omitempty"`
+	// NextCursor is the cursor of the next page of the result, passed as the
+	// cursor argument of the next call, if any.
+	NextCursor string `json:"nextCursor,omitempty"`
 }
 
 // Additional properties describing a Tool to clients.
@@ -0,0 +1,77 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/googleapis/genai-toolbox/internal/server/mcp/jsonrpc"
+	mcputil "github.com/googleapis/genai-toolbox/internal/server/mcp/util"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+// completionHandler handles the completion/complete request in body, for the
+// arguments of the tools of toolset.
+func completionHandler(ctx context.Context, id jsonrpc.RequestId, body []byte, toolset tools.Toolset, toolsMap map[string]tools.Tool) (any, error) {
+	var req mcputil.CompleteRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		err = fmt.Errorf("invalid mcp completion/complete request: %w", err)
+		return jsonrpc.NewError(id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
+	}
+	if req.Params.Ref.Type != mcputil.REF_TOOL {
+		err := fmt.Errorf("invalid reference type %q: only %q is supported", req.Params.Ref.Type, mcputil.REF_TOOL)
+		return jsonrpc.NewError(id, jsonrpc.INVALID_PARAMS, err.Error(), nil), err
+	}
+
+	toolName := req.Params.Ref.Name
+	tool, ok := toolsMap[toolName]
+	if !ok || !toolsetHasTool(toolset, toolName) {
+		err := fmt.Errorf("invalid tool name: tool with name %q does not exist", toolName)
+		return jsonrpc.NewError(id, jsonrpc.INVALID_PARAMS, err.Error(), nil), err
+	}
+	// suggestions may be read from the source of the tool, so they are only
+	// offered for tools that don't require authentication
+	if !tool.Authorized([]string{}) {
+		err := fmt.Errorf("unauthorized tool: completion is not available for tools requiring authentication")
+		return jsonrpc.NewError(id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
+	}
+
+	completion, err := tools.Complete(ctx, tool, req.Params.Argument.Name, req.Params.Argument.Value)
+	if err != nil {
+		return jsonrpc.NewError(id, jsonrpc.INVALID_PARAMS, err.Error(), nil), err
+	}
+
+	var result mcputil.CompleteResult
+	result.Completion.Values = completion.Values
+	result.Completion.Total = completion.Total
+	result.Completion.HasMore = completion.HasMore
+	return jsonrpc.JSONRPCResponse{
+		Jsonrpc: jsonrpc.JSONRPC_VERSION,
+		Id:      id,
+		Result:  result,
+	}, nil
+}
+
+// toolsetHasTool returns whether toolName is one of the tools of toolset.
+func toolsetHasTool(toolset tools.Toolset, toolName string) bool {
+	for _, m := range toolset.McpManifest {
+		if m.Name == toolName {
+			return true
+		}
+	}
+	return false
+}
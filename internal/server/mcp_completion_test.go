@@ -0,0 +1,127 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	mcputil "github.com/googleapis/genai-toolbox/internal/server/mcp/util"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+func TestMcpCompletion(t *testing.T) {
+	city := tools.NewStringParameter("city", "The city of the hotel.")
+	city.Completion = &tools.ParamCompletion{Values: []string{"Basel", "Bern", "Zurich"}}
+	completionTool := MockTool{
+		Name:   "search_hotels",
+		Params: tools.Parameters{city, tools.NewIntParameter("stars", "The stars of the hotel.")},
+	}
+	toolsMap, toolsets := setUpResources(t, []MockTool{tool1, completionTool})
+	r, shutdown := setUpServer(t, "mcp", toolsMap, toolsets)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	complete := func(refType, toolName, argument, value string) map[string]any {
+		return map[string]any{
+			"jsonrpc": jsonrpcVersion,
+			"id":      "completion",
+			"method":  mcputil.COMPLETION_COMPLETE,
+			"params": map[string]any{
+				"ref":      map[string]any{"type": refType, "name": toolName},
+				"argument": map[string]any{"name": argument, "value": value},
+			},
+		}
+	}
+
+	tcs := []struct {
+		name      string
+		url       string
+		body      map[string]any
+		want      map[string]any
+		wantError bool
+	}{
+		{
+			name: "values matching the prefix",
+			url:  "/",
+			body: complete(mcputil.REF_TOOL, completionTool.Name, "city", "b"),
+			want: map[string]any{
+				"values": []any{"Basel", "Bern"},
+				"total":  float64(2),
+			},
+		},
+		{
+			name: "parameter without completion",
+			url:  "/",
+			body: complete(mcputil.REF_TOOL, completionTool.Name, "stars", ""),
+			want: map[string]any{"values": []any{}},
+		},
+		{
+			name:      "unknown parameter",
+			url:       "/",
+			body:      complete(mcputil.REF_TOOL, completionTool.Name, "country", ""),
+			wantError: true,
+		},
+		{
+			name:      "tool outside of the toolset",
+			url:       "/tool1_only",
+			body:      complete(mcputil.REF_TOOL, completionTool.Name, "city", ""),
+			wantError: true,
+		},
+		{
+			name:      "prompt reference",
+			url:       "/",
+			body:      complete("ref/prompt", completionTool.Name, "city", ""),
+			wantError: true,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			reqMarshal, err := json.Marshal(tc.body)
+			if err != nil {
+				t.Fatalf("unexpected error during marshaling of body")
+			}
+			resp, body, err := runRequest(ts, http.MethodPost, tc.url, bytes.NewBuffer(reqMarshal), nil)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("response status code is not 200, got %d: %s", resp.StatusCode, string(body))
+			}
+
+			var got map[string]any
+			if err := json.Unmarshal(body, &got); err != nil {
+				t.Fatalf("unexpected error unmarshalling body: %s", err)
+			}
+			if tc.wantError {
+				if _, ok := got["error"]; !ok {
+					t.Fatalf("expected an error, got %s", string(body))
+				}
+				return
+			}
+			result, ok := got["result"].(map[string]any)
+			if !ok {
+				t.Fatalf("expected a result, got %s", string(body))
+			}
+			if diff := cmp.Diff(tc.want, result["completion"]); diff != "" {
+				t.Fatalf("unexpected completion: diff %v", diff)
+			}
+		})
+	}
+}
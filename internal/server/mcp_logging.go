@@ -0,0 +1,136 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/googleapis/genai-toolbox/internal/log"
+	"github.com/googleapis/genai-toolbox/internal/server/mcp/jsonrpc"
+	mcputil "github.com/googleapis/genai-toolbox/internal/server/mcp/util"
+)
+
+// mcpLoggerName is the logger of the log messages sent to MCP clients.
+const mcpLoggerName = "toolbox"
+
+// mcpSessionLog is the log level that the client of an MCP session set with
+// logging/setLevel, which is "" until the client opts in to log messages.
+type mcpSessionLog struct {
+	mu    sync.Mutex
+	level string
+}
+
+func (l *mcpSessionLog) setLevel(level string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.level = level
+}
+
+func (l *mcpSessionLog) getLevel() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.level
+}
+
+// mcpLogging sends the log messages of the requests of an MCP session to its
+// client, with send.
+type mcpLogging struct {
+	session *mcpSessionLog
+	send    func(notification any)
+}
+
+// logger returns a logger mirroring the entries of base at or above the level
+// of the session to its client, or base if the client didn't opt in.
+func (l *mcpLogging) logger(base log.Logger) log.Logger {
+	if l == nil {
+		return base
+	}
+	level := l.session.getLevel()
+	if level == "" {
+		return base
+	}
+	return mcpLogger{Logger: base, severity: slices.Index(mcputil.LOGGING_LEVELS, level), send: l.send}
+}
+
+// setLevelHandler handles the logging/setLevel request in body.
+func (l *mcpLogging) setLevelHandler(id jsonrpc.RequestId, body []byte) (any, error) {
+	var req mcputil.SetLevelRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		err = fmt.Errorf("invalid mcp logging/setLevel request: %w", err)
+		return jsonrpc.NewError(id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
+	}
+	if !slices.Contains(mcputil.LOGGING_LEVELS, req.Params.Level) {
+		err := fmt.Errorf("invalid log level %q: must be one of %q", req.Params.Level, mcputil.LOGGING_LEVELS)
+		return jsonrpc.NewError(id, jsonrpc.INVALID_PARAMS, err.Error(), nil), err
+	}
+	if l == nil {
+		err := fmt.Errorf("logging requires a session, which the transport of the request doesn't have")
+		return jsonrpc.NewError(id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
+	}
+	l.session.setLevel(req.Params.Level)
+	return jsonrpc.JSONRPCResponse{
+		Jsonrpc: jsonrpc.JSONRPC_VERSION,
+		Id:      id,
+		Result:  struct{}{},
+	}, nil
+}
+
+// mcpLogger is a logger that also sends its entries at or above severity to
+// an MCP client as notifications/message.
+type mcpLogger struct {
+	log.Logger
+	severity int
+	send     func(notification any)
+}
+
+func (l mcpLogger) DebugContext(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	l.Logger.DebugContext(ctx, msg, keysAndValues...)
+	l.notify("debug", msg, keysAndValues)
+}
+
+func (l mcpLogger) InfoContext(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	l.Logger.InfoContext(ctx, msg, keysAndValues...)
+	l.notify("info", msg, keysAndValues)
+}
+
+func (l mcpLogger) WarnContext(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	l.Logger.WarnContext(ctx, msg, keysAndValues...)
+	l.notify("warning", msg, keysAndValues)
+}
+
+func (l mcpLogger) ErrorContext(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	l.Logger.ErrorContext(ctx, msg, keysAndValues...)
+	l.notify("error", msg, keysAndValues)
+}
+
+func (l mcpLogger) notify(level, msg string, keysAndValues []interface{}) {
+	if slices.Index(mcputil.LOGGING_LEVELS, level) < l.severity {
+		return
+	}
+	var data any = msg
+	if len(keysAndValues) > 0 {
+		// the attributes of the entry are sent along with its message
+		m := map[string]any{"message": msg}
+		for i := 0; i+1 < len(keysAndValues); i += 2 {
+			m[fmt.Sprint(keysAndValues[i])] = keysAndValues[i+1]
+		}
+		data = m
+	}
+	l.send(mcputil.NewLoggingMessage(level, mcpLoggerName, data))
+}
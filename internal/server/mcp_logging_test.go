@@ -0,0 +1,183 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/log"
+	"github.com/googleapis/genai-toolbox/internal/server/mcp/jsonrpc"
+	mcputil "github.com/googleapis/genai-toolbox/internal/server/mcp/util"
+)
+
+func TestMcpLogger(t *testing.T) {
+	base, err := log.NewStdLogger(&bytes.Buffer{}, &bytes.Buffer{}, "debug")
+	if err != nil {
+		t.Fatalf("unable to initialize logger: %s", err)
+	}
+	var got []any
+	logging := &mcpLogging{session: &mcpSessionLog{}, send: func(n any) { got = append(got, n) }}
+
+	// entries are not sent until the client sets a level
+	if logging.logger(base) != base {
+		t.Fatalf("expected the base logger before the client opts in")
+	}
+	var nilLogging *mcpLogging
+	if nilLogging.logger(base) != base {
+		t.Fatalf("expected the base logger without a session")
+	}
+
+	logging.session.setLevel("warning")
+	ctx := context.Background()
+	logger := logging.logger(base)
+	logger.DebugContext(ctx, "debug message")
+	logger.InfoContext(ctx, "info message")
+	logger.WarnContext(ctx, "warn message")
+	logger.ErrorContext(ctx, "error message", "tool", "my-tool")
+
+	want := []any{
+		mcputil.NewLoggingMessage("warning", mcpLoggerName, "warn message"),
+		mcputil.NewLoggingMessage("error", mcpLoggerName, map[string]any{"message": "error message", "tool": "my-tool"}),
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected notifications: diff %v", diff)
+	}
+}
+
+func TestMcpSetLevel(t *testing.T) {
+	setLevel := func(level string) []byte {
+		b, _ := json.Marshal(map[string]any{
+			"jsonrpc": jsonrpcVersion,
+			"id":      "set-level",
+			"method":  mcputil.LOGGING_SET_LEVEL,
+			"params":  map[string]any{"level": level},
+		})
+		return b
+	}
+
+	logging := &mcpLogging{session: &mcpSessionLog{}, send: func(any) {}}
+	if _, err := logging.setLevelHandler("set-level", setLevel("info")); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := logging.session.getLevel(); got != "info" {
+		t.Fatalf("unexpected level: got %q, want %q", got, "info")
+	}
+
+	res, err := logging.setLevelHandler("set-level", setLevel("verbose"))
+	if err == nil {
+		t.Fatalf("expected an error for an invalid level")
+	}
+	if code := res.(jsonrpc.JSONRPCError).Error.Code; code != jsonrpc.INVALID_PARAMS {
+		t.Fatalf("unexpected error code: got %d, want %d", code, jsonrpc.INVALID_PARAMS)
+	}
+	if got := logging.session.getLevel(); got != "info" {
+		t.Fatalf("the level was changed by an invalid request")
+	}
+
+	var nilLogging *mcpLogging
+	if _, err := nilLogging.setLevelHandler("set-level", setLevel("info")); err == nil {
+		t.Fatalf("expected an error without a session")
+	}
+}
+
+func TestMcpLoggingNotifications(t *testing.T) {
+	toolsMap, toolsets := setUpResources(t, []MockTool{tool1, tool2})
+	r, shutdown := setUpServer(t, "mcp", toolsMap, toolsets)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	initializeWant := map[string]any{
+		"jsonrpc": jsonrpcVersion,
+		"id":      "mcp-initialize",
+		"result": map[string]any{
+			"protocolVersion": "2025-03-26",
+			"capabilities": map[string]any{
+				"completions": map[string]any{},
+				"logging":     map[string]any{},
+				"resources":   map[string]any{},
+				"tools":       map[string]any{"listChanged": false},
+			},
+			"serverInfo": map[string]any{"name": serverName, "version": fakeVersionString},
+		},
+	}
+	sessionId := runInitializeLifecycle(t, ts, "2025-03-26", initializeWant, true)
+	header := map[string]string{"Mcp-Session-Id": sessionId}
+
+	post := func(body map[string]any, header map[string]string) (*http.Response, string) {
+		t.Helper()
+		b, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("unexpected error during marshaling of body")
+		}
+		resp, respBody, err := runRequest(ts, http.MethodPost, "/", bytes.NewBuffer(b), header)
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		return resp, string(respBody)
+	}
+	callTool := map[string]any{
+		"jsonrpc": jsonrpcVersion,
+		"id":      "tools-call",
+		"method":  "tools/call",
+		"params":  map[string]any{"name": tool1.Name},
+	}
+
+	// no log messages are sent before the client opts in
+	eventHeader := map[string]string{"Mcp-Session-Id": sessionId, "Accept": "application/json, text/event-stream"}
+	resp, _ := post(callTool, eventHeader)
+	if contentType := resp.Header.Get("Content-Type"); contentType != "application/json" {
+		t.Fatalf("unexpected content-type header: got %s, want application/json", contentType)
+	}
+
+	resp, body := post(map[string]any{
+		"jsonrpc": jsonrpcVersion,
+		"id":      "set-level",
+		"method":  mcputil.LOGGING_SET_LEVEL,
+		"params":  map[string]any{"level": "debug"},
+	}, header)
+	if resp.StatusCode != http.StatusOK || !strings.Contains(body, `"result":{}`) {
+		t.Fatalf("unexpected logging/setLevel response: %d %s", resp.StatusCode, body)
+	}
+
+	resp, body = post(callTool, eventHeader)
+	if contentType := resp.Header.Get("Content-Type"); contentType != "text/event-stream" {
+		t.Fatalf("unexpected content-type header: got %s, want text/event-stream", contentType)
+	}
+	events := strings.Split(strings.TrimSpace(body), "\n\n")
+	if len(events) < 2 {
+		t.Fatalf("expected log messages before the response, got %q", body)
+	}
+	for _, event := range events[:len(events)-1] {
+		if !strings.Contains(event, `"method":"notifications/message"`) || !strings.Contains(event, `"level":"debug"`) {
+			t.Fatalf("unexpected log message event: %q", event)
+		}
+	}
+	if last := events[len(events)-1]; !strings.Contains(last, `"id":"tools-call"`) {
+		t.Fatalf("expected the response as the last event, got %q", last)
+	}
+
+	// clients that don't accept an event stream only get the response
+	resp, _ = post(callTool, header)
+	if contentType := resp.Header.Get("Content-Type"); contentType != "application/json" {
+		t.Fatalf("unexpected content-type header: got %s, want application/json", contentType)
+	}
+}
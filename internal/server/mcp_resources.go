@@ -0,0 +1,143 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/googleapis/genai-toolbox/internal/server/mcp/jsonrpc"
+	mcputil "github.com/googleapis/genai-toolbox/internal/server/mcp/util"
+)
+
+const (
+	// resultURIPrefix prefixes the URIs of the full results of summarized
+	// tool calls.
+	resultURIPrefix = "toolbox://results/"
+	// resultTTL is how long full results can be read after they were
+	// summarized.
+	resultTTL = 10 * time.Minute
+	// maxResults is the number of full results kept, beyond which the oldest
+	// are dropped.
+	maxResults = 100
+)
+
+// mcpResultStore keeps the full results of summarized tool calls, which the
+// client of the session they were returned to reads as resources.
+type mcpResultStore struct {
+	mu      sync.Mutex
+	results map[string]*mcpResult
+}
+
+type mcpResult struct {
+	session string
+	name    string
+	text    string
+	created time.Time
+}
+
+func newMcpResultStore() *mcpResultStore {
+	return &mcpResultStore{results: make(map[string]*mcpResult)}
+}
+
+// add keeps the full result of a call of toolName in a session, and returns
+// the URI and name of its resource.
+func (m *mcpResultStore) add(id, session, toolName, text string) (string, string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.evict()
+	if len(m.results) >= maxResults {
+		var oldest string
+		for uri, r := range m.results {
+			if oldest == "" || r.created.Before(m.results[oldest].created) {
+				oldest = uri
+			}
+		}
+		delete(m.results, oldest)
+	}
+
+	uri := resultURIPrefix + id
+	name := fmt.Sprintf("%s result", toolName)
+	m.results[uri] = &mcpResult{session: session, name: name, text: text, created: time.Now()}
+	return uri, name
+}
+
+// evict drops the expired results. It must be called with mu held.
+func (m *mcpResultStore) evict() {
+	now := time.Now()
+	for uri, r := range m.results {
+		if now.Sub(r.created) > resultTTL {
+			delete(m.results, uri)
+		}
+	}
+}
+
+// listHandler handles the resources/list request of a session, listing its
+// results.
+func (m *mcpResultStore) listHandler(id jsonrpc.RequestId, session string) (any, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.evict()
+	resources := []mcputil.Resource{}
+	for uri, r := range m.results {
+		if r.session != session {
+			continue
+		}
+		resources = append(resources, mcputil.Resource{
+			BaseMetadata: mcputil.BaseMetadata{Name: r.name},
+			URI:          uri,
+			MimeType:     "application/json",
+			Size:         len(r.text),
+		})
+	}
+	sort.Slice(resources, func(i, j int) bool {
+		return m.results[resources[i].URI].created.Before(m.results[resources[j].URI].created)
+	})
+	return jsonrpc.JSONRPCResponse{
+		Jsonrpc: jsonrpc.JSONRPC_VERSION,
+		Id:      id,
+		Result:  mcputil.ListResourcesResult{Resources: resources},
+	}, nil
+}
+
+// readHandler handles the resources/read request of a session in body. Only
+// the results of the session can be read.
+func (m *mcpResultStore) readHandler(id jsonrpc.RequestId, session string, body []byte) (any, error) {
+	var req mcputil.ReadResourceRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		err = fmt.Errorf("invalid mcp resources/read request: %w", err)
+		return jsonrpc.NewError(id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
+	}
+	uri := req.Params.URI
+
+	m.mu.Lock()
+	m.evict()
+	r, ok := m.results[uri]
+	m.mu.Unlock()
+	if !ok || r.session != session {
+		err := fmt.Errorf("resource not found")
+		return jsonrpc.NewError(id, mcputil.RESOURCE_NOT_FOUND, err.Error(), map[string]any{"uri": uri}), err
+	}
+	return jsonrpc.JSONRPCResponse{
+		Jsonrpc: jsonrpc.JSONRPC_VERSION,
+		Id:      id,
+		Result: mcputil.ReadResourceResult{
+			Contents: []mcputil.TextResourceContents{{URI: uri, MimeType: "application/json", Text: r.text}},
+		},
+	}, nil
+}
@@ -0,0 +1,200 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/googleapis/genai-toolbox/internal/server/mcp/jsonrpc"
+	mcputil "github.com/googleapis/genai-toolbox/internal/server/mcp/util"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+// samplingTimeout bounds the wait for the client to sample its LLM, which
+// may involve the approval of its user.
+const samplingTimeout = 2 * time.Minute
+
+// summarizePrompt is the system prompt of the summaries of results.
+const summarizePrompt = "You summarize the results of database tools for an AI agent. Be concise, and keep the facts, figures and identifiers the agent needs to answer questions about the data."
+
+// mcpClientRequests tracks the requests sent to the client of a session,
+// which it answers with messages of its own.
+type mcpClientRequests struct {
+	mu sync.Mutex
+	// sampling is whether the client declared the sampling capability
+	sampling bool
+	pending  map[string]chan mcpClientResponse
+}
+
+// mcpClientResponse is the response of a client to a request of the server.
+type mcpClientResponse struct {
+	Method string            `json:"method"`
+	Id     jsonrpc.RequestId `json:"id"`
+	Result json.RawMessage   `json:"result"`
+	Error  *jsonrpc.Error    `json:"error"`
+}
+
+// setCapabilities records the capabilities the client declared when it
+// initialized the session.
+func (r *mcpClientRequests) setCapabilities(capabilities mcputil.ClientCapabilities) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sampling = capabilities.Sampling != nil
+}
+
+func (r *mcpClientRequests) supportsSampling() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.sampling
+}
+
+// call sends a request to the client with send, and returns the result of its
+// response.
+func (r *mcpClientRequests) call(ctx context.Context, send func(request any) error, method string, params any) (json.RawMessage, error) {
+	id := util.NewID(ctx)
+	ch := make(chan mcpClientResponse, 1)
+	r.mu.Lock()
+	if r.pending == nil {
+		r.pending = make(map[string]chan mcpClientResponse)
+	}
+	r.pending[id] = ch
+	r.mu.Unlock()
+	defer func() {
+		r.mu.Lock()
+		delete(r.pending, id)
+		r.mu.Unlock()
+	}()
+
+	req := jsonrpc.JSONRPCRequest{
+		Jsonrpc: jsonrpc.JSONRPC_VERSION,
+		Id:      id,
+		Request: jsonrpc.Request{Method: method},
+		Params:  params,
+	}
+	if err := send(req); err != nil {
+		return nil, fmt.Errorf("unable to send %s request: %w", method, err)
+	}
+	select {
+	case res := <-ch:
+		if res.Error != nil {
+			return nil, fmt.Errorf("%s request failed: %s", method, res.Error.Message)
+		}
+		return res.Result, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("no response to %s request: %w", method, ctx.Err())
+	}
+}
+
+// deliver passes the message in body to the request it answers, and returns
+// false if the message isn't a response. Responses to requests that are no
+// longer pending are dropped.
+func (r *mcpClientRequests) deliver(body []byte) bool {
+	var res mcpClientResponse
+	if err := json.Unmarshal(body, &res); err != nil {
+		return false
+	}
+	if res.Method != "" || res.Id == nil || (res.Result == nil && res.Error == nil) {
+		return false
+	}
+	if r == nil {
+		return true
+	}
+	id, _ := res.Id.(string)
+	r.mu.Lock()
+	ch, ok := r.pending[id]
+	r.mu.Unlock()
+	if ok {
+		select {
+		case ch <- res:
+		default:
+		}
+	}
+	return true
+}
+
+// mcpSampling samples the LLM of the client of a session, by sending it
+// requests with send.
+type mcpSampling struct {
+	requests *mcpClientRequests
+	send     func(request any) error
+}
+
+// createMessage asks the client to sample its LLM.
+func (m *mcpSampling) createMessage(ctx context.Context, params mcputil.CreateMessageParams) (string, error) {
+	raw, err := m.requests.call(ctx, m.send, mcputil.SAMPLING_CREATE_MESSAGE, params)
+	if err != nil {
+		return "", err
+	}
+	var res mcputil.CreateMessageResult
+	if err := json.Unmarshal(raw, &res); err != nil {
+		return "", fmt.Errorf("invalid sampling result: %w", err)
+	}
+	if res.Content.Type != "text" || res.Content.Text == "" {
+		return "", fmt.Errorf("the client sampled no text")
+	}
+	return res.Content.Text, nil
+}
+
+// estimateTokens estimates the number of tokens of text, at roughly 4 bytes
+// per token.
+func estimateTokens(text string) int {
+	return (len(text) + 3) / 4
+}
+
+// summarizer returns the summarizer of the results exceeding the token
+// budget of the server, or nil if it has no budget or the client of sampling
+// can't sample. Summarized results are kept as resources of the session.
+func (s *Server) summarizer(sampling *mcpSampling) mcputil.Summarizer {
+	if s.resultTokenBudget <= 0 || s.mcpResults == nil || sampling == nil || !sampling.requests.supportsSampling() {
+		return nil
+	}
+	return func(ctx context.Context, toolName string, rows []string) *mcputil.Summary {
+		result := "[" + strings.Join(rows, ",") + "]"
+		if estimateTokens(result) <= s.resultTokenBudget {
+			return nil
+		}
+		logger, err := util.LoggerFromContext(ctx)
+		if err != nil {
+			return nil
+		}
+
+		sampleCtx, cancel := context.WithTimeout(ctx, samplingTimeout)
+		defer cancel()
+		text, err := sampling.createMessage(sampleCtx, mcputil.CreateMessageParams{
+			Messages: []mcputil.SamplingMessage{{
+				Role: "user",
+				Content: mcputil.SamplingContent{
+					Type: "text",
+					Text: fmt.Sprintf("Summarize the %d rows of the result of the tool %q:\n\n%s", len(rows), toolName, result),
+				},
+			}},
+			SystemPrompt:   summarizePrompt,
+			IncludeContext: "none",
+			MaxTokens:      s.resultTokenBudget,
+		})
+		if err != nil {
+			logger.WarnContext(ctx, fmt.Sprintf("unable to summarize the result of tool %q, returning it in full: %s", toolName, err))
+			return nil
+		}
+
+		uri, name := s.mcpResults.add(util.NewID(ctx), util.SessionIDFromContext(ctx), toolName, result)
+		return &mcputil.Summary{Text: text, URI: uri, Name: name, Rows: len(rows)}
+	}
+}
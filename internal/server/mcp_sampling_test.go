@@ -0,0 +1,285 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server/mcp/jsonrpc"
+	mcputil "github.com/googleapis/genai-toolbox/internal/server/mcp/util"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+func TestMcpClientRequests(t *testing.T) {
+	requests := &mcpClientRequests{}
+	requests.setCapabilities(mcputil.ClientCapabilities{Sampling: &struct{}{}})
+	if !requests.supportsSampling() {
+		t.Fatalf("expected the client to support sampling")
+	}
+
+	respond := func(request any) error {
+		id := request.(jsonrpc.JSONRPCRequest).Id
+		b, _ := json.Marshal(map[string]any{"jsonrpc": jsonrpcVersion, "id": id, "result": map[string]any{"ok": true}})
+		go func() {
+			if !requests.deliver(b) {
+				t.Errorf("expected the response to be delivered")
+			}
+		}()
+		return nil
+	}
+	got, err := requests.call(context.Background(), respond, "test/method", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if string(got) != `{"ok":true}` {
+		t.Fatalf("unexpected result: %s", got)
+	}
+
+	fail := func(request any) error {
+		id := request.(jsonrpc.JSONRPCRequest).Id
+		b, _ := json.Marshal(jsonrpc.NewError(id, jsonrpc.INTERNAL_ERROR, "user rejected sampling", nil))
+		go requests.deliver(b)
+		return nil
+	}
+	if _, err := requests.call(context.Background(), fail, "test/method", nil); err == nil || !strings.Contains(err.Error(), "user rejected sampling") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// requests are not responses, and late responses are dropped
+	if requests.deliver([]byte(`{"jsonrpc":"2.0","id":"1","method":"tools/list"}`)) {
+		t.Fatalf("a request was delivered as a response")
+	}
+	if !requests.deliver([]byte(`{"jsonrpc":"2.0","id":"unknown","result":{}}`)) {
+		t.Fatalf("expected a late response to be dropped")
+	}
+	var nilRequests *mcpClientRequests
+	if !nilRequests.deliver([]byte(`{"jsonrpc":"2.0","id":"unknown","result":{}}`)) {
+		t.Fatalf("expected a response without session to be dropped")
+	}
+}
+
+// samplingResponse returns the response of a client to the sampling request
+// in event, summarizing with summary.
+func samplingResponse(t *testing.T, event string, summary string) []byte {
+	t.Helper()
+	var req struct {
+		Id     string                      `json:"id"`
+		Method string                      `json:"method"`
+		Params mcputil.CreateMessageParams `json:"params"`
+	}
+	if err := json.Unmarshal([]byte(event), &req); err != nil {
+		t.Fatalf("unable to unmarshal sampling request %q: %s", event, err)
+	}
+	if req.Method != mcputil.SAMPLING_CREATE_MESSAGE || len(req.Params.Messages) != 1 || !strings.Contains(req.Params.Messages[0].Content.Text, `"some_params"`) {
+		t.Fatalf("unexpected sampling request: %s", event)
+	}
+	b, _ := json.Marshal(map[string]any{
+		"jsonrpc": jsonrpcVersion,
+		"id":      req.Id,
+		"result": map[string]any{
+			"role":    "assistant",
+			"content": map[string]any{"type": "text", "text": summary},
+			"model":   "test-model",
+		},
+	})
+	return b
+}
+
+func TestMcpSummarization(t *testing.T) {
+	toolsMap, toolsets := setUpResources(t, []MockTool{tool1, tool2})
+	server, shutdown := newTestServer(t, NewResourceManager(nil, nil, toolsMap, toolsets))
+	defer shutdown()
+	server.resultTokenBudget = 1
+	r, err := mcpRouter(server)
+	if err != nil {
+		t.Fatalf("unable to initialize mcp router: %s", err)
+	}
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	post := func(body map[string]any, header map[string]string) (*http.Response, []byte) {
+		t.Helper()
+		b, _ := json.Marshal(body)
+		resp, respBody, err := runRequest(ts, http.MethodPost, "/", bytes.NewBuffer(b), header)
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		return resp, respBody
+	}
+	initialize := func(capabilities map[string]any) string {
+		t.Helper()
+		resp, _ := post(map[string]any{
+			"jsonrpc": jsonrpcVersion,
+			"id":      "mcp-initialize",
+			"method":  "initialize",
+			"params":  map[string]any{"protocolVersion": "2025-03-26", "capabilities": capabilities},
+		}, nil)
+		return resp.Header.Get("Mcp-Session-Id")
+	}
+	callTool := map[string]any{
+		"jsonrpc": jsonrpcVersion,
+		"id":      "tools-call",
+		"method":  "tools/call",
+		"params":  map[string]any{"name": tool2.Name, "arguments": map[string]any{"param1": 1, "param2": 2}},
+	}
+
+	// clients without sampling get the full result
+	plainSession := initialize(map[string]any{})
+	_, body := post(callTool, map[string]string{"Mcp-Session-Id": plainSession, "Accept": "application/json, text/event-stream"})
+	if !strings.Contains(string(body), `\"some_params\"`) {
+		t.Fatalf("expected the full result, got %s", body)
+	}
+
+	session := initialize(map[string]any{"sampling": map[string]any{}})
+	b, _ := json.Marshal(callTool)
+	req, _ := http.NewRequest(http.MethodPost, ts.URL+"/", bytes.NewBuffer(b))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+	req.Header.Set("Mcp-Session-Id", session)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	defer resp.Body.Close()
+	if contentType := resp.Header.Get("Content-Type"); contentType != "text/event-stream" {
+		t.Fatalf("unexpected content-type header: got %s, want text/event-stream", contentType)
+	}
+
+	events := bufio.NewReader(resp.Body)
+	readEvent := func() string {
+		t.Helper()
+		for {
+			line, err := events.ReadString('\n')
+			if err != nil {
+				t.Fatalf("unable to read event: %s", err)
+			}
+			if data, ok := strings.CutPrefix(line, "data: "); ok {
+				return strings.TrimSpace(data)
+			}
+		}
+	}
+	answer := samplingResponse(t, readEvent(), "a summary of the rows")
+	resp2, _, err := runRequest(ts, http.MethodPost, "/", bytes.NewBuffer(answer), map[string]string{"Mcp-Session-Id": session})
+	if err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	if resp2.StatusCode != http.StatusAccepted {
+		t.Fatalf("unexpected status of the sampling response: %d", resp2.StatusCode)
+	}
+
+	var result struct {
+		Result struct {
+			Content []struct {
+				Text string `json:"text"`
+			} `json:"content"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal([]byte(readEvent()), &result); err != nil {
+		t.Fatalf("unable to unmarshal the result: %s", err)
+	}
+	if len(result.Result.Content) != 2 || result.Result.Content[0].Text != "a summary of the rows" {
+		t.Fatalf("unexpected summarized result: %+v", result)
+	}
+	uri := strings.TrimSuffix(result.Result.Content[1].Text[strings.Index(result.Result.Content[1].Text, resultURIPrefix):], ".")
+
+	// the full result is a resource of the session
+	readResource := func(session string) map[string]any {
+		t.Helper()
+		_, body := post(map[string]any{
+			"jsonrpc": jsonrpcVersion,
+			"id":      "resources-read",
+			"method":  mcputil.RESOURCES_READ,
+			"params":  map[string]any{"uri": uri},
+		}, map[string]string{"Mcp-Session-Id": session})
+		var got map[string]any
+		if err := json.Unmarshal(body, &got); err != nil {
+			t.Fatalf("unable to unmarshal %s: %s", body, err)
+		}
+		return got
+	}
+	want := map[string]any{
+		"contents": []any{map[string]any{"uri": uri, "mimeType": "application/json", "text": `["some_params"]`}},
+	}
+	if diff := cmp.Diff(want, readResource(session)["result"]); diff != "" {
+		t.Fatalf("unexpected resource: diff %v", diff)
+	}
+	if _, ok := readResource(plainSession)["error"]; !ok {
+		t.Fatalf("expected the resource to be hidden from other sessions")
+	}
+}
+
+func TestStdioSampling(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	toolsMap, toolsets := setUpResources(t, []MockTool{tool1, tool2})
+	server, shutdown := newTestServer(t, NewResourceManager(nil, nil, toolsMap, toolsets))
+	defer shutdown()
+	server.resultTokenBudget = 1
+
+	inR, inW := io.Pipe()
+	outR, outW := io.Pipe()
+	session := NewStdioSession(server, inR, outW)
+	go func() { _ = session.Start(util.WithLogger(ctx, server.logger)) }()
+	out := bufio.NewReader(outR)
+	send := func(message string) {
+		t.Helper()
+		if _, err := fmt.Fprintln(inW, message); err != nil {
+			t.Fatalf("unable to write message: %s", err)
+		}
+	}
+	receive := func() string {
+		t.Helper()
+		line, err := out.ReadString('\n')
+		if err != nil {
+			t.Fatalf("unable to read message: %s", err)
+		}
+		return line
+	}
+
+	send(`{"jsonrpc":"2.0","id":"mcp-initialize","method":"initialize","params":{"protocolVersion":"2025-06-18","capabilities":{"sampling":{}}}}`)
+	receive()
+	send(`{"jsonrpc":"2.0","id":"tools-call","method":"tools/call","params":{"name":"some_params","arguments":{"param1":1,"param2":2}}}`)
+	// the response to the sampling request is read while the call waits
+	send(string(samplingResponse(t, receive(), "a summary of the rows")))
+
+	var result struct {
+		Result struct {
+			Content []map[string]any `json:"content"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal([]byte(receive()), &result); err != nil {
+		t.Fatalf("unable to unmarshal the result: %s", err)
+	}
+	if len(result.Result.Content) != 2 {
+		t.Fatalf("unexpected summarized result: %+v", result)
+	}
+	if got := result.Result.Content[0]["text"]; got != "a summary of the rows" {
+		t.Fatalf("unexpected summary: %v", got)
+	}
+	link := result.Result.Content[1]
+	if link["type"] != "resource_link" || !strings.HasPrefix(link["uri"].(string), resultURIPrefix) {
+		t.Fatalf("unexpected resource link: %v", link)
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"github.com/googleapis/genai-toolbox/internal/server/mcp/jsonrpc"
+	mcputil "github.com/googleapis/genai-toolbox/internal/server/mcp/util"
+)
+
+var (
+	// errCallCancelled is the cause of the calls cancelled with
+	// $/cancelRequest, which are answered with a REQUEST_CANCELLED error.
+	errCallCancelled = errors.New("request cancelled")
+	// errCallAbandoned is the cause of the calls cancelled with
+	// notifications/cancelled, which aren't answered.
+	errCallAbandoned = errors.New("request abandoned")
+)
+
+// stdioCalls runs the tools calls of a stdio session on a bounded pool of
+// workers, so that a slow call doesn't hold up the other messages of the
+// session, and tracks them so that the client can cancel them.
+type stdioCalls struct {
+	// workers holds a token for each call running
+	workers chan struct{}
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	calls   map[string]*stdioCall
+}
+
+// stdioCall is a tools call of a stdio session, tracked from when it is
+// read until it returns.
+type stdioCall struct {
+	id     jsonrpc.RequestId
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+}
+
+func newStdioCalls(workers int) *stdioCalls {
+	return &stdioCalls{
+		workers: make(chan struct{}, max(workers, 1)),
+		calls:   make(map[string]*stdioCall),
+	}
+}
+
+// add tracks a call of the request id, with a context derived from ctx which
+// is cancelled when the client cancels the request.
+func (c *stdioCalls) add(ctx context.Context, id jsonrpc.RequestId) *stdioCall {
+	ctx, cancel := context.WithCancelCause(ctx)
+	call := &stdioCall{id: id, ctx: ctx, cancel: cancel}
+	c.mu.Lock()
+	c.calls[requestKey(id)] = call
+	c.mu.Unlock()
+	return call
+}
+
+// remove stops tracking call.
+func (c *stdioCalls) remove(call *stdioCall) {
+	key := requestKey(call.id)
+	c.mu.Lock()
+	// the id may have been reused by a later call
+	if c.calls[key] == call {
+		delete(c.calls, key)
+	}
+	c.mu.Unlock()
+	call.cancel(nil)
+}
+
+// start runs run with the context of call in a new goroutine once a worker
+// is free. It blocks until then, which stops reading the messages of the
+// client while all the workers are busy, unless ctx is done first. A call
+// cancelled while it waits runs right away, without a worker, to answer
+// the request.
+func (c *stdioCalls) start(ctx context.Context, call *stdioCall, run func(ctx context.Context)) error {
+	worker := false
+	select {
+	case c.workers <- struct{}{}:
+		worker = true
+	case <-ctx.Done():
+		c.remove(call)
+		return ctx.Err()
+	case <-call.ctx.Done():
+	}
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		defer func() {
+			c.remove(call)
+			if worker {
+				<-c.workers
+			}
+		}()
+		run(call.ctx)
+	}()
+	return nil
+}
+
+// cancel cancels the call of the request id with cause, and reports whether
+// it is tracked.
+func (c *stdioCalls) cancel(id jsonrpc.RequestId, cause error) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	call, ok := c.calls[requestKey(id)]
+	if ok {
+		call.cancel(cause)
+	}
+	return ok
+}
+
+// wait waits for the calls running to return.
+func (c *stdioCalls) wait() {
+	c.wg.Wait()
+}
+
+// requestKey returns the key of the request id in the calls running, the
+// same for the ids of a request and of the notifications cancelling it.
+func requestKey(id jsonrpc.RequestId) string {
+	b, _ := json.Marshal(id)
+	return string(b)
+}
+
+// cancelledRequest returns the id of the request a $/cancelRequest or
+// notifications/cancelled notification cancels, and the cause to cancel it
+// with, which is nil if message isn't such a notification.
+func cancelledRequest(message []byte) (jsonrpc.RequestId, error) {
+	var n struct {
+		Method string `json:"method"`
+		Params struct {
+			Id        jsonrpc.RequestId `json:"id"`
+			RequestId jsonrpc.RequestId `json:"requestId"`
+		} `json:"params"`
+	}
+	if err := json.Unmarshal(message, &n); err != nil {
+		return nil, nil
+	}
+	switch n.Method {
+	case mcputil.CANCEL_REQUEST:
+		return n.Params.Id, errCallCancelled
+	case mcputil.NOTIFICATIONS_CANCELLED:
+		return n.Params.RequestId, errCallAbandoned
+	default:
+		return nil, nil
+	}
+}
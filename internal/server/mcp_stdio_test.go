@@ -0,0 +1,173 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+// blockingTool blocks its invocations until they are cancelled.
+type blockingTool struct {
+	MockTool
+}
+
+func (blockingTool) Invoke(ctx context.Context, _ tools.ParamValues) (any, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+// startStdioSession starts a stdio session with the slow and no_params tools
+// and the given number of workers, and returns functions to send messages to
+// it and receive the id and error code of its responses.
+func startStdioSession(t *testing.T, workers int) (send func(string), receive func() (any, int)) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	slow := blockingTool{MockTool{Name: "slow", Params: []tools.Parameter{}}}
+	toolsMap := map[string]tools.Tool{slow.Name: slow, tool1.Name: tool1}
+	toolset, err := tools.ToolsetConfig{Name: "", ToolNames: []string{slow.Name, tool1.Name}}.Initialize(fakeVersionString, toolsMap)
+	if err != nil {
+		t.Fatalf("unable to initialize toolset: %s", err)
+	}
+	server, shutdown := newTestServer(t, NewResourceManager(nil, nil, toolsMap, map[string]tools.Toolset{"": toolset}))
+	t.Cleanup(shutdown)
+	server.stdioWorkers = workers
+
+	inR, inW := io.Pipe()
+	outR, outW := io.Pipe()
+	session := NewStdioSession(server, inR, outW)
+	go func() { _ = session.Start(util.WithLogger(ctx, server.logger)) }()
+	out := bufio.NewReader(outR)
+
+	send = func(message string) {
+		t.Helper()
+		if _, err := fmt.Fprintln(inW, message); err != nil {
+			t.Fatalf("unable to write message: %s", err)
+		}
+	}
+	receive = func() (any, int) {
+		t.Helper()
+		line, err := out.ReadString('\n')
+		if err != nil {
+			t.Fatalf("unable to read message: %s", err)
+		}
+		var res struct {
+			Id    any `json:"id"`
+			Error struct {
+				Code int `json:"code"`
+			} `json:"error"`
+		}
+		if err := json.Unmarshal([]byte(line), &res); err != nil {
+			t.Fatalf("unable to unmarshal response %q: %s", line, err)
+		}
+		return res.Id, res.Error.Code
+	}
+	send(`{"jsonrpc":"2.0","id":"mcp-initialize","method":"initialize","params":{"protocolVersion":"2025-06-18"}}`)
+	receive()
+	return send, receive
+}
+
+type stdioResponse struct {
+	Id   any
+	Code int
+}
+
+// stdioStep is a message sent to a stdio session, and the responses received
+// after it.
+type stdioStep struct {
+	send string
+	want []stdioResponse
+}
+
+func TestStdioCalls(t *testing.T) {
+	const (
+		callSlow   = `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"slow"}}`
+		callFast   = `{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"no_params"}}`
+		listTools  = `{"jsonrpc":"2.0","id":"list","method":"tools/list"}`
+		cancelSlow = `{"jsonrpc":"2.0","method":"$/cancelRequest","params":{"id":1}}`
+	)
+	tcs := []struct {
+		name    string
+		workers int
+		steps   []stdioStep
+	}{
+		{
+			name:    "slow call doesn't hold up others",
+			workers: 2,
+			steps: []stdioStep{
+				{send: callSlow},
+				{send: callFast, want: []stdioResponse{{Id: float64(2)}}},
+				{send: listTools, want: []stdioResponse{{Id: "list"}}},
+				{send: cancelSlow, want: []stdioResponse{{Id: float64(1), Code: -32800}}},
+			},
+		},
+		{
+			name:    "calls wait for a free worker",
+			workers: 1,
+			steps: []stdioStep{
+				{send: callSlow},
+				{send: callFast},
+				{send: cancelSlow, want: []stdioResponse{{Id: float64(1), Code: -32800}, {Id: float64(2)}}},
+			},
+		},
+		{
+			name:    "queued call cancelled",
+			workers: 1,
+			steps: []stdioStep{
+				{send: callSlow},
+				{send: `{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"slow"}}`},
+				{send: `{"jsonrpc":"2.0","method":"$/cancelRequest","params":{"id":2}}`, want: []stdioResponse{{Id: float64(2), Code: -32800}}},
+				{send: cancelSlow, want: []stdioResponse{{Id: float64(1), Code: -32800}}},
+			},
+		},
+		{
+			name:    "abandoned call isn't answered",
+			workers: 1,
+			steps: []stdioStep{
+				{send: callSlow},
+				{send: `{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":1}}`},
+				// the worker of the abandoned call is free again
+				{send: callFast, want: []stdioResponse{{Id: float64(2)}}},
+				{send: listTools, want: []stdioResponse{{Id: "list"}}},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			send, receive := startStdioSession(t, tc.workers)
+			for _, step := range tc.steps {
+				send(step.send)
+				var got []stdioResponse
+				for range step.want {
+					id, code := receive()
+					got = append(got, stdioResponse{Id: id, Code: code})
+				}
+				if diff := cmp.Diff(step.want, got); diff != "" {
+					t.Fatalf("unexpected responses to %s: diff %v", step.send, diff)
+				}
+			}
+		})
+	}
+}
@@ -264,7 +264,10 @@ func TestMcpEndpoint(t *testing.T) {
 				"result": map[string]any{
 					"protocolVersion": "2024-11-05",
 					"capabilities": map[string]any{
-						"tools": map[string]any{"listChanged": false},
+						"completions": map[string]any{},
+						"logging":     map[string]any{},
+						"resources":   map[string]any{},
+						"tools":       map[string]any{"listChanged": false},
 					},
 					"serverInfo": map[string]any{"name": serverName, "version": fakeVersionString},
 				},
@@ -280,7 +283,10 @@ func TestMcpEndpoint(t *testing.T) {
 				"result": map[string]any{
 					"protocolVersion": "2025-03-26",
 					"capabilities": map[string]any{
-						"tools": map[string]any{"listChanged": false},
+						"completions": map[string]any{},
+						"logging":     map[string]any{},
+						"resources":   map[string]any{},
+						"tools":       map[string]any{"listChanged": false},
 					},
 					"serverInfo": map[string]any{"name": serverName, "version": fakeVersionString},
 				},
@@ -296,7 +302,10 @@ func TestMcpEndpoint(t *testing.T) {
 				"result": map[string]any{
 					"protocolVersion": "2025-06-18",
 					"capabilities": map[string]any{
-						"tools": map[string]any{"listChanged": false},
+						"completions": map[string]any{},
+						"logging":     map[string]any{},
+						"resources":   map[string]any{},
+						"tools":       map[string]any{"listChanged": false},
 					},
 					"serverInfo": map[string]any{"name": serverName, "version": fakeVersionString},
 				},
@@ -730,7 +739,7 @@ func TestStdioSession(t *testing.T) {
 		t.Fatalf("unable to initialize logger: %s", err)
 	}
 
-	otelShutdown, err := telemetry.SetupOTel(ctx, fakeVersionString, "", false, "toolbox")
+	otelShutdown, err := telemetry.SetupOTel(ctx, fakeVersionString, "", false, "toolbox", nil)
 	if err != nil {
 		t.Fatalf("unable to setup otel: %s", err)
 	}
@@ -792,3 +801,77 @@ func TestStdioSession(t *testing.T) {
 		t.Fatalf("unexpected read: got %s, want %s", read, want)
 	}
 }
+
+func TestMcpRemovedByReload(t *testing.T) {
+	toolsMap, toolsets := setUpResources(t, []MockTool{tool1, tool2})
+	resourceManager := NewResourceManager(nil, nil, toolsMap, toolsets)
+	r, shutdown := setUpServerWithResources(t, "mcp", resourceManager)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	// reload without tool2 and the toolsets that reference it
+	resourceManager.SetResources(nil, nil, map[string]tools.Tool{tool1.Name: toolsMap[tool1.Name]}, map[string]tools.Toolset{"": toolsets["tool1_only"]})
+
+	testCases := []struct {
+		name     string
+		url      string
+		body     map[string]any
+		wantCode float64
+		wantKind string
+		wantName string
+	}{
+		{
+			name: "call removed tool",
+			url:  "/",
+			body: map[string]any{
+				"jsonrpc": jsonrpcVersion,
+				"id":      "tools-call",
+				"method":  "tools/call",
+				"params":  map[string]any{"name": tool2.Name, "arguments": map[string]any{}},
+			},
+			wantCode: jsonrpc.INVALID_PARAMS,
+			wantKind: "tool",
+			wantName: tool2.Name,
+		},
+		{
+			name: "list removed toolset",
+			url:  "/tool2_only",
+			body: map[string]any{
+				"jsonrpc": jsonrpcVersion,
+				"id":      "tools-list",
+				"method":  "tools/list",
+			},
+			wantCode: jsonrpc.INVALID_REQUEST,
+			wantKind: "toolset",
+			wantName: "tool2_only",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reqMarshal, err := json.Marshal(tc.body)
+			if err != nil {
+				t.Fatalf("unexpected error during marshaling of body")
+			}
+			_, body, err := runRequest(ts, http.MethodPost, tc.url, bytes.NewBuffer(reqMarshal), nil)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			var got struct {
+				Error struct {
+					Code float64 `json:"code"`
+					Data struct {
+						Removed ResourceRemovedError `json:"removed"`
+					} `json:"data"`
+				} `json:"error"`
+			}
+			if err := json.Unmarshal(body, &got); err != nil {
+				t.Fatalf("unexpected error unmarshalling body: %s", err)
+			}
+			removed := got.Error.Data.Removed
+			if got.Error.Code != tc.wantCode || removed.Kind != tc.wantKind || removed.Name != tc.wantName || removed.RemovedAt.IsZero() {
+				t.Fatalf("unexpected response: %s", body)
+			}
+		})
+	}
+}
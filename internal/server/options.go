@@ -0,0 +1,81 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/googleapis/genai-toolbox/internal/auth"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithClock sets the Clock of the timestamps the server returns to clients,
+// such as when reloads removed tools, and of the checks of policy time
+// windows. Expirations, such as of MCP sessions, always use the system clock.
+func WithClock(clock util.Clock) Option {
+	return func(s *Server) {
+		s.clock = clock
+	}
+}
+
+// WithIDGenerator sets the IDGenerator of the IDs the server returns to
+// clients, such as request and MCP session IDs, and of the IDs tools generate.
+func WithIDGenerator(ids util.IDGenerator) Option {
+	return func(s *Server) {
+		s.ids = ids
+	}
+}
+
+// WithClaimsEnricher adds a ClaimsEnricher enriching the claims verified by
+// auth services before they're used by invocations. Enrichers run in the
+// order they were added, each on the claims returned by the previous one.
+func WithClaimsEnricher(e auth.ClaimsEnricher) Option {
+	return func(s *Server) {
+		s.claimsEnrichers = append(s.claimsEnrichers, e)
+	}
+}
+
+// enrichClaims returns the claims verified by authService, enriched by the
+// ClaimsEnrichers of the server.
+func (s *Server) enrichClaims(ctx context.Context, authService string, claims map[string]any) (map[string]any, error) {
+	for _, e := range s.claimsEnrichers {
+		var err error
+		if claims, err = e.EnrichClaims(ctx, authService, claims); err != nil {
+			return nil, fmt.Errorf("unable to enrich the claims of auth service %q: %w", authService, err)
+		}
+	}
+	return claims, nil
+}
+
+// now returns the current time from the Clock of the server.
+func (s *Server) now() time.Time {
+	if s.clock == nil {
+		return util.SystemClock()
+	}
+	return s.clock()
+}
+
+// newID returns a new ID from the IDGenerator of the server.
+func (s *Server) newID() string {
+	if s.ids == nil {
+		return util.RandomIDs()
+	}
+	return s.ids()
+}
@@ -0,0 +1,202 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/policy"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+// PolicyConfig restricts when, and on which environments, the tools it lists
+// and the tools of the toolsets it lists can be invoked. Invocations must be
+// in one of TimeWindows, if any, and use a source whose `environment` is one
+// of Environments, if any.
+type PolicyConfig struct {
+	Name        string             `yaml:"name" validate:"required"`
+	Tools       []string           `yaml:"tools"`
+	Toolsets    []string           `yaml:"toolsets"`
+	TimeWindows []TimeWindowConfig `yaml:"timeWindows" validate:"dive"`
+	// TimeZone is the IANA time zone of the time windows, UTC by default.
+	TimeZone     string   `yaml:"timeZone"`
+	Environments []string `yaml:"environments"`
+}
+
+// TimeWindowConfig is a daily window of time, from Start to End formatted as
+// "HH:MM", on Days ("mon", "tue"...) or every day if empty. A window ending
+// before it starts ends on the next day.
+type TimeWindowConfig struct {
+	Days  []string `yaml:"days"`
+	Start string   `yaml:"start" validate:"required"`
+	End   string   `yaml:"end" validate:"required"`
+}
+
+// PolicyConfigs is a type used to allow unmarshal of the policy configs
+type PolicyConfigs map[string]PolicyConfig
+
+// validate interface
+var _ yaml.InterfaceUnmarshalerContext = &PolicyConfigs{}
+
+func (c *PolicyConfigs) UnmarshalYAML(ctx context.Context, unmarshal func(interface{}) error) error {
+	*c = make(PolicyConfigs)
+	var raw map[string]util.DelayedUnmarshaler
+	if err := unmarshal(&raw); err != nil {
+		return err
+	}
+	// Report the errors of every policy at once
+	var errs []error
+	for _, name := range sortedKeys(raw) {
+		u := raw[name]
+		var v map[string]any
+		if err := u.Unmarshal(&v); err != nil {
+			errs = append(errs, newConfigError(fmt.Errorf("unable to unmarshal %q: %w", name, err), "policies", name))
+			continue
+		}
+		actual := PolicyConfig{Name: name}
+		err := decodeResource(ctx, v, func() error {
+			dec, err := util.NewStrictDecoder(v)
+			if err != nil {
+				return newConfigError(fmt.Errorf("error creating decoder: %w", err), "policies", name)
+			}
+			if err := dec.DecodeContext(ctx, &actual); err != nil {
+				return newDecodeError("policies", name, v, fmt.Errorf("unable to parse policy %q: %w", name, err))
+			}
+			return nil
+		})
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if len(actual.TimeWindows) == 0 && len(actual.Environments) == 0 {
+			errs = append(errs, newConfigError(fmt.Errorf("policy %q must set timeWindows or environments", name), "policies", name))
+			continue
+		}
+		if len(actual.Tools) == 0 && len(actual.Toolsets) == 0 {
+			errs = append(errs, newConfigError(fmt.Errorf("policy %q must list tools or toolsets", name), "policies", name))
+			continue
+		}
+		if _, err := actual.policy(); err != nil {
+			errs = append(errs, newConfigError(fmt.Errorf("invalid policy %q: %w", name, err), "policies", name))
+			continue
+		}
+		(*c)[name] = actual
+	}
+	return errors.Join(errs...)
+}
+
+// policy returns the policy of c.
+func (c PolicyConfig) policy() (policy.Policy, error) {
+	p := policy.Policy{Name: c.Name, Environments: c.Environments, Location: time.UTC}
+	if c.TimeZone != "" {
+		loc, err := time.LoadLocation(c.TimeZone)
+		if err != nil {
+			return policy.Policy{}, fmt.Errorf("invalid time zone %q: %w", c.TimeZone, err)
+		}
+		p.Location = loc
+	}
+	for _, wc := range c.TimeWindows {
+		var w policy.TimeWindow
+		var err error
+		if w.Start, err = policy.ParseTimeOfDay(wc.Start); err != nil {
+			return policy.Policy{}, err
+		}
+		if w.End, err = policy.ParseTimeOfDay(wc.End); err != nil {
+			return policy.Policy{}, err
+		}
+		for _, d := range wc.Days {
+			day, err := policy.ParseWeekday(d)
+			if err != nil {
+				return policy.Policy{}, err
+			}
+			w.Days = append(w.Days, day)
+		}
+		p.TimeWindows = append(p.TimeWindows, w)
+	}
+	return p, nil
+}
+
+// applyPolicies wraps the tools of toolsMap that policies apply to, so that
+// their invocations are checked against the policies. environments are the
+// environments of the sources, by name.
+func applyPolicies(policies PolicyConfigs, toolsets ToolsetConfigs, toolConfigs ToolConfigs, toolsMap map[string]tools.Tool, environments map[string]string) error {
+	if len(policies) == 0 {
+		return nil
+	}
+	byTool := make(map[string][]policy.Policy)
+	for _, name := range sortedKeys(policies) {
+		pc := policies[name]
+		p, err := pc.policy()
+		if err != nil {
+			return fmt.Errorf("invalid policy %q: %w", name, err)
+		}
+		toolNames := slices.Clone(pc.Tools)
+		for _, toolsetName := range pc.Toolsets {
+			ts, ok := toolsets[toolsetName]
+			if !ok {
+				return fmt.Errorf("policy %q references toolset %q, which does not exist", name, toolsetName)
+			}
+			toolNames = append(toolNames, ts.ToolNames...)
+		}
+		slices.Sort(toolNames)
+		for _, toolName := range slices.Compact(toolNames) {
+			if _, ok := toolsMap[toolName]; !ok {
+				return fmt.Errorf("policy %q references tool %q, which does not exist", name, toolName)
+			}
+			byTool[toolName] = append(byTool[toolName], p)
+		}
+	}
+	for toolName, ps := range byTool {
+		toolsMap[toolName] = policyTool{
+			Tool:        toolsMap[toolName],
+			policies:    ps,
+			environment: environments[toolSourceName(toolConfigs[toolName])],
+		}
+	}
+	return nil
+}
+
+// policyTool checks the invocations of a tool against policies. Invocations
+// the policies don't allow fail with a policy.DeniedError.
+type policyTool struct {
+	tools.Tool
+	policies []policy.Policy
+	// environment is the environment of the source of the tool.
+	environment string
+}
+
+func (t policyTool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	now := util.Now(ctx)
+	for _, p := range t.policies {
+		if err := p.Check(now, t.environment); err != nil {
+			return nil, err
+		}
+	}
+	return t.Tool.Invoke(ctx, params)
+}
+
+// environmentSourceConfig is the config of a source with an `environment`,
+// such as "prod", which policies can restrict tools to.
+type environmentSourceConfig struct {
+	sources.SourceConfig
+	environment string
+}
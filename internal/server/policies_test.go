@@ -0,0 +1,352 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/policy"
+	"github.com/googleapis/genai-toolbox/internal/server/mcp/jsonrpc"
+	"github.com/googleapis/genai-toolbox/internal/sources/sqlite"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+func TestParsePolicyConfigs(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want PolicyConfigs
+		err  string
+	}{
+		{
+			desc: "basic example",
+			in: `
+			policies:
+				change-window:
+					tools:
+						- my-tool
+					toolsets:
+						- my-toolset
+					timeWindows:
+						- days: [mon, tue, wed, thu, fri]
+						  start: "18:00"
+						  end: "08:00"
+						- days: [sat, sun]
+						  start: "00:00"
+						  end: "00:00"
+					timeZone: America/New_York
+					environments:
+						- dev
+						- staging
+			`,
+			want: PolicyConfigs{
+				"change-window": PolicyConfig{
+					Name:     "change-window",
+					Tools:    []string{"my-tool"},
+					Toolsets: []string{"my-toolset"},
+					TimeWindows: []TimeWindowConfig{
+						{Days: []string{"mon", "tue", "wed", "thu", "fri"}, Start: "18:00", End: "08:00"},
+						{Days: []string{"sat", "sun"}, Start: "00:00", End: "00:00"},
+					},
+					TimeZone:     "America/New_York",
+					Environments: []string{"dev", "staging"},
+				},
+			},
+		},
+		{
+			desc: "no conditions",
+			in: `
+			policies:
+				change-window:
+					tools:
+						- my-tool
+			`,
+			err: `policy "change-window" must set timeWindows or environments`,
+		},
+		{
+			desc: "no tools",
+			in: `
+			policies:
+				change-window:
+					environments:
+						- dev
+			`,
+			err: `policy "change-window" must list tools or toolsets`,
+		},
+		{
+			desc: "invalid time zone",
+			in: `
+			policies:
+				change-window:
+					tools:
+						- my-tool
+					timeWindows:
+						- start: "18:00"
+						  end: "08:00"
+					timeZone: Nowhere/Special
+			`,
+			err: `invalid policy "change-window": invalid time zone "Nowhere/Special"`,
+		},
+		{
+			desc: "invalid time of day",
+			in: `
+			policies:
+				change-window:
+					tools:
+						- my-tool
+					timeWindows:
+						- start: 6pm
+						  end: "08:00"
+			`,
+			err: `invalid policy "change-window": invalid time of day "6pm": must be formatted as HH:MM`,
+		},
+		{
+			desc: "invalid day",
+			in: `
+			policies:
+				change-window:
+					tools:
+						- my-tool
+					timeWindows:
+						- days: [monday]
+						  start: "18:00"
+						  end: "08:00"
+			`,
+			err: `invalid policy "change-window": invalid day "monday"`,
+		},
+		{
+			desc: "missing end",
+			in: `
+			policies:
+				change-window:
+					tools:
+						- my-tool
+					timeWindows:
+						- start: "18:00"
+			`,
+			err: `unable to parse policy "change-window"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Policies PolicyConfigs `yaml:"policies"`
+			}{}
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Policies); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestParseSourceEnvironment(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	in := `
+	sources:
+		my-source:
+			kind: sqlite
+			database: my.db
+			environment: prod
+	`
+	got := struct {
+		Sources SourceConfigs `yaml:"sources"`
+	}{}
+	if err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(in), &got); err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+	want := SourceConfigs{
+		"my-source": environmentSourceConfig{
+			SourceConfig: sqlite.Config{Name: "my-source", Kind: "sqlite", Database: "my.db"},
+			environment:  "prod",
+		},
+	}
+	if diff := cmp.Diff(want, got.Sources, cmp.AllowUnexported(environmentSourceConfig{})); diff != "" {
+		t.Fatalf("incorrect parse: diff %v", diff)
+	}
+}
+
+func TestApplyPoliciesUnknownResources(t *testing.T) {
+	toolsMap, _ := setUpResources(t, []MockTool{tool1, tool2})
+	toolsets := ToolsetConfigs{"my-toolset": {Name: "my-toolset", ToolNames: []string{"missing"}}}
+	tcs := []struct {
+		desc     string
+		policies PolicyConfigs
+		err      string
+	}{
+		{
+			desc:     "unknown tool",
+			policies: PolicyConfigs{"non-prod": {Name: "non-prod", Tools: []string{"nope"}, Environments: []string{"dev"}}},
+			err:      `policy "non-prod" references tool "nope", which does not exist`,
+		},
+		{
+			desc:     "unknown toolset",
+			policies: PolicyConfigs{"non-prod": {Name: "non-prod", Toolsets: []string{"nope"}, Environments: []string{"dev"}}},
+			err:      `policy "non-prod" references toolset "nope", which does not exist`,
+		},
+		{
+			desc:     "unknown tool of toolset",
+			policies: PolicyConfigs{"non-prod": {Name: "non-prod", Toolsets: []string{"my-toolset"}, Environments: []string{"dev"}}},
+			err:      `policy "non-prod" references tool "missing", which does not exist`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := applyPolicies(tc.policies, toolsets, nil, toolsMap, nil)
+			if err == nil || err.Error() != tc.err {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestPolicyTimeWindowClock(t *testing.T) {
+	toolsMap, _ := setUpResources(t, []MockTool{tool1, tool2})
+	policies := PolicyConfigs{"office-hours": {
+		Name:        "office-hours",
+		Tools:       []string{tool1.Name},
+		TimeWindows: []TimeWindowConfig{{Start: "09:00", End: "17:00"}},
+	}}
+	if err := applyPolicies(policies, nil, nil, toolsMap, nil); err != nil {
+		t.Fatalf("unable to apply policies: %s", err)
+	}
+	tcs := []struct {
+		desc   string
+		now    time.Time
+		denied bool
+	}{
+		{desc: "in the window", now: time.Date(2025, 1, 6, 10, 0, 0, 0, time.UTC)},
+		{desc: "out of the window", now: time.Date(2025, 1, 6, 20, 0, 0, 0, time.UTC), denied: true},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctx := util.WithClock(context.Background(), util.FixedClock(tc.now))
+			_, err := toolsMap[tool1.Name].Invoke(ctx, nil)
+			var denied *policy.DeniedError
+			if got := errors.As(err, &denied); got != tc.denied {
+				t.Fatalf("unexpected error: got %v, want denied %t", err, tc.denied)
+			}
+		})
+	}
+}
+
+// setUpPolicyResources returns resources where tool1 is only allowed on
+// sources in the dev environment, which its source isn't
+func setUpPolicyResources(t *testing.T) *ResourceManager {
+	toolsMap, toolsets := setUpResources(t, []MockTool{tool1, tool2})
+	policies := PolicyConfigs{"non-prod": {Name: "non-prod", Tools: []string{tool1.Name}, Environments: []string{"dev"}}}
+	if err := applyPolicies(policies, nil, nil, toolsMap, nil); err != nil {
+		t.Fatalf("unable to apply policies: %s", err)
+	}
+	return NewResourceManager(nil, nil, toolsMap, toolsets)
+}
+
+func TestPolicyDenied(t *testing.T) {
+	r, shutdown := setUpServerWithResources(t, "api", setUpPolicyResources(t))
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	resp, body, err := runRequest(ts, http.MethodPost, "/tool/"+tool1.Name+"/invoke", bytes.NewBuffer([]byte(`{}`)), nil)
+	if err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("unexpected status code: got %d, want %d: %s", resp.StatusCode, http.StatusForbidden, string(body))
+	}
+	var got struct {
+		Policy policy.DeniedError `json:"policy"`
+	}
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatalf("unable to parse response: %s", err)
+	}
+	want := policy.DeniedError{Policy: "non-prod", Reason: `only allowed on sources in environments ["dev"]`}
+	if diff := cmp.Diff(want, got.Policy); diff != "" {
+		t.Fatalf("unexpected policy error: diff %v", diff)
+	}
+
+	// tools without policies are allowed
+	resp, _, err = runRequest(ts, http.MethodPost, "/tool/"+tool2.Name+"/invoke", bytes.NewBuffer([]byte(`{"param1": 1, "param2": 2}`)), nil)
+	if err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status code: got %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+}
+
+func TestMcpPolicyDenied(t *testing.T) {
+	r, shutdown := setUpServerWithResources(t, "mcp", setUpPolicyResources(t))
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	reqMarshal, err := json.Marshal(map[string]any{
+		"jsonrpc": jsonrpcVersion,
+		"id":      "tools-call",
+		"method":  "tools/call",
+		"params":  map[string]any{"name": tool1.Name, "arguments": map[string]any{}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error during marshaling of body")
+	}
+	_, body, err := runRequest(ts, http.MethodPost, "/", bytes.NewBuffer(reqMarshal), nil)
+	if err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	var got struct {
+		Error struct {
+			Code float64 `json:"code"`
+			Data struct {
+				Policy policy.DeniedError `json:"policy"`
+			} `json:"data"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatalf("unable to parse response: %s", err)
+	}
+	if got.Error.Code != jsonrpc.INVALID_REQUEST {
+		t.Fatalf("unexpected error code: got %v, want %v", got.Error.Code, jsonrpc.INVALID_REQUEST)
+	}
+	if got.Error.Data.Policy.Policy != "non-prod" {
+		t.Fatalf("unexpected policy error: %+v", got.Error.Data.Policy)
+	}
+}
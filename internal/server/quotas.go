@@ -0,0 +1,174 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/quota"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+// QuotaConfig is a daily budget of invocations and result bytes per caller,
+// shared by the tools it lists and the tools of the toolsets it lists.
+// Callers are identified by the email or subject verified by an auth service,
+// and anonymous callers share a budget.
+type QuotaConfig struct {
+	Name              string   `yaml:"name" validate:"required"`
+	Tools             []string `yaml:"tools"`
+	Toolsets          []string `yaml:"toolsets"`
+	InvocationsPerDay int64    `yaml:"invocationsPerDay" validate:"gte=0"`
+	ResultBytesPerDay int64    `yaml:"resultBytesPerDay" validate:"gte=0"`
+}
+
+// QuotaConfigs is a type used to allow unmarshal of the quota configs
+type QuotaConfigs map[string]QuotaConfig
+
+// validate interface
+var _ yaml.InterfaceUnmarshalerContext = &QuotaConfigs{}
+
+func (c *QuotaConfigs) UnmarshalYAML(ctx context.Context, unmarshal func(interface{}) error) error {
+	*c = make(QuotaConfigs)
+	var raw map[string]util.DelayedUnmarshaler
+	if err := unmarshal(&raw); err != nil {
+		return err
+	}
+	// Report the errors of every quota at once
+	var errs []error
+	for _, name := range sortedKeys(raw) {
+		u := raw[name]
+		var v map[string]any
+		if err := u.Unmarshal(&v); err != nil {
+			errs = append(errs, newConfigError(fmt.Errorf("unable to unmarshal %q: %w", name, err), "quotas", name))
+			continue
+		}
+		actual := QuotaConfig{Name: name}
+		err := decodeResource(ctx, v, func() error {
+			dec, err := util.NewStrictDecoder(v)
+			if err != nil {
+				return newConfigError(fmt.Errorf("error creating decoder: %w", err), "quotas", name)
+			}
+			if err := dec.DecodeContext(ctx, &actual); err != nil {
+				return newDecodeError("quotas", name, v, fmt.Errorf("unable to parse quota %q: %w", name, err))
+			}
+			return nil
+		})
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if actual.InvocationsPerDay == 0 && actual.ResultBytesPerDay == 0 {
+			errs = append(errs, newConfigError(fmt.Errorf("quota %q must set invocationsPerDay or resultBytesPerDay", name), "quotas", name))
+			continue
+		}
+		if len(actual.Tools) == 0 && len(actual.Toolsets) == 0 {
+			errs = append(errs, newConfigError(fmt.Errorf("quota %q must list tools or toolsets", name), "quotas", name))
+			continue
+		}
+		(*c)[name] = actual
+	}
+	return errors.Join(errs...)
+}
+
+// applyQuotas wraps the tools of toolsMap that quotas apply to, so that
+// their invocations are counted by the Limiter of ctx, or by a Limiter in
+// memory if ctx has none.
+func applyQuotas(ctx context.Context, quotas QuotaConfigs, toolsets ToolsetConfigs, toolsMap map[string]tools.Tool) error {
+	if len(quotas) == 0 {
+		return nil
+	}
+	limiter := quota.LimiterFromContext(ctx)
+	if limiter == nil {
+		limiter = quota.NewLimiter(quota.NewMemoryStore())
+	}
+
+	byTool := make(map[string][]quota.Quota)
+	for _, name := range sortedKeys(quotas) {
+		qc := quotas[name]
+		q := quota.Quota{Name: name, InvocationsPerDay: qc.InvocationsPerDay, ResultBytesPerDay: qc.ResultBytesPerDay}
+		toolNames := slices.Clone(qc.Tools)
+		for _, toolsetName := range qc.Toolsets {
+			ts, ok := toolsets[toolsetName]
+			if !ok {
+				return fmt.Errorf("quota %q references toolset %q, which does not exist", name, toolsetName)
+			}
+			toolNames = append(toolNames, ts.ToolNames...)
+		}
+		slices.Sort(toolNames)
+		for _, toolName := range slices.Compact(toolNames) {
+			if _, ok := toolsMap[toolName]; !ok {
+				return fmt.Errorf("quota %q references tool %q, which does not exist", name, toolName)
+			}
+			byTool[toolName] = append(byTool[toolName], q)
+		}
+	}
+	for toolName, qs := range byTool {
+		toolsMap[toolName] = quotaTool{Tool: toolsMap[toolName], quotas: qs, limiter: limiter}
+	}
+	return nil
+}
+
+// quotaTool counts the invocations of a tool, and the bytes of their
+// results, against quotas. Invocations by callers who exhausted a quota fail
+// with a quota.ExceededError.
+type quotaTool struct {
+	tools.Tool
+	quotas  []quota.Quota
+	limiter *quota.Limiter
+}
+
+func (t quotaTool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	caller := tools.InvocationContextFromContext(ctx).Caller
+	if err := t.limiter.Admit(ctx, t.quotas, caller); err != nil {
+		return nil, err
+	}
+	res, err := t.Tool.Invoke(ctx, params)
+	if err != nil {
+		return res, err
+	}
+	size, err := resultSize(res)
+	if err != nil {
+		// the result can't be returned either, which the handler reports
+		return res, nil
+	}
+	if err := t.limiter.AddResultBytes(ctx, t.quotas, caller, size); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// setQuotaHeaders sets the response headers with the remaining budget of the
+// quotas applied to the request of ctx, if any.
+func setQuotaHeaders(ctx context.Context, w http.ResponseWriter) {
+	invocations, bytes, resetAt, ok := quota.StatusFromContext(ctx).Remaining()
+	if !ok {
+		return
+	}
+	if invocations >= 0 {
+		w.Header().Set("Toolbox-Quota-Remaining-Invocations", strconv.FormatInt(invocations, 10))
+	}
+	if bytes >= 0 {
+		w.Header().Set("Toolbox-Quota-Remaining-Bytes", strconv.FormatInt(bytes, 10))
+	}
+	w.Header().Set("Toolbox-Quota-Reset", resetAt.UTC().Format(time.RFC3339))
+}
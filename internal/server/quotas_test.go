@@ -0,0 +1,246 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/quota"
+	"github.com/googleapis/genai-toolbox/internal/server/mcp/jsonrpc"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+)
+
+func TestParseQuotaConfigs(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want QuotaConfigs
+		err  string
+	}{
+		{
+			desc: "basic example",
+			in: `
+			quotas:
+				daily:
+					tools:
+						- my-tool
+					toolsets:
+						- my-toolset
+					invocationsPerDay: 100
+					resultBytesPerDay: 1048576
+			`,
+			want: QuotaConfigs{
+				"daily": QuotaConfig{
+					Name:              "daily",
+					Tools:             []string{"my-tool"},
+					Toolsets:          []string{"my-toolset"},
+					InvocationsPerDay: 100,
+					ResultBytesPerDay: 1048576,
+				},
+			},
+		},
+		{
+			desc: "no limit",
+			in: `
+			quotas:
+				daily:
+					tools:
+						- my-tool
+			`,
+			err: `quota "daily" must set invocationsPerDay or resultBytesPerDay`,
+		},
+		{
+			desc: "no tools",
+			in: `
+			quotas:
+				daily:
+					invocationsPerDay: 100
+			`,
+			err: `quota "daily" must list tools or toolsets`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Quotas QuotaConfigs `yaml:"quotas"`
+			}{}
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Quotas); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestApplyQuotasUnknownResources(t *testing.T) {
+	toolsMap, _ := setUpResources(t, []MockTool{tool1, tool2})
+	toolsets := ToolsetConfigs{"my-toolset": {Name: "my-toolset", ToolNames: []string{"missing"}}}
+	tcs := []struct {
+		desc   string
+		quotas QuotaConfigs
+		err    string
+	}{
+		{
+			desc:   "unknown tool",
+			quotas: QuotaConfigs{"daily": {Name: "daily", Tools: []string{"nope"}, InvocationsPerDay: 1}},
+			err:    `quota "daily" references tool "nope", which does not exist`,
+		},
+		{
+			desc:   "unknown toolset",
+			quotas: QuotaConfigs{"daily": {Name: "daily", Toolsets: []string{"nope"}, InvocationsPerDay: 1}},
+			err:    `quota "daily" references toolset "nope", which does not exist`,
+		},
+		{
+			desc:   "unknown tool of toolset",
+			quotas: QuotaConfigs{"daily": {Name: "daily", Toolsets: []string{"my-toolset"}, InvocationsPerDay: 1}},
+			err:    `quota "daily" references tool "missing", which does not exist`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := applyQuotas(context.Background(), tc.quotas, toolsets, toolsMap)
+			if err == nil || err.Error() != tc.err {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.err)
+			}
+		})
+	}
+}
+
+// setUpQuotaResources returns resources where tool1 is limited to two
+// invocations per day
+func setUpQuotaResources(t *testing.T) *ResourceManager {
+	toolsMap, toolsets := setUpResources(t, []MockTool{tool1, tool2})
+	quotas := QuotaConfigs{"daily": {Name: "daily", Tools: []string{tool1.Name}, InvocationsPerDay: 2}}
+	if err := applyQuotas(context.Background(), quotas, nil, toolsMap); err != nil {
+		t.Fatalf("unable to apply quotas: %s", err)
+	}
+	return NewResourceManager(nil, nil, toolsMap, toolsets)
+}
+
+func TestQuotaEndpoint(t *testing.T) {
+	r, shutdown := setUpServerWithResources(t, "api", setUpQuotaResources(t))
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	for _, want := range []string{"1", "0"} {
+		resp, body, err := runRequest(ts, http.MethodPost, "/tool/"+tool1.Name+"/invoke", bytes.NewBuffer([]byte(`{}`)), nil)
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("unexpected status code: got %d, want %d: %s", resp.StatusCode, http.StatusOK, string(body))
+		}
+		if got := resp.Header.Get("Toolbox-Quota-Remaining-Invocations"); got != want {
+			t.Fatalf("unexpected remaining invocations: got %q, want %q", got, want)
+		}
+		if resp.Header.Get("Toolbox-Quota-Reset") == "" {
+			t.Fatalf("missing quota reset header")
+		}
+	}
+
+	resp, body, err := runRequest(ts, http.MethodPost, "/tool/"+tool1.Name+"/invoke", bytes.NewBuffer([]byte(`{}`)), nil)
+	if err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("unexpected status code: got %d, want %d", resp.StatusCode, http.StatusTooManyRequests)
+	}
+	if got := resp.Header.Get("Toolbox-Quota-Remaining-Invocations"); got != "0" {
+		t.Fatalf("unexpected remaining invocations: got %q, want %q", got, "0")
+	}
+	var got struct {
+		Quota quota.ExceededError `json:"quota"`
+	}
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatalf("unable to parse response: %s", err)
+	}
+	if got.Quota.Quota != "daily" || got.Quota.Limit != "invocationsPerDay" || got.Quota.Max != 2 {
+		t.Fatalf("unexpected quota error: %+v", got.Quota)
+	}
+
+	// tools without quotas are not limited and have no quota headers
+	resp, _, err = runRequest(ts, http.MethodPost, "/tool/"+tool2.Name+"/invoke", bytes.NewBuffer([]byte(`{"param1": 1, "param2": 2}`)), nil)
+	if err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status code: got %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if got := resp.Header.Get("Toolbox-Quota-Reset"); got != "" {
+		t.Fatalf("unexpected quota reset header: %q", got)
+	}
+}
+
+func TestMcpQuotaExceeded(t *testing.T) {
+	r, shutdown := setUpServerWithResources(t, "mcp", setUpQuotaResources(t))
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	reqMarshal, err := json.Marshal(map[string]any{
+		"jsonrpc": jsonrpcVersion,
+		"id":      "tools-call",
+		"method":  "tools/call",
+		"params":  map[string]any{"name": tool1.Name, "arguments": map[string]any{}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error during marshaling of body")
+	}
+	var body []byte
+	for i := 0; i < 3; i++ {
+		_, body, err = runRequest(ts, http.MethodPost, "/", bytes.NewBuffer(reqMarshal), nil)
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+	}
+	var got struct {
+		Error struct {
+			Code float64 `json:"code"`
+			Data struct {
+				Quota quota.ExceededError `json:"quota"`
+			} `json:"data"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatalf("unable to parse response: %s", err)
+	}
+	if got.Error.Code != jsonrpc.INVALID_REQUEST {
+		t.Fatalf("unexpected error code: got %v, want %v", got.Error.Code, jsonrpc.INVALID_REQUEST)
+	}
+	if got.Error.Data.Quota.Quota != "daily" || got.Error.Data.Quota.Limit != "invocationsPerDay" {
+		t.Fatalf("unexpected quota error: %+v", got.Error.Data.Quota)
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+// writeResult writes the resultResponse of the tool invocation result res,
+// its warnings and the cursor of its next page, or its dataResponse with the
+// ResultAsData envelope, along with its metadata, as render.Render would,
+// but without holding the whole response in memory: the rows of a []any
+// result are marshaled one at a time and escaped into the result string, or
+// written as is into the data, as they're written.
+//
+// It returns an error, with nothing written, if the first row can't be
+// marshaled. Rows that can't be marshaled after the response was started
+// abort it, since its status can no longer be changed.
+func writeResult(w http.ResponseWriter, envelope ResultEnvelope, res any, warnings []string, nextCursor string, metadata *tools.ResultMetadata) error {
+	rows, ok := res.([]any)
+	if !ok || rows == nil {
+		// a nil []any is marshaled as null
+		ok, rows = false, []any{res}
+	}
+	var first []byte
+	if len(rows) > 0 {
+		var err error
+		if first, err = json.Marshal(rows[0]); err != nil {
+			return err
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	bw := bufio.NewWriter(w)
+	var result io.Writer = jsonStringWriter{w: bw}
+	if envelope == ResultAsData {
+		result = bw
+		_, _ = bw.WriteString(`{"data":`)
+	} else {
+		_, _ = bw.WriteString(`{"result":"`)
+	}
+	if !ok {
+		_, _ = result.Write(first)
+	} else {
+		_, _ = result.Write([]byte("["))
+		for i, row := range rows {
+			b := first
+			if i > 0 {
+				var err error
+				if b, err = json.Marshal(row); err != nil {
+					panic(http.ErrAbortHandler)
+				}
+				_, _ = result.Write([]byte(","))
+			}
+			if _, err := result.Write(b); err != nil {
+				// the client is gone
+				return nil
+			}
+		}
+		_, _ = result.Write([]byte("]"))
+	}
+	if envelope != ResultAsData {
+		_, _ = bw.WriteString(`"`)
+	}
+	if len(warnings) > 0 {
+		b, err := json.Marshal(warnings)
+		if err != nil {
+			panic(http.ErrAbortHandler)
+		}
+		_, _ = fmt.Fprintf(bw, `,"warnings":%s`, b)
+	}
+	if nextCursor != "" {
+		b, err := json.Marshal(nextCursor)
+		if err != nil {
+			panic(http.ErrAbortHandler)
+		}
+		_, _ = fmt.Fprintf(bw, `,"nextCursor":%s`, b)
+	}
+	if metadata != nil {
+		b, err := json.Marshal(metadata)
+		if err != nil {
+			panic(http.ErrAbortHandler)
+		}
+		_, _ = fmt.Fprintf(bw, `,"metadata":%s`, b)
+	}
+	_, _ = bw.WriteString("}\n")
+	// the client is gone if the response can't be flushed
+	_ = bw.Flush()
+	return nil
+}
+
+// resultSize returns the length of the tool invocation result res marshaled
+// as JSON. The rows of a []any result are marshaled one at a time, as
+// writeResult does, so that the whole result isn't held in memory again.
+func resultSize(res any) (int64, error) {
+	rows, ok := res.([]any)
+	if !ok || rows == nil {
+		b, err := json.Marshal(res)
+		return int64(len(b)), err
+	}
+	// the brackets, and the commas between rows
+	size := int64(1 + max(len(rows), 1))
+	for _, row := range rows {
+		b, err := json.Marshal(row)
+		if err != nil {
+			return 0, err
+		}
+		size += int64(len(b))
+	}
+	return size, nil
+}
+
+// jsonStringWriter writes the bytes written to it to w escaped as the
+// contents of a JSON string, as encoding/json escapes strings.
+type jsonStringWriter struct {
+	w io.Writer
+}
+
+const hexDigits = "0123456789abcdef"
+
+func (sw jsonStringWriter) Write(p []byte) (int, error) {
+	start := 0
+	for i, c := range p {
+		var esc []byte
+		switch {
+		case c == '"' || c == '\\':
+			esc = []byte{'\\', c}
+		case c == '\n':
+			esc = []byte(`\n`)
+		case c == '\r':
+			esc = []byte(`\r`)
+		case c == '\t':
+			esc = []byte(`\t`)
+		case c < 0x20 || c == '<' || c == '>' || c == '&':
+			esc = []byte{'\\', 'u', '0', '0', hexDigits[c>>4], hexDigits[c&0xf]}
+		default:
+			continue
+		}
+		if _, err := sw.w.Write(p[start:i]); err != nil {
+			return start, err
+		}
+		if _, err := sw.w.Write(esc); err != nil {
+			return i, err
+		}
+		start = i + 1
+	}
+	if _, err := sw.w.Write(p[start:]); err != nil {
+		return start, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,235 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/render"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+func TestWriteResult(t *testing.T) {
+	tcs := []struct {
+		desc       string
+		res        any
+		warnings   []string
+		nextCursor string
+		metadata   *tools.ResultMetadata
+	}{
+		{desc: "nil", res: nil},
+		{desc: "nil rows", res: []any(nil)},
+		{desc: "no rows", res: []any{}},
+		{
+			desc: "rows",
+			res: []any{
+				map[string]any{"id": 1, "name": `"quoted" \ back\slash`},
+				map[string]any{"id": 2, "name": "<b>tom & jerry</b>\n\t", "tags": []any{"a", "b"}},
+				map[string]any{"id": 3, "name": "日本語   \x01"},
+			},
+			warnings:   []string{"parameter \"limit\" is <deprecated>"},
+			nextCursor: "MjphYmM",
+			metadata:   &tools.ResultMetadata{RowCount: 3, ExecutionMs: 12, Source: "my-pg-source", Truncated: true},
+		},
+		{desc: "string", res: `{"not": "rows"}`},
+		{desc: "map", res: map[string]any{"rows": []any{1, 2}}},
+	}
+	for _, tc := range tcs {
+		for _, envelope := range []ResultEnvelope{"", ResultAsString, ResultAsData} {
+			t.Run(tc.desc+"/"+envelope.String(), func(t *testing.T) {
+				// the streamed response is the same as the rendered one
+				// only the typed envelope returns the metadata
+				metadata := tc.metadata
+				var resp render.Renderer = dataResponse{Data: tc.res, Warnings: tc.warnings, NextCursor: tc.nextCursor, Metadata: metadata}
+				if envelope != ResultAsData {
+					metadata = nil
+					b, err := json.Marshal(tc.res)
+					if err != nil {
+						t.Fatalf("unexpected error: %s", err)
+					}
+					resp = resultResponse{Result: string(b), Warnings: tc.warnings, NextCursor: tc.nextCursor}
+				}
+				want := httptest.NewRecorder()
+				render.JSON(want, httptest.NewRequest(http.MethodPost, "/", nil), resp)
+
+				got := httptest.NewRecorder()
+				if err := writeResult(got, envelope, tc.res, tc.warnings, tc.nextCursor, metadata); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if got.Code != http.StatusOK {
+					t.Fatalf("unexpected status: got %d", got.Code)
+				}
+				if diff := cmp.Diff(want.Header().Get("Content-Type"), got.Header().Get("Content-Type")); diff != "" {
+					t.Fatalf("incorrect content type: diff %v", diff)
+				}
+				if diff := cmp.Diff(want.Body.String(), got.Body.String()); diff != "" {
+					t.Fatalf("incorrect response: diff %v", diff)
+				}
+			})
+		}
+	}
+}
+
+func TestWriteResultError(t *testing.T) {
+	// nothing is written if the first row can't be marshaled
+	w := httptest.NewRecorder()
+	if err := writeResult(w, ResultAsString, []any{math.Inf(1)}, nil, "", nil); err == nil {
+		t.Fatalf("expected an error marshaling the result")
+	}
+	if w.Body.Len() != 0 || len(w.Header()) != 0 {
+		t.Fatalf("unexpected response written: %q", w.Body.String())
+	}
+
+	// failing to write the started response isn't an error
+	if err := writeResult(failingWriter{httptest.NewRecorder()}, ResultAsString, []any{1}, nil, "", nil); err != nil {
+		t.Fatalf("unexpected error after the response was started: %s", err)
+	}
+
+	// the response is aborted if a later row can't be marshaled
+	defer func() {
+		if r := recover(); r != http.ErrAbortHandler {
+			t.Fatalf("expected the response to be aborted, got %v", r)
+		}
+	}()
+	_ = writeResult(httptest.NewRecorder(), ResultAsData, []any{1, math.Inf(1)}, nil, "", nil)
+}
+
+// failingWriter is a response writer whose client is gone.
+type failingWriter struct {
+	*httptest.ResponseRecorder
+}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, fmt.Errorf("connection reset")
+}
+
+func TestResultSize(t *testing.T) {
+	for _, res := range []any{
+		nil,
+		[]any(nil),
+		[]any{},
+		[]any{map[string]any{"id": 1, "name": "<b>tom & jerry</b>"}},
+		[]any{1, "two", []any{3}},
+		"not rows",
+		map[string]any{"rows": []any{1, 2}},
+	} {
+		want, err := json.Marshal(res)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		got, err := resultSize(res)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got != int64(len(want)) {
+			t.Fatalf("incorrect size of %s: got %d, want %d", want, got, len(want))
+		}
+	}
+	if _, err := resultSize([]any{1, math.Inf(1)}); err == nil {
+		t.Fatalf("expected an error marshaling the result")
+	}
+}
+
+func TestResultMetadata(t *testing.T) {
+	tool := switchableTool{Tool: MockTool{Name: "my-tool"}, name: "my-tool", source: "my-source", enabled: true, sourceEnabled: true}
+	resources := NewResourceManager(nil, nil, map[string]tools.Tool{"my-tool": tool}, map[string]tools.Toolset{"": {}})
+	server, shutdown := newTestServer(t, resources)
+	defer shutdown()
+	server.resultEnvelope = ResultAsData
+	api, err := apiRouter(server)
+	if err != nil {
+		t.Fatalf("unable to initialize api router: %s", err)
+	}
+	mcpR, err := mcpRouter(server)
+	if err != nil {
+		t.Fatalf("unable to initialize mcp router: %s", err)
+	}
+	want := tools.ResultMetadata{RowCount: 1, Source: "my-source"}
+	ignoreTime := cmpopts.IgnoreFields(tools.ResultMetadata{}, "ExecutionMs")
+
+	t.Run("api", func(t *testing.T) {
+		ts := runServer(api, false)
+		defer ts.Close()
+		_, body, err := runRequest(ts, http.MethodPost, "/tool/my-tool/invoke", bytes.NewBuffer([]byte(`{}`)), nil)
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		var got dataResponse
+		if err := json.Unmarshal(body, &got); err != nil {
+			t.Fatalf("unable to parse response %q: %s", body, err)
+		}
+		if got.Metadata == nil {
+			t.Fatalf("expected metadata in response: %s", body)
+		}
+		if diff := cmp.Diff(want, *got.Metadata, ignoreTime); diff != "" {
+			t.Fatalf("incorrect metadata: diff %v", diff)
+		}
+	})
+
+	for _, protocol := range []string{protocolVersion20241105, protocolVersion20250326, protocolVersion20250618} {
+		t.Run("mcp/"+protocol, func(t *testing.T) {
+			ts := runServer(mcpR, false)
+			defer ts.Close()
+			initialize := fmt.Sprintf(`{"jsonrpc":"2.0","id":"init","method":"initialize","params":{"protocolVersion":%q}}`, protocol)
+			resp, _, err := runRequest(ts, http.MethodPost, "/", bytes.NewBufferString(initialize), nil)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			header := map[string]string{"Mcp-Session-Id": resp.Header.Get("Mcp-Session-Id"), "MCP-Protocol-Version": protocol}
+			call := `{"jsonrpc":"2.0","id":"call","method":"tools/call","params":{"name":"my-tool","arguments":{}}}`
+			_, body, err := runRequest(ts, http.MethodPost, "/", bytes.NewBufferString(call), header)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			var got struct {
+				Result struct {
+					Metadata          *tools.ResultMetadata `json:"metadata"`
+					StructuredContent *struct {
+						Data     []string              `json:"data"`
+						Metadata *tools.ResultMetadata `json:"metadata"`
+					} `json:"structuredContent"`
+				} `json:"result"`
+			}
+			if err := json.Unmarshal(body, &got); err != nil {
+				t.Fatalf("unable to parse response %q: %s", body, err)
+			}
+			// the latest version returns the metadata as structured content
+			metadata := got.Result.Metadata
+			if protocol == protocolVersion20250618 {
+				if got.Result.StructuredContent == nil || metadata != nil {
+					t.Fatalf("expected structured content in response: %s", body)
+				}
+				if diff := cmp.Diff([]string{"my-tool"}, got.Result.StructuredContent.Data); diff != "" {
+					t.Fatalf("incorrect data: diff %v", diff)
+				}
+				metadata = got.Result.StructuredContent.Metadata
+			}
+			if metadata == nil {
+				t.Fatalf("expected metadata in response: %s", body)
+			}
+			if diff := cmp.Diff(want, *metadata, ignoreTime); diff != "" {
+				t.Fatalf("incorrect metadata: diff %v", diff)
+			}
+		})
+	}
+}
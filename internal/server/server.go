@@ -16,12 +16,15 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
-	"net"
+	"maps"
 	"net/http"
 	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -39,23 +42,103 @@ import (
 
 // Server contains info for running an instance of Toolbox. Should be instantiated with NewServer().
 type Server struct {
-	version         string
-	srv             *http.Server
-	listener        net.Listener
+	version string
+	// listeners are the addresses the server listens on. See newListeners.
+	listeners       []*listener
 	root            chi.Router
 	logger          log.Logger
 	instrumentation *telemetry.Instrumentation
 	sseManager      *sseManager
-	ResourceMgr     *ResourceManager
+	locale          string
+	toolsPageSize   int
+	stdioWorkers    int
+	// resultEnvelope is how invocation results are returned by the HTTP API.
+	resultEnvelope ResultEnvelope
+	// resultTokenBudget is the estimated number of tokens beyond which MCP
+	// tool results are summarized, or zero to never summarize them.
+	resultTokenBudget int
+	// invocationHeaders are the canonical names of the request headers passed
+	// to tools in their invocation context.
+	invocationHeaders []string
+	mcpClients        *mcpClientManager
+	mcpResults        *mcpResultStore
+	switches          *switches
+	ResourceMgr       *ResourceManager
+	// clock and ids are the providers of the timestamps and IDs returned to
+	// clients. See WithClock and WithIDGenerator.
+	clock util.Clock
+	ids   util.IDGenerator
+	// claimsEnrichers enrich the verified claims of invocations, in order.
+	// See WithClaimsEnricher.
+	claimsEnrichers []auth.ClaimsEnricher
 }
 
 // ResourceManager contains available resources for the server. Should be initialized with NewResourceManager().
+//
+// The resources are held in an immutable ResourceSnapshot that reloads replace
+// as a whole, so that a request that reads a snapshot once sees a consistent
+// set of resources even while a reload applies.
 type ResourceManager struct {
-	mu           sync.RWMutex
+	// mu serializes reloads; reads don't lock.
+	mu       sync.Mutex
+	snapshot atomic.Pointer[ResourceSnapshot]
+	// clock is the Clock of the server, for the times of reloads.
+	clock util.Clock
+}
+
+// ResourceSnapshot is a version of the resources of the server. It must not be
+// modified once it is in use.
+type ResourceSnapshot struct {
+	// Version is incremented by every reload, starting at 1.
+	Version  uint64
+	LoadedAt time.Time
+
 	sources      map[string]sources.Source
 	authServices map[string]auth.AuthService
 	tools        map[string]tools.Tool
 	toolsets     map[string]tools.Toolset
+	// removedTools and removedToolsets record when reloads removed tools and
+	// toolsets, until they are added back.
+	removedTools    map[string]time.Time
+	removedToolsets map[string]time.Time
+	// localized caches the toolsets by localizedToolsetKey, up to
+	// maxLocalizedToolsets of them, so that their manifests are localized once
+	// per snapshot rather than for every request listing them.
+	localized      sync.Map
+	localizedCount atomic.Int64
+}
+
+// maxLocalizedToolsets bounds the localized toolsets cached by a snapshot,
+// since the locales are chosen by clients.
+const maxLocalizedToolsets = 64
+
+// localizedToolsetKey is the key of a toolset localized for locales.
+type localizedToolsetKey struct {
+	toolset string
+	locales string
+}
+
+// configVersionHeader is the response header with the version of the
+// resources that served a request, for debugging reloads.
+const configVersionHeader = "Toolbox-Config-Version"
+
+// setConfigVersion sets the configVersionHeader of a response served with
+// resources.
+func setConfigVersion(w http.ResponseWriter, resources *ResourceSnapshot) {
+	w.Header().Set(configVersionHeader, strconv.FormatUint(resources.Version, 10))
+}
+
+// ResourceRemovedError is returned for calls to a tool or toolset that a
+// reload of the configuration removed.
+type ResourceRemovedError struct {
+	// Kind is either "tool" or "toolset".
+	Kind      string    `json:"kind"`
+	Name      string    `json:"name"`
+	RemovedAt time.Time `json:"removedAt"`
+}
+
+func (e *ResourceRemovedError) Error() string {
+	return fmt.Sprintf("%s %q was removed by a reload of the configuration at %s", e.Kind, e.Name, e.RemovedAt.Format(time.RFC3339))
 }
 
 func NewResourceManager(
@@ -63,64 +146,185 @@ func NewResourceManager(
 	authServicesMap map[string]auth.AuthService,
 	toolsMap map[string]tools.Tool, toolsetsMap map[string]tools.Toolset,
 ) *ResourceManager {
-	resourceMgr := &ResourceManager{
-		mu:           sync.RWMutex{},
-		sources:      sourcesMap,
-		authServices: authServicesMap,
-		tools:        toolsMap,
-		toolsets:     toolsetsMap,
-	}
+	resourceMgr := &ResourceManager{}
+	resourceMgr.snapshot.Store(&ResourceSnapshot{
+		Version:         1,
+		LoadedAt:        time.Now(),
+		sources:         maps.Clone(sourcesMap),
+		authServices:    maps.Clone(authServicesMap),
+		tools:           maps.Clone(toolsMap),
+		toolsets:        maps.Clone(toolsetsMap),
+		removedTools:    make(map[string]time.Time),
+		removedToolsets: make(map[string]time.Time),
+	})
 
 	return resourceMgr
 }
 
+// Snapshot returns the current version of the resources. Requests should read
+// it once and use it throughout, rather than looking up each resource in the
+// ResourceManager.
+func (r *ResourceManager) Snapshot() *ResourceSnapshot {
+	return r.snapshot.Load()
+}
+
 func (r *ResourceManager) GetSource(sourceName string) (sources.Source, bool) {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
-	source, ok := r.sources[sourceName]
-	return source, ok
+	return r.Snapshot().GetSource(sourceName)
 }
 
 func (r *ResourceManager) GetAuthService(authServiceName string) (auth.AuthService, bool) {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
-	authService, ok := r.authServices[authServiceName]
-	return authService, ok
+	return r.Snapshot().GetAuthService(authServiceName)
 }
 
 func (r *ResourceManager) GetTool(toolName string) (tools.Tool, bool) {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
-	tool, ok := r.tools[toolName]
-	return tool, ok
+	return r.Snapshot().GetTool(toolName)
 }
 
 func (r *ResourceManager) GetToolset(toolsetName string) (tools.Toolset, bool) {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
-	toolset, ok := r.toolsets[toolsetName]
-	return toolset, ok
+	return r.Snapshot().GetToolset(toolsetName)
+}
+
+// RemovedTool returns a ResourceRemovedError if a reload removed the tool
+// named toolName, or nil otherwise.
+func (r *ResourceManager) RemovedTool(toolName string) error {
+	return r.Snapshot().RemovedTool(toolName)
+}
+
+// RemovedToolset returns a ResourceRemovedError if a reload removed the
+// toolset named toolsetName, or nil otherwise.
+func (r *ResourceManager) RemovedToolset(toolsetName string) error {
+	return r.Snapshot().RemovedToolset(toolsetName)
 }
 
+// SetResources replaces the resources with those of a reloaded configuration
+// in a new snapshot. The replaced resources aren't closed, so that calls in
+// flight complete with the snapshot they started with.
 func (r *ResourceManager) SetResources(sourcesMap map[string]sources.Source, authServicesMap map[string]auth.AuthService, toolsMap map[string]tools.Tool, toolsetsMap map[string]tools.Toolset) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	r.sources = sourcesMap
-	r.authServices = authServicesMap
-	r.tools = toolsMap
-	r.toolsets = toolsetsMap
+	old := r.Snapshot()
+	now := time.Now()
+	if r.clock != nil {
+		now = r.clock()
+	}
+	r.snapshot.Store(&ResourceSnapshot{
+		Version:         old.Version + 1,
+		LoadedAt:        now,
+		sources:         maps.Clone(sourcesMap),
+		authServices:    maps.Clone(authServicesMap),
+		tools:           maps.Clone(toolsMap),
+		toolsets:        maps.Clone(toolsetsMap),
+		removedTools:    trackRemoved(old.removedTools, old.tools, toolsMap, now),
+		removedToolsets: trackRemoved(old.removedToolsets, old.toolsets, toolsetsMap, now),
+	})
+}
+
+// trackRemoved returns a copy of removed that records the names in old but
+// not in updated as removed at now, and forgets the names in updated.
+func trackRemoved[V any](removed map[string]time.Time, old, updated map[string]V, now time.Time) map[string]time.Time {
+	result := maps.Clone(removed)
+	for name := range old {
+		if _, ok := updated[name]; !ok {
+			result[name] = now
+		}
+	}
+	for name := range updated {
+		delete(result, name)
+	}
+	return result
 }
 
 func (r *ResourceManager) GetAuthServiceMap() map[string]auth.AuthService {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
-	return r.authServices
+	return r.Snapshot().GetAuthServiceMap()
 }
 
 func (r *ResourceManager) GetToolsMap() map[string]tools.Tool {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
-	return r.tools
+	return r.Snapshot().GetToolsMap()
+}
+
+func (s *ResourceSnapshot) GetSource(sourceName string) (sources.Source, bool) {
+	source, ok := s.sources[sourceName]
+	return source, ok
+}
+
+func (s *ResourceSnapshot) GetAuthService(authServiceName string) (auth.AuthService, bool) {
+	authService, ok := s.authServices[authServiceName]
+	return authService, ok
+}
+
+func (s *ResourceSnapshot) GetTool(toolName string) (tools.Tool, bool) {
+	tool, ok := s.tools[toolName]
+	return tool, ok
+}
+
+func (s *ResourceSnapshot) GetToolset(toolsetName string) (tools.Toolset, bool) {
+	toolset, ok := s.toolsets[toolsetName]
+	return toolset, ok
+}
+
+// GetLocalizedToolset returns the toolset with the descriptions of its
+// manifests in the first of locales available.
+func (s *ResourceSnapshot) GetLocalizedToolset(toolsetName string, locales []string) (tools.Toolset, bool) {
+	toolset, ok := s.GetToolset(toolsetName)
+	if !ok || len(locales) == 0 {
+		return toolset, ok
+	}
+	key := localizedToolsetKey{toolset: toolsetName, locales: strings.Join(locales, ",")}
+	if v, ok := s.localized.Load(key); ok {
+		return v.(tools.Toolset), true
+	}
+	toolset.Manifest = toolset.Manifest.Localize(locales)
+	toolset.McpManifest = tools.LocalizeMcpManifests(toolset.McpManifest, locales)
+	if s.localizedCount.Add(1) <= maxLocalizedToolsets {
+		s.localized.Store(key, toolset)
+	}
+	return toolset, true
+}
+
+// GetLocalizedToolManifest returns the manifest of tool named toolName with
+// its descriptions in the first of locales available. The manifest is read
+// from the default toolset, which holds the manifests of every tool and is
+// localized once per snapshot.
+func (s *ResourceSnapshot) GetLocalizedToolManifest(toolName string, locales []string) (tools.Manifest, bool) {
+	if toolset, ok := s.GetLocalizedToolset("", locales); ok {
+		if manifest, ok := toolset.Manifest.ToolsManifest[toolName]; ok {
+			return manifest, true
+		}
+	}
+	tool, ok := s.GetTool(toolName)
+	if !ok {
+		return tools.Manifest{}, false
+	}
+	return tool.Manifest().Localize(locales), true
+}
+
+// RemovedTool returns a ResourceRemovedError if a reload removed the tool
+// named toolName, or nil otherwise.
+func (s *ResourceSnapshot) RemovedTool(toolName string) error {
+	if at, ok := s.removedTools[toolName]; ok {
+		return &ResourceRemovedError{Kind: "tool", Name: toolName, RemovedAt: at}
+	}
+	return nil
+}
+
+// RemovedToolset returns a ResourceRemovedError if a reload removed the
+// toolset named toolsetName, or nil otherwise.
+func (s *ResourceSnapshot) RemovedToolset(toolsetName string) error {
+	if at, ok := s.removedToolsets[toolsetName]; ok {
+		return &ResourceRemovedError{Kind: "toolset", Name: toolsetName, RemovedAt: at}
+	}
+	return nil
+}
+
+// GetAuthServiceMap returns the auth services of the snapshot, which must not
+// be modified.
+func (s *ResourceSnapshot) GetAuthServiceMap() map[string]auth.AuthService {
+	return s.authServices
+}
+
+// GetToolsMap returns the tools of the snapshot, which must not be modified.
+func (s *ResourceSnapshot) GetToolsMap() map[string]tools.Tool {
+	return s.tools
 }
 
 func InitializeConfigs(ctx context.Context, cfg ServerConfig) (
@@ -143,7 +347,17 @@ func InitializeConfigs(ctx context.Context, cfg ServerConfig) (
 
 	// initialize and validate the sources from configs
 	sourcesMap := make(map[string]sources.Source)
+	disabledSources := make(map[string]bool)
+	sourceEnvironments := make(map[string]string)
 	for name, sc := range cfg.SourceConfigs {
+		if dc, ok := sc.(disabledSourceConfig); ok {
+			sc = dc.SourceConfig
+			disabledSources[name] = true
+		}
+		if ec, ok := sc.(environmentSourceConfig); ok {
+			sc = ec.SourceConfig
+			sourceEnvironments[name] = ec.environment
+		}
 		s, err := func() (sources.Source, error) {
 			childCtx, span := instrumentation.Tracer.Start(
 				ctx,
@@ -192,27 +406,38 @@ func InitializeConfigs(ctx context.Context, cfg ServerConfig) (
 	// initialize and validate the tools from configs
 	toolsMap := make(map[string]tools.Tool)
 	for name, tc := range cfg.ToolConfigs {
-		t, err := func() (tools.Tool, error) {
-			_, span := instrumentation.Tracer.Start(
-				ctx,
-				"toolbox/server/tool/init",
-				trace.WithAttributes(attribute.String("tool_kind", tc.ToolConfigKind())),
-				trace.WithAttributes(attribute.String("tool_name", name)),
-			)
-			defer span.End()
-			t, err := tc.Initialize(sourcesMap)
-			if err != nil {
-				return nil, fmt.Errorf("unable to initialize tool %q: %w", name, err)
-			}
-			return t, nil
-		}()
+		t, err := initializeTool(ctx, cfg, name, tc, sourcesMap)
 		if err != nil {
 			return nil, nil, nil, nil, err
 		}
-		toolsMap[name] = t
+		toolsMap[name] = instrumentedTool{Tool: t, name: name, instrumentation: instrumentation, slowThreshold: cfg.SlowQueryThreshold}
 	}
 	l.InfoContext(ctx, fmt.Sprintf("Initialized %d tools.", len(toolsMap)))
 
+	if err := applyCandidates(ctx, cfg, toolsMap, sourcesMap, sourceEnvironments); err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	if err := applyQuotas(ctx, cfg.QuotaConfigs, cfg.ToolsetConfigs, toolsMap); err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	if err := applyPolicies(cfg.PolicyConfigs, cfg.ToolsetConfigs, cfg.ToolConfigs, toolsMap, sourceEnvironments); err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	// tools can be disabled, by the configuration or at runtime
+	for name, tc := range cfg.ToolConfigs {
+		source := toolSourceName(tc)
+		toolsMap[name] = switchableTool{
+			Tool:          toolsMap[name],
+			name:          name,
+			source:        source,
+			enabled:       tools.Enabled(tc),
+			sourceEnabled: !disabledSources[source],
+		}
+	}
+
 	// create a default toolset that contains all tools
 	allToolNames := make([]string, 0, len(toolsMap))
 	for name := range toolsMap {
@@ -246,11 +471,61 @@ func InitializeConfigs(ctx context.Context, cfg ServerConfig) (
 	}
 	l.InfoContext(ctx, fmt.Sprintf("Initialized %d toolsets.", len(toolsetsMap)))
 
+	if err := checkUnauthenticated(ctx, l, cfg, toolsMap); err != nil {
+		return nil, nil, nil, nil, err
+	}
+
 	return sourcesMap, authServicesMap, toolsMap, toolsetsMap, nil
 }
 
+// initializeTool initializes the tool named name from its config, with the
+// server-wide defaults of cfg.
+func initializeTool(ctx context.Context, cfg ServerConfig, name string, tc tools.ToolConfig, sourcesMap map[string]sources.Source) (tools.Tool, error) {
+	instrumentation, err := util.InstrumentationFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	_, span := instrumentation.Tracer.Start(
+		ctx,
+		"toolbox/server/tool/init",
+		trace.WithAttributes(attribute.String("tool_kind", tc.ToolConfigKind())),
+		trace.WithAttributes(attribute.String("tool_name", name)),
+	)
+	defer span.End()
+	if sc, ok := tc.(tools.ShellToolConfig); ok && sc.RunsShellCommands() && !cfg.EnableShellTools {
+		return nil, fmt.Errorf("unable to initialize tool %q: tool kind %q runs commands on the host and requires the --enable-shell-tools flag", name, tc.ToolConfigKind())
+	}
+	if cfg.RejectUnknownParameters {
+		tc = tools.WithDefaultRejectUnknownParameters(tc)
+	}
+	if cfg.SQLComment {
+		tc = tools.WithDefaultSQLComment(tc)
+	}
+	t, err := tc.Initialize(sourcesMap)
+	if err != nil {
+		return nil, fmt.Errorf("unable to initialize tool %q: %w", name, err)
+	}
+	if oc, ok := tc.(tools.ConfigWithOptions); ok && oc.Options.EnrichDescription {
+		t, err = tools.EnrichDescription(ctx, oc.ToolConfig, t, sourcesMap)
+		if err != nil {
+			return nil, fmt.Errorf("unable to enrich description of tool %q: %w", name, err)
+		}
+	}
+	// results are checked against the result schema before their rows are
+	// filtered and their columns projected, and rows are filtered before
+	// their columns are projected, so that filters can use every column
+	t = tools.CheckContract(tc, tools.NormalizeResults(tc, t, cfg.NumberFormat))
+	t = tools.FilterRows(tc, t)
+	// results are paged once every row that isn't returned was removed
+	t, err = tools.PaginateRows(tc, tools.ProjectColumns(tc, t))
+	if err != nil {
+		return nil, fmt.Errorf("unable to initialize tool %q: %w", name, err)
+	}
+	return tools.MemoizeManifests(t), nil
+}
+
 // NewServer returns a Server object based on provided Config.
-func NewServer(ctx context.Context, cfg ServerConfig) (*Server, error) {
+func NewServer(ctx context.Context, cfg ServerConfig, opts ...Option) (*Server, error) {
 	instrumentation, err := util.InstrumentationFromContext(ctx)
 	if err != nil {
 		return nil, err
@@ -298,26 +573,57 @@ func NewServer(ctx context.Context, cfg ServerConfig) (*Server, error) {
 	httpLogger := httplog.NewLogger("httplog", httpOpts)
 	r.Use(httplog.RequestLogger(httpLogger))
 
+	// the admin endpoints have a stack of their own when they are served on
+	// AdminAddress, so that they are never reachable from the agent-facing
+	// addresses
+	adminRoot := chi.NewRouter()
+	adminRoot.Use(middleware.Recoverer)
+	adminRoot.Use(httplog.RequestLogger(httpLogger))
+
+	listeners, err := newListeners(cfg, r, adminRoot)
+	if err != nil {
+		return nil, err
+	}
+
 	sourcesMap, authServicesMap, toolsMap, toolsetsMap, err := InitializeConfigs(ctx, cfg)
 	if err != nil {
 		return nil, fmt.Errorf("unable to initialize configs: %w", err)
 	}
 
-	addr := net.JoinHostPort(cfg.Address, strconv.Itoa(cfg.Port))
-	srv := &http.Server{Addr: addr, Handler: r}
-
 	sseManager := newSseManager(ctx)
 
 	resourceManager := NewResourceManager(sourcesMap, authServicesMap, toolsMap, toolsetsMap)
 
+	invocationHeaders := make([]string, 0, len(cfg.InvocationHeaders))
+	for _, h := range cfg.InvocationHeaders {
+		invocationHeaders = append(invocationHeaders, http.CanonicalHeaderKey(h))
+	}
+
 	s := &Server{
 		version:         cfg.Version,
-		srv:             srv,
+		listeners:       listeners,
 		root:            r,
 		logger:          l,
 		instrumentation: instrumentation,
 		sseManager:      sseManager,
-		ResourceMgr:     resourceManager,
+		locale:          tools.NormalizeLocale(cfg.Locale),
+		toolsPageSize:   cfg.ToolsPageSize,
+		stdioWorkers:    cfg.StdioWorkers,
+		resultEnvelope:  cfg.ResultEnvelope,
+
+		resultTokenBudget: cfg.ResultTokenBudget,
+		invocationHeaders: invocationHeaders,
+		mcpClients:        newMcpClientManager(ctx),
+		mcpResults:        newMcpResultStore(),
+		switches:          newSwitches(),
+		ResourceMgr:       resourceManager,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.clock != nil {
+		resourceManager.clock = s.clock
+		resourceManager.snapshot.Load().LoadedAt = s.clock()
 	}
 	// control plane
 	apiR, err := apiRouter(s)
@@ -330,6 +636,17 @@ func NewServer(ctx context.Context, cfg ServerConfig) (*Server, error) {
 		return nil, err
 	}
 	r.Mount("/mcp", mcpR)
+	if cfg.AdminToken != "" {
+		adminR, err := adminRouter(s, cfg.AdminToken)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.AdminAddress != "" {
+			adminRoot.Mount("/admin", adminR)
+		} else {
+			r.Mount("/admin", adminR)
+		}
+	}
 	if cfg.UI {
 		webR, err := webRouter()
 		if err != nil {
@@ -345,27 +662,43 @@ func NewServer(ctx context.Context, cfg ServerConfig) (*Server, error) {
 	return s, nil
 }
 
-// Listen starts a listener for the given Server instance.
+// Listen starts the listeners for the given Server instance. If one of them
+// can't be started, the others are closed.
 func (s *Server) Listen(ctx context.Context) error {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	if s.listener != nil {
-		return fmt.Errorf("server is already listening: %s", s.listener.Addr().String())
+	for _, l := range s.listeners {
+		if l.ln != nil {
+			return fmt.Errorf("server is already listening: %s", l)
+		}
 	}
-	lc := net.ListenConfig{KeepAlive: 30 * time.Second}
-	var err error
-	if s.listener, err = lc.Listen(ctx, "tcp", s.srv.Addr); err != nil {
-		return fmt.Errorf("failed to open listener for %q: %w", s.srv.Addr, err)
+	for i, l := range s.listeners {
+		if err := l.listen(ctx); err != nil {
+			for _, opened := range s.listeners[:i] {
+				opened.ln.Close()
+				opened.ln = nil
+			}
+			return err
+		}
+		if l.admin {
+			s.logger.DebugContext(ctx, fmt.Sprintf("admin endpoints listening on %s", l))
+		} else {
+			s.logger.DebugContext(ctx, fmt.Sprintf("server listening on %s", l))
+		}
 	}
-	s.logger.DebugContext(ctx, fmt.Sprintf("server listening on %s", s.srv.Addr))
 	return nil
 }
 
-// Serve starts an HTTP server for the given Server instance.
+// Serve starts an HTTP server for each listener of the given Server instance,
+// and returns the error of the first one to stop.
 func (s *Server) Serve(ctx context.Context) error {
 	s.logger.DebugContext(ctx, "Starting a HTTP server.")
-	return s.srv.Serve(s.listener)
+	errCh := make(chan error, len(s.listeners))
+	for _, l := range s.listeners {
+		go func() { errCh <- l.srv.Serve(l.ln) }()
+	}
+	return <-errCh
 }
 
 // ServeStdio starts a new stdio session for mcp.
@@ -378,5 +711,21 @@ func (s *Server) ServeStdio(ctx context.Context, stdin io.Reader, stdout io.Writ
 // connections. It uses http.Server.Shutdown() and has the same functionality.
 func (s *Server) Shutdown(ctx context.Context) error {
 	s.logger.DebugContext(ctx, "shutting down the server.")
-	return s.srv.Shutdown(ctx)
+	var errs []error
+	for _, l := range s.listeners {
+		if err := l.srv.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// preferredLocales returns the locales of the descriptions to serve, in order
+// of preference, from an Accept-Language header and the server locale.
+func (s *Server) preferredLocales(acceptLanguage string) []string {
+	locales := tools.ParseAcceptLanguage(acceptLanguage)
+	if s.locale != "" {
+		locales = append(locales, s.locale)
+	}
+	return locales
 }
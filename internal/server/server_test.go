@@ -16,6 +16,7 @@ package server_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -46,7 +47,7 @@ func TestServe(t *testing.T) {
 		Port:    port,
 	}
 
-	otelShutdown, err := telemetry.SetupOTel(ctx, "0.0.0", "", false, "toolbox")
+	otelShutdown, err := telemetry.SetupOTel(ctx, "0.0.0", "", false, "toolbox", nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %s", err)
 	}
@@ -172,3 +173,194 @@ func TestUpdateServer(t *testing.T) {
 		t.Errorf("error updating server, toolset (-want +got):\n%s", diff)
 	}
 }
+
+// mockShellToolConfig is a tool config that runs commands on the host.
+type mockShellToolConfig struct{}
+
+func (mockShellToolConfig) ToolConfigKind() string  { return "mock-shell" }
+func (mockShellToolConfig) RunsShellCommands() bool { return true }
+func (mockShellToolConfig) Initialize(map[string]sources.Source) (tools.Tool, error) {
+	return mockShellTool{}, nil
+}
+
+type mockShellTool struct{}
+
+func (mockShellTool) Invoke(context.Context, tools.ParamValues) (any, error) { return nil, nil }
+func (mockShellTool) ParseParams(map[string]any, map[string]map[string]any) (tools.ParamValues, error) {
+	return nil, nil
+}
+func (mockShellTool) Manifest() tools.Manifest       { return tools.Manifest{} }
+func (mockShellTool) McpManifest() tools.McpManifest { return tools.McpManifest{} }
+func (mockShellTool) Authorized([]string) bool       { return true }
+
+func TestInitializeConfigsShellTools(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("error setting up logger: %s", err)
+	}
+	instrumentation, err := telemetry.CreateTelemetryInstrumentation("0.0.0")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	ctx = util.WithInstrumentation(ctx, instrumentation)
+
+	tcs := []struct {
+		desc             string
+		enableShellTools bool
+		wantErr          string
+	}{
+		{
+			desc:    "shell tools disabled",
+			wantErr: `unable to initialize tool "my-shell-tool": tool kind "mock-shell" runs commands on the host and requires the --enable-shell-tools flag`,
+		},
+		{
+			desc:             "shell tools enabled",
+			enableShellTools: true,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := server.ServerConfig{
+				Version:          "0.0.0",
+				ToolConfigs:      server.ToolConfigs{"my-shell-tool": mockShellToolConfig{}},
+				EnableShellTools: tc.enableShellTools,
+			}
+			_, _, toolsMap, _, err := server.InitializeConfigs(ctx, cfg)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if _, ok := toolsMap["my-shell-tool"]; !ok {
+				t.Fatalf("tool %q was not initialized", "my-shell-tool")
+			}
+		})
+	}
+}
+
+func TestResourceManagerRemoved(t *testing.T) {
+	toolsets := map[string]tools.Toolset{"example-toolset": {Name: "example-toolset"}}
+	r := server.NewResourceManager(nil, nil, map[string]tools.Tool{"example-tool": nil}, toolsets)
+	if err := r.RemovedTool("example-tool"); err != nil {
+		t.Fatalf("unexpected error for configured tool: %s", err)
+	}
+
+	r.SetResources(nil, nil, map[string]tools.Tool{}, map[string]tools.Toolset{})
+	var removedErr *server.ResourceRemovedError
+	if err := r.RemovedTool("example-tool"); !errors.As(err, &removedErr) || removedErr.Kind != "tool" {
+		t.Fatalf("expected tool to be removed, got %v", err)
+	}
+	if err := r.RemovedToolset("example-toolset"); !errors.As(err, &removedErr) || removedErr.Kind != "toolset" {
+		t.Fatalf("expected toolset to be removed, got %v", err)
+	}
+	if err := r.RemovedTool("unknown-tool"); err != nil {
+		t.Fatalf("unexpected error for tool that was never configured: %s", err)
+	}
+
+	// adding a tool back clears its removal
+	r.SetResources(nil, nil, map[string]tools.Tool{"example-tool": nil}, toolsets)
+	if err := r.RemovedTool("example-tool"); err != nil {
+		t.Fatalf("unexpected error for re-added tool: %s", err)
+	}
+	if err := r.RemovedToolset("example-toolset"); err != nil {
+		t.Fatalf("unexpected error for re-added toolset: %s", err)
+	}
+}
+
+func TestResourceManagerSnapshot(t *testing.T) {
+	r := server.NewResourceManager(nil, nil, map[string]tools.Tool{"old-tool": nil}, nil)
+	before := r.Snapshot()
+	if before.Version != 1 {
+		t.Fatalf("unexpected initial version: got %d, want 1", before.Version)
+	}
+
+	r.SetResources(nil, nil, map[string]tools.Tool{"new-tool": nil}, nil)
+	after := r.Snapshot()
+	if after.Version != 2 {
+		t.Fatalf("unexpected version after reload: got %d, want 2", after.Version)
+	}
+
+	// a snapshot taken before the reload keeps serving the old resources
+	if _, ok := before.GetTool("old-tool"); !ok {
+		t.Errorf("old snapshot lost its tool")
+	}
+	if _, ok := before.GetTool("new-tool"); ok {
+		t.Errorf("old snapshot has a tool of the reload")
+	}
+	if _, ok := after.GetTool("new-tool"); !ok {
+		t.Errorf("new snapshot is missing the reloaded tool")
+	}
+	if before.RemovedTool("old-tool") != nil || after.RemovedTool("old-tool") == nil {
+		t.Errorf("only the new snapshot should record the removed tool")
+	}
+}
+
+func TestResourceSnapshotLocalizedToolset(t *testing.T) {
+	toolset := tools.Toolset{
+		Name: "example-toolset",
+		Manifest: tools.ToolsetManifest{
+			ToolsManifest: map[string]tools.Manifest{
+				"example-tool": {Description: "hello", Descriptions: map[string]string{"ja": "こんにちは"}},
+			},
+		},
+		McpManifest: []tools.McpManifest{
+			{Name: "example-tool", Description: "hello", Descriptions: map[string]string{"ja": "こんにちは"}},
+		},
+	}
+	r := server.NewResourceManager(nil, nil, nil, map[string]tools.Toolset{toolset.Name: toolset})
+	snapshot := r.Snapshot()
+
+	got, ok := snapshot.GetLocalizedToolset(toolset.Name, []string{"ja-jp"})
+	if !ok {
+		t.Fatalf("toolset %q not found", toolset.Name)
+	}
+	if d := got.Manifest.ToolsManifest["example-tool"].Description; d != "こんにちは" {
+		t.Fatalf("unexpected description: got %q", d)
+	}
+	if d := got.McpManifest[0].Description; d != "こんにちは" {
+		t.Fatalf("unexpected MCP description: got %q", d)
+	}
+	// the toolset of the snapshot is left as is
+	if d := toolset.Manifest.ToolsManifest["example-tool"].Description; d != "hello" {
+		t.Fatalf("localizing modified the toolset: got %q", d)
+	}
+
+	// the localized toolset is cached by the snapshot
+	again, _ := snapshot.GetLocalizedToolset(toolset.Name, []string{"ja-jp"})
+	again.Manifest.ToolsManifest["cached"] = tools.Manifest{}
+	if _, ok := got.Manifest.ToolsManifest["cached"]; !ok {
+		t.Fatalf("expected the localized toolset to be cached")
+	}
+
+	if _, ok := snapshot.GetLocalizedToolset("unknown-toolset", []string{"ja"}); ok {
+		t.Fatalf("expected unknown toolset not to be found")
+	}
+}
+
+func TestResourceSnapshotLocalizedToolManifest(t *testing.T) {
+	toolset := tools.Toolset{
+		Manifest: tools.ToolsetManifest{
+			ToolsManifest: map[string]tools.Manifest{
+				"example-tool": {Description: "hello", Descriptions: map[string]string{"ja": "こんにちは"}},
+			},
+		},
+	}
+	r := server.NewResourceManager(nil, nil, nil, map[string]tools.Toolset{"": toolset})
+	snapshot := r.Snapshot()
+
+	got, ok := snapshot.GetLocalizedToolManifest("example-tool", []string{"ja-jp"})
+	if !ok {
+		t.Fatalf("manifest of %q not found", "example-tool")
+	}
+	if got.Description != "こんにちは" {
+		t.Fatalf("unexpected description: got %q", got.Description)
+	}
+
+	if _, ok := snapshot.GetLocalizedToolManifest("unknown-tool", []string{"ja"}); ok {
+		t.Fatalf("expected unknown tool not to be found")
+	}
+}
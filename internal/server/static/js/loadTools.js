@@ -26,7 +26,7 @@ let toolDetailsAbortController = null;
 export async function loadTools(secondNavContent, toolDisplayArea, toolsetName) {
     secondNavContent.innerHTML = '<p>Fetching tools...</p>';
     try {
-        const response = await fetch(`/api/toolset/${toolsetName}`);
+        const response = await fetch(`/api/v1/toolset/${toolsetName}`);
         if (!response.ok) {
             throw new Error(`HTTP error! status: ${response.status}`);
         }
@@ -112,7 +112,7 @@ async function fetchToolDetails(toolName, toolDisplayArea) {
     toolDisplayArea.innerHTML = '<p>Loading tool details...</p>';
 
     try {
-        const response = await fetch(`/api/tool/${encodeURIComponent(toolName)}`, { signal });
+        const response = await fetch(`/api/v1/tool/${encodeURIComponent(toolName)}`, { signal });
         if (!response.ok) {
                 throw new Error(`HTTP error! status: ${response.status}`);
         }
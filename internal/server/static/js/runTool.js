@@ -80,7 +80,7 @@ export async function handleRunTool(toolId, form, responseArea, parameters, pret
 
     console.debug('Running tool:', toolId, 'with typed params:', typedParams);
     try {
-        const response = await fetch(`/api/tool/${toolId}/invoke`, {
+        const response = await fetch(`/api/v1/tool/${toolId}/invoke`, {
             method: 'POST',
             headers: headers,
             body: JSON.stringify(typedParams)
@@ -145,7 +145,8 @@ export function displayResults(results, responseArea, prettify) {
         return;
     }
     try {
-        const resultJson = JSON.parse(results.result);
+        // servers started with --result-envelope=data return parsed results
+        const resultJson = 'data' in results ? results.data : JSON.parse(results.result);
         if (prettify) {
             responseArea.value = JSON.stringify(resultJson, null, 2);
         } else {
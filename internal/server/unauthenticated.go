@@ -0,0 +1,85 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net"
+	"slices"
+	"strings"
+
+	"github.com/googleapis/genai-toolbox/internal/log"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+// maxListedTools is the number of tools named by the warning about tools
+// without authRequired.
+const maxListedTools = 10
+
+// unauthenticatedTools returns the sorted names of the tools that can be
+// invoked without authentication.
+func unauthenticatedTools(toolsMap map[string]tools.Tool) []string {
+	var names []string
+	for _, name := range slices.Sorted(maps.Keys(toolsMap)) {
+		if toolsMap[name].Authorized(nil) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// isLoopback returns whether address is a loopback address, which only
+// clients on the same host can connect to.
+func isLoopback(address string) bool {
+	if strings.EqualFold(address, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(address)
+	return ip != nil && ip.IsLoopback()
+}
+
+// checkUnauthenticated warns about the tools of toolsMap that can be invoked
+// without authentication, unless cfg allows them. It returns an error instead
+// if cfg only allows them on loopback addresses, and one of the agent-facing
+// addresses of the server is another address. Unix sockets count as loopback
+// addresses.
+//
+// Tools served over stdio are only available to the process that started
+// Toolbox, so they aren't checked.
+func checkUnauthenticated(ctx context.Context, l log.Logger, cfg ServerConfig, toolsMap map[string]tools.Tool) error {
+	if cfg.AllowUnauthenticated || cfg.Stdio {
+		return nil
+	}
+	names := unauthenticatedTools(toolsMap)
+	if len(names) == 0 {
+		return nil
+	}
+	listed := strings.Join(names[:min(len(names), maxListedTools)], ", ")
+	if len(names) > maxListedTools {
+		listed += fmt.Sprintf(" and %d more", len(names)-maxListedTools)
+	}
+	addresses := agentAddresses(cfg)
+	if cfg.UnauthenticatedLoopbackOnly {
+		for _, address := range addresses {
+			if !isLocalAddress(address) {
+				return fmt.Errorf("tools that don't set authRequired (%s) can't be served on the non-loopback address %q without --allow-unauthenticated", listed, address)
+			}
+		}
+	}
+	l.WarnContext(ctx, fmt.Sprintf("SECURITY WARNING: tools that don't set authRequired can be invoked by anyone who can connect to %s: %s. Set authRequired on them, or start Toolbox with --allow-unauthenticated to acknowledge it.", strings.Join(addresses, ", "), listed))
+	return nil
+}
@@ -0,0 +1,134 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/googleapis/genai-toolbox/internal/log"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+// authRequiredTool is a tool requiring the my-auth auth service.
+type authRequiredTool struct {
+	MockTool
+}
+
+func (authRequiredTool) Authorized(verified []string) bool {
+	return tools.IsAuthorized([]string{"my-auth"}, verified)
+}
+
+func TestCheckUnauthenticated(t *testing.T) {
+	toolsMap := map[string]tools.Tool{
+		"open":    MockTool{Name: "open"},
+		"private": authRequiredTool{MockTool{Name: "private"}},
+	}
+	tcs := []struct {
+		desc     string
+		cfg      ServerConfig
+		tools    map[string]tools.Tool
+		wantWarn bool
+		wantErr  bool
+	}{
+		{
+			desc:     "warns",
+			cfg:      ServerConfig{Address: "127.0.0.1"},
+			tools:    toolsMap,
+			wantWarn: true,
+		},
+		{
+			desc:  "allowed",
+			cfg:   ServerConfig{Address: "0.0.0.0", AllowUnauthenticated: true, UnauthenticatedLoopbackOnly: true},
+			tools: toolsMap,
+		},
+		{
+			desc:  "stdio",
+			cfg:   ServerConfig{Stdio: true, UnauthenticatedLoopbackOnly: true},
+			tools: toolsMap,
+		},
+		{
+			desc:  "every tool requires auth",
+			cfg:   ServerConfig{Address: "0.0.0.0", UnauthenticatedLoopbackOnly: true},
+			tools: map[string]tools.Tool{"private": toolsMap["private"]},
+		},
+		{
+			desc:     "loopback only on loopback",
+			cfg:      ServerConfig{Address: "::1", UnauthenticatedLoopbackOnly: true},
+			tools:    toolsMap,
+			wantWarn: true,
+		},
+		{
+			desc:     "loopback only on loopback and unix socket",
+			cfg:      ServerConfig{Address: "127.0.0.1", ListenAddresses: []string{"unix:/run/toolbox.sock"}, UnauthenticatedLoopbackOnly: true},
+			tools:    toolsMap,
+			wantWarn: true,
+		},
+		{
+			desc:    "loopback only with a further address on every interface",
+			cfg:     ServerConfig{Address: "127.0.0.1", ListenAddresses: []string{"0.0.0.0:5001"}, UnauthenticatedLoopbackOnly: true},
+			tools:   toolsMap,
+			wantErr: true,
+		},
+		{
+			desc:    "loopback only on every interface",
+			cfg:     ServerConfig{Address: "0.0.0.0", UnauthenticatedLoopbackOnly: true},
+			tools:   toolsMap,
+			wantErr: true,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			var out bytes.Buffer
+			l, err := log.NewStdLogger(&out, &out, "info")
+			if err != nil {
+				t.Fatalf("unable to initialize logger: %s", err)
+			}
+			err = checkUnauthenticated(context.Background(), l, tc.cfg, tc.tools)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err != nil && !strings.Contains(err.Error(), "(open)") {
+				t.Fatalf("expected the error to name the tool: %s", err)
+			}
+			warned := strings.Contains(out.String(), "SECURITY WARNING: tools that don't set authRequired can be invoked")
+			if warned != tc.wantWarn {
+				t.Fatalf("unexpected warning %t: %q", warned, out.String())
+			}
+			if warned && strings.Contains(out.String(), "private") {
+				t.Fatalf("unexpected warning about a tool requiring auth: %q", out.String())
+			}
+		})
+	}
+}
+
+func TestIsLoopback(t *testing.T) {
+	for address, want := range map[string]bool{
+		"127.0.0.1": true,
+		"127.0.0.2": true,
+		"::1":       true,
+		"localhost": true,
+		"0.0.0.0":   false,
+		"":          false,
+		"10.0.0.1":  false,
+		"example":   false,
+	} {
+		if got := isLoopback(address); got != want {
+			t.Errorf("isLoopback(%q) = %t, want %t", address, got, want)
+		}
+	}
+}
@@ -0,0 +1,234 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+// maxViewDepth limits how deeply views can reference other views.
+const maxViewDepth = 10
+
+// viewRefRegex matches references to views, e.g. {{view "active_users"}} or
+// {{ view "orders_since" "$1" }}. Other template actions, such as template
+// parameters, are left as is.
+var viewRefRegex = regexp.MustCompile(`\{\{-?\s*view\s[^}]*\}\}`)
+
+// ViewConfig is a named, parameterized query defined for a source. Tools
+// using the same source reference it in their statements as
+// {{view "name" arg...}}, and the reference is replaced by the statement of
+// the view, in parentheses, when the tools file is loaded.
+type ViewConfig struct {
+	Name        string   `yaml:"name" validate:"required"`
+	Source      string   `yaml:"source" validate:"required"`
+	Description string   `yaml:"description"`
+	Statement   string   `yaml:"statement" validate:"required"`
+	Parameters  []string `yaml:"parameters"`
+}
+
+// ViewConfigs is a type used to allow unmarshal of the view configs
+type ViewConfigs map[string]ViewConfig
+
+// validate interface
+var _ yaml.InterfaceUnmarshalerContext = &ViewConfigs{}
+
+func (c *ViewConfigs) UnmarshalYAML(ctx context.Context, unmarshal func(interface{}) error) error {
+	*c = make(ViewConfigs)
+	var raw map[string]util.DelayedUnmarshaler
+	if err := unmarshal(&raw); err != nil {
+		return err
+	}
+	// Report the errors of every view at once
+	var errs []error
+	for _, name := range sortedKeys(raw) {
+		u := raw[name]
+		var v map[string]any
+		if err := u.Unmarshal(&v); err != nil {
+			errs = append(errs, newConfigError(fmt.Errorf("unable to unmarshal %q: %w", name, err), "views", name))
+			continue
+		}
+		actual := ViewConfig{Name: name}
+		err := decodeResource(ctx, v, func() error {
+			dec, err := util.NewStrictDecoder(v)
+			if err != nil {
+				return newConfigError(fmt.Errorf("error creating decoder: %w", err), "views", name)
+			}
+			if err := dec.DecodeContext(ctx, &actual); err != nil {
+				return newDecodeError("views", name, v, fmt.Errorf("unable to parse view %q: %w", name, err))
+			}
+			return nil
+		})
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		(*c)[name] = actual
+	}
+	return errors.Join(errs...)
+}
+
+type contextKey string
+
+// viewConfigsKey is the key used to store the views of a tools file within
+// context
+const viewConfigsKey contextKey = "viewConfigs"
+
+// WithViewConfigs adds the views that tools can reference into the context,
+// so that they are expanded when the tool configs are unmarshalled.
+func WithViewConfigs(ctx context.Context, views ViewConfigs) context.Context {
+	return context.WithValue(ctx, viewConfigsKey, views)
+}
+
+func viewConfigsFromContext(ctx context.Context) ViewConfigs {
+	views, _ := ctx.Value(viewConfigsKey).(ViewConfigs)
+	return views
+}
+
+// ExpandViews replaces the view references in every string of a raw tool
+// config. Views can only be referenced by tools using the same source; nested
+// configs with their own "source" field are checked against that source.
+func ExpandViews(views ViewConfigs, v any, source string) (any, error) {
+	switch v := v.(type) {
+	case string:
+		return expandViewRefs(views, v, source, nil)
+	case map[string]any:
+		if s, ok := v["source"].(string); ok {
+			source = s
+		}
+		for key, val := range v {
+			expanded, err := ExpandViews(views, val, source)
+			if err != nil {
+				return nil, err
+			}
+			v[key] = expanded
+		}
+		return v, nil
+	case []any:
+		for i, val := range v {
+			expanded, err := ExpandViews(views, val, source)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = expanded
+		}
+		return v, nil
+	}
+	return v, nil
+}
+
+// expandViewRefs replaces the view references in s. stack holds the views
+// being expanded, to detect views referencing themselves.
+func expandViewRefs(views ViewConfigs, s, source string, stack []string) (string, error) {
+	var expandErr error
+	expanded := viewRefRegex.ReplaceAllStringFunc(s, func(ref string) string {
+		if expandErr != nil {
+			return ref
+		}
+		var out strings.Builder
+		tmpl, err := template.New("view").Funcs(template.FuncMap{
+			"view": func(name string, args ...any) (string, error) {
+				return renderView(views, name, args, source, stack)
+			},
+		}).Parse(ref)
+		if err != nil {
+			expandErr = fmt.Errorf("invalid view reference %s: %w", ref, err)
+			return ref
+		}
+		if err := tmpl.Execute(&out, nil); err != nil {
+			expandErr = fmt.Errorf("unable to expand view reference %s: %w", ref, unwrapViewError(err))
+			return ref
+		}
+		return out.String()
+	})
+	if expandErr != nil {
+		return "", expandErr
+	}
+	return expanded, nil
+}
+
+// viewError is an error returned while rendering a view. Template errors
+// wrapping it are dropped, so that errors in nested views don't repeat the
+// location of every reference.
+type viewError struct {
+	err error
+}
+
+func (e *viewError) Error() string {
+	return e.err.Error()
+}
+
+func unwrapViewError(err error) error {
+	var ve *viewError
+	if errors.As(err, &ve) {
+		return ve
+	}
+	return err
+}
+
+// renderView returns the statement of a view, with its parameters replaced
+// by the given arguments and the views it references expanded.
+func renderView(views ViewConfigs, name string, args []any, source string, stack []string) (string, error) {
+	view, ok := views[name]
+	if !ok {
+		return "", &viewError{fmt.Errorf("view %q is not defined", name)}
+	}
+	if source != "" && view.Source != source {
+		return "", &viewError{fmt.Errorf("view %q is defined for source %q and can't be used with source %q", name, view.Source, source)}
+	}
+	if slices.Contains(stack, name) {
+		return "", &viewError{fmt.Errorf("view %q references itself", name)}
+	}
+	if len(stack) >= maxViewDepth {
+		return "", &viewError{fmt.Errorf("views are nested more than %d levels deep", maxViewDepth)}
+	}
+	if len(args) != len(view.Parameters) {
+		return "", &viewError{fmt.Errorf("view %q expects %d arguments, got %d", name, len(view.Parameters), len(args))}
+	}
+
+	data := make(map[string]any, len(args))
+	for i, p := range view.Parameters {
+		data[p] = args[i]
+	}
+	stack = append(stack, name)
+
+	// references to other views are expanded by the same template, with the
+	// parameters of this view available to them
+	var out strings.Builder
+	tmpl, err := template.New(name).Option("missingkey=error").Funcs(template.FuncMap{
+		"view": func(name string, args ...any) (string, error) {
+			return renderView(views, name, args, view.Source, stack)
+		},
+	}).Parse(view.Statement)
+	if err != nil {
+		return "", &viewError{fmt.Errorf("invalid statement for view %q: %w", name, err)}
+	}
+	if err := tmpl.Execute(&out, data); err != nil {
+		if ve := unwrapViewError(err); ve != err {
+			return "", ve
+		}
+		return "", &viewError{fmt.Errorf("unable to render view %q: %w", name, err)}
+	}
+	statement := strings.TrimSuffix(strings.TrimSpace(out.String()), ";")
+	return "(" + statement + ")", nil
+}
@@ -0,0 +1,119 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+)
+
+func TestExpandViews(t *testing.T) {
+	views := server.ViewConfigs{
+		"active_users": server.ViewConfig{
+			Name:      "active_users",
+			Source:    "my-pg",
+			Statement: "SELECT * FROM users WHERE deleted_at IS NULL;\n",
+		},
+		"recent_orders": server.ViewConfig{
+			Name:       "recent_orders",
+			Source:     "my-pg",
+			Statement:  "SELECT o.* FROM orders o JOIN {{view \"active_users\"}} u ON u.id = o.user_id WHERE o.created_at > now() - interval '{{.days}} days' AND o.status = {{.status}}",
+			Parameters: []string{"days", "status"},
+		},
+		"loop": server.ViewConfig{
+			Name:      "loop",
+			Source:    "my-pg",
+			Statement: "SELECT * FROM {{view \"loop\"}}",
+		},
+		"other_source": server.ViewConfig{
+			Name:      "other_source",
+			Source:    "my-bq",
+			Statement: "SELECT 1",
+		},
+	}
+
+	tcs := []struct {
+		desc string
+		in   map[string]any
+		want map[string]any
+		err  string
+	}{
+		{
+			desc: "no references",
+			in:   map[string]any{"source": "my-pg", "statement": "SELECT * FROM {{.tableName}} WHERE id = $1"},
+			want: map[string]any{"source": "my-pg", "statement": "SELECT * FROM {{.tableName}} WHERE id = $1"},
+		},
+		{
+			desc: "simple view",
+			in:   map[string]any{"source": "my-pg", "statement": "SELECT count(*) FROM {{view \"active_users\"}} AS u"},
+			want: map[string]any{"source": "my-pg", "statement": "SELECT count(*) FROM (SELECT * FROM users WHERE deleted_at IS NULL) AS u"},
+		},
+		{
+			desc: "nested view with arguments",
+			in:   map[string]any{"source": "my-pg", "statement": "SELECT * FROM {{ view \"recent_orders\" 30 \"$1\" }} r WHERE r.total > {{.minTotal}}"},
+			want: map[string]any{"source": "my-pg", "statement": "SELECT * FROM (SELECT o.* FROM orders o JOIN (SELECT * FROM users WHERE deleted_at IS NULL) u ON u.id = o.user_id WHERE o.created_at > now() - interval '30 days' AND o.status = $1) r WHERE r.total > {{.minTotal}}"},
+		},
+		{
+			desc: "nested tool config",
+			in: map[string]any{
+				"left": map[string]any{"source": "my-pg", "statement": "SELECT * FROM {{view \"active_users\"}} u"},
+				"list": []any{"{{view \"other_source\"}}"},
+			},
+			want: map[string]any{
+				"left": map[string]any{"source": "my-pg", "statement": "SELECT * FROM (SELECT * FROM users WHERE deleted_at IS NULL) u"},
+				"list": []any{"(SELECT 1)"},
+			},
+		},
+		{
+			desc: "undefined view",
+			in:   map[string]any{"source": "my-pg", "statement": "SELECT * FROM {{view \"nope\"}}"},
+			err:  `unable to expand view reference {{view "nope"}}: view "nope" is not defined`,
+		},
+		{
+			desc: "different source",
+			in:   map[string]any{"source": "my-pg", "statement": "SELECT * FROM {{view \"other_source\"}}"},
+			err:  `unable to expand view reference {{view "other_source"}}: view "other_source" is defined for source "my-bq" and can't be used with source "my-pg"`,
+		},
+		{
+			desc: "wrong number of arguments",
+			in:   map[string]any{"source": "my-pg", "statement": "SELECT * FROM {{view \"recent_orders\" 30}}"},
+			err:  `unable to expand view reference {{view "recent_orders" 30}}: view "recent_orders" expects 2 arguments, got 1`,
+		},
+		{
+			desc: "recursive view",
+			in:   map[string]any{"source": "my-pg", "statement": "SELECT * FROM {{view \"loop\"}}"},
+			err:  `unable to expand view reference {{view "loop"}}: view "loop" references itself`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := server.ExpandViews(views, tc.in, "")
+			if tc.err != "" {
+				if err == nil || err.Error() != tc.err {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect expansion: diff %v", diff)
+			}
+		})
+	}
+}
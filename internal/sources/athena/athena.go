@@ -0,0 +1,421 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package athena
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/googleapis/genai-toolbox/internal/util/normalize"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const SourceKind string = "athena"
+
+// defaultQueryTimeout is how long a query may run when queryTimeout is not
+// configured.
+const defaultQueryTimeout = 5 * time.Minute
+
+// maxPollInterval bounds the backoff between checks of a running query.
+const maxPollInterval = 2 * time.Second
+
+// validate interface
+var _ sources.SourceConfig = Config{}
+
+func init() {
+	if !sources.Register(SourceKind, newConfig) {
+		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name, Workgroup: "primary"}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name      string `yaml:"name" validate:"required"`
+	Kind      string `yaml:"kind" validate:"required"`
+	Region    string `yaml:"region" validate:"required"`
+	Workgroup string `yaml:"workgroup"`
+	// OutputLocation is the S3 location query results are written to, such
+	// as "s3://my-bucket/athena/". It can be omitted if the workgroup has a
+	// result location, and is ignored if the workgroup enforces its own.
+	OutputLocation  string `yaml:"outputLocation"`
+	Catalog         string `yaml:"catalog"`
+	Database        string `yaml:"database"`
+	QueryTimeout    string `yaml:"queryTimeout"`
+	Endpoint        string `yaml:"endpoint"`        // Optional, e.g. a VPC endpoint URL
+	AccessKeyId     string `yaml:"accessKeyId"`     // Optional, defaults to the AWS credential chain
+	SecretAccessKey string `yaml:"secretAccessKey"` // Optional, required if accessKeyId is set
+	SessionToken    string `yaml:"sessionToken"`    // Optional
+}
+
+func (r Config) SourceConfigKind() string {
+	return SourceKind
+}
+
+func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	//nolint:all // Reassigned ctx
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, r.Name)
+	defer span.End()
+
+	if r.OutputLocation != "" && !strings.HasPrefix(r.OutputLocation, "s3://") {
+		return nil, fmt.Errorf("outputLocation must be an S3 URI such as \"s3://my-bucket/path/\", got %q", r.OutputLocation)
+	}
+	queryTimeout := defaultQueryTimeout
+	if r.QueryTimeout != "" {
+		var err error
+		queryTimeout, err = time.ParseDuration(r.QueryTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse QueryTimeout string as time.Duration: %s", err)
+		}
+	}
+	endpoint := r.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://athena.%s.amazonaws.com", r.Region)
+	}
+	if _, err := url.ParseRequestURI(endpoint); err != nil {
+		return nil, fmt.Errorf("failed to parse endpoint: %w", err)
+	}
+
+	userAgent, err := util.UserAgentFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	opts := []func(*awsconfig.LoadOptions) error{
+		awsconfig.WithRegion(r.Region),
+	}
+	switch {
+	case r.AccessKeyId != "" && r.SecretAccessKey != "":
+		opts = append(opts, awsconfig.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(r.AccessKeyId, r.SecretAccessKey, r.SessionToken),
+		))
+	case r.AccessKeyId != "" || r.SecretAccessKey != "":
+		return nil, fmt.Errorf("accessKeyId and secretAccessKey must be set together")
+	}
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load aws config: %w", err)
+	}
+
+	s := &Source{
+		Name:           r.Name,
+		Kind:           SourceKind,
+		Region:         r.Region,
+		Endpoint:       strings.TrimSuffix(endpoint, "/"),
+		Workgroup:      r.Workgroup,
+		OutputLocation: r.OutputLocation,
+		Catalog:        r.Catalog,
+		Database:       r.Database,
+		QueryTimeout:   queryTimeout,
+		Credentials:    awsCfg.Credentials,
+		Client:         &http.Client{Timeout: 30 * time.Second},
+		userAgent:      userAgent,
+	}
+	if err := s.checkResultLocation(ctx); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+var _ sources.Source = &Source{}
+
+type Source struct {
+	Name           string `yaml:"name"`
+	Kind           string `yaml:"kind"`
+	Region         string
+	Endpoint       string
+	Workgroup      string
+	OutputLocation string
+	Catalog        string
+	Database       string
+	QueryTimeout   time.Duration
+	Credentials    aws.CredentialsProvider
+	Client         *http.Client
+
+	userAgent string
+}
+
+func (s *Source) SourceKind() string {
+	return SourceKind
+}
+
+// checkResultLocation returns an error if queries would have nowhere to write
+// their results, which Athena otherwise only reports once a query is started.
+func (s *Source) checkResultLocation(ctx context.Context) error {
+	var resp struct {
+		WorkGroup struct {
+			Configuration struct {
+				ResultConfiguration struct {
+					OutputLocation string
+				}
+				ManagedQueryResultsConfiguration struct {
+					Enabled bool
+				}
+			}
+		}
+	}
+	if err := s.call(ctx, "GetWorkGroup", map[string]any{"WorkGroup": s.Workgroup}, &resp); err != nil {
+		return fmt.Errorf("unable to get workgroup %q: %w", s.Workgroup, err)
+	}
+	conf := resp.WorkGroup.Configuration
+	if s.OutputLocation == "" && conf.ResultConfiguration.OutputLocation == "" && !conf.ManagedQueryResultsConfiguration.Enabled {
+		return fmt.Errorf("workgroup %q has no query result location; set outputLocation", s.Workgroup)
+	}
+	return nil
+}
+
+// AthenaQuery starts the execution of query, waits for it to complete, and
+// returns the rows of its result. Params are the values of the ? placeholders
+// of query, as SQL literals. Queries still running after the query timeout
+// are stopped.
+func (s *Source) AthenaQuery(ctx context.Context, query string, params []string) ([]any, error) {
+	start := map[string]any{
+		"QueryString": query,
+		"WorkGroup":   s.Workgroup,
+	}
+	if len(params) > 0 {
+		start["ExecutionParameters"] = params
+	}
+	if s.Catalog != "" || s.Database != "" {
+		execCtx := map[string]any{}
+		if s.Catalog != "" {
+			execCtx["Catalog"] = s.Catalog
+		}
+		if s.Database != "" {
+			execCtx["Database"] = s.Database
+		}
+		start["QueryExecutionContext"] = execCtx
+	}
+	if s.OutputLocation != "" {
+		start["ResultConfiguration"] = map[string]any{"OutputLocation": s.OutputLocation}
+	}
+	var started struct {
+		QueryExecutionId string
+	}
+	if err := s.call(ctx, "StartQueryExecution", start, &started); err != nil {
+		return nil, fmt.Errorf("unable to start query: %w", err)
+	}
+
+	statementType, err := s.wait(ctx, started.QueryExecutionId)
+	if err != nil {
+		return nil, err
+	}
+	return s.results(ctx, started.QueryExecutionId, statementType)
+}
+
+// wait polls a query execution until it completes, and returns its statement
+// type.
+func (s *Source) wait(ctx context.Context, id string) (string, error) {
+	waitCtx, cancel := context.WithTimeout(ctx, s.QueryTimeout)
+	defer cancel()
+
+	interval := 100 * time.Millisecond
+	for {
+		var resp struct {
+			QueryExecution struct {
+				StatementType string
+				Status        struct {
+					State             string
+					StateChangeReason string
+				}
+			}
+		}
+		if err := s.call(waitCtx, "GetQueryExecution", map[string]any{"QueryExecutionId": id}, &resp); err != nil {
+			s.stop(ctx, id)
+			if waitCtx.Err() != nil && ctx.Err() == nil {
+				return "", fmt.Errorf("query %s did not complete within %s", id, s.QueryTimeout)
+			}
+			return "", fmt.Errorf("unable to get query status: %w", err)
+		}
+		status := resp.QueryExecution.Status
+		switch status.State {
+		case "SUCCEEDED":
+			return resp.QueryExecution.StatementType, nil
+		case "FAILED", "CANCELLED":
+			return "", fmt.Errorf("query %s %s: %s", id, strings.ToLower(status.State), status.StateChangeReason)
+		}
+
+		select {
+		case <-waitCtx.Done():
+			s.stop(ctx, id)
+			if ctx.Err() != nil {
+				return "", ctx.Err()
+			}
+			return "", fmt.Errorf("query %s did not complete within %s", id, s.QueryTimeout)
+		case <-time.After(interval):
+		}
+		interval = min(interval*2, maxPollInterval)
+	}
+}
+
+// stop stops a query execution that is no longer waited for, so it doesn't
+// keep scanning data.
+func (s *Source) stop(ctx context.Context, id string) {
+	stopCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+	defer cancel()
+	_ = s.call(stopCtx, "StopQueryExecution", map[string]any{"QueryExecutionId": id}, nil)
+}
+
+type columnInfo struct {
+	Name string
+	Type string
+}
+
+type resultsResponse struct {
+	NextToken string
+	ResultSet struct {
+		Rows []struct {
+			Data []struct {
+				VarCharValue *string
+			}
+		}
+		ResultSetMetadata struct {
+			ColumnInfo []columnInfo
+		}
+	}
+}
+
+// results reads every page of the result of a query execution.
+func (s *Source) results(ctx context.Context, id, statementType string) ([]any, error) {
+	out := []any{}
+	req := map[string]any{"QueryExecutionId": id, "MaxResults": 1000}
+	for page := 0; ; page++ {
+		var resp resultsResponse
+		if err := s.call(ctx, "GetQueryResults", req, &resp); err != nil {
+			return nil, fmt.Errorf("unable to get query results: %w", err)
+		}
+		cols := resp.ResultSet.ResultSetMetadata.ColumnInfo
+		rows := resp.ResultSet.Rows
+		// the first row of the results of a SELECT holds the column names
+		if page == 0 && statementType == "DML" && len(rows) > 0 {
+			rows = rows[1:]
+		}
+		for _, r := range rows {
+			row := make(map[string]any, len(cols))
+			for i, col := range cols {
+				if i >= len(r.Data) || r.Data[i].VarCharValue == nil {
+					row[col.Name] = nil
+					continue
+				}
+				row[col.Name] = convertValue(col.Type, *r.Data[i].VarCharValue)
+			}
+			out = append(out, row)
+		}
+		if resp.NextToken == "" {
+			return out, nil
+		}
+		req["NextToken"] = resp.NextToken
+	}
+}
+
+// convertValue converts a value, which Athena returns as a string, to the
+// type of its column.
+func convertValue(typ string, v string) any {
+	switch typ {
+	case "boolean":
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	case "tinyint", "smallint", "integer", "bigint":
+		if i, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return i
+		}
+	case "float", "real", "double":
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f
+		}
+	case "decimal":
+		return normalize.Decimal(v)
+	}
+	return v
+}
+
+// call calls an operation of the Athena API with a request signed with
+// Signature Version 4, and decodes its response into out.
+func (s *Source) call(ctx context.Context, operation string, in any, out any) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.Endpoint+"/", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "AmazonAthena."+operation)
+	req.Header.Set("User-Agent", s.userAgent)
+
+	creds, err := s.Credentials.Retrieve(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve aws credentials: %w", err)
+	}
+	hash := sha256.Sum256(body)
+	if err := v4.NewSigner().SignHTTP(ctx, creds, req, hex.EncodeToString(hash[:]), "athena", s.Region, time.Now()); err != nil {
+		return fmt.Errorf("failed to sign request: %w", err)
+	}
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var apiErr struct {
+			Type    string `json:"__type"`
+			Message string `json:"message"`
+		}
+		if err := json.Unmarshal(respBody, &apiErr); err == nil && apiErr.Message != "" {
+			// error types may be prefixed with a namespace, as in
+			// "com.amazonaws.athena#InvalidRequestException"
+			typ := apiErr.Type[strings.LastIndex(apiErr.Type, "#")+1:]
+			return fmt.Errorf("%s: %s", typ, apiErr.Message)
+		}
+		return fmt.Errorf("unexpected status code: %d, response body: %s", resp.StatusCode, string(respBody))
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
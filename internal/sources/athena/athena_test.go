@@ -0,0 +1,316 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package athena_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources/athena"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/googleapis/genai-toolbox/internal/util/normalize"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestParseFromYamlAthena(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		want server.SourceConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			sources:
+				my-athena:
+					kind: athena
+					region: us-east-1
+			`,
+			want: server.SourceConfigs{
+				"my-athena": athena.Config{
+					Name:      "my-athena",
+					Kind:      athena.SourceKind,
+					Region:    "us-east-1",
+					Workgroup: "primary",
+				},
+			},
+		},
+		{
+			desc: "all fields",
+			in: `
+			sources:
+				my-athena:
+					kind: athena
+					region: eu-west-1
+					workgroup: analytics
+					outputLocation: s3://my-bucket/athena/
+					catalog: AwsDataCatalog
+					database: sales
+					queryTimeout: 10m
+					accessKeyId: my-key
+					secretAccessKey: my-secret
+			`,
+			want: server.SourceConfigs{
+				"my-athena": athena.Config{
+					Name:            "my-athena",
+					Kind:            athena.SourceKind,
+					Region:          "eu-west-1",
+					Workgroup:       "analytics",
+					OutputLocation:  "s3://my-bucket/athena/",
+					Catalog:         "AwsDataCatalog",
+					Database:        "sales",
+					QueryTimeout:    "10m",
+					AccessKeyId:     "my-key",
+					SecretAccessKey: "my-secret",
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if !cmp.Equal(tc.want, got.Sources) {
+				t.Fatalf("incorrect parse: want %v, got %v", tc.want, got.Sources)
+			}
+		})
+	}
+}
+
+func TestFailParseFromYaml(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		err  string
+	}{
+		{
+			desc: "missing required field",
+			in: `
+			sources:
+				my-athena:
+					kind: athena
+			`,
+			err: "unable to parse source \"my-athena\" as \"athena\": Key: 'Config.Region' Error:Field validation for 'Region' failed on the 'required' tag",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err == nil {
+				t.Fatalf("expect parsing to fail")
+			}
+			errStr := err.Error()
+			if errStr != tc.err {
+				t.Fatalf("unexpected error: got %q, want %q", errStr, tc.err)
+			}
+		})
+	}
+}
+
+// fakeAthena answers Athena API calls. Queries run for one status check
+// before they succeed, unless their text contains "fail" or "slow".
+type fakeAthena struct {
+	mu                sync.Mutex
+	workgroupLocation string
+	started           []map[string]any
+	stopped           []string
+	checks            int
+}
+
+func (f *fakeAthena) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=my-key/") {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"__type":"UnrecognizedClientException","message":"The security token included in the request is invalid."}`))
+		return
+	}
+	var req map[string]any
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var resp any
+	switch op := strings.TrimPrefix(r.Header.Get("X-Amz-Target"), "AmazonAthena."); op {
+	case "GetWorkGroup":
+		resp = map[string]any{"WorkGroup": map[string]any{"Configuration": map[string]any{
+			"ResultConfiguration": map[string]any{"OutputLocation": f.workgroupLocation},
+		}}}
+	case "StartQueryExecution":
+		f.started = append(f.started, req)
+		resp = map[string]any{"QueryExecutionId": req["QueryString"]}
+	case "GetQueryExecution":
+		f.checks++
+		id := req["QueryExecutionId"].(string)
+		state, reason := "RUNNING", ""
+		switch {
+		case strings.Contains(id, "fail"):
+			state, reason = "FAILED", "COLUMN_NOT_FOUND: line 1:8: Column 'nope' cannot be resolved"
+		case strings.Contains(id, "slow"):
+		case f.checks > 1:
+			state = "SUCCEEDED"
+		}
+		resp = map[string]any{"QueryExecution": map[string]any{
+			"StatementType": "DML",
+			"Status":        map[string]any{"State": state, "StateChangeReason": reason},
+		}}
+	case "StopQueryExecution":
+		f.stopped = append(f.stopped, req["QueryExecutionId"].(string))
+		resp = map[string]any{}
+	case "GetQueryResults":
+		cols := []map[string]any{{"Name": "id", "Type": "bigint"}, {"Name": "name", "Type": "varchar"}, {"Name": "price", "Type": "decimal"}}
+		if req["NextToken"] == nil {
+			resp = map[string]any{
+				"NextToken": "page2",
+				"ResultSet": map[string]any{
+					"ResultSetMetadata": map[string]any{"ColumnInfo": cols},
+					"Rows": []any{
+						map[string]any{"Data": []any{map[string]any{"VarCharValue": "id"}, map[string]any{"VarCharValue": "name"}, map[string]any{"VarCharValue": "price"}}},
+						map[string]any{"Data": []any{map[string]any{"VarCharValue": "1"}, map[string]any{"VarCharValue": "widget"}, map[string]any{"VarCharValue": "12345678901234567890.12"}}},
+					},
+				},
+			}
+		} else {
+			resp = map[string]any{
+				"ResultSet": map[string]any{
+					"ResultSetMetadata": map[string]any{"ColumnInfo": cols},
+					"Rows": []any{
+						map[string]any{"Data": []any{map[string]any{"VarCharValue": "2"}, map[string]any{}, map[string]any{"VarCharValue": "0.50"}}},
+					},
+				},
+			}
+		}
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"__type":"com.amazonaws.athena#InvalidRequestException","message":"unknown operation ` + op + `"}`))
+		return
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func newTestSource(t *testing.T, f *fakeAthena, cfg athena.Config) (*athena.Source, error) {
+	t.Helper()
+	ts := httptest.NewServer(f)
+	t.Cleanup(ts.Close)
+	cfg.Name = "my-athena"
+	cfg.Kind = athena.SourceKind
+	cfg.Region = "us-east-1"
+	cfg.Endpoint = ts.URL
+	cfg.AccessKeyId = "my-key"
+	cfg.SecretAccessKey = "my-secret"
+	if cfg.Workgroup == "" {
+		cfg.Workgroup = "primary"
+	}
+	ctx := util.WithUserAgent(context.Background(), "test")
+	s, err := cfg.Initialize(ctx, noop.NewTracerProvider().Tracer(""))
+	if err != nil {
+		return nil, err
+	}
+	return s.(*athena.Source), nil
+}
+
+func TestAthenaQuery(t *testing.T) {
+	f := &fakeAthena{}
+	s, err := newTestSource(t, f, athena.Config{OutputLocation: "s3://my-bucket/athena/", Database: "sales"})
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+
+	got, err := s.AthenaQuery(context.Background(), "SELECT * FROM products WHERE region = ?", []string{"'us'"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := []any{
+		map[string]any{"id": int64(1), "name": "widget", "price": normalize.Decimal("12345678901234567890.12")},
+		map[string]any{"id": int64(2), "name": nil, "price": normalize.Decimal("0.50")},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect result: diff %v", diff)
+	}
+
+	wantStart := []map[string]any{{
+		"QueryString":           "SELECT * FROM products WHERE region = ?",
+		"WorkGroup":             "primary",
+		"ExecutionParameters":   []any{"'us'"},
+		"QueryExecutionContext": map[string]any{"Database": "sales"},
+		"ResultConfiguration":   map[string]any{"OutputLocation": "s3://my-bucket/athena/"},
+	}}
+	if diff := cmp.Diff(wantStart, f.started); diff != "" {
+		t.Fatalf("incorrect start request: diff %v", diff)
+	}
+}
+
+func TestAthenaQueryFailed(t *testing.T) {
+	s, err := newTestSource(t, &fakeAthena{workgroupLocation: "s3://wg-bucket/"}, athena.Config{})
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	_, err = s.AthenaQuery(context.Background(), "SELECT nope FROM fail", nil)
+	want := "query SELECT nope FROM fail failed: COLUMN_NOT_FOUND: line 1:8: Column 'nope' cannot be resolved"
+	if err == nil || err.Error() != want {
+		t.Fatalf("unexpected error: got %v, want %q", err, want)
+	}
+}
+
+func TestAthenaQueryTimeout(t *testing.T) {
+	f := &fakeAthena{workgroupLocation: "s3://wg-bucket/"}
+	s, err := newTestSource(t, f, athena.Config{QueryTimeout: "300ms"})
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	_, err = s.AthenaQuery(context.Background(), "slow", nil)
+	if err == nil || err.Error() != "query slow did not complete within 300ms" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"slow"}, f.stopped); diff != "" {
+		t.Fatalf("query was not stopped: diff %v", diff)
+	}
+}
+
+func TestInitializeWithoutResultLocation(t *testing.T) {
+	_, err := newTestSource(t, &fakeAthena{}, athena.Config{})
+	want := "workgroup \"primary\" has no query result location; set outputLocation"
+	if err == nil || err.Error() != want {
+		t.Fatalf("unexpected error: got %v, want %q", err, want)
+	}
+}
+
+func TestInitializeInvalidOutputLocation(t *testing.T) {
+	_, err := newTestSource(t, &fakeAthena{}, athena.Config{OutputLocation: "my-bucket/athena"})
+	want := "outputLocation must be an S3 URI such as \"s3://my-bucket/path/\", got \"my-bucket/athena\""
+	if err == nil || err.Error() != want {
+		t.Fatalf("unexpected error: got %v, want %q", err, want)
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const (
+	AuthTypePassword = "password"
+	AuthTypeAWSIAM   = "aws-iam"
+)
+
+// AuthType is the method used to authenticate database connections.
+type AuthType string
+
+func (a *AuthType) String() string {
+	if string(*a) != "" {
+		return strings.ToLower(string(*a))
+	}
+	return AuthTypePassword
+}
+
+func (a *AuthType) UnmarshalYAML(ctx context.Context, unmarshal func(interface{}) error) error {
+	var authType string
+	if err := unmarshal(&authType); err != nil {
+		return err
+	}
+	switch strings.ToLower(authType) {
+	case AuthTypePassword, AuthTypeAWSIAM:
+		*a = AuthType(strings.ToLower(authType))
+		return nil
+	default:
+		return fmt.Errorf(`authType invalid: must be one of "password", or "aws-iam"`)
+	}
+}
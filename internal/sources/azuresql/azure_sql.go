@@ -0,0 +1,168 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azuresql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/microsoft/go-mssqldb/azuread"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const SourceKind string = "azure-sql"
+
+// validate interface
+var _ sources.SourceConfig = Config{}
+
+func init() {
+	if !sources.Register(SourceKind, newConfig) {
+		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name, Port: "1433"}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	// Azure SQL configs
+	Name            string `yaml:"name" validate:"required"`
+	Kind            string `yaml:"kind" validate:"required"`
+	Server          string `yaml:"server" validate:"required"`
+	Port            string `yaml:"port"`
+	Database        string `yaml:"database" validate:"required"`
+	User            string `yaml:"user"`
+	Password        string `yaml:"password"`
+	FailoverPartner string `yaml:"failoverPartner"`
+}
+
+func (r Config) SourceConfigKind() string {
+	// Returns Azure SQL source kind
+	return SourceKind
+}
+
+func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	// Initializes an Azure SQL source
+	db, err := initAzureSQLConnection(ctx, tracer, r.Name, r.Server, r.Port, r.User, r.Password, r.Database, r.FailoverPartner)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create db connection: %w", err)
+	}
+
+	// Verify db connection
+	err = db.PingContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect successfully: %w", err)
+	}
+
+	s := &Source{
+		Name: r.Name,
+		Kind: SourceKind,
+		Db:   db,
+	}
+	return s, nil
+}
+
+var _ sources.Source = &Source{}
+var _ sources.TableDescriber = &Source{}
+var _ sources.SchemaLister = &Source{}
+
+type Source struct {
+	// Azure SQL struct with connection pool
+	Name string `yaml:"name"`
+	Kind string `yaml:"kind"`
+	Db   *sql.DB
+}
+
+func (s *Source) SourceKind() string {
+	// Returns Azure SQL source kind
+	return SourceKind
+}
+
+func (s *Source) MSSQLDB() *sql.DB {
+	// Returns an Azure SQL database connection pool
+	return s.Db
+}
+
+func (s *Source) DescribeTable(ctx context.Context, table string) ([]sources.Column, error) {
+	return sources.DescribeMSSQLTable(ctx, s.Db, table)
+}
+
+func (s *Source) ListSchema(ctx context.Context) ([]sources.Table, error) {
+	return sources.ListMSSQLSchema(ctx, s.Db)
+}
+
+// getConnectionURL builds the connection string for the server. If user and
+// password are both provided, SQL authentication is used. Otherwise, Azure AD
+// tokens are fetched from DefaultAzureCredential.
+func getConnectionURL(server, port, user, pass, dbname, failoverPartner string) (*url.URL, error) {
+	query := url.Values{}
+	query.Add("database", dbname)
+	// Azure SQL Database always requires encrypted connections
+	query.Add("encrypt", "true")
+	if failoverPartner != "" {
+		query.Add("failoverpartner", failoverPartner)
+	}
+
+	u := &url.URL{
+		Scheme: "sqlserver",
+		Host:   fmt.Sprintf("%s:%s", server, port),
+	}
+
+	switch {
+	case user != "" && pass != "":
+		u.User = url.UserPassword(user, pass)
+	case user != "" || pass != "":
+		return nil, fmt.Errorf("user and password must be provided together. Please provide both a username and password, or leave both fields empty")
+	default:
+		query.Add("fedauth", azuread.ActiveDirectoryDefault)
+	}
+	u.RawQuery = query.Encode()
+	return u, nil
+}
+
+func initAzureSQLConnection(
+	ctx context.Context,
+	tracer trace.Tracer,
+	name, server, port, user, pass, dbname, failoverPartner string,
+) (
+	*sql.DB,
+	error,
+) {
+	//nolint:all // Reassigned ctx
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, name)
+	defer span.End()
+
+	u, err := getConnectionURL(server, port, user, pass, dbname, failoverPartner)
+	if err != nil {
+		return nil, err
+	}
+
+	// Open database connection. The azuread driver handles both SQL and
+	// Azure AD authentication.
+	db, err := sql.Open(azuread.DriverName, u.String())
+	if err != nil {
+		return nil, fmt.Errorf("sql.Open: %w", err)
+	}
+	return db, nil
+}
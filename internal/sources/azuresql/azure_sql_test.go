@@ -0,0 +1,141 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azuresql_test
+
+import (
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources/azuresql"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+)
+
+func TestParseFromYamlAzureSQL(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		want server.SourceConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			sources:
+				my-azure-sql-instance:
+					kind: azure-sql
+					server: my-server.database.windows.net
+					database: my_db
+			`,
+			want: server.SourceConfigs{
+				"my-azure-sql-instance": azuresql.Config{
+					Name:     "my-azure-sql-instance",
+					Kind:     azuresql.SourceKind,
+					Server:   "my-server.database.windows.net",
+					Port:     "1433",
+					Database: "my_db",
+				},
+			},
+		},
+		{
+			desc: "sql authentication with failover partner",
+			in: `
+			sources:
+				my-azure-sql-instance:
+					kind: azure-sql
+					server: my-fog.database.windows.net
+					port: "1434"
+					database: my_db
+					user: my_user
+					password: my_pass
+					failoverPartner: my-fog.secondary.database.windows.net
+			`,
+			want: server.SourceConfigs{
+				"my-azure-sql-instance": azuresql.Config{
+					Name:            "my-azure-sql-instance",
+					Kind:            azuresql.SourceKind,
+					Server:          "my-fog.database.windows.net",
+					Port:            "1434",
+					Database:        "my_db",
+					User:            "my_user",
+					Password:        "my_pass",
+					FailoverPartner: "my-fog.secondary.database.windows.net",
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if !cmp.Equal(tc.want, got.Sources) {
+				t.Fatalf("incorrect parse: want %v, got %v", tc.want, got.Sources)
+			}
+		})
+	}
+}
+
+func TestFailParseFromYaml(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		err  string
+	}{
+		{
+			desc: "extra field",
+			in: `
+			sources:
+				my-azure-sql-instance:
+					kind: azure-sql
+					server: my-server.database.windows.net
+					database: my_db
+					foo: bar
+			`,
+			err: "unable to parse source \"my-azure-sql-instance\" as \"azure-sql\": [2:1] unknown field \"foo\"\n   1 | database: my_db\n>  2 | foo: bar\n       ^\n   3 | kind: azure-sql\n   4 | server: my-server.database.windows.net",
+		},
+		{
+			desc: "missing required field",
+			in: `
+			sources:
+				my-azure-sql-instance:
+					kind: azure-sql
+					database: my_db
+			`,
+			err: "unable to parse source \"my-azure-sql-instance\" as \"azure-sql\": Key: 'Config.Server' Error:Field validation for 'Server' failed on the 'required' tag",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err == nil {
+				t.Fatalf("expect parsing to fail")
+			}
+			errStr := err.Error()
+			if errStr != tc.err {
+				t.Fatalf("unexpected error: got %q, want %q", errStr, tc.err)
+			}
+		})
+	}
+}
@@ -21,6 +21,7 @@ import (
 	bigqueryapi "cloud.google.com/go/bigquery"
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/telemetry"
 	"github.com/googleapis/genai-toolbox/internal/util"
 	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/oauth2/google"
@@ -53,6 +54,12 @@ type Config struct {
 	Kind     string `yaml:"kind" validate:"required"`
 	Project  string `yaml:"project" validate:"required"`
 	Location string `yaml:"location"`
+	// DailyBytesBilledBudget limits the bytes billed by the queries of the
+	// tools of the source per day. There is no limit if it is zero.
+	DailyBytesBilledBudget int64 `yaml:"dailyBytesBilledBudget" validate:"gte=0"`
+	// ReadOnly rejects the statements of execute-sql tools writing to the
+	// datasets.
+	ReadOnly bool `yaml:"readOnly"`
 }
 
 func (r Config) SourceConfigKind() string {
@@ -73,12 +80,15 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 		Client:      client,
 		RestService: restService,
 		Location:    r.Location,
+		Budget:      NewBudget(r.DailyBytesBilledBudget),
+		readOnly:    r.ReadOnly,
 	}
 	return s, nil
 
 }
 
 var _ sources.Source = &Source{}
+var _ sources.ReadOnlySource = &Source{}
 
 type Source struct {
 	// BigQuery Google SQL struct with client
@@ -87,6 +97,9 @@ type Source struct {
 	Client      *bigqueryapi.Client
 	RestService *bigqueryrestapi.Service
 	Location    string `yaml:"location"`
+	Budget      *Budget
+
+	readOnly bool
 }
 
 func (s *Source) SourceKind() string {
@@ -94,6 +107,11 @@ func (s *Source) SourceKind() string {
 	return SourceKind
 }
 
+// ReadOnly reports whether the source is configured with readOnly.
+func (s *Source) ReadOnly() bool {
+	return s.readOnly
+}
+
 func (s *Source) BigQueryClient() *bigqueryapi.Client {
 	return s.Client
 }
@@ -102,6 +120,10 @@ func (s *Source) BigQueryRestService() *bigqueryrestapi.Service {
 	return s.RestService
 }
 
+func (s *Source) BytesBilledBudget() *Budget {
+	return s.Budget
+}
+
 func initBigQueryConnection(
 	ctx context.Context,
 	tracer trace.Tracer,
@@ -137,3 +159,22 @@ func initBigQueryConnection(
 
 	return client, restService, nil
 }
+
+// ReportBytesBilled reports the bytes billed by the query job backing it to
+// the statistics of the tool invocation, if any, and to budget. Failures to get
+// the job statistics are ignored since they don't affect the query results.
+func ReportBytesBilled(ctx context.Context, it *bigqueryapi.RowIterator, budget *Budget) {
+	stats := telemetry.InvocationStatsFromContext(ctx)
+	job := it.SourceJob()
+	if (stats == nil && budget == nil) || job == nil {
+		return
+	}
+	status, err := job.Status(ctx)
+	if err != nil || status.Statistics == nil {
+		return
+	}
+	if qs, ok := status.Statistics.Details.(*bigqueryapi.QueryStatistics); ok {
+		stats.AddBytesBilled(qs.TotalBytesBilled)
+		budget.Add(qs.TotalBytesBilled)
+	}
+}
@@ -48,6 +48,24 @@ func TestParseFromYamlBigQuery(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "with daily budget",
+			in: `
+			sources:
+				my-instance:
+					kind: bigquery
+					project: my-project
+					dailyBytesBilledBudget: 1000000
+			`,
+			want: server.SourceConfigs{
+				"my-instance": bigquery.Config{
+					Name:                   "my-instance",
+					Kind:                   bigquery.SourceKind,
+					Project:                "my-project",
+					DailyBytesBilledBudget: 1000000,
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
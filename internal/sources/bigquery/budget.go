@@ -0,0 +1,92 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigquery
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Budget limits the bytes billed by the queries of a source per day, in UTC.
+// Usage is tracked in memory, so it starts over when Toolbox restarts. A nil
+// Budget is unlimited.
+type Budget struct {
+	mu    sync.Mutex
+	limit int64
+	day   string
+	used  int64
+}
+
+// NewBudget returns a Budget of limit bytes per day, or nil if limit isn't
+// positive.
+func NewBudget(limit int64) *Budget {
+	if limit <= 0 {
+		return nil
+	}
+	return &Budget{limit: limit}
+}
+
+// rollover starts a new day of usage if the day changed. b.mu must be held.
+func (b *Budget) rollover() {
+	if day := time.Now().UTC().Format(time.DateOnly); day != b.day {
+		b.day = day
+		b.used = 0
+	}
+}
+
+// Remaining returns the number of bytes left in the budget of the day.
+func (b *Budget) Remaining() int64 {
+	if b == nil {
+		return -1
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.rollover()
+	return max(b.limit-b.used, 0)
+}
+
+// Check returns an error if a query billing an estimated number of bytes
+// would exceed the budget of the day.
+func (b *Budget) Check(estimated int64) error {
+	if b == nil {
+		return nil
+	}
+	if remaining := b.Remaining(); estimated > remaining {
+		return fmt.Errorf("query would bill an estimated %d bytes, which exceeds the remaining daily budget of %d bytes out of %d bytes; the budget resets at midnight UTC", estimated, remaining, b.limit)
+	}
+	return nil
+}
+
+// Add records the bytes billed by a query.
+func (b *Budget) Add(n int64) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.rollover()
+	b.used += n
+}
+
+// CheckQueryCost returns an error if a query processing an estimated number
+// of bytes, as reported by a dry run, would exceed maximumBytesBilled, unless
+// it is zero, or the budget of the day.
+func CheckQueryCost(estimated, maximumBytesBilled int64, budget *Budget) error {
+	if maximumBytesBilled > 0 && estimated > maximumBytesBilled {
+		return fmt.Errorf("query would process an estimated %d bytes, which exceeds the maximumBytesBilled of %d bytes", estimated, maximumBytesBilled)
+	}
+	return budget.Check(estimated)
+}
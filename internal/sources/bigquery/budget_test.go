@@ -0,0 +1,77 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigquery_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/googleapis/genai-toolbox/internal/sources/bigquery"
+)
+
+func TestBudget(t *testing.T) {
+	b := bigquery.NewBudget(100)
+	if err := b.Check(60); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	b.Add(60)
+	if got := b.Remaining(); got != 40 {
+		t.Fatalf("incorrect remaining budget: got %d, want 40", got)
+	}
+	err := b.Check(50)
+	if err == nil {
+		t.Fatalf("expected query exceeding the budget to be rejected")
+	}
+	if !strings.Contains(err.Error(), "remaining daily budget of 40 bytes") {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestNilBudget(t *testing.T) {
+	b := bigquery.NewBudget(0)
+	if b != nil {
+		t.Fatalf("expected no budget for a zero limit")
+	}
+	b.Add(1 << 40)
+	if err := b.Check(1 << 40); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := b.Remaining(); got != -1 {
+		t.Fatalf("incorrect remaining budget: got %d, want -1", got)
+	}
+}
+
+func TestCheckQueryCost(t *testing.T) {
+	tcs := []struct {
+		desc      string
+		estimated int64
+		maximum   int64
+		budget    *bigquery.Budget
+		wantErr   bool
+	}{
+		{desc: "no limits", estimated: 1 << 40},
+		{desc: "within maximum", estimated: 10, maximum: 10},
+		{desc: "exceeds maximum", estimated: 11, maximum: 10, wantErr: true},
+		{desc: "exceeds budget", estimated: 11, budget: bigquery.NewBudget(10), wantErr: true},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := bigquery.CheckQueryCost(tc.estimated, tc.maximum, tc.budget)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("unexpected error: got %v, want error %t", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cassandra
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/gocql/gocql"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const SourceKind string = "cassandra"
+
+// validate interface
+var _ sources.SourceConfig = Config{}
+
+func init() {
+	if !sources.Register(SourceKind, newConfig) {
+		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name         string   `yaml:"name" validate:"required"`
+	Kind         string   `yaml:"kind" validate:"required"`
+	Hosts        []string `yaml:"hosts" validate:"required,min=1"`
+	Port         string   `yaml:"port"`
+	Keyspace     string   `yaml:"keyspace"`
+	User         string   `yaml:"user"`
+	Password     string   `yaml:"password"`
+	QueryTimeout string   `yaml:"queryTimeout"`
+	// Consistency is the consistency level of the statements, e.g.
+	// LOCAL_QUORUM.
+	Consistency string `yaml:"consistency"`
+	// LocalDC is the datacenter statements are routed to, with the nodes of
+	// the other datacenters only used if none of its nodes are up.
+	LocalDC string `yaml:"localDC"`
+	// MaxPreparedStatements is the number of prepared statements cached per
+	// session.
+	MaxPreparedStatements int    `yaml:"maxPreparedStatements" validate:"gte=0"`
+	SSLMode               string `yaml:"sslMode" validate:"required_with=SSLRootCert SSLCert SSLKey,omitempty,oneof=disable require verify-ca verify-full"`
+	SSLRootCert           string `yaml:"sslRootCert"` // Optional, file path or inline PEM
+	SSLCert               string `yaml:"sslCert" validate:"required_with=SSLKey"`
+	SSLKey                string `yaml:"sslKey" validate:"required_with=SSLCert"`
+}
+
+func (r Config) SourceConfigKind() string {
+	return SourceKind
+}
+
+func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	cluster, err := r.clusterConfig()
+	if err != nil {
+		return nil, err
+	}
+	session, err := initCassandraSession(ctx, tracer, r.Name, cluster)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create session: %w", err)
+	}
+
+	s := &Source{
+		Name:    r.Name,
+		Kind:    SourceKind,
+		Session: session,
+	}
+	return s, nil
+}
+
+var _ sources.Source = &Source{}
+
+type Source struct {
+	Name    string `yaml:"name"`
+	Kind    string `yaml:"kind"`
+	Session *gocql.Session
+}
+
+func (s *Source) SourceKind() string {
+	return SourceKind
+}
+
+func (s *Source) CassandraSession() *gocql.Session {
+	return s.Session
+}
+
+// clusterConfig returns the configuration of the sessions of the source.
+// Statements are routed to a replica of the data they read or write, which
+// requires them to be prepared, and the prepared statements are cached.
+func (r Config) clusterConfig() (*gocql.ClusterConfig, error) {
+	cluster := gocql.NewCluster(r.Hosts...)
+	cluster.Keyspace = r.Keyspace
+	if r.Port != "" {
+		port, err := strconv.Atoi(r.Port)
+		if err != nil {
+			return nil, fmt.Errorf("invalid port %q: %w", r.Port, err)
+		}
+		cluster.Port = port
+	}
+	if r.User != "" {
+		cluster.Authenticator = gocql.PasswordAuthenticator{Username: r.User, Password: r.Password}
+	}
+	if r.QueryTimeout != "" {
+		timeout, err := time.ParseDuration(r.QueryTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid queryTimeout %q: %w", r.QueryTimeout, err)
+		}
+		cluster.Timeout = timeout
+	}
+	if r.Consistency != "" {
+		consistency, err := gocql.ParseConsistencyWrapper(r.Consistency)
+		if err != nil {
+			return nil, fmt.Errorf("invalid consistency %q: %w", r.Consistency, err)
+		}
+		cluster.Consistency = consistency
+	}
+	if r.MaxPreparedStatements > 0 {
+		cluster.MaxPreparedStmts = r.MaxPreparedStatements
+	}
+
+	fallback := gocql.RoundRobinHostPolicy()
+	if r.LocalDC != "" {
+		fallback = gocql.DCAwareRoundRobinPolicy(r.LocalDC)
+	}
+	cluster.PoolConfig.HostSelectionPolicy = gocql.TokenAwareHostPolicy(fallback)
+
+	if r.SSLMode != "" {
+		tlsConfig, err := sources.NewTLSConfig(r.SSLMode, r.SSLRootCert, r.SSLCert, r.SSLKey)
+		if err != nil {
+			return nil, err
+		}
+		if tlsConfig != nil {
+			cluster.SslOpts = &gocql.SslOptions{Config: tlsConfig}
+		}
+	}
+	return cluster, nil
+}
+
+func initCassandraSession(ctx context.Context, tracer trace.Tracer, name string, cluster *gocql.ClusterConfig) (*gocql.Session, error) {
+	//nolint:all // Reassigned ctx
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, name)
+	defer span.End()
+
+	// creating the session connects to the cluster
+	return cluster.CreateSession()
+}
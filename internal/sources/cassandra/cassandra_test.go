@@ -0,0 +1,186 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cassandra_test
+
+import (
+	"strings"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources/cassandra"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestParseFromYamlCassandra(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		want server.SourceConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			sources:
+				my-cassandra:
+					kind: cassandra
+					hosts:
+						- 127.0.0.1
+					keyspace: my_keyspace
+			`,
+			want: server.SourceConfigs{
+				"my-cassandra": cassandra.Config{
+					Name:     "my-cassandra",
+					Kind:     cassandra.SourceKind,
+					Hosts:    []string{"127.0.0.1"},
+					Keyspace: "my_keyspace",
+				},
+			},
+		},
+		{
+			desc: "with routing, prepared statements and tls",
+			in: `
+			sources:
+				my-cassandra:
+					kind: cassandra
+					hosts:
+						- 10.0.0.1
+						- 10.0.0.2
+					port: "9142"
+					keyspace: my_keyspace
+					user: my_user
+					password: my_pass
+					queryTimeout: 5s
+					consistency: LOCAL_QUORUM
+					localDC: europe-west1
+					maxPreparedStatements: 5000
+					sslMode: verify-full
+					sslRootCert: /etc/ssl/ca.pem
+			`,
+			want: server.SourceConfigs{
+				"my-cassandra": cassandra.Config{
+					Name:                  "my-cassandra",
+					Kind:                  cassandra.SourceKind,
+					Hosts:                 []string{"10.0.0.1", "10.0.0.2"},
+					Port:                  "9142",
+					Keyspace:              "my_keyspace",
+					User:                  "my_user",
+					Password:              "my_pass",
+					QueryTimeout:          "5s",
+					Consistency:           "LOCAL_QUORUM",
+					LocalDC:               "europe-west1",
+					MaxPreparedStatements: 5000,
+					SSLMode:               "verify-full",
+					SSLRootCert:           "/etc/ssl/ca.pem",
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Sources); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestFailParseFromYaml(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		err  string
+	}{
+		{
+			desc: "missing hosts",
+			in: `
+			sources:
+				my-cassandra:
+					kind: cassandra
+					keyspace: my_keyspace
+			`,
+			err: "Field validation for 'Hosts' failed on the 'required' tag",
+		},
+		{
+			desc: "invalid sslMode",
+			in: `
+			sources:
+				my-cassandra:
+					kind: cassandra
+					hosts:
+						- 127.0.0.1
+					sslMode: fail
+			`,
+			err: "Field validation for 'SSLMode' failed on the 'oneof' tag",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err == nil {
+				t.Fatalf("expect parsing to fail")
+			}
+			if !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("unexpected error: got %q, want it to contain %q", err.Error(), tc.err)
+			}
+		})
+	}
+}
+
+func TestInitializeInvalidConfig(t *testing.T) {
+	tcs := []struct {
+		desc string
+		cfg  cassandra.Config
+		err  string
+	}{
+		{
+			desc: "invalid consistency",
+			cfg:  cassandra.Config{Name: "my-cassandra", Kind: cassandra.SourceKind, Hosts: []string{"127.0.0.1"}, Consistency: "MOST"},
+			err:  `invalid consistency "MOST"`,
+		},
+		{
+			desc: "invalid port",
+			cfg:  cassandra.Config{Name: "my-cassandra", Kind: cassandra.SourceKind, Hosts: []string{"127.0.0.1"}, Port: "cql"},
+			err:  `invalid port "cql"`,
+		},
+		{
+			desc: "invalid query timeout",
+			cfg:  cassandra.Config{Name: "my-cassandra", Kind: cassandra.SourceKind, Hosts: []string{"127.0.0.1"}, QueryTimeout: "soon"},
+			err:  `invalid queryTimeout "soon"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := tc.cfg.Initialize(t.Context(), noop.NewTracerProvider().Tracer(""))
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("unexpected error: got %v, want it to contain %q", err, tc.err)
+			}
+		})
+	}
+}
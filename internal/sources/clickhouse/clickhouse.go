@@ -0,0 +1,158 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouse
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"math"
+	"net"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const SourceKind string = "clickhouse"
+
+// validate interface
+var _ sources.SourceConfig = Config{}
+
+func init() {
+	if !sources.Register(SourceKind, newConfig) {
+		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name         string `yaml:"name" validate:"required"`
+	Kind         string `yaml:"kind" validate:"required"`
+	Host         string `yaml:"host" validate:"required"`
+	Port         string `yaml:"port" validate:"required"`
+	User         string `yaml:"user" validate:"required"`
+	Password     string `yaml:"password"`
+	Database     string `yaml:"database"`
+	QueryTimeout string `yaml:"queryTimeout"`
+	SSLMode      string `yaml:"sslMode" validate:"required_with=SSLRootCert SSLCert SSLKey,omitempty,oneof=disable require verify-ca verify-full"`
+	SSLRootCert  string `yaml:"sslRootCert"` // Optional, file path or inline PEM
+	SSLCert      string `yaml:"sslCert" validate:"required_with=SSLKey"`
+	SSLKey       string `yaml:"sslKey" validate:"required_with=SSLCert"`
+	// AsyncInsert has the server buffer the rows of INSERT statements and
+	// write them in batches, which suits tools inserting few rows at a time.
+	AsyncInsert bool `yaml:"asyncInsert"`
+	// WaitForAsyncInsert is whether INSERT statements return once their rows
+	// are written, which is the default, or once they are buffered.
+	WaitForAsyncInsert *bool `yaml:"waitForAsyncInsert"`
+}
+
+func (r Config) SourceConfigKind() string {
+	return SourceKind
+}
+
+func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	var tlsConfig *tls.Config
+	if r.SSLMode != "" {
+		var err error
+		tlsConfig, err = sources.NewTLSConfig(r.SSLMode, r.SSLRootCert, r.SSLCert, r.SSLKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+	conn, err := initClickHouseConnection(ctx, tracer, r, tlsConfig)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create connection: %w", err)
+	}
+
+	if err := conn.Ping(ctx); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("unable to connect successfully: %w", err)
+	}
+
+	s := &Source{
+		Name: r.Name,
+		Kind: SourceKind,
+		Conn: conn,
+	}
+	return s, nil
+}
+
+var _ sources.Source = &Source{}
+
+type Source struct {
+	Name string `yaml:"name"`
+	Kind string `yaml:"kind"`
+	Conn driver.Conn
+}
+
+func (s *Source) SourceKind() string {
+	return SourceKind
+}
+
+func (s *Source) ClickHouseConn() driver.Conn {
+	return s.Conn
+}
+
+// settings returns the ClickHouse settings of the queries of the source.
+func (r Config) settings() clickhouse.Settings {
+	settings := clickhouse.Settings{}
+	if r.AsyncInsert {
+		settings["async_insert"] = 1
+		settings["wait_for_async_insert"] = 1
+		if r.WaitForAsyncInsert != nil && !*r.WaitForAsyncInsert {
+			settings["wait_for_async_insert"] = 0
+		}
+	}
+	return settings
+}
+
+func initClickHouseConnection(ctx context.Context, tracer trace.Tracer, r Config, tlsConfig *tls.Config) (driver.Conn, error) {
+	//nolint:all // Reassigned ctx
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, r.Name)
+	defer span.End()
+
+	opts := &clickhouse.Options{
+		Protocol: clickhouse.Native,
+		Addr:     []string{net.JoinHostPort(r.Host, r.Port)},
+		Auth: clickhouse.Auth{
+			Database: r.Database,
+			Username: r.User,
+			Password: r.Password,
+		},
+		TLS:      tlsConfig,
+		Settings: r.settings(),
+	}
+	if r.QueryTimeout != "" {
+		timeout, err := time.ParseDuration(r.QueryTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid queryTimeout %q: %w", r.QueryTimeout, err)
+		}
+		opts.ReadTimeout = timeout
+		// the server also stops the query, rather than only the client
+		opts.Settings["max_execution_time"] = int(math.Ceil(timeout.Seconds()))
+	}
+	return clickhouse.Open(opts)
+}
@@ -0,0 +1,155 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouse_test
+
+import (
+	"strings"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources/clickhouse"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+)
+
+func TestParseFromYamlClickHouse(t *testing.T) {
+	wait := false
+	tcs := []struct {
+		desc string
+		in   string
+		want server.SourceConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			sources:
+				my-clickhouse:
+					kind: clickhouse
+					host: 0.0.0.0
+					port: "9000"
+					database: my_db
+					user: my_user
+					password: my_pass
+			`,
+			want: server.SourceConfigs{
+				"my-clickhouse": clickhouse.Config{
+					Name:     "my-clickhouse",
+					Kind:     clickhouse.SourceKind,
+					Host:     "0.0.0.0",
+					Port:     "9000",
+					Database: "my_db",
+					User:     "my_user",
+					Password: "my_pass",
+				},
+			},
+		},
+		{
+			desc: "with tls, query timeout and async inserts",
+			in: `
+			sources:
+				my-clickhouse:
+					kind: clickhouse
+					host: 0.0.0.0
+					port: "9440"
+					user: my_user
+					password: my_pass
+					queryTimeout: 30s
+					sslMode: verify-full
+					sslRootCert: /etc/ssl/ca.pem
+					asyncInsert: true
+					waitForAsyncInsert: false
+			`,
+			want: server.SourceConfigs{
+				"my-clickhouse": clickhouse.Config{
+					Name:               "my-clickhouse",
+					Kind:               clickhouse.SourceKind,
+					Host:               "0.0.0.0",
+					Port:               "9440",
+					User:               "my_user",
+					Password:           "my_pass",
+					QueryTimeout:       "30s",
+					SSLMode:            "verify-full",
+					SSLRootCert:        "/etc/ssl/ca.pem",
+					AsyncInsert:        true,
+					WaitForAsyncInsert: &wait,
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Sources); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestFailParseFromYaml(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		err  string
+	}{
+		{
+			desc: "missing required field",
+			in: `
+			sources:
+				my-clickhouse:
+					kind: clickhouse
+					port: "9000"
+					user: my_user
+			`,
+			err: "Field validation for 'Host' failed on the 'required' tag",
+		},
+		{
+			desc: "invalid sslMode",
+			in: `
+			sources:
+				my-clickhouse:
+					kind: clickhouse
+					host: 0.0.0.0
+					port: "9000"
+					user: my_user
+					sslMode: fail
+			`,
+			err: "Field validation for 'SSLMode' failed on the 'oneof' tag",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err == nil {
+				t.Fatalf("expect parsing to fail")
+			}
+			if !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("unexpected error: got %q, want it to contain %q", err.Error(), tc.err)
+			}
+		})
+	}
+}
@@ -88,6 +88,8 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 }
 
 var _ sources.Source = &Source{}
+var _ sources.TableDescriber = &Source{}
+var _ sources.SchemaLister = &Source{}
 
 type Source struct {
 	// Cloud SQL MSSQL struct with connection pool
@@ -106,6 +108,14 @@ func (s *Source) MSSQLDB() *sql.DB {
 	return s.Db
 }
 
+func (s *Source) DescribeTable(ctx context.Context, table string) ([]sources.Column, error) {
+	return sources.DescribeMSSQLTable(ctx, s.Db, table)
+}
+
+func (s *Source) ListSchema(ctx context.Context) ([]sources.Table, error) {
+	return sources.ListMSSQLSchema(ctx, s.Db)
+}
+
 func initCloudSQLMssqlConnection(ctx context.Context, tracer trace.Tracer, name, project, region, instance, ipAddress, ipType, user, pass, dbname string) (*sql.DB, error) {
 	//nolint:all // Reassigned ctx
 	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, name)
@@ -82,6 +82,8 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 }
 
 var _ sources.Source = &Source{}
+var _ sources.TableDescriber = &Source{}
+var _ sources.SchemaLister = &Source{}
 
 type Source struct {
 	Name string `yaml:"name"`
@@ -97,6 +99,14 @@ func (s *Source) MySQLPool() *sql.DB {
 	return s.Pool
 }
 
+func (s *Source) DescribeTable(ctx context.Context, table string) ([]sources.Column, error) {
+	return sources.DescribeMySQLTable(ctx, s.Pool, table)
+}
+
+func (s *Source) ListSchema(ctx context.Context) ([]sources.Table, error) {
+	return sources.ListMySQLSchema(ctx, s.Pool)
+}
+
 func initCloudSQLMySQLConnectionPool(ctx context.Context, tracer trace.Tracer, name, project, region, instance, ipType, user, pass, dbname string) (*sql.DB, error) {
 	//nolint:all // Reassigned ctx
 	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, name)
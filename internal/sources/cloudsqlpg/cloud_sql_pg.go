@@ -82,6 +82,8 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 }
 
 var _ sources.Source = &Source{}
+var _ sources.TableDescriber = &Source{}
+var _ sources.SchemaLister = &Source{}
 
 type Source struct {
 	Name string `yaml:"name"`
@@ -97,6 +99,14 @@ func (s *Source) PostgresPool() *pgxpool.Pool {
 	return s.Pool
 }
 
+func (s *Source) DescribeTable(ctx context.Context, table string) ([]sources.Column, error) {
+	return sources.DescribePostgresTable(ctx, s.Pool, table)
+}
+
+func (s *Source) ListSchema(ctx context.Context) ([]sources.Table, error) {
+	return sources.ListPostgresSchema(ctx, s.Pool)
+}
+
 func getConnectionConfig(ctx context.Context, user, pass, dbname string) (string, bool, error) {
 	useIAM := true
 
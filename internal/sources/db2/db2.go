@@ -0,0 +1,163 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db2
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const SourceKind string = "db2"
+
+// driverName is the database/sql driver registered by the IBM Db2 driver,
+// which is only linked into binaries built with the "db2" build tag.
+const driverName string = "go_ibm_db"
+
+// validate interface
+var _ sources.SourceConfig = Config{}
+
+func init() {
+	if !sources.Register(SourceKind, newConfig) {
+		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name                 string `yaml:"name" validate:"required"`
+	Kind                 string `yaml:"kind" validate:"required"`
+	Host                 string `yaml:"host" validate:"required"`
+	Port                 string `yaml:"port" validate:"required"`
+	Database             string `yaml:"database" validate:"required"`
+	User                 string `yaml:"user" validate:"required"`
+	Password             string `yaml:"password" validate:"required"`
+	CurrentSchema        string `yaml:"currentSchema"`
+	UseSSL               bool   `yaml:"ssl"`
+	SSLServerCertificate string `yaml:"sslServerCertificate"`
+}
+
+func (r Config) SourceConfigKind() string {
+	return SourceKind
+}
+
+func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	db, err := initDB2Connection(ctx, tracer, r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create db connection: %w", err)
+	}
+
+	err = db.PingContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect successfully: %w", err)
+	}
+
+	s := &Source{
+		Name: r.Name,
+		Kind: SourceKind,
+		Db:   db,
+	}
+	return s, nil
+}
+
+var _ sources.Source = &Source{}
+var _ sources.TableDescriber = &Source{}
+
+type Source struct {
+	Name string `yaml:"name"`
+	Kind string `yaml:"kind"`
+	Db   *sql.DB
+}
+
+func (s *Source) SourceKind() string {
+	return SourceKind
+}
+
+func (s *Source) DB2DB() *sql.DB {
+	return s.Db
+}
+
+// columnsQuery lists the columns of a table, in the current schema when the
+// schema is empty.
+const columnsQuery = `SELECT COLNAME, TYPENAME FROM SYSCAT.COLUMNS
+WHERE TABNAME = ? AND TABSCHEMA = COALESCE(NULLIF(CAST(? AS VARCHAR(128)), ''), CURRENT SCHEMA)
+ORDER BY COLNO`
+
+func (s *Source) DescribeTable(ctx context.Context, table string) ([]sources.Column, error) {
+	schema, name := sources.SplitTableName(table)
+	return sources.DescribeTableSQL(ctx, s.Db, columnsQuery, name, schema)
+}
+
+// DSN returns the CLI connection string for the source. Values with
+// characters that have a meaning in connection strings, such as a password
+// containing ";", are enclosed in braces.
+func (r Config) DSN() string {
+	keywords := []string{
+		"HOSTNAME=" + dsnValue(r.Host),
+		"PORT=" + dsnValue(r.Port),
+		"DATABASE=" + dsnValue(r.Database),
+		"UID=" + dsnValue(r.User),
+		"PWD=" + dsnValue(r.Password),
+	}
+	if r.CurrentSchema != "" {
+		keywords = append(keywords, "CurrentSchema="+dsnValue(r.CurrentSchema))
+	}
+	if r.UseSSL {
+		keywords = append(keywords, "Security=SSL")
+		if r.SSLServerCertificate != "" {
+			keywords = append(keywords, "SSLServerCertificate="+dsnValue(r.SSLServerCertificate))
+		}
+	}
+	return strings.Join(keywords, ";")
+}
+
+// dsnValue returns v as the value of a keyword of a connection string, enclosed
+// in braces, with its closing braces doubled, if it contains ";", "=", braces
+// or leading or trailing spaces.
+func dsnValue(v string) string {
+	if !strings.ContainsAny(v, ";={}") && strings.TrimSpace(v) == v {
+		return v
+	}
+	return "{" + strings.ReplaceAll(v, "}", "}}") + "}"
+}
+
+func initDB2Connection(ctx context.Context, tracer trace.Tracer, r Config) (*sql.DB, error) {
+	//nolint:all // Reassigned ctx
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, r.Name)
+	defer span.End()
+
+	if !slices.Contains(sql.Drivers(), driverName) {
+		return nil, fmt.Errorf("the Db2 driver is not included in this build; rebuild with `-tags db2` and the IBM Db2 CLI driver installed")
+	}
+
+	db, err := sql.Open(driverName, r.DSN())
+	if err != nil {
+		return nil, fmt.Errorf("sql.Open: %w", err)
+	}
+	return db, nil
+}
@@ -0,0 +1,193 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db2_test
+
+import (
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources/db2"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+)
+
+func TestParseFromYamlDB2(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		want server.SourceConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			sources:
+				my-db2-instance:
+					kind: db2
+					host: 0.0.0.0
+					port: 50000
+					database: my_db
+					user: my_user
+					password: my_pass
+			`,
+			want: server.SourceConfigs{
+				"my-db2-instance": db2.Config{
+					Name:     "my-db2-instance",
+					Kind:     db2.SourceKind,
+					Host:     "0.0.0.0",
+					Port:     "50000",
+					Database: "my_db",
+					User:     "my_user",
+					Password: "my_pass",
+				},
+			},
+		},
+		{
+			desc: "schema and ssl",
+			in: `
+			sources:
+				my-db2-instance:
+					kind: db2
+					host: db2.example.com
+					port: 50001
+					database: my_db
+					user: my_user
+					password: my_pass
+					currentSchema: SALES
+					ssl: true
+					sslServerCertificate: /etc/ssl/db2.arm
+			`,
+			want: server.SourceConfigs{
+				"my-db2-instance": db2.Config{
+					Name:                 "my-db2-instance",
+					Kind:                 db2.SourceKind,
+					Host:                 "db2.example.com",
+					Port:                 "50001",
+					Database:             "my_db",
+					User:                 "my_user",
+					Password:             "my_pass",
+					CurrentSchema:        "SALES",
+					UseSSL:               true,
+					SSLServerCertificate: "/etc/ssl/db2.arm",
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if !cmp.Equal(tc.want, got.Sources) {
+				t.Fatalf("incorrect parse: want %v, got %v", tc.want, got.Sources)
+			}
+		})
+	}
+}
+
+func TestFailParseFromYaml(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		err  string
+	}{
+		{
+			desc: "missing required field",
+			in: `
+			sources:
+				my-db2-instance:
+					kind: db2
+					host: 0.0.0.0
+					port: 50000
+					user: my_user
+					password: my_pass
+			`,
+			err: "unable to parse source \"my-db2-instance\" as \"db2\": Key: 'Config.Database' Error:Field validation for 'Database' failed on the 'required' tag",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err == nil {
+				t.Fatalf("expect parsing to fail")
+			}
+			errStr := err.Error()
+			if errStr != tc.err {
+				t.Fatalf("unexpected error: got %q, want %q", errStr, tc.err)
+			}
+		})
+	}
+}
+
+func TestDSN(t *testing.T) {
+	tcs := []struct {
+		desc string
+		cfg  db2.Config
+		want string
+	}{
+		{
+			desc: "basic",
+			cfg: db2.Config{
+				Host:     "localhost",
+				Port:     "50000",
+				Database: "my_db",
+				User:     "my_user",
+				Password: "my_pass",
+			},
+			want: "HOSTNAME=localhost;PORT=50000;DATABASE=my_db;UID=my_user;PWD=my_pass",
+		},
+		{
+			desc: "special characters",
+			cfg: db2.Config{
+				Host:     "localhost",
+				Port:     "50000",
+				Database: "my_db",
+				User:     "my_user",
+				Password: "p;a=s{s}",
+			},
+			want: "HOSTNAME=localhost;PORT=50000;DATABASE=my_db;UID=my_user;PWD={p;a=s{s}}}",
+		},
+		{
+			desc: "schema and ssl",
+			cfg: db2.Config{
+				Host:                 "localhost",
+				Port:                 "50001",
+				Database:             "my_db",
+				User:                 "my_user",
+				Password:             "my_pass",
+				CurrentSchema:        "SALES",
+				UseSSL:               true,
+				SSLServerCertificate: "/etc/ssl/db2.arm",
+			},
+			want: "HOSTNAME=localhost;PORT=50001;DATABASE=my_db;UID=my_user;PWD=my_pass;CurrentSchema=SALES;Security=SSL;SSLServerCertificate=/etc/ssl/db2.arm",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, tc.cfg.DSN()); diff != "" {
+				t.Fatalf("incorrect dsn: diff %v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !db2
+
+package db2_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/googleapis/genai-toolbox/internal/sources/db2"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestInitializeWithoutDriver(t *testing.T) {
+	cfg := db2.Config{
+		Name:     "my-db2-instance",
+		Kind:     db2.SourceKind,
+		Host:     "127.0.0.1",
+		Port:     "50000",
+		Database: "my_db",
+		User:     "my_user",
+		Password: "my_pass",
+	}
+	_, err := cfg.Initialize(context.Background(), noop.NewTracerProvider().Tracer("test"))
+	if err == nil || !strings.Contains(err.Error(), "-tags db2") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
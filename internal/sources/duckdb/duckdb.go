@@ -0,0 +1,262 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package duckdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const SourceKind string = "duckdb"
+
+// memory is the name of an in-memory database.
+const memory = ":memory:"
+
+// validate interface
+var _ sources.SourceConfig = Config{}
+
+func init() {
+	if !sources.Register(SourceKind, newConfig) {
+		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name string `yaml:"name" validate:"required"`
+	Kind string `yaml:"kind" validate:"required"`
+	// Database is the path of the database file, or ":memory:", the default,
+	// for a database that only lives for the duration of each statement,
+	// which suits querying Parquet and CSV files.
+	Database string `yaml:"database"`
+	// Binary is the path of the DuckDB CLI, which runs the statements.
+	// Defaults to "duckdb" on the PATH.
+	Binary       string `yaml:"binary"`
+	QueryTimeout string `yaml:"queryTimeout"`
+	// ReadOnly opens the database file read-only, so that statements can't
+	// write to it and don't wait for each other.
+	ReadOnly bool `yaml:"readOnly"`
+}
+
+func (r Config) SourceConfigKind() string {
+	return SourceKind
+}
+
+func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	//nolint:all // Reassigned ctx
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, r.Name)
+	defer span.End()
+
+	binary := r.Binary
+	if binary == "" {
+		binary = "duckdb"
+	}
+	path, err := exec.LookPath(binary)
+	if err != nil {
+		return nil, fmt.Errorf("unable to find the DuckDB CLI: %w", err)
+	}
+	database := r.Database
+	if database == "" {
+		database = memory
+	}
+	var timeout time.Duration
+	if r.QueryTimeout != "" {
+		if timeout, err = time.ParseDuration(r.QueryTimeout); err != nil {
+			return nil, fmt.Errorf("invalid queryTimeout %q: %w", r.QueryTimeout, err)
+		}
+	}
+
+	s := &Source{
+		Name:     r.Name,
+		Kind:     SourceKind,
+		binary:   path,
+		database: database,
+		timeout:  timeout,
+		readOnly: r.ReadOnly && database != memory,
+	}
+	if _, err := s.DuckDBQuery(ctx, "SELECT 1", nil); err != nil {
+		return nil, fmt.Errorf("unable to connect successfully: %w", err)
+	}
+	return s, nil
+}
+
+var _ sources.Source = &Source{}
+
+type Source struct {
+	Name string `yaml:"name"`
+	Kind string `yaml:"kind"`
+
+	binary   string
+	database string
+	timeout  time.Duration
+	readOnly bool
+	// mu serializes the statements writing to a database file, since DuckDB
+	// only lets one process open it for writing.
+	mu sync.Mutex
+}
+
+func (s *Source) SourceKind() string {
+	return SourceKind
+}
+
+// DuckDBQuery runs statement with the DuckDB CLI, binding params to its $1,
+// $2, ... or ? placeholders, and returns the rows of its last result.
+func (s *Source) DuckDBQuery(ctx context.Context, statement string, params []any) ([]any, error) {
+	script, err := buildScript(statement, params)
+	if err != nil {
+		return nil, err
+	}
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+	args := []string{"-json", "-bail"}
+	if s.readOnly {
+		args = append(args, "-readonly")
+	} else if s.database != memory {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+	}
+	args = append(args, s.database)
+
+	// the statement is written to the standard input of the CLI rather than
+	// passed as an argument, so its length isn't limited
+	cmd := exec.CommandContext(ctx, s.binary, args...)
+	cmd.Stdin = strings.NewReader(script)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err = cmd.Run()
+	if ctx.Err() != nil {
+		return nil, fmt.Errorf("unable to run query: %w", ctx.Err())
+	}
+	if err != nil {
+		// the CLI prints the errors of statements, such as "Parser Error: ...",
+		// and exits after the first one
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, errors.New(msg)
+		}
+		return nil, fmt.Errorf("unable to run the DuckDB CLI: %w", err)
+	}
+	return parseResults(&stdout)
+}
+
+// buildScript returns the script running statement with params. Statements
+// with parameters are prepared, and executed with the parameters as literals.
+func buildScript(statement string, params []any) (string, error) {
+	// the statement is terminated on a new line, in case it ends with a
+	// line comment
+	statement = strings.TrimRight(statement, " \t\r\n;") + "\n;\n"
+	if len(params) == 0 {
+		return checkScript(statement)
+	}
+	literals := make([]string, 0, len(params))
+	for i, p := range params {
+		l, err := literal(p)
+		if err != nil {
+			return "", fmt.Errorf("unable to bind parameter %d: %w", i+1, err)
+		}
+		literals = append(literals, l)
+	}
+	return checkScript(fmt.Sprintf("PREPARE toolbox_statement AS %sEXECUTE toolbox_statement(%s);\n", statement, strings.Join(literals, ", ")))
+}
+
+// checkScript returns script, or an error if one of its lines would be run
+// by the CLI as a dot command, such as .shell or .system, rather than as SQL.
+func checkScript(script string) (string, error) {
+	for _, line := range strings.Split(script, "\n") {
+		if strings.HasPrefix(strings.TrimLeft(line, " \t\r"), ".") {
+			return "", fmt.Errorf("statements must not contain lines starting with a dot, which the DuckDB CLI runs as commands")
+		}
+	}
+	return script, nil
+}
+
+// literal formats v as a DuckDB literal. Arrays are lists.
+func literal(v any) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "NULL", nil
+	case string:
+		if strings.ContainsRune(v, 0) {
+			return "", fmt.Errorf("strings must not contain a null character")
+		}
+		// DuckDB strings don't escape with backslashes
+		return "'" + strings.ReplaceAll(v, "'", "''") + "'", nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return "", fmt.Errorf("unsupported float %v", v)
+		}
+		return strconv.FormatFloat(v, 'g', -1, 64), nil
+	case []any:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			l, err := literal(item)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, l)
+		}
+		return "[" + strings.Join(items, ", ") + "]", nil
+	default:
+		return "", fmt.Errorf("unsupported type %T", v)
+	}
+}
+
+// parseResults returns the rows of the last result printed by the CLI in
+// JSON mode, which prints a JSON array for each statement returning rows.
+// Numbers keep every digit.
+func parseResults(r io.Reader) ([]any, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	var out []any
+	for {
+		var rows []any
+		if err := dec.Decode(&rows); err == io.EOF {
+			return out, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("unable to parse results: %w", err)
+		}
+		out = rows
+	}
+}
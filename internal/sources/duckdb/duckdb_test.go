@@ -0,0 +1,173 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package duckdb_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources/duckdb"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestParseFromYamlDuckDB(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		want server.SourceConfigs
+	}{
+		{
+			desc: "in-memory",
+			in: `
+			sources:
+				my-duckdb:
+					kind: duckdb
+			`,
+			want: server.SourceConfigs{
+				"my-duckdb": duckdb.Config{
+					Name: "my-duckdb",
+					Kind: duckdb.SourceKind,
+				},
+			},
+		},
+		{
+			desc: "database file",
+			in: `
+			sources:
+				my-duckdb:
+					kind: duckdb
+					database: /data/analytics.duckdb
+					binary: /usr/local/bin/duckdb
+					queryTimeout: 30s
+					readOnly: true
+			`,
+			want: server.SourceConfigs{
+				"my-duckdb": duckdb.Config{
+					Name:         "my-duckdb",
+					Kind:         duckdb.SourceKind,
+					Database:     "/data/analytics.duckdb",
+					Binary:       "/usr/local/bin/duckdb",
+					QueryTimeout: "30s",
+					ReadOnly:     true,
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Sources); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+// fakeCLI writes a script standing in for the DuckDB CLI, which records its
+// arguments and standard input next to itself, fails statements containing
+// FAIL and otherwise prints two rows.
+func fakeCLI(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), "duckdb")
+	script := `#!/bin/sh
+printf '%s\n' "$@" > "$0.args"
+cat > "$0.stdin"
+if grep -q FAIL "$0.stdin"; then
+	echo "Parser Error: syntax error at or near \"FAIL\"" >&2
+	exit 1
+fi
+printf '[{"n":1},\n{"n":12345678901234567890}]\n'
+`
+	if err := os.WriteFile(path, []byte(script), 0o755); err != nil {
+		t.Fatalf("unable to write fake CLI: %s", err)
+	}
+	return path
+}
+
+func TestDuckDBQuery(t *testing.T) {
+	ctx := context.Background()
+	binary := fakeCLI(t)
+	cfg := duckdb.Config{
+		Name:     "my-duckdb",
+		Kind:     duckdb.SourceKind,
+		Database: "/data/analytics.duckdb",
+		Binary:   binary,
+		ReadOnly: true,
+	}
+	s, err := cfg.Initialize(ctx, noop.NewTracerProvider().Tracer(""))
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	source := s.(*duckdb.Source)
+
+	got, err := source.DuckDBQuery(ctx, "SELECT n FROM t WHERE name = $1 AND id = ANY($2) -- tagged", []any{"it's", []any{1, 2}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := []any{
+		map[string]any{"n": json.Number("1")},
+		map[string]any{"n": json.Number("12345678901234567890")},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect results: diff %v", diff)
+	}
+
+	args, err := os.ReadFile(binary + ".args")
+	if err != nil {
+		t.Fatalf("unable to read arguments: %s", err)
+	}
+	if diff := cmp.Diff("-json\n-bail\n-readonly\n/data/analytics.duckdb\n", string(args)); diff != "" {
+		t.Fatalf("incorrect arguments: diff %v", diff)
+	}
+	stdin, err := os.ReadFile(binary + ".stdin")
+	if err != nil {
+		t.Fatalf("unable to read statements: %s", err)
+	}
+	wantStdin := "PREPARE toolbox_statement AS SELECT n FROM t WHERE name = $1 AND id = ANY($2) -- tagged\n;\nEXECUTE toolbox_statement('it''s', [1, 2]);\n"
+	if diff := cmp.Diff(wantStdin, string(stdin)); diff != "" {
+		t.Fatalf("incorrect statements: diff %v", diff)
+	}
+
+	_, err = source.DuckDBQuery(ctx, "SELECT FAIL", nil)
+	if err == nil || !strings.Contains(err.Error(), "Parser Error") {
+		t.Fatalf("want the error of the CLI, got %v", err)
+	}
+	_, err = source.DuckDBQuery(ctx, "SELECT 1;\n  .shell touch /tmp/pwned", nil)
+	if err == nil || !strings.Contains(err.Error(), "starting with a dot") {
+		t.Fatalf("want a dot command error, got %v", err)
+	}
+	_, err = source.DuckDBQuery(ctx, "SELECT $1", []any{"a\n.system touch /tmp/pwned"})
+	if err == nil || !strings.Contains(err.Error(), "starting with a dot") {
+		t.Fatalf("want a dot command error for a parameter, got %v", err)
+	}
+	_, err = source.DuckDBQuery(ctx, "SELECT $1", []any{map[string]any{"a": 1}})
+	if err == nil || !strings.Contains(err.Error(), "unsupported type") {
+		t.Fatalf("want an unsupported type error, got %v", err)
+	}
+}
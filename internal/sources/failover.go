@@ -0,0 +1,86 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sources
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"sync"
+	"time"
+)
+
+// failoverCooldown is how long an endpoint that failed to connect is skipped
+// in favor of the endpoints of lower priority.
+const failoverCooldown = 30 * time.Second
+
+// Endpoint is a failover endpoint of a database source.
+type Endpoint struct {
+	Host string `yaml:"host" validate:"required"`
+	Port string `yaml:"port" validate:"required"`
+}
+
+// NewFailoverConnector returns a connector that connects to the first
+// reachable of connectors, which are in order of priority. A connector that
+// failed to connect is only tried again after failoverCooldown, or if no
+// other connector can connect, so that new connections fail over without
+// waiting for an unreachable endpoint, and fail back once it recovers.
+func NewFailoverConnector(connectors ...driver.Connector) driver.Connector {
+	return &failoverConnector{connectors: connectors, failedAt: make([]time.Time, len(connectors))}
+}
+
+type failoverConnector struct {
+	connectors []driver.Connector
+
+	mu       sync.Mutex
+	failedAt []time.Time
+}
+
+func (c *failoverConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	var fresh, cooling []int
+	c.mu.Lock()
+	for i, t := range c.failedAt {
+		if !t.IsZero() && time.Since(t) < failoverCooldown {
+			cooling = append(cooling, i)
+		} else {
+			fresh = append(fresh, i)
+		}
+	}
+	c.mu.Unlock()
+
+	var errs []error
+	for _, i := range append(fresh, cooling...) {
+		conn, err := c.connectors[i].Connect(ctx)
+		c.mu.Lock()
+		if err != nil {
+			c.failedAt[i] = time.Now()
+		} else {
+			c.failedAt[i] = time.Time{}
+		}
+		c.mu.Unlock()
+		if err == nil {
+			return conn, nil
+		}
+		errs = append(errs, err)
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, errors.Join(errs...)
+}
+
+func (c *failoverConnector) Driver() driver.Driver {
+	return c.connectors[0].Driver()
+}
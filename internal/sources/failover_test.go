@@ -0,0 +1,102 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sources_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/googleapis/genai-toolbox/internal/sources"
+)
+
+type fakeConn struct {
+	driver.Conn
+	endpoint string
+}
+
+type fakeConnector struct {
+	endpoint string
+	err      error
+	calls    int
+}
+
+func (c *fakeConnector) Connect(context.Context) (driver.Conn, error) {
+	c.calls++
+	if c.err != nil {
+		return nil, c.err
+	}
+	return fakeConn{endpoint: c.endpoint}, nil
+}
+
+func (c *fakeConnector) Driver() driver.Driver {
+	return nil
+}
+
+func TestFailoverConnector(t *testing.T) {
+	ctx := context.Background()
+	primary := &fakeConnector{endpoint: "primary"}
+	secondary := &fakeConnector{endpoint: "secondary"}
+	connector := sources.NewFailoverConnector(primary, secondary)
+
+	connect := func(want string) {
+		t.Helper()
+		conn, err := connector.Connect(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got := conn.(fakeConn).endpoint; got != want {
+			t.Fatalf("connected to %q, want %q", got, want)
+		}
+	}
+
+	// the primary has priority
+	connect("primary")
+	connect("primary")
+	if secondary.calls != 0 {
+		t.Fatalf("expected the secondary not to be tried")
+	}
+
+	// an unreachable primary fails over to the secondary
+	primary.err = errors.New("primary is down")
+	connect("secondary")
+	if primary.calls != 3 {
+		t.Fatalf("expected the primary to be tried first, got %d calls", primary.calls)
+	}
+
+	// the failed primary is skipped while it cools down
+	connect("secondary")
+	if primary.calls != 3 {
+		t.Fatalf("expected the failed primary to be skipped, got %d calls", primary.calls)
+	}
+
+	// the failed primary is still tried if no other endpoint can connect
+	primary.err = nil
+	secondary.err = errors.New("secondary is down")
+	connect("primary")
+
+	primary.err = errors.New("primary is down")
+	_, err := connector.Connect(ctx)
+	if err == nil {
+		t.Fatalf("expected an error when no endpoint can connect")
+	}
+	for _, want := range []string{"primary is down", "secondary is down"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected error %q to contain %q", err, want)
+		}
+	}
+}
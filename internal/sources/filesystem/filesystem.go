@@ -0,0 +1,119 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystem
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const SourceKind string = "filesystem"
+
+// validate interface
+var _ sources.SourceConfig = Config{}
+
+func init() {
+	if !sources.Register(SourceKind, newConfig) {
+		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name string `yaml:"name" validate:"required"`
+	Kind string `yaml:"kind" validate:"required"`
+	Path string `yaml:"path" validate:"required"`
+}
+
+func (r Config) SourceConfigKind() string {
+	return SourceKind
+}
+
+func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	root, err := initFilesystemRoot(ctx, tracer, r.Name, r.Path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open root directory: %w", err)
+	}
+
+	s := &Source{
+		Name: r.Name,
+		Kind: SourceKind,
+		Root: root,
+	}
+	return s, nil
+}
+
+var _ sources.Source = &Source{}
+
+type Source struct {
+	Name string `yaml:"name"`
+	Kind string `yaml:"kind"`
+	Root *os.Root
+}
+
+func (s *Source) SourceKind() string {
+	return SourceKind
+}
+
+// FilesystemRoot returns the root directory of the source. Files can't be
+// accessed outside of it, including through symlinks.
+func (s *Source) FilesystemRoot() *os.Root {
+	return s.Root
+}
+
+// CleanPath converts a path given to a tool into a path relative to the root
+// directory. Both relative paths and paths starting with "/" are resolved
+// from the root directory, and paths escaping it are rejected.
+func CleanPath(name string) (string, error) {
+	if strings.Contains(name, "\x00") {
+		return "", fmt.Errorf("invalid path %q", name)
+	}
+	slashed := strings.ReplaceAll(name, "\\", "/")
+	// a relative path escapes the root if it climbs above it
+	if rel := path.Clean(slashed); rel == ".." || strings.HasPrefix(rel, "../") {
+		return "", fmt.Errorf("path %q is outside of the root directory", name)
+	}
+	cleaned := path.Clean("/" + slashed)
+	if cleaned == "/" {
+		return ".", nil
+	}
+	return strings.TrimPrefix(cleaned, "/"), nil
+}
+
+func initFilesystemRoot(ctx context.Context, tracer trace.Tracer, name, dir string) (*os.Root, error) {
+	//nolint:all // Reassigned ctx
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, name)
+	defer span.End()
+
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return nil, err
+	}
+	return root, nil
+}
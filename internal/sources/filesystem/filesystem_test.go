@@ -0,0 +1,150 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystem_test
+
+import (
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources/filesystem"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+)
+
+func TestParseFromYamlFilesystem(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		want server.SourceConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			sources:
+				my-files:
+					kind: filesystem
+					path: /srv/docs
+			`,
+			want: server.SourceConfigs{
+				"my-files": filesystem.Config{
+					Name: "my-files",
+					Kind: filesystem.SourceKind,
+					Path: "/srv/docs",
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if !cmp.Equal(tc.want, got.Sources) {
+				t.Fatalf("incorrect parse: want %v, got %v", tc.want, got.Sources)
+			}
+		})
+	}
+}
+
+func TestFailParseFromYaml(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		err  string
+	}{
+		{
+			desc: "extra field",
+			in: `
+			sources:
+				my-files:
+					kind: filesystem
+					path: /srv/docs
+					foo: bar
+			`,
+			err: "unable to parse source \"my-files\" as \"filesystem\": [1:1] unknown field \"foo\"\n>  1 | foo: bar\n       ^\n   2 | kind: filesystem\n   3 | path: /srv/docs",
+		},
+		{
+			desc: "missing required field",
+			in: `
+			sources:
+				my-files:
+					kind: filesystem
+			`,
+			err: "unable to parse source \"my-files\" as \"filesystem\": Key: 'Config.Path' Error:Field validation for 'Path' failed on the 'required' tag",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err == nil {
+				t.Fatalf("expect parsing to fail")
+			}
+			errStr := err.Error()
+			if errStr != tc.err {
+				t.Fatalf("unexpected error: got %q, want %q", errStr, tc.err)
+			}
+		})
+	}
+}
+
+func TestCleanPath(t *testing.T) {
+	tcs := []struct {
+		in   string
+		want string
+		err  string
+	}{
+		{in: "", want: "."},
+		{in: ".", want: "."},
+		{in: "/", want: "."},
+		{in: "docs/readme.md", want: "docs/readme.md"},
+		{in: "/docs/readme.md", want: "docs/readme.md"},
+		{in: "docs/../readme.md", want: "readme.md"},
+		{in: "./docs//a/", want: "docs/a"},
+		{in: `docs\a.txt`, want: "docs/a.txt"},
+		{in: "/../etc/passwd", want: "etc/passwd"},
+		{in: "..", err: `path ".." is outside of the root directory`},
+		{in: "../etc/passwd", err: `path "../etc/passwd" is outside of the root directory`},
+		{in: "docs/../../etc", err: `path "docs/../../etc" is outside of the root directory`},
+		{in: `..\secret`, err: `path "..\\secret" is outside of the root directory`},
+		{in: "a\x00b", err: `invalid path "a\x00b"`},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.in, func(t *testing.T) {
+			got, err := filesystem.CleanPath(tc.in)
+			if tc.err != "" {
+				if err == nil || err.Error() != tc.err {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tc.want {
+				t.Fatalf("incorrect path: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
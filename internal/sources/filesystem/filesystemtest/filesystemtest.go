@@ -0,0 +1,64 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package filesystemtest provides a filesystem source for the tests of the
+// filesystem tools.
+package filesystemtest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/filesystem"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// SourceName is the name of the source returned by NewSource.
+const SourceName = "my-files"
+
+// NewSource returns a source rooted at a temporary directory containing a few
+// files, and a symlink "escape.txt" pointing to a file outside of the root.
+func NewSource(t *testing.T) sources.Source {
+	t.Helper()
+	base := t.TempDir()
+	dir := filepath.Join(base, "root")
+	files := map[string]string{
+		"readme.md":         "# hello",
+		"docs/guide.md":     "guide",
+		"docs/api/index.md": "api",
+		"docs/api/logo.png": "\x89PNG\x00\xff",
+		"../secret.txt":     "secret",
+	}
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatalf("unable to create directory: %s", err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatalf("unable to write file: %s", err)
+		}
+	}
+	if err := os.Symlink(filepath.Join(base, "secret.txt"), filepath.Join(dir, "escape.txt")); err != nil {
+		t.Fatalf("unable to create symlink: %s", err)
+	}
+
+	src, err := filesystem.Config{Name: SourceName, Kind: filesystem.SourceKind, Path: dir}.Initialize(context.Background(), noop.NewTracerProvider().Tracer("test"))
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	return src
+}
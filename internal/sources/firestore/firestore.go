@@ -52,6 +52,8 @@ type Config struct {
 	Kind     string `yaml:"kind" validate:"required"`
 	Project  string `yaml:"project" validate:"required"`
 	Database string `yaml:"database"` // Optional, defaults to "(default)"
+	// Serialization configures how document values are returned by tools
+	Serialization Serialization `yaml:"serialization"`
 }
 
 func (r Config) SourceConfigKind() string {
@@ -78,6 +80,8 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 		Client:      client,
 		RulesClient: rulesClient,
 		ProjectId:   r.Project,
+
+		Serialization: r.Serialization,
 	}
 	return s, nil
 }
@@ -91,6 +95,8 @@ type Source struct {
 	Client      *firestore.Client
 	RulesClient *firebaserules.Service
 	ProjectId   string `yaml:"projectId"`
+
+	Serialization Serialization `yaml:"serialization"`
 }
 
 func (s *Source) SourceKind() string {
@@ -110,6 +116,10 @@ func (s *Source) GetProjectId() string {
 	return s.ProjectId
 }
 
+func (s *Source) FirestoreSerialization() Serialization {
+	return s.Serialization
+}
+
 func initFirestoreConnection(
 	ctx context.Context,
 	tracer trace.Tracer,
@@ -65,6 +65,33 @@ func TestParseFromYamlFirestore(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "with serialization",
+			in: `
+			sources:
+				my-firestore:
+					kind: firestore
+					project: my-project
+					serialization:
+						references: name
+						timestamps: unixMillis
+						geoPoints: geojson
+						bytes: hex
+			`,
+			want: server.SourceConfigs{
+				"my-firestore": firestore.Config{
+					Name:    "my-firestore",
+					Kind:    firestore.SourceKind,
+					Project: "my-project",
+					Serialization: firestore.Serialization{
+						References: "name",
+						Timestamps: "unixMillis",
+						GeoPoints:  "geojson",
+						Bytes:      "hex",
+					},
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -110,6 +137,18 @@ func TestFailParseFromYamlFirestore(t *testing.T) {
 			`,
 			err: "unable to parse source \"my-firestore\" as \"firestore\": Key: 'Config.Project' Error:Field validation for 'Project' failed on the 'required' tag",
 		},
+		{
+			desc: "invalid serialization",
+			in: `
+			sources:
+				my-firestore:
+					kind: firestore
+					project: my-project
+					serialization:
+						geoPoints: wkt
+			`,
+			err: "unable to parse source \"my-firestore\" as \"firestore\": [4:14] Key: 'Serialization.GeoPoints' Error:Field validation for 'GeoPoints' failed on the 'oneof' tag\n   1 | kind: firestore\n   2 | project: my-project\n   3 | serialization:\n>  4 |   geoPoints: wkt\n                    ^\n",
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
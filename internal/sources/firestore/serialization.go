@@ -0,0 +1,110 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package firestore
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/genproto/googleapis/type/latlng"
+)
+
+// Serialization configures how the Firestore specific values of documents,
+// such as references and GeoPoints, are returned by Firestore tools. Each
+// field defaults to the first of its values.
+type Serialization struct {
+	// References are returned as document paths relative to the database
+	// ("path"), or as full resource names ("name").
+	References string `yaml:"references" validate:"omitempty,oneof=path name"`
+	// Timestamps are returned as RFC 3339 strings ("rfc3339"), or as
+	// milliseconds since the Unix epoch ("unixMillis").
+	Timestamps string `yaml:"timestamps" validate:"omitempty,oneof=rfc3339 unixMillis"`
+	// GeoPoints are returned as objects with latitude and longitude fields
+	// ("object"), or as GeoJSON points ("geojson").
+	GeoPoints string `yaml:"geoPoints" validate:"omitempty,oneof=object geojson"`
+	// Bytes are returned as base64 ("base64") or hex ("hex") strings.
+	Bytes string `yaml:"bytes" validate:"omitempty,oneof=base64 hex"`
+}
+
+// Value returns the document field value v, or a whole document's data, with
+// its Firestore specific values serialized.
+func (s Serialization) Value(v any) any {
+	switch v := v.(type) {
+	case *firestore.DocumentRef:
+		if v == nil {
+			return nil
+		}
+		return s.Reference(v)
+	case *latlng.LatLng:
+		if v == nil {
+			return nil
+		}
+		if s.GeoPoints == "geojson" {
+			return map[string]any{"type": "Point", "coordinates": []any{v.GetLongitude(), v.GetLatitude()}}
+		}
+		return map[string]any{"latitude": v.GetLatitude(), "longitude": v.GetLongitude()}
+	case time.Time:
+		return s.Timestamp(v)
+	case []byte:
+		if s.Bytes == "hex" {
+			return hex.EncodeToString(v)
+		}
+		return base64.StdEncoding.EncodeToString(v)
+	case map[string]any:
+		return s.Document(v)
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = s.Value(item)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// Document returns the data of a document with its Firestore specific values
+// serialized.
+func (s Serialization) Document(data map[string]any) map[string]any {
+	out := make(map[string]any, len(data))
+	for k, v := range data {
+		out[k] = s.Value(v)
+	}
+	return out
+}
+
+// Reference returns the serialized document reference ref.
+func (s Serialization) Reference(ref *firestore.DocumentRef) string {
+	if s.References == "name" {
+		return ref.Path
+	}
+	// the path of a reference is its resource name,
+	// projects/{project}/databases/{database}/documents/{path}
+	if _, path, ok := strings.Cut(ref.Path, "/documents/"); ok {
+		return path
+	}
+	return ref.Path
+}
+
+// Timestamp returns the serialized timestamp t.
+func (s Serialization) Timestamp(t time.Time) any {
+	if s.Timestamps == "unixMillis" {
+		return t.UnixMilli()
+	}
+	return t.Format(time.RFC3339Nano)
+}
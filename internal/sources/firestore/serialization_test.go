@@ -0,0 +1,74 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package firestore_test
+
+import (
+	"testing"
+	"time"
+
+	firestoreapi "cloud.google.com/go/firestore"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/sources/firestore"
+	"google.golang.org/genproto/googleapis/type/latlng"
+)
+
+func TestSerialization(t *testing.T) {
+	ref := &firestoreapi.DocumentRef{ID: "alice", Path: "projects/my-project/databases/(default)/documents/users/alice"}
+	ts := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	data := map[string]any{
+		"owner":    ref,
+		"location": &latlng.LatLng{Latitude: 51.5, Longitude: -0.1},
+		"created":  ts,
+		"avatar":   []byte("hi"),
+		"tags":     []any{"a", map[string]any{"friend": ref}},
+		"count":    int64(1),
+	}
+	tcs := []struct {
+		desc string
+		s    firestore.Serialization
+		want map[string]any
+	}{
+		{
+			desc: "defaults",
+			want: map[string]any{
+				"owner":    "users/alice",
+				"location": map[string]any{"latitude": 51.5, "longitude": -0.1},
+				"created":  "2025-01-02T03:04:05Z",
+				"avatar":   "aGk=",
+				"tags":     []any{"a", map[string]any{"friend": "users/alice"}},
+				"count":    int64(1),
+			},
+		},
+		{
+			desc: "alternatives",
+			s:    firestore.Serialization{References: "name", Timestamps: "unixMillis", GeoPoints: "geojson", Bytes: "hex"},
+			want: map[string]any{
+				"owner":    ref.Path,
+				"location": map[string]any{"type": "Point", "coordinates": []any{-0.1, 51.5}},
+				"created":  ts.UnixMilli(),
+				"avatar":   "6869",
+				"tags":     []any{"a", map[string]any{"friend": ref.Path}},
+				"count":    int64(1),
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, tc.s.Document(data)); diff != "" {
+				t.Fatalf("incorrect serialization: diff %v", diff)
+			}
+		})
+	}
+}
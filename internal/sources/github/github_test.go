@@ -0,0 +1,148 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github_test
+
+import (
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources/github"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+)
+
+func TestParseFromYamlGitHub(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		want server.SourceConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			sources:
+				my-github:
+					kind: github
+					token: ghp_token
+			`,
+			want: server.SourceConfigs{
+				"my-github": github.Config{
+					Name:  "my-github",
+					Kind:  github.SourceKind,
+					Token: "ghp_token",
+				},
+			},
+		},
+		{
+			desc: "enterprise server",
+			in: `
+			sources:
+				my-github:
+					kind: github
+					token: ghp_token
+					baseUrl: https://github.example.com/api/v3
+			`,
+			want: server.SourceConfigs{
+				"my-github": github.Config{
+					Name:    "my-github",
+					Kind:    github.SourceKind,
+					Token:   "ghp_token",
+					BaseURL: "https://github.example.com/api/v3",
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if !cmp.Equal(tc.want, got.Sources) {
+				t.Fatalf("incorrect parse: want %v, got %v", tc.want, got.Sources)
+			}
+		})
+	}
+}
+
+func TestFailParseFromYaml(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		err  string
+	}{
+		{
+			desc: "missing required field",
+			in: `
+			sources:
+				my-github:
+					kind: github
+			`,
+			err: "unable to parse source \"my-github\" as \"github\": Key: 'Config.Token' Error:Field validation for 'Token' failed on the 'required' tag",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err == nil {
+				t.Fatalf("expect parsing to fail")
+			}
+			errStr := err.Error()
+			if errStr != tc.err {
+				t.Fatalf("unexpected error: got %q, want %q", errStr, tc.err)
+			}
+		})
+	}
+}
+
+func TestRepositoryPath(t *testing.T) {
+	tcs := []struct {
+		in   string
+		want string
+		err  bool
+	}{
+		{in: "googleapis/genai-toolbox", want: "/repos/googleapis/genai-toolbox"},
+		{in: "genai-toolbox", err: true},
+		{in: "/genai-toolbox", err: true},
+		{in: "googleapis/", err: true},
+		{in: "googleapis/genai-toolbox/issues", err: true},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.in, func(t *testing.T) {
+			got, err := github.RepositoryPath(tc.in)
+			if tc.err {
+				if err == nil {
+					t.Fatalf("expected error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tc.want {
+				t.Fatalf("incorrect path: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
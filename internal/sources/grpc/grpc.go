@@ -0,0 +1,311 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+const SourceKind string = "grpc"
+
+// validate interface
+var _ sources.SourceConfig = Config{}
+
+func init() {
+	if !sources.Register(SourceKind, newConfig) {
+		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name, Timeout: "30s"} // Default timeout
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name                   string            `yaml:"name" validate:"required"`
+	Kind                   string            `yaml:"kind" validate:"required"`
+	Address                string            `yaml:"address" validate:"required"`
+	UseTLS                 bool              `yaml:"useTLS"`
+	DisableSslVerification bool              `yaml:"disableSslVerification"`
+	Timeout                string            `yaml:"timeout"`
+	Headers                map[string]string `yaml:"headers"`
+	DescriptorSet          string            `yaml:"descriptorSet"`
+}
+
+func (r Config) SourceConfigKind() string {
+	return SourceKind
+}
+
+func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	client, err := initGRPCClient(ctx, tracer, r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create client: %w", err)
+	}
+
+	s := &Source{
+		Name:   r.Name,
+		Kind:   SourceKind,
+		Client: client,
+	}
+	return s, nil
+}
+
+var _ sources.Source = &Source{}
+
+type Source struct {
+	Name   string `yaml:"name"`
+	Kind   string `yaml:"kind"`
+	Client *Client
+}
+
+func (s *Source) SourceKind() string {
+	return SourceKind
+}
+
+func (s *Source) GRPCClient() *Client {
+	return s.Client
+}
+
+// Client invokes RPCs on a gRPC server. Methods are described either by a
+// compiled descriptor set, or by the server itself using server reflection.
+type Client struct {
+	Conn    *grpc.ClientConn
+	Headers map[string]string
+	Timeout time.Duration
+
+	// files resolves descriptors loaded from a descriptor set. It is nil when
+	// descriptors are fetched using server reflection.
+	files *protoregistry.Files
+
+	mu        sync.Mutex
+	reflected map[string]*protoregistry.Files
+}
+
+// FindMethod returns the descriptor of a method, given its full name (e.g.
+// "helloworld.Greeter/SayHello" or "helloworld.Greeter.SayHello").
+func (c *Client) FindMethod(ctx context.Context, name string) (protoreflect.MethodDescriptor, error) {
+	fullName := strings.TrimPrefix(name, "/")
+	if i := strings.LastIndex(fullName, "/"); i >= 0 {
+		fullName = fullName[:i] + "." + fullName[i+1:]
+	}
+	i := strings.LastIndex(fullName, ".")
+	if i < 0 {
+		return nil, fmt.Errorf("invalid method name %q: must be of the form package.Service/Method", name)
+	}
+	serviceName, methodName := fullName[:i], fullName[i+1:]
+
+	files := c.files
+	if files == nil {
+		var err error
+		files, err = c.reflectFiles(ctx, serviceName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	d, err := files.FindDescriptorByName(protoreflect.FullName(serviceName))
+	if err != nil {
+		return nil, fmt.Errorf("unable to find service %q: %w", serviceName, err)
+	}
+	service, ok := d.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%q is not a service", serviceName)
+	}
+	method := service.Methods().ByName(protoreflect.Name(methodName))
+	if method == nil {
+		return nil, fmt.Errorf("service %q has no method %q", serviceName, methodName)
+	}
+	return method, nil
+}
+
+// Invoke calls a unary RPC, adding the configured headers as metadata.
+func (c *Client) Invoke(ctx context.Context, method protoreflect.MethodDescriptor, req, resp proto.Message) error {
+	if c.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.Timeout)
+		defer cancel()
+	}
+	for k, v := range c.Headers {
+		ctx = metadata.AppendToOutgoingContext(ctx, k, v)
+	}
+	fullMethod := fmt.Sprintf("/%s/%s", method.Parent().FullName(), method.Name())
+	return c.Conn.Invoke(ctx, fullMethod, req, resp)
+}
+
+// reflectFiles fetches the file defining a service, along with its
+// dependencies, using server reflection.
+func (c *Client) reflectFiles(ctx context.Context, serviceName string) (*protoregistry.Files, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if files, ok := c.reflected[serviceName]; ok {
+		return files, nil
+	}
+
+	if c.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.Timeout)
+		defer cancel()
+	}
+	for k, v := range c.Headers {
+		ctx = metadata.AppendToOutgoingContext(ctx, k, v)
+	}
+	stream, err := reflectionpb.NewServerReflectionClient(c.Conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to use server reflection: %w", err)
+	}
+	defer func() { _ = stream.CloseSend() }()
+
+	fdps := make(map[string]*descriptorpb.FileDescriptorProto)
+	req := &reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: serviceName},
+	}
+	for req != nil {
+		if err := stream.Send(req); err != nil {
+			return nil, fmt.Errorf("unable to send server reflection request: %w", err)
+		}
+		resp, err := stream.Recv()
+		if err != nil {
+			return nil, fmt.Errorf("unable to receive server reflection response: %w", err)
+		}
+		if errResp := resp.GetErrorResponse(); errResp != nil {
+			return nil, fmt.Errorf("server reflection error for %q: %s", serviceName, errResp.GetErrorMessage())
+		}
+		for _, b := range resp.GetFileDescriptorResponse().GetFileDescriptorProto() {
+			fdp := &descriptorpb.FileDescriptorProto{}
+			if err := proto.Unmarshal(b, fdp); err != nil {
+				return nil, fmt.Errorf("unable to parse file descriptor: %w", err)
+			}
+			fdps[fdp.GetName()] = fdp
+		}
+
+		// request the next dependency that hasn't been fetched yet
+		req = nil
+		for _, fdp := range fdps {
+			for _, dep := range fdp.GetDependency() {
+				if _, ok := fdps[dep]; !ok && req == nil {
+					req = &reflectionpb.ServerReflectionRequest{
+						MessageRequest: &reflectionpb.ServerReflectionRequest_FileByFilename{FileByFilename: dep},
+					}
+				}
+			}
+		}
+	}
+
+	fds := &descriptorpb.FileDescriptorSet{}
+	for _, fdp := range fdps {
+		fds.File = append(fds.File, fdp)
+	}
+	files, err := protodesc.NewFiles(fds)
+	if err != nil {
+		return nil, fmt.Errorf("unable to build descriptors from server reflection: %w", err)
+	}
+	if c.reflected == nil {
+		c.reflected = make(map[string]*protoregistry.Files)
+	}
+	c.reflected[serviceName] = files
+	return files, nil
+}
+
+// loadDescriptorSet reads a FileDescriptorSet, as produced by
+// `protoc --include_imports --descriptor_set_out`.
+func loadDescriptorSet(path string) (*protoregistry.Files, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read descriptor set: %w", err)
+	}
+	fds := &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(b, fds); err != nil {
+		return nil, fmt.Errorf("unable to parse descriptor set: %w", err)
+	}
+	files, err := protodesc.NewFiles(fds)
+	if err != nil {
+		return nil, fmt.Errorf("unable to build descriptors from descriptor set: %w", err)
+	}
+	return files, nil
+}
+
+func initGRPCClient(ctx context.Context, tracer trace.Tracer, r Config) (*Client, error) {
+	//nolint:all // Reassigned ctx
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, r.Name)
+	defer span.End()
+
+	duration, err := time.ParseDuration(r.Timeout)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse Timeout string as time.Duration: %s", err)
+	}
+
+	var files *protoregistry.Files
+	if r.DescriptorSet != "" {
+		files, err = loadDescriptorSet(r.DescriptorSet)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	creds := insecure.NewCredentials()
+	if r.UseTLS {
+		if r.DisableSslVerification {
+			logger, err := util.LoggerFromContext(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("unable to get logger from ctx: %s", err)
+			}
+			logger.WarnContext(ctx, "Insecure gRPC is enabled for gRPC source %s. TLS certificate verification is skipped.\n", r.Name)
+		}
+		creds = credentials.NewTLS(&tls.Config{InsecureSkipVerify: r.DisableSslVerification})
+	}
+
+	ua, err := util.UserAgentFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := grpc.NewClient(r.Address, grpc.WithTransportCredentials(creds), grpc.WithUserAgent(ua))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create connection: %w", err)
+	}
+
+	client := &Client{
+		Conn:    conn,
+		Headers: r.Headers,
+		Timeout: duration,
+		files:   files,
+	}
+	return client, nil
+}
@@ -0,0 +1,359 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hive
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"os"
+	"strings"
+	"sync/atomic"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/jcmturner/gokrb5/v8/client"
+	"github.com/jcmturner/gokrb5/v8/config"
+	"github.com/jcmturner/gokrb5/v8/credentials"
+	"github.com/jcmturner/gokrb5/v8/keytab"
+	"github.com/jcmturner/gokrb5/v8/spnego"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const SourceKind string = "hive"
+
+// authentication types
+const (
+	AuthTypeNone     = "none"
+	AuthTypeLDAP     = "ldap"
+	AuthTypeKerberos = "kerberos"
+)
+
+// validate interface
+var _ sources.SourceConfig = Config{}
+
+func init() {
+	if !sources.Register(SourceKind, newConfig) {
+		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name, HTTPPath: "cliservice", AuthType: AuthTypeNone}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name     string `yaml:"name" validate:"required"`
+	Kind     string `yaml:"kind" validate:"required"`
+	Host     string `yaml:"host" validate:"required"`
+	Port     string `yaml:"port" validate:"required"`
+	HTTPPath string `yaml:"httpPath"`
+	Database string `yaml:"database"`
+	AuthType string `yaml:"authType" validate:"oneof=none ldap kerberos"`
+	// User is the user sessions are opened as. With LDAP authentication it is
+	// also the user authenticated with Password.
+	User     string `yaml:"user" validate:"required_if=AuthType ldap"`
+	Password string `yaml:"password" validate:"required_if=AuthType ldap"`
+	// KerberosPrincipal is the principal authenticated with
+	// KerberosKeytab, such as "toolbox@EXAMPLE.COM". Without a keytab, the
+	// credential cache of KRB5CCNAME is used.
+	KerberosPrincipal        string `yaml:"kerberosPrincipal"`
+	KerberosKeytab           string `yaml:"kerberosKeytab"`
+	KerberosConfig           string `yaml:"kerberosConfig"`
+	KerberosServicePrincipal string `yaml:"kerberosServicePrincipal"`
+	UseTLS                   bool   `yaml:"tls"`
+	TLSRootCAFile            string `yaml:"tlsRootCAFile"`
+	TLSInsecureSkipVerify    bool   `yaml:"tlsInsecureSkipVerify"`
+}
+
+func (r Config) SourceConfigKind() string {
+	return SourceKind
+}
+
+func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	//nolint:all // Reassigned ctx
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, r.Name)
+	defer span.End()
+
+	httpClient, err := newHTTPClient(r)
+	if err != nil {
+		return nil, err
+	}
+	s := &Source{
+		Name:                     r.Name,
+		Kind:                     SourceKind,
+		URL:                      r.URL(),
+		Database:                 r.Database,
+		AuthType:                 r.AuthType,
+		User:                     r.User,
+		Password:                 r.Password,
+		KerberosServicePrincipal: r.KerberosServicePrincipal,
+		Client:                   httpClient,
+	}
+	if r.AuthType == AuthTypeKerberos {
+		s.krb5Client, err = newKerberosClient(r)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// open and close a session to check the server can be reached and
+	// authenticated with
+	session, err := s.openSession(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect successfully: %w", err)
+	}
+	if err := s.closeSession(ctx, session); err != nil {
+		return nil, fmt.Errorf("unable to connect successfully: %w", err)
+	}
+	return s, nil
+}
+
+// URL returns the URL of the Thrift HTTP endpoint of the server.
+func (r Config) URL() string {
+	scheme := "http"
+	if r.UseTLS {
+		scheme = "https"
+	}
+	u := url.URL{
+		Scheme: scheme,
+		Host:   net.JoinHostPort(r.Host, r.Port),
+		Path:   "/" + strings.TrimPrefix(r.HTTPPath, "/"),
+	}
+	return u.String()
+}
+
+func newHTTPClient(r Config) (*http.Client, error) {
+	// the server sets a cookie after authenticating the first request, which
+	// spares authenticating later ones
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create cookie jar: %w", err)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if r.UseTLS {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: r.TLSInsecureSkipVerify}
+		if r.TLSRootCAFile != "" {
+			pem, err := os.ReadFile(r.TLSRootCAFile)
+			if err != nil {
+				return nil, fmt.Errorf("unable to read tlsRootCAFile: %w", err)
+			}
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("unable to parse certificates of tlsRootCAFile %q", r.TLSRootCAFile)
+			}
+			transport.TLSClientConfig.RootCAs = pool
+		}
+	}
+	// queries over data lakes can run for minutes, so requests are bounded by
+	// the context of the invocation rather than a client timeout
+	return &http.Client{Jar: jar, Transport: transport}, nil
+}
+
+func newKerberosClient(r Config) (*client.Client, error) {
+	confPath := r.KerberosConfig
+	if confPath == "" {
+		confPath = os.Getenv("KRB5_CONFIG")
+	}
+	if confPath == "" {
+		confPath = "/etc/krb5.conf"
+	}
+	conf, err := config.Load(confPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load kerberos config %q: %w", confPath, err)
+	}
+
+	var cl *client.Client
+	if r.KerberosKeytab != "" {
+		if r.KerberosPrincipal == "" {
+			return nil, fmt.Errorf("kerberosPrincipal is required with kerberosKeytab")
+		}
+		kt, err := keytab.Load(r.KerberosKeytab)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load kerberos keytab %q: %w", r.KerberosKeytab, err)
+		}
+		user, realm, _ := strings.Cut(r.KerberosPrincipal, "@")
+		if realm == "" {
+			realm = conf.LibDefaults.DefaultRealm
+		}
+		cl = client.NewWithKeytab(user, realm, kt, conf, client.DisablePAFXFAST(true))
+	} else {
+		ccPath := strings.TrimPrefix(os.Getenv("KRB5CCNAME"), "FILE:")
+		if ccPath == "" {
+			return nil, fmt.Errorf("kerberosKeytab or the KRB5CCNAME environment variable is required for kerberos authentication")
+		}
+		cc, err := credentials.LoadCCache(ccPath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load kerberos credential cache %q: %w", ccPath, err)
+		}
+		cl, err = client.NewFromCCache(cc, conf, client.DisablePAFXFAST(true))
+		if err != nil {
+			return nil, fmt.Errorf("unable to create kerberos client: %w", err)
+		}
+	}
+	if err := cl.Login(); err != nil {
+		return nil, fmt.Errorf("unable to log in to kerberos: %w", err)
+	}
+	return cl, nil
+}
+
+var _ sources.Source = &Source{}
+
+type Source struct {
+	Name                     string `yaml:"name"`
+	Kind                     string `yaml:"kind"`
+	URL                      string
+	Database                 string
+	AuthType                 string
+	User                     string
+	Password                 string
+	KerberosServicePrincipal string
+	Client                   *http.Client
+
+	krb5Client *client.Client
+	seqID      atomic.Int32
+}
+
+func (s *Source) SourceKind() string {
+	return SourceKind
+}
+
+// sessionCredentials returns the user and password sessions are opened with.
+func (s *Source) sessionCredentials() (string, string) {
+	if s.AuthType == AuthTypeKerberos {
+		// the user is taken from the kerberos ticket, and only used here to
+		// impersonate another user if set
+		return s.User, ""
+	}
+	user, password := s.User, s.Password
+	// servers without authentication still expect basic credentials
+	if user == "" {
+		user = "anonymous"
+	}
+	if password == "" {
+		password = "anonymous"
+	}
+	return user, password
+}
+
+// HiveQuery runs statement in a new session, and returns the rows of its
+// result set. Statements without a result set return no rows.
+func (s *Source) HiveQuery(ctx context.Context, statement string) ([]any, error) {
+	session, err := s.openSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// sessions and operations hold resources on the server, so they are
+	// closed even if the invocation was canceled
+	cleanupCtx := context.WithoutCancel(ctx)
+	defer func() { _ = s.closeSession(cleanupCtx, session) }()
+
+	op, err := s.executeStatement(ctx, session, statement)
+	if err != nil {
+		return nil, fmt.Errorf("unable to execute query: %w", err)
+	}
+	defer func() { _ = s.closeOperation(cleanupCtx, op) }()
+
+	out := []any{}
+	if !op.boolean(3) {
+		return out, nil
+	}
+	cols, err := s.resultSetMetadata(ctx, op)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get result set metadata: %w", err)
+	}
+	for {
+		rows, err := s.fetchResults(ctx, op, cols)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch results: %w", err)
+		}
+		// HiveServer2 doesn't reliably report whether more rows remain, so
+		// results are fetched until none are returned
+		if len(rows) == 0 {
+			return out, nil
+		}
+		out = append(out, rows...)
+	}
+}
+
+// call calls method of the TCLIService with req as its only argument, and
+// returns the response.
+func (s *Source) call(ctx context.Context, method string, req tStruct) (tFields, error) {
+	var w thriftWriter
+	seqID := s.seqID.Add(1)
+	if err := w.writeMessage(method, messageCall, seqID, tStruct{{1, req}}); err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(w.Bytes()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/x-thrift")
+	httpReq.Header.Set("Accept", "application/x-thrift")
+	switch s.AuthType {
+	case AuthTypeKerberos:
+		// an empty service principal is derived from the host as HTTP/host
+		if err := spnego.SetSPNEGOHeader(s.krb5Client, httpReq, s.KerberosServicePrincipal); err != nil {
+			return nil, fmt.Errorf("failed to set kerberos authorization: %w", err)
+		}
+	default:
+		user, password := s.sessionCredentials()
+		httpReq.SetBasicAuth(user, password)
+	}
+
+	resp, err := s.Client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return nil, fmt.Errorf("unexpected status code: %d, response body: %s", resp.StatusCode, string(body))
+	}
+
+	r := thriftReader{r: resp.Body}
+	name, typ, respSeqID, err := r.readMessage()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	result, err := r.readStruct()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	switch {
+	case typ == messageException:
+		return nil, fmt.Errorf("%s failed: %s", method, result.str(1))
+	case typ != messageReply || name != method || respSeqID != seqID:
+		return nil, fmt.Errorf("unexpected response %q to %s", name, method)
+	}
+	// field 0 of the result is the return value
+	out := result.strct(0)
+	if out == nil {
+		return nil, fmt.Errorf("%s returned no result", method)
+	}
+	return out, nil
+}
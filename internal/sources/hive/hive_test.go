@@ -0,0 +1,214 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hive_test
+
+import (
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources/hive"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+)
+
+func TestParseFromYamlHive(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		want server.SourceConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			sources:
+				my-hive:
+					kind: hive
+					host: 127.0.0.1
+					port: 10001
+			`,
+			want: server.SourceConfigs{
+				"my-hive": hive.Config{
+					Name:     "my-hive",
+					Kind:     hive.SourceKind,
+					Host:     "127.0.0.1",
+					Port:     "10001",
+					HTTPPath: "cliservice",
+					AuthType: hive.AuthTypeNone,
+				},
+			},
+		},
+		{
+			desc: "ldap example",
+			in: `
+			sources:
+				my-hive:
+					kind: hive
+					host: hive.example.com
+					port: 443
+					httpPath: /gateway/default/hive
+					database: sales
+					authType: ldap
+					user: toolbox
+					password: my_pass
+					tls: true
+					tlsRootCAFile: /etc/ssl/ca.pem
+			`,
+			want: server.SourceConfigs{
+				"my-hive": hive.Config{
+					Name:          "my-hive",
+					Kind:          hive.SourceKind,
+					Host:          "hive.example.com",
+					Port:          "443",
+					HTTPPath:      "/gateway/default/hive",
+					Database:      "sales",
+					AuthType:      hive.AuthTypeLDAP,
+					User:          "toolbox",
+					Password:      "my_pass",
+					UseTLS:        true,
+					TLSRootCAFile: "/etc/ssl/ca.pem",
+				},
+			},
+		},
+		{
+			desc: "kerberos example",
+			in: `
+			sources:
+				my-hive:
+					kind: hive
+					host: hive.example.com
+					port: 10001
+					authType: kerberos
+					kerberosPrincipal: toolbox@EXAMPLE.COM
+					kerberosKeytab: /etc/security/toolbox.keytab
+					kerberosServicePrincipal: HTTP/hive.example.com
+			`,
+			want: server.SourceConfigs{
+				"my-hive": hive.Config{
+					Name:                     "my-hive",
+					Kind:                     hive.SourceKind,
+					Host:                     "hive.example.com",
+					Port:                     "10001",
+					HTTPPath:                 "cliservice",
+					AuthType:                 hive.AuthTypeKerberos,
+					KerberosPrincipal:        "toolbox@EXAMPLE.COM",
+					KerberosKeytab:           "/etc/security/toolbox.keytab",
+					KerberosServicePrincipal: "HTTP/hive.example.com",
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if !cmp.Equal(tc.want, got.Sources) {
+				t.Fatalf("incorrect parse: want %v, got %v", tc.want, got.Sources)
+			}
+		})
+	}
+}
+
+func TestFailParseFromYaml(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		err  string
+	}{
+		{
+			desc: "missing required field",
+			in: `
+			sources:
+				my-hive:
+					kind: hive
+					host: 127.0.0.1
+			`,
+			err: "unable to parse source \"my-hive\" as \"hive\": Key: 'Config.Port' Error:Field validation for 'Port' failed on the 'required' tag",
+		},
+		{
+			desc: "ldap without password",
+			in: `
+			sources:
+				my-hive:
+					kind: hive
+					host: 127.0.0.1
+					port: 10001
+					authType: ldap
+					user: toolbox
+			`,
+			err: "unable to parse source \"my-hive\" as \"hive\": Key: 'Config.Password' Error:Field validation for 'Password' failed on the 'required_if' tag",
+		},
+		{
+			desc: "invalid auth type",
+			in: `
+			sources:
+				my-hive:
+					kind: hive
+					host: 127.0.0.1
+					port: 10001
+					authType: plain
+			`,
+			err: "unable to parse source \"my-hive\" as \"hive\": [1:11] Key: 'Config.AuthType' Error:Field validation for 'AuthType' failed on the 'oneof' tag\n>  1 | authType: plain\n                 ^\n   2 | host: 127.0.0.1\n   3 | kind: hive\n   4 | port: 10001",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err == nil {
+				t.Fatalf("expect parsing to fail")
+			}
+			errStr := err.Error()
+			if errStr != tc.err {
+				t.Fatalf("unexpected error: got %q, want %q", errStr, tc.err)
+			}
+		})
+	}
+}
+
+func TestURL(t *testing.T) {
+	tcs := []struct {
+		desc string
+		cfg  hive.Config
+		want string
+	}{
+		{
+			desc: "http",
+			cfg:  hive.Config{Host: "127.0.0.1", Port: "10001", HTTPPath: "cliservice"},
+			want: "http://127.0.0.1:10001/cliservice",
+		},
+		{
+			desc: "https with leading slash",
+			cfg:  hive.Config{Host: "::1", Port: "443", HTTPPath: "/gateway/default/hive", UseTLS: true},
+			want: "https://[::1]:443/gateway/default/hive",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := tc.cfg.URL(); got != tc.want {
+				t.Fatalf("unexpected url: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,295 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hive
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/googleapis/genai-toolbox/internal/util/normalize"
+)
+
+// This file implements the calls of the TCLIService, the Thrift service of
+// HiveServer2 that the Spark Thrift Server also implements.
+
+// protocolV8 is HIVE_CLI_SERVICE_PROTOCOL_V8, the latest protocol version
+// supported by both HiveServer2 and the Spark Thrift Server. Servers answer
+// with the lower of their own and the requested version.
+const protocolV8 int32 = 7
+
+// fetchSize is the number of rows requested by each FetchResults call.
+const fetchSize int64 = 10000
+
+// status codes of TStatus
+const (
+	statusSuccess         int32 = 0
+	statusSuccessWithInfo int32 = 1
+)
+
+// type ids of TTypeId for the types whose values need converting
+const (
+	typeIDBinary  int32 = 9
+	typeIDArray   int32 = 10
+	typeIDMap     int32 = 11
+	typeIDStruct  int32 = 12
+	typeIDUnion   int32 = 13
+	typeIDDecimal int32 = 15
+)
+
+// column is a column of a result set.
+type column struct {
+	name   string
+	typeID int32
+}
+
+// checkStatus returns an error for a TStatus that isn't successful.
+func checkStatus(status tFields) error {
+	if status == nil {
+		return fmt.Errorf("response is missing a status")
+	}
+	switch code := status.i32(1); code {
+	case statusSuccess, statusSuccessWithInfo:
+		return nil
+	default:
+		msg := status.str(5)
+		if msg == "" {
+			msg = fmt.Sprintf("request failed with status code %d", code)
+		}
+		if state := status.str(3); state != "" {
+			msg = fmt.Sprintf("%s (SQLSTATE %s)", msg, state)
+		}
+		return fmt.Errorf("%s", msg)
+	}
+}
+
+// handleIdentifier re-encodes a decoded THandleIdentifier.
+func handleIdentifier(id tFields) tStruct {
+	return tStruct{{1, []byte(id.str(1))}, {2, []byte(id.str(2))}}
+}
+
+func sessionHandle(h tFields) tStruct {
+	return tStruct{{1, handleIdentifier(h.strct(1))}}
+}
+
+func operationHandle(h tFields) tStruct {
+	return tStruct{{1, handleIdentifier(h.strct(1))}, {2, h.i32(2)}, {3, h.boolean(3)}}
+}
+
+// openSession opens a session in database, or the default database if it is
+// empty, and returns its TSessionHandle.
+func (s *Source) openSession(ctx context.Context) (tFields, error) {
+	conf := map[string]string{
+		// name columns "col" rather than "table.col"
+		"set:hiveconf:hive.resultset.use.unique.column.names": "false",
+	}
+	if s.Database != "" {
+		conf["use:database"] = s.Database
+	}
+	user, password := s.sessionCredentials()
+	resp, err := s.call(ctx, "OpenSession", tStruct{
+		{1, protocolV8},
+		{2, user},
+		{3, password},
+		{4, conf},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open session: %w", err)
+	}
+	if err := checkStatus(resp.strct(1)); err != nil {
+		return nil, fmt.Errorf("failed to open session: %w", err)
+	}
+	h := resp.strct(3)
+	if h == nil {
+		return nil, fmt.Errorf("failed to open session: response is missing a session handle")
+	}
+	return h, nil
+}
+
+func (s *Source) closeSession(ctx context.Context, session tFields) error {
+	resp, err := s.call(ctx, "CloseSession", tStruct{{1, sessionHandle(session)}})
+	if err != nil {
+		return err
+	}
+	return checkStatus(resp.strct(1))
+}
+
+func (s *Source) closeOperation(ctx context.Context, op tFields) error {
+	resp, err := s.call(ctx, "CloseOperation", tStruct{{1, operationHandle(op)}})
+	if err != nil {
+		return err
+	}
+	return checkStatus(resp.strct(1))
+}
+
+// executeStatement runs statement synchronously and returns its
+// TOperationHandle.
+func (s *Source) executeStatement(ctx context.Context, session tFields, statement string) (tFields, error) {
+	resp, err := s.call(ctx, "ExecuteStatement", tStruct{
+		{1, sessionHandle(session)},
+		{2, statement},
+		{4, false},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := checkStatus(resp.strct(1)); err != nil {
+		return nil, err
+	}
+	op := resp.strct(2)
+	if op == nil {
+		return nil, fmt.Errorf("response is missing an operation handle")
+	}
+	return op, nil
+}
+
+func (s *Source) resultSetMetadata(ctx context.Context, op tFields) ([]column, error) {
+	resp, err := s.call(ctx, "GetResultSetMetadata", tStruct{{1, operationHandle(op)}})
+	if err != nil {
+		return nil, err
+	}
+	if err := checkStatus(resp.strct(1)); err != nil {
+		return nil, err
+	}
+	var cols []column
+	for _, c := range resp.strct(2).list(1) {
+		desc, _ := c.(tFields)
+		col := column{name: desc.str(1)}
+		// the first entry of a TTypeDesc is the type of the column, and
+		// entries other than primitive ones are complex types
+		if entries := desc.strct(2).list(1); len(entries) > 0 {
+			entry, _ := entries[0].(tFields)
+			switch id, v := entry.union(); id {
+			case 1:
+				p, _ := v.(tFields)
+				col.typeID = p.i32(1)
+			case 2:
+				col.typeID = typeIDArray
+			case 3:
+				col.typeID = typeIDMap
+			case 4:
+				col.typeID = typeIDStruct
+			case 5:
+				col.typeID = typeIDUnion
+			}
+		}
+		cols = append(cols, col)
+	}
+	return cols, nil
+}
+
+// fetchResults fetches the next rows of an operation, and returns no rows
+// once they are exhausted.
+func (s *Source) fetchResults(ctx context.Context, op tFields, cols []column) ([]any, error) {
+	resp, err := s.call(ctx, "FetchResults", tStruct{
+		{1, operationHandle(op)},
+		{2, int32(0)}, // FETCH_NEXT
+		{3, fetchSize},
+		{4, int16(0)}, // query output rather than logs
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := checkStatus(resp.strct(1)); err != nil {
+		return nil, err
+	}
+	return decodeRowSet(resp.strct(3), cols)
+}
+
+// decodeRowSet decodes a TRowSet, which servers send by column from protocol
+// version 6 and by row before.
+func decodeRowSet(rowSet tFields, cols []column) ([]any, error) {
+	if columns := rowSet.list(3); len(columns) > 0 {
+		if len(columns) != len(cols) {
+			return nil, fmt.Errorf("result has %d columns, expected %d", len(columns), len(cols))
+		}
+		var values [][]any
+		var nulls []string
+		for _, c := range columns {
+			col, _ := c.(tFields)
+			_, v := col.union()
+			data, _ := v.(tFields)
+			values = append(values, data.list(1))
+			nulls = append(nulls, data.str(2))
+		}
+		out := make([]any, 0, len(values[0]))
+		for i := range values[0] {
+			row := make(map[string]any, len(cols))
+			for j, col := range cols {
+				if i >= len(values[j]) || isNull(nulls[j], i) {
+					row[col.name] = nil
+					continue
+				}
+				row[col.name] = convertValue(col.typeID, values[j][i])
+			}
+			out = append(out, row)
+		}
+		return out, nil
+	}
+
+	rows := rowSet.list(2)
+	out := make([]any, 0, len(rows))
+	for _, r := range rows {
+		tr, _ := r.(tFields)
+		colVals := tr.list(1)
+		if len(colVals) != len(cols) {
+			return nil, fmt.Errorf("row has %d columns, expected %d", len(colVals), len(cols))
+		}
+		row := make(map[string]any, len(cols))
+		for j, col := range cols {
+			cv, _ := colVals[j].(tFields)
+			_, v := cv.union()
+			value, _ := v.(tFields)
+			if v, ok := value[1]; ok {
+				row[col.name] = convertValue(col.typeID, v)
+			} else {
+				row[col.name] = nil
+			}
+		}
+		out = append(out, row)
+	}
+	return out, nil
+}
+
+// isNull reports whether bit i of the nulls bitmap of a TColumn is set.
+func isNull(bitmap string, i int) bool {
+	return i/8 < len(bitmap) && bitmap[i/8]&(1<<(i%8)) != 0
+}
+
+// convertValue converts values that are sent as strings to the type of their
+// column.
+func convertValue(typeID int32, v any) any {
+	s, ok := v.(string)
+	if !ok {
+		return v
+	}
+	switch typeID {
+	case typeIDDecimal:
+		return normalize.Decimal(s)
+	case typeIDBinary:
+		return []byte(s)
+	case typeIDArray, typeIDMap, typeIDStruct, typeIDUnion:
+		// complex values are sent as JSON
+		var out any
+		d := json.NewDecoder(bytes.NewReader([]byte(s)))
+		d.UseNumber()
+		if err := d.Decode(&out); err != nil {
+			return s
+		}
+		return out
+	}
+	return s
+}
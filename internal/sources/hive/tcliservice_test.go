@@ -0,0 +1,305 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hive
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/util/normalize"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+var okStatus = tStruct{{1, statusSuccess}}
+
+func identifier(guid string) tStruct {
+	return tStruct{{1, []byte(guid)}, {2, []byte("secret")}}
+}
+
+// fakeServer is a HiveServer2 answering every statement with the same
+// result set, by column.
+type fakeServer struct {
+	mu         sync.Mutex
+	statements []string
+	conf       map[any]any
+	open       int
+	fetched    bool
+}
+
+func (f *fakeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if user, password, ok := r.BasicAuth(); !ok || user != "toolbox" || password != "my_pass" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	tr := thriftReader{r: r.Body}
+	name, _, seqID, err := tr.readMessage()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	args, err := tr.readStruct()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	req := args.strct(1)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var resp tStruct
+	switch name {
+	case "OpenSession":
+		f.open++
+		f.conf, _ = req[4].(map[any]any)
+		resp = tStruct{{1, okStatus}, {2, protocolV8}, {3, tStruct{{1, identifier("session")}}}}
+	case "CloseSession", "CloseOperation":
+		if name == "CloseSession" {
+			f.open--
+		}
+		resp = tStruct{{1, okStatus}}
+	case "ExecuteStatement":
+		stmt := req.str(2)
+		f.statements = append(f.statements, stmt)
+		f.fetched = false
+		switch {
+		case strings.HasPrefix(stmt, "SELECT"):
+			resp = tStruct{{1, okStatus}, {2, tStruct{{1, identifier("op")}, {2, int32(0)}, {3, true}}}}
+		case strings.HasPrefix(stmt, "INSERT"):
+			resp = tStruct{{1, okStatus}, {2, tStruct{{1, identifier("op")}, {2, int32(0)}, {3, false}}}}
+		default:
+			resp = tStruct{{1, tStruct{{1, int32(3)}, {3, "42000"}, {5, "Error while compiling statement: FAILED: ParseException"}}}}
+		}
+	case "GetResultSetMetadata":
+		primitive := func(typeID int32) tStruct {
+			return tStruct{{1, []tStruct{{{1, tStruct{{1, typeID}}}}}}}
+		}
+		array := tStruct{{1, []tStruct{{{2, tStruct{{1, int32(1)}}}}, {{1, tStruct{{1, int32(7)}}}}}}}
+		resp = tStruct{{1, okStatus}, {2, tStruct{{1, []tStruct{
+			{{1, "id"}, {2, primitive(3)}, {3, int32(1)}},
+			{{1, "name"}, {2, primitive(7)}, {3, int32(2)}},
+			{{1, "price"}, {2, primitive(typeIDDecimal)}, {3, int32(3)}},
+			{{1, "tags"}, {2, array}, {3, int32(4)}},
+		}}}}}
+	case "FetchResults":
+		rowSet := tStruct{{1, int64(0)}, {2, []tStruct{}}, {3, []tStruct{
+			{{4, tStruct{{1, []int32{}}, {2, []byte{}}}}},
+			{{7, tStruct{{1, []string{}}, {2, []byte{}}}}},
+			{{7, tStruct{{1, []string{}}, {2, []byte{}}}}},
+			{{7, tStruct{{1, []string{}}, {2, []byte{}}}}},
+		}}}
+		if !f.fetched {
+			f.fetched = true
+			rowSet = tStruct{{1, int64(0)}, {2, []tStruct{}}, {3, []tStruct{
+				{{4, tStruct{{1, []int32{1, 2}}, {2, []byte{}}}}},
+				{{7, tStruct{{1, []string{"widget", ""}}, {2, []byte{0b10}}}}},
+				{{7, tStruct{{1, []string{"12345678901234567890.12", "0.50"}}, {2, []byte{}}}}},
+				{{7, tStruct{{1, []string{`["a","b"]`, "[]"}}, {2, []byte{}}}}},
+			}}}
+		}
+		resp = tStruct{{1, okStatus}, {2, false}, {3, rowSet}}
+	default:
+		var ew thriftWriter
+		_ = ew.writeMessage(name, messageException, seqID, tStruct{{1, "Invalid method name: '" + name + "'"}, {2, int32(1)}})
+		_, _ = w.Write(ew.Bytes())
+		return
+	}
+	var rw thriftWriter
+	if err := rw.writeMessage(name, messageReply, seqID, tStruct{{0, resp}}); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-thrift")
+	_, _ = w.Write(rw.Bytes())
+}
+
+func newTestSource(t *testing.T, f *fakeServer) *Source {
+	t.Helper()
+	ts := httptest.NewServer(f)
+	t.Cleanup(ts.Close)
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	host, port, _ := net.SplitHostPort(u.Host)
+	cfg := Config{
+		Name:     "my-hive",
+		Kind:     SourceKind,
+		Host:     host,
+		Port:     port,
+		HTTPPath: "cliservice",
+		Database: "sales",
+		AuthType: AuthTypeLDAP,
+		User:     "toolbox",
+		Password: "my_pass",
+	}
+	s, err := cfg.Initialize(context.Background(), noop.NewTracerProvider().Tracer(""))
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	return s.(*Source)
+}
+
+func TestHiveQuery(t *testing.T) {
+	f := &fakeServer{}
+	s := newTestSource(t, f)
+
+	got, err := s.HiveQuery(context.Background(), "SELECT * FROM products")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := []any{
+		map[string]any{
+			"id":    int32(1),
+			"name":  "widget",
+			"price": normalize.Decimal("12345678901234567890.12"),
+			"tags":  []any{"a", "b"},
+		},
+		map[string]any{
+			"id":    int32(2),
+			"name":  nil,
+			"price": normalize.Decimal("0.50"),
+			"tags":  []any{},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect result: diff %v", diff)
+	}
+	if f.open != 0 {
+		t.Fatalf("sessions left open: %d", f.open)
+	}
+	wantConf := map[any]any{
+		"use:database": "sales",
+		"set:hiveconf:hive.resultset.use.unique.column.names": "false",
+	}
+	if diff := cmp.Diff(wantConf, f.conf); diff != "" {
+		t.Fatalf("incorrect session configuration: diff %v", diff)
+	}
+}
+
+func TestHiveQueryWithoutResultSet(t *testing.T) {
+	s := newTestSource(t, &fakeServer{})
+	got, err := s.HiveQuery(context.Background(), "INSERT INTO products VALUES (3, 'gadget')")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(got) != 0 {
+		t.Fatalf("expected no rows, got %v", got)
+	}
+}
+
+func TestHiveQueryError(t *testing.T) {
+	f := &fakeServer{}
+	s := newTestSource(t, f)
+	_, err := s.HiveQuery(context.Background(), "SELEC 1")
+	want := "unable to execute query: Error while compiling statement: FAILED: ParseException (SQLSTATE 42000)"
+	if err == nil || err.Error() != want {
+		t.Fatalf("unexpected error: got %v, want %q", err, want)
+	}
+	if f.open != 0 {
+		t.Fatalf("sessions left open: %d", f.open)
+	}
+}
+
+func TestInitializeUnauthorized(t *testing.T) {
+	ts := httptest.NewServer(&fakeServer{})
+	defer ts.Close()
+	u, _ := url.Parse(ts.URL)
+	host, port, _ := net.SplitHostPort(u.Host)
+	cfg := Config{Name: "my-hive", Kind: SourceKind, Host: host, Port: port, HTTPPath: "cliservice", AuthType: AuthTypeNone}
+	_, err := cfg.Initialize(context.Background(), noop.NewTracerProvider().Tracer(""))
+	if err == nil || !strings.Contains(err.Error(), "unexpected status code: 401") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestDecodeRowSetByRow(t *testing.T) {
+	cols := []column{{name: "id", typeID: 4}, {name: "info", typeID: typeIDMap}}
+	rowSet := tFields{2: []any{
+		tFields{1: []any{
+			tFields{5: tFields{1: int64(1)}},
+			tFields{7: tFields{1: `{"k":1.5}`}},
+		}},
+		tFields{1: []any{
+			tFields{5: tFields{}},
+			tFields{7: tFields{1: "not json"}},
+		}},
+	}}
+	got, err := decodeRowSet(rowSet, cols)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := []any{
+		map[string]any{"id": int64(1), "info": map[string]any{"k": json.Number("1.5")}},
+		map[string]any{"id": nil, "info": "not json"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect result: diff %v", diff)
+	}
+}
+
+func TestThriftRoundTrip(t *testing.T) {
+	var w thriftWriter
+	in := tStruct{
+		{1, true},
+		{2, int8(-1)},
+		{3, int16(300)},
+		{4, int32(-70000)},
+		{5, int64(1) << 40},
+		{6, 2.5},
+		{7, "text"},
+		{8, map[string]string{"a": "b"}},
+		{9, []int64{1, 2}},
+		{10, []tStruct{{{1, "nested"}}}},
+		{11, nil},
+	}
+	if err := w.writeMessage("Echo", messageCall, 7, in); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	r := thriftReader{r: &w}
+	name, typ, seqID, err := r.readMessage()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if name != "Echo" || typ != messageCall || seqID != 7 {
+		t.Fatalf("unexpected message header: %q %d %d", name, typ, seqID)
+	}
+	got, err := r.readStruct()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := tFields{
+		1:  true,
+		2:  int8(-1),
+		3:  int16(300),
+		4:  int32(-70000),
+		5:  int64(1) << 40,
+		6:  2.5,
+		7:  "text",
+		8:  map[any]any{"a": "b"},
+		9:  []any{int64(1), int64(2)},
+		10: []any{tFields{1: "nested"}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect round trip: diff %v", diff)
+	}
+}
@@ -0,0 +1,430 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hive
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+)
+
+// This file implements the parts of the Thrift binary protocol needed to call
+// the TCLIService of HiveServer2 and the Spark Thrift Server over HTTP.
+
+// Thrift type ids of the binary protocol.
+const (
+	typeStop   byte = 0
+	typeBool   byte = 2
+	typeByte   byte = 3
+	typeDouble byte = 4
+	typeI16    byte = 6
+	typeI32    byte = 8
+	typeI64    byte = 10
+	typeString byte = 11
+	typeStruct byte = 12
+	typeMap    byte = 13
+	typeSet    byte = 14
+	typeList   byte = 15
+)
+
+// Thrift message types.
+const (
+	messageCall      int32 = 1
+	messageReply     int32 = 2
+	messageException int32 = 3
+)
+
+const versionMask uint32 = 0xffff0000
+const version1 uint32 = 0x80010000
+
+// tField is a field of a struct to encode.
+type tField struct {
+	id    int16
+	value any
+}
+
+// tStruct is a struct to encode. Fields with a nil value are skipped, since
+// they are unset optional fields.
+type tStruct []tField
+
+// tFields is a decoded struct, keyed by field id. Strings and binaries are
+// decoded as string, lists and sets as []any and maps as map[any]any.
+type tFields map[int16]any
+
+func (f tFields) str(id int16) string {
+	s, _ := f[id].(string)
+	return s
+}
+
+func (f tFields) i32(id int16) int32 {
+	v, _ := f[id].(int32)
+	return v
+}
+
+func (f tFields) boolean(id int16) bool {
+	v, _ := f[id].(bool)
+	return v
+}
+
+func (f tFields) strct(id int16) tFields {
+	v, _ := f[id].(tFields)
+	return v
+}
+
+func (f tFields) list(id int16) []any {
+	v, _ := f[id].([]any)
+	return v
+}
+
+// union returns the id and value of the field set in a decoded union.
+func (f tFields) union() (int16, any) {
+	for id, v := range f {
+		return id, v
+	}
+	return 0, nil
+}
+
+type thriftWriter struct {
+	bytes.Buffer
+}
+
+func (w *thriftWriter) writeI16(v int16) {
+	_ = binary.Write(w, binary.BigEndian, v)
+}
+
+func (w *thriftWriter) writeI32(v int32) {
+	_ = binary.Write(w, binary.BigEndian, v)
+}
+
+func (w *thriftWriter) writeString(s string) {
+	w.writeI32(int32(len(s)))
+	w.WriteString(s)
+}
+
+func (w *thriftWriter) writeMessage(name string, typ int32, seqID int32, s tStruct) error {
+	w.writeI32(int32(version1 | uint32(typ)))
+	w.writeString(name)
+	w.writeI32(seqID)
+	return w.writeStruct(s)
+}
+
+func (w *thriftWriter) writeStruct(s tStruct) error {
+	for _, f := range s {
+		if f.value == nil {
+			continue
+		}
+		typ, err := thriftType(f.value)
+		if err != nil {
+			return fmt.Errorf("field %d: %w", f.id, err)
+		}
+		w.WriteByte(typ)
+		w.writeI16(f.id)
+		if err := w.writeValue(f.value); err != nil {
+			return fmt.Errorf("field %d: %w", f.id, err)
+		}
+	}
+	w.WriteByte(typeStop)
+	return nil
+}
+
+func (w *thriftWriter) writeList(elemType byte, n int, write func(i int) error) error {
+	w.WriteByte(elemType)
+	w.writeI32(int32(n))
+	for i := 0; i < n; i++ {
+		if err := write(i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *thriftWriter) writeValue(v any) error {
+	switch v := v.(type) {
+	case bool:
+		if v {
+			w.WriteByte(1)
+		} else {
+			w.WriteByte(0)
+		}
+	case int8:
+		w.WriteByte(byte(v))
+	case int16:
+		w.writeI16(v)
+	case int32:
+		w.writeI32(v)
+	case int64:
+		_ = binary.Write(w, binary.BigEndian, v)
+	case float64:
+		_ = binary.Write(w, binary.BigEndian, math.Float64bits(v))
+	case string:
+		w.writeString(v)
+	case []byte:
+		w.writeI32(int32(len(v)))
+		w.Write(v)
+	case tStruct:
+		return w.writeStruct(v)
+	case map[string]string:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		w.WriteByte(typeString)
+		w.WriteByte(typeString)
+		w.writeI32(int32(len(v)))
+		for _, k := range keys {
+			w.writeString(k)
+			w.writeString(v[k])
+		}
+	case []tStruct:
+		return w.writeList(typeStruct, len(v), func(i int) error { return w.writeStruct(v[i]) })
+	case []bool:
+		return w.writeList(typeBool, len(v), func(i int) error { return w.writeValue(v[i]) })
+	case []int8:
+		return w.writeList(typeByte, len(v), func(i int) error { return w.writeValue(v[i]) })
+	case []int16:
+		return w.writeList(typeI16, len(v), func(i int) error { return w.writeValue(v[i]) })
+	case []int32:
+		return w.writeList(typeI32, len(v), func(i int) error { return w.writeValue(v[i]) })
+	case []int64:
+		return w.writeList(typeI64, len(v), func(i int) error { return w.writeValue(v[i]) })
+	case []float64:
+		return w.writeList(typeDouble, len(v), func(i int) error { return w.writeValue(v[i]) })
+	case []string:
+		return w.writeList(typeString, len(v), func(i int) error { return w.writeValue(v[i]) })
+	case [][]byte:
+		return w.writeList(typeString, len(v), func(i int) error { return w.writeValue(v[i]) })
+	default:
+		return fmt.Errorf("unsupported thrift value of type %T", v)
+	}
+	return nil
+}
+
+func thriftType(v any) (byte, error) {
+	switch v.(type) {
+	case bool:
+		return typeBool, nil
+	case int8:
+		return typeByte, nil
+	case int16:
+		return typeI16, nil
+	case int32:
+		return typeI32, nil
+	case int64:
+		return typeI64, nil
+	case float64:
+		return typeDouble, nil
+	case string, []byte:
+		return typeString, nil
+	case tStruct:
+		return typeStruct, nil
+	case map[string]string:
+		return typeMap, nil
+	case []tStruct, []bool, []int8, []int16, []int32, []int64, []float64, []string, [][]byte:
+		return typeList, nil
+	}
+	return 0, fmt.Errorf("unsupported thrift value of type %T", v)
+}
+
+// maxContainerSize bounds the size of strings and containers read from a
+// response, so a malformed response can't exhaust memory.
+const maxContainerSize = 1 << 28
+
+type thriftReader struct {
+	r io.Reader
+}
+
+func (r *thriftReader) read(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := io.ReadFull(r.r, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (r *thriftReader) readByte() (byte, error) {
+	b, err := r.read(1)
+	if err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
+
+func (r *thriftReader) readI16() (int16, error) {
+	b, err := r.read(2)
+	if err != nil {
+		return 0, err
+	}
+	return int16(binary.BigEndian.Uint16(b)), nil
+}
+
+func (r *thriftReader) readI32() (int32, error) {
+	b, err := r.read(4)
+	if err != nil {
+		return 0, err
+	}
+	return int32(binary.BigEndian.Uint32(b)), nil
+}
+
+func (r *thriftReader) readI64() (int64, error) {
+	b, err := r.read(8)
+	if err != nil {
+		return 0, err
+	}
+	return int64(binary.BigEndian.Uint64(b)), nil
+}
+
+func (r *thriftReader) readSize() (int, error) {
+	n, err := r.readI32()
+	if err != nil {
+		return 0, err
+	}
+	if n < 0 || n > maxContainerSize {
+		return 0, fmt.Errorf("invalid size %d", n)
+	}
+	return int(n), nil
+}
+
+func (r *thriftReader) readString() (string, error) {
+	n, err := r.readSize()
+	if err != nil {
+		return "", err
+	}
+	b, err := r.read(n)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// readMessage reads the header of a strict binary protocol message, and
+// returns its name, type and sequence id. The message body is read with
+// readStruct.
+func (r *thriftReader) readMessage() (string, int32, int32, error) {
+	v, err := r.readI32()
+	if err != nil {
+		return "", 0, 0, err
+	}
+	if uint32(v)&versionMask != version1 {
+		return "", 0, 0, fmt.Errorf("unsupported thrift message version %#x", uint32(v)&versionMask)
+	}
+	name, err := r.readString()
+	if err != nil {
+		return "", 0, 0, err
+	}
+	seqID, err := r.readI32()
+	if err != nil {
+		return "", 0, 0, err
+	}
+	return name, int32(uint32(v) &^ versionMask), seqID, nil
+}
+
+func (r *thriftReader) readStruct() (tFields, error) {
+	f := tFields{}
+	for {
+		typ, err := r.readByte()
+		if err != nil {
+			return nil, err
+		}
+		if typ == typeStop {
+			return f, nil
+		}
+		id, err := r.readI16()
+		if err != nil {
+			return nil, err
+		}
+		v, err := r.readValue(typ)
+		if err != nil {
+			return nil, fmt.Errorf("field %d: %w", id, err)
+		}
+		f[id] = v
+	}
+}
+
+func (r *thriftReader) readValue(typ byte) (any, error) {
+	switch typ {
+	case typeBool:
+		b, err := r.readByte()
+		return b != 0, err
+	case typeByte:
+		b, err := r.readByte()
+		return int8(b), err
+	case typeI16:
+		return r.readI16()
+	case typeI32:
+		return r.readI32()
+	case typeI64:
+		return r.readI64()
+	case typeDouble:
+		v, err := r.readI64()
+		return math.Float64frombits(uint64(v)), err
+	case typeString:
+		return r.readString()
+	case typeStruct:
+		return r.readStruct()
+	case typeMap:
+		kt, err := r.readByte()
+		if err != nil {
+			return nil, err
+		}
+		vt, err := r.readByte()
+		if err != nil {
+			return nil, err
+		}
+		n, err := r.readSize()
+		if err != nil {
+			return nil, err
+		}
+		m := make(map[any]any, n)
+		for i := 0; i < n; i++ {
+			k, err := r.readValue(kt)
+			if err != nil {
+				return nil, err
+			}
+			v, err := r.readValue(vt)
+			if err != nil {
+				return nil, err
+			}
+			switch k.(type) {
+			case tFields, []any, map[any]any:
+				return nil, fmt.Errorf("unsupported map key of thrift type %d", kt)
+			}
+			m[k] = v
+		}
+		return m, nil
+	case typeList, typeSet:
+		et, err := r.readByte()
+		if err != nil {
+			return nil, err
+		}
+		n, err := r.readSize()
+		if err != nil {
+			return nil, err
+		}
+		l := make([]any, 0, min(n, 1024))
+		for i := 0; i < n; i++ {
+			v, err := r.readValue(et)
+			if err != nil {
+				return nil, err
+			}
+			l = append(l, v)
+		}
+		return l, nil
+	}
+	return nil, fmt.Errorf("unsupported thrift type %d", typ)
+}
@@ -0,0 +1,170 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const SourceKind string = "kafka"
+
+// SASL mechanisms supported by the source.
+const (
+	SASLPlain       = "PLAIN"
+	SASLScramSHA256 = "SCRAM-SHA-256"
+	SASLScramSHA512 = "SCRAM-SHA-512"
+)
+
+// validate interface
+var _ sources.SourceConfig = Config{}
+
+func init() {
+	if !sources.Register(SourceKind, newConfig) {
+		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name string `yaml:"name" validate:"required"`
+	Kind string `yaml:"kind" validate:"required"`
+	// Brokers are the addresses of the bootstrap brokers, e.g.
+	// localhost:9092. The other brokers of the cluster are discovered from
+	// their metadata.
+	Brokers  []string `yaml:"brokers" validate:"required,min=1"`
+	ClientID string   `yaml:"clientId"`
+	// Timeout bounds each request sent to the brokers, e.g. 10s.
+	Timeout       string `yaml:"timeout"`
+	SASLMechanism string `yaml:"saslMechanism" validate:"required_with=User,omitempty,oneof=PLAIN SCRAM-SHA-256 SCRAM-SHA-512"`
+	User          string `yaml:"user" validate:"required_with=SASLMechanism"`
+	Password      string `yaml:"password"`
+	SSLMode       string `yaml:"sslMode" validate:"required_with=SSLRootCert SSLCert SSLKey,omitempty,oneof=disable require verify-ca verify-full"`
+	SSLRootCert   string `yaml:"sslRootCert"` // Optional, file path or inline PEM
+	SSLCert       string `yaml:"sslCert" validate:"required_with=SSLKey"`
+	SSLKey        string `yaml:"sslKey" validate:"required_with=SSLCert"`
+}
+
+func (r Config) SourceConfigKind() string {
+	return SourceKind
+}
+
+func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	client, err := r.client()
+	if err != nil {
+		return nil, err
+	}
+	if err := initKafkaClient(ctx, tracer, r.Name, client); err != nil {
+		return nil, fmt.Errorf("unable to connect successfully: %w", err)
+	}
+
+	s := &Source{
+		Name:   r.Name,
+		Kind:   SourceKind,
+		Client: client,
+	}
+	return s, nil
+}
+
+var _ sources.Source = &Source{}
+
+type Source struct {
+	Name   string `yaml:"name"`
+	Kind   string `yaml:"kind"`
+	Client *kafka.Client
+}
+
+func (s *Source) SourceKind() string {
+	return SourceKind
+}
+
+// KafkaClient returns the client of the source. Its transport routes each
+// request to the broker leading the partitions it targets, and is shared by
+// the writers of the tools.
+func (s *Source) KafkaClient() *kafka.Client {
+	return s.Client
+}
+
+// client returns the client of the source, whose transport authenticates
+// with SASL and TLS if they are configured.
+func (r Config) client() (*kafka.Client, error) {
+	transport := &kafka.Transport{ClientID: r.ClientID}
+	client := &kafka.Client{
+		Addr:      kafka.TCP(r.Brokers...),
+		Transport: transport,
+	}
+	if r.Timeout != "" {
+		timeout, err := time.ParseDuration(r.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout %q: %w", r.Timeout, err)
+		}
+		client.Timeout = timeout
+		transport.DialTimeout = timeout
+	}
+	if r.SASLMechanism != "" {
+		mechanism, err := saslMechanism(r.SASLMechanism, r.User, r.Password)
+		if err != nil {
+			return nil, err
+		}
+		transport.SASL = mechanism
+	}
+	if r.SSLMode != "" {
+		tlsConfig, err := sources.NewTLSConfig(r.SSLMode, r.SSLRootCert, r.SSLCert, r.SSLKey)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLS = tlsConfig
+	}
+	return client, nil
+}
+
+func saslMechanism(name, user, password string) (sasl.Mechanism, error) {
+	switch name {
+	case SASLPlain:
+		return plain.Mechanism{Username: user, Password: password}, nil
+	case SASLScramSHA256:
+		return scram.Mechanism(scram.SHA256, user, password)
+	case SASLScramSHA512:
+		return scram.Mechanism(scram.SHA512, user, password)
+	}
+	return nil, fmt.Errorf("invalid saslMechanism %q", name)
+}
+
+func initKafkaClient(ctx context.Context, tracer trace.Tracer, name string, client *kafka.Client) error {
+	//nolint:all // Reassigned ctx
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, name)
+	defer span.End()
+
+	// fetching the metadata of the cluster verifies that the brokers are
+	// reachable and that the client is authenticated
+	_, err := client.Metadata(ctx, &kafka.MetadataRequest{})
+	return err
+}
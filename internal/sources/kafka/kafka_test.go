@@ -0,0 +1,197 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka_test
+
+import (
+	"strings"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources/kafka"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestParseFromYamlKafka(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		want server.SourceConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			sources:
+				my-kafka:
+					kind: kafka
+					brokers:
+						- localhost:9092
+			`,
+			want: server.SourceConfigs{
+				"my-kafka": kafka.Config{
+					Name:    "my-kafka",
+					Kind:    kafka.SourceKind,
+					Brokers: []string{"localhost:9092"},
+				},
+			},
+		},
+		{
+			desc: "with sasl and tls",
+			in: `
+			sources:
+				my-kafka:
+					kind: kafka
+					brokers:
+						- broker-1:9093
+						- broker-2:9093
+					clientId: toolbox
+					timeout: 10s
+					saslMechanism: SCRAM-SHA-512
+					user: my_user
+					password: my_pass
+					sslMode: verify-full
+					sslRootCert: /etc/ssl/ca.pem
+			`,
+			want: server.SourceConfigs{
+				"my-kafka": kafka.Config{
+					Name:          "my-kafka",
+					Kind:          kafka.SourceKind,
+					Brokers:       []string{"broker-1:9093", "broker-2:9093"},
+					ClientID:      "toolbox",
+					Timeout:       "10s",
+					SASLMechanism: "SCRAM-SHA-512",
+					User:          "my_user",
+					Password:      "my_pass",
+					SSLMode:       "verify-full",
+					SSLRootCert:   "/etc/ssl/ca.pem",
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Sources); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestFailParseFromYaml(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		err  string
+	}{
+		{
+			desc: "missing brokers",
+			in: `
+			sources:
+				my-kafka:
+					kind: kafka
+			`,
+			err: "Field validation for 'Brokers' failed on the 'required' tag",
+		},
+		{
+			desc: "invalid sasl mechanism",
+			in: `
+			sources:
+				my-kafka:
+					kind: kafka
+					brokers:
+						- localhost:9092
+					saslMechanism: GSSAPI
+					user: my_user
+			`,
+			err: "Field validation for 'SASLMechanism' failed on the 'oneof' tag",
+		},
+		{
+			desc: "sasl mechanism without user",
+			in: `
+			sources:
+				my-kafka:
+					kind: kafka
+					brokers:
+						- localhost:9092
+					saslMechanism: PLAIN
+			`,
+			err: "Field validation for 'User' failed on the 'required_with' tag",
+		},
+		{
+			desc: "invalid sslMode",
+			in: `
+			sources:
+				my-kafka:
+					kind: kafka
+					brokers:
+						- localhost:9092
+					sslMode: fail
+			`,
+			err: "Field validation for 'SSLMode' failed on the 'oneof' tag",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err == nil {
+				t.Fatalf("expect parsing to fail")
+			}
+			if !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("unexpected error: got %q, want it to contain %q", err.Error(), tc.err)
+			}
+		})
+	}
+}
+
+func TestInitializeInvalidConfig(t *testing.T) {
+	tcs := []struct {
+		desc string
+		cfg  kafka.Config
+		err  string
+	}{
+		{
+			desc: "invalid timeout",
+			cfg:  kafka.Config{Name: "my-kafka", Kind: kafka.SourceKind, Brokers: []string{"localhost:9092"}, Timeout: "soon"},
+			err:  `invalid timeout "soon"`,
+		},
+		{
+			desc: "missing client certificate",
+			cfg:  kafka.Config{Name: "my-kafka", Kind: kafka.SourceKind, Brokers: []string{"localhost:9092"}, SSLMode: "require", SSLCert: "/does/not/exist.pem", SSLKey: "/does/not/exist.key"},
+			err:  "unable to load sslCert",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := tc.cfg.Initialize(t.Context(), noop.NewTracerProvider().Tracer(""))
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("unexpected error: got %v, want it to contain %q", err, tc.err)
+			}
+		})
+	}
+}
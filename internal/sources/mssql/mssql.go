@@ -17,12 +17,13 @@ package mssql
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"net/url"
 
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
-	_ "github.com/microsoft/go-mssqldb"
+	mssqldb "github.com/microsoft/go-mssqldb"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -47,14 +48,23 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources
 
 type Config struct {
 	// Cloud SQL MSSQL configs
-	Name     string `yaml:"name" validate:"required"`
-	Kind     string `yaml:"kind" validate:"required"`
-	Host     string `yaml:"host" validate:"required"`
-	Port     string `yaml:"port" validate:"required"`
-	User     string `yaml:"user" validate:"required"`
-	Password string `yaml:"password" validate:"required"`
-	Database string `yaml:"database" validate:"required"`
+	Name string `yaml:"name" validate:"required"`
+	Kind string `yaml:"kind" validate:"required"`
+	// Uri is a sqlserver:// URL or an ADO or ODBC connection string, used
+	// instead of the connection fields below
+	Uri      string `yaml:"uri" validate:"excluded_with=Host Port User Password Database Encrypt Failover"`
+	Host     string `yaml:"host" validate:"required_without=Uri"`
+	Port     string `yaml:"port" validate:"required_without=Uri"`
+	User     string `yaml:"user" validate:"required_without=Uri"`
+	Password string `yaml:"password" validate:"required_without=Uri"`
+	Database string `yaml:"database" validate:"required_without=Uri"`
 	Encrypt  string `yaml:"encrypt"`
+	// Failover are the endpoints to connect to, in order of priority, when
+	// the host is unreachable
+	Failover []sources.Endpoint `yaml:"failover" validate:"dive"`
+	// ReadOnly rejects the statements of execute-sql tools writing to the
+	// database.
+	ReadOnly bool `yaml:"readOnly"`
 }
 
 func (r Config) SourceConfigKind() string {
@@ -64,7 +74,13 @@ func (r Config) SourceConfigKind() string {
 
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
 	// Initializes a MSSQL source
-	db, err := initMssqlConnection(ctx, tracer, r.Name, r.Host, r.Port, r.User, r.Password, r.Database, r.Encrypt)
+	connStrings := []string{r.connectionString()}
+	for _, e := range r.Failover {
+		c := r
+		c.Host, c.Port = e.Host, e.Port
+		connStrings = append(connStrings, c.connectionString())
+	}
+	db, err := initMssqlConnection(ctx, tracer, r.Name, connStrings)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create db connection: %w", err)
 	}
@@ -76,20 +92,26 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 	}
 
 	s := &Source{
-		Name: r.Name,
-		Kind: SourceKind,
-		Db:   db,
+		Name:     r.Name,
+		Kind:     SourceKind,
+		Db:       db,
+		readOnly: r.ReadOnly,
 	}
 	return s, nil
 }
 
 var _ sources.Source = &Source{}
+var _ sources.TableDescriber = &Source{}
+var _ sources.SchemaLister = &Source{}
+var _ sources.ReadOnlySource = &Source{}
 
 type Source struct {
 	// Cloud SQL MSSQL struct with connection pool
 	Name string `yaml:"name"`
 	Kind string `yaml:"kind"`
 	Db   *sql.DB
+
+	readOnly bool
 }
 
 func (s *Source) SourceKind() string {
@@ -97,15 +119,49 @@ func (s *Source) SourceKind() string {
 	return SourceKind
 }
 
+// ReadOnly reports whether the source is configured with readOnly.
+func (s *Source) ReadOnly() bool {
+	return s.readOnly
+}
+
 func (s *Source) MSSQLDB() *sql.DB {
 	// Returns a Cloud SQL MSSQL database connection pool
 	return s.Db
 }
 
+func (s *Source) DescribeTable(ctx context.Context, table string) ([]sources.Column, error) {
+	return sources.DescribeMSSQLTable(ctx, s.Db, table)
+}
+
+func (s *Source) ListSchema(ctx context.Context) ([]sources.Table, error) {
+	return sources.ListMSSQLSchema(ctx, s.Db)
+}
+
+// connectionString returns the Uri of the config, or the URL of its
+// connection fields.
+func (r Config) connectionString() string {
+	if r.Uri != "" {
+		return r.Uri
+	}
+	query := url.Values{}
+	query.Add("database", r.Database)
+	if r.Encrypt != "" {
+		query.Add("encrypt", r.Encrypt)
+	}
+	u := &url.URL{
+		Scheme:   "sqlserver",
+		User:     url.UserPassword(r.User, r.Password),
+		Host:     fmt.Sprintf("%s:%s", r.Host, r.Port),
+		RawQuery: query.Encode(),
+	}
+	return u.String()
+}
+
 func initMssqlConnection(
 	ctx context.Context,
 	tracer trace.Tracer,
-	name, host, port, user, pass, dbname, encrypt string,
+	name string,
+	connStrings []string,
 ) (
 	*sql.DB,
 	error,
@@ -114,24 +170,22 @@ func initMssqlConnection(
 	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, name)
 	defer span.End()
 
-	// Create dsn
-	query := url.Values{}
-	query.Add("database", dbname)
-	if encrypt != "" {
-		query.Add("encrypt", encrypt)
+	if len(connStrings) == 1 {
+		// Open database connection
+		db, err := sql.Open("sqlserver", connStrings[0])
+		if err != nil {
+			return nil, fmt.Errorf("sql.Open: %w", err)
+		}
+		return db, nil
 	}
 
-	url := &url.URL{
-		Scheme:   "sqlserver",
-		User:     url.UserPassword(user, pass),
-		Host:     fmt.Sprintf("%s:%s", host, port),
-		RawQuery: query.Encode(),
-	}
-
-	// Open database connection
-	db, err := sql.Open("sqlserver", url.String())
-	if err != nil {
-		return nil, fmt.Errorf("sql.Open: %w", err)
+	connectors := make([]driver.Connector, 0, len(connStrings))
+	for _, connString := range connStrings {
+		connector, err := mssqldb.NewConnector(connString)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create connector: %w", err)
+		}
+		connectors = append(connectors, connector)
 	}
-	return db, nil
+	return sql.OpenDB(sources.NewFailoverConnector(connectors...)), nil
 }
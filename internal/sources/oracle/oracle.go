@@ -0,0 +1,176 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oracle
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	_ "github.com/sijms/go-ora/v2"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const SourceKind string = "oracle"
+
+// validate interface
+var _ sources.SourceConfig = Config{}
+
+func init() {
+	if !sources.Register(SourceKind, newConfig) {
+		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name string `yaml:"name" validate:"required"`
+	Kind string `yaml:"kind" validate:"required"`
+	// ConnectionString is an Oracle connect descriptor, such as
+	// "(DESCRIPTION=(ADDRESS=(PROTOCOL=TCP)(HOST=db)(PORT=1521))(CONNECT_DATA=(SERVICE_NAME=FREEPDB1)))",
+	// used instead of the host, port and service name
+	ConnectionString string `yaml:"connectionString" validate:"excluded_with=Host Port ServiceName"`
+	Host             string `yaml:"host" validate:"required_without=ConnectionString"`
+	Port             string `yaml:"port" validate:"required_without=ConnectionString"`
+	ServiceName      string `yaml:"serviceName" validate:"required_without=ConnectionString"`
+	User             string `yaml:"user" validate:"required"`
+	Password         string `yaml:"password" validate:"required"`
+	UseSSL           bool   `yaml:"ssl"`
+	// ReadOnly rejects the statements of execute-sql tools writing to the
+	// database.
+	ReadOnly bool `yaml:"readOnly"`
+}
+
+func (r Config) SourceConfigKind() string {
+	return SourceKind
+}
+
+func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	db, err := initOracleConnection(ctx, tracer, r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create db connection: %w", err)
+	}
+
+	err = db.PingContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect successfully: %w", err)
+	}
+
+	s := &Source{
+		Name:     r.Name,
+		Kind:     SourceKind,
+		Db:       db,
+		readOnly: r.ReadOnly,
+	}
+	return s, nil
+}
+
+var _ sources.Source = &Source{}
+var _ sources.TableDescriber = &Source{}
+var _ sources.ReadOnlySource = &Source{}
+
+type Source struct {
+	Name string `yaml:"name"`
+	Kind string `yaml:"kind"`
+	Db   *sql.DB
+
+	readOnly bool
+}
+
+func (s *Source) SourceKind() string {
+	return SourceKind
+}
+
+// ReadOnly reports whether the source is configured with readOnly.
+func (s *Source) ReadOnly() bool {
+	return s.readOnly
+}
+
+func (s *Source) OracleDB() *sql.DB {
+	return s.Db
+}
+
+// columnsQuery lists the columns of a table, in the current schema when the
+// schema is empty, which Oracle treats as NULL.
+const columnsQuery = `SELECT COLUMN_NAME, DATA_TYPE FROM ALL_TAB_COLUMNS
+WHERE TABLE_NAME = :1 AND OWNER = COALESCE(:2, SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA'))
+ORDER BY COLUMN_ID`
+
+func (s *Source) DescribeTable(ctx context.Context, table string) ([]sources.Column, error) {
+	schema, name := sources.SplitTableName(table)
+	return sources.DescribeTableSQL(ctx, s.Db, columnsQuery, name, schema)
+}
+
+// plsqlBlock matches the start of PL/SQL blocks, and of statements creating
+// PL/SQL units, whose final semicolon is part of them.
+var plsqlBlock = regexp.MustCompile(`(?is)^\s*(BEGIN|DECLARE|CREATE\s+(OR\s+REPLACE\s+)?((NON)?EDITIONABLE\s+)?(FUNCTION|PROCEDURE|PACKAGE|TRIGGER|TYPE))\b`)
+
+// TrimStatement removes the semicolons ending a SQL statement, which Oracle
+// rejects. PL/SQL blocks are returned unchanged.
+func TrimStatement(statement string) string {
+	if plsqlBlock.MatchString(statement) {
+		return statement
+	}
+	return strings.TrimRight(statement, " \t\r\n;")
+}
+
+// DSN returns the go-ora URL of the source.
+func (r Config) DSN() string {
+	query := url.Values{}
+	// the driver requires a port even when the servers are in the connect
+	// descriptor
+	host := ":0"
+	if r.ConnectionString != "" {
+		query.Add("connStr", r.ConnectionString)
+	} else {
+		host = net.JoinHostPort(r.Host, r.Port)
+	}
+	if r.UseSSL {
+		query.Add("SSL", "TRUE")
+	}
+	u := &url.URL{
+		Scheme:   "oracle",
+		User:     url.UserPassword(r.User, r.Password),
+		Host:     host,
+		Path:     "/" + r.ServiceName,
+		RawQuery: query.Encode(),
+	}
+	return u.String()
+}
+
+func initOracleConnection(ctx context.Context, tracer trace.Tracer, r Config) (*sql.DB, error) {
+	//nolint:all // Reassigned ctx
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, r.Name)
+	defer span.End()
+
+	db, err := sql.Open("oracle", r.DSN())
+	if err != nil {
+		return nil, fmt.Errorf("sql.Open: %w", err)
+	}
+	return db, nil
+}
@@ -0,0 +1,151 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// replicaCheckInterval is how long the health of a replica is trusted before
+// it is checked again.
+const replicaCheckInterval = 10 * time.Second
+
+// replicaPingTimeout bounds the health check of a replica, so that a read is
+// not held up by an unreachable replica for longer.
+const replicaPingTimeout = 2 * time.Second
+
+// Replica is a read replica of the primary database. It is connected to with
+// the same database, credentials and TLS options as the primary.
+type Replica struct {
+	Host string `yaml:"host" validate:"required"`
+	Port string `yaml:"port" validate:"required"`
+}
+
+// replica is the pool of a Replica and its last known health.
+type replica struct {
+	pool *pgxpool.Pool
+
+	mu      sync.Mutex
+	checked time.Time
+	healthy bool
+}
+
+// isHealthy reports whether the replica answered its last health check,
+// checking it again if that is older than replicaCheckInterval.
+func (r *replica) isHealthy(ctx context.Context) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if time.Since(r.checked) < replicaCheckInterval {
+		return r.healthy
+	}
+	ctx, cancel := context.WithTimeout(ctx, replicaPingTimeout)
+	defer cancel()
+	r.healthy = r.pool.Ping(ctx) == nil
+	r.checked = time.Now()
+	return r.healthy
+}
+
+// replicaSet balances reads across the healthy replicas.
+type replicaSet struct {
+	replicas []*replica
+	next     atomic.Uint64
+}
+
+// pool returns the pool of the next healthy replica in round-robin order, or
+// nil if no replica is healthy.
+func (rs *replicaSet) pool(ctx context.Context) *pgxpool.Pool {
+	n := uint64(len(rs.replicas))
+	if n == 0 {
+		return nil
+	}
+	start := rs.next.Add(1)
+	for i := uint64(0); i < n; i++ {
+		r := rs.replicas[(start+i)%n]
+		if r.isHealthy(ctx) {
+			return r.pool
+		}
+	}
+	return nil
+}
+
+func (rs *replicaSet) close() {
+	for _, r := range rs.replicas {
+		r.pool.Close()
+	}
+}
+
+// newReplicaSet creates the pools of replicas, from the config of the pool of
+// the primary.
+func newReplicaSet(ctx context.Context, primary *pgxpool.Config, replicas []Replica) (*replicaSet, error) {
+	rs := &replicaSet{}
+	for _, r := range replicas {
+		config, err := replicaConfig(primary, r)
+		if err != nil {
+			rs.close()
+			return nil, err
+		}
+		pool, err := pgxpool.NewWithConfig(ctx, config)
+		if err != nil {
+			rs.close()
+			return nil, fmt.Errorf("unable to create connection pool for replica %s:%s: %w", r.Host, r.Port, err)
+		}
+		rs.replicas = append(rs.replicas, &replica{pool: pool})
+	}
+	return rs, nil
+}
+
+// replicaConfig returns the pool config of the primary with its host replaced
+// by the host of r, keeping the TLS options of the primary host.
+func replicaConfig(primary *pgxpool.Config, r Replica) (*pgxpool.Config, error) {
+	port, err := strconv.ParseUint(r.Port, 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("invalid port %q of replica %q", r.Port, r.Host)
+	}
+	withServerName := func(c *tls.Config) *tls.Config {
+		if c == nil {
+			return nil
+		}
+		c = c.Clone()
+		if c.ServerName != "" {
+			c.ServerName = r.Host
+		}
+		return c
+	}
+
+	config := primary.Copy()
+	cc := config.ConnConfig
+	// the fallbacks of the primary host are its TLS fallbacks, e.g. of the
+	// "prefer" sslmode, while the others are of additional hosts
+	fallbacks := []*pgconn.FallbackConfig{}
+	for _, f := range cc.Fallbacks {
+		if f.Host == cc.Host && f.Port == cc.Port {
+			fallbacks = append(fallbacks, &pgconn.FallbackConfig{Host: r.Host, Port: uint16(port), TLSConfig: withServerName(f.TLSConfig)})
+		}
+	}
+	cc.Fallbacks = fallbacks
+	cc.Host = r.Host
+	cc.Port = uint16(port)
+	cc.TLSConfig = withServerName(cc.TLSConfig)
+	return config, nil
+}
@@ -0,0 +1,191 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const SourceKind string = "prometheus"
+
+// validate interface
+var _ sources.SourceConfig = Config{}
+
+func init() {
+	if !sources.Register(SourceKind, newConfig) {
+		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name, Timeout: "30s"} // Default timeout
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name     string            `yaml:"name" validate:"required"`
+	Kind     string            `yaml:"kind" validate:"required"`
+	URL      string            `yaml:"url" validate:"required"`
+	Timeout  string            `yaml:"timeout"`
+	Headers  map[string]string `yaml:"headers"`
+	User     string            `yaml:"user"`
+	Password string            `yaml:"password"`
+}
+
+func (r Config) SourceConfigKind() string {
+	return SourceKind
+}
+
+func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	client, err := initPrometheusClient(ctx, tracer, r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create client: %w", err)
+	}
+
+	// Verify the server is reachable and the credentials are accepted
+	_, err = client.Get(ctx, "/api/v1/status/buildinfo", nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect successfully: %w", err)
+	}
+
+	s := &Source{
+		Name:   r.Name,
+		Kind:   SourceKind,
+		Client: client,
+	}
+	return s, nil
+}
+
+var _ sources.Source = &Source{}
+
+type Source struct {
+	Name   string `yaml:"name"`
+	Kind   string `yaml:"kind"`
+	Client *Client
+}
+
+func (s *Source) SourceKind() string {
+	return SourceKind
+}
+
+func (s *Source) PrometheusClient() *Client {
+	return s.Client
+}
+
+// Client sends requests to the Prometheus HTTP API.
+type Client struct {
+	BaseURL    string
+	Headers    map[string]string
+	User       string
+	Password   string
+	HTTPClient *http.Client
+}
+
+// apiResponse is the envelope returned by every Prometheus HTTP API endpoint.
+type apiResponse struct {
+	Status    string          `json:"status"`
+	Data      json.RawMessage `json:"data"`
+	ErrorType string          `json:"errorType"`
+	Error     string          `json:"error"`
+	Warnings  []string        `json:"warnings"`
+}
+
+// Get calls the API endpoint at path and returns the `data` field of the
+// response.
+func (c *Client) Get(ctx context.Context, path string, params url.Values) (json.RawMessage, error) {
+	u := strings.TrimSuffix(c.BaseURL, "/") + path
+	if len(params) > 0 {
+		u += "?" + params.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create request: %w", err)
+	}
+	for k, v := range c.Headers {
+		req.Header.Set(k, v)
+	}
+	if c.User != "" {
+		req.SetBasicAuth(c.User, c.Password)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error making HTTP request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read response body: %w", err)
+	}
+
+	var r apiResponse
+	if err := json.Unmarshal(body, &r); err != nil {
+		// errors from proxies in front of Prometheus aren't JSON
+		return nil, fmt.Errorf("unexpected response with status %d: %s", resp.StatusCode, string(body))
+	}
+	if r.Status != "success" {
+		return nil, fmt.Errorf("%s: %s", r.ErrorType, r.Error)
+	}
+	return r.Data, nil
+}
+
+func initPrometheusClient(ctx context.Context, tracer trace.Tracer, r Config) (*Client, error) {
+	//nolint:all // Reassigned ctx
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, r.Name)
+	defer span.End()
+
+	duration, err := time.ParseDuration(r.Timeout)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse Timeout string as time.Duration: %s", err)
+	}
+
+	if _, err := url.ParseRequestURI(r.URL); err != nil {
+		return nil, fmt.Errorf("failed to parse url %v", err)
+	}
+
+	ua, err := util.UserAgentFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	headers := map[string]string{"User-Agent": ua}
+	for k, v := range r.Headers {
+		headers[k] = v
+	}
+
+	client := &Client{
+		BaseURL:    r.URL,
+		Headers:    headers,
+		User:       r.User,
+		Password:   r.Password,
+		HTTPClient: &http.Client{Timeout: duration},
+	}
+	return client, nil
+}
@@ -0,0 +1,134 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s3_test
+
+import (
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources/s3"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+)
+
+func TestParseFromYamlS3(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		want server.SourceConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			sources:
+				my-s3:
+					kind: s3
+					region: us-east-1
+			`,
+			want: server.SourceConfigs{
+				"my-s3": s3.Config{
+					Name:   "my-s3",
+					Kind:   s3.SourceKind,
+					Region: "us-east-1",
+				},
+			},
+		},
+		{
+			desc: "minio example",
+			in: `
+			sources:
+				my-minio:
+					kind: s3
+					region: us-east-1
+					endpoint: http://127.0.0.1:9000
+					accessKeyId: minioadmin
+					secretAccessKey: minioadmin
+					usePathStyle: true
+			`,
+			want: server.SourceConfigs{
+				"my-minio": s3.Config{
+					Name:            "my-minio",
+					Kind:            s3.SourceKind,
+					Region:          "us-east-1",
+					Endpoint:        "http://127.0.0.1:9000",
+					AccessKeyId:     "minioadmin",
+					SecretAccessKey: "minioadmin",
+					UsePathStyle:    true,
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if !cmp.Equal(tc.want, got.Sources) {
+				t.Fatalf("incorrect parse: want %v, got %v", tc.want, got.Sources)
+			}
+		})
+	}
+}
+
+func TestFailParseFromYaml(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		err  string
+	}{
+		{
+			desc: "extra field",
+			in: `
+			sources:
+				my-s3:
+					kind: s3
+					region: us-east-1
+					foo: bar
+			`,
+			err: "unable to parse source \"my-s3\" as \"s3\": [1:1] unknown field \"foo\"\n>  1 | foo: bar\n       ^\n   2 | kind: s3\n   3 | region: us-east-1",
+		},
+		{
+			desc: "missing required field",
+			in: `
+			sources:
+				my-s3:
+					kind: s3
+			`,
+			err: "unable to parse source \"my-s3\" as \"s3\": Key: 'Config.Region' Error:Field validation for 'Region' failed on the 'required' tag",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err == nil {
+				t.Fatalf("expect parsing to fail")
+			}
+			errStr := err.Error()
+			if errStr != tc.err {
+				t.Fatalf("unexpected error: got %q, want %q", errStr, tc.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package saphana
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+
+	hdb "github.com/SAP/go-hdb/driver"
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const SourceKind string = "saphana"
+
+// validate interface
+var _ sources.SourceConfig = Config{}
+
+func init() {
+	if !sources.Register(SourceKind, newConfig) {
+		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name     string `yaml:"name" validate:"required"`
+	Kind     string `yaml:"kind" validate:"required"`
+	Host     string `yaml:"host" validate:"required"`
+	Port     string `yaml:"port" validate:"required"`
+	User     string `yaml:"user" validate:"required"`
+	Password string `yaml:"password" validate:"required"`
+	// FailoverHosts are "host:port" addresses tried in order when the
+	// primary host can't be reached, such as the hosts of a system
+	// replication secondary.
+	FailoverHosts         []string `yaml:"failoverHosts"`
+	UseTLS                bool     `yaml:"tls"`
+	TLSServerName         string   `yaml:"tlsServerName"`
+	TLSRootCAFile         string   `yaml:"tlsRootCAFile"`
+	TLSInsecureSkipVerify bool     `yaml:"tlsInsecureSkipVerify"`
+}
+
+func (r Config) SourceConfigKind() string {
+	return SourceKind
+}
+
+func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	db, err := initSAPHANAConnection(ctx, tracer, r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create db connection: %w", err)
+	}
+
+	err = db.PingContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect successfully: %w", err)
+	}
+
+	s := &Source{
+		Name: r.Name,
+		Kind: SourceKind,
+		Db:   db,
+	}
+	return s, nil
+}
+
+var _ sources.Source = &Source{}
+var _ sources.TableDescriber = &Source{}
+
+type Source struct {
+	Name string `yaml:"name"`
+	Kind string `yaml:"kind"`
+	Db   *sql.DB
+}
+
+func (s *Source) SourceKind() string {
+	return SourceKind
+}
+
+func (s *Source) SAPHANADB() *sql.DB {
+	return s.Db
+}
+
+// columnsQuery lists the columns of a table, in the current schema when the
+// schema is empty.
+const columnsQuery = `SELECT COLUMN_NAME, DATA_TYPE_NAME FROM SYS.TABLE_COLUMNS
+WHERE TABLE_NAME = ? AND SCHEMA_NAME = COALESCE(NULLIF(?, ''), CURRENT_SCHEMA)
+ORDER BY POSITION`
+
+func (s *Source) DescribeTable(ctx context.Context, table string) ([]sources.Column, error) {
+	schema, name := sources.SplitTableName(table)
+	return sources.DescribeTableSQL(ctx, s.Db, columnsQuery, name, schema)
+}
+
+func initSAPHANAConnection(ctx context.Context, tracer trace.Tracer, r Config) (*sql.DB, error) {
+	//nolint:all // Reassigned ctx
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, r.Name)
+	defer span.End()
+
+	var tlsConfig *tls.Config
+	if r.UseTLS {
+		tlsConfig = &tls.Config{
+			ServerName:         r.TLSServerName,
+			InsecureSkipVerify: r.TLSInsecureSkipVerify,
+		}
+		if r.TLSRootCAFile != "" {
+			pem, err := os.ReadFile(r.TLSRootCAFile)
+			if err != nil {
+				return nil, fmt.Errorf("unable to read tlsRootCAFile: %w", err)
+			}
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("unable to parse certificates of tlsRootCAFile %q", r.TLSRootCAFile)
+			}
+			tlsConfig.RootCAs = pool
+		}
+	}
+
+	hosts := append([]string{net.JoinHostPort(r.Host, r.Port)}, r.FailoverHosts...)
+	c := &failoverConnector{}
+	for _, host := range hosts {
+		if _, _, err := net.SplitHostPort(host); err != nil {
+			return nil, fmt.Errorf("invalid failover host %q: %w", host, err)
+		}
+		connector := hdb.NewBasicAuthConnector(host, r.User, r.Password)
+		if tlsConfig != nil {
+			// the server name defaults to the host being connected to
+			hostConfig := tlsConfig.Clone()
+			if hostConfig.ServerName == "" {
+				hostConfig.ServerName, _, _ = net.SplitHostPort(host)
+			}
+			if err := connector.SetTLSConfig(hostConfig); err != nil {
+				return nil, fmt.Errorf("unable to set tls config: %w", err)
+			}
+		}
+		c.hosts = append(c.hosts, host)
+		c.connectors = append(c.connectors, connector)
+	}
+	return sql.OpenDB(c), nil
+}
+
+// failoverConnector opens connections to the first of its hosts that can be
+// reached. The primary host is always tried first, so new connections return
+// to it once it recovers.
+type failoverConnector struct {
+	hosts      []string
+	connectors []*hdb.Connector
+}
+
+func (c *failoverConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	var errs []error
+	for i, connector := range c.connectors {
+		conn, err := connector.Connect(ctx)
+		if err == nil {
+			return conn, nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", c.hosts[i], err))
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, errors.Join(errs...)
+}
+
+func (c *failoverConnector) Driver() driver.Driver {
+	return c.connectors[0].Driver()
+}
@@ -0,0 +1,191 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package saphana_test
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources/saphana"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestParseFromYamlSAPHANA(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		want server.SourceConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			sources:
+				my-hana-instance:
+					kind: saphana
+					host: 0.0.0.0
+					port: 30015
+					user: my_user
+					password: my_pass
+			`,
+			want: server.SourceConfigs{
+				"my-hana-instance": saphana.Config{
+					Name:     "my-hana-instance",
+					Kind:     saphana.SourceKind,
+					Host:     "0.0.0.0",
+					Port:     "30015",
+					User:     "my_user",
+					Password: "my_pass",
+				},
+			},
+		},
+		{
+			desc: "tls and failover hosts",
+			in: `
+			sources:
+				my-hana-instance:
+					kind: saphana
+					host: hana-primary.example.com
+					port: 443
+					user: my_user
+					password: my_pass
+					failoverHosts:
+						- hana-secondary.example.com:443
+					tls: true
+					tlsRootCAFile: /etc/ssl/hana.pem
+			`,
+			want: server.SourceConfigs{
+				"my-hana-instance": saphana.Config{
+					Name:          "my-hana-instance",
+					Kind:          saphana.SourceKind,
+					Host:          "hana-primary.example.com",
+					Port:          "443",
+					User:          "my_user",
+					Password:      "my_pass",
+					FailoverHosts: []string{"hana-secondary.example.com:443"},
+					UseTLS:        true,
+					TLSRootCAFile: "/etc/ssl/hana.pem",
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if !cmp.Equal(tc.want, got.Sources) {
+				t.Fatalf("incorrect parse: want %v, got %v", tc.want, got.Sources)
+			}
+		})
+	}
+}
+
+func TestFailParseFromYaml(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		err  string
+	}{
+		{
+			desc: "missing required field",
+			in: `
+			sources:
+				my-hana-instance:
+					kind: saphana
+					host: 0.0.0.0
+					port: 30015
+					user: my_user
+			`,
+			err: "unable to parse source \"my-hana-instance\" as \"saphana\": Key: 'Config.Password' Error:Field validation for 'Password' failed on the 'required' tag",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err == nil {
+				t.Fatalf("expect parsing to fail")
+			}
+			errStr := err.Error()
+			if errStr != tc.err {
+				t.Fatalf("unexpected error: got %q, want %q", errStr, tc.err)
+			}
+		})
+	}
+}
+
+// unusedAddr returns the address of a port nothing listens on.
+func unusedAddr(t *testing.T) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unable to listen: %s", err)
+	}
+	addr := l.Addr().String()
+	l.Close()
+	return addr
+}
+
+func TestInitializeTriesFailoverHosts(t *testing.T) {
+	primary, secondary := unusedAddr(t), unusedAddr(t)
+	host, port, _ := net.SplitHostPort(primary)
+	cfg := saphana.Config{
+		Name:          "my-hana-instance",
+		Kind:          saphana.SourceKind,
+		Host:          host,
+		Port:          port,
+		User:          "my_user",
+		Password:      "my_pass",
+		FailoverHosts: []string{secondary},
+	}
+	_, err := cfg.Initialize(context.Background(), noop.NewTracerProvider().Tracer("test"))
+	if err == nil {
+		t.Fatalf("expected initialization to fail")
+	}
+	for _, addr := range []string{primary, secondary} {
+		if !strings.Contains(err.Error(), addr) {
+			t.Errorf("expected error to mention %s, got %q", addr, err)
+		}
+	}
+}
+
+func TestInitializeInvalidFailoverHost(t *testing.T) {
+	cfg := saphana.Config{
+		Name:          "my-hana-instance",
+		Kind:          saphana.SourceKind,
+		Host:          "127.0.0.1",
+		Port:          "30015",
+		User:          "my_user",
+		Password:      "my_pass",
+		FailoverHosts: []string{"hana-secondary"},
+	}
+	_, err := cfg.Initialize(context.Background(), noop.NewTracerProvider().Tracer("test"))
+	if err == nil || !strings.Contains(err.Error(), `invalid failover host "hana-secondary"`) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
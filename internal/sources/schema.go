@@ -0,0 +1,259 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sources
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Column describes a column of a table.
+type Column struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Comment string `json:"comment,omitempty"`
+	// PrimaryKey, Nullable and Generated are only set by SchemaLister.
+	PrimaryKey bool `json:"primaryKey,omitempty"`
+	Nullable   bool `json:"nullable,omitempty"`
+	// Generated is whether the database sets the column when it is omitted
+	// from an insert, from a default value, an identity or a computation.
+	Generated bool `json:"generated,omitempty"`
+}
+
+// Table describes a table and its columns.
+type Table struct {
+	Schema  string   `json:"schema,omitempty"`
+	Name    string   `json:"name"`
+	Comment string   `json:"comment,omitempty"`
+	Columns []Column `json:"columns"`
+}
+
+// QualifiedName returns the name of the table qualified with its schema.
+func (t Table) QualifiedName() string {
+	if t.Schema == "" {
+		return t.Name
+	}
+	return t.Schema + "." + t.Name
+}
+
+// TableDescriber is implemented by sources able to list the columns of their
+// tables from the database metadata.
+type TableDescriber interface {
+	// DescribeTable returns the columns of table, which may be qualified with
+	// a schema name. No columns are returned if the table doesn't exist.
+	DescribeTable(ctx context.Context, table string) ([]Column, error)
+}
+
+// SchemaLister is implemented by sources able to list all of their user
+// tables, with their columns and comments, from the database metadata.
+type SchemaLister interface {
+	// ListSchema returns the tables of the database visible to the source.
+	ListSchema(ctx context.Context) ([]Table, error)
+}
+
+// SplitTableName splits a possibly schema qualified table name into its
+// schema and table parts. The schema is empty if the name is unqualified.
+func SplitTableName(name string) (schema, table string) {
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		return name[:i], name[i+1:]
+	}
+	return "", name
+}
+
+// DescribeTableSQL runs a query selecting the name and type of columns and
+// returns them in order.
+func DescribeTableSQL(ctx context.Context, db *sql.DB, query string, args ...any) ([]Column, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query columns: %w", err)
+	}
+	defer rows.Close()
+
+	var cols []Column
+	for rows.Next() {
+		var c Column
+		if err := rows.Scan(&c.Name, &c.Type); err != nil {
+			return nil, fmt.Errorf("unable to scan column: %w", err)
+		}
+		cols = append(cols, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("unable to query columns: %w", err)
+	}
+	return cols, nil
+}
+
+// postgresColumnsQuery lists the columns of a table, looking it up in the
+// search path when the schema is empty.
+const postgresColumnsQuery = `SELECT column_name, data_type FROM information_schema.columns
+WHERE table_name = $1 AND (($2 = '' AND table_schema = ANY(current_schemas(false))) OR table_schema = $2)
+ORDER BY table_schema, ordinal_position`
+
+// DescribePostgresTable returns the columns of a PostgreSQL table.
+func DescribePostgresTable(ctx context.Context, pool *pgxpool.Pool, name string) ([]Column, error) {
+	schema, table := SplitTableName(name)
+	rows, err := pool.Query(ctx, postgresColumnsQuery, table, schema)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query columns: %w", err)
+	}
+	defer rows.Close()
+
+	var cols []Column
+	for rows.Next() {
+		var c Column
+		if err := rows.Scan(&c.Name, &c.Type); err != nil {
+			return nil, fmt.Errorf("unable to scan column: %w", err)
+		}
+		cols = append(cols, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("unable to query columns: %w", err)
+	}
+	return cols, nil
+}
+
+// mysqlColumnsQuery lists the columns of a table, in the current database
+// when the schema is empty.
+const mysqlColumnsQuery = `SELECT column_name, column_type FROM information_schema.columns
+WHERE table_name = ? AND table_schema = COALESCE(NULLIF(?, ''), DATABASE())
+ORDER BY ordinal_position`
+
+// DescribeMySQLTable returns the columns of a MySQL compatible table.
+func DescribeMySQLTable(ctx context.Context, db *sql.DB, name string) ([]Column, error) {
+	schema, table := SplitTableName(name)
+	return DescribeTableSQL(ctx, db, mysqlColumnsQuery, table, schema)
+}
+
+// mssqlColumnsQuery lists the columns of a table, in the default schema of
+// the user when the schema is empty.
+const mssqlColumnsQuery = `SELECT column_name, data_type FROM information_schema.columns
+WHERE table_name = @table AND table_schema = COALESCE(NULLIF(@schema, ''), SCHEMA_NAME())
+ORDER BY ordinal_position`
+
+// DescribeMSSQLTable returns the columns of a SQL Server table.
+func DescribeMSSQLTable(ctx context.Context, db *sql.DB, name string) ([]Column, error) {
+	schema, table := SplitTableName(name)
+	return DescribeTableSQL(ctx, db, mssqlColumnsQuery, sql.Named("table", table), sql.Named("schema", schema))
+}
+
+// schemaRows are the rows of a query listing the columns of tables, which
+// is implemented by both database/sql and pgx rows.
+type schemaRows interface {
+	Next() bool
+	Scan(dest ...any) error
+	Err() error
+}
+
+// scanSchema groups rows selecting the schema, name and comment of a table,
+// then the name, type, comment, primary key, nullable and generated flags of
+// a column, into tables. The rows must be ordered by table.
+func scanSchema(rows schemaRows) ([]Table, error) {
+	var tables []Table
+	for rows.Next() {
+		var t Table
+		var c Column
+		if err := rows.Scan(&t.Schema, &t.Name, &t.Comment, &c.Name, &c.Type, &c.Comment, &c.PrimaryKey, &c.Nullable, &c.Generated); err != nil {
+			return nil, fmt.Errorf("unable to scan column: %w", err)
+		}
+		if n := len(tables); n == 0 || tables[n-1].Schema != t.Schema || tables[n-1].Name != t.Name {
+			tables = append(tables, t)
+		}
+		last := &tables[len(tables)-1]
+		last.Columns = append(last.Columns, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("unable to query schema: %w", err)
+	}
+	return tables, nil
+}
+
+// ListSchemaSQL runs a query selecting the columns of tables as expected by
+// scanSchema and returns the tables.
+func ListSchemaSQL(ctx context.Context, db *sql.DB, query string, args ...any) ([]Table, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query schema: %w", err)
+	}
+	defer rows.Close()
+	return scanSchema(rows)
+}
+
+// postgresSchemaQuery lists the columns of the tables and views outside of
+// the system schemas.
+const postgresSchemaQuery = `SELECT c.table_schema, c.table_name,
+COALESCE(obj_description(format('%I.%I', c.table_schema, c.table_name)::regclass, 'pg_class'), ''),
+c.column_name, c.data_type,
+COALESCE(col_description(format('%I.%I', c.table_schema, c.table_name)::regclass, c.ordinal_position::int), ''),
+EXISTS (SELECT 1 FROM information_schema.table_constraints tc
+	JOIN information_schema.key_column_usage k ON k.constraint_schema = tc.constraint_schema AND k.constraint_name = tc.constraint_name
+	WHERE tc.constraint_type = 'PRIMARY KEY' AND tc.table_schema = c.table_schema AND tc.table_name = c.table_name AND k.column_name = c.column_name),
+c.is_nullable = 'YES',
+c.column_default IS NOT NULL OR c.is_identity = 'YES' OR c.is_generated = 'ALWAYS'
+FROM information_schema.columns c
+WHERE c.table_schema NOT IN ('pg_catalog', 'information_schema') AND c.table_schema NOT LIKE 'pg\_toast%'
+ORDER BY c.table_schema, c.table_name, c.ordinal_position`
+
+// ListPostgresSchema returns the tables of a PostgreSQL database.
+func ListPostgresSchema(ctx context.Context, pool *pgxpool.Pool) ([]Table, error) {
+	rows, err := pool.Query(ctx, postgresSchemaQuery)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query schema: %w", err)
+	}
+	defer rows.Close()
+	return scanSchema(rows)
+}
+
+// mysqlSchemaQuery lists the columns of the tables and views of the current
+// database.
+const mysqlSchemaQuery = `SELECT c.table_schema, c.table_name, COALESCE(t.table_comment, ''),
+c.column_name, c.column_type, COALESCE(c.column_comment, ''),
+c.column_key = 'PRI', c.is_nullable = 'YES',
+c.column_default IS NOT NULL OR c.extra LIKE '%auto_increment%' OR c.extra LIKE '%GENERATED%'
+FROM information_schema.columns c
+JOIN information_schema.tables t ON t.table_schema = c.table_schema AND t.table_name = c.table_name
+WHERE c.table_schema = DATABASE()
+ORDER BY c.table_name, c.ordinal_position`
+
+// ListMySQLSchema returns the tables of a MySQL compatible database.
+func ListMySQLSchema(ctx context.Context, db *sql.DB) ([]Table, error) {
+	return ListSchemaSQL(ctx, db, mysqlSchemaQuery)
+}
+
+// mssqlSchemaQuery lists the columns of the user tables and views, with
+// their MS_Description extended properties as comments.
+const mssqlSchemaQuery = `SELECT s.name, o.name, CAST(COALESCE(tp.value, '') AS NVARCHAR(MAX)),
+c.name, ty.name, CAST(COALESCE(cp.value, '') AS NVARCHAR(MAX)),
+CASE WHEN EXISTS (SELECT 1 FROM sys.indexes i
+	JOIN sys.index_columns ic ON ic.object_id = i.object_id AND ic.index_id = i.index_id
+	WHERE i.object_id = o.object_id AND i.is_primary_key = 1 AND ic.column_id = c.column_id) THEN 1 ELSE 0 END,
+c.is_nullable,
+CASE WHEN c.is_identity = 1 OR c.is_computed = 1 OR c.default_object_id <> 0 THEN 1 ELSE 0 END
+FROM sys.objects o
+JOIN sys.schemas s ON s.schema_id = o.schema_id
+JOIN sys.columns c ON c.object_id = o.object_id
+JOIN sys.types ty ON ty.user_type_id = c.user_type_id
+LEFT JOIN sys.extended_properties tp ON tp.class = 1 AND tp.major_id = o.object_id AND tp.minor_id = 0 AND tp.name = 'MS_Description'
+LEFT JOIN sys.extended_properties cp ON cp.class = 1 AND cp.major_id = o.object_id AND cp.minor_id = c.column_id AND cp.name = 'MS_Description'
+WHERE o.type IN ('U', 'V') AND o.is_ms_shipped = 0
+ORDER BY s.name, o.name, c.column_id`
+
+// ListMSSQLSchema returns the tables of a SQL Server database.
+func ListMSSQLSchema(ctx context.Context, db *sql.DB) ([]Table, error) {
+	return ListSchemaSQL(ctx, db, mssqlSchemaQuery)
+}
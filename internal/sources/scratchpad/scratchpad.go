@@ -0,0 +1,227 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scratchpad
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"go.opentelemetry.io/otel/trace"
+	_ "modernc.org/sqlite" // Pure Go SQLite driver
+)
+
+const SourceKind string = "scratchpad"
+
+// pageSize is the SQLite page size used to convert maxSizeBytes to pages.
+const pageSize int64 = 4096
+
+// validate interface
+var _ sources.SourceConfig = Config{}
+
+func init() {
+	if !sources.Register(SourceKind, newConfig) {
+		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name, Scope: ScopeServer, SessionTTL: "30m"} // Default scope and TTL
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+// Scope controls whether the scratchpad database is shared by all clients of
+// the server, or private to each client session.
+type Scope string
+
+const (
+	ScopeServer  Scope = "server"
+	ScopeSession Scope = "session"
+)
+
+func (s *Scope) UnmarshalYAML(ctx context.Context, unmarshal func(interface{}) error) error {
+	var scope string
+	if err := unmarshal(&scope); err != nil {
+		return err
+	}
+	switch strings.ToLower(scope) {
+	case string(ScopeServer), string(ScopeSession):
+		*s = Scope(strings.ToLower(scope))
+		return nil
+	default:
+		return fmt.Errorf(`scope invalid: must be one of "server", or "session"`)
+	}
+}
+
+type Config struct {
+	Name         string `yaml:"name" validate:"required"`
+	Kind         string `yaml:"kind" validate:"required"`
+	Scope        Scope  `yaml:"scope" validate:"required"`
+	SessionTTL   string `yaml:"sessionTTL" validate:"required"`
+	MaxSizeBytes int64  `yaml:"maxSizeBytes"`
+}
+
+func (r Config) SourceConfigKind() string {
+	return SourceKind
+}
+
+func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	sessionTTL, err := time.ParseDuration(r.SessionTTL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sessionTTL %q: %w", r.SessionTTL, err)
+	}
+	if r.MaxSizeBytes < 0 {
+		return nil, fmt.Errorf("maxSizeBytes must not be negative")
+	}
+
+	client, err := initScratchpadClient(ctx, tracer, r.Name, r.Scope, sessionTTL, r.MaxSizeBytes)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create scratchpad database: %w", err)
+	}
+
+	s := &Source{
+		Name:   r.Name,
+		Kind:   SourceKind,
+		Client: client,
+	}
+	return s, nil
+}
+
+var _ sources.Source = &Source{}
+
+type Source struct {
+	Name   string `yaml:"name"`
+	Kind   string `yaml:"kind"`
+	Client *Client
+}
+
+func (s *Source) SourceKind() string {
+	return SourceKind
+}
+
+func (s *Source) ScratchpadClient() *Client {
+	return s.Client
+}
+
+// Client hands out the in-memory databases of a scratchpad. The databases
+// only live in the memory of the server and are lost when it stops.
+type Client struct {
+	Scope      Scope
+	SessionTTL time.Duration
+	maxPages   int64
+
+	shared   *sql.DB
+	mu       sync.Mutex
+	sessions map[string]*sessionDB
+}
+
+type sessionDB struct {
+	db       *sql.DB
+	lastUsed time.Time
+}
+
+// DB returns the database to use for a tool invocation. With the "session"
+// scope, each client session gets its own database, which is dropped after
+// it hasn't been used for SessionTTL. Invocations that aren't part of a
+// session, such as calls to the native API, use the shared database.
+func (c *Client) DB(ctx context.Context) (*sql.DB, error) {
+	if c.Scope != ScopeSession {
+		return c.shared, nil
+	}
+	sessionID := util.SessionIDFromContext(ctx)
+	if sessionID == "" {
+		return c.shared, nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	// drop the databases of sessions that have expired
+	for id, s := range c.sessions {
+		if id != sessionID && now.Sub(s.lastUsed) > c.SessionTTL {
+			s.db.Close()
+			delete(c.sessions, id)
+		}
+	}
+
+	if s, ok := c.sessions[sessionID]; ok {
+		s.lastUsed = now
+		return s.db, nil
+	}
+	db, err := openMemoryDB(ctx, c.maxPages)
+	if err != nil {
+		return nil, err
+	}
+	c.sessions[sessionID] = &sessionDB{db: db, lastUsed: now}
+	return db, nil
+}
+
+// openMemoryDB opens a new in-memory SQLite database. An in-memory database
+// is private to the connection that created it, so the pool is limited to a
+// single connection that is never closed.
+func openMemoryDB(ctx context.Context, maxPages int64) (*sql.DB, error) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		return nil, fmt.Errorf("sql.Open: %w", err)
+	}
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+	db.SetConnMaxLifetime(0)
+	db.SetConnMaxIdleTime(0)
+
+	if maxPages > 0 {
+		if _, err := db.ExecContext(ctx, fmt.Sprintf("PRAGMA page_size = %d; PRAGMA max_page_count = %d", pageSize, maxPages)); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("unable to set database size limit: %w", err)
+		}
+	} else if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("unable to connect successfully: %w", err)
+	}
+	return db, nil
+}
+
+func initScratchpadClient(ctx context.Context, tracer trace.Tracer, name string, scope Scope, sessionTTL time.Duration, maxSizeBytes int64) (*Client, error) {
+	//nolint:all // Reassigned ctx
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, name)
+	defer span.End()
+
+	var maxPages int64
+	if maxSizeBytes > 0 {
+		maxPages = max(maxSizeBytes/pageSize, 1)
+	}
+
+	shared, err := openMemoryDB(ctx, maxPages)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		Scope:      scope,
+		SessionTTL: sessionTTL,
+		maxPages:   maxPages,
+		shared:     shared,
+		sessions:   make(map[string]*sessionDB),
+	}, nil
+}
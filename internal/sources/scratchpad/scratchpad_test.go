@@ -0,0 +1,197 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scratchpad_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources/scratchpad"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestParseFromYamlScratchpad(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		want server.SourceConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			sources:
+				my-scratchpad:
+					kind: scratchpad
+			`,
+			want: server.SourceConfigs{
+				"my-scratchpad": scratchpad.Config{
+					Name:       "my-scratchpad",
+					Kind:       scratchpad.SourceKind,
+					Scope:      scratchpad.ScopeServer,
+					SessionTTL: "30m",
+				},
+			},
+		},
+		{
+			desc: "session scope",
+			in: `
+			sources:
+				my-scratchpad:
+					kind: scratchpad
+					scope: Session
+					sessionTTL: 1h
+					maxSizeBytes: 10485760
+			`,
+			want: server.SourceConfigs{
+				"my-scratchpad": scratchpad.Config{
+					Name:         "my-scratchpad",
+					Kind:         scratchpad.SourceKind,
+					Scope:        scratchpad.ScopeSession,
+					SessionTTL:   "1h",
+					MaxSizeBytes: 10485760,
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if !cmp.Equal(tc.want, got.Sources) {
+				t.Fatalf("incorrect parse: want %v, got %v", tc.want, got.Sources)
+			}
+		})
+	}
+}
+
+func TestFailParseFromYaml(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		err  string
+	}{
+		{
+			desc: "extra field",
+			in: `
+			sources:
+				my-scratchpad:
+					kind: scratchpad
+					foo: bar
+			`,
+			err: "unable to parse source \"my-scratchpad\" as \"scratchpad\": [1:1] unknown field \"foo\"\n>  1 | foo: bar\n       ^\n   2 | kind: scratchpad",
+		},
+		{
+			desc: "invalid scope",
+			in: `
+			sources:
+				my-scratchpad:
+					kind: scratchpad
+					scope: global
+			`,
+			err: "unable to parse source \"my-scratchpad\" as \"scratchpad\": scope invalid: must be one of \"server\", or \"session\"",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err == nil {
+				t.Fatalf("expect parsing to fail")
+			}
+			errStr := err.Error()
+			if errStr != tc.err {
+				t.Fatalf("unexpected error: got %q, want %q", errStr, tc.err)
+			}
+		})
+	}
+}
+
+func TestClientDB(t *testing.T) {
+	ctx := context.Background()
+	tracer := noop.NewTracerProvider().Tracer("test")
+	newClient := func(t *testing.T, scope scratchpad.Scope) *scratchpad.Client {
+		src, err := scratchpad.Config{Name: "my-scratchpad", Kind: scratchpad.SourceKind, Scope: scope, SessionTTL: "30m"}.Initialize(ctx, tracer)
+		if err != nil {
+			t.Fatalf("unable to initialize source: %s", err)
+		}
+		return src.(*scratchpad.Source).ScratchpadClient()
+	}
+	// createAndCount creates a table in the database for ctx and returns the
+	// number of tables in it.
+	createAndCount := func(t *testing.T, c *scratchpad.Client, ctx context.Context, table string) int {
+		db, err := c.DB(ctx)
+		if err != nil {
+			t.Fatalf("unable to get database: %s", err)
+		}
+		if _, err := db.ExecContext(ctx, "CREATE TABLE "+table+" (a)"); err != nil {
+			t.Fatalf("unable to create table: %s", err)
+		}
+		var n int
+		if err := db.QueryRowContext(ctx, "SELECT count(*) FROM sqlite_schema WHERE type = 'table'").Scan(&n); err != nil {
+			t.Fatalf("unable to count tables: %s", err)
+		}
+		return n
+	}
+
+	t.Run("server scope is shared across sessions", func(t *testing.T) {
+		c := newClient(t, scratchpad.ScopeServer)
+		if got := createAndCount(t, c, util.WithSessionID(ctx, "a"), "t1"); got != 1 {
+			t.Fatalf("unexpected table count: got %d, want 1", got)
+		}
+		if got := createAndCount(t, c, util.WithSessionID(ctx, "b"), "t2"); got != 2 {
+			t.Fatalf("unexpected table count: got %d, want 2", got)
+		}
+	})
+
+	t.Run("session scope is private to each session", func(t *testing.T) {
+		c := newClient(t, scratchpad.ScopeSession)
+		if got := createAndCount(t, c, util.WithSessionID(ctx, "a"), "t1"); got != 1 {
+			t.Fatalf("unexpected table count: got %d, want 1", got)
+		}
+		if got := createAndCount(t, c, util.WithSessionID(ctx, "b"), "t2"); got != 1 {
+			t.Fatalf("unexpected table count: got %d, want 1", got)
+		}
+		if got := createAndCount(t, c, util.WithSessionID(ctx, "a"), "t3"); got != 2 {
+			t.Fatalf("unexpected table count: got %d, want 2", got)
+		}
+	})
+
+	t.Run("expired sessions are dropped", func(t *testing.T) {
+		c := newClient(t, scratchpad.ScopeSession)
+		c.SessionTTL = time.Millisecond
+		createAndCount(t, c, util.WithSessionID(ctx, "a"), "t1")
+		time.Sleep(10 * time.Millisecond)
+		// accessing another session drops the expired one
+		createAndCount(t, c, util.WithSessionID(ctx, "b"), "t1")
+		if got := createAndCount(t, c, util.WithSessionID(ctx, "a"), "t1"); got != 1 {
+			t.Fatalf("unexpected table count: got %d, want 1", got)
+		}
+	})
+}
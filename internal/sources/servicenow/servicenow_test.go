@@ -0,0 +1,133 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicenow_test
+
+import (
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources/servicenow"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+)
+
+func TestParseFromYamlServiceNow(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		want server.SourceConfigs
+	}{
+		{
+			desc: "api key example",
+			in: `
+			sources:
+				my-servicenow:
+					kind: servicenow
+					instanceUrl: https://example.service-now.com
+					apiKey: key
+			`,
+			want: server.SourceConfigs{
+				"my-servicenow": servicenow.Config{
+					Name:        "my-servicenow",
+					Kind:        servicenow.SourceKind,
+					InstanceURL: "https://example.service-now.com",
+					APIKey:      "key",
+				},
+			},
+		},
+		{
+			desc: "basic auth example",
+			in: `
+			sources:
+				my-servicenow:
+					kind: servicenow
+					instanceUrl: https://example.service-now.com
+					user: bot
+					password: secret
+			`,
+			want: server.SourceConfigs{
+				"my-servicenow": servicenow.Config{
+					Name:        "my-servicenow",
+					Kind:        servicenow.SourceKind,
+					InstanceURL: "https://example.service-now.com",
+					User:        "bot",
+					Password:    "secret",
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if !cmp.Equal(tc.want, got.Sources) {
+				t.Fatalf("incorrect parse: want %v, got %v", tc.want, got.Sources)
+			}
+		})
+	}
+}
+
+func TestFailParseFromYaml(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		err  string
+	}{
+		{
+			desc: "missing credentials",
+			in: `
+			sources:
+				my-servicenow:
+					kind: servicenow
+					instanceUrl: https://example.service-now.com
+			`,
+			err: "unable to parse source \"my-servicenow\" as \"servicenow\": Key: 'Config.APIKey' Error:Field validation for 'APIKey' failed on the 'required_without' tag\nKey: 'Config.User' Error:Field validation for 'User' failed on the 'required_without' tag",
+		},
+		{
+			desc: "user without password",
+			in: `
+			sources:
+				my-servicenow:
+					kind: servicenow
+					instanceUrl: https://example.service-now.com
+					user: bot
+			`,
+			err: "unable to parse source \"my-servicenow\" as \"servicenow\": Key: 'Config.Password' Error:Field validation for 'Password' failed on the 'required_with' tag",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err == nil {
+				t.Fatalf("expect parsing to fail")
+			}
+			errStr := err.Error()
+			if errStr != tc.err {
+				t.Fatalf("unexpected error: got %q, want %q", errStr, tc.err)
+			}
+		})
+	}
+}
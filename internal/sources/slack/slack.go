@@ -0,0 +1,113 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slack
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const SourceKind string = "slack"
+
+// validate interface
+var _ sources.SourceConfig = Config{}
+
+func init() {
+	if !sources.Register(SourceKind, newConfig) {
+		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+// Config posts messages either with an incoming webhook, or with a bot token
+// to a channel.
+type Config struct {
+	Name       string `yaml:"name" validate:"required"`
+	Kind       string `yaml:"kind" validate:"required"`
+	WebhookURL string `yaml:"webhookUrl" validate:"required_without=Token,excluded_with=Token"`
+	Token      string `yaml:"token" validate:"required_without=WebhookURL"`
+	Channel    string `yaml:"channel" validate:"required_with=Token"`
+}
+
+func (r Config) SourceConfigKind() string {
+	return SourceKind
+}
+
+func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	//nolint:all // Reassigned ctx
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, r.Name)
+	defer span.End()
+
+	if r.WebhookURL != "" {
+		if _, err := url.ParseRequestURI(r.WebhookURL); err != nil {
+			return nil, fmt.Errorf("failed to parse webhookUrl: %w", err)
+		}
+	}
+
+	s := &Source{
+		Name:       r.Name,
+		Kind:       SourceKind,
+		WebhookURL: r.WebhookURL,
+		Token:      r.Token,
+		Channel:    r.Channel,
+		Client:     &http.Client{Timeout: 30 * time.Second},
+	}
+	return s, nil
+}
+
+var _ sources.Source = &Source{}
+
+type Source struct {
+	Name       string `yaml:"name"`
+	Kind       string `yaml:"kind"`
+	WebhookURL string
+	Token      string
+	Channel    string
+	Client     *http.Client
+}
+
+func (s *Source) SourceKind() string {
+	return SourceKind
+}
+
+func (s *Source) SlackWebhookURL() string {
+	return s.WebhookURL
+}
+
+func (s *Source) SlackToken() string {
+	return s.Token
+}
+
+func (s *Source) SlackChannel() string {
+	return s.Channel
+}
+
+func (s *Source) HTTPClient() *http.Client {
+	return s.Client
+}
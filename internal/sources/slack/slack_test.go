@@ -0,0 +1,139 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slack_test
+
+import (
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources/slack"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+)
+
+func TestParseFromYamlSlack(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		want server.SourceConfigs
+	}{
+		{
+			desc: "webhook example",
+			in: `
+			sources:
+				my-slack:
+					kind: slack
+					webhookUrl: https://hooks.slack.com/services/T000/B000/XXXX
+			`,
+			want: server.SourceConfigs{
+				"my-slack": slack.Config{
+					Name:       "my-slack",
+					Kind:       slack.SourceKind,
+					WebhookURL: "https://hooks.slack.com/services/T000/B000/XXXX",
+				},
+			},
+		},
+		{
+			desc: "token example",
+			in: `
+			sources:
+				my-slack:
+					kind: slack
+					token: xoxb-token
+					channel: C0123456
+			`,
+			want: server.SourceConfigs{
+				"my-slack": slack.Config{
+					Name:    "my-slack",
+					Kind:    slack.SourceKind,
+					Token:   "xoxb-token",
+					Channel: "C0123456",
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if !cmp.Equal(tc.want, got.Sources) {
+				t.Fatalf("incorrect parse: want %v, got %v", tc.want, got.Sources)
+			}
+		})
+	}
+}
+
+func TestFailParseFromYaml(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		err  string
+	}{
+		{
+			desc: "missing webhook and token",
+			in: `
+			sources:
+				my-slack:
+					kind: slack
+			`,
+			err: "unable to parse source \"my-slack\" as \"slack\": Key: 'Config.WebhookURL' Error:Field validation for 'WebhookURL' failed on the 'required_without' tag\nKey: 'Config.Token' Error:Field validation for 'Token' failed on the 'required_without' tag",
+		},
+		{
+			desc: "token without channel",
+			in: `
+			sources:
+				my-slack:
+					kind: slack
+					token: xoxb-token
+			`,
+			err: "unable to parse source \"my-slack\" as \"slack\": Key: 'Config.Channel' Error:Field validation for 'Channel' failed on the 'required_with' tag",
+		},
+		{
+			desc: "webhook and token",
+			in: `
+			sources:
+				my-slack:
+					kind: slack
+					webhookUrl: https://hooks.slack.com/services/T000/B000/XXXX
+					token: xoxb-token
+					channel: C0123456
+			`,
+			err: "unable to parse source \"my-slack\" as \"slack\": [4:13] Key: 'Config.WebhookURL' Error:Field validation for 'WebhookURL' failed on the 'excluded_with' tag\n   1 | channel: C0123456\n   2 | kind: slack\n   3 | token: xoxb-token\n>  4 | webhookUrl: https://hooks.slack.com/services/T000/B000/XXXX\n                   ^\n",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err == nil {
+				t.Fatalf("expect parsing to fail")
+			}
+			errStr := err.Error()
+			if errStr != tc.err {
+				t.Fatalf("unexpected error: got %q, want %q", errStr, tc.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,528 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snowflake
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/googleapis/genai-toolbox/internal/util/normalize"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const SourceKind string = "snowflake"
+
+// defaultQueryTimeout is how long a query may run when queryTimeout is not
+// configured.
+const defaultQueryTimeout = 5 * time.Minute
+
+// maxPollInterval bounds the backoff between checks of a running query.
+const maxPollInterval = 2 * time.Second
+
+// jwtLifetime is how long the JWTs of key-pair authentication are valid,
+// which Snowflake limits to an hour.
+const jwtLifetime = time.Hour
+
+// validate interface
+var _ sources.SourceConfig = Config{}
+
+func init() {
+	if !sources.Register(SourceKind, newConfig) {
+		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name string `yaml:"name" validate:"required"`
+	Kind string `yaml:"kind" validate:"required"`
+	// Account is the account identifier, such as "myorg-myaccount".
+	Account   string `yaml:"account" validate:"required"`
+	User      string `yaml:"user" validate:"required"`
+	Warehouse string `yaml:"warehouse"`
+	Database  string `yaml:"database"`
+	Schema    string `yaml:"schema"`
+	Role      string `yaml:"role"`
+	// PrivateKey is the unencrypted PKCS #8 private key of key-pair
+	// authentication, PEM encoded. PrivateKeyFile is the path of a file
+	// containing it.
+	PrivateKey     string `yaml:"privateKey"`
+	PrivateKeyFile string `yaml:"privateKeyFile"`
+	// Token is a programmatic access token of the user. The SQL API doesn't
+	// accept the passwords of users.
+	Token        string `yaml:"token"`
+	QueryTimeout string `yaml:"queryTimeout"`
+	ReadOnly     bool   `yaml:"readOnly"`
+	Endpoint     string `yaml:"endpoint"` // Optional, e.g. a private connectivity URL
+}
+
+func (r Config) SourceConfigKind() string {
+	return SourceKind
+}
+
+func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	//nolint:all // Reassigned ctx
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, r.Name)
+	defer span.End()
+
+	queryTimeout := defaultQueryTimeout
+	if r.QueryTimeout != "" {
+		var err error
+		queryTimeout, err = time.ParseDuration(r.QueryTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse QueryTimeout string as time.Duration: %s", err)
+		}
+	}
+	endpoint := r.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://%s.snowflakecomputing.com", r.Account)
+	}
+	if _, err := url.ParseRequestURI(endpoint); err != nil {
+		return nil, fmt.Errorf("failed to parse endpoint: %w", err)
+	}
+
+	userAgent, err := util.UserAgentFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Source{
+		Name:         r.Name,
+		Kind:         SourceKind,
+		Account:      r.Account,
+		User:         r.User,
+		Endpoint:     strings.TrimSuffix(endpoint, "/"),
+		Warehouse:    r.Warehouse,
+		Database:     r.Database,
+		Schema:       r.Schema,
+		Role:         r.Role,
+		QueryTimeout: queryTimeout,
+		Client:       &http.Client{Timeout: 60 * time.Second},
+		readOnly:     r.ReadOnly,
+		userAgent:    userAgent,
+	}
+
+	keyPEM := []byte(r.PrivateKey)
+	if r.PrivateKeyFile != "" {
+		if r.PrivateKey != "" {
+			return nil, fmt.Errorf("privateKey and privateKeyFile can't both be set")
+		}
+		keyPEM, err = os.ReadFile(r.PrivateKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read privateKeyFile: %w", err)
+		}
+	}
+	switch {
+	case len(keyPEM) > 0 && r.Token != "":
+		return nil, fmt.Errorf("a private key and token can't both be set")
+	case len(keyPEM) > 0:
+		s.privateKey, err = parsePrivateKey(keyPEM)
+		if err != nil {
+			return nil, err
+		}
+	case r.Token != "":
+		s.accessToken = r.Token
+	default:
+		return nil, fmt.Errorf("one of privateKey, privateKeyFile or token must be set")
+	}
+
+	// check the credentials and settings, rather than when a tool is invoked
+	if _, err := s.SnowflakeQuery(ctx, "SELECT 1", nil); err != nil {
+		return nil, fmt.Errorf("unable to connect successfully: %w", err)
+	}
+	return s, nil
+}
+
+// parsePrivateKey parses a PEM encoded PKCS #8 RSA private key.
+func parsePrivateKey(keyPEM []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, fmt.Errorf("private key must be PEM encoded")
+	}
+	if block.Type == "ENCRYPTED PRIVATE KEY" {
+		return nil, fmt.Errorf("encrypted private keys are not supported")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse private key: %w", err)
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private key must be an RSA key, got %T", key)
+	}
+	return rsaKey, nil
+}
+
+var _ sources.Source = &Source{}
+
+var _ sources.ReadOnlySource = &Source{}
+
+type Source struct {
+	Name         string `yaml:"name"`
+	Kind         string `yaml:"kind"`
+	Account      string
+	User         string
+	Endpoint     string
+	Warehouse    string
+	Database     string
+	Schema       string
+	Role         string
+	QueryTimeout time.Duration
+	Client       *http.Client
+
+	privateKey  *rsa.PrivateKey
+	accessToken string
+	readOnly    bool
+	userAgent   string
+
+	mu       sync.Mutex
+	token    string
+	tokenExp time.Time
+}
+
+func (s *Source) SourceKind() string {
+	return SourceKind
+}
+
+// ReadOnly reports whether the source is configured with readOnly.
+func (s *Source) ReadOnly() bool {
+	return s.readOnly
+}
+
+// Binding is the value of a ? placeholder of a statement. Value is nil for
+// NULL.
+type Binding struct {
+	// Type is the Snowflake type of the value, such as "TEXT" or "FIXED".
+	Type  string  `json:"type"`
+	Value *string `json:"value"`
+}
+
+type rowType struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Scale int    `json:"scale"`
+}
+
+type statementResponse struct {
+	Code              string `json:"code"`
+	Message           string `json:"message"`
+	StatementHandle   string `json:"statementHandle"`
+	ResultSetMetaData struct {
+		PartitionInfo []struct{} `json:"partitionInfo"`
+		RowType       []rowType  `json:"rowType"`
+	} `json:"resultSetMetaData"`
+	Data [][]*string `json:"data"`
+}
+
+// SnowflakeQuery executes statement, waits for it to complete, and returns
+// the rows of its result. Statements still running after the query timeout
+// are cancelled.
+func (s *Source) SnowflakeQuery(ctx context.Context, statement string, bindings []Binding) ([]any, error) {
+	req := map[string]any{
+		"statement": statement,
+		"timeout":   int(math.Ceil(s.QueryTimeout.Seconds())),
+	}
+	for k, v := range map[string]string{"warehouse": s.Warehouse, "database": s.Database, "schema": s.Schema, "role": s.Role} {
+		if v != "" {
+			req[k] = v
+		}
+	}
+	if len(bindings) > 0 {
+		b := make(map[string]Binding, len(bindings))
+		for i, binding := range bindings {
+			b[strconv.Itoa(i+1)] = binding
+		}
+		req["bindings"] = b
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, s.QueryTimeout)
+	defer cancel()
+
+	var resp statementResponse
+	status, err := s.call(waitCtx, http.MethodPost, "/api/v2/statements", req, &resp)
+	if err != nil {
+		if waitCtx.Err() != nil && ctx.Err() == nil {
+			return nil, fmt.Errorf("query did not complete within %s", s.QueryTimeout)
+		}
+		return nil, err
+	}
+	if status == http.StatusAccepted {
+		if err := s.wait(ctx, waitCtx, resp.StatementHandle, &resp); err != nil {
+			return nil, err
+		}
+	}
+	return s.results(ctx, &resp)
+}
+
+// wait polls a statement that is still running until it completes, and
+// decodes its first partition into resp.
+func (s *Source) wait(ctx, waitCtx context.Context, handle string, resp *statementResponse) error {
+	interval := 100 * time.Millisecond
+	for {
+		select {
+		case <-waitCtx.Done():
+			s.cancel(ctx, handle)
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return fmt.Errorf("query %s did not complete within %s", handle, s.QueryTimeout)
+		case <-time.After(interval):
+		}
+		interval = min(interval*2, maxPollInterval)
+
+		*resp = statementResponse{}
+		status, err := s.call(waitCtx, http.MethodGet, "/api/v2/statements/"+url.PathEscape(handle), nil, resp)
+		if err != nil {
+			s.cancel(ctx, handle)
+			if waitCtx.Err() != nil && ctx.Err() == nil {
+				return fmt.Errorf("query %s did not complete within %s", handle, s.QueryTimeout)
+			}
+			return err
+		}
+		if status != http.StatusAccepted {
+			return nil
+		}
+	}
+}
+
+// cancel cancels a statement that is no longer waited for, so it doesn't
+// keep using the warehouse.
+func (s *Source) cancel(ctx context.Context, handle string) {
+	cancelCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+	defer cancel()
+	_, _ = s.call(cancelCtx, http.MethodPost, "/api/v2/statements/"+url.PathEscape(handle)+"/cancel", nil, nil)
+}
+
+// results reads every partition of the result of a statement, whose first
+// partition is in resp.
+func (s *Source) results(ctx context.Context, resp *statementResponse) ([]any, error) {
+	cols := resp.ResultSetMetaData.RowType
+	out := []any{}
+	data := resp.Data
+	for partition := 0; ; {
+		for _, r := range data {
+			row := make(map[string]any, len(cols))
+			for i, col := range cols {
+				if i >= len(r) || r[i] == nil {
+					row[col.Name] = nil
+					continue
+				}
+				row[col.Name] = convertValue(col, *r[i])
+			}
+			out = append(out, row)
+		}
+		partition++
+		if partition >= len(resp.ResultSetMetaData.PartitionInfo) {
+			return out, nil
+		}
+		var next statementResponse
+		path := fmt.Sprintf("/api/v2/statements/%s?partition=%d", url.PathEscape(resp.StatementHandle), partition)
+		if _, err := s.call(ctx, http.MethodGet, path, nil, &next); err != nil {
+			return nil, fmt.Errorf("unable to get partition %d of query results: %w", partition, err)
+		}
+		data = next.Data
+	}
+}
+
+// convertValue converts a value, which the SQL API returns as a string, to
+// the type of its column.
+func convertValue(col rowType, v string) any {
+	switch col.Type {
+	case "boolean":
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	case "fixed":
+		if col.Scale == 0 {
+			if i, err := strconv.ParseInt(v, 10, 64); err == nil {
+				return i
+			}
+		}
+		return normalize.Decimal(v)
+	case "real":
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f
+		}
+	case "date":
+		// the number of days since the epoch
+		if days, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return time.Unix(days*24*60*60, 0).UTC().Format(time.DateOnly)
+		}
+	case "time":
+		// the number of seconds since midnight
+		if t, err := parseEpoch(v); err == nil {
+			return t.UTC().Format("15:04:05.999999999")
+		}
+	case "timestamp_ntz", "timestamp_ltz":
+		// the number of seconds since the epoch
+		if t, err := parseEpoch(v); err == nil {
+			return t.UTC()
+		}
+	case "timestamp_tz":
+		// the number of seconds since the epoch, and the offset of the time
+		// zone in minutes plus 1440
+		if secs, offset, ok := strings.Cut(v, " "); ok {
+			t, err := parseEpoch(secs)
+			minutes, err2 := strconv.Atoi(offset)
+			if err == nil && err2 == nil {
+				return t.In(time.FixedZone("", (minutes-1440)*60))
+			}
+		}
+	case "variant", "object", "array":
+		var parsed any
+		if err := json.Unmarshal([]byte(v), &parsed); err == nil {
+			return parsed
+		}
+	}
+	return v
+}
+
+// parseEpoch parses a number of seconds, with up to nine decimals, since the
+// epoch.
+func parseEpoch(v string) (time.Time, error) {
+	secs, frac, _ := strings.Cut(v, ".")
+	sec, err := strconv.ParseInt(secs, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	var nsec int64
+	if frac != "" {
+		nsec, err = strconv.ParseInt((frac + "000000000")[:9], 10, 64)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if strings.HasPrefix(secs, "-") {
+			nsec = -nsec
+		}
+	}
+	return time.Unix(sec, nsec), nil
+}
+
+// authorization returns the token authenticating requests to the SQL API, and
+// its type.
+func (s *Source) authorization() (string, string, error) {
+	if s.privateKey == nil {
+		return s.accessToken, "PROGRAMMATIC_ACCESS_TOKEN", nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	if s.token != "" && now.Add(5*time.Minute).Before(s.tokenExp) {
+		return s.token, "KEYPAIR_JWT", nil
+	}
+
+	pub, err := x509.MarshalPKIXPublicKey(&s.privateKey.PublicKey)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to encode public key: %w", err)
+	}
+	fingerprint := sha256.Sum256(pub)
+	// the account locator of identifiers such as "xy12345.us-east-1"
+	account, _, _ := strings.Cut(strings.ToUpper(s.Account), ".")
+	qualifiedUser := account + "." + strings.ToUpper(s.User)
+	exp := now.Add(jwtLifetime)
+	token, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss": qualifiedUser + ".SHA256:" + base64.StdEncoding.EncodeToString(fingerprint[:]),
+		"sub": qualifiedUser,
+		"iat": now.Unix(),
+		"exp": exp.Unix(),
+	}).SignedString(s.privateKey)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to sign jwt: %w", err)
+	}
+	s.token, s.tokenExp = token, exp
+	return token, "KEYPAIR_JWT", nil
+}
+
+// call sends a request to the SQL API, and decodes its response into out.
+// It returns the status code of the response, which is 202 for statements
+// still running.
+func (s *Source) call(ctx context.Context, method, path string, in any, out any) (int, error) {
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return 0, fmt.Errorf("failed to encode request: %w", err)
+		}
+		body = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, s.Endpoint+path, body)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	token, tokenType, err := s.authorization()
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("X-Snowflake-Authorization-Token-Type", tokenType)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", s.userAgent)
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var apiErr struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		}
+		if err := json.Unmarshal(respBody, &apiErr); err == nil && apiErr.Message != "" {
+			return resp.StatusCode, fmt.Errorf("%s: %s", apiErr.Code, apiErr.Message)
+		}
+		return resp.StatusCode, fmt.Errorf("unexpected status code: %d, response body: %s", resp.StatusCode, string(respBody))
+	}
+	if out == nil {
+		return resp.StatusCode, nil
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return resp.StatusCode, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return resp.StatusCode, nil
+}
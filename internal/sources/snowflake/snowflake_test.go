@@ -0,0 +1,387 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snowflake_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources/snowflake"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/googleapis/genai-toolbox/internal/util/normalize"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestParseFromYamlSnowflake(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		want server.SourceConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			sources:
+				my-snowflake:
+					kind: snowflake
+					account: myorg-myaccount
+					user: toolbox
+					privateKeyFile: /keys/rsa_key.p8
+			`,
+			want: server.SourceConfigs{
+				"my-snowflake": snowflake.Config{
+					Name:           "my-snowflake",
+					Kind:           snowflake.SourceKind,
+					Account:        "myorg-myaccount",
+					User:           "toolbox",
+					PrivateKeyFile: "/keys/rsa_key.p8",
+				},
+			},
+		},
+		{
+			desc: "all fields",
+			in: `
+			sources:
+				my-snowflake:
+					kind: snowflake
+					account: myorg-myaccount
+					user: toolbox
+					token: my-token
+					warehouse: compute_wh
+					database: sales
+					schema: public
+					role: analyst
+					queryTimeout: 10m
+					readOnly: true
+			`,
+			want: server.SourceConfigs{
+				"my-snowflake": snowflake.Config{
+					Name:         "my-snowflake",
+					Kind:         snowflake.SourceKind,
+					Account:      "myorg-myaccount",
+					User:         "toolbox",
+					Token:        "my-token",
+					Warehouse:    "compute_wh",
+					Database:     "sales",
+					Schema:       "public",
+					Role:         "analyst",
+					QueryTimeout: "10m",
+					ReadOnly:     true,
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if !cmp.Equal(tc.want, got.Sources) {
+				t.Fatalf("incorrect parse: want %v, got %v", tc.want, got.Sources)
+			}
+		})
+	}
+}
+
+func TestFailParseFromYaml(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		err  string
+	}{
+		{
+			desc: "missing required field",
+			in: `
+			sources:
+				my-snowflake:
+					kind: snowflake
+					user: toolbox
+			`,
+			err: "unable to parse source \"my-snowflake\" as \"snowflake\": Key: 'Config.Account' Error:Field validation for 'Account' failed on the 'required' tag",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err == nil {
+				t.Fatalf("expect parsing to fail")
+			}
+			errStr := err.Error()
+			if errStr != tc.err {
+				t.Fatalf("unexpected error: got %q, want %q", errStr, tc.err)
+			}
+		})
+	}
+}
+
+// fakeSnowflake answers SQL API calls. Statements complete immediately,
+// unless their text contains "async", which run for one status check,
+// "slow", which never complete, or "fail".
+type fakeSnowflake struct {
+	// key verifies the JWTs of key-pair authentication if set, otherwise
+	// requests must use the "my-token" programmatic access token.
+	key *rsa.PublicKey
+
+	mu        sync.Mutex
+	requests  []map[string]any
+	cancelled []string
+	claims    jwt.MapClaims
+}
+
+func (f *fakeSnowflake) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	authorized := false
+	if f.key != nil {
+		claims := jwt.MapClaims{}
+		_, err := jwt.ParseWithClaims(token, claims, func(*jwt.Token) (any, error) { return f.key, nil }, jwt.WithValidMethods([]string{"RS256"}))
+		authorized = err == nil && r.Header.Get("X-Snowflake-Authorization-Token-Type") == "KEYPAIR_JWT"
+		f.claims = claims
+	} else {
+		authorized = token == "my-token" && r.Header.Get("X-Snowflake-Authorization-Token-Type") == "PROGRAMMATIC_ACCESS_TOKEN"
+	}
+	if !authorized {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"code":"390144","message":"JWT token is invalid."}`))
+		return
+	}
+
+	rowType := []map[string]any{
+		{"name": "ID", "type": "fixed", "scale": 0},
+		{"name": "NAME", "type": "text"},
+		{"name": "PRICE", "type": "fixed", "scale": 2},
+		{"name": "CREATED", "type": "date"},
+		{"name": "TAGS", "type": "array"},
+	}
+	result := map[string]any{
+		"code":            "090001",
+		"statementHandle": "handle",
+		"resultSetMetaData": map[string]any{
+			"partitionInfo": []any{map[string]any{}, map[string]any{}},
+			"rowType":       rowType,
+		},
+		"data": [][]any{{"1", "widget", "12345678901234567890.12", "19000", `["a","b"]`}},
+	}
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/api/v2/statements":
+		var req map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		f.requests = append(f.requests, req)
+		statement := req["statement"].(string)
+		switch {
+		case strings.Contains(statement, "fail"):
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": "000904", "message": "SQL compilation error: error line 1 at position 7\ninvalid identifier 'NOPE'"})
+		case strings.Contains(statement, "async"), strings.Contains(statement, "slow"):
+			w.WriteHeader(http.StatusAccepted)
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": "333334", "statementHandle": statement})
+		default:
+			_ = json.NewEncoder(w).Encode(result)
+		}
+	case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/cancel"):
+		f.cancelled = append(f.cancelled, strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/v2/statements/"), "/cancel"))
+		_, _ = w.Write([]byte(`{"code":"000604"}`))
+	case r.Method == http.MethodGet && r.URL.Query().Get("partition") == "1":
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": [][]any{{"2", nil, "0.50", "-1", nil}}})
+	case r.Method == http.MethodGet && strings.Contains(r.URL.Path, "slow"):
+		w.WriteHeader(http.StatusAccepted)
+		_ = json.NewEncoder(w).Encode(map[string]any{"code": "333334"})
+	case r.Method == http.MethodGet:
+		_ = json.NewEncoder(w).Encode(result)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func newTestSource(t *testing.T, f *fakeSnowflake, cfg snowflake.Config) (*snowflake.Source, error) {
+	t.Helper()
+	ts := httptest.NewServer(f)
+	t.Cleanup(ts.Close)
+	cfg.Name = "my-snowflake"
+	cfg.Kind = snowflake.SourceKind
+	cfg.Account = "myorg-myaccount"
+	cfg.User = "toolbox"
+	cfg.Endpoint = ts.URL
+	if cfg.PrivateKey == "" && cfg.PrivateKeyFile == "" && cfg.Token == "" {
+		cfg.Token = "my-token"
+	}
+	ctx := util.WithUserAgent(context.Background(), "test")
+	s, err := cfg.Initialize(ctx, noop.NewTracerProvider().Tracer(""))
+	if err != nil {
+		return nil, err
+	}
+	return s.(*snowflake.Source), nil
+}
+
+func TestSnowflakeQuery(t *testing.T) {
+	f := &fakeSnowflake{}
+	s, err := newTestSource(t, f, snowflake.Config{Warehouse: "compute_wh", Database: "sales", QueryTimeout: "90s"})
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+
+	region := "us"
+	got, err := s.SnowflakeQuery(context.Background(), "SELECT * FROM products WHERE region = ?", []snowflake.Binding{{Type: "TEXT", Value: &region}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := []any{
+		map[string]any{"ID": int64(1), "NAME": "widget", "PRICE": normalize.Decimal("12345678901234567890.12"), "CREATED": "2022-01-08", "TAGS": []any{"a", "b"}},
+		map[string]any{"ID": int64(2), "NAME": nil, "PRICE": normalize.Decimal("0.50"), "CREATED": "1969-12-31", "TAGS": nil},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect result: diff %v", diff)
+	}
+
+	wantReq := map[string]any{
+		"statement": "SELECT * FROM products WHERE region = ?",
+		"timeout":   float64(90),
+		"warehouse": "compute_wh",
+		"database":  "sales",
+		"bindings":  map[string]any{"1": map[string]any{"type": "TEXT", "value": "us"}},
+	}
+	if diff := cmp.Diff(wantReq, f.requests[len(f.requests)-1]); diff != "" {
+		t.Fatalf("incorrect statement request: diff %v", diff)
+	}
+}
+
+func TestSnowflakeQueryAsync(t *testing.T) {
+	s, err := newTestSource(t, &fakeSnowflake{}, snowflake.Config{})
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	got, err := s.SnowflakeQuery(context.Background(), "SELECT async", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("unexpected number of rows: got %d, want 2", len(got))
+	}
+}
+
+func TestSnowflakeQueryFailed(t *testing.T) {
+	s, err := newTestSource(t, &fakeSnowflake{}, snowflake.Config{})
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	_, err = s.SnowflakeQuery(context.Background(), "SELECT nope FROM fail", nil)
+	want := "000904: SQL compilation error: error line 1 at position 7\ninvalid identifier 'NOPE'"
+	if err == nil || err.Error() != want {
+		t.Fatalf("unexpected error: got %v, want %q", err, want)
+	}
+}
+
+func TestSnowflakeQueryTimeout(t *testing.T) {
+	f := &fakeSnowflake{}
+	s, err := newTestSource(t, f, snowflake.Config{QueryTimeout: "300ms"})
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	_, err = s.SnowflakeQuery(context.Background(), "slow", nil)
+	if err == nil || err.Error() != "query slow did not complete within 300ms" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"slow"}, f.cancelled); diff != "" {
+		t.Fatalf("query was not cancelled: diff %v", diff)
+	}
+}
+
+func TestKeyPairAuthentication(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("unable to generate key: %s", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("unable to encode key: %s", err)
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+
+	f := &fakeSnowflake{key: &key.PublicKey}
+	if _, err := newTestSource(t, f, snowflake.Config{PrivateKey: string(keyPEM)}); err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	if got, want := f.claims["sub"], "MYORG-MYACCOUNT.TOOLBOX"; got != want {
+		t.Fatalf("incorrect subject: got %v, want %q", got, want)
+	}
+	if iss, _ := f.claims["iss"].(string); !strings.HasPrefix(iss, "MYORG-MYACCOUNT.TOOLBOX.SHA256:") {
+		t.Fatalf("incorrect issuer: %q", iss)
+	}
+	exp, err := f.claims.GetExpirationTime()
+	if err != nil || exp.After(time.Now().Add(time.Hour)) {
+		t.Fatalf("incorrect expiration time: %v", exp)
+	}
+}
+
+func TestInitializeFailures(t *testing.T) {
+	tcs := []struct {
+		desc string
+		cfg  snowflake.Config
+		err  string
+	}{
+		{
+			desc: "invalid token",
+			cfg:  snowflake.Config{Token: "wrong"},
+			err:  "unable to connect successfully: 390144: JWT token is invalid.",
+		},
+		{
+			desc: "private key and token",
+			cfg:  snowflake.Config{PrivateKey: "key", Token: "my-token"},
+			err:  "a private key and token can't both be set",
+		},
+		{
+			desc: "invalid private key",
+			cfg:  snowflake.Config{PrivateKey: "key"},
+			err:  "private key must be PEM encoded",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := newTestSource(t, &fakeSnowflake{}, tc.cfg)
+			if err == nil || err.Error() != tc.err {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.err)
+			}
+		})
+	}
+}
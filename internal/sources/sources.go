@@ -64,6 +64,19 @@ type Source interface {
 	SourceKind() string
 }
 
+// ReadOnlySource is implemented by sources which can be configured with
+// readOnly, so that the tools executing the SQL statements of clients reject
+// statements writing to the database.
+type ReadOnlySource interface {
+	ReadOnly() bool
+}
+
+// IsReadOnly reports whether s is a ReadOnlySource configured with readOnly.
+func IsReadOnly(s Source) bool {
+	ro, ok := s.(ReadOnlySource)
+	return ok && ro.ReadOnly()
+}
+
 // InitConnectionSpan adds a span for database pool connection initialization
 func InitConnectionSpan(ctx context.Context, tracer trace.Tracer, sourceKind, sourceName string) (context.Context, trace.Span) {
 	ctx, span := tracer.Start(
@@ -51,6 +51,9 @@ type Config struct {
 	Instance string          `yaml:"instance" validate:"required"`
 	Dialect  sources.Dialect `yaml:"dialect" validate:"required"`
 	Database string          `yaml:"database" validate:"required"`
+	// ReadOnly rejects the statements of execute-sql tools writing to the
+	// database, and runs them in read-only transactions.
+	ReadOnly bool `yaml:"readOnly"`
 }
 
 func (r Config) SourceConfigKind() string {
@@ -64,27 +67,36 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 	}
 
 	s := &Source{
-		Name:    r.Name,
-		Kind:    SourceKind,
-		Client:  client,
-		Dialect: r.Dialect.String(),
+		Name:     r.Name,
+		Kind:     SourceKind,
+		Client:   client,
+		Dialect:  r.Dialect.String(),
+		readOnly: r.ReadOnly,
 	}
 	return s, nil
 }
 
 var _ sources.Source = &Source{}
+var _ sources.ReadOnlySource = &Source{}
 
 type Source struct {
 	Name    string `yaml:"name"`
 	Kind    string `yaml:"kind"`
 	Client  *spanner.Client
 	Dialect string
+
+	readOnly bool
 }
 
 func (s *Source) SourceKind() string {
 	return SourceKind
 }
 
+// ReadOnly reports whether the source is configured with readOnly.
+func (s *Source) ReadOnly() bool {
+	return s.readOnly
+}
+
 func (s *Source) SpannerClient() *spanner.Client {
 	return s.Client
 }
@@ -74,6 +74,8 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 }
 
 var _ sources.Source = &Source{}
+var _ sources.TableDescriber = &Source{}
+var _ sources.SchemaLister = &Source{}
 
 type Source struct {
 	Name string `yaml:"name"`
@@ -89,6 +91,27 @@ func (s *Source) SQLiteDB() *sql.DB {
 	return s.Db
 }
 
+func (s *Source) DescribeTable(ctx context.Context, table string) ([]sources.Column, error) {
+	schema, name := sources.SplitTableName(table)
+	if schema == "" {
+		schema = "main"
+	}
+	return sources.DescribeTableSQL(ctx, s.Db, "SELECT name, type FROM pragma_table_info(?, ?) ORDER BY cid", name, schema)
+}
+
+// schemaQuery lists the columns of the tables and views of the main
+// database. SQLite has no comments. An INTEGER primary key is an alias of the
+// rowid, which is generated.
+const schemaQuery = `SELECT '', m.name, '', p.name, p.type, '', p.pk > 0, p."notnull" = 0 AND p.pk = 0,
+p.dflt_value IS NOT NULL OR (p.pk > 0 AND upper(p.type) = 'INTEGER' AND (SELECT count(*) FROM pragma_table_info(m.name) k WHERE k.pk > 0) = 1)
+FROM sqlite_schema m JOIN pragma_table_info(m.name) p
+WHERE m.type IN ('table', 'view') AND m.name NOT LIKE 'sqlite\_%' ESCAPE '\'
+ORDER BY m.name, p.cid`
+
+func (s *Source) ListSchema(ctx context.Context) ([]sources.Table, error) {
+	return sources.ListSchemaSQL(ctx, s.Db, schemaQuery)
+}
+
 func initSQLiteConnection(ctx context.Context, tracer trace.Tracer, name, dbPath string) (*sql.DB, error) {
 	//nolint:all // Reassigned ctx
 	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, name)
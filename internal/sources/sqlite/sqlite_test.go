@@ -15,6 +15,8 @@
 package sqlite_test
 
 import (
+	"context"
+	"path/filepath"
 	"testing"
 
 	yaml "github.com/goccy/go-yaml"
@@ -23,6 +25,7 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/sources/sqlite"
 	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
 func TestParseFromYamlSQLite(t *testing.T) {
@@ -64,3 +67,46 @@ func TestParseFromYamlSQLite(t *testing.T) {
 		})
 	}
 }
+
+func TestListSchema(t *testing.T) {
+	ctx := context.Background()
+	cfg := sqlite.Config{Name: "my-sqlite-db", Kind: sqlite.SourceKind, Database: filepath.Join(t.TempDir(), "test.db")}
+	src, err := cfg.Initialize(ctx, noop.NewTracerProvider().Tracer("test"))
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	db := src.(*sqlite.Source).SQLiteDB()
+	for _, stmt := range []string{
+		"CREATE TABLE orders (id INTEGER PRIMARY KEY, customer_id INTEGER NOT NULL, total REAL, status TEXT DEFAULT 'new')",
+		"CREATE TABLE customers (id INTEGER PRIMARY KEY, name TEXT)",
+		"CREATE VIEW big_orders AS SELECT id, total FROM orders WHERE total > 100",
+	} {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("unable to set up database: %s", err)
+		}
+	}
+
+	got, err := src.(*sqlite.Source).ListSchema(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := []sources.Table{
+		{Name: "big_orders", Columns: []sources.Column{
+			{Name: "id", Type: "INTEGER", Nullable: true},
+			{Name: "total", Type: "REAL", Nullable: true},
+		}},
+		{Name: "customers", Columns: []sources.Column{
+			{Name: "id", Type: "INTEGER", PrimaryKey: true, Generated: true},
+			{Name: "name", Type: "TEXT", Nullable: true},
+		}},
+		{Name: "orders", Columns: []sources.Column{
+			{Name: "id", Type: "INTEGER", PrimaryKey: true, Generated: true},
+			{Name: "customer_id", Type: "INTEGER"},
+			{Name: "total", Type: "REAL", Nullable: true},
+			{Name: "status", Type: "TEXT", Nullable: true, Generated: true},
+		}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect schema: diff %v", diff)
+	}
+}
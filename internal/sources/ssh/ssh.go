@@ -0,0 +1,322 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ssh
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+const SourceKind string = "ssh"
+
+// validate interface
+var _ sources.SourceConfig = Config{}
+
+func init() {
+	if !sources.Register(SourceKind, newConfig) {
+		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name, Port: "22", Timeout: "10s"} // Default port and dial timeout
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name                       string `yaml:"name" validate:"required"`
+	Kind                       string `yaml:"kind" validate:"required"`
+	Host                       string `yaml:"host" validate:"required"`
+	Port                       string `yaml:"port"`
+	User                       string `yaml:"user" validate:"required"`
+	PrivateKey                 string `yaml:"privateKey"`
+	PrivateKeyPath             string `yaml:"privateKeyPath"`
+	Passphrase                 string `yaml:"passphrase"`
+	HostKey                    string `yaml:"hostKey"`
+	KnownHostsFile             string `yaml:"knownHostsFile"`
+	DisableHostKeyVerification bool   `yaml:"disableHostKeyVerification"`
+	Timeout                    string `yaml:"timeout"`
+}
+
+func (r Config) SourceConfigKind() string {
+	return SourceKind
+}
+
+func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	client, err := initSSHClient(ctx, tracer, r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create client: %w", err)
+	}
+
+	// Verify the server is reachable and the key is accepted
+	if err := client.connect(); err != nil {
+		return nil, fmt.Errorf("unable to connect successfully: %w", err)
+	}
+
+	s := &Source{
+		Name:   r.Name,
+		Kind:   SourceKind,
+		Client: client,
+	}
+	return s, nil
+}
+
+var _ sources.Source = &Source{}
+
+type Source struct {
+	Name   string `yaml:"name"`
+	Kind   string `yaml:"kind"`
+	Client *Client
+}
+
+func (s *Source) SourceKind() string {
+	return SourceKind
+}
+
+func (s *Source) SSHClient() *Client {
+	return s.Client
+}
+
+// Client runs commands on a remote server. The underlying connection is shared
+// by every command and is re-established if it drops.
+type Client struct {
+	Address string
+	Config  *ssh.ClientConfig
+
+	mu   sync.Mutex
+	conn *ssh.Client
+}
+
+// Result is the outcome of a remote command.
+type Result struct {
+	ExitCode  int    `json:"exitCode"`
+	Stdout    string `json:"stdout"`
+	Stderr    string `json:"stderr"`
+	Truncated bool   `json:"truncated"`
+}
+
+// connect dials the server if there is no open connection.
+func (c *Client) connect() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.conn != nil {
+		return nil
+	}
+	conn, err := ssh.Dial("tcp", c.Address, c.Config)
+	if err != nil {
+		return err
+	}
+	c.conn = conn
+	return nil
+}
+
+// newSession opens a session, reconnecting once if the connection dropped.
+func (c *Client) newSession() (*ssh.Session, error) {
+	if err := c.connect(); err != nil {
+		return nil, fmt.Errorf("unable to connect: %w", err)
+	}
+	c.mu.Lock()
+	conn := c.conn
+	c.mu.Unlock()
+	session, err := conn.NewSession()
+	if err == nil {
+		return session, nil
+	}
+
+	// the connection is likely broken, so drop it and try again
+	c.mu.Lock()
+	if c.conn == conn {
+		_ = c.conn.Close()
+		c.conn = nil
+	}
+	c.mu.Unlock()
+	if err := c.connect(); err != nil {
+		return nil, fmt.Errorf("unable to reconnect: %w", err)
+	}
+	c.mu.Lock()
+	conn = c.conn
+	c.mu.Unlock()
+	return conn.NewSession()
+}
+
+// Run executes command in a new session. At most maxOutputBytes of stdout and
+// stderr are kept each. A non-zero exit status is reported in the result
+// rather than as an error. If ctx is done before the command exits, the
+// session is closed and ctx.Err() is returned.
+func (c *Client) Run(ctx context.Context, command string, maxOutputBytes int64) (*Result, error) {
+	session, err := c.newSession()
+	if err != nil {
+		return nil, fmt.Errorf("unable to open session: %w", err)
+	}
+	defer session.Close()
+
+	stdout := &limitedBuffer{limit: maxOutputBytes}
+	stderr := &limitedBuffer{limit: maxOutputBytes}
+	session.Stdout = stdout
+	session.Stderr = stderr
+
+	done := make(chan error, 1)
+	go func() { done <- session.Run(command) }()
+
+	select {
+	case <-ctx.Done():
+		_ = session.Signal(ssh.SIGKILL)
+		_ = session.Close()
+		return nil, ctx.Err()
+	case err = <-done:
+	}
+
+	result := &Result{
+		Stdout:    stdout.String(),
+		Stderr:    stderr.String(),
+		Truncated: stdout.truncated || stderr.truncated,
+	}
+	var exitErr *ssh.ExitError
+	switch {
+	case err == nil:
+	case errors.As(err, &exitErr):
+		result.ExitCode = exitErr.ExitStatus()
+	default:
+		return nil, fmt.Errorf("unable to run command: %w", err)
+	}
+	return result, nil
+}
+
+// limitedBuffer keeps the first limit bytes written to it and discards the
+// rest, so a chatty command can't exhaust memory.
+type limitedBuffer struct {
+	buf       bytes.Buffer
+	limit     int64
+	truncated bool
+}
+
+var _ io.Writer = &limitedBuffer{}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	remaining := b.limit - int64(b.buf.Len())
+	if remaining <= 0 {
+		b.truncated = b.truncated || len(p) > 0
+		return len(p), nil
+	}
+	if int64(len(p)) > remaining {
+		b.buf.Write(p[:remaining])
+		b.truncated = true
+		return len(p), nil
+	}
+	return b.buf.Write(p)
+}
+
+func (b *limitedBuffer) String() string {
+	return b.buf.String()
+}
+
+// hostKeyCallback builds the host key check from the configuration. A host key
+// source is required unless verification is explicitly disabled.
+func hostKeyCallback(r Config) (ssh.HostKeyCallback, error) {
+	switch {
+	case r.HostKey != "":
+		key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(r.HostKey))
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse hostKey: %w", err)
+		}
+		return ssh.FixedHostKey(key), nil
+	case r.KnownHostsFile != "":
+		callback, err := knownhosts.New(r.KnownHostsFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read knownHostsFile: %w", err)
+		}
+		return callback, nil
+	case r.DisableHostKeyVerification:
+		//nolint:gosec // explicitly requested by the configuration
+		return ssh.InsecureIgnoreHostKey(), nil
+	}
+	return nil, fmt.Errorf("one of hostKey or knownHostsFile must be set, unless disableHostKeyVerification is true")
+}
+
+// signer parses the private key used to authenticate.
+func signer(r Config) (ssh.Signer, error) {
+	key := []byte(r.PrivateKey)
+	if r.PrivateKeyPath != "" {
+		if r.PrivateKey != "" {
+			return nil, fmt.Errorf("only one of privateKey or privateKeyPath can be set")
+		}
+		b, err := os.ReadFile(r.PrivateKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read privateKeyPath: %w", err)
+		}
+		key = b
+	}
+	if len(key) == 0 {
+		return nil, fmt.Errorf("one of privateKey or privateKeyPath must be set")
+	}
+	var s ssh.Signer
+	var err error
+	if r.Passphrase != "" {
+		s, err = ssh.ParsePrivateKeyWithPassphrase(key, []byte(r.Passphrase))
+	} else {
+		s, err = ssh.ParsePrivateKey(key)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse private key: %w", err)
+	}
+	return s, nil
+}
+
+func initSSHClient(ctx context.Context, tracer trace.Tracer, r Config) (*Client, error) {
+	//nolint:all // Reassigned ctx
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, r.Name)
+	defer span.End()
+
+	duration, err := time.ParseDuration(r.Timeout)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse Timeout string as time.Duration: %s", err)
+	}
+
+	callback, err := hostKeyCallback(r)
+	if err != nil {
+		return nil, err
+	}
+	s, err := signer(r)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &Client{
+		Address: net.JoinHostPort(r.Host, r.Port),
+		Config: &ssh.ClientConfig{
+			User:            r.User,
+			Auth:            []ssh.AuthMethod{ssh.PublicKeys(s)},
+			HostKeyCallback: callback,
+			Timeout:         duration,
+		},
+	}
+	return client, nil
+}
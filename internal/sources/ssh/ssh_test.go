@@ -0,0 +1,136 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ssh_test
+
+import (
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources/ssh"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+)
+
+func TestParseFromYamlSSH(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		want server.SourceConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			sources:
+				my-ssh-instance:
+					kind: ssh
+					host: 10.0.0.12
+					user: diagnostics
+					privateKeyPath: /etc/toolbox/id_ed25519
+					knownHostsFile: /etc/toolbox/known_hosts
+			`,
+			want: server.SourceConfigs{
+				"my-ssh-instance": ssh.Config{
+					Name:           "my-ssh-instance",
+					Kind:           ssh.SourceKind,
+					Host:           "10.0.0.12",
+					Port:           "22",
+					User:           "diagnostics",
+					PrivateKeyPath: "/etc/toolbox/id_ed25519",
+					KnownHostsFile: "/etc/toolbox/known_hosts",
+					Timeout:        "10s",
+				},
+			},
+		},
+		{
+			desc: "advanced example",
+			in: `
+			sources:
+				my-ssh-instance:
+					kind: ssh
+					host: bastion.example.com
+					port: 2222
+					user: diagnostics
+					privateKey: my-key
+					passphrase: my-passphrase
+					hostKey: ssh-ed25519 AAAA
+					timeout: 5s
+			`,
+			want: server.SourceConfigs{
+				"my-ssh-instance": ssh.Config{
+					Name:       "my-ssh-instance",
+					Kind:       ssh.SourceKind,
+					Host:       "bastion.example.com",
+					Port:       "2222",
+					User:       "diagnostics",
+					PrivateKey: "my-key",
+					Passphrase: "my-passphrase",
+					HostKey:    "ssh-ed25519 AAAA",
+					Timeout:    "5s",
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if !cmp.Equal(tc.want, got.Sources) {
+				t.Fatalf("incorrect parse: want %v, got %v", tc.want, got.Sources)
+			}
+		})
+	}
+}
+
+func TestFailParseFromYaml(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		err  string
+	}{
+		{
+			desc: "missing required field",
+			in: `
+			sources:
+				my-ssh-instance:
+					kind: ssh
+					host: 10.0.0.12
+			`,
+			err: "unable to parse source \"my-ssh-instance\" as \"ssh\": Key: 'Config.User' Error:Field validation for 'User' failed on the 'required' tag",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err == nil {
+				t.Fatalf("expect parsing to fail")
+			}
+			errStr := err.Error()
+			if errStr != tc.err {
+				t.Fatalf("unexpected error: got %q, want %q", errStr, tc.err)
+			}
+		})
+	}
+}
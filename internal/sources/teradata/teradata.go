@@ -0,0 +1,206 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package teradata
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const SourceKind string = "teradata"
+
+// validate interface
+var _ sources.SourceConfig = Config{}
+
+func init() {
+	if !sources.Register(SourceKind, newConfig) {
+		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name     string `yaml:"name" validate:"required"`
+	Kind     string `yaml:"kind" validate:"required"`
+	URL      string `yaml:"url" validate:"required"`
+	System   string `yaml:"system" validate:"required"`
+	User     string `yaml:"user" validate:"required"`
+	Password string `yaml:"password" validate:"required"`
+	// QueryBand is set on every query sent to the system, in addition to the
+	// query band of the tool sending it.
+	QueryBand map[string]string `yaml:"queryBand"`
+}
+
+func (r Config) SourceConfigKind() string {
+	return SourceKind
+}
+
+func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	//nolint:all // Reassigned ctx
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, r.Name)
+	defer span.End()
+
+	if _, err := url.ParseRequestURI(r.URL); err != nil {
+		return nil, fmt.Errorf("failed to parse url: %w", err)
+	}
+	if err := ValidateQueryBand(r.QueryBand); err != nil {
+		return nil, err
+	}
+
+	s := &Source{
+		Name:      r.Name,
+		Kind:      SourceKind,
+		URL:       strings.TrimSuffix(r.URL, "/"),
+		System:    r.System,
+		User:      r.User,
+		Password:  r.Password,
+		QueryBand: r.QueryBand,
+		Client:    &http.Client{Timeout: 30 * time.Second},
+	}
+	return s, nil
+}
+
+var _ sources.Source = &Source{}
+
+type Source struct {
+	Name      string `yaml:"name"`
+	Kind      string `yaml:"kind"`
+	URL       string
+	System    string
+	User      string
+	Password  string
+	QueryBand map[string]string
+	Client    *http.Client
+}
+
+func (s *Source) SourceKind() string {
+	return SourceKind
+}
+
+// queryRequest is the body of a request to run a query with the Query
+// Service.
+type queryRequest struct {
+	Query          string            `json:"query"`
+	Format         string            `json:"format"`
+	IncludeColumns bool              `json:"includeColumns"`
+	Params         []any             `json:"params,omitempty"`
+	QueryBands     map[string]string `json:"queryBands,omitempty"`
+}
+
+type queryResponse struct {
+	Results []struct {
+		ResultSet bool             `json:"resultSet"`
+		Data      []map[string]any `json:"data"`
+		RowCount  int64            `json:"rowCount"`
+	} `json:"results"`
+}
+
+// TeradataQuery runs a query with ? placeholders with the Query Service, in
+// a session with the query band of the source and queryBand, and returns the
+// rows of its result sets. Keys of queryBand override those of the source.
+func (s *Source) TeradataQuery(ctx context.Context, query string, params []any, queryBand map[string]string) ([]any, error) {
+	if err := ValidateQueryBand(queryBand); err != nil {
+		return nil, err
+	}
+	band := maps.Clone(s.QueryBand)
+	if band == nil {
+		band = make(map[string]string, len(queryBand))
+	}
+	maps.Copy(band, queryBand)
+
+	b, err := json.Marshal(queryRequest{
+		Query:          query,
+		Format:         "OBJECT",
+		IncludeColumns: true,
+		Params:         params,
+		QueryBands:     band,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+	u := fmt.Sprintf("%s/systems/%s/queries", s.URL, url.PathEscape(s.System))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.SetBasicAuth(s.User, s.Password)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("unexpected status code: %d, response body: %s", resp.StatusCode, string(respBody))
+	}
+
+	// decode numbers as json.Number so decimals and large integers keep
+	// every digit
+	var result queryResponse
+	d := json.NewDecoder(bytes.NewReader(respBody))
+	d.UseNumber()
+	if err := d.Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	out := []any{}
+	for _, r := range result.Results {
+		if !r.ResultSet {
+			out = append(out, map[string]any{"rowCount": r.RowCount})
+			continue
+		}
+		for _, row := range r.Data {
+			out = append(out, row)
+		}
+	}
+	return out, nil
+}
+
+// ValidateQueryBand returns an error if a name or value of a query band
+// contains characters that delimit query band pairs.
+func ValidateQueryBand(queryBand map[string]string) error {
+	for k, v := range queryBand {
+		if k == "" || strings.ContainsAny(k, "=;") || strings.ContainsAny(v, "=;") {
+			return fmt.Errorf("invalid query band pair %q=%q: names and values must not contain '=' or ';'", k, v)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package teradata_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources/teradata"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestParseFromYamlTeradata(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		want server.SourceConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			sources:
+				my-teradata:
+					kind: teradata
+					url: https://teradata.example.com:1443
+					system: prod
+					user: my_user
+					password: my_pass
+					queryBand:
+						ApplicationName: toolbox
+			`,
+			want: server.SourceConfigs{
+				"my-teradata": teradata.Config{
+					Name:      "my-teradata",
+					Kind:      teradata.SourceKind,
+					URL:       "https://teradata.example.com:1443",
+					System:    "prod",
+					User:      "my_user",
+					Password:  "my_pass",
+					QueryBand: map[string]string{"ApplicationName": "toolbox"},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if !cmp.Equal(tc.want, got.Sources) {
+				t.Fatalf("incorrect parse: want %v, got %v", tc.want, got.Sources)
+			}
+		})
+	}
+}
+
+func TestFailParseFromYaml(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		err  string
+	}{
+		{
+			desc: "missing required field",
+			in: `
+			sources:
+				my-teradata:
+					kind: teradata
+					url: https://teradata.example.com:1443
+					user: my_user
+					password: my_pass
+			`,
+			err: "unable to parse source \"my-teradata\" as \"teradata\": Key: 'Config.System' Error:Field validation for 'System' failed on the 'required' tag",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err == nil {
+				t.Fatalf("expect parsing to fail")
+			}
+			errStr := err.Error()
+			if errStr != tc.err {
+				t.Fatalf("unexpected error: got %q, want %q", errStr, tc.err)
+			}
+		})
+	}
+}
+
+func TestTeradataQuery(t *testing.T) {
+	var gotBody map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/systems/prod/queries" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if user, pass, ok := r.BasicAuth(); !ok || user != "my_user" || pass != "my_pass" {
+			t.Errorf("unexpected credentials: %q %q", user, pass)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&gotBody); err != nil {
+			t.Errorf("unable to decode request: %s", err)
+		}
+		w.Write([]byte(`{"queryDuration": 12, "results": [{"resultSet": true, "columns": [{"name": "id", "type": "DECIMAL"}], "data": [{"id": 12345678901234567890.5}], "rowCount": 1}]}`))
+	}))
+	defer srv.Close()
+
+	cfg := teradata.Config{
+		Name:      "my-teradata",
+		Kind:      teradata.SourceKind,
+		URL:       srv.URL,
+		System:    "prod",
+		User:      "my_user",
+		Password:  "my_pass",
+		QueryBand: map[string]string{"ApplicationName": "toolbox", "Team": "default"},
+	}
+	src, err := cfg.Initialize(context.Background(), noop.NewTracerProvider().Tracer("test"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	got, err := src.(*teradata.Source).TeradataQuery(context.Background(), "SELECT id FROM orders WHERE region = ?", []any{"EMEA"}, map[string]string{"Team": "finance"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	wantBody := map[string]any{
+		"query":          "SELECT id FROM orders WHERE region = ?",
+		"format":         "OBJECT",
+		"includeColumns": true,
+		"params":         []any{"EMEA"},
+		"queryBands":     map[string]any{"ApplicationName": "toolbox", "Team": "finance"},
+	}
+	if diff := cmp.Diff(wantBody, gotBody); diff != "" {
+		t.Fatalf("incorrect request: diff %v", diff)
+	}
+	want := []any{map[string]any{"id": json.Number("12345678901234567890.5")}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect result: diff %v", diff)
+	}
+}
+
+func TestInitializeInvalidQueryBand(t *testing.T) {
+	cfg := teradata.Config{
+		Name:      "my-teradata",
+		Kind:      teradata.SourceKind,
+		URL:       "https://teradata.example.com:1443",
+		System:    "prod",
+		User:      "my_user",
+		Password:  "my_pass",
+		QueryBand: map[string]string{"Team": "a;b"},
+	}
+	_, err := cfg.Initialize(context.Background(), noop.NewTracerProvider().Tracer("test"))
+	if err == nil || !strings.Contains(err.Error(), "invalid query band pair") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
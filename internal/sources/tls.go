@@ -0,0 +1,135 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sources
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// SSL modes of the SQL sources, named after the sslmode of libpq.
+const (
+	SSLModeDisable    string = "disable"
+	SSLModeRequire    string = "require"
+	SSLModeVerifyCA   string = "verify-ca"
+	SSLModeVerifyFull string = "verify-full"
+)
+
+// inlinePEM matches the blocks of inline PEM, whose line breaks may have been
+// folded into spaces by YAML.
+var inlinePEM = regexp.MustCompile(`-----BEGIN ([A-Z0-9 ]+)-----([^-]*)-----END ([A-Z0-9 ]+)-----`)
+
+// NewTLSConfig returns the TLS config of sslMode, or nil if TLS is disabled.
+// rootCert, cert and key are either file paths or inline PEM. As with libpq,
+// "require" verifies the server certificate like "verify-ca" if rootCert is
+// set. The ServerName of the config is left to the caller.
+func NewTLSConfig(sslMode, rootCert, cert, key string) (*tls.Config, error) {
+	if sslMode == SSLModeDisable {
+		return nil, nil
+	}
+
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if rootCert != "" {
+		rootPEM, err := loadPEM(rootCert)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load sslRootCert: %w", err)
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(rootPEM) {
+			return nil, fmt.Errorf("unable to parse certificates of sslRootCert")
+		}
+	}
+	if cert != "" || key != "" {
+		if cert == "" || key == "" {
+			return nil, fmt.Errorf("sslCert and sslKey must be set together")
+		}
+		certPEM, err := loadPEM(cert)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load sslCert: %w", err)
+		}
+		keyPEM, err := loadPEM(key)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load sslKey: %w", err)
+		}
+		pair, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{pair}
+	}
+
+	switch sslMode {
+	case SSLModeVerifyFull:
+	case SSLModeVerifyCA:
+		verifyChainOnly(config)
+	case SSLModeRequire:
+		if rootCert != "" {
+			verifyChainOnly(config)
+		} else {
+			config.InsecureSkipVerify = true
+		}
+	default:
+		return nil, fmt.Errorf("invalid sslMode %q: must be one of %q", sslMode, []string{SSLModeDisable, SSLModeRequire, SSLModeVerifyCA, SSLModeVerifyFull})
+	}
+	return config, nil
+}
+
+// verifyChainOnly verifies the certificate chain of the server, but not its
+// host name.
+func verifyChainOnly(config *tls.Config) {
+	roots := config.RootCAs
+	config.InsecureSkipVerify = true
+	config.VerifyConnection = func(cs tls.ConnectionState) error {
+		if len(cs.PeerCertificates) == 0 {
+			return fmt.Errorf("server did not present a certificate")
+		}
+		opts := x509.VerifyOptions{Roots: roots, Intermediates: x509.NewCertPool()}
+		for _, c := range cs.PeerCertificates[1:] {
+			opts.Intermediates.AddCert(c)
+		}
+		_, err := cs.PeerCertificates[0].Verify(opts)
+		return err
+	}
+}
+
+// loadPEM returns the PEM of value, which is either inline PEM or the path of
+// a PEM file.
+func loadPEM(value string) ([]byte, error) {
+	if !strings.Contains(value, "-----BEGIN ") {
+		return os.ReadFile(value)
+	}
+	// An inline PEM set through an environment variable loses its line
+	// breaks when quoted in YAML, so the blocks are encoded again
+	var out []byte
+	for _, m := range inlinePEM.FindAllStringSubmatch(value, -1) {
+		if m[1] != m[3] {
+			return nil, fmt.Errorf("mismatched PEM block %q", m[1])
+		}
+		block, _ := pem.Decode([]byte(fmt.Sprintf("-----BEGIN %s-----\n%s\n-----END %s-----\n", m[1], strings.Join(strings.Fields(m[2]), "\n"), m[1])))
+		if block == nil {
+			return nil, fmt.Errorf("invalid PEM block %q", m[1])
+		}
+		out = append(out, pem.EncodeToMemory(block)...)
+	}
+	if out == nil {
+		return nil, fmt.Errorf("no PEM block found")
+	}
+	return out, nil
+}
@@ -0,0 +1,161 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sources_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/googleapis/genai-toolbox/internal/sources"
+)
+
+// newCert returns a self-signed certificate and the PEM of it and its key.
+func newCert(t *testing.T, name string) (*x509.Certificate, string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unable to generate key: %s", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("unable to create certificate: %s", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("unable to parse certificate: %s", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("unable to marshal key: %s", err)
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return cert, string(certPEM), string(keyPEM)
+}
+
+func TestNewTLSConfig(t *testing.T) {
+	ca, caPEM, _ := newCert(t, "ca")
+	other, _, _ := newCert(t, "other")
+	_, clientPEM, clientKeyPEM := newCert(t, "client")
+
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.pem")
+	if err := os.WriteFile(caFile, []byte(caPEM), 0o600); err != nil {
+		t.Fatalf("unable to write file: %s", err)
+	}
+	// the line breaks of a PEM quoted in YAML are folded into spaces
+	foldedCA := strings.ReplaceAll(strings.TrimSpace(caPEM), "\n", " ")
+
+	t.Run("disable", func(t *testing.T) {
+		got, err := sources.NewTLSConfig(sources.SSLModeDisable, caFile, "", "")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got != nil {
+			t.Fatalf("expected no TLS config, got %v", got)
+		}
+	})
+
+	t.Run("require", func(t *testing.T) {
+		got, err := sources.NewTLSConfig(sources.SSLModeRequire, "", "", "")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !got.InsecureSkipVerify || got.VerifyConnection != nil {
+			t.Fatalf("expected the server certificate not to be verified")
+		}
+	})
+
+	for _, mode := range []string{sources.SSLModeRequire, sources.SSLModeVerifyCA} {
+		t.Run(mode+" with root cert", func(t *testing.T) {
+			got, err := sources.NewTLSConfig(mode, caFile, "", "")
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got.VerifyConnection == nil {
+				t.Fatalf("expected the certificate chain to be verified")
+			}
+			if err := got.VerifyConnection(tls.ConnectionState{PeerCertificates: []*x509.Certificate{ca}}); err != nil {
+				t.Fatalf("unexpected error verifying the root: %s", err)
+			}
+			if err := got.VerifyConnection(tls.ConnectionState{PeerCertificates: []*x509.Certificate{other}}); err == nil {
+				t.Fatalf("expected an error verifying an unknown certificate")
+			}
+		})
+	}
+
+	t.Run("verify-full with inline certs", func(t *testing.T) {
+		got, err := sources.NewTLSConfig(sources.SSLModeVerifyFull, foldedCA, clientPEM, clientKeyPEM)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got.InsecureSkipVerify {
+			t.Fatalf("expected the host name to be verified")
+		}
+		if _, err := ca.Verify(x509.VerifyOptions{Roots: got.RootCAs}); err != nil {
+			t.Fatalf("expected the root cert to be trusted: %s", err)
+		}
+		if len(got.Certificates) != 1 {
+			t.Fatalf("expected a client certificate, got %d", len(got.Certificates))
+		}
+	})
+
+	errCases := []struct {
+		desc     string
+		mode     string
+		rootCert string
+		cert     string
+		key      string
+		err      string
+	}{
+		{desc: "invalid mode", mode: "prefer", err: `invalid sslMode "prefer"`},
+		{desc: "missing file", mode: sources.SSLModeVerifyFull, rootCert: filepath.Join(dir, "missing.pem"), err: "unable to load sslRootCert"},
+		{desc: "invalid root cert", mode: sources.SSLModeVerifyFull, rootCert: "-----BEGIN CERTIFICATE----- abc -----END CERTIFICATE-----", err: "sslRootCert"},
+		{desc: "cert without key", mode: sources.SSLModeVerifyFull, cert: clientPEM, err: "sslCert and sslKey must be set together"},
+		{desc: "mismatched key", mode: sources.SSLModeVerifyFull, cert: caPEM, key: clientKeyPEM, err: "unable to load client certificate"},
+	}
+	for _, tc := range errCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := sources.NewTLSConfig(tc.mode, tc.rootCert, tc.cert, tc.key)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("unexpected error: got %q, want it to contain %q", err, tc.err)
+			}
+		})
+	}
+}
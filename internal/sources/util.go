@@ -23,6 +23,8 @@ import (
 	"strings"
 
 	"cloud.google.com/go/cloudsqlconn"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/feature/rds/auth"
 	"golang.org/x/oauth2/google"
 )
 
@@ -102,3 +104,27 @@ func GetIAMAccessToken(ctx context.Context) (string, error) {
 	}
 	return token.AccessToken, nil
 }
+
+// GetAWSRDSAuthToken generates a short-lived IAM authentication token for an
+// Amazon RDS or Aurora database user. The token is used in place of a password
+// and is valid for 15 minutes.
+func GetAWSRDSAuthToken(ctx context.Context, region, host, port, user string) (string, error) {
+	opts := []func(*awsconfig.LoadOptions) error{}
+	if region != "" {
+		opts = append(opts, awsconfig.WithRegion(region))
+	}
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return "", fmt.Errorf("unable to load aws config: %w", err)
+	}
+	if cfg.Region == "" {
+		return "", fmt.Errorf("aws region is not set: set region in the source config or AWS_REGION")
+	}
+
+	endpoint := fmt.Sprintf("%s:%s", host, port)
+	token, err := auth.BuildAuthToken(ctx, endpoint, cfg.Region, user, cfg.Credentials)
+	if err != nil {
+		return "", fmt.Errorf("unable to build rds auth token: %w", err)
+	}
+	return token, nil
+}
@@ -0,0 +1,155 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vertica
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	_ "github.com/vertica/vertica-sql-go"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const SourceKind string = "vertica"
+
+// validate interface
+var _ sources.SourceConfig = Config{}
+
+func init() {
+	if !sources.Register(SourceKind, newConfig) {
+		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name     string `yaml:"name" validate:"required"`
+	Kind     string `yaml:"kind" validate:"required"`
+	Host     string `yaml:"host" validate:"required"`
+	Port     string `yaml:"port" validate:"required"`
+	User     string `yaml:"user" validate:"required"`
+	Password string `yaml:"password" validate:"required"`
+	Database string `yaml:"database" validate:"required"`
+	TLSMode  string `yaml:"tlsMode" validate:"omitempty,oneof=none server server-strict"`
+	// BackupNodes are "host:port" addresses of other nodes tried in order
+	// when the host can't be reached.
+	BackupNodes []string `yaml:"backupNodes"`
+	LoadBalance bool     `yaml:"loadBalance"`
+}
+
+func (r Config) SourceConfigKind() string {
+	return SourceKind
+}
+
+func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	db, err := initVerticaConnection(ctx, tracer, r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create db connection: %w", err)
+	}
+
+	err = db.PingContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect successfully: %w", err)
+	}
+
+	s := &Source{
+		Name: r.Name,
+		Kind: SourceKind,
+		Db:   db,
+	}
+	return s, nil
+}
+
+var _ sources.Source = &Source{}
+var _ sources.TableDescriber = &Source{}
+
+type Source struct {
+	Name string `yaml:"name"`
+	Kind string `yaml:"kind"`
+	Db   *sql.DB
+}
+
+func (s *Source) SourceKind() string {
+	return SourceKind
+}
+
+func (s *Source) VerticaDB() *sql.DB {
+	return s.Db
+}
+
+// columnsQuery lists the columns of a table, in the current schema when the
+// schema is empty. Vertica identifiers are case insensitive, but the catalog
+// keeps the case they were created with.
+const columnsQuery = `SELECT column_name, data_type FROM v_catalog.columns
+WHERE LOWER(table_name) = LOWER(?) AND LOWER(table_schema) = LOWER(COALESCE(NULLIF(?, ''), CURRENT_SCHEMA()))
+ORDER BY ordinal_position`
+
+func (s *Source) DescribeTable(ctx context.Context, table string) ([]sources.Column, error) {
+	schema, name := sources.SplitTableName(table)
+	return sources.DescribeTableSQL(ctx, s.Db, columnsQuery, name, schema)
+}
+
+// DSN returns the connection URL of the source.
+func (r Config) DSN() string {
+	query := url.Values{}
+	if r.TLSMode != "" {
+		query.Set("tlsmode", r.TLSMode)
+	}
+	if len(r.BackupNodes) > 0 {
+		query.Set("backup_server_node", strings.Join(r.BackupNodes, ","))
+	}
+	if r.LoadBalance {
+		query.Set("connection_load_balance", "1")
+	}
+	u := &url.URL{
+		Scheme:   "vertica",
+		User:     url.UserPassword(r.User, r.Password),
+		Host:     net.JoinHostPort(r.Host, r.Port),
+		Path:     r.Database,
+		RawQuery: query.Encode(),
+	}
+	return u.String()
+}
+
+func initVerticaConnection(ctx context.Context, tracer trace.Tracer, r Config) (*sql.DB, error) {
+	//nolint:all // Reassigned ctx
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, r.Name)
+	defer span.End()
+
+	for _, node := range r.BackupNodes {
+		if _, _, err := net.SplitHostPort(node); err != nil {
+			return nil, fmt.Errorf("invalid backup node %q: %w", node, err)
+		}
+	}
+
+	db, err := sql.Open("vertica", r.DSN())
+	if err != nil {
+		return nil, fmt.Errorf("sql.Open: %w", err)
+	}
+	return db, nil
+}
@@ -31,6 +31,15 @@ const (
 	toolInvokeCountName = "toolbox.server.tool.invoke.count"
 	mcpSseCountName     = "toolbox.server.mcp.sse.count"
 	mcpPostCountName    = "toolbox.server.mcp.post.count"
+
+	toolInvokeDurationName    = "toolbox.server.tool.invoke.duration"
+	toolInvokeRowsName        = "toolbox.server.tool.invoke.rows"
+	toolInvokeResultSizeName  = "toolbox.server.tool.invoke.result.size"
+	toolInvokeBytesBilledName = "toolbox.server.tool.invoke.bytes_billed"
+	toolContractDriftName     = "toolbox.server.tool.contract.drift"
+
+	toolCanaryInvokeCountName = "toolbox.server.tool.canary.invoke.count"
+	toolCanaryDurationName    = "toolbox.server.tool.canary.duration"
 )
 
 // Instrumentation defines the telemetry instrumentation for toolbox
@@ -42,6 +51,17 @@ type Instrumentation struct {
 	ToolInvoke metric.Int64Counter
 	McpSse     metric.Int64Counter
 	McpPost    metric.Int64Counter
+
+	// ToolInvokeDuration is recorded with the context of the invocation
+	// span, so that its exemplars link to the traces of invocations.
+	ToolInvokeDuration    metric.Float64Histogram
+	ToolInvokeRows        metric.Int64Histogram
+	ToolInvokeResultSize  metric.Int64Histogram
+	ToolInvokeBytesBilled metric.Int64Counter
+	ToolContractDrift     metric.Int64Counter
+
+	ToolCanaryInvoke   metric.Int64Counter
+	ToolCanaryDuration metric.Float64Histogram
 }
 
 func CreateTelemetryInstrumentation(versionString string) (*Instrumentation, error) {
@@ -96,14 +116,84 @@ func CreateTelemetryInstrumentation(versionString string) (*Instrumentation, err
 		return nil, fmt.Errorf("unable to create %s metric: %w", mcpPostCountName, err)
 	}
 
+	toolInvokeDuration, err := meter.Float64Histogram(
+		toolInvokeDurationName,
+		metric.WithDescription("Duration of tool invocations."),
+		metric.WithUnit("ms"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create %s metric: %w", toolInvokeDurationName, err)
+	}
+
+	toolInvokeRows, err := meter.Int64Histogram(
+		toolInvokeRowsName,
+		metric.WithDescription("Number of rows returned by tool invocations."),
+		metric.WithUnit("{row}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create %s metric: %w", toolInvokeRowsName, err)
+	}
+
+	toolInvokeResultSize, err := meter.Int64Histogram(
+		toolInvokeResultSizeName,
+		metric.WithDescription("Size of the JSON encoded results of tool invocations."),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create %s metric: %w", toolInvokeResultSizeName, err)
+	}
+
+	toolInvokeBytesBilled, err := meter.Int64Counter(
+		toolInvokeBytesBilledName,
+		metric.WithDescription("Number of bytes billed by the database for tool invocations, for sources reporting it."),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create %s metric: %w", toolInvokeBytesBilledName, err)
+	}
+
+	toolContractDrift, err := meter.Int64Counter(
+		toolContractDriftName,
+		metric.WithDescription("Number of columns of tool results drifting from the result schema declared by the tool, by kind of drift."),
+		metric.WithUnit("{column}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create %s metric: %w", toolContractDriftName, err)
+	}
+
+	toolCanaryInvoke, err := meter.Int64Counter(
+		toolCanaryInvokeCountName,
+		metric.WithDescription("Number of invocations of tools with a candidate definition, by variant."),
+		metric.WithUnit("{call}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create %s metric: %w", toolCanaryInvokeCountName, err)
+	}
+
+	toolCanaryDuration, err := meter.Float64Histogram(
+		toolCanaryDurationName,
+		metric.WithDescription("Duration of invocations of tools with a candidate definition, by variant."),
+		metric.WithUnit("ms"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create %s metric: %w", toolCanaryDurationName, err)
+	}
+
 	instrumentation := &Instrumentation{
-		Tracer:     tracer,
-		meter:      meter,
-		ToolsetGet: toolsetGet,
-		ToolGet:    toolGet,
-		ToolInvoke: toolInvoke,
-		McpSse:     mcpSse,
-		McpPost:    mcpPost,
+		Tracer:                tracer,
+		meter:                 meter,
+		ToolsetGet:            toolsetGet,
+		ToolGet:               toolGet,
+		ToolInvoke:            toolInvoke,
+		McpSse:                mcpSse,
+		McpPost:               mcpPost,
+		ToolInvokeDuration:    toolInvokeDuration,
+		ToolInvokeRows:        toolInvokeRows,
+		ToolInvokeResultSize:  toolInvokeResultSize,
+		ToolInvokeBytesBilled: toolInvokeBytesBilled,
+		ToolContractDrift:     toolContractDrift,
+		ToolCanaryInvoke:      toolCanaryInvoke,
+		ToolCanaryDuration:    toolCanaryDuration,
 	}
 	return instrumentation, nil
 }
@@ -0,0 +1,113 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+	"maps"
+	"sync"
+	"sync/atomic"
+)
+
+// InvocationStats collects statistics of a tool invocation which are only
+// known to the tool, such as the bytes billed by the database. Its methods are
+// safe to call on a nil InvocationStats, in which case they do nothing.
+type InvocationStats struct {
+	bytesBilled    atomic.Int64
+	hasBytesBilled atomic.Bool
+
+	mu            sync.Mutex
+	contractDrift map[string]int64
+	statement     string
+}
+
+// AddBytesBilled adds to the number of bytes billed by the invocation.
+func (s *InvocationStats) AddBytesBilled(n int64) {
+	if s == nil {
+		return
+	}
+	s.bytesBilled.Add(n)
+	s.hasBytesBilled.Store(true)
+}
+
+// BytesBilled returns the number of bytes billed by the invocation, and
+// whether it was reported by the tool.
+func (s *InvocationStats) BytesBilled() (int64, bool) {
+	if s == nil {
+		return 0, false
+	}
+	return s.bytesBilled.Load(), s.hasBytesBilled.Load()
+}
+
+// AddContractDrift counts a column of the result drifting from the result
+// schema declared by the tool, by kind of drift.
+func (s *InvocationStats) AddContractDrift(kind string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.contractDrift == nil {
+		s.contractDrift = make(map[string]int64)
+	}
+	s.contractDrift[kind]++
+}
+
+// ContractDrift returns the number of columns of the result drifting from
+// the result schema declared by the tool, by kind of drift.
+func (s *InvocationStats) ContractDrift() map[string]int64 {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return maps.Clone(s.contractDrift)
+}
+
+// SetStatement records the statement the invocation executes on its source,
+// replacing the one recorded before if the tool executes several.
+func (s *InvocationStats) SetStatement(statement string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.statement = statement
+}
+
+// Statement returns the last statement the invocation executed, or "" if the
+// tool doesn't record it.
+func (s *InvocationStats) Statement() string {
+	if s == nil {
+		return ""
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.statement
+}
+
+type invocationStatsKey struct{}
+
+// WithInvocationStats adds the statistics of a tool invocation to the context.
+func WithInvocationStats(ctx context.Context, stats *InvocationStats) context.Context {
+	return context.WithValue(ctx, invocationStatsKey{}, stats)
+}
+
+// InvocationStatsFromContext returns the statistics of the tool invocation of
+// the context, or nil if there is none.
+func InvocationStatsFromContext(ctx context.Context) *InvocationStats {
+	stats, _ := ctx.Value(invocationStatsKey{}).(*InvocationStats)
+	return stats
+}
@@ -18,22 +18,26 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 
 	mexporter "github.com/GoogleCloudPlatform/opentelemetry-operations-go/exporter/metric"
 	texporter "github.com/GoogleCloudPlatform/opentelemetry-operations-go/exporter/trace"
 	"go.opentelemetry.io/contrib/propagators/autoprop"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
 	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/exemplar"
 	"go.opentelemetry.io/otel/sdk/resource"
 	tracesdk "go.opentelemetry.io/otel/sdk/trace"
-	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
 )
 
 // setupOTelSDK bootstraps the OpenTelemetry pipeline.
+// resourceAttributes are added to the resource of all spans and metrics.
 // If it does not return an error, make sure to call shutdown for proper cleanup.
-func SetupOTel(ctx context.Context, versionString, telemetryOTLP string, telemetryGCP bool, telemetryServiceName string) (shutdown func(context.Context) error, err error) {
+func SetupOTel(ctx context.Context, versionString, telemetryOTLP string, telemetryGCP bool, telemetryServiceName string, resourceAttributes map[string]string) (shutdown func(context.Context) error, err error) {
 	var shutdownFuncs []func(context.Context) error
 
 	// shutdown calls cleanup functions registered via shutdownFuncs.
@@ -56,7 +60,7 @@ func SetupOTel(ctx context.Context, versionString, telemetryOTLP string, telemet
 	// Configure Context Propagation to use the default W3C traceparent format.
 	otel.SetTextMapPropagator(autoprop.NewTextMapPropagator())
 
-	res, err := newResource(ctx, versionString, telemetryServiceName)
+	res, err := newResource(ctx, versionString, telemetryServiceName, resourceAttributes)
 	if err != nil {
 		errMsg := fmt.Errorf("unable to set up resource: %w", err)
 		handleErr(errMsg)
@@ -86,7 +90,11 @@ func SetupOTel(ctx context.Context, versionString, telemetryOTLP string, telemet
 
 // newResource create default resources for telemetry data.
 // Resource represents the entity producing telemetry.
-func newResource(ctx context.Context, versionString string, telemetryServiceName string) (*resource.Resource, error) {
+func newResource(ctx context.Context, versionString string, telemetryServiceName string, resourceAttributes map[string]string) (*resource.Resource, error) {
+	attrs, err := customAttributes(resourceAttributes)
+	if err != nil {
+		return nil, err
+	}
 	// Ensure default SDK resources and the required service name are set.
 	r, err := resource.New(
 		ctx,
@@ -96,6 +104,7 @@ func newResource(ctx context.Context, versionString string, telemetryServiceName
 		resource.WithContainer(),    // Discover and provide container information.
 		resource.WithHost(),         //Discover and provide host information.
 		resource.WithSchemaURL(semconv.SchemaURL), // Set the schema url.
+		resource.WithAttributes(attrs...),         // Add the configured resource attributes, which take precedence over OTEL_RESOURCE_ATTRIBUTES.
 		resource.WithAttributes( // Add other custom resource attributes.
 			semconv.ServiceName(telemetryServiceName),
 			semconv.ServiceVersion(versionString),
@@ -107,6 +116,30 @@ func newResource(ctx context.Context, versionString string, telemetryServiceName
 	return r, nil
 }
 
+// customAttributes converts the configured resource attributes, sorted by
+// key. service.name and service.version are set by their own flags.
+func customAttributes(resourceAttributes map[string]string) ([]attribute.KeyValue, error) {
+	keys := make([]string, 0, len(resourceAttributes))
+	for k := range resourceAttributes {
+		switch k {
+		case "":
+			return nil, fmt.Errorf("resource attribute keys can't be empty")
+		case string(semconv.ServiceNameKey):
+			return nil, fmt.Errorf("resource attribute %q can't be set, use --telemetry-service-name instead", k)
+		case string(semconv.ServiceVersionKey):
+			return nil, fmt.Errorf("resource attribute %q can't be set, it's the version of Toolbox", k)
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	attrs := make([]attribute.KeyValue, 0, len(keys))
+	for _, k := range keys {
+		attrs = append(attrs, attribute.String(k, resourceAttributes[k]))
+	}
+	return attrs, nil
+}
+
 // newTracerProvider creates TracerProvider.
 // TracerProvider is a factory for Tracers and is responsible for creating spans.
 func newTracerProvider(ctx context.Context, r *resource.Resource, telemetryOTLP string, telemetryGCP bool) (*tracesdk.TracerProvider, error) {
@@ -136,7 +169,9 @@ func newTracerProvider(ctx context.Context, r *resource.Resource, telemetryOTLP
 // newMeterProvider creates MeterProvider.
 // MeterProvider is a factory for Meters, and is responsible for creating metrics.
 func newMeterProvider(ctx context.Context, r *resource.Resource, telemetryOTLP string, telemetryGCP bool) (*metric.MeterProvider, error) {
-	metricOpts := []metric.Option{}
+	// Measurements made within sampled spans keep their trace and span IDs as
+	// exemplars, which link latency histograms to the traces of invocations.
+	metricOpts := []metric.Option{metric.WithResource(r), metric.WithExemplarFilter(exemplar.TraceBasedFilter)}
 	if telemetryOTLP != "" {
 		// otlpmetrichttp provides an OTLP metrics exporter using HTTP with protobuf payloads.
 		// By default, the telemetry is sent to https://localhost:4318/v1/metrics.
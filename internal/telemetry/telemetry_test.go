@@ -0,0 +1,76 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/googleapis/genai-toolbox/internal/telemetry"
+)
+
+func TestSetupOTelResourceAttributes(t *testing.T) {
+	tcs := []struct {
+		desc  string
+		attrs map[string]string
+		err   string
+	}{
+		{
+			desc:  "no attributes",
+			attrs: nil,
+		},
+		{
+			desc: "attributes",
+			attrs: map[string]string{
+				"deployment.environment": "prod",
+				"team":                   "agents",
+			},
+		},
+		{
+			desc:  "empty key",
+			attrs: map[string]string{"": "prod"},
+			err:   "resource attribute keys can't be empty",
+		},
+		{
+			desc:  "service name",
+			attrs: map[string]string{"service.name": "other"},
+			err:   `resource attribute "service.name" can't be set, use --telemetry-service-name instead`,
+		},
+		{
+			desc:  "service version",
+			attrs: map[string]string{"service.version": "1.0.0"},
+			err:   `resource attribute "service.version" can't be set, it's the version of Toolbox`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctx := context.Background()
+			shutdown, err := telemetry.SetupOTel(ctx, "0.0.0", "", false, "toolbox", tc.attrs)
+			if tc.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if err := shutdown(ctx); err != nil {
+					t.Fatalf("unexpected error shutting down: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("expected error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/sources/alloydbpg"
 	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/postgres/postgrescommon"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -169,19 +170,17 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 	if err != nil {
 		return nil, fmt.Errorf("unable to execute query: %w. Query: %v , Values: %v", err, t.Statement, allParamValues)
 	}
-
-	fields := results.FieldDescriptions()
+	defer results.Close()
 
 	var out []any
 	for results.Next() {
-		v, err := results.Values()
+		if tools.RowLimitReached(ctx, len(out)) {
+			break
+		}
+		vMap, err := postgrescommon.RowMap(results, false)
 		if err != nil {
 			return nil, fmt.Errorf("unable to parse row: %w", err)
 		}
-		vMap := make(map[string]any)
-		for i, f := range fields {
-			vMap[f.Name] = v[i]
-		}
 		out = append(out, vMap)
 	}
 
@@ -0,0 +1,111 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package athenasql_test
+
+import (
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/athena/athenasql"
+)
+
+func TestParseFromYamlAthena(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: athena-sql
+					source: my-athena
+					description: some description
+					statement: |
+						SELECT * FROM sales.orders WHERE region = :region
+					authRequired:
+						- my-google-auth-service
+					parameters:
+						- name: region
+						  type: string
+						  description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": athenasql.Config{
+					Name:         "example_tool",
+					Kind:         "athena-sql",
+					Source:       "my-athena",
+					Description:  "some description",
+					Statement:    "SELECT * FROM sales.orders WHERE region = :region\n",
+					AuthRequired: []string{"my-google-auth-service"},
+					Parameters: []tools.Parameter{
+						tools.NewStringParameter("region", "some description"),
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestLiteral(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   any
+		want string
+	}{
+		{desc: "null", in: nil, want: "NULL"},
+		{desc: "string", in: "it's", want: "'it''s'"},
+		{desc: "int", in: 42, want: "42"},
+		{desc: "float", in: 2.5, want: "2.5"},
+		{desc: "bool", in: true, want: "TRUE"},
+		{desc: "array", in: []any{"us", "eu"}, want: "ARRAY['us', 'eu']"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := athenasql.Literal(tc.in)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tc.want {
+				t.Fatalf("incorrect literal: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,305 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigquerycreatetable
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	bigqueryapi "cloud.google.com/go/bigquery"
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	bigqueryds "github.com/googleapis/genai-toolbox/internal/sources/bigquery"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+const kind string = "bigquery-create-table"
+const projectKey string = "project"
+const datasetKey string = "dataset"
+const tableKey string = "table"
+const schemaKey string = "schema"
+const descriptionKey string = "description"
+
+// fieldTypes are the accepted column types, including the GoogleSQL aliases
+// of the legacy type names used by the API.
+var fieldTypes = map[string]bigqueryapi.FieldType{
+	"STRING":     bigqueryapi.StringFieldType,
+	"BYTES":      bigqueryapi.BytesFieldType,
+	"INTEGER":    bigqueryapi.IntegerFieldType,
+	"INT64":      bigqueryapi.IntegerFieldType,
+	"FLOAT":      bigqueryapi.FloatFieldType,
+	"FLOAT64":    bigqueryapi.FloatFieldType,
+	"BOOLEAN":    bigqueryapi.BooleanFieldType,
+	"BOOL":       bigqueryapi.BooleanFieldType,
+	"TIMESTAMP":  bigqueryapi.TimestampFieldType,
+	"RECORD":     bigqueryapi.RecordFieldType,
+	"STRUCT":     bigqueryapi.RecordFieldType,
+	"DATE":       bigqueryapi.DateFieldType,
+	"TIME":       bigqueryapi.TimeFieldType,
+	"DATETIME":   bigqueryapi.DateTimeFieldType,
+	"NUMERIC":    bigqueryapi.NumericFieldType,
+	"BIGNUMERIC": bigqueryapi.BigNumericFieldType,
+	"GEOGRAPHY":  bigqueryapi.GeographyFieldType,
+	"INTERVAL":   bigqueryapi.IntervalFieldType,
+	"JSON":       bigqueryapi.JSONFieldType,
+}
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	BigQueryClient() *bigqueryapi.Client
+}
+
+// validate compatible sources are still compatible
+var _ compatibleSource = &bigqueryds.Source{}
+
+var compatibleSources = [...]string{bigqueryds.SourceKind}
+
+type Config struct {
+	Name         string   `yaml:"name" validate:"required"`
+	Kind         string   `yaml:"kind" validate:"required"`
+	Source       string   `yaml:"source" validate:"required"`
+	Description  string   `yaml:"description" validate:"required"`
+	AuthRequired []string `yaml:"authRequired"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(compatibleSource)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
+	}
+
+	projectParameter := tools.NewStringParameterWithDefault(projectKey, s.BigQueryClient().Project(), "The Google Cloud project ID containing the dataset.")
+	datasetParameter := tools.NewStringParameter(datasetKey, "The dataset to create the table in.")
+	tableParameter := tools.NewStringParameter(tableKey, "The ID of the table to create. It must not already exist.")
+	schemaParameter := tools.NewArrayParameter(schemaKey,
+		"The columns of the table, in order. Each column is an object with a 'name', a 'type' (e.g. STRING, INT64, FLOAT64, BOOL, NUMERIC, DATE, TIMESTAMP, JSON or RECORD), an optional 'mode' (NULLABLE, REQUIRED or REPEATED, default NULLABLE), an optional 'description', and for RECORD columns, the nested 'fields' in the same format.",
+		tools.NewMapParameter("column", "A column of the table.", ""))
+	descriptionParameter := tools.NewStringParameterWithDefault(descriptionKey, "", "An optional description of the table.")
+	parameters := tools.Parameters{projectParameter, datasetParameter, tableParameter, schemaParameter, descriptionParameter}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   parameters,
+		AuthRequired: cfg.AuthRequired,
+		Client:       s.BigQueryClient(),
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:  mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+
+	Client      *bigqueryapi.Client
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	mapParams := params.AsMap()
+	projectId, ok := mapParams[projectKey].(string)
+	if !ok || projectId == "" {
+		return nil, fmt.Errorf("invalid or missing '%s' parameter; expected a non-empty string", projectKey)
+	}
+
+	datasetId, ok := mapParams[datasetKey].(string)
+	if !ok || datasetId == "" {
+		return nil, fmt.Errorf("invalid or missing '%s' parameter; expected a non-empty string", datasetKey)
+	}
+
+	tableId, ok := mapParams[tableKey].(string)
+	if !ok || tableId == "" {
+		return nil, fmt.Errorf("invalid or missing '%s' parameter; expected a non-empty string", tableKey)
+	}
+
+	rawSchema, ok := mapParams[schemaKey].([]any)
+	if !ok {
+		return nil, fmt.Errorf("invalid or missing '%s' parameter; expected an array", schemaKey)
+	}
+	schema, err := ParseSchema(rawSchema)
+	if err != nil {
+		return nil, fmt.Errorf("invalid '%s' parameter: %w", schemaKey, err)
+	}
+
+	description, _ := mapParams[descriptionKey].(string)
+
+	tableHandle := t.Client.DatasetInProject(projectId, datasetId).Table(tableId)
+
+	// Create fails if the table already exists, so an existing table is
+	// never replaced
+	md := &bigqueryapi.TableMetadata{Schema: schema, Description: description}
+	if err := tableHandle.Create(ctx, md); err != nil {
+		return nil, fmt.Errorf("failed to create table %s.%s.%s: %w", projectId, datasetId, tableId, err)
+	}
+
+	metadata, err := tableHandle.Metadata(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get metadata for table %s.%s.%s: %w", projectId, datasetId, tableId, err)
+	}
+
+	return metadata, nil
+}
+
+// ParseSchema converts the columns of the schema parameter to a table schema.
+func ParseSchema(columns []any) (bigqueryapi.Schema, error) {
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("schema must have at least one column")
+	}
+	schema := make(bigqueryapi.Schema, 0, len(columns))
+	seen := make(map[string]bool, len(columns))
+	for i, c := range columns {
+		column, ok := c.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("column #%d must be an object", i)
+		}
+		field, err := parseField(column)
+		if err != nil {
+			return nil, fmt.Errorf("column #%d: %w", i, err)
+		}
+		// column names are case-insensitive
+		name := strings.ToLower(field.Name)
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate column %q", field.Name)
+		}
+		seen[name] = true
+		schema = append(schema, field)
+	}
+	return schema, nil
+}
+
+func parseField(column map[string]any) (*bigqueryapi.FieldSchema, error) {
+	for k := range column {
+		switch k {
+		case "name", "type", "mode", "description", "fields":
+		default:
+			return nil, fmt.Errorf("unknown key %q", k)
+		}
+	}
+
+	name, ok := column["name"].(string)
+	if !ok || name == "" {
+		return nil, fmt.Errorf("missing 'name'")
+	}
+	rawType, ok := column["type"].(string)
+	if !ok || rawType == "" {
+		return nil, fmt.Errorf("column %q is missing 'type'", name)
+	}
+	fieldType, ok := fieldTypes[strings.ToUpper(rawType)]
+	if !ok {
+		return nil, fmt.Errorf("column %q has unsupported type %q", name, rawType)
+	}
+
+	field := &bigqueryapi.FieldSchema{Name: name, Type: fieldType}
+	if description, ok := column["description"]; ok {
+		field.Description, ok = description.(string)
+		if !ok {
+			return nil, fmt.Errorf("column %q has a 'description' that is not a string", name)
+		}
+	}
+
+	mode := ""
+	if rawMode, ok := column["mode"]; ok {
+		mode, ok = rawMode.(string)
+		if !ok {
+			return nil, fmt.Errorf("column %q has a 'mode' that is not a string", name)
+		}
+	}
+	switch strings.ToUpper(mode) {
+	case "", "NULLABLE":
+	case "REQUIRED":
+		field.Required = true
+	case "REPEATED":
+		field.Repeated = true
+	default:
+		return nil, fmt.Errorf("column %q has invalid mode %q; expected NULLABLE, REQUIRED or REPEATED", name, mode)
+	}
+
+	rawFields, hasFields := column["fields"]
+	if fieldType != bigqueryapi.RecordFieldType {
+		if hasFields {
+			return nil, fmt.Errorf("column %q has 'fields' but is not a RECORD", name)
+		}
+		return field, nil
+	}
+	nested, ok := rawFields.([]any)
+	if !ok {
+		return nil, fmt.Errorf("RECORD column %q must have 'fields'", name)
+	}
+	schema, err := ParseSchema(nested)
+	if err != nil {
+		return nil, fmt.Errorf("column %q: %w", name, err)
+	}
+	field.Schema = schema
+	return field, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
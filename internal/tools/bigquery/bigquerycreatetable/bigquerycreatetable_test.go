@@ -0,0 +1,183 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigquerycreatetable_test
+
+import (
+	"testing"
+
+	bigqueryapi "cloud.google.com/go/bigquery"
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools/bigquery/bigquerycreatetable"
+)
+
+func TestParseFromYamlBigQueryCreateTable(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: bigquery-create-table
+					source: my-instance
+					description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": bigquerycreatetable.Config{
+					Name:         "example_tool",
+					Kind:         "bigquery-create-table",
+					Source:       "my-instance",
+					Description:  "some description",
+					AuthRequired: []string{},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+
+}
+
+func TestParseSchema(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   []any
+		want bigqueryapi.Schema
+	}{
+		{
+			desc: "columns",
+			in: []any{
+				map[string]any{"name": "id", "type": "INT64", "mode": "REQUIRED", "description": "the id"},
+				map[string]any{"name": "tags", "type": "string", "mode": "repeated"},
+				map[string]any{"name": "price", "type": "NUMERIC"},
+			},
+			want: bigqueryapi.Schema{
+				{Name: "id", Type: bigqueryapi.IntegerFieldType, Required: true, Description: "the id"},
+				{Name: "tags", Type: bigqueryapi.StringFieldType, Repeated: true},
+				{Name: "price", Type: bigqueryapi.NumericFieldType},
+			},
+		},
+		{
+			desc: "record",
+			in: []any{
+				map[string]any{"name": "address", "type": "STRUCT", "fields": []any{
+					map[string]any{"name": "city", "type": "STRING"},
+				}},
+			},
+			want: bigqueryapi.Schema{
+				{Name: "address", Type: bigqueryapi.RecordFieldType, Schema: bigqueryapi.Schema{
+					{Name: "city", Type: bigqueryapi.StringFieldType},
+				}},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := bigquerycreatetable.ParseSchema(tc.in)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect schema: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestFailParseSchema(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   []any
+		err  string
+	}{
+		{
+			desc: "empty",
+			in:   []any{},
+			err:  "schema must have at least one column",
+		},
+		{
+			desc: "missing type",
+			in:   []any{map[string]any{"name": "id"}},
+			err:  `column #0: column "id" is missing 'type'`,
+		},
+		{
+			desc: "unsupported type",
+			in:   []any{map[string]any{"name": "id", "type": "UUID"}},
+			err:  `column #0: column "id" has unsupported type "UUID"`,
+		},
+		{
+			desc: "invalid mode",
+			in:   []any{map[string]any{"name": "id", "type": "STRING", "mode": "OPTIONAL"}},
+			err:  `column #0: column "id" has invalid mode "OPTIONAL"; expected NULLABLE, REQUIRED or REPEATED`,
+		},
+		{
+			desc: "unknown key",
+			in:   []any{map[string]any{"name": "id", "type": "STRING", "default": "x"}},
+			err:  `column #0: unknown key "default"`,
+		},
+		{
+			desc: "duplicate column",
+			in: []any{
+				map[string]any{"name": "id", "type": "STRING"},
+				map[string]any{"name": "ID", "type": "STRING"},
+			},
+			err: `duplicate column "ID"`,
+		},
+		{
+			desc: "record without fields",
+			in:   []any{map[string]any{"name": "address", "type": "RECORD"}},
+			err:  `column #0: RECORD column "address" must have 'fields'`,
+		},
+		{
+			desc: "fields on scalar",
+			in:   []any{map[string]any{"name": "id", "type": "STRING", "fields": []any{}}},
+			err:  `column #0: column "id" has 'fields' but is not a RECORD`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := bigquerycreatetable.ParseSchema(tc.in)
+			if err == nil {
+				t.Fatalf("expect schema parsing to fail")
+			}
+			if err.Error() != tc.err {
+				t.Fatalf("unexpected error: got %q, want %q", err.Error(), tc.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigquerydeletetable
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	bigqueryapi "cloud.google.com/go/bigquery"
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	bigqueryds "github.com/googleapis/genai-toolbox/internal/sources/bigquery"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+const kind string = "bigquery-delete-table"
+const projectKey string = "project"
+const datasetKey string = "dataset"
+const tableKey string = "table"
+const confirmKey string = "confirm"
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	BigQueryClient() *bigqueryapi.Client
+}
+
+// validate compatible sources are still compatible
+var _ compatibleSource = &bigqueryds.Source{}
+
+var compatibleSources = [...]string{bigqueryds.SourceKind}
+
+type Config struct {
+	Name         string   `yaml:"name" validate:"required"`
+	Kind         string   `yaml:"kind" validate:"required"`
+	Source       string   `yaml:"source" validate:"required"`
+	Description  string   `yaml:"description" validate:"required"`
+	AuthRequired []string `yaml:"authRequired"`
+	// AllowedDatasets are the datasets, as "project.dataset" or "dataset" in
+	// the project of the source, whose tables the tool may delete. The tool
+	// may delete tables in any dataset if it is empty.
+	AllowedDatasets []string `yaml:"allowedDatasets" validate:"dive,required"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(compatibleSource)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
+	}
+
+	allowedDatasets, err := QualifyDatasets(cfg.AllowedDatasets, s.BigQueryClient().Project())
+	if err != nil {
+		return nil, fmt.Errorf("invalid 'allowedDatasets' for %q tool: %w", cfg.Name, err)
+	}
+
+	projectParameter := tools.NewStringParameterWithDefault(projectKey, s.BigQueryClient().Project(), "The Google Cloud project ID containing the dataset and table.")
+	datasetParameter := tools.NewStringParameter(datasetKey, "The table's parent dataset.")
+	tableParameter := tools.NewStringParameter(tableKey, "The table to delete.")
+	confirmParameter := tools.NewStringParameter(confirmKey, "The ID of the table to delete, repeated to confirm the deletion. Deleting a table also deletes all of its data and can't be undone.")
+	parameters := tools.Parameters{projectParameter, datasetParameter, tableParameter, confirmParameter}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:            cfg.Name,
+		Kind:            kind,
+		Parameters:      parameters,
+		AuthRequired:    cfg.AuthRequired,
+		AllowedDatasets: allowedDatasets,
+		Client:          s.BigQueryClient(),
+		manifest:        tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:     mcpManifest,
+	}
+	return t, nil
+}
+
+// QualifyDatasets returns datasets as "project.dataset", qualifying the
+// datasets without a project with the given project.
+func QualifyDatasets(datasets []string, project string) ([]string, error) {
+	qualified := make([]string, 0, len(datasets))
+	for _, d := range datasets {
+		switch parts := strings.Split(d, "."); len(parts) {
+		case 1:
+			qualified = append(qualified, project+"."+d)
+		case 2:
+			if parts[0] == "" || parts[1] == "" {
+				return nil, fmt.Errorf("invalid dataset %q", d)
+			}
+			qualified = append(qualified, d)
+		default:
+			return nil, fmt.Errorf("invalid dataset %q; expected \"project.dataset\" or \"dataset\"", d)
+		}
+	}
+	return qualified, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name            string           `yaml:"name"`
+	Kind            string           `yaml:"kind"`
+	AuthRequired    []string         `yaml:"authRequired"`
+	Parameters      tools.Parameters `yaml:"parameters"`
+	AllowedDatasets []string         `yaml:"allowedDatasets"`
+
+	Client      *bigqueryapi.Client
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	mapParams := params.AsMap()
+	projectId, ok := mapParams[projectKey].(string)
+	if !ok || projectId == "" {
+		return nil, fmt.Errorf("invalid or missing '%s' parameter; expected a non-empty string", projectKey)
+	}
+
+	datasetId, ok := mapParams[datasetKey].(string)
+	if !ok || datasetId == "" {
+		return nil, fmt.Errorf("invalid or missing '%s' parameter; expected a non-empty string", datasetKey)
+	}
+
+	tableId, ok := mapParams[tableKey].(string)
+	if !ok || tableId == "" {
+		return nil, fmt.Errorf("invalid or missing '%s' parameter; expected a non-empty string", tableKey)
+	}
+
+	confirm, _ := mapParams[confirmKey].(string)
+	if confirm != tableId {
+		return nil, fmt.Errorf("deletion of table %s.%s.%s not confirmed; set '%s' to %q to delete it", projectId, datasetId, tableId, confirmKey, tableId)
+	}
+
+	if len(t.AllowedDatasets) > 0 && !slices.Contains(t.AllowedDatasets, projectId+"."+datasetId) {
+		return nil, fmt.Errorf("tool %q is not allowed to delete tables in dataset %s.%s", t.Name, projectId, datasetId)
+	}
+
+	tableHandle := t.Client.DatasetInProject(projectId, datasetId).Table(tableId)
+	if err := tableHandle.Delete(ctx); err != nil {
+		return nil, fmt.Errorf("failed to delete table %s.%s.%s: %w", projectId, datasetId, tableId, err)
+	}
+
+	return map[string]any{
+		"project": projectId,
+		"dataset": datasetId,
+		"table":   tableId,
+		"deleted": true,
+	}, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
@@ -48,6 +48,7 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.T
 type compatibleSource interface {
 	BigQueryClient() *bigqueryapi.Client
 	BigQueryRestService() *bigqueryrestapi.Service
+	BytesBilledBudget() *bigqueryds.Budget
 }
 
 // validate compatible sources are still compatible
@@ -61,6 +62,11 @@ type Config struct {
 	Source       string   `yaml:"source" validate:"required"`
 	Description  string   `yaml:"description" validate:"required"`
 	AuthRequired []string `yaml:"authRequired"`
+	// MaximumBytesBilled limits the bytes billed by each query. Queries
+	// estimated to exceed it are rejected without running.
+	MaximumBytesBilled int64 `yaml:"maximumBytesBilled" validate:"gte=0"`
+	// ReadOnly rejects statements writing to the datasets.
+	ReadOnly bool `yaml:"readOnly"`
 }
 
 // validate interface
@@ -100,14 +106,17 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 
 	// finish tool setup
 	t := Tool{
-		Name:         cfg.Name,
-		Kind:         kind,
-		Parameters:   parameters,
-		AuthRequired: cfg.AuthRequired,
-		Client:       s.BigQueryClient(),
-		RestService:  s.BigQueryRestService(),
-		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
-		mcpManifest:  mcpManifest,
+		Name:               cfg.Name,
+		Kind:               kind,
+		Parameters:         parameters,
+		AuthRequired:       cfg.AuthRequired,
+		Client:             s.BigQueryClient(),
+		MaximumBytesBilled: cfg.MaximumBytesBilled,
+		Budget:             s.BytesBilledBudget(),
+		ReadOnly:           cfg.ReadOnly || sources.IsReadOnly(rawS),
+		RestService:        s.BigQueryRestService(),
+		manifest:           tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:        mcpManifest,
 	}
 	return t, nil
 }
@@ -122,8 +131,13 @@ type Tool struct {
 	Parameters   tools.Parameters `yaml:"parameters"`
 	Client       *bigqueryapi.Client
 	RestService  *bigqueryrestapi.Service
-	manifest     tools.Manifest
-	mcpManifest  tools.McpManifest
+	// MaximumBytesBilled limits the bytes billed by each query.
+	MaximumBytesBilled int64
+	Budget             *bigqueryds.Budget
+	// ReadOnly rejects statements writing to the datasets.
+	ReadOnly    bool
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
 }
 
 func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
@@ -136,6 +150,11 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 	if !ok {
 		return nil, fmt.Errorf("unable to cast dry_run parameter %s", paramsMap["dry_run"])
 	}
+	if t.ReadOnly {
+		if err := tools.CheckReadOnly(tools.DialectGoogleSQL, sql); err != nil {
+			return nil, err
+		}
+	}
 
 	dryRunJob, err := dryRunQuery(ctx, t.RestService, t.Client.Project(), t.Client.Location, sql)
 	if err != nil {
@@ -154,10 +173,15 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 		return "Dry run was requested, but no job information was returned.", nil
 	}
 
+	if err := bigqueryds.CheckQueryCost(dryRunJob.Statistics.TotalBytesProcessed, t.MaximumBytesBilled, t.Budget); err != nil {
+		return nil, err
+	}
+
 	statementType := dryRunJob.Statistics.Query.StatementType
 	// JobStatistics.QueryStatistics.StatementType
-	query := t.Client.Query(sql)
+	query := t.Client.Query(tools.TagStatement(ctx, sql))
 	query.Location = t.Client.Location
+	query.MaxBytesBilled = t.MaximumBytesBilled
 
 	// Log the query executed for debugging.
 	logger, err := util.LoggerFromContext(ctx)
@@ -174,7 +198,7 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 	if err != nil {
 		return nil, fmt.Errorf("unable to execute query: %w", err)
 	}
-	for {
+	for !tools.RowLimitReached(ctx, len(out)) {
 		var row map[string]bigqueryapi.Value
 		err = it.Next(&row)
 		if err == iterator.Done {
@@ -189,6 +213,8 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 		}
 		out = append(out, vMap)
 	}
+	bigqueryds.ReportBytesBilled(ctx, it, t.Budget)
+
 	// If the query returned any rows, return them directly.
 	if len(out) > 0 {
 		return out, nil
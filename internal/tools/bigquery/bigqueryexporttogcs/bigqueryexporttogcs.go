@@ -0,0 +1,234 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigqueryexporttogcs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	bigqueryapi "cloud.google.com/go/bigquery"
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	bigqueryds "github.com/googleapis/genai-toolbox/internal/sources/bigquery"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+const kind string = "bigquery-export-to-gcs"
+const projectKey string = "project"
+const datasetKey string = "dataset"
+const tableKey string = "table"
+const destinationUriKey string = "destinationUri"
+const formatKey string = "format"
+const compressionKey string = "compression"
+const printHeaderKey string = "printHeader"
+
+// formats are the accepted formats of the exported files.
+var formats = map[string]bigqueryapi.DataFormat{
+	"CSV":                    bigqueryapi.CSV,
+	"NEWLINE_DELIMITED_JSON": bigqueryapi.JSON,
+	"JSON":                   bigqueryapi.JSON,
+	"AVRO":                   bigqueryapi.Avro,
+	"PARQUET":                bigqueryapi.Parquet,
+}
+
+// compressions are the accepted compressions of the exported files.
+var compressions = map[string]bigqueryapi.Compression{
+	"NONE":    bigqueryapi.None,
+	"GZIP":    bigqueryapi.Gzip,
+	"DEFLATE": bigqueryapi.Deflate,
+	"SNAPPY":  bigqueryapi.Snappy,
+}
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	BigQueryClient() *bigqueryapi.Client
+}
+
+// validate compatible sources are still compatible
+var _ compatibleSource = &bigqueryds.Source{}
+
+var compatibleSources = [...]string{bigqueryds.SourceKind}
+
+type Config struct {
+	Name         string   `yaml:"name" validate:"required"`
+	Kind         string   `yaml:"kind" validate:"required"`
+	Source       string   `yaml:"source" validate:"required"`
+	Description  string   `yaml:"description" validate:"required"`
+	AuthRequired []string `yaml:"authRequired"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(compatibleSource)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
+	}
+
+	projectParameter := tools.NewStringParameterWithDefault(projectKey, s.BigQueryClient().Project(), "The Google Cloud project ID containing the dataset and table.")
+	datasetParameter := tools.NewStringParameter(datasetKey, "The dataset of the table to export.")
+	tableParameter := tools.NewStringParameter(tableKey, "The table to export.")
+	destinationUriParameter := tools.NewStringParameter(destinationUriKey, "The Cloud Storage URI to export the table to, e.g. 'gs://bucket/path/export-*.csv'. A '*' wildcard is required for tables larger than 1 GB, which are exported to several files.")
+	formatParameter := tools.NewStringParameterWithDefault(formatKey, "CSV", "The format of the files: CSV, NEWLINE_DELIMITED_JSON, AVRO or PARQUET.")
+	compressionParameter := tools.NewStringParameterWithDefault(compressionKey, "NONE", "The compression of the files: NONE, GZIP, DEFLATE or SNAPPY. GZIP is supported for CSV and NEWLINE_DELIMITED_JSON, DEFLATE and SNAPPY for AVRO, and GZIP and SNAPPY for PARQUET.")
+	printHeaderParameter := tools.NewBooleanParameterWithDefault(printHeaderKey, true, "Whether to print a header row in CSV files.")
+	parameters := tools.Parameters{projectParameter, datasetParameter, tableParameter, destinationUriParameter, formatParameter, compressionParameter, printHeaderParameter}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   parameters,
+		AuthRequired: cfg.AuthRequired,
+		Client:       s.BigQueryClient(),
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:  mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+
+	Client      *bigqueryapi.Client
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	mapParams := params.AsMap()
+	projectId, ok := mapParams[projectKey].(string)
+	if !ok || projectId == "" {
+		return nil, fmt.Errorf("invalid or missing '%s' parameter; expected a non-empty string", projectKey)
+	}
+
+	datasetId, ok := mapParams[datasetKey].(string)
+	if !ok || datasetId == "" {
+		return nil, fmt.Errorf("invalid or missing '%s' parameter; expected a non-empty string", datasetKey)
+	}
+
+	tableId, ok := mapParams[tableKey].(string)
+	if !ok || tableId == "" {
+		return nil, fmt.Errorf("invalid or missing '%s' parameter; expected a non-empty string", tableKey)
+	}
+
+	destinationUri, _ := mapParams[destinationUriKey].(string)
+	format, _ := mapParams[formatKey].(string)
+	compression, _ := mapParams[compressionKey].(string)
+	ref, err := NewGCSReference(destinationUri, format, compression)
+	if err != nil {
+		return nil, err
+	}
+
+	extractor := t.Client.DatasetInProject(projectId, datasetId).Table(tableId).ExtractorTo(ref)
+	printHeader, _ := mapParams[printHeaderKey].(bool)
+	extractor.DisableHeader = !printHeader
+
+	job, err := extractor.Run(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start export job from table %s.%s.%s: %w", projectId, datasetId, tableId, err)
+	}
+	status, err := job.Wait(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait for export job %s: %w", job.ID(), err)
+	}
+	if err := status.Err(); err != nil {
+		return nil, fmt.Errorf("export job %s failed: %w", job.ID(), err)
+	}
+
+	result := map[string]any{
+		"jobId":          job.ID(),
+		"destinationUri": destinationUri,
+	}
+	if stats, ok := status.Statistics.Details.(*bigqueryapi.ExtractStatistics); ok && len(stats.DestinationURIFileCounts) > 0 {
+		result["fileCount"] = stats.DestinationURIFileCounts[0]
+	}
+	return result, nil
+}
+
+// NewGCSReference returns a reference to the Cloud Storage files to export
+// to, validating the parameters of the export.
+func NewGCSReference(uri, format, compression string) (*bigqueryapi.GCSReference, error) {
+	if !strings.HasPrefix(uri, "gs://") {
+		return nil, fmt.Errorf("invalid '%s' parameter %q; expected a Cloud Storage URI starting with \"gs://\"", destinationUriKey, uri)
+	}
+	dataFormat, ok := formats[strings.ToUpper(format)]
+	if !ok {
+		return nil, fmt.Errorf("invalid '%s' parameter %q; expected CSV, NEWLINE_DELIMITED_JSON, AVRO or PARQUET", formatKey, format)
+	}
+	comp, ok := compressions[strings.ToUpper(compression)]
+	if !ok {
+		return nil, fmt.Errorf("invalid '%s' parameter %q; expected NONE, GZIP, DEFLATE or SNAPPY", compressionKey, compression)
+	}
+
+	ref := bigqueryapi.NewGCSReference(uri)
+	ref.DestinationFormat = dataFormat
+	ref.Compression = comp
+	return ref, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigqueryexporttogcs_test
+
+import (
+	"testing"
+
+	bigqueryapi "cloud.google.com/go/bigquery"
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools/bigquery/bigqueryexporttogcs"
+)
+
+func TestParseFromYamlBigQueryExportToGCS(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: bigquery-export-to-gcs
+					source: my-instance
+					description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": bigqueryexporttogcs.Config{
+					Name:         "example_tool",
+					Kind:         "bigquery-export-to-gcs",
+					Source:       "my-instance",
+					Description:  "some description",
+					AuthRequired: []string{},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+
+}
+
+func TestNewGCSReference(t *testing.T) {
+	ref, err := bigqueryexporttogcs.NewGCSReference("gs://bucket/export-*.json", "newline_delimited_json", "gzip")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := bigqueryapi.NewGCSReference("gs://bucket/export-*.json")
+	want.DestinationFormat = bigqueryapi.JSON
+	want.Compression = bigqueryapi.Gzip
+	if diff := cmp.Diff(want, ref); diff != "" {
+		t.Fatalf("incorrect reference: diff %v", diff)
+	}
+
+	for _, tc := range []struct{ uri, format, compression string }{
+		{"/tmp/export.csv", "CSV", "NONE"},
+		{"gs://bucket/export.orc", "ORC", "NONE"},
+		{"gs://bucket/export.csv", "CSV", "ZSTD"},
+	} {
+		if _, err := bigqueryexporttogcs.NewGCSReference(tc.uri, tc.format, tc.compression); err == nil {
+			t.Fatalf("expect %v to be rejected", tc)
+		}
+	}
+}
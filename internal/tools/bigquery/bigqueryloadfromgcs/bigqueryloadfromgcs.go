@@ -0,0 +1,263 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigqueryloadfromgcs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	bigqueryapi "cloud.google.com/go/bigquery"
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	bigqueryds "github.com/googleapis/genai-toolbox/internal/sources/bigquery"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+const kind string = "bigquery-load-from-gcs"
+const projectKey string = "project"
+const datasetKey string = "dataset"
+const tableKey string = "table"
+const sourceUrisKey string = "sourceUris"
+const formatKey string = "format"
+const autodetectKey string = "autodetect"
+const skipLeadingRowsKey string = "skipLeadingRows"
+const writeDispositionKey string = "writeDisposition"
+
+// formats are the accepted formats of the loaded files.
+var formats = map[string]bigqueryapi.DataFormat{
+	"CSV":                    bigqueryapi.CSV,
+	"NEWLINE_DELIMITED_JSON": bigqueryapi.JSON,
+	"JSON":                   bigqueryapi.JSON,
+	"AVRO":                   bigqueryapi.Avro,
+	"PARQUET":                bigqueryapi.Parquet,
+	"ORC":                    bigqueryapi.ORC,
+}
+
+// writeDispositions are the accepted actions when the table already exists.
+var writeDispositions = map[string]bigqueryapi.TableWriteDisposition{
+	"WRITE_APPEND":   bigqueryapi.WriteAppend,
+	"WRITE_TRUNCATE": bigqueryapi.WriteTruncate,
+	"WRITE_EMPTY":    bigqueryapi.WriteEmpty,
+}
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	BigQueryClient() *bigqueryapi.Client
+}
+
+// validate compatible sources are still compatible
+var _ compatibleSource = &bigqueryds.Source{}
+
+var compatibleSources = [...]string{bigqueryds.SourceKind}
+
+type Config struct {
+	Name         string   `yaml:"name" validate:"required"`
+	Kind         string   `yaml:"kind" validate:"required"`
+	Source       string   `yaml:"source" validate:"required"`
+	Description  string   `yaml:"description" validate:"required"`
+	AuthRequired []string `yaml:"authRequired"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(compatibleSource)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
+	}
+
+	projectParameter := tools.NewStringParameterWithDefault(projectKey, s.BigQueryClient().Project(), "The Google Cloud project ID containing the dataset and table.")
+	datasetParameter := tools.NewStringParameter(datasetKey, "The dataset of the table to load the data into.")
+	tableParameter := tools.NewStringParameter(tableKey, "The table to load the data into. It is created if it doesn't exist.")
+	sourceUrisParameter := tools.NewArrayParameter(sourceUrisKey, "The Cloud Storage URIs of the files to load, e.g. 'gs://bucket/path/*.csv'. Each URI may contain one '*' wildcard.", tools.NewStringParameter("uri", "A Cloud Storage URI."))
+	formatParameter := tools.NewStringParameterWithDefault(formatKey, "CSV", "The format of the files: CSV, NEWLINE_DELIMITED_JSON, AVRO, PARQUET or ORC.")
+	autodetectParameter := tools.NewBooleanParameterWithDefault(autodetectKey, true, "Whether to infer the schema of the table from the files. Only used for CSV and NEWLINE_DELIMITED_JSON files when the table doesn't exist.")
+	skipLeadingRowsParameter := tools.NewIntParameterWithDefault(skipLeadingRowsKey, 0, "The number of header rows to skip at the top of CSV files.")
+	writeDispositionParameter := tools.NewStringParameterWithDefault(writeDispositionKey, "WRITE_EMPTY", "What to do if the table already has data: WRITE_APPEND to append the rows, WRITE_TRUNCATE to replace the data of the table, or WRITE_EMPTY to fail.")
+	parameters := tools.Parameters{projectParameter, datasetParameter, tableParameter, sourceUrisParameter, formatParameter, autodetectParameter, skipLeadingRowsParameter, writeDispositionParameter}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   parameters,
+		AuthRequired: cfg.AuthRequired,
+		Client:       s.BigQueryClient(),
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:  mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+
+	Client      *bigqueryapi.Client
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	mapParams := params.AsMap()
+	projectId, ok := mapParams[projectKey].(string)
+	if !ok || projectId == "" {
+		return nil, fmt.Errorf("invalid or missing '%s' parameter; expected a non-empty string", projectKey)
+	}
+
+	datasetId, ok := mapParams[datasetKey].(string)
+	if !ok || datasetId == "" {
+		return nil, fmt.Errorf("invalid or missing '%s' parameter; expected a non-empty string", datasetKey)
+	}
+
+	tableId, ok := mapParams[tableKey].(string)
+	if !ok || tableId == "" {
+		return nil, fmt.Errorf("invalid or missing '%s' parameter; expected a non-empty string", tableKey)
+	}
+
+	rawUris, ok := mapParams[sourceUrisKey].([]any)
+	if !ok {
+		return nil, fmt.Errorf("invalid or missing '%s' parameter; expected an array", sourceUrisKey)
+	}
+	typedUris, err := tools.ConvertAnySliceToTyped(rawUris, "string")
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert '%s' parameter: %w", sourceUrisKey, err)
+	}
+
+	format, _ := mapParams[formatKey].(string)
+	autodetect, _ := mapParams[autodetectKey].(bool)
+	skipLeadingRows, _ := mapParams[skipLeadingRowsKey].(int)
+	ref, err := NewGCSReference(typedUris.([]string), format, autodetect, skipLeadingRows)
+	if err != nil {
+		return nil, err
+	}
+
+	rawDisposition, _ := mapParams[writeDispositionKey].(string)
+	disposition, ok := writeDispositions[strings.ToUpper(rawDisposition)]
+	if !ok {
+		return nil, fmt.Errorf("invalid '%s' parameter %q; expected WRITE_APPEND, WRITE_TRUNCATE or WRITE_EMPTY", writeDispositionKey, rawDisposition)
+	}
+
+	loader := t.Client.DatasetInProject(projectId, datasetId).Table(tableId).LoaderFrom(ref)
+	loader.WriteDisposition = disposition
+	loader.CreateDisposition = bigqueryapi.CreateIfNeeded
+
+	job, err := loader.Run(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start load job into table %s.%s.%s: %w", projectId, datasetId, tableId, err)
+	}
+	status, err := job.Wait(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait for load job %s: %w", job.ID(), err)
+	}
+	if err := status.Err(); err != nil {
+		return nil, fmt.Errorf("load job %s failed: %w", job.ID(), err)
+	}
+
+	result := map[string]any{
+		"jobId": job.ID(),
+		"table": fmt.Sprintf("%s.%s.%s", projectId, datasetId, tableId),
+	}
+	if stats, ok := status.Statistics.Details.(*bigqueryapi.LoadStatistics); ok {
+		result["inputFiles"] = stats.InputFiles
+		result["inputFileBytes"] = stats.InputFileBytes
+		result["outputRows"] = stats.OutputRows
+		result["outputBytes"] = stats.OutputBytes
+	}
+	return result, nil
+}
+
+// NewGCSReference returns a reference to the Cloud Storage files to load,
+// validating the parameters of the load.
+func NewGCSReference(uris []string, format string, autodetect bool, skipLeadingRows int) (*bigqueryapi.GCSReference, error) {
+	if len(uris) == 0 {
+		return nil, fmt.Errorf("'%s' parameter cannot be empty", sourceUrisKey)
+	}
+	for _, uri := range uris {
+		if !strings.HasPrefix(uri, "gs://") {
+			return nil, fmt.Errorf("invalid source URI %q; expected a Cloud Storage URI starting with \"gs://\"", uri)
+		}
+	}
+	dataFormat, ok := formats[strings.ToUpper(format)]
+	if !ok {
+		return nil, fmt.Errorf("invalid '%s' parameter %q; expected CSV, NEWLINE_DELIMITED_JSON, AVRO, PARQUET or ORC", formatKey, format)
+	}
+	if skipLeadingRows < 0 {
+		return nil, fmt.Errorf("invalid '%s' parameter %d; expected a non-negative integer", skipLeadingRowsKey, skipLeadingRows)
+	}
+	if skipLeadingRows > 0 && dataFormat != bigqueryapi.CSV {
+		return nil, fmt.Errorf("'%s' parameter is only supported for CSV files", skipLeadingRowsKey)
+	}
+
+	ref := bigqueryapi.NewGCSReference(uris...)
+	ref.SourceFormat = dataFormat
+	// self-describing formats carry their own schema
+	ref.AutoDetect = autodetect && (dataFormat == bigqueryapi.CSV || dataFormat == bigqueryapi.JSON)
+	ref.SkipLeadingRows = int64(skipLeadingRows)
+	return ref, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
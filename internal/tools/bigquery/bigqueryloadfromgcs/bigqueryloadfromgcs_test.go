@@ -0,0 +1,143 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigqueryloadfromgcs_test
+
+import (
+	"testing"
+
+	bigqueryapi "cloud.google.com/go/bigquery"
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools/bigquery/bigqueryloadfromgcs"
+)
+
+func TestParseFromYamlBigQueryLoadFromGCS(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: bigquery-load-from-gcs
+					source: my-instance
+					description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": bigqueryloadfromgcs.Config{
+					Name:         "example_tool",
+					Kind:         "bigquery-load-from-gcs",
+					Source:       "my-instance",
+					Description:  "some description",
+					AuthRequired: []string{},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+
+}
+
+func TestNewGCSReference(t *testing.T) {
+	ref, err := bigqueryloadfromgcs.NewGCSReference([]string{"gs://bucket/a-*.csv"}, "csv", true, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := bigqueryapi.NewGCSReference("gs://bucket/a-*.csv")
+	want.SourceFormat = bigqueryapi.CSV
+	want.AutoDetect = true
+	want.SkipLeadingRows = 1
+	if diff := cmp.Diff(want, ref); diff != "" {
+		t.Fatalf("incorrect reference: diff %v", diff)
+	}
+
+	// self-describing formats are never autodetected
+	ref, err = bigqueryloadfromgcs.NewGCSReference([]string{"gs://bucket/a.parquet"}, "PARQUET", true, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if ref.AutoDetect {
+		t.Fatalf("expect autodetect to be disabled for PARQUET")
+	}
+}
+
+func TestFailNewGCSReference(t *testing.T) {
+	tcs := []struct {
+		desc            string
+		uris            []string
+		format          string
+		skipLeadingRows int
+		err             string
+	}{
+		{
+			desc:   "no uris",
+			format: "CSV",
+			err:    "'sourceUris' parameter cannot be empty",
+		},
+		{
+			desc:   "not a gcs uri",
+			uris:   []string{"s3://bucket/a.csv"},
+			format: "CSV",
+			err:    `invalid source URI "s3://bucket/a.csv"; expected a Cloud Storage URI starting with "gs://"`,
+		},
+		{
+			desc:   "invalid format",
+			uris:   []string{"gs://bucket/a.xml"},
+			format: "XML",
+			err:    `invalid 'format' parameter "XML"; expected CSV, NEWLINE_DELIMITED_JSON, AVRO, PARQUET or ORC`,
+		},
+		{
+			desc:            "skip rows of json",
+			uris:            []string{"gs://bucket/a.json"},
+			format:          "NEWLINE_DELIMITED_JSON",
+			skipLeadingRows: 1,
+			err:             "'skipLeadingRows' parameter is only supported for CSV files",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := bigqueryloadfromgcs.NewGCSReference(tc.uris, tc.format, false, tc.skipLeadingRows)
+			if err == nil {
+				t.Fatalf("expect reference to fail")
+			}
+			if err.Error() != tc.err {
+				t.Fatalf("unexpected error: got %q, want %q", err.Error(), tc.err)
+			}
+		})
+	}
+}
@@ -48,6 +48,7 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.T
 type compatibleSource interface {
 	BigQueryClient() *bigqueryapi.Client
 	BigQueryRestService() *bigqueryrestapi.Service
+	BytesBilledBudget() *bigqueryds.Budget
 }
 
 // validate compatible sources are still compatible
@@ -64,6 +65,9 @@ type Config struct {
 	AuthRequired       []string         `yaml:"authRequired"`
 	Parameters         tools.Parameters `yaml:"parameters"`
 	TemplateParameters tools.Parameters `yaml:"templateParameters"`
+	// MaximumBytesBilled limits the bytes billed by each query. Queries
+	// estimated to exceed it are rejected without running.
+	MaximumBytesBilled int64 `yaml:"maximumBytesBilled" validate:"gte=0"`
 }
 
 // validate interface
@@ -107,6 +111,8 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		Statement:          cfg.Statement,
 		AuthRequired:       cfg.AuthRequired,
 		Client:             s.BigQueryClient(),
+		MaximumBytesBilled: cfg.MaximumBytesBilled,
+		Budget:             s.BytesBilledBudget(),
 		RestService:        s.BigQueryRestService(),
 		manifest:           tools.Manifest{Description: cfg.Description, Parameters: paramManifest, AuthRequired: cfg.AuthRequired},
 		mcpManifest:        mcpManifest,
@@ -127,6 +133,9 @@ type Tool struct {
 	Statement          string
 	Client             *bigqueryapi.Client
 	RestService        *bigqueryrestapi.Service
+	// MaximumBytesBilled limits the bytes billed by each query.
+	MaximumBytesBilled int64
+	Budget             *bigqueryds.Budget
 	manifest           tools.Manifest
 	mcpManifest        tools.McpManifest
 }
@@ -136,7 +145,7 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 	lowLevelParams := make([]*bigqueryrestapi.QueryParameter, 0, len(t.Parameters))
 
 	paramsMap := params.AsMap()
-	newStatement, err := tools.ResolveTemplateParams(t.TemplateParameters, t.Statement, paramsMap)
+	newStatement, err := tools.ResolveTemplateParams(tools.DialectGoogleSQL, t.TemplateParameters, t.Statement, paramsMap)
 	if err != nil {
 		return nil, fmt.Errorf("unable to extract template params %w", err)
 	}
@@ -208,7 +217,7 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 		lowLevelParams = append(lowLevelParams, lowLevelParam)
 	}
 
-	query := t.Client.Query(newStatement)
+	query := t.Client.Query(tools.TagStatement(ctx, newStatement))
 	query.Parameters = highLevelParams
 	query.Location = t.Client.Location
 
@@ -217,7 +226,11 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 		// This is a fallback check in case the switch logic was bypassed.
 		return nil, fmt.Errorf("final query validation failed: %w", err)
 	}
+	if err := bigqueryds.CheckQueryCost(dryRunJob.Statistics.TotalBytesProcessed, t.MaximumBytesBilled, t.Budget); err != nil {
+		return nil, err
+	}
 	statementType := dryRunJob.Statistics.Query.StatementType
+	query.MaxBytesBilled = t.MaximumBytesBilled
 
 	// This block handles SELECT statements, which return a row set.
 	// We iterate through the results, convert each row into a map of
@@ -228,7 +241,7 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 	}
 
 	var out []any
-	for {
+	for !tools.RowLimitReached(ctx, len(out)) {
 		var row map[string]bigqueryapi.Value
 		err = it.Next(&row)
 		if err == iterator.Done {
@@ -243,6 +256,8 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 		}
 		out = append(out, vMap)
 	}
+	bigqueryds.ReportBytesBilled(ctx, it, t.Budget)
+
 	// If the query returned any rows, return them directly.
 	if len(out) > 0 {
 		return out, nil
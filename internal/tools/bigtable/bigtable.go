@@ -166,7 +166,7 @@ func getMapParamsType(tparams tools.Parameters, params tools.ParamValues) (map[s
 
 func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
 	paramsMap := params.AsMap()
-	newStatement, err := tools.ResolveTemplateParams(t.TemplateParameters, t.Statement, paramsMap)
+	newStatement, err := tools.ResolveTemplateParams(tools.DialectGoogleSQL, t.TemplateParameters, t.Statement, paramsMap)
 	if err != nil {
 		return nil, fmt.Errorf("unable to extract template params %w", err)
 	}
@@ -0,0 +1,274 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigtablewrite
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"cloud.google.com/go/bigtable"
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	bigtabledb "github.com/googleapis/genai-toolbox/internal/sources/bigtable"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+const kind string = "bigtable-write"
+const rowKeyKey string = "rowKey"
+const mutationsKey string = "mutations"
+
+// The operations of a mutation.
+const (
+	opSet          = "set"
+	opDeleteCells  = "deleteCells"
+	opDeleteFamily = "deleteFamily"
+	opDeleteRow    = "deleteRow"
+)
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	BigtableClient() *bigtable.Client
+}
+
+// validate compatible sources are still compatible
+var _ compatibleSource = &bigtabledb.Source{}
+
+var compatibleSources = [...]string{bigtabledb.SourceKind}
+
+type Config struct {
+	Name           string   `yaml:"name" validate:"required"`
+	Kind           string   `yaml:"kind" validate:"required"`
+	Source         string   `yaml:"source" validate:"required"`
+	Description    string   `yaml:"description" validate:"required"`
+	Table          string   `yaml:"table" validate:"required"`
+	ColumnFamilies []string `yaml:"columnFamilies" validate:"required,min=1"`
+	AllowDeleteRow bool     `yaml:"allowDeleteRow"`
+	AuthRequired   []string `yaml:"authRequired"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(compatibleSource)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
+	}
+
+	ops := fmt.Sprintf("'%s' to set the 'value' of a cell, '%s' to delete the cells of a column, '%s' to delete the cells of a column family", opSet, opDeleteCells, opDeleteFamily)
+	if cfg.AllowDeleteRow {
+		ops += fmt.Sprintf(", '%s' to delete the row", opDeleteRow)
+	}
+	rowKeyParameter := tools.NewStringParameter(rowKeyKey, "The key of the row to write.")
+	mutationsParameter := tools.NewArrayParameter(mutationsKey,
+		fmt.Sprintf("The mutations applied to the row, atomically and in order. Each mutation is an object with an 'op' (%s), and the 'family' and 'column' it applies to. The column families are %q.", ops, cfg.ColumnFamilies),
+		tools.NewMapParameter("mutation", "A mutation of the row.", ""))
+	parameters := tools.Parameters{rowKeyParameter, mutationsParameter}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:           cfg.Name,
+		Kind:           kind,
+		Parameters:     parameters,
+		AuthRequired:   cfg.AuthRequired,
+		ColumnFamilies: cfg.ColumnFamilies,
+		AllowDeleteRow: cfg.AllowDeleteRow,
+		Table:          s.BigtableClient().Open(cfg.Table),
+		manifest:       tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:    mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name           string           `yaml:"name"`
+	Kind           string           `yaml:"kind"`
+	AuthRequired   []string         `yaml:"authRequired"`
+	Parameters     tools.Parameters `yaml:"parameters"`
+	ColumnFamilies []string         `yaml:"columnFamilies"`
+	AllowDeleteRow bool             `yaml:"allowDeleteRow"`
+
+	Table       *bigtable.Table
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	mapParams := params.AsMap()
+	rowKey, ok := mapParams[rowKeyKey].(string)
+	if !ok || rowKey == "" {
+		return nil, fmt.Errorf("invalid or missing '%s' parameter; expected a non-empty string", rowKeyKey)
+	}
+	rawMutations, ok := mapParams[mutationsKey].([]any)
+	if !ok {
+		return nil, fmt.Errorf("invalid or missing '%s' parameter; expected an array", mutationsKey)
+	}
+	mutations, err := ParseMutations(rawMutations, t.ColumnFamilies, t.AllowDeleteRow)
+	if err != nil {
+		return nil, fmt.Errorf("invalid '%s' parameter: %w", mutationsKey, err)
+	}
+
+	mut := bigtable.NewMutation()
+	for _, m := range mutations {
+		switch m.Op {
+		case opSet:
+			mut.Set(m.Family, m.Column, bigtable.ServerTime, []byte(m.Value))
+		case opDeleteCells:
+			mut.DeleteCellsInColumn(m.Family, m.Column)
+		case opDeleteFamily:
+			mut.DeleteCellsInFamily(m.Family)
+		case opDeleteRow:
+			mut.DeleteRow()
+		}
+	}
+	if err := t.Table.Apply(ctx, rowKey, mut); err != nil {
+		return nil, fmt.Errorf("unable to apply mutations to row %q: %w", rowKey, err)
+	}
+	return map[string]any{"rowKey": rowKey, "mutations": len(mutations)}, nil
+}
+
+// Mutation is a mutation of a row.
+type Mutation struct {
+	Op     string
+	Family string
+	Column string
+	Value  string
+}
+
+// ParseMutations converts the mutations of the mutations parameter, checking
+// that they only apply to the allowed column families.
+func ParseMutations(raw []any, families []string, allowDeleteRow bool) ([]Mutation, error) {
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("at least one mutation is required")
+	}
+	mutations := make([]Mutation, 0, len(raw))
+	for i, r := range raw {
+		obj, ok := r.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("mutation #%d must be an object", i)
+		}
+		m, err := parseMutation(obj, families, allowDeleteRow)
+		if err != nil {
+			return nil, fmt.Errorf("mutation #%d: %w", i, err)
+		}
+		mutations = append(mutations, m)
+	}
+	return mutations, nil
+}
+
+func parseMutation(obj map[string]any, families []string, allowDeleteRow bool) (Mutation, error) {
+	var m Mutation
+	for k, v := range obj {
+		s, ok := v.(string)
+		if !ok {
+			return m, fmt.Errorf("%q must be a string", k)
+		}
+		switch k {
+		case "op":
+			m.Op = s
+		case "family":
+			m.Family = s
+		case "column":
+			m.Column = s
+		case "value":
+			m.Value = s
+		default:
+			return m, fmt.Errorf("unknown key %q", k)
+		}
+	}
+	_, hasValue := obj["value"]
+
+	switch m.Op {
+	case opSet, opDeleteCells:
+		if m.Column == "" {
+			return m, fmt.Errorf("'%s' requires a 'column'", m.Op)
+		}
+		if m.Op == opSet && !hasValue {
+			return m, fmt.Errorf("'%s' requires a 'value'", m.Op)
+		}
+	case opDeleteFamily:
+		if m.Column != "" {
+			return m, fmt.Errorf("'%s' does not take a 'column'", m.Op)
+		}
+	case opDeleteRow:
+		if !allowDeleteRow {
+			return m, fmt.Errorf("'%s' is not allowed by this tool", m.Op)
+		}
+		if m.Family != "" || m.Column != "" {
+			return m, fmt.Errorf("'%s' does not take a 'family' or a 'column'", m.Op)
+		}
+	case "":
+		return m, fmt.Errorf("missing 'op'")
+	default:
+		return m, fmt.Errorf("invalid op %q; expected %q, %q, %q or %q", m.Op, opSet, opDeleteCells, opDeleteFamily, opDeleteRow)
+	}
+	if hasValue && m.Op != opSet {
+		return m, fmt.Errorf("'%s' does not take a 'value'", m.Op)
+	}
+	if m.Op != opDeleteRow && !slices.Contains(families, m.Family) {
+		return m, fmt.Errorf("column family %q is not one of %q", m.Family, families)
+	}
+	return m, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
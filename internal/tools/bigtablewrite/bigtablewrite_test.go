@@ -0,0 +1,163 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigtablewrite_test
+
+import (
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools/bigtablewrite"
+)
+
+func TestParseFromYamlBigtableWrite(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: bigtable-write
+					source: my-bigtable-instance
+					description: some description
+					table: results
+					columnFamilies:
+						- outcome
+						- notes
+					allowDeleteRow: true
+			`,
+			want: server.ToolConfigs{
+				"example_tool": bigtablewrite.Config{
+					Name:           "example_tool",
+					Kind:           "bigtable-write",
+					Source:         "my-bigtable-instance",
+					Description:    "some description",
+					Table:          "results",
+					ColumnFamilies: []string{"outcome", "notes"},
+					AllowDeleteRow: true,
+					AuthRequired:   []string{},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestParseMutations(t *testing.T) {
+	families := []string{"outcome", "notes"}
+	tcs := []struct {
+		desc           string
+		in             []any
+		allowDeleteRow bool
+		want           []bigtablewrite.Mutation
+		wantErr        string
+	}{
+		{
+			desc: "mutations",
+			in: []any{
+				map[string]any{"op": "set", "family": "outcome", "column": "status", "value": "done"},
+				map[string]any{"op": "deleteCells", "family": "notes", "column": "draft"},
+				map[string]any{"op": "deleteFamily", "family": "notes"},
+			},
+			want: []bigtablewrite.Mutation{
+				{Op: "set", Family: "outcome", Column: "status", Value: "done"},
+				{Op: "deleteCells", Family: "notes", Column: "draft"},
+				{Op: "deleteFamily", Family: "notes"},
+			},
+		},
+		{
+			desc:           "delete row",
+			in:             []any{map[string]any{"op": "deleteRow"}},
+			allowDeleteRow: true,
+			want:           []bigtablewrite.Mutation{{Op: "deleteRow"}},
+		},
+		{
+			desc:    "delete row not allowed",
+			in:      []any{map[string]any{"op": "deleteRow"}},
+			wantErr: "mutation #0: 'deleteRow' is not allowed by this tool",
+		},
+		{
+			desc:    "family not allowed",
+			in:      []any{map[string]any{"op": "set", "family": "audit", "column": "status", "value": "done"}},
+			wantErr: `mutation #0: column family "audit" is not one of ["outcome" "notes"]`,
+		},
+		{
+			desc:    "set without value",
+			in:      []any{map[string]any{"op": "set", "family": "outcome", "column": "status"}},
+			wantErr: "mutation #0: 'set' requires a 'value'",
+		},
+		{
+			desc:    "delete with value",
+			in:      []any{map[string]any{"op": "deleteCells", "family": "outcome", "column": "status", "value": "done"}},
+			wantErr: "mutation #0: 'deleteCells' does not take a 'value'",
+		},
+		{
+			desc:    "unknown key",
+			in:      []any{map[string]any{"op": "set", "family": "outcome", "column": "status", "value": "done", "timestamp": "now"}},
+			wantErr: `mutation #0: unknown key "timestamp"`,
+		},
+		{
+			desc:    "invalid op",
+			in:      []any{map[string]any{"op": "increment", "family": "outcome", "column": "count"}},
+			wantErr: `mutation #0: invalid op "increment"; expected "set", "deleteCells", "deleteFamily" or "deleteRow"`,
+		},
+		{
+			desc:    "no mutations",
+			in:      []any{},
+			wantErr: "at least one mutation is required",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := bigtablewrite.ParseMutations(tc.in, families, tc.allowDeleteRow)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect mutations: diff %v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cassandracql
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"reflect"
+	"time"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/gocql/gocql"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/cassandra"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util/normalize"
+	"gopkg.in/inf.v0"
+)
+
+const kind string = "cassandra-cql"
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	CassandraSession() *gocql.Session
+}
+
+// validate compatible sources are still compatible
+var _ compatibleSource = &cassandra.Source{}
+
+var compatibleSources = [...]string{cassandra.SourceKind}
+
+type Config struct {
+	Name               string           `yaml:"name" validate:"required"`
+	Kind               string           `yaml:"kind" validate:"required"`
+	Source             string           `yaml:"source" validate:"required"`
+	Description        string           `yaml:"description" validate:"required"`
+	Statement          string           `yaml:"statement" validate:"required"`
+	AuthRequired       []string         `yaml:"authRequired"`
+	Parameters         tools.Parameters `yaml:"parameters"`
+	TemplateParameters tools.Parameters `yaml:"templateParameters"`
+}
+
+// validate interface
+var _ tools.StatementToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) ToolSource() string {
+	return cfg.Source
+}
+
+func (cfg Config) ToolStatement() string {
+	return cfg.Statement
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(compatibleSource)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
+	}
+
+	allParameters, paramManifest, paramMcpManifest, err := tools.ProcessParameters(cfg.TemplateParameters, cfg.Parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: paramMcpManifest,
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:               cfg.Name,
+		Kind:               kind,
+		Parameters:         cfg.Parameters,
+		TemplateParameters: cfg.TemplateParameters,
+		AllParams:          allParameters,
+		Statement:          cfg.Statement,
+		AuthRequired:       cfg.AuthRequired,
+		Session:            s.CassandraSession(),
+		manifest:           tools.Manifest{Description: cfg.Description, Parameters: paramManifest, AuthRequired: cfg.AuthRequired},
+		mcpManifest:        mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name               string           `yaml:"name"`
+	Kind               string           `yaml:"kind"`
+	AuthRequired       []string         `yaml:"authRequired"`
+	Parameters         tools.Parameters `yaml:"parameters"`
+	TemplateParameters tools.Parameters `yaml:"templateParameters"`
+	AllParams          tools.Parameters `yaml:"allParams"`
+
+	Session     *gocql.Session
+	Statement   string
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	paramsMap := params.AsMap()
+	// CQL quotes identifiers with double quotes, as ANSI SQL does
+	newStatement, err := tools.ResolveTemplateParams(tools.DialectANSI, t.TemplateParameters, t.Statement, paramsMap)
+	if err != nil {
+		return nil, fmt.Errorf("unable to extract template params %w", err)
+	}
+
+	newParams, err := tools.GetParams(t.Parameters, paramsMap)
+	if err != nil {
+		return nil, fmt.Errorf("unable to extract standard params %w", err)
+	}
+
+	// the driver binds arrays, such as the values of array parameters, as
+	// lists, which IN ? accepts
+	newStatement, sliceParams, err := tools.ConvertPlaceholders(tools.PlaceholderQuestion, newStatement, newParams)
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert placeholders: %w", err)
+	}
+	newStatement = tools.TagStatement(ctx, newStatement)
+	// the driver prepares the statement, and caches it, so that it is routed
+	// to a replica of the partition it reads or writes
+	iter := t.Session.Query(newStatement, sliceParams...).WithContext(ctx).Iter()
+
+	rowData, err := iter.RowData()
+	if err != nil {
+		_ = iter.Close()
+		return nil, fmt.Errorf("unable to execute query: %w", err)
+	}
+	// scan each column into a pointer to a value of its type, which the
+	// driver sets to nil for null values
+	values := make([]any, len(rowData.Values))
+	for i, v := range rowData.Values {
+		values[i] = reflect.New(reflect.TypeOf(v)).Interface()
+	}
+
+	var out []any
+	for !tools.RowLimitReached(ctx, len(out)) && iter.Scan(values...) {
+		vMap := make(map[string]any)
+		for i, name := range rowData.Columns {
+			vMap[name] = convertValue(values[i])
+		}
+		out = append(out, vMap)
+	}
+
+	if err := iter.Close(); err != nil {
+		return nil, fmt.Errorf("unable to execute query: %w", err)
+	}
+
+	return out, nil
+}
+
+// convertValue dereferences the scanned values, and returns UUIDs as
+// strings and decimals as exact numbers. Varints are left to the
+// normalization of results, as *big.Int.
+func convertValue(v any) any {
+	switch v := v.(type) {
+	case *inf.Dec:
+		if v == nil {
+			return nil
+		}
+		return normalize.Decimal(v.String())
+	case *big.Int:
+		if v == nil {
+			return nil
+		}
+		return v
+	case gocql.UUID:
+		return v.String()
+	case time.Duration:
+		return v.String()
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil
+		}
+		return convertValue(rv.Elem().Interface())
+	}
+	return v
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.AllParams, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
@@ -0,0 +1,120 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudsqlcommon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+
+	httpsrc "github.com/googleapis/genai-toolbox/internal/sources/http"
+)
+
+// Client calls the Cloud SQL Admin API through an http source, such as one
+// with a base URL of "https://sqladmin.googleapis.com".
+type Client struct {
+	BaseURL     string
+	Headers     map[string]string
+	QueryParams map[string]string
+	HTTPClient  *http.Client
+}
+
+// NewClient returns a Client sending requests with the base URL, headers and
+// query parameters of s.
+func NewClient(s *httpsrc.Source) *Client {
+	return &Client{
+		BaseURL:     s.BaseURL,
+		Headers:     maps.Clone(s.DefaultHeaders),
+		QueryParams: s.QueryParams,
+		HTTPClient:  s.Client,
+	}
+}
+
+// ResourcePath joins the segments of a resource path under /v1, escaping
+// each of them so parameters can't address other resources.
+func ResourcePath(segments ...string) (string, error) {
+	path := "/v1"
+	for _, s := range segments {
+		if s == "" {
+			return "", fmt.Errorf("resource path segments must not be empty")
+		}
+		path += "/" + url.PathEscape(s)
+	}
+	return path, nil
+}
+
+// Do sends a request to path, with body encoded as JSON if it isn't nil, and
+// returns the decoded JSON response.
+func (c *Client) Do(ctx context.Context, method, path string, query url.Values, body any) (map[string]any, error) {
+	u, err := url.Parse(c.BaseURL + path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse url: %w", err)
+	}
+	q := u.Query()
+	for k, v := range c.QueryParams {
+		q.Set(k, v)
+	}
+	for k, v := range query {
+		q[k] = v
+	}
+	u.RawQuery = q.Encode()
+
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request: %w", err)
+		}
+		reqBody = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	for k, v := range c.Headers {
+		req.Header.Set(k, v)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("unexpected status code: %d, response body: %s", resp.StatusCode, string(respBody))
+	}
+
+	out := map[string]any{}
+	if len(respBody) == 0 {
+		return out, nil
+	}
+	if err := json.Unmarshal(respBody, &out); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return out, nil
+}
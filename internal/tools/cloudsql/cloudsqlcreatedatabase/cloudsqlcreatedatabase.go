@@ -0,0 +1,150 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudsqlcreatedatabase
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	httpsrc "github.com/googleapis/genai-toolbox/internal/sources/http"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/cloudsql/cloudsqlcommon"
+)
+
+const kind string = "cloud-sql-create-database"
+const projectKey string = "project"
+const instanceKey string = "instance"
+const nameKey string = "name"
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name         string   `yaml:"name" validate:"required"`
+	Kind         string   `yaml:"kind" validate:"required"`
+	Source       string   `yaml:"source" validate:"required"`
+	Description  string   `yaml:"description" validate:"required"`
+	AuthRequired []string `yaml:"authRequired"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	s, ok := srcs[cfg.Source].(*httpsrc.Source)
+	if !ok {
+		return nil, fmt.Errorf("invalid or missing source for %q tool: source kind must be `http`", kind)
+	}
+
+	projectParameter := tools.NewStringParameter(projectKey, "The Google Cloud project ID.")
+	instanceParameter := tools.NewStringParameter(instanceKey, "The ID of the Cloud SQL instance, without the project ID.")
+	nameParameter := tools.NewStringParameter(nameKey, "The name of the database to create.")
+	parameters := tools.Parameters{projectParameter, instanceParameter, nameParameter}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   parameters,
+		AuthRequired: cfg.AuthRequired,
+		Client:       cloudsqlcommon.NewClient(s),
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:  mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+
+	Client      *cloudsqlcommon.Client
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	mapParams := params.AsMap()
+	project, ok := mapParams[projectKey].(string)
+	if !ok || project == "" {
+		return nil, fmt.Errorf("invalid or missing '%s' parameter; expected a non-empty string", projectKey)
+	}
+	instance, ok := mapParams[instanceKey].(string)
+	if !ok || instance == "" {
+		return nil, fmt.Errorf("invalid or missing '%s' parameter; expected a non-empty string", instanceKey)
+	}
+	name, ok := mapParams[nameKey].(string)
+	if !ok || name == "" {
+		return nil, fmt.Errorf("invalid or missing '%s' parameter; expected a non-empty string", nameKey)
+	}
+	path, err := cloudsqlcommon.ResourcePath("projects", project, "instances", instance, "databases")
+	if err != nil {
+		return nil, err
+	}
+
+	// the database is created by a long-running operation, which is returned
+	// for the caller to check on
+	body := map[string]any{"name": name, "project": project, "instance": instance}
+	resp, err := t.Client.Do(ctx, http.MethodPost, path, nil, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create database %q on instance %q: %w", name, instance, err)
+	}
+	return resp, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
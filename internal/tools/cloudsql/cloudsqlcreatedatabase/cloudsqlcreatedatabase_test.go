@@ -0,0 +1,119 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudsqlcreatedatabase_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	httpsrc "github.com/googleapis/genai-toolbox/internal/sources/http"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/cloudsql/cloudsqlcreatedatabase"
+)
+
+func TestParseFromYaml(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: cloud-sql-create-database
+					source: my-sqladmin
+					description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": cloudsqlcreatedatabase.Config{
+					Name:         "example_tool",
+					Kind:         "cloud-sql-create-database",
+					Source:       "my-sqladmin",
+					Description:  "some description",
+					AuthRequired: []string{},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInvokeCreatesDatabase(t *testing.T) {
+	var gotMethod, gotPath string
+	var gotBody map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod, gotPath = r.Method, r.URL.Path
+		if err := json.NewDecoder(r.Body).Decode(&gotBody); err != nil {
+			t.Errorf("unable to decode request: %s", err)
+		}
+		_, _ = w.Write([]byte(`{"kind": "sql#operation", "name": "op-1", "status": "PENDING"}`))
+	}))
+	defer srv.Close()
+
+	src := &httpsrc.Source{Name: "my-sqladmin", Kind: httpsrc.SourceKind, BaseURL: srv.URL, Client: srv.Client()}
+	cfg := cloudsqlcreatedatabase.Config{Name: "create", Kind: "cloud-sql-create-database", Source: "my-sqladmin", Description: "some description"}
+	tool, err := cfg.Initialize(map[string]sources.Source{"my-sqladmin": src})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	got, err := tool.Invoke(context.Background(), tools.ParamValues{
+		{Name: "project", Value: "my-project"},
+		{Name: "instance", Value: "my-instance"},
+		{Name: "name", Value: "orders"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if gotMethod != http.MethodPost || gotPath != "/v1/projects/my-project/instances/my-instance/databases" {
+		t.Fatalf("unexpected request: %s %s", gotMethod, gotPath)
+	}
+	wantBody := map[string]any{"name": "orders", "project": "my-project", "instance": "my-instance"}
+	if diff := cmp.Diff(wantBody, gotBody); diff != "" {
+		t.Fatalf("incorrect request body: diff %v", diff)
+	}
+	want := map[string]any{"kind": "sql#operation", "name": "op-1", "status": "PENDING"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect result: diff %v", diff)
+	}
+}
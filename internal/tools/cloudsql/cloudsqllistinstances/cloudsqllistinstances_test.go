@@ -0,0 +1,120 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudsqllistinstances_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	httpsrc "github.com/googleapis/genai-toolbox/internal/sources/http"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/cloudsql/cloudsqllistinstances"
+)
+
+func TestParseFromYaml(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: cloud-sql-list-instances
+					source: my-sqladmin
+					description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": cloudsqllistinstances.Config{
+					Name:         "example_tool",
+					Kind:         "cloud-sql-list-instances",
+					Source:       "my-sqladmin",
+					Description:  "some description",
+					AuthRequired: []string{},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInvokeFollowsPages(t *testing.T) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer my-token" {
+			t.Errorf("unexpected authorization header: %q", got)
+		}
+		paths = append(paths, r.URL.RequestURI())
+		if r.URL.Query().Get("pageToken") == "" {
+			_, _ = w.Write([]byte(`{"items": [{"name": "a"}], "nextPageToken": "next"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"items": [{"name": "b"}]}`))
+	}))
+	defer srv.Close()
+
+	src := &httpsrc.Source{
+		Name:           "my-sqladmin",
+		Kind:           httpsrc.SourceKind,
+		BaseURL:        srv.URL,
+		DefaultHeaders: map[string]string{"Authorization": "Bearer my-token"},
+		Client:         srv.Client(),
+	}
+	cfg := cloudsqllistinstances.Config{Name: "list", Kind: "cloud-sql-list-instances", Source: "my-sqladmin", Description: "some description"}
+	tool, err := cfg.Initialize(map[string]sources.Source{"my-sqladmin": src})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	got, err := tool.Invoke(context.Background(), tools.ParamValues{{Name: "project", Value: "my/project"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := []any{map[string]any{"name": "a"}, map[string]any{"name": "b"}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect result: diff %v", diff)
+	}
+	wantPaths := []string{"/v1/projects/my%2Fproject/instances", "/v1/projects/my%2Fproject/instances?pageToken=next"}
+	if diff := cmp.Diff(wantPaths, paths); diff != "" {
+		t.Fatalf("incorrect requests: diff %v", diff)
+	}
+}
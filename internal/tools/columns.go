@@ -0,0 +1,112 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"slices"
+)
+
+// ColumnProjection restricts the columns of the results of a tool returned
+// to callers, by the roles in a claim verified by their auth services.
+type ColumnProjection struct {
+	// RoleClaim is the claim listing the roles of the caller, as a string or
+	// a list of strings.
+	RoleClaim string `yaml:"roleClaim" validate:"required"`
+	// Roles are the columns returned to callers by role. Callers with
+	// several roles get the columns of any of them.
+	Roles map[string]ColumnList `yaml:"roles" validate:"dive"`
+	// Default are the columns returned to callers without any of Roles, or
+	// every column if it isn't set.
+	Default *ColumnList `yaml:"default"`
+}
+
+// ColumnList allows only the columns of Allow, or every column except the
+// columns of Deny. It allows every column if neither is set.
+type ColumnList struct {
+	Allow []string `yaml:"allow" validate:"excluded_with=Deny"`
+	Deny  []string `yaml:"deny"`
+}
+
+// allows reports whether l allows column.
+func (l ColumnList) allows(column string) bool {
+	if len(l.Allow) > 0 {
+		return slices.Contains(l.Allow, column)
+	}
+	return !slices.Contains(l.Deny, column)
+}
+
+// lists returns the column lists applying to a caller with claims.
+func (p ColumnProjection) lists(claims map[string]any) []ColumnList {
+	var roles []string
+	switch v := claims[p.RoleClaim].(type) {
+	case string:
+		roles = []string{v}
+	case []any:
+		for _, r := range v {
+			if s, ok := r.(string); ok {
+				roles = append(roles, s)
+			}
+		}
+	case []string:
+		roles = v
+	}
+	var lists []ColumnList
+	for _, r := range roles {
+		if l, ok := p.Roles[r]; ok {
+			lists = append(lists, l)
+		}
+	}
+	if len(lists) == 0 && p.Default != nil {
+		lists = append(lists, *p.Default)
+	}
+	return lists
+}
+
+// ProjectColumns returns t with the columns of its results restricted by the
+// tool's columnProjection option, or t itself if it isn't set. Columns are
+// removed from every row the caller's roles don't allow.
+func ProjectColumns(cfg ToolConfig, t Tool) Tool {
+	oc, ok := cfg.(ConfigWithOptions)
+	if !ok || oc.Options.ColumnProjection == nil {
+		return t
+	}
+	return toolWithColumnProjection{Tool: t, projection: *oc.Options.ColumnProjection}
+}
+
+type toolWithColumnProjection struct {
+	Tool
+	projection ColumnProjection
+}
+
+func (t toolWithColumnProjection) Invoke(ctx context.Context, params ParamValues) (any, error) {
+	res, err := t.Tool.Invoke(ctx, params)
+	if err != nil {
+		return res, err
+	}
+	lists := t.projection.lists(callerClaims(InvocationContextFromContext(ctx)))
+	if len(lists) == 0 {
+		return res, nil
+	}
+	return mapRows(res, "columnProjection", func(row map[string]any) (map[string]any, error) {
+		out := make(map[string]any, len(row))
+		for column, v := range row {
+			if slices.ContainsFunc(lists, func(l ColumnList) bool { return l.allows(column) }) {
+				out[column] = v
+			}
+		}
+		return out, nil
+	})
+}
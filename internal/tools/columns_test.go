@@ -0,0 +1,122 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+func TestProjectColumns(t *testing.T) {
+	rows := []any{
+		map[string]any{"id": 1, "title": "broken login", "owner_email": "alice@example.com"},
+		map[string]any{"id": 2, "title": "slow search", "owner_email": "bob@example.com"},
+	}
+	projection := tools.ColumnProjection{
+		RoleClaim: "roles",
+		Roles: map[string]tools.ColumnList{
+			"analyst":        {},
+			"external-agent": {Allow: []string{"id", "title"}},
+			"support":        {Deny: []string{"title"}},
+		},
+		Default: &tools.ColumnList{Allow: []string{"id"}},
+	}
+	withRoles := func(roles any) tools.InvocationContext {
+		return tools.InvocationContext{
+			AuthServices: []string{"my-google-auth"},
+			Claims:       map[string]map[string]any{"my-google-auth": {"roles": roles}},
+		}
+	}
+	tcs := []struct {
+		desc       string
+		projection tools.ColumnProjection
+		res        any
+		ic         tools.InvocationContext
+		want       any
+	}{
+		{
+			desc:       "full rows",
+			projection: projection,
+			res:        rows,
+			ic:         withRoles("analyst"),
+			want:       rows,
+		},
+		{
+			desc:       "allowed columns",
+			projection: projection,
+			res:        rows,
+			ic:         withRoles("external-agent"),
+			want: []any{
+				map[string]any{"id": 1, "title": "broken login"},
+				map[string]any{"id": 2, "title": "slow search"},
+			},
+		},
+		{
+			desc:       "denied columns",
+			projection: projection,
+			res:        rows[0],
+			ic:         withRoles("support"),
+			want:       map[string]any{"id": 1, "owner_email": "alice@example.com"},
+		},
+		{
+			desc:       "columns of any role",
+			projection: projection,
+			res:        rows[0],
+			ic:         withRoles([]any{"external-agent", "support"}),
+			want:       rows[0],
+		},
+		{
+			desc:       "default",
+			projection: projection,
+			res:        rows[0],
+			ic:         withRoles("unknown"),
+			want:       map[string]any{"id": 1},
+		},
+		{
+			desc:       "anonymous caller",
+			projection: projection,
+			res:        rows[0],
+			want:       map[string]any{"id": 1},
+		},
+		{
+			desc:       "no default",
+			projection: tools.ColumnProjection{RoleClaim: "roles", Roles: projection.Roles},
+			res:        rows[0],
+			want:       rows[0],
+		},
+		{
+			desc:       "message",
+			projection: projection,
+			res:        "The query returned 0 rows.",
+			want:       "The query returned 0 rows.",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := tools.WithOptions(mockToolConfig{}, tools.Options{ColumnProjection: &tc.projection})
+			tool := tools.ProjectColumns(cfg, rowsTool{res: tc.res})
+			got, err := tool.Invoke(tools.WithInvocationContext(context.Background(), tc.ic), nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect result: diff %v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// MaxCompletionValues is the maximum number of values of a completion, as
+// allowed by MCP.
+const MaxCompletionValues = 100
+
+// CompletionQuerier is implemented by tools able to run the statement of a
+// parameter completion on their source.
+type CompletionQuerier interface {
+	// QueryCompletions runs statement and returns the values of its first
+	// column.
+	QueryCompletions(ctx context.Context, statement string) ([]string, error)
+}
+
+// Completion is the suggested values of a parameter.
+type Completion struct {
+	Values  []string
+	Total   int
+	HasMore bool
+}
+
+// Complete returns the suggested values of the parameter name of tool that
+// start with prefix, ignoring case. A parameter without completion has no
+// suggested values.
+func Complete(ctx context.Context, tool Tool, name, prefix string) (Completion, error) {
+	var pc *ParamCompletion
+	found := false
+	for _, p := range tool.Manifest().Parameters {
+		if p.Name == name {
+			pc, found = p.Completion, true
+			break
+		}
+	}
+	if !found {
+		return Completion{}, fmt.Errorf("tool has no parameter %q", name)
+	}
+	if pc == nil {
+		return Completion{Values: []string{}}, nil
+	}
+
+	values := pc.Values
+	if pc.Statement != "" {
+		q, ok := tool.(CompletionQuerier)
+		if !ok {
+			return Completion{}, fmt.Errorf("completion statements are not supported by the tool")
+		}
+		var err error
+		values, err = q.QueryCompletions(ctx, pc.Statement)
+		if err != nil {
+			return Completion{}, fmt.Errorf("unable to query completions: %w", err)
+		}
+	}
+
+	prefix = strings.ToLower(prefix)
+	matches := []string{}
+	for _, v := range values {
+		if strings.HasPrefix(strings.ToLower(v), prefix) {
+			matches = append(matches, v)
+		}
+	}
+	c := Completion{Values: matches, Total: len(matches)}
+	if len(matches) > MaxCompletionValues {
+		c.Values = matches[:MaxCompletionValues]
+		c.HasMore = true
+	}
+	return c, nil
+}
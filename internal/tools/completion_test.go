@@ -0,0 +1,102 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+// manifestTool is a tool with the manifest of params.
+type manifestTool struct {
+	tools.Tool
+	params tools.Parameters
+}
+
+func (t manifestTool) Manifest() tools.Manifest {
+	return tools.Manifest{Parameters: t.params.Manifest()}
+}
+
+// completionTool is a tool whose completion statements return queried.
+type completionTool struct {
+	manifestTool
+	queried []string
+}
+
+func (t completionTool) QueryCompletions(context.Context, string) ([]string, error) {
+	return t.queried, nil
+}
+
+func TestComplete(t *testing.T) {
+	static := tools.NewStringParameter("static", "a param with values")
+	static.Completion = &tools.ParamCompletion{Values: []string{"Basel", "bern", "Zurich"}}
+	queried := tools.NewStringParameter("queried", "a param with a statement")
+	queried.Completion = &tools.ParamCompletion{Statement: "SELECT name FROM hotels"}
+	many := make([]string, 150)
+	for i := range many {
+		many[i] = fmt.Sprintf("hotel %d", i)
+	}
+	tool := completionTool{
+		manifestTool: manifestTool{params: tools.Parameters{static, queried, tools.NewIntParameter("plain", "a param without completion")}},
+		queried:      many,
+	}
+
+	tcs := []struct {
+		name   string
+		param  string
+		prefix string
+		want   tools.Completion
+	}{
+		{
+			name:   "values matching the prefix ignoring case",
+			param:  "static",
+			prefix: "B",
+			want:   tools.Completion{Values: []string{"Basel", "bern"}, Total: 2},
+		},
+		{
+			name:  "no completion",
+			param: "plain",
+			want:  tools.Completion{Values: []string{}},
+		},
+		{
+			name:  "queried values are truncated",
+			param: "queried",
+			want:  tools.Completion{Values: many[:tools.MaxCompletionValues], Total: 150, HasMore: true},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tools.Complete(context.Background(), tool, tc.param, tc.prefix)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("unexpected completion: diff %v", diff)
+			}
+		})
+	}
+
+	if _, err := tools.Complete(context.Background(), tool, "unknown", ""); err == nil {
+		t.Fatalf("expected an error for an unknown parameter")
+	}
+	// statements require a tool able to query its source
+	if _, err := tools.Complete(context.Background(), tool.manifestTool, "queried", ""); err == nil {
+		t.Fatalf("expected an error for a tool without a querier")
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"slices"
+	"strconv"
+
+	"github.com/googleapis/genai-toolbox/internal/telemetry"
+)
+
+// Kinds of drift between the results of a tool and its result schema.
+const (
+	DriftMissing    = "missing"
+	DriftUnexpected = "unexpected"
+	DriftType       = "type"
+)
+
+// ResultColumn is a column of the result schema declared by a tool.
+type ResultColumn struct {
+	Name string `yaml:"name" validate:"required"`
+	Type string `yaml:"type" validate:"required,oneof=string integer float boolean array map"`
+}
+
+// CheckContract returns t with the rows of its results checked against the
+// tool's resultSchema option, or t itself if it isn't set. Columns of the
+// schema missing from every row, columns of rows which aren't in the schema,
+// and values of another type than their column are returned as warnings, and
+// counted in the telemetry.InvocationStats of the invocation.
+//
+// Null values match any type. Decimals and big integers, which can be
+// returned as strings, match the float and integer types.
+func CheckContract(cfg ToolConfig, t Tool) Tool {
+	oc, ok := cfg.(ConfigWithOptions)
+	if !ok || len(oc.Options.ResultSchema) == 0 {
+		return t
+	}
+	return toolWithContract{Tool: t, schema: oc.Options.ResultSchema}
+}
+
+type toolWithContract struct {
+	Tool
+	schema []ResultColumn
+}
+
+func (t toolWithContract) Invoke(ctx context.Context, params ParamValues) (any, error) {
+	res, err := t.Tool.Invoke(ctx, params)
+	if err != nil {
+		return res, err
+	}
+	var rows []map[string]any
+	switch res := res.(type) {
+	case map[string]any:
+		rows = []map[string]any{res}
+	case []any:
+		for _, item := range res {
+			if row, ok := item.(map[string]any); ok {
+				rows = append(rows, row)
+			}
+		}
+	}
+	stats := telemetry.InvocationStatsFromContext(ctx)
+	for _, d := range contractDrift(t.schema, rows) {
+		AddWarning(ctx, "result drifted from the declared resultSchema: %s", d.message)
+		stats.AddContractDrift(d.kind)
+	}
+	return res, nil
+}
+
+type drift struct {
+	kind    string
+	message string
+}
+
+// contractDrift returns the drift of rows from schema, sorted by column.
+func contractDrift(schema []ResultColumn, rows []map[string]any) []drift {
+	if len(rows) == 0 {
+		return nil
+	}
+	var drifts []drift
+	declared := make(map[string]bool, len(schema))
+	for _, c := range schema {
+		declared[c.Name] = true
+		present := false
+		mismatch := ""
+		for _, row := range rows {
+			v, ok := row[c.Name]
+			if !ok {
+				continue
+			}
+			present = true
+			if mismatch == "" && !matchesType(v, c.Type) {
+				mismatch = valueType(v)
+			}
+		}
+		switch {
+		case !present:
+			drifts = append(drifts, drift{DriftMissing, fmt.Sprintf("column %q is missing", c.Name)})
+		case mismatch != "":
+			drifts = append(drifts, drift{DriftType, fmt.Sprintf("column %q is of type %s, not %s", c.Name, mismatch, c.Type)})
+		}
+	}
+	var unexpected []string
+	for _, row := range rows {
+		for column := range row {
+			if !declared[column] && !slices.Contains(unexpected, column) {
+				unexpected = append(unexpected, column)
+			}
+		}
+	}
+	slices.Sort(unexpected)
+	for _, column := range unexpected {
+		drifts = append(drifts, drift{DriftUnexpected, fmt.Sprintf("column %q is not declared", column)})
+	}
+	return drifts
+}
+
+// matchesType reports whether the normalized value v is of the type of a
+// result column.
+func matchesType(v any, typ string) bool {
+	if v == nil {
+		return true
+	}
+	got := valueType(v)
+	switch {
+	case got == typ:
+		return true
+	case typ == "float" && got == "integer":
+		return true
+	case got == "string" && (typ == "integer" || typ == "float"):
+		s := v.(string)
+		if typ == "integer" {
+			_, err := strconv.ParseInt(s, 10, 64)
+			if err == nil {
+				return true
+			}
+			// integers JavaScript can't represent exactly are strings
+			_, ok := new(big.Int).SetString(s, 10)
+			return ok
+		}
+		_, err := strconv.ParseFloat(s, 64)
+		return err == nil
+	}
+	return false
+}
+
+// valueType returns the type of a result column of the normalized value v.
+func valueType(v any) string {
+	switch v := v.(type) {
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return "integer"
+	case float32, float64:
+		return "float"
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return "integer"
+		}
+		return "float"
+	case map[string]any:
+		return "map"
+	case []any:
+		return "array"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/telemetry"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+func TestCheckContract(t *testing.T) {
+	schema := []tools.ResultColumn{
+		{Name: "id", Type: "integer"},
+		{Name: "title", Type: "string"},
+		{Name: "price", Type: "float"},
+		{Name: "tags", Type: "array"},
+	}
+	tcs := []struct {
+		desc      string
+		res       any
+		warnings  []string
+		wantDrift map[string]int64
+	}{
+		{
+			desc: "matching rows",
+			res: []any{
+				map[string]any{"id": int64(1), "title": "a", "price": 1.5, "tags": []any{"x"}},
+				map[string]any{"id": int64(2), "title": nil, "price": int64(3), "tags": []any{}},
+			},
+		},
+		{
+			desc: "decimals as strings",
+			res:  map[string]any{"id": "9007199254740993", "title": "a", "price": "1.50", "tags": nil},
+		},
+		{
+			desc: "drifted rows",
+			res: []any{
+				map[string]any{"id": "one", "title": "a", "price": 1.5, "owner": "alice@example.com"},
+				map[string]any{"id": int64(2), "title": "b", "price": 2.5, "created": "2025-01-01"},
+			},
+			warnings: []string{
+				`result drifted from the declared resultSchema: column "id" is of type string, not integer`,
+				`result drifted from the declared resultSchema: column "tags" is missing`,
+				`result drifted from the declared resultSchema: column "created" is not declared`,
+				`result drifted from the declared resultSchema: column "owner" is not declared`,
+			},
+			wantDrift: map[string]int64{tools.DriftType: 1, tools.DriftMissing: 1, tools.DriftUnexpected: 2},
+		},
+		{
+			desc: "no rows",
+			res:  []any{},
+		},
+		{
+			desc: "message",
+			res:  "The query returned 0 rows.",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := tools.WithOptions(mockToolConfig{}, tools.Options{ResultSchema: schema})
+			tool := tools.CheckContract(cfg, rowsTool{res: tc.res})
+			stats := &telemetry.InvocationStats{}
+			ctx := telemetry.WithInvocationStats(tools.WithWarnings(context.Background()), stats)
+			got, err := tool.Invoke(ctx, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.res, got); diff != "" {
+				t.Fatalf("result was changed: diff %v", diff)
+			}
+			if diff := cmp.Diff(tc.warnings, tools.Warnings(ctx)); diff != "" {
+				t.Fatalf("incorrect warnings: diff %v", diff)
+			}
+			if diff := cmp.Diff(tc.wantDrift, stats.ContractDrift()); diff != "" {
+				t.Fatalf("incorrect drift: diff %v", diff)
+			}
+		})
+	}
+}
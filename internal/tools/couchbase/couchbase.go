@@ -130,7 +130,7 @@ type Tool struct {
 
 func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
 	namedParamsMap := params.AsMap()
-	newStatement, err := tools.ResolveTemplateParams(t.TemplateParameters, t.Statement, namedParamsMap)
+	newStatement, err := tools.ResolveTemplateParams("", t.TemplateParameters, t.Statement, namedParamsMap)
 	if err != nil {
 		return nil, fmt.Errorf("unable to extract template params %w", err)
 	}
@@ -146,9 +146,13 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 	if err != nil {
 		return nil, fmt.Errorf("unable to execute query: %w", err)
 	}
+	defer results.Close() //nolint:errcheck
 
 	var out []any
 	for results.Next() {
+		if tools.RowLimitReached(ctx, len(out)) {
+			break
+		}
 		var result json.RawMessage
 		err := results.Row(&result)
 		if err != nil {
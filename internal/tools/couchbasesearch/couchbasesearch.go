@@ -0,0 +1,223 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package couchbasesearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/couchbase/gocb/v2"
+	cbsearch "github.com/couchbase/gocb/v2/search"
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/couchbase"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+const kind string = "couchbase-search"
+const queryKey string = "query"
+const fieldsKey string = "fields"
+const limitKey string = "limit"
+
+// defaultLimit is the default number of hits returned when limit is not
+// configured.
+const defaultLimit = 10
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	CouchbaseScope() *gocb.Scope
+}
+
+// validate compatible sources are still compatible
+var _ compatibleSource = &couchbase.Source{}
+
+var compatibleSources = [...]string{couchbase.SourceKind}
+
+type Config struct {
+	Name         string   `yaml:"name" validate:"required"`
+	Kind         string   `yaml:"kind" validate:"required"`
+	Source       string   `yaml:"source" validate:"required"`
+	Description  string   `yaml:"description" validate:"required"`
+	Index        string   `yaml:"index" validate:"required"`
+	Fields       []string `yaml:"fields"`
+	Limit        int      `yaml:"limit" validate:"gte=0"`
+	AuthRequired []string `yaml:"authRequired"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(compatibleSource)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
+	}
+
+	limit := cfg.Limit
+	if limit == 0 {
+		limit = defaultLimit
+	}
+	fields := make([]any, 0, len(cfg.Fields))
+	for _, f := range cfg.Fields {
+		fields = append(fields, f)
+	}
+
+	queryParameter := tools.NewStringParameter(queryKey, "The full-text search query, in the query string syntax, for example `+city:paris description:\"swimming pool\"`.")
+	fieldsParameter := tools.NewArrayParameterWithDefault(fieldsKey, fields, "The stored fields of the documents to return with each hit, or `*` for all of them.", tools.NewStringParameter("field", "A stored field."))
+	limitParameter := tools.NewIntParameterWithDefault(limitKey, limit, "The maximum number of hits to return.")
+	parameters := tools.Parameters{queryParameter, fieldsParameter, limitParameter}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   parameters,
+		AuthRequired: cfg.AuthRequired,
+		Index:        cfg.Index,
+		Scope:        s.CouchbaseScope(),
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:  mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+	Index        string           `yaml:"index"`
+
+	Scope       *gocb.Scope
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	mapParams := params.AsMap()
+	query, ok := mapParams[queryKey].(string)
+	if !ok || query == "" {
+		return nil, fmt.Errorf("invalid or missing '%s' parameter; expected a non-empty string", queryKey)
+	}
+	rawFields, _ := mapParams[fieldsKey].([]any)
+	fields := make([]string, 0, len(rawFields))
+	for _, f := range rawFields {
+		field, ok := f.(string)
+		if !ok || field == "" {
+			return nil, fmt.Errorf("invalid '%s' parameter; expected an array of non-empty strings", fieldsKey)
+		}
+		fields = append(fields, field)
+	}
+	limit, ok := mapParams[limitKey].(int)
+	if !ok || limit <= 0 {
+		return nil, fmt.Errorf("invalid '%s' parameter; expected a positive integer", limitKey)
+	}
+
+	results, err := t.Scope.Search(t.Index, gocb.SearchRequest{SearchQuery: cbsearch.NewQueryStringQuery(query)}, &gocb.SearchOptions{
+		Limit:   uint32(limit),
+		Fields:  fields,
+		Context: ctx,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to execute search: %w", err)
+	}
+	return ReadHits(results.Raw())
+}
+
+// hitReader reads the raw hits of a search result.
+type hitReader interface {
+	NextBytes() []byte
+	Err() error
+	Close() error
+}
+
+// ReadHits returns the id, score and requested fields of each hit of a
+// search result.
+func ReadHits(r hitReader) ([]any, error) {
+	defer r.Close()
+	out := []any{}
+	for b := r.NextBytes(); b != nil; b = r.NextBytes() {
+		var hit struct {
+			ID     string         `json:"id"`
+			Score  float64        `json:"score"`
+			Fields map[string]any `json:"fields"`
+		}
+		decoder := json.NewDecoder(bytes.NewReader(b))
+		decoder.UseNumber()
+		if err := decoder.Decode(&hit); err != nil {
+			return nil, fmt.Errorf("error processing hit: %w", err)
+		}
+		row := map[string]any{"id": hit.ID, "score": hit.Score}
+		if hit.Fields != nil {
+			row["fields"] = hit.Fields
+		}
+		out = append(out, row)
+	}
+	if err := r.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read search results: %w", err)
+	}
+	return out, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
@@ -0,0 +1,126 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package couchbasesearch_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools/couchbasesearch"
+)
+
+func TestParseFromYamlCouchbaseSearch(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: couchbase-search
+					source: my-couchbase-instance
+					description: some description
+					index: hotels-index
+					fields:
+						- name
+						- city
+					limit: 5
+			`,
+			want: server.ToolConfigs{
+				"example_tool": couchbasesearch.Config{
+					Name:         "example_tool",
+					Kind:         "couchbase-search",
+					Source:       "my-couchbase-instance",
+					Description:  "some description",
+					Index:        "hotels-index",
+					Fields:       []string{"name", "city"},
+					Limit:        5,
+					AuthRequired: []string{},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+// fakeHits is a search result with the given raw hits.
+type fakeHits struct {
+	hits   []string
+	closed bool
+}
+
+func (f *fakeHits) NextBytes() []byte {
+	if len(f.hits) == 0 {
+		return nil
+	}
+	b := []byte(f.hits[0])
+	f.hits = f.hits[1:]
+	return b
+}
+
+func (f *fakeHits) Err() error {
+	return nil
+}
+
+func (f *fakeHits) Close() error {
+	f.closed = true
+	return nil
+}
+
+func TestReadHits(t *testing.T) {
+	hits := &fakeHits{hits: []string{
+		`{"index":"hotels-index_1","id":"hotel_1","score":1.5,"fields":{"name":"Le Grand","stars":12345678901234567890}}`,
+		`{"index":"hotels-index_1","id":"hotel_2","score":0.5}`,
+	}}
+	got, err := couchbasesearch.ReadHits(hits)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := []any{
+		map[string]any{"id": "hotel_1", "score": 1.5, "fields": map[string]any{"name": "Le Grand", "stars": json.Number("12345678901234567890")}},
+		map[string]any{"id": "hotel_2", "score": 0.5},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect hits: diff %v", diff)
+	}
+	if !hits.closed {
+		t.Fatalf("search result was not closed")
+	}
+}
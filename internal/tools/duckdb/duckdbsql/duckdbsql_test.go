@@ -0,0 +1,119 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package duckdbsql_test
+
+import (
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/duckdb/duckdbsql"
+)
+
+func TestParseFromYamlDuckDB(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: duckdb-sql
+					source: my-duckdb
+					description: some description
+					statement: |
+						SELECT * FROM read_parquet('events/*.parquet') WHERE list_contains(:countries, country)
+					authRequired:
+						- my-google-auth-service
+					parameters:
+						- name: countries
+						  type: array
+						  description: some description
+						  items:
+								name: country
+								type: string
+								description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": duckdbsql.Config{
+					Name:         "example_tool",
+					Kind:         "duckdb-sql",
+					Source:       "my-duckdb",
+					Description:  "some description",
+					Statement:    "SELECT * FROM read_parquet('events/*.parquet') WHERE list_contains(:countries, country)\n",
+					AuthRequired: []string{"my-google-auth-service"},
+					Parameters: []tools.Parameter{
+						tools.NewArrayParameter("countries", "some description",
+							tools.NewStringParameter("country", "some description")),
+					},
+				},
+			},
+		},
+		{
+			desc: "with template parameters",
+			in: `
+			tools:
+				example_tool:
+					kind: duckdb-sql
+					source: my-duckdb
+					description: some description
+					statement: |
+						SELECT * FROM {{ident .tableName}}
+					templateParameters:
+						- name: tableName
+						  type: string
+						  description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": duckdbsql.Config{
+					Name:         "example_tool",
+					Kind:         "duckdb-sql",
+					Source:       "my-duckdb",
+					Description:  "some description",
+					Statement:    "SELECT * FROM {{ident .tableName}}\n",
+					AuthRequired: []string{},
+					TemplateParameters: []tools.Parameter{
+						tools.NewStringParameter("tableName", "some description"),
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
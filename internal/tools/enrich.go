@@ -0,0 +1,115 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/googleapis/genai-toolbox/internal/sources"
+)
+
+// sqlIdentifier matches a plain or quoted SQL identifier.
+const sqlIdentifier = "(?:[A-Za-z_][\\w$]*|\"[^\"]+\"|`[^`]+`|\\[[^\\]]+\\])"
+
+// identifierQuotes are the characters quoting SQL identifiers.
+const identifierQuotes = "\"`[]"
+
+var (
+	sqlCommentRegex = regexp.MustCompile(`(?s)--[^\n]*|/\*.*?\*/`)
+	tableRefRegex   = regexp.MustCompile(`(?i)\b(?:FROM|JOIN|UPDATE|INTO)\s+(` + sqlIdentifier + `(?:\s*\.\s*` + sqlIdentifier + `)*)`)
+	identifierRegex = regexp.MustCompile(sqlIdentifier)
+)
+
+// ReferencedTables returns the names of the tables a SQL statement reads from
+// or writes to, in order of first appearance. Quotes are removed from the
+// names, and schema qualified names are joined with a dot.
+//
+// The statement is not parsed, so names of CTEs or table functions may be
+// returned as well.
+func ReferencedTables(statement string) []string {
+	statement = sqlCommentRegex.ReplaceAllString(statement, " ")
+	var tables []string
+	seen := make(map[string]bool)
+	for _, m := range tableRefRegex.FindAllStringSubmatch(statement, -1) {
+		parts := identifierRegex.FindAllString(m[1], -1)
+		for i, p := range parts {
+			parts[i] = strings.Trim(p, identifierQuotes)
+		}
+		name := strings.Join(parts, ".")
+		if !seen[name] {
+			seen[name] = true
+			tables = append(tables, name)
+		}
+	}
+	return tables
+}
+
+// EnrichDescription returns t with the columns of the tables referenced by
+// the statement of cfg appended to its description, so that agents get the
+// schema without a separate lookup. Tables unknown to the source are skipped.
+func EnrichDescription(ctx context.Context, cfg ToolConfig, t Tool, srcs map[string]sources.Source) (Tool, error) {
+	sc, ok := cfg.(StatementToolConfig)
+	if !ok {
+		return nil, fmt.Errorf("enrichDescription is not supported by tool kind %q", cfg.ToolConfigKind())
+	}
+	d, ok := srcs[sc.ToolSource()].(sources.TableDescriber)
+	if !ok {
+		return nil, fmt.Errorf("enrichDescription is not supported by source %q", sc.ToolSource())
+	}
+
+	var lines []string
+	for _, table := range ReferencedTables(sc.ToolStatement()) {
+		cols, err := d.DescribeTable(ctx, table)
+		if err != nil {
+			return nil, fmt.Errorf("unable to describe table %q: %w", table, err)
+		}
+		if len(cols) == 0 {
+			continue
+		}
+		colDescs := make([]string, 0, len(cols))
+		for _, c := range cols {
+			colDescs = append(colDescs, fmt.Sprintf("%s (%s)", c.Name, c.Type))
+		}
+		lines = append(lines, fmt.Sprintf("- %s: %s", table, strings.Join(colDescs, ", ")))
+	}
+	if len(lines) == 0 {
+		return t, nil
+	}
+
+	suffix := "\n\nTables referenced by this tool:\n" + strings.Join(lines, "\n")
+	return toolWithDescriptionSuffix{Tool: t, suffix: suffix}, nil
+}
+
+type toolWithDescriptionSuffix struct {
+	Tool
+	suffix string
+}
+
+func (t toolWithDescriptionSuffix) Manifest() Manifest {
+	m := t.Tool.Manifest()
+	m.Description += t.suffix
+	m.Descriptions = appendToDescriptions(m.Descriptions, t.suffix)
+	return m
+}
+
+func (t toolWithDescriptionSuffix) McpManifest() McpManifest {
+	m := t.Tool.McpManifest()
+	m.Description += t.suffix
+	m.Descriptions = appendToDescriptions(m.Descriptions, t.suffix)
+	return m
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/sqlite"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/sqlitesql"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestReferencedTables(t *testing.T) {
+	tcs := []struct {
+		desc      string
+		statement string
+		want      []string
+	}{
+		{
+			desc:      "select",
+			statement: "SELECT * FROM flights WHERE id = $1",
+			want:      []string{"flights"},
+		},
+		{
+			desc:      "joins and schemas",
+			statement: "SELECT * FROM public.flights f JOIN airports a ON f.origin = a.code LEFT JOIN public.flights g ON true",
+			want:      []string{"public.flights", "airports"},
+		},
+		{
+			desc:      "quoted identifiers",
+			statement: "SELECT * FROM \"My Schema\".\"Flights\" JOIN `airports` JOIN [dbo].[gates]",
+			want:      []string{"My Schema.Flights", "airports", "dbo.gates"},
+		},
+		{
+			desc:      "writes",
+			statement: "INSERT INTO tickets (id) VALUES (?); UPDATE flights SET seats = 1; DELETE FROM bookings",
+			want:      []string{"tickets", "flights", "bookings"},
+		},
+		{
+			desc:      "comments and subqueries",
+			statement: "-- FROM ignored\nSELECT * FROM (SELECT id FROM /* JOIN nope */ flights) t",
+			want:      []string{"flights"},
+		},
+		{
+			desc:      "no tables",
+			statement: "SELECT 1",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := tools.ReferencedTables(tc.statement)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect tables: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestEnrichDescription(t *testing.T) {
+	ctx := context.Background()
+	src, err := sqlite.Config{Name: "my-sqlite", Kind: sqlite.SourceKind, Database: ":memory:"}.Initialize(ctx, noop.NewTracerProvider().Tracer("test"))
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	db := src.(*sqlite.Source).SQLiteDB()
+	if _, err := db.Exec("CREATE TABLE flights (id INTEGER, airline TEXT)"); err != nil {
+		t.Fatalf("unable to create table: %s", err)
+	}
+	srcs := map[string]sources.Source{"my-sqlite": src}
+
+	cfg := sqlitesql.Config{
+		Name:        "search_flights",
+		Kind:        "sqlite-sql",
+		Source:      "my-sqlite",
+		Description: "Search flights.",
+		Statement:   "SELECT * FROM flights JOIN missing ON true",
+	}
+	tool, err := cfg.Initialize(srcs)
+	if err != nil {
+		t.Fatalf("unable to initialize tool: %s", err)
+	}
+	got, err := tools.EnrichDescription(ctx, cfg, tool, srcs)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := "Search flights.\n\nTables referenced by this tool:\n- flights: id (INTEGER), airline (TEXT)"
+	if got.Manifest().Description != want {
+		t.Fatalf("incorrect manifest description: got %q, want %q", got.Manifest().Description, want)
+	}
+	if got.McpManifest().Description != want {
+		t.Fatalf("incorrect mcp manifest description: got %q, want %q", got.McpManifest().Description, want)
+	}
+
+	// tools without a statement can't be enriched
+	if _, err := tools.EnrichDescription(ctx, mockToolConfig{}, tool, srcs); err == nil {
+		t.Fatalf("expected an error for a tool without a statement")
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fsglob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	fssrc "github.com/googleapis/genai-toolbox/internal/sources/filesystem"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+const kind string = "fs-glob"
+const patternKey string = "pattern"
+
+// defaultMaxResults is the largest number of paths returned when maxResults
+// is not configured.
+const defaultMaxResults = 1000
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	FilesystemRoot() *os.Root
+}
+
+// validate compatible sources are still compatible
+var _ compatibleSource = &fssrc.Source{}
+
+var compatibleSources = [...]string{fssrc.SourceKind}
+
+type Config struct {
+	Name         string   `yaml:"name" validate:"required"`
+	Kind         string   `yaml:"kind" validate:"required"`
+	Source       string   `yaml:"source" validate:"required"`
+	Description  string   `yaml:"description" validate:"required"`
+	AuthRequired []string `yaml:"authRequired"`
+	MaxResults   int      `yaml:"maxResults"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(compatibleSource)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
+	}
+
+	maxResults := cfg.MaxResults
+	if maxResults <= 0 {
+		maxResults = defaultMaxResults
+	}
+
+	patternParameter := tools.NewStringParameter(patternKey, "The glob pattern to match, relative to the root directory. Supports '*', '?', character classes, '{a,b}' alternatives, and '**' to match any number of directories (e.g. 'logs/**/*.json').")
+	parameters := tools.Parameters{patternParameter}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   parameters,
+		AuthRequired: cfg.AuthRequired,
+		MaxResults:   maxResults,
+		Root:         s.FilesystemRoot(),
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:  mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+// errEnoughMatches stops the walk once more paths than returned are found.
+var errEnoughMatches = errors.New("enough matches")
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+	MaxResults   int              `yaml:"maxResults"`
+
+	Root        *os.Root
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	mapParams := params.AsMap()
+	pattern, ok := mapParams[patternKey].(string)
+	if !ok || pattern == "" {
+		return nil, fmt.Errorf("invalid or missing '%s' parameter; expected a non-empty string", patternKey)
+	}
+	pattern = strings.TrimPrefix(pattern, "/")
+	if !doublestar.ValidatePattern(pattern) {
+		return nil, fmt.Errorf("invalid glob pattern %q", pattern)
+	}
+	for _, segment := range strings.Split(pattern, "/") {
+		if segment == ".." {
+			return nil, fmt.Errorf("pattern %q is outside of the root directory", pattern)
+		}
+	}
+
+	// the walk stops once a path past maxResults is found, or the invocation
+	// is cancelled, so that patterns such as ** over a large tree are bounded
+	matches := make([]string, 0)
+	err := doublestar.GlobWalk(t.Root.FS(), pattern, func(p string, d os.DirEntry) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		matches = append(matches, p)
+		if len(matches) > t.MaxResults {
+			return errEnoughMatches
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errEnoughMatches) {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		return nil, fmt.Errorf("unable to match pattern %q: %w", pattern, err)
+	}
+
+	// matches are sorted so truncated results are stable
+	sort.Strings(matches)
+	truncated := len(matches) > t.MaxResults
+	if truncated {
+		tools.AddWarning(ctx, "pattern %q matched more than %d paths, only %d are listed", pattern, t.MaxResults, t.MaxResults)
+		tools.SetTruncated(ctx)
+		matches = matches[:t.MaxResults]
+	}
+	paths := make([]any, 0, len(matches))
+	for _, m := range matches {
+		paths = append(paths, m)
+	}
+
+	return map[string]any{
+		"paths":     paths,
+		"truncated": truncated,
+	}, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
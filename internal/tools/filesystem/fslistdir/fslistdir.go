@@ -0,0 +1,198 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fslistdir
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	fssrc "github.com/googleapis/genai-toolbox/internal/sources/filesystem"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+const kind string = "fs-list-dir"
+const pathKey string = "path"
+
+// defaultMaxResults is the largest number of entries returned when
+// maxResults is not configured.
+const defaultMaxResults = 1000
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	FilesystemRoot() *os.Root
+}
+
+// validate compatible sources are still compatible
+var _ compatibleSource = &fssrc.Source{}
+
+var compatibleSources = [...]string{fssrc.SourceKind}
+
+type Config struct {
+	Name         string   `yaml:"name" validate:"required"`
+	Kind         string   `yaml:"kind" validate:"required"`
+	Source       string   `yaml:"source" validate:"required"`
+	Description  string   `yaml:"description" validate:"required"`
+	AuthRequired []string `yaml:"authRequired"`
+	MaxResults   int      `yaml:"maxResults"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(compatibleSource)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
+	}
+
+	maxResults := cfg.MaxResults
+	if maxResults <= 0 {
+		maxResults = defaultMaxResults
+	}
+
+	pathParameter := tools.NewStringParameterWithDefault(pathKey, ".", "The path of the directory to list, relative to the root directory.")
+	parameters := tools.Parameters{pathParameter}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   parameters,
+		AuthRequired: cfg.AuthRequired,
+		MaxResults:   maxResults,
+		Root:         s.FilesystemRoot(),
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:  mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+	MaxResults   int              `yaml:"maxResults"`
+
+	Root        *os.Root
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	mapParams := params.AsMap()
+	rawPath, _ := mapParams[pathKey].(string)
+	name, err := fssrc.CleanPath(rawPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// entries are sorted by name so truncated listings are stable
+	dirEntries, err := fs.ReadDir(t.Root.FS(), name)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list directory %q: %w", name, err)
+	}
+	truncated := len(dirEntries) > t.MaxResults
+	if truncated {
+		tools.AddWarning(ctx, "directory %q has %d entries, only the first %d are listed", name, len(dirEntries), t.MaxResults)
+		tools.SetTruncated(ctx)
+		dirEntries = dirEntries[:t.MaxResults]
+	}
+
+	entries := make([]any, 0, len(dirEntries))
+	for _, e := range dirEntries {
+		entry := map[string]any{
+			"name": e.Name(),
+			"path": path.Join(name, e.Name()),
+			"type": entryType(e.Type()),
+		}
+		if info, err := e.Info(); err == nil {
+			entry["size"] = info.Size()
+			entry["lastModified"] = info.ModTime().UTC()
+		}
+		entries = append(entries, entry)
+	}
+
+	return map[string]any{
+		"entries":   entries,
+		"truncated": truncated,
+	}, nil
+}
+
+func entryType(mode fs.FileMode) string {
+	switch {
+	case mode.IsDir():
+		return "directory"
+	case mode&fs.ModeSymlink != 0:
+		return "symlink"
+	case mode.IsRegular():
+		return "file"
+	}
+	return "other"
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
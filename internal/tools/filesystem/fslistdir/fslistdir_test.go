@@ -0,0 +1,178 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fslistdir_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/filesystem/filesystemtest"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/filesystem/fslistdir"
+)
+
+func TestParseFromYamlFsListDir(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: fs-list-dir
+					source: my-files
+					description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": fslistdir.Config{
+					Name:         "example_tool",
+					Kind:         "fs-list-dir",
+					Source:       "my-files",
+					Description:  "some description",
+					AuthRequired: []string{},
+				},
+			},
+		},
+		{
+			desc: "with limit",
+			in: `
+			tools:
+				example_tool:
+					kind: fs-list-dir
+					source: my-files
+					description: some description
+					maxResults: 10
+			`,
+			want: server.ToolConfigs{
+				"example_tool": fslistdir.Config{
+					Name:         "example_tool",
+					Kind:         "fs-list-dir",
+					Source:       "my-files",
+					Description:  "some description",
+					AuthRequired: []string{},
+					MaxResults:   10,
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+// newTestTool creates a tool on a filesystemtest source.
+func newTestTool(t *testing.T, cfg fslistdir.Config) tools.Tool {
+	t.Helper()
+	cfg.Name = "example_tool"
+	cfg.Kind = "fs-list-dir"
+	cfg.Source = filesystemtest.SourceName
+	cfg.Description = "some description"
+	tool, err := cfg.Initialize(map[string]sources.Source{filesystemtest.SourceName: filesystemtest.NewSource(t)})
+	if err != nil {
+		t.Fatalf("unable to initialize tool: %s", err)
+	}
+	return tool
+}
+
+func TestInvoke(t *testing.T) {
+	tcs := []struct {
+		desc          string
+		maxResults    int
+		path          string
+		wantNames     []string
+		wantTruncated bool
+		err           string
+	}{
+		{
+			desc:      "root directory",
+			path:      ".",
+			wantNames: []string{"docs:directory", "escape.txt:symlink", "readme.md:file"},
+		},
+		{
+			desc:      "subdirectory",
+			path:      "/docs/api",
+			wantNames: []string{"index.md:file", "logo.png:file"},
+		},
+		{
+			desc:          "truncated",
+			maxResults:    1,
+			path:          "docs/api",
+			wantNames:     []string{"index.md:file"},
+			wantTruncated: true,
+		},
+		{
+			desc: "path traversal",
+			path: "docs/../..",
+			err:  `path "docs/../.." is outside of the root directory`,
+		},
+		{
+			desc: "missing directory",
+			path: "nope",
+			err:  `unable to list directory "nope"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			tool := newTestTool(t, fslistdir.Config{MaxResults: tc.maxResults})
+			got, err := tool.Invoke(context.Background(), tools.ParamValues{{Name: "path", Value: tc.path}})
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			gotMap := got.(map[string]any)
+			gotNames := []string{}
+			for _, e := range gotMap["entries"].([]any) {
+				entry := e.(map[string]any)
+				gotNames = append(gotNames, entry["name"].(string)+":"+entry["type"].(string))
+			}
+			if diff := cmp.Diff(tc.wantNames, gotNames); diff != "" {
+				t.Fatalf("incorrect entries: diff %v", diff)
+			}
+			if gotMap["truncated"] != tc.wantTruncated {
+				t.Fatalf("incorrect truncated: got %v, want %v", gotMap["truncated"], tc.wantTruncated)
+			}
+		})
+	}
+}
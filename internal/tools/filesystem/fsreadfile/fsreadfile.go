@@ -0,0 +1,198 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fsreadfile
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"unicode/utf8"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	fssrc "github.com/googleapis/genai-toolbox/internal/sources/filesystem"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+const kind string = "fs-read-file"
+const pathKey string = "path"
+
+// defaultMaxBytes is the largest file returned when maxBytes is not configured.
+const defaultMaxBytes int64 = 1 << 20
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	FilesystemRoot() *os.Root
+}
+
+// validate compatible sources are still compatible
+var _ compatibleSource = &fssrc.Source{}
+
+var compatibleSources = [...]string{fssrc.SourceKind}
+
+type Config struct {
+	Name         string   `yaml:"name" validate:"required"`
+	Kind         string   `yaml:"kind" validate:"required"`
+	Source       string   `yaml:"source" validate:"required"`
+	Description  string   `yaml:"description" validate:"required"`
+	AuthRequired []string `yaml:"authRequired"`
+	MaxBytes     int64    `yaml:"maxBytes"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(compatibleSource)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
+	}
+
+	maxBytes := cfg.MaxBytes
+	if maxBytes <= 0 {
+		maxBytes = defaultMaxBytes
+	}
+
+	pathParameter := tools.NewStringParameter(pathKey, "The path of the file to read, relative to the root directory.")
+	parameters := tools.Parameters{pathParameter}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   parameters,
+		AuthRequired: cfg.AuthRequired,
+		MaxBytes:     maxBytes,
+		Root:         s.FilesystemRoot(),
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:  mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+	MaxBytes     int64            `yaml:"maxBytes"`
+
+	Root        *os.Root
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	mapParams := params.AsMap()
+	rawPath, ok := mapParams[pathKey].(string)
+	if !ok || rawPath == "" {
+		return nil, fmt.Errorf("invalid or missing '%s' parameter; expected a non-empty string", pathKey)
+	}
+	name, err := fssrc.CleanPath(rawPath)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := t.Root.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open file %q: %w", name, err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("unable to stat file %q: %w", name, err)
+	}
+	if info.IsDir() {
+		return nil, fmt.Errorf("%q is a directory", name)
+	}
+	if info.Size() > t.MaxBytes {
+		return nil, fmt.Errorf("file %q is %d bytes, which exceeds the limit of %d bytes", name, info.Size(), t.MaxBytes)
+	}
+
+	// read one byte past the limit to detect files growing while being read
+	body, err := io.ReadAll(io.LimitReader(f, t.MaxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read file %q: %w", name, err)
+	}
+	if int64(len(body)) > t.MaxBytes {
+		return nil, fmt.Errorf("file %q exceeds the limit of %d bytes", name, t.MaxBytes)
+	}
+
+	result := map[string]any{
+		"path":         name,
+		"size":         len(body),
+		"lastModified": info.ModTime().UTC(),
+	}
+	// binary files are returned base64 encoded since they can't be represented as JSON strings
+	if utf8.Valid(body) {
+		result["content"] = string(body)
+	} else {
+		result["content"] = base64.StdEncoding.EncodeToString(body)
+		result["encoding"] = "base64"
+	}
+	return result, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
@@ -0,0 +1,179 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fsreadfile_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/filesystem/filesystemtest"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/filesystem/fsreadfile"
+)
+
+func TestParseFromYamlFsReadFile(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: fs-read-file
+					source: my-files
+					description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": fsreadfile.Config{
+					Name:         "example_tool",
+					Kind:         "fs-read-file",
+					Source:       "my-files",
+					Description:  "some description",
+					AuthRequired: []string{},
+				},
+			},
+		},
+		{
+			desc: "with limit",
+			in: `
+			tools:
+				example_tool:
+					kind: fs-read-file
+					source: my-files
+					description: some description
+					maxBytes: 1024
+			`,
+			want: server.ToolConfigs{
+				"example_tool": fsreadfile.Config{
+					Name:         "example_tool",
+					Kind:         "fs-read-file",
+					Source:       "my-files",
+					Description:  "some description",
+					AuthRequired: []string{},
+					MaxBytes:     1024,
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+// newTestTool creates a tool on a filesystemtest source.
+func newTestTool(t *testing.T, cfg fsreadfile.Config) tools.Tool {
+	t.Helper()
+	cfg.Name = "example_tool"
+	cfg.Kind = "fs-read-file"
+	cfg.Source = filesystemtest.SourceName
+	cfg.Description = "some description"
+	tool, err := cfg.Initialize(map[string]sources.Source{filesystemtest.SourceName: filesystemtest.NewSource(t)})
+	if err != nil {
+		t.Fatalf("unable to initialize tool: %s", err)
+	}
+	return tool
+}
+
+func TestInvoke(t *testing.T) {
+	tcs := []struct {
+		desc     string
+		maxBytes int64
+		path     string
+		want     map[string]any
+		err      string
+	}{
+		{
+			desc: "text file",
+			path: "docs/guide.md",
+			want: map[string]any{"path": "docs/guide.md", "size": 5, "content": "guide"},
+		},
+		{
+			desc: "absolute path is relative to the root",
+			path: "/readme.md",
+			want: map[string]any{"path": "readme.md", "size": 7, "content": "# hello"},
+		},
+		{
+			desc: "binary file",
+			path: "docs/api/logo.png",
+			want: map[string]any{"path": "docs/api/logo.png", "size": 6, "content": "iVBORwD/", "encoding": "base64"},
+		},
+		{
+			desc:     "file too large",
+			maxBytes: 3,
+			path:     "readme.md",
+			err:      `file "readme.md" is 7 bytes, which exceeds the limit of 3 bytes`,
+		},
+		{
+			desc: "directory",
+			path: "docs",
+			err:  `"docs" is a directory`,
+		},
+		{
+			desc: "path traversal",
+			path: "../secret.txt",
+			err:  `path "../secret.txt" is outside of the root directory`,
+		},
+		{
+			desc: "symlink escaping the root",
+			path: "escape.txt",
+			err:  `unable to open file "escape.txt"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			tool := newTestTool(t, fsreadfile.Config{MaxBytes: tc.maxBytes})
+			got, err := tool.Invoke(context.Background(), tools.ParamValues{{Name: "path", Value: tc.path}})
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			gotMap := got.(map[string]any)
+			delete(gotMap, "lastModified")
+			if diff := cmp.Diff(tc.want, gotMap); diff != "" {
+				t.Fatalf("incorrect result: diff %v", diff)
+			}
+		})
+	}
+}
@@ -17,17 +17,27 @@ package firestoregetdocuments
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	firestoreapi "cloud.google.com/go/firestore"
 	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	firestoreds "github.com/googleapis/genai-toolbox/internal/sources/firestore"
 	"github.com/googleapis/genai-toolbox/internal/tools"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const kind string = "firestore-get-documents"
 const documentPathsKey string = "documentPaths"
 
+// defaultMaxPaths and defaultConcurrency are used when maxPaths and
+// concurrency are not configured.
+const (
+	defaultMaxPaths    = 100
+	defaultConcurrency = 10
+)
+
 func init() {
 	if !tools.Register(kind, newConfig) {
 		panic(fmt.Sprintf("tool kind %q already registered", kind))
@@ -44,6 +54,7 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.T
 
 type compatibleSource interface {
 	FirestoreClient() *firestoreapi.Client
+	FirestoreSerialization() firestoreds.Serialization
 }
 
 // validate compatible sources are still compatible
@@ -57,6 +68,8 @@ type Config struct {
 	Source       string   `yaml:"source" validate:"required"`
 	Description  string   `yaml:"description" validate:"required"`
 	AuthRequired []string `yaml:"authRequired"`
+	MaxPaths     int      `yaml:"maxPaths" validate:"gte=0"`
+	Concurrency  int      `yaml:"concurrency" validate:"gte=0"`
 }
 
 // validate interface
@@ -79,6 +92,15 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
 	}
 
+	maxPaths := cfg.MaxPaths
+	if maxPaths == 0 {
+		maxPaths = defaultMaxPaths
+	}
+	concurrency := cfg.Concurrency
+	if concurrency == 0 {
+		concurrency = defaultConcurrency
+	}
+
 	documentPathsParameter := tools.NewArrayParameter(documentPathsKey, "Array of document paths to retrieve from Firestore.", tools.NewStringParameter("item", "Document path"))
 	parameters := tools.Parameters{documentPathsParameter}
 
@@ -90,13 +112,16 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 
 	// finish tool setup
 	t := Tool{
-		Name:         cfg.Name,
-		Kind:         kind,
-		Parameters:   parameters,
-		AuthRequired: cfg.AuthRequired,
-		Client:       s.FirestoreClient(),
-		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
-		mcpManifest:  mcpManifest,
+		Name:          cfg.Name,
+		Kind:          kind,
+		Parameters:    parameters,
+		AuthRequired:  cfg.AuthRequired,
+		MaxPaths:      maxPaths,
+		Concurrency:   concurrency,
+		Client:        s.FirestoreClient(),
+		Serialization: s.FirestoreSerialization(),
+		manifest:      tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:   mcpManifest,
 	}
 	return t, nil
 }
@@ -109,10 +134,13 @@ type Tool struct {
 	Kind         string           `yaml:"kind"`
 	AuthRequired []string         `yaml:"authRequired"`
 	Parameters   tools.Parameters `yaml:"parameters"`
+	MaxPaths     int              `yaml:"maxPaths"`
+	Concurrency  int              `yaml:"concurrency"`
 
-	Client      *firestoreapi.Client
-	manifest    tools.Manifest
-	mcpManifest tools.McpManifest
+	Client        *firestoreapi.Client
+	Serialization firestoreds.Serialization
+	manifest      tools.Manifest
+	mcpManifest   tools.McpManifest
 }
 
 func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
@@ -137,36 +165,57 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 		return nil, fmt.Errorf("unexpected type conversion error for document paths")
 	}
 
-	// Create document references from paths
-	docRefs := make([]*firestoreapi.DocumentRef, len(documentPaths))
-	for i, path := range documentPaths {
-		docRefs[i] = t.Client.Doc(path)
+	if len(documentPaths) > t.MaxPaths {
+		return nil, fmt.Errorf("too many document paths: %d (maximum: %d)", len(documentPaths), t.MaxPaths)
 	}
 
-	// Get all documents
-	snapshots, err := t.Client.GetAll(ctx, docRefs)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get documents: %w", err)
+	// fetch the documents with a bounded number of workers, each document
+	// reporting its own status so that one failure doesn't fail the batch
+	results := make([]any, len(documentPaths))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(t.Concurrency, len(documentPaths)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = t.getDocument(ctx, documentPaths[i])
+			}
+		}()
 	}
+	for i := range documentPaths {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
 
-	// Convert snapshots to response data
-	results := make([]any, len(snapshots))
-	for i, snapshot := range snapshots {
-		docData := make(map[string]any)
-		docData["path"] = documentPaths[i]
-		docData["exists"] = snapshot.Exists()
+	return results, nil
+}
 
-		if snapshot.Exists() {
-			docData["data"] = snapshot.Data()
-			docData["createTime"] = snapshot.CreateTime
-			docData["updateTime"] = snapshot.UpdateTime
-			docData["readTime"] = snapshot.ReadTime
-		}
+// getDocument returns the document at path with its metadata, or the error
+// getting it.
+func (t Tool) getDocument(ctx context.Context, path string) map[string]any {
+	docData := map[string]any{"path": path}
 
-		results[i] = docData
+	docRef := t.Client.Doc(path)
+	if docRef == nil {
+		docData["error"] = fmt.Sprintf("invalid document path %q", path)
+		return docData
+	}
+	snapshot, err := docRef.Get(ctx)
+	if err != nil && status.Code(err) != codes.NotFound {
+		docData["error"] = err.Error()
+		return docData
 	}
 
-	return results, nil
+	docData["exists"] = snapshot.Exists()
+	if snapshot.Exists() {
+		docData["data"] = t.Serialization.Document(snapshot.Data())
+		docData["createTime"] = t.Serialization.Timestamp(snapshot.CreateTime)
+		docData["updateTime"] = t.Serialization.Timestamp(snapshot.UpdateTime)
+		docData["readTime"] = t.Serialization.Timestamp(snapshot.ReadTime)
+	}
+	return docData
 }
 
 func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
@@ -75,6 +75,29 @@ func TestParseFromYamlFirestoreGetDocuments(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "with limits",
+			in: `
+			tools:
+				batch_get_docs:
+					kind: firestore-get-documents
+					source: my-firestore-instance
+					description: Get documents in batches
+					maxPaths: 500
+					concurrency: 25
+			`,
+			want: server.ToolConfigs{
+				"batch_get_docs": firestoregetdocuments.Config{
+					Name:         "batch_get_docs",
+					Kind:         "firestore-get-documents",
+					Source:       "my-firestore-instance",
+					Description:  "Get documents in batches",
+					AuthRequired: []string{},
+					MaxPaths:     500,
+					Concurrency:  25,
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
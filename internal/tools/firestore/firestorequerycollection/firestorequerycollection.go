@@ -88,6 +88,7 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.T
 // compatibleSource defines the interface for sources that can provide a Firestore client
 type compatibleSource interface {
 	FirestoreClient() *firestoreapi.Client
+	FirestoreSerialization() firestoreds.Serialization
 }
 
 // validate compatible sources are still compatible
@@ -137,13 +138,14 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 
 	// finish tool setup
 	t := Tool{
-		Name:         cfg.Name,
-		Kind:         kind,
-		Parameters:   parameters,
-		AuthRequired: cfg.AuthRequired,
-		Client:       s.FirestoreClient(),
-		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
-		mcpManifest:  mcpManifest,
+		Name:          cfg.Name,
+		Kind:          kind,
+		Parameters:    parameters,
+		AuthRequired:  cfg.AuthRequired,
+		Client:        s.FirestoreClient(),
+		Serialization: s.FirestoreSerialization(),
+		manifest:      tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:   mcpManifest,
 	}
 	return t, nil
 }
@@ -203,9 +205,10 @@ type Tool struct {
 	AuthRequired []string         `yaml:"authRequired"`
 	Parameters   tools.Parameters `yaml:"parameters"`
 
-	Client      *firestoreapi.Client
-	manifest    tools.Manifest
-	mcpManifest tools.McpManifest
+	Client        *firestoreapi.Client
+	Serialization firestoreds.Serialization
+	manifest      tools.Manifest
+	mcpManifest   tools.McpManifest
 }
 
 // FilterConfig represents a filter for the query
@@ -436,6 +439,11 @@ func (t Tool) executeQuery(ctx context.Context, query *firestoreapi.Query, analy
 	docIterator := query.Documents(ctx)
 	docs, err := docIterator.GetAll()
 	if err != nil {
+		// queries requiring a missing index return how to create it, so the
+		// agent or operator can act on it
+		if resp, ok := ParseMissingIndexError(err); ok {
+			return resp, nil
+		}
 		return nil, fmt.Errorf(errQueryExecutionFailed, err)
 	}
 
@@ -445,10 +453,10 @@ func (t Tool) executeQuery(ctx context.Context, query *firestoreapi.Query, analy
 		results[i] = QueryResult{
 			ID:         doc.Ref.ID,
 			Path:       doc.Ref.Path,
-			Data:       doc.Data(),
-			CreateTime: doc.CreateTime,
-			UpdateTime: doc.UpdateTime,
-			ReadTime:   doc.ReadTime,
+			Data:       t.Serialization.Document(doc.Data()),
+			CreateTime: t.Serialization.Timestamp(doc.CreateTime),
+			UpdateTime: t.Serialization.Timestamp(doc.UpdateTime),
+			ReadTime:   t.Serialization.Timestamp(doc.ReadTime),
 		}
 	}
 
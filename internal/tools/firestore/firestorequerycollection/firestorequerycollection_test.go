@@ -15,13 +15,19 @@
 package firestorequerycollection_test
 
 import (
+	"encoding/base64"
+	"errors"
 	"testing"
 
+	"cloud.google.com/go/firestore/apiv1/admin/adminpb"
 	yaml "github.com/goccy/go-yaml"
 	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/genai-toolbox/internal/server"
 	"github.com/googleapis/genai-toolbox/internal/testutils"
 	"github.com/googleapis/genai-toolbox/internal/tools/firestore/firestorequerycollection"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
 func TestParseFromYamlFirestoreQueryCollection(t *testing.T) {
@@ -154,3 +160,48 @@ func TestParseFromYamlMultipleTools(t *testing.T) {
 		t.Fatalf("incorrect parse: diff %v", diff)
 	}
 }
+
+func TestParseMissingIndexError(t *testing.T) {
+	index, err := proto.Marshal(&adminpb.Index{
+		Name:       "projects/my-project/databases/(default)/collectionGroups/cities/indexes/_",
+		QueryScope: adminpb.Index_COLLECTION,
+		Fields: []*adminpb.Index_IndexField{
+			{FieldPath: "country", ValueMode: &adminpb.Index_IndexField_Order_{Order: adminpb.Index_IndexField_ASCENDING}},
+			{FieldPath: "tags", ValueMode: &adminpb.Index_IndexField_ArrayConfig_{ArrayConfig: adminpb.Index_IndexField_CONTAINS}},
+			{FieldPath: "__name__", ValueMode: &adminpb.Index_IndexField_Order_{Order: adminpb.Index_IndexField_DESCENDING}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unable to marshal index: %s", err)
+	}
+	link := "https://console.firebase.google.com/v1/r/project/my-project/firestore/indexes?create_composite=" + base64.RawURLEncoding.EncodeToString(index)
+
+	got, ok := firestorequerycollection.ParseMissingIndexError(status.Error(codes.FailedPrecondition, "The query requires an index. You can create it here: "+link))
+	if !ok {
+		t.Fatalf("expected a missing index error")
+	}
+	want := &firestorequerycollection.MissingIndexResponse{
+		Error:           got.Error,
+		IndexURL:        link,
+		CollectionGroup: "cities",
+		QueryScope:      "COLLECTION",
+		Fields: []firestorequerycollection.IndexField{
+			{FieldPath: "country", Order: "ASCENDING"},
+			{FieldPath: "tags", ArrayConfig: "CONTAINS"},
+			{FieldPath: "__name__", Order: "DESCENDING"},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect response: diff %v", diff)
+	}
+
+	for _, err := range []error{
+		errors.New("boom"),
+		status.Error(codes.FailedPrecondition, "the database is being created"),
+		status.Error(codes.InvalidArgument, "The query requires an index. You can create it here: "+link),
+	} {
+		if _, ok := firestorequerycollection.ParseMissingIndexError(err); ok {
+			t.Fatalf("unexpected missing index error for %q", err)
+		}
+	}
+}
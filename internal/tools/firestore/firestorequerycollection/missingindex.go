@@ -0,0 +1,112 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package firestorequerycollection
+
+import (
+	"encoding/base64"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	"cloud.google.com/go/firestore/apiv1/admin/adminpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// indexURLRegex matches the link to create a missing index in the error of a
+// query requiring one.
+var indexURLRegex = regexp.MustCompile(`https://\S+create_composite=\S+`)
+
+// MissingIndexResponse is returned instead of an error when a query fails
+// because it requires a composite index that doesn't exist.
+type MissingIndexResponse struct {
+	Error string `json:"error"`
+	// IndexURL is the link to create the index in the Firebase console.
+	IndexURL        string       `json:"indexUrl"`
+	CollectionGroup string       `json:"collectionGroup,omitempty"`
+	QueryScope      string       `json:"queryScope,omitempty"`
+	Fields          []IndexField `json:"fields,omitempty"`
+}
+
+// IndexField is a field of a missing index, with either its order or array
+// config set.
+type IndexField struct {
+	FieldPath   string `json:"fieldPath"`
+	Order       string `json:"order,omitempty"`
+	ArrayConfig string `json:"arrayConfig,omitempty"`
+}
+
+// ParseMissingIndexError returns the index a query requires if err is the
+// error of a query failing because the index is missing.
+func ParseMissingIndexError(err error) (*MissingIndexResponse, bool) {
+	if status.Code(err) != codes.FailedPrecondition {
+		return nil, false
+	}
+	msg := status.Convert(err).Message()
+	link := indexURLRegex.FindString(msg)
+	if link == "" {
+		return nil, false
+	}
+	resp := &MissingIndexResponse{
+		Error:    "the query requires a composite index that doesn't exist; create it with indexUrl and retry once it is built",
+		IndexURL: link,
+	}
+
+	// the link describes the index as a base64 encoded Index message
+	u, err := url.Parse(link)
+	if err != nil {
+		return resp, true
+	}
+	index, err := decodeIndex(u.Query().Get("create_composite"))
+	if err != nil {
+		return resp, true
+	}
+	// index names are projects/{p}/databases/{d}/collectionGroups/{c}/indexes/{i}
+	resp.CollectionGroup = path.Base(path.Dir(path.Dir(index.GetName())))
+	if index.GetQueryScope() != adminpb.Index_QUERY_SCOPE_UNSPECIFIED {
+		resp.QueryScope = index.GetQueryScope().String()
+	}
+	for _, f := range index.GetFields() {
+		field := IndexField{FieldPath: f.GetFieldPath()}
+		switch {
+		case f.GetOrder() != adminpb.Index_IndexField_ORDER_UNSPECIFIED:
+			field.Order = f.GetOrder().String()
+		case f.GetArrayConfig() != adminpb.Index_IndexField_ARRAY_CONFIG_UNSPECIFIED:
+			field.ArrayConfig = f.GetArrayConfig().String()
+		}
+		resp.Fields = append(resp.Fields, field)
+	}
+	return resp, true
+}
+
+// decodeIndex decodes the base64 encoded Index message s.
+func decodeIndex(s string) (*adminpb.Index, error) {
+	// the encoding is unescaped, so '+' is parsed from the query as ' '
+	s = strings.ReplaceAll(strings.TrimRight(s, "="), " ", "+")
+	b, err := base64.RawStdEncoding.DecodeString(s)
+	if err != nil {
+		b, err = base64.RawURLEncoding.DecodeString(s)
+		if err != nil {
+			return nil, err
+		}
+	}
+	index := &adminpb.Index{}
+	if err := proto.Unmarshal(b, index); err != nil {
+		return nil, err
+	}
+	return index, nil
+}
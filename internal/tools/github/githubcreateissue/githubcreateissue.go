@@ -0,0 +1,182 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubcreateissue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	githubds "github.com/googleapis/genai-toolbox/internal/sources/github"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+const kind string = "github-create-issue"
+const titleKey string = "title"
+const bodyKey string = "body"
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	GitHubRequest(ctx context.Context, method, path string, query url.Values, body any) ([]byte, error)
+}
+
+// validate compatible sources are still compatible
+var _ compatibleSource = &githubds.Source{}
+
+var compatibleSources = [...]string{githubds.SourceKind}
+
+type Config struct {
+	Name         string   `yaml:"name" validate:"required"`
+	Kind         string   `yaml:"kind" validate:"required"`
+	Source       string   `yaml:"source" validate:"required"`
+	Description  string   `yaml:"description" validate:"required"`
+	AuthRequired []string `yaml:"authRequired"`
+	Repository   string   `yaml:"repository" validate:"required"`
+	Labels       []string `yaml:"labels"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(compatibleSource)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
+	}
+
+	repoPath, err := githubds.RepositoryPath(cfg.Repository)
+	if err != nil {
+		return nil, err
+	}
+
+	titleParameter := tools.NewStringParameter(titleKey, "The title of the issue.")
+	bodyParameter := tools.NewStringParameterWithDefault(bodyKey, "", "The body of the issue in Markdown, such as the queries that were run and their results.")
+	parameters := tools.Parameters{titleParameter, bodyParameter}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   parameters,
+		AuthRequired: cfg.AuthRequired,
+		Repository:   cfg.Repository,
+		Labels:       cfg.Labels,
+		repoPath:     repoPath,
+		Source:       s,
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:  mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+	Repository   string           `yaml:"repository"`
+	Labels       []string         `yaml:"labels"`
+
+	repoPath    string
+	Source      compatibleSource
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	mapParams := params.AsMap()
+	title, ok := mapParams[titleKey].(string)
+	if !ok || title == "" {
+		return nil, fmt.Errorf("invalid or missing '%s' parameter; expected a non-empty string", titleKey)
+	}
+	body, _ := mapParams[bodyKey].(string)
+
+	issue := map[string]any{"title": title}
+	if body != "" {
+		issue["body"] = body
+	}
+	if len(t.Labels) > 0 {
+		issue["labels"] = t.Labels
+	}
+	respBody, err := t.Source.GitHubRequest(ctx, http.MethodPost, t.repoPath+"/issues", nil, issue)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create issue in %q: %w", t.Repository, err)
+	}
+
+	var resp struct {
+		Number  int    `json:"number"`
+		HTMLURL string `json:"html_url"`
+	}
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return map[string]any{
+		"number": resp.Number,
+		"url":    resp.HTMLURL,
+	}, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
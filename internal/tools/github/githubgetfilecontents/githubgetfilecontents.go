@@ -0,0 +1,230 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubgetfilecontents
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	githubds "github.com/googleapis/genai-toolbox/internal/sources/github"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+const kind string = "github-get-file-contents"
+const pathKey string = "path"
+const refKey string = "ref"
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	GitHubRequest(ctx context.Context, method, path string, query url.Values, body any) ([]byte, error)
+}
+
+// validate compatible sources are still compatible
+var _ compatibleSource = &githubds.Source{}
+
+var compatibleSources = [...]string{githubds.SourceKind}
+
+type Config struct {
+	Name         string   `yaml:"name" validate:"required"`
+	Kind         string   `yaml:"kind" validate:"required"`
+	Source       string   `yaml:"source" validate:"required"`
+	Description  string   `yaml:"description" validate:"required"`
+	AuthRequired []string `yaml:"authRequired"`
+	Repository   string   `yaml:"repository" validate:"required"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(compatibleSource)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
+	}
+
+	repoPath, err := githubds.RepositoryPath(cfg.Repository)
+	if err != nil {
+		return nil, err
+	}
+
+	pathParameter := tools.NewStringParameter(pathKey, "The path of the file or directory in the repository, for example `db/schema.sql`.")
+	refParameter := tools.NewStringParameterWithDefault(refKey, "", "The branch, tag or commit to read from. Defaults to the default branch of the repository.")
+	parameters := tools.Parameters{pathParameter, refParameter}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   parameters,
+		AuthRequired: cfg.AuthRequired,
+		Repository:   cfg.Repository,
+		repoPath:     repoPath,
+		Source:       s,
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:  mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+	Repository   string           `yaml:"repository"`
+
+	repoPath    string
+	Source      compatibleSource
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+type content struct {
+	Type     string `json:"type"`
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	SHA      string `json:"sha"`
+	Size     int    `json:"size"`
+	Encoding string `json:"encoding"`
+	Content  string `json:"content"`
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	mapParams := params.AsMap()
+	path, ok := mapParams[pathKey].(string)
+	path = strings.Trim(path, "/")
+	if !ok || path == "" {
+		return nil, fmt.Errorf("invalid or missing '%s' parameter; expected a non-empty string", pathKey)
+	}
+	ref, _ := mapParams[refKey].(string)
+
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	var values url.Values
+	if ref != "" {
+		values = url.Values{"ref": {ref}}
+	}
+	respBody, err := t.Source.GitHubRequest(ctx, http.MethodGet, t.repoPath+"/contents/"+strings.Join(segments, "/"), values, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contents of %q: %w", path, err)
+	}
+
+	// directories are returned as a list of their entries
+	if strings.HasPrefix(strings.TrimSpace(string(respBody)), "[") {
+		var entries []content
+		if err := json.Unmarshal(respBody, &entries); err != nil {
+			return nil, fmt.Errorf("failed to decode response: %w", err)
+		}
+		result := make([]any, 0, len(entries))
+		for _, e := range entries {
+			result = append(result, map[string]any{
+				"type": e.Type,
+				"name": e.Name,
+				"path": e.Path,
+				"size": e.Size,
+			})
+		}
+		return result, nil
+	}
+
+	var c content
+	if err := json.Unmarshal(respBody, &c); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if c.Type != "file" {
+		return nil, fmt.Errorf("%q is a %s, not a file or directory", path, c.Type)
+	}
+	// the API only includes the content of files up to 1 MB
+	if c.Encoding != "base64" {
+		return nil, fmt.Errorf("file %q is %d bytes, which is too large to be read", path, c.Size)
+	}
+	b, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(c.Content, "\n", ""))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode content of %q: %w", path, err)
+	}
+
+	result := map[string]any{
+		"path": c.Path,
+		"sha":  c.SHA,
+		"size": c.Size,
+	}
+	// binary files are returned base64 encoded since they can't be represented as JSON strings
+	if utf8.Valid(b) {
+		result["content"] = string(b)
+	} else {
+		result["content"] = base64.StdEncoding.EncodeToString(b)
+		result["encoding"] = "base64"
+	}
+	return result, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
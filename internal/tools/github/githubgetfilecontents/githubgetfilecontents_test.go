@@ -0,0 +1,179 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubgetfilecontents_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	githubds "github.com/googleapis/genai-toolbox/internal/sources/github"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools/github/githubgetfilecontents"
+)
+
+func TestParseFromYamlGitHubGetFileContents(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: github-get-file-contents
+					source: my-github
+					description: some description
+					repository: example/schemas
+			`,
+			want: server.ToolConfigs{
+				"example_tool": githubgetfilecontents.Config{
+					Name:         "example_tool",
+					Kind:         "github-get-file-contents",
+					Source:       "my-github",
+					Description:  "some description",
+					AuthRequired: []string{},
+					Repository:   "example/schemas",
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInvoke(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer ghp_token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/repos/example/schemas/contents/db/schema.sql":
+			if r.URL.Query().Get("ref") != "main" {
+				http.NotFound(w, r)
+				return
+			}
+			// "CREATE TABLE orders (id INT);\n" split across lines like the API does
+			_, _ = w.Write([]byte(`{"type": "file", "name": "schema.sql", "path": "db/schema.sql", "sha": "abc", "size": 30, "encoding": "base64", "content": "Q1JFQVRFIFRBQkxFIG9y\nZGVycyAoaWQgSU5UKTsK\n"}`))
+		case "/repos/example/schemas/contents/db/logo.png":
+			_, _ = w.Write([]byte(`{"type": "file", "name": "logo.png", "path": "db/logo.png", "sha": "def", "size": 2, "encoding": "base64", "content": "/w8="}`))
+		case "/repos/example/schemas/contents/db":
+			_, _ = w.Write([]byte(`[{"type": "file", "name": "schema.sql", "path": "db/schema.sql", "size": 30}, {"type": "dir", "name": "migrations", "path": "db/migrations", "size": 0}]`))
+		case "/repos/example/schemas/contents/db/big.sql":
+			_, _ = w.Write([]byte(`{"type": "file", "name": "big.sql", "path": "db/big.sql", "sha": "ghi", "size": 2000000, "encoding": "none", "content": ""}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	srcs := map[string]sources.Source{
+		"my-github": &githubds.Source{Name: "my-github", Kind: githubds.SourceKind, BaseURL: srv.URL, Token: "ghp_token", Client: srv.Client()},
+	}
+	cfg := githubgetfilecontents.Config{Name: "example_tool", Kind: "github-get-file-contents", Source: "my-github", Description: "some description", Repository: "example/schemas"}
+	tool, err := cfg.Initialize(srcs)
+	if err != nil {
+		t.Fatalf("unable to initialize tool: %s", err)
+	}
+
+	tcs := []struct {
+		desc    string
+		params  map[string]any
+		want    any
+		wantErr string
+	}{
+		{
+			desc:   "text file",
+			params: map[string]any{"path": "/db/schema.sql", "ref": "main"},
+			want:   map[string]any{"path": "db/schema.sql", "sha": "abc", "size": 30, "content": "CREATE TABLE orders (id INT);\n"},
+		},
+		{
+			desc:   "binary file",
+			params: map[string]any{"path": "db/logo.png"},
+			want:   map[string]any{"path": "db/logo.png", "sha": "def", "size": 2, "content": "/w8=", "encoding": "base64"},
+		},
+		{
+			desc:   "directory",
+			params: map[string]any{"path": "db"},
+			want: []any{
+				map[string]any{"type": "file", "name": "schema.sql", "path": "db/schema.sql", "size": 30},
+				map[string]any{"type": "dir", "name": "migrations", "path": "db/migrations", "size": 0},
+			},
+		},
+		{
+			desc:    "file too large",
+			params:  map[string]any{"path": "db/big.sql"},
+			wantErr: `file "db/big.sql" is 2000000 bytes, which is too large to be read`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			params, err := tool.ParseParams(tc.params, nil)
+			if err != nil {
+				t.Fatalf("unable to parse params: %s", err)
+			}
+			got, err := tool.Invoke(context.Background(), params)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect result: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInitializeInvalidRepository(t *testing.T) {
+	srcs := map[string]sources.Source{
+		"my-github": &githubds.Source{Name: "my-github", Kind: githubds.SourceKind},
+	}
+	cfg := githubgetfilecontents.Config{Name: "example_tool", Kind: "github-get-file-contents", Source: "my-github", Description: "some description", Repository: "schemas"}
+	_, err := cfg.Initialize(srcs)
+	want := `invalid repository "schemas": expected the format "owner/name"`
+	if err == nil || err.Error() != want {
+		t.Fatalf("unexpected error: got %v, want %q", err, want)
+	}
+}
@@ -0,0 +1,223 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githublistissues
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	githubds "github.com/googleapis/genai-toolbox/internal/sources/github"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+const kind string = "github-list-issues"
+const stateKey string = "state"
+const labelsKey string = "labels"
+const perPageKey string = "perPage"
+const pageKey string = "page"
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	GitHubRequest(ctx context.Context, method, path string, query url.Values, body any) ([]byte, error)
+}
+
+// validate compatible sources are still compatible
+var _ compatibleSource = &githubds.Source{}
+
+var compatibleSources = [...]string{githubds.SourceKind}
+
+type Config struct {
+	Name         string   `yaml:"name" validate:"required"`
+	Kind         string   `yaml:"kind" validate:"required"`
+	Source       string   `yaml:"source" validate:"required"`
+	Description  string   `yaml:"description" validate:"required"`
+	AuthRequired []string `yaml:"authRequired"`
+	Repository   string   `yaml:"repository" validate:"required"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(compatibleSource)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
+	}
+
+	repoPath, err := githubds.RepositoryPath(cfg.Repository)
+	if err != nil {
+		return nil, err
+	}
+
+	stateParameter := tools.NewStringParameterWithDefault(stateKey, "open", "The state of the issues to return, one of `open`, `closed` or `all`.")
+	labelsParameter := tools.NewStringParameterWithDefault(labelsKey, "", "A comma separated list of labels. Only issues with all of the labels are returned.")
+	perPageParameter := tools.NewIntParameterWithDefault(perPageKey, 30, "The maximum number of issues to return, up to 100.")
+	pageParameter := tools.NewIntParameterWithDefault(pageKey, 1, "The page of results to return, starting at 1.")
+	parameters := tools.Parameters{stateParameter, labelsParameter, perPageParameter, pageParameter}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   parameters,
+		AuthRequired: cfg.AuthRequired,
+		Repository:   cfg.Repository,
+		repoPath:     repoPath,
+		Source:       s,
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:  mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+	Repository   string           `yaml:"repository"`
+
+	repoPath    string
+	Source      compatibleSource
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	mapParams := params.AsMap()
+	state, _ := mapParams[stateKey].(string)
+	switch state {
+	case "open", "closed", "all":
+	default:
+		return nil, fmt.Errorf("invalid '%s' parameter %q; expected one of \"open\", \"closed\" or \"all\"", stateKey, state)
+	}
+	labels, _ := mapParams[labelsKey].(string)
+	perPage, _ := mapParams[perPageKey].(int)
+	page, _ := mapParams[pageKey].(int)
+
+	values := url.Values{
+		"state":    {state},
+		"per_page": {strconv.Itoa(perPage)},
+		"page":     {strconv.Itoa(page)},
+	}
+	if labels != "" {
+		values.Set("labels", labels)
+	}
+	respBody, err := t.Source.GitHubRequest(ctx, http.MethodGet, t.repoPath+"/issues", values, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list issues of %q: %w", t.Repository, err)
+	}
+
+	var resp []struct {
+		Number    int    `json:"number"`
+		Title     string `json:"title"`
+		State     string `json:"state"`
+		Body      string `json:"body"`
+		HTMLURL   string `json:"html_url"`
+		Comments  int    `json:"comments"`
+		CreatedAt string `json:"created_at"`
+		UpdatedAt string `json:"updated_at"`
+		User      struct {
+			Login string `json:"login"`
+		} `json:"user"`
+		Labels []struct {
+			Name string `json:"name"`
+		} `json:"labels"`
+		PullRequest json.RawMessage `json:"pull_request"`
+	}
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	issues := make([]any, 0, len(resp))
+	for _, issue := range resp {
+		// the API returns pull requests as issues too
+		if issue.PullRequest != nil {
+			continue
+		}
+		labelNames := make([]any, 0, len(issue.Labels))
+		for _, l := range issue.Labels {
+			labelNames = append(labelNames, l.Name)
+		}
+		issues = append(issues, map[string]any{
+			"number":    issue.Number,
+			"title":     issue.Title,
+			"state":     issue.State,
+			"body":      issue.Body,
+			"url":       issue.HTMLURL,
+			"author":    issue.User.Login,
+			"labels":    labelNames,
+			"comments":  issue.Comments,
+			"createdAt": issue.CreatedAt,
+			"updatedAt": issue.UpdatedAt,
+		})
+	}
+	return issues, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
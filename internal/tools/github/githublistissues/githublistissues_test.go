@@ -0,0 +1,139 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githublistissues_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	githubds "github.com/googleapis/genai-toolbox/internal/sources/github"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools/github/githublistissues"
+)
+
+func TestParseFromYamlGitHubListIssues(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: github-list-issues
+					source: my-github
+					description: some description
+					repository: example/schemas
+			`,
+			want: server.ToolConfigs{
+				"example_tool": githublistissues.Config{
+					Name:         "example_tool",
+					Kind:         "github-list-issues",
+					Source:       "my-github",
+					Description:  "some description",
+					AuthRequired: []string{},
+					Repository:   "example/schemas",
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInvoke(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/example/schemas/issues" {
+			http.NotFound(w, r)
+			return
+		}
+		q := r.URL.Query()
+		if q.Get("state") != "all" || q.Get("labels") != "bug" || q.Get("per_page") != "30" || q.Get("page") != "1" {
+			t.Errorf("unexpected query: %v", q)
+		}
+		_, _ = w.Write([]byte(`[
+			{"number": 2, "title": "Add index", "state": "open", "html_url": "https://github.com/example/schemas/pull/2", "pull_request": {"url": "x"}},
+			{"number": 1, "title": "orders.total is nullable", "state": "open", "body": "details", "html_url": "https://github.com/example/schemas/issues/1", "comments": 3, "created_at": "2025-01-01T00:00:00Z", "updated_at": "2025-01-02T00:00:00Z", "user": {"login": "octocat"}, "labels": [{"name": "bug"}]}
+		]`))
+	}))
+	defer srv.Close()
+
+	srcs := map[string]sources.Source{
+		"my-github": &githubds.Source{Name: "my-github", Kind: githubds.SourceKind, BaseURL: srv.URL, Token: "ghp_token", Client: srv.Client()},
+	}
+	cfg := githublistissues.Config{Name: "example_tool", Kind: "github-list-issues", Source: "my-github", Description: "some description", Repository: "example/schemas"}
+	tool, err := cfg.Initialize(srcs)
+	if err != nil {
+		t.Fatalf("unable to initialize tool: %s", err)
+	}
+	params, err := tool.ParseParams(map[string]any{"state": "all", "labels": "bug"}, nil)
+	if err != nil {
+		t.Fatalf("unable to parse params: %s", err)
+	}
+	got, err := tool.Invoke(context.Background(), params)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := []any{
+		map[string]any{
+			"number":    1,
+			"title":     "orders.total is nullable",
+			"state":     "open",
+			"body":      "details",
+			"url":       "https://github.com/example/schemas/issues/1",
+			"author":    "octocat",
+			"labels":    []any{"bug"},
+			"comments":  3,
+			"createdAt": "2025-01-01T00:00:00Z",
+			"updatedAt": "2025-01-02T00:00:00Z",
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect result: diff %v", diff)
+	}
+
+	params, err = tool.ParseParams(map[string]any{"state": "merged"}, nil)
+	if err != nil {
+		t.Fatalf("unable to parse params: %s", err)
+	}
+	if _, err := tool.Invoke(context.Background(), params); err == nil {
+		t.Fatalf("expected an error for an invalid state")
+	}
+}
@@ -0,0 +1,197 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubsearchcode
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	githubds "github.com/googleapis/genai-toolbox/internal/sources/github"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+const kind string = "github-search-code"
+const queryKey string = "query"
+const perPageKey string = "perPage"
+const pageKey string = "page"
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	GitHubRequest(ctx context.Context, method, path string, query url.Values, body any) ([]byte, error)
+}
+
+// validate compatible sources are still compatible
+var _ compatibleSource = &githubds.Source{}
+
+var compatibleSources = [...]string{githubds.SourceKind}
+
+type Config struct {
+	Name         string   `yaml:"name" validate:"required"`
+	Kind         string   `yaml:"kind" validate:"required"`
+	Source       string   `yaml:"source" validate:"required"`
+	Description  string   `yaml:"description" validate:"required"`
+	AuthRequired []string `yaml:"authRequired"`
+	Qualifiers   string   `yaml:"qualifiers"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(compatibleSource)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
+	}
+
+	queryParameter := tools.NewStringParameter(queryKey, "The code search query, for example `CREATE TABLE orders language:SQL`.")
+	perPageParameter := tools.NewIntParameterWithDefault(perPageKey, 20, "The maximum number of results to return, up to 100.")
+	pageParameter := tools.NewIntParameterWithDefault(pageKey, 1, "The page of results to return, starting at 1.")
+	parameters := tools.Parameters{queryParameter, perPageParameter, pageParameter}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   parameters,
+		AuthRequired: cfg.AuthRequired,
+		Qualifiers:   cfg.Qualifiers,
+		Source:       s,
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:  mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+	Qualifiers   string           `yaml:"qualifiers"`
+
+	Source      compatibleSource
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	mapParams := params.AsMap()
+	query, ok := mapParams[queryKey].(string)
+	if !ok || query == "" {
+		return nil, fmt.Errorf("invalid or missing '%s' parameter; expected a non-empty string", queryKey)
+	}
+	perPage, _ := mapParams[perPageKey].(int)
+	page, _ := mapParams[pageKey].(int)
+
+	// configured qualifiers, such as an organization, restrict every search
+	if t.Qualifiers != "" {
+		query = query + " " + t.Qualifiers
+	}
+	values := url.Values{
+		"q":        {query},
+		"per_page": {strconv.Itoa(perPage)},
+		"page":     {strconv.Itoa(page)},
+	}
+	respBody, err := t.Source.GitHubRequest(ctx, http.MethodGet, "/search/code", values, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search code: %w", err)
+	}
+
+	var resp struct {
+		TotalCount int `json:"total_count"`
+		Items      []struct {
+			Name       string `json:"name"`
+			Path       string `json:"path"`
+			SHA        string `json:"sha"`
+			HTMLURL    string `json:"html_url"`
+			Repository struct {
+				FullName string `json:"full_name"`
+			} `json:"repository"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	items := make([]any, 0, len(resp.Items))
+	for _, item := range resp.Items {
+		items = append(items, map[string]any{
+			"repository": item.Repository.FullName,
+			"path":       item.Path,
+			"name":       item.Name,
+			"sha":        item.SHA,
+			"url":        item.HTMLURL,
+		})
+	}
+	return map[string]any{
+		"totalCount": resp.TotalCount,
+		"items":      items,
+	}, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
@@ -0,0 +1,226 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googledriveexportdocument
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	driveds "github.com/googleapis/genai-toolbox/internal/sources/googledrive"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"google.golang.org/api/drive/v3"
+)
+
+const kind string = "googledrive-export-document"
+const fileIdKey string = "fileId"
+
+// defaultMaxBytes is the most content returned when maxBytes is not configured.
+const defaultMaxBytes int64 = 1 << 20
+
+// exportMimeTypes are the text formats Google Workspace documents are
+// exported to, by their MIME type.
+var exportMimeTypes = map[string]string{
+	"application/vnd.google-apps.document":     "text/plain",
+	"application/vnd.google-apps.presentation": "text/plain",
+	"application/vnd.google-apps.spreadsheet":  "text/csv",
+}
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	DriveService() *drive.Service
+}
+
+// validate compatible sources are still compatible
+var _ compatibleSource = &driveds.Source{}
+
+var compatibleSources = [...]string{driveds.SourceKind}
+
+type Config struct {
+	Name         string   `yaml:"name" validate:"required"`
+	Kind         string   `yaml:"kind" validate:"required"`
+	Source       string   `yaml:"source" validate:"required"`
+	Description  string   `yaml:"description" validate:"required"`
+	AuthRequired []string `yaml:"authRequired"`
+	MaxBytes     int64    `yaml:"maxBytes"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(compatibleSource)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
+	}
+
+	maxBytes := cfg.MaxBytes
+	if maxBytes <= 0 {
+		maxBytes = defaultMaxBytes
+	}
+
+	fileIdParameter := tools.NewStringParameter(fileIdKey, "The ID of the file to export, as returned by a search.")
+	parameters := tools.Parameters{fileIdParameter}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   parameters,
+		AuthRequired: cfg.AuthRequired,
+		MaxBytes:     maxBytes,
+		Service:      s.DriveService(),
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:  mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+	MaxBytes     int64            `yaml:"maxBytes"`
+
+	Service     *drive.Service
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	mapParams := params.AsMap()
+	fileId, ok := mapParams[fileIdKey].(string)
+	if !ok || fileId == "" {
+		return nil, fmt.Errorf("invalid or missing '%s' parameter; expected a non-empty string", fileIdKey)
+	}
+
+	file, err := t.Service.Files.Get(fileId).Fields("id,name,mimeType").SupportsAllDrives(true).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file %q: %w", fileId, err)
+	}
+
+	var resp *http.Response
+	contentType, ok := ExportMimeType(file.MimeType)
+	switch {
+	case !ok:
+		return nil, fmt.Errorf("file %q has type %q, which has no text content to export", file.Name, file.MimeType)
+	case strings.HasPrefix(file.MimeType, "application/vnd.google-apps."):
+		resp, err = t.Service.Files.Export(fileId, contentType).Context(ctx).Download()
+	default:
+		resp, err = t.Service.Files.Get(fileId).SupportsAllDrives(true).Context(ctx).Download()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to export file %q: %w", file.Name, err)
+	}
+	defer resp.Body.Close()
+
+	// read one byte past the limit to detect content that is too large
+	body, err := io.ReadAll(io.LimitReader(resp.Body, t.MaxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %q: %w", file.Name, err)
+	}
+	truncated := int64(len(body)) > t.MaxBytes
+	if truncated {
+		body = body[:t.MaxBytes]
+		// don't cut a multi-byte character in half
+		for i := 0; i < utf8.UTFMax && len(body) > 0; i++ {
+			if r, size := utf8.DecodeLastRune(body); r != utf8.RuneError || size > 1 {
+				break
+			}
+			body = body[:len(body)-1]
+		}
+		tools.AddWarning(ctx, "the content of %q was truncated to %d bytes", file.Name, len(body))
+		tools.SetTruncated(ctx)
+	}
+
+	return map[string]any{
+		"id":          file.Id,
+		"name":        file.Name,
+		"mimeType":    file.MimeType,
+		"contentType": contentType,
+		"content":     string(body),
+		"truncated":   truncated,
+	}, nil
+}
+
+// ExportMimeType returns the text format a file of the MIME type mimeType is
+// exported as, and whether it has text content.
+func ExportMimeType(mimeType string) (string, bool) {
+	if exportType, ok := exportMimeTypes[mimeType]; ok {
+		return exportType, true
+	}
+	switch {
+	case strings.HasPrefix(mimeType, "text/"),
+		mimeType == "application/json",
+		mimeType == "application/xml":
+		return mimeType, true
+	}
+	return "", false
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
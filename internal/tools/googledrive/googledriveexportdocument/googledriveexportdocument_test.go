@@ -0,0 +1,98 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googledriveexportdocument_test
+
+import (
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools/googledrive/googledriveexportdocument"
+)
+
+func TestParseFromYamlGoogleDriveExportDocument(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: googledrive-export-document
+					source: my-drive
+					description: Read a document
+					maxBytes: 65536
+			`,
+			want: server.ToolConfigs{
+				"example_tool": googledriveexportdocument.Config{
+					Name:         "example_tool",
+					Kind:         "googledrive-export-document",
+					Source:       "my-drive",
+					Description:  "Read a document",
+					MaxBytes:     65536,
+					AuthRequired: []string{},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestExportMimeType(t *testing.T) {
+	tcs := []struct {
+		mimeType string
+		want     string
+		ok       bool
+	}{
+		{mimeType: "application/vnd.google-apps.document", want: "text/plain", ok: true},
+		{mimeType: "application/vnd.google-apps.presentation", want: "text/plain", ok: true},
+		{mimeType: "application/vnd.google-apps.spreadsheet", want: "text/csv", ok: true},
+		{mimeType: "text/markdown", want: "text/markdown", ok: true},
+		{mimeType: "application/json", want: "application/json", ok: true},
+		{mimeType: "application/vnd.google-apps.folder"},
+		{mimeType: "image/png"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.mimeType, func(t *testing.T) {
+			got, ok := googledriveexportdocument.ExportMimeType(tc.mimeType)
+			if got != tc.want || ok != tc.ok {
+				t.Fatalf("incorrect export type: got %q, %t, want %q, %t", got, ok, tc.want, tc.ok)
+			}
+		})
+	}
+}
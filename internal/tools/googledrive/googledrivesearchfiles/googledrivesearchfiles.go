@@ -0,0 +1,196 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googledrivesearchfiles
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	driveds "github.com/googleapis/genai-toolbox/internal/sources/googledrive"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"google.golang.org/api/drive/v3"
+)
+
+const kind string = "googledrive-search-files"
+const queryKey string = "query"
+const pageSizeKey string = "pageSize"
+
+// fileFields are the fields of the files returned.
+const fileFields = "files(id,name,mimeType,modifiedTime,size,webViewLink)"
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	DriveService() *drive.Service
+}
+
+// validate compatible sources are still compatible
+var _ compatibleSource = &driveds.Source{}
+
+var compatibleSources = [...]string{driveds.SourceKind}
+
+type Config struct {
+	Name         string   `yaml:"name" validate:"required"`
+	Kind         string   `yaml:"kind" validate:"required"`
+	Source       string   `yaml:"source" validate:"required"`
+	Description  string   `yaml:"description" validate:"required"`
+	AuthRequired []string `yaml:"authRequired"`
+	// FolderId restricts the search to the files in a folder
+	FolderId string `yaml:"folderId"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(compatibleSource)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
+	}
+
+	queryParameter := tools.NewStringParameter(queryKey, "Text to search for in the name and content of files.")
+	pageSizeParameter := tools.NewIntParameterWithDefault(pageSizeKey, 20, "The maximum number of files to return.")
+	parameters := tools.Parameters{queryParameter, pageSizeParameter}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   parameters,
+		AuthRequired: cfg.AuthRequired,
+		FolderId:     cfg.FolderId,
+		Service:      s.DriveService(),
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:  mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+	FolderId     string           `yaml:"folderId"`
+
+	Service     *drive.Service
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	mapParams := params.AsMap()
+	query, ok := mapParams[queryKey].(string)
+	if !ok || query == "" {
+		return nil, fmt.Errorf("invalid or missing '%s' parameter; expected a non-empty string", queryKey)
+	}
+	pageSize, _ := mapParams[pageSizeKey].(int)
+
+	resp, err := t.Service.Files.List().
+		Q(BuildQuery(query, t.FolderId)).
+		PageSize(int64(pageSize)).
+		Fields(fileFields).
+		SupportsAllDrives(true).
+		IncludeItemsFromAllDrives(true).
+		Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("failed to search files: %w", err)
+	}
+
+	files := make([]any, 0, len(resp.Files))
+	for _, f := range resp.Files {
+		fileData := map[string]any{
+			"id":           f.Id,
+			"name":         f.Name,
+			"mimeType":     f.MimeType,
+			"modifiedTime": f.ModifiedTime,
+			"webViewLink":  f.WebViewLink,
+		}
+		// Google Workspace documents have no size
+		if f.Size > 0 {
+			fileData["size"] = f.Size
+		}
+		files = append(files, fileData)
+	}
+	return files, nil
+}
+
+// BuildQuery returns the Drive query searching for text in the files that
+// aren't trashed, in the folder folderId if it is set.
+func BuildQuery(text, folderId string) string {
+	q := fmt.Sprintf("fullText contains %s and trashed = false", quote(text))
+	if folderId != "" {
+		q += fmt.Sprintf(" and %s in parents", quote(folderId))
+	}
+	return q
+}
+
+// quote returns s as a Drive query string literal.
+func quote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `'`, `\'`)
+	return "'" + s + "'"
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googledrivesearchfiles_test
+
+import (
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools/googledrive/googledrivesearchfiles"
+)
+
+func TestParseFromYamlGoogleDriveSearchFiles(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: googledrive-search-files
+					source: my-drive
+					description: Search the handbook
+					folderId: 0B1234
+					authRequired:
+						- my-google-auth-service
+			`,
+			want: server.ToolConfigs{
+				"example_tool": googledrivesearchfiles.Config{
+					Name:         "example_tool",
+					Kind:         "googledrive-search-files",
+					Source:       "my-drive",
+					Description:  "Search the handbook",
+					FolderId:     "0B1234",
+					AuthRequired: []string{"my-google-auth-service"},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestBuildQuery(t *testing.T) {
+	tcs := []struct {
+		desc     string
+		text     string
+		folderId string
+		want     string
+	}{
+		{desc: "text", text: "expense policy", want: "fullText contains 'expense policy' and trashed = false"},
+		{desc: "quotes are escaped", text: `bob's \ notes`, want: `fullText contains 'bob\'s \\ notes' and trashed = false`},
+		{desc: "folder", text: "policy", folderId: "0B1234", want: "fullText contains 'policy' and trashed = false and '0B1234' in parents"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := googledrivesearchfiles.BuildQuery(tc.text, tc.folderId); got != tc.want {
+				t.Fatalf("incorrect query: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
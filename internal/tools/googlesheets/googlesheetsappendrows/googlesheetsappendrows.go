@@ -0,0 +1,183 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlesheetsappendrows
+
+import (
+	"context"
+	"fmt"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	sheetsds "github.com/googleapis/genai-toolbox/internal/sources/googlesheets"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"google.golang.org/api/sheets/v4"
+)
+
+const kind string = "googlesheets-append-rows"
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	SheetsService() *sheets.Service
+}
+
+// validate compatible sources are still compatible
+var _ compatibleSource = &sheetsds.Source{}
+
+var compatibleSources = [...]string{sheetsds.SourceKind}
+
+type Config struct {
+	Name          string `yaml:"name" validate:"required"`
+	Kind          string `yaml:"kind" validate:"required"`
+	Source        string `yaml:"source" validate:"required"`
+	Description   string `yaml:"description" validate:"required"`
+	SpreadsheetId string `yaml:"spreadsheetId" validate:"required"`
+	// Range is the table the rows are appended after, e.g. Sheet1!A:D
+	Range            string   `yaml:"range" validate:"required"`
+	ValueInputOption string   `yaml:"valueInputOption" validate:"omitempty,oneof=USER_ENTERED RAW"`
+	AuthRequired     []string `yaml:"authRequired"`
+	// Parameters are the cells of the appended row, in order
+	Parameters         tools.Parameters `yaml:"parameters" validate:"required"`
+	TemplateParameters tools.Parameters `yaml:"templateParameters"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(compatibleSource)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
+	}
+
+	allParameters, paramManifest, paramMcpManifest, err := tools.ProcessParameters(cfg.TemplateParameters, cfg.Parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	valueInputOption := cfg.ValueInputOption
+	if valueInputOption == "" {
+		valueInputOption = "USER_ENTERED"
+	}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: paramMcpManifest,
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:               cfg.Name,
+		Kind:               kind,
+		AuthRequired:       cfg.AuthRequired,
+		Parameters:         cfg.Parameters,
+		TemplateParameters: cfg.TemplateParameters,
+		AllParams:          allParameters,
+		SpreadsheetId:      cfg.SpreadsheetId,
+		Range:              cfg.Range,
+		ValueInputOption:   valueInputOption,
+		Service:            s.SheetsService(),
+		manifest:           tools.Manifest{Description: cfg.Description, Parameters: paramManifest, AuthRequired: cfg.AuthRequired},
+		mcpManifest:        mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name               string           `yaml:"name"`
+	Kind               string           `yaml:"kind"`
+	AuthRequired       []string         `yaml:"authRequired"`
+	Parameters         tools.Parameters `yaml:"parameters"`
+	TemplateParameters tools.Parameters `yaml:"templateParameters"`
+	AllParams          tools.Parameters `yaml:"allParams"`
+
+	SpreadsheetId    string
+	Range            string
+	ValueInputOption string
+	Service          *sheets.Service
+	manifest         tools.Manifest
+	mcpManifest      tools.McpManifest
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	paramsMap := params.AsMap()
+	rng, err := tools.ResolveTemplateParams("", t.TemplateParameters, t.Range, paramsMap)
+	if err != nil {
+		return nil, fmt.Errorf("unable to extract template params %w", err)
+	}
+	row, err := tools.GetParams(t.Parameters, paramsMap)
+	if err != nil {
+		return nil, fmt.Errorf("unable to extract standard params %w", err)
+	}
+
+	resp, err := t.Service.Spreadsheets.Values.Append(t.SpreadsheetId, rng, &sheets.ValueRange{Values: [][]any{row.AsSlice()}}).
+		ValueInputOption(t.ValueInputOption).
+		InsertDataOption("INSERT_ROWS").
+		Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("unable to append row to %q: %w", rng, err)
+	}
+
+	result := map[string]any{"tableRange": resp.TableRange}
+	if resp.Updates != nil {
+		result["updatedRange"] = resp.Updates.UpdatedRange
+		result["updatedRows"] = resp.Updates.UpdatedRows
+		result["updatedCells"] = resp.Updates.UpdatedCells
+	}
+	return result, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.AllParams, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlesheetsappendrows_test
+
+import (
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/googlesheets/googlesheetsappendrows"
+)
+
+func TestParseFromYamlGoogleSheetsAppendRows(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: googlesheets-append-rows
+					source: my-sheets
+					description: some description
+					spreadsheetId: my-spreadsheet
+					range: "Tracker!A:B"
+					valueInputOption: RAW
+					parameters:
+						- name: project
+						  type: string
+						  description: name of the project
+						- name: budget
+						  type: float
+						  description: budget of the project
+			`,
+			want: server.ToolConfigs{
+				"example_tool": googlesheetsappendrows.Config{
+					Name:             "example_tool",
+					Kind:             "googlesheets-append-rows",
+					Source:           "my-sheets",
+					Description:      "some description",
+					SpreadsheetId:    "my-spreadsheet",
+					Range:            "Tracker!A:B",
+					ValueInputOption: "RAW",
+					AuthRequired:     []string{},
+					Parameters: []tools.Parameter{
+						tools.NewStringParameter("project", "name of the project"),
+						tools.NewFloatParameter("budget", "budget of the project"),
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlesheetsreadrange_test
+
+import (
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/googlesheets/googlesheetsreadrange"
+)
+
+func TestParseFromYamlGoogleSheetsReadRange(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: googlesheets-read-range
+					source: my-sheets
+					description: Read the project tracker
+					spreadsheetId: 1BxiMVs0XRA5nFMdKvBdBZjgmUUqptlbs74OgvE2upms
+					range: Tracker!A:E
+					headerRow: true
+			`,
+			want: server.ToolConfigs{
+				"example_tool": googlesheetsreadrange.Config{
+					Name:          "example_tool",
+					Kind:          "googlesheets-read-range",
+					Source:        "my-sheets",
+					Description:   "Read the project tracker",
+					SpreadsheetId: "1BxiMVs0XRA5nFMdKvBdBZjgmUUqptlbs74OgvE2upms",
+					Range:         "Tracker!A:E",
+					HeaderRow:     true,
+					AuthRequired:  []string{},
+				},
+			},
+		},
+		{
+			desc: "with template parameters",
+			in: `
+			tools:
+				example_tool:
+					kind: googlesheets-read-range
+					source: my-sheets
+					description: Read a sheet
+					spreadsheetId: my-spreadsheet
+					range: "{{.sheet}}!A1:Z100"
+					templateParameters:
+						- name: sheet
+						  type: string
+						  description: name of the sheet
+			`,
+			want: server.ToolConfigs{
+				"example_tool": googlesheetsreadrange.Config{
+					Name:          "example_tool",
+					Kind:          "googlesheets-read-range",
+					Source:        "my-sheets",
+					Description:   "Read a sheet",
+					SpreadsheetId: "my-spreadsheet",
+					Range:         "{{.sheet}}!A1:Z100",
+					AuthRequired:  []string{},
+					TemplateParameters: []tools.Parameter{
+						tools.NewStringParameter("sheet", "name of the sheet"),
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestRowsWithHeader(t *testing.T) {
+	values := [][]any{
+		{"project", "owner", "", "budget"},
+		{"apollo", "alice", "ignored", 1000.0},
+		{"gemini"},
+	}
+	want := []any{
+		map[string]any{"project": "apollo", "owner": "alice", "budget": 1000.0},
+		map[string]any{"project": "gemini", "owner": nil, "budget": nil},
+	}
+	if diff := cmp.Diff(want, googlesheetsreadrange.RowsWithHeader(values)); diff != "" {
+		t.Fatalf("incorrect rows: diff %v", diff)
+	}
+	if diff := cmp.Diff([]any{}, googlesheetsreadrange.RowsWithHeader(nil)); diff != "" {
+		t.Fatalf("incorrect rows: diff %v", diff)
+	}
+}
@@ -0,0 +1,312 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	httpsrc "github.com/googleapis/genai-toolbox/internal/sources/http"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+const kind string = "graphql"
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name          string            `yaml:"name" validate:"required"`
+	Kind          string            `yaml:"kind" validate:"required"`
+	Source        string            `yaml:"source" validate:"required"`
+	Description   string            `yaml:"description" validate:"required"`
+	AuthRequired  []string          `yaml:"authRequired"`
+	Path          string            `yaml:"path"`
+	Headers       map[string]string `yaml:"headers"`
+	Query         string            `yaml:"query" validate:"required"`
+	OperationName string            `yaml:"operationName"`
+	Parameters    tools.Parameters  `yaml:"parameters"`
+	ResultPath    string            `yaml:"resultPath"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(*httpsrc.Source)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be `http`", kind)
+	}
+
+	// Verify no duplicate parameter names
+	if err := tools.CheckDuplicateParameters(cfg.Parameters); err != nil {
+		return nil, err
+	}
+
+	// Build the endpoint URL once, since GraphQL uses a single endpoint
+	endpoint, err := url.Parse(s.BaseURL + cfg.Path)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing URL: %s", err)
+	}
+	query := endpoint.Query()
+	for k, v := range s.QueryParams {
+		query.Add(k, v)
+	}
+	endpoint.RawQuery = query.Encode()
+
+	// Combine Source and Tool headers.
+	// In case of conflict, Tool header overrides Source header
+	combinedHeaders := make(map[string]string)
+	maps.Copy(combinedHeaders, s.DefaultHeaders)
+	maps.Copy(combinedHeaders, cfg.Headers)
+
+	paramManifest := cfg.Parameters.Manifest()
+	if paramManifest == nil {
+		paramManifest = make([]tools.ParameterManifest, 0)
+	}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: cfg.Parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	return Tool{
+		Name:          cfg.Name,
+		Kind:          kind,
+		AuthRequired:  cfg.AuthRequired,
+		URL:           endpoint.String(),
+		Headers:       combinedHeaders,
+		Query:         cfg.Query,
+		OperationName: cfg.OperationName,
+		Parameters:    cfg.Parameters,
+		ResultPath:    cfg.ResultPath,
+		Client:        s.Client,
+		manifest:      tools.Manifest{Description: cfg.Description, Parameters: paramManifest, AuthRequired: cfg.AuthRequired},
+		mcpManifest:   mcpManifest,
+	}, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string   `yaml:"name"`
+	Kind         string   `yaml:"kind"`
+	AuthRequired []string `yaml:"authRequired"`
+
+	URL           string            `yaml:"url"`
+	Headers       map[string]string `yaml:"headers"`
+	Query         string            `yaml:"query"`
+	OperationName string            `yaml:"operationName"`
+	Parameters    tools.Parameters  `yaml:"parameters"`
+	ResultPath    string            `yaml:"resultPath"`
+
+	Client      *http.Client
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+// gqlRequest is the body of a GraphQL request sent over HTTP.
+type gqlRequest struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+// gqlError is a single entry of the "errors" list of a GraphQL response.
+type gqlError struct {
+	Message    string         `json:"message"`
+	Locations  []any          `json:"locations,omitempty"`
+	Path       []any          `json:"path,omitempty"`
+	Extensions map[string]any `json:"extensions,omitempty"`
+}
+
+// gqlResponse is the body of a GraphQL response.
+type gqlResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []gqlError      `json:"errors"`
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	body, err := json.Marshal(gqlRequest{
+		Query:         t.Query,
+		OperationName: t.OperationName,
+		Variables:     params.AsMap(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal request body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error creating HTTP request: %s", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/graphql-response+json, application/json")
+	for k, v := range t.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := t.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error making HTTP request: %s", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeResponse(resp.StatusCode, respBody, t.ResultPath)
+}
+
+// DecodeResponse decodes a GraphQL response body and extracts the value at
+// resultPath from its data. Following the GraphQL spec, a response without
+// data is treated as a failure and its errors are returned, while errors
+// alongside partial data are returned together with the data.
+func DecodeResponse(statusCode int, body []byte, resultPath string) (any, error) {
+	var gqlResp gqlResponse
+	if err := json.Unmarshal(body, &gqlResp); err != nil {
+		if statusCode != http.StatusOK {
+			return nil, fmt.Errorf("unexpected status code: %d, response body: %s", statusCode, string(body))
+		}
+		return nil, fmt.Errorf("unable to parse GraphQL response: %w", err)
+	}
+
+	var data any
+	if len(gqlResp.Data) > 0 {
+		if err := json.Unmarshal(gqlResp.Data, &data); err != nil {
+			return nil, fmt.Errorf("unable to parse GraphQL response data: %w", err)
+		}
+	}
+	if data == nil {
+		if len(gqlResp.Errors) > 0 {
+			return nil, fmt.Errorf("GraphQL request failed: %s", formatErrors(gqlResp.Errors))
+		}
+		if statusCode != http.StatusOK {
+			return nil, fmt.Errorf("unexpected status code: %d, response body: %s", statusCode, string(body))
+		}
+		return nil, nil
+	}
+
+	result, err := ExtractPath(data, resultPath)
+	if err != nil {
+		return nil, err
+	}
+	if len(gqlResp.Errors) > 0 {
+		return map[string]any{"data": result, "errors": gqlResp.Errors}, nil
+	}
+	return result, nil
+}
+
+// formatErrors joins the messages of GraphQL errors, prefixed by the path of
+// the field they were raised on.
+func formatErrors(errs []gqlError) string {
+	msgs := make([]string, 0, len(errs))
+	for _, e := range errs {
+		if len(e.Path) == 0 {
+			msgs = append(msgs, e.Message)
+			continue
+		}
+		segments := make([]string, 0, len(e.Path))
+		for _, p := range e.Path {
+			segments = append(segments, fmt.Sprint(p))
+		}
+		msgs = append(msgs, fmt.Sprintf("%s: %s", strings.Join(segments, "."), e.Message))
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// ExtractPath returns the value at a dot separated path (e.g.
+// "repository.issues.nodes") within data. Integer segments index into lists.
+// An empty path returns data as-is.
+func ExtractPath(data any, path string) (any, error) {
+	if path == "" {
+		return data, nil
+	}
+	current := data
+	for _, segment := range strings.Split(path, ".") {
+		switch v := current.(type) {
+		case map[string]any:
+			next, ok := v[segment]
+			if !ok {
+				return nil, fmt.Errorf("resultPath %q not found in response: missing field %q", path, segment)
+			}
+			current = next
+		case []any:
+			idx, err := strconv.Atoi(segment)
+			if err != nil || idx < 0 || idx >= len(v) {
+				return nil, fmt.Errorf("resultPath %q not found in response: invalid list index %q", path, segment)
+			}
+			current = v[idx]
+		case nil:
+			// a nullable field resolved to null, so the rest of the path is null too
+			return nil, nil
+		default:
+			return nil, fmt.Errorf("resultPath %q not found in response: %q is not an object or a list", path, segment)
+		}
+	}
+	return current, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
@@ -0,0 +1,211 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphql_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/graphql"
+)
+
+func TestParseFromYamlGraphQL(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: graphql
+					source: my-instance
+					description: some description
+					query: "{ viewer { login } }"
+			`,
+			want: server.ToolConfigs{
+				"example_tool": graphql.Config{
+					Name:         "example_tool",
+					Kind:         "graphql",
+					Source:       "my-instance",
+					Description:  "some description",
+					Query:        "{ viewer { login } }",
+					AuthRequired: []string{},
+				},
+			},
+		},
+		{
+			desc: "advanced example",
+			in: `
+			tools:
+				example_tool:
+					kind: graphql
+					source: my-instance
+					description: some description
+					path: /graphql
+					headers:
+						X-Custom-Header: example
+					query: "query GetRepo($owner: String!, $name: String!) { repository(owner: $owner, name: $name) { stargazerCount } }"
+					operationName: GetRepo
+					resultPath: repository.stargazerCount
+					authRequired:
+						- my-google-auth-service
+					parameters:
+						- name: owner
+						  type: string
+						  description: some description
+						- name: name
+						  type: string
+						  description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": graphql.Config{
+					Name:          "example_tool",
+					Kind:          "graphql",
+					Source:        "my-instance",
+					Description:   "some description",
+					Path:          "/graphql",
+					Headers:       map[string]string{"X-Custom-Header": "example"},
+					Query:         "query GetRepo($owner: String!, $name: String!) { repository(owner: $owner, name: $name) { stargazerCount } }",
+					OperationName: "GetRepo",
+					ResultPath:    "repository.stargazerCount",
+					AuthRequired:  []string{"my-google-auth-service"},
+					Parameters: []tools.Parameter{
+						tools.NewStringParameter("owner", "some description"),
+						tools.NewStringParameter("name", "some description"),
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestDecodeResponse(t *testing.T) {
+	tcs := []struct {
+		desc       string
+		statusCode int
+		body       string
+		resultPath string
+		want       any
+		wantErr    string
+	}{
+		{
+			desc:       "data",
+			statusCode: 200,
+			body:       `{"data":{"viewer":{"login":"octocat"}}}`,
+			want:       map[string]any{"viewer": map[string]any{"login": "octocat"}},
+		},
+		{
+			desc:       "result path",
+			statusCode: 200,
+			body:       `{"data":{"repository":{"issues":{"nodes":[{"title":"a"},{"title":"b"}]}}}}`,
+			resultPath: "repository.issues.nodes.1.title",
+			want:       "b",
+		},
+		{
+			desc:       "null along result path",
+			statusCode: 200,
+			body:       `{"data":{"repository":null}}`,
+			resultPath: "repository.issues",
+			want:       nil,
+		},
+		{
+			desc:       "partial data",
+			statusCode: 200,
+			body:       `{"data":{"a":1,"b":null},"errors":[{"message":"boom","path":["b"]}]}`,
+			want: map[string]any{
+				"data":   map[string]any{"a": float64(1), "b": nil},
+				"errors": []any{map[string]any{"message": "boom", "path": []any{"b"}}},
+			},
+		},
+		{
+			desc:       "request error",
+			statusCode: 400,
+			body:       `{"errors":[{"message":"Variable \"$id\" of required type \"ID!\" was not provided."}]}`,
+			wantErr:    `GraphQL request failed: Variable "$id" of required type "ID!" was not provided.`,
+		},
+		{
+			desc:       "field error",
+			statusCode: 200,
+			body:       `{"data":null,"errors":[{"message":"not found","path":["node",0]}]}`,
+			wantErr:    "GraphQL request failed: node.0: not found",
+		},
+		{
+			desc:       "non json error",
+			statusCode: 502,
+			body:       `Bad Gateway`,
+			wantErr:    "unexpected status code: 502, response body: Bad Gateway",
+		},
+		{
+			desc:       "missing result path",
+			statusCode: 200,
+			body:       `{"data":{"viewer":{}}}`,
+			resultPath: "viewer.login",
+			wantErr:    `resultPath "viewer.login" not found in response: missing field "login"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := graphql.DecodeResponse(tc.statusCode, []byte(tc.body), tc.resultPath)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			// round trip through JSON so typed errors compare as plain values
+			b, err := json.Marshal(got)
+			if err != nil {
+				t.Fatalf("unable to marshal result: %s", err)
+			}
+			var gotValue any
+			if err := json.Unmarshal(b, &gotValue); err != nil {
+				t.Fatalf("unable to unmarshal result: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, gotValue); diff != "" {
+				t.Fatalf("incorrect result: diff %v", diff)
+			}
+		})
+	}
+}
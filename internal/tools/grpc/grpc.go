@@ -0,0 +1,196 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	grpcsrc "github.com/googleapis/genai-toolbox/internal/sources/grpc"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+const kind string = "grpc"
+
+// resolveTimeout bounds the time spent fetching a method descriptor with
+// server reflection during tool initialization.
+const resolveTimeout = 30 * time.Second
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	GRPCClient() *grpcsrc.Client
+}
+
+// validate compatible sources are still compatible
+var _ compatibleSource = &grpcsrc.Source{}
+
+var compatibleSources = [...]string{grpcsrc.SourceKind}
+
+type Config struct {
+	Name         string           `yaml:"name" validate:"required"`
+	Kind         string           `yaml:"kind" validate:"required"`
+	Source       string           `yaml:"source" validate:"required"`
+	Description  string           `yaml:"description" validate:"required"`
+	Method       string           `yaml:"method" validate:"required"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(compatibleSource)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
+	}
+	client := s.GRPCClient()
+
+	ctx, cancel := context.WithTimeout(context.Background(), resolveTimeout)
+	defer cancel()
+	method, err := client.FindMethod(ctx, cfg.Method)
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve method %q: %w", cfg.Method, err)
+	}
+	if method.IsStreamingClient() || method.IsStreamingServer() {
+		return nil, fmt.Errorf("method %q is a streaming RPC: only unary RPCs are supported", cfg.Method)
+	}
+
+	// verify every parameter maps to a field of the request message
+	fields := method.Input().Fields()
+	for _, p := range cfg.Parameters {
+		name := p.GetName()
+		if fields.ByJSONName(name) == nil && fields.ByName(protoreflect.Name(name)) == nil {
+			return nil, fmt.Errorf("parameter %q does not match any field of request message %q", name, method.Input().FullName())
+		}
+	}
+
+	paramManifest := cfg.Parameters.Manifest()
+	if paramManifest == nil {
+		paramManifest = make([]tools.ParameterManifest, 0)
+	}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: cfg.Parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   cfg.Parameters,
+		AuthRequired: cfg.AuthRequired,
+		Client:       client,
+		Method:       method,
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: paramManifest, AuthRequired: cfg.AuthRequired},
+		mcpManifest:  mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+
+	Client      *grpcsrc.Client
+	Method      protoreflect.MethodDescriptor
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	// parameters are mapped to request fields using the protobuf JSON mapping
+	reqJSON, err := json.Marshal(params.AsMap())
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal request: %w", err)
+	}
+	req := dynamicpb.NewMessage(t.Method.Input())
+	if err := protojson.Unmarshal(reqJSON, req); err != nil {
+		return nil, fmt.Errorf("unable to build request message %q: %w", t.Method.Input().FullName(), err)
+	}
+
+	resp := dynamicpb.NewMessage(t.Method.Output())
+	if err := t.Client.Invoke(ctx, t.Method, req, resp); err != nil {
+		if st, ok := status.FromError(err); ok {
+			return nil, fmt.Errorf("RPC failed with code %s: %s", st.Code(), st.Message())
+		}
+		return nil, fmt.Errorf("RPC failed: %w", err)
+	}
+
+	respJSON, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(resp)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal response: %w", err)
+	}
+	var out any
+	if err := json.Unmarshal(respJSON, &out); err != nil {
+		return nil, fmt.Errorf("unable to parse response: %w", err)
+	}
+	return out, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
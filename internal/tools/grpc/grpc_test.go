@@ -0,0 +1,237 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc_test
+
+import (
+	"net"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	grpcsrc "github.com/googleapis/genai-toolbox/internal/sources/grpc"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/grpc"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"go.opentelemetry.io/otel/trace/noop"
+	grpcgo "google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+func TestParseFromYamlGRPC(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: grpc
+					source: my-grpc-instance
+					description: some description
+					method: orders.v1.OrderService/GetOrder
+					authRequired:
+						- my-google-auth-service
+					parameters:
+						- name: orderId
+						  type: string
+						  description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": grpc.Config{
+					Name:         "example_tool",
+					Kind:         "grpc",
+					Source:       "my-grpc-instance",
+					Description:  "some description",
+					Method:       "orders.v1.OrderService/GetOrder",
+					AuthRequired: []string{"my-google-auth-service"},
+					Parameters: []tools.Parameter{
+						tools.NewStringParameter("orderId", "some description"),
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInvokeWithReflection(t *testing.T) {
+	// serve the health service with server reflection enabled
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unable to listen: %s", err)
+	}
+	srv := grpcgo.NewServer()
+	healthSrv := health.NewServer()
+	healthSrv.SetServingStatus("orders", healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(srv, healthSrv)
+	reflection.Register(srv)
+	go func() { _ = srv.Serve(lis) }()
+	defer srv.Stop()
+
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	ctx = util.WithUserAgent(ctx, "test")
+	src, err := grpcsrc.Config{Name: "my-grpc-instance", Kind: grpcsrc.SourceKind, Address: lis.Addr().String(), Timeout: "5s"}.Initialize(ctx, noop.NewTracerProvider().Tracer("test"))
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	srcs := map[string]sources.Source{"my-grpc-instance": src}
+
+	tcs := []struct {
+		desc    string
+		method  string
+		params  tools.Parameters
+		data    map[string]any
+		want    any
+		wantErr string
+	}{
+		{
+			desc:   "unary call",
+			method: "grpc.health.v1.Health/Check",
+			params: tools.Parameters{tools.NewStringParameter("service", "some description")},
+			data:   map[string]any{"service": "orders"},
+			want:   map[string]any{"status": "SERVING"},
+		},
+		{
+			desc:    "rpc error",
+			method:  "grpc.health.v1.Health.Check",
+			params:  tools.Parameters{tools.NewStringParameter("service", "some description")},
+			data:    map[string]any{"service": "unknown"},
+			wantErr: "RPC failed with code NotFound: unknown service",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			tool, err := grpc.Config{
+				Name:        "example_tool",
+				Kind:        "grpc",
+				Source:      "my-grpc-instance",
+				Description: "some description",
+				Method:      tc.method,
+				Parameters:  tc.params,
+			}.Initialize(srcs)
+			if err != nil {
+				t.Fatalf("unable to initialize tool: %s", err)
+			}
+			params, err := tool.ParseParams(tc.data, nil)
+			if err != nil {
+				t.Fatalf("unable to parse params: %s", err)
+			}
+			got, err := tool.Invoke(ctx, params)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect result: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestFailInitialize(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unable to listen: %s", err)
+	}
+	srv := grpcgo.NewServer()
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+	reflection.Register(srv)
+	go func() { _ = srv.Serve(lis) }()
+	defer srv.Stop()
+
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	ctx = util.WithUserAgent(ctx, "test")
+	src, err := grpcsrc.Config{Name: "my-grpc-instance", Kind: grpcsrc.SourceKind, Address: lis.Addr().String(), Timeout: "5s"}.Initialize(ctx, noop.NewTracerProvider().Tracer("test"))
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	srcs := map[string]sources.Source{"my-grpc-instance": src}
+
+	tcs := []struct {
+		desc   string
+		method string
+		params tools.Parameters
+		err    string
+	}{
+		{
+			desc:   "streaming method",
+			method: "grpc.health.v1.Health/Watch",
+			err:    `method "grpc.health.v1.Health/Watch" is a streaming RPC: only unary RPCs are supported`,
+		},
+		{
+			desc:   "unknown method",
+			method: "grpc.health.v1.Health/Missing",
+			err:    `unable to resolve method "grpc.health.v1.Health/Missing": service "grpc.health.v1.Health" has no method "Missing"`,
+		},
+		{
+			desc:   "unknown field",
+			method: "grpc.health.v1.Health/Check",
+			params: tools.Parameters{tools.NewStringParameter("name", "some description")},
+			err:    `parameter "name" does not match any field of request message "grpc.health.v1.HealthCheckRequest"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := grpc.Config{
+				Name:        "example_tool",
+				Kind:        "grpc",
+				Source:      "my-grpc-instance",
+				Description: "some description",
+				Method:      tc.method,
+				Parameters:  tc.params,
+			}.Initialize(srcs)
+			if err == nil || err.Error() != tc.err {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hivesql_test
+
+import (
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/hive/hivesql"
+)
+
+func TestParseFromYamlHive(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: hive-sql
+					source: my-hive
+					description: some description
+					statement: |
+						SELECT * FROM sales.orders WHERE region = :region
+					authRequired:
+						- my-google-auth-service
+					parameters:
+						- name: region
+						  type: string
+						  description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": hivesql.Config{
+					Name:         "example_tool",
+					Kind:         "hive-sql",
+					Source:       "my-hive",
+					Description:  "some description",
+					Statement:    "SELECT * FROM sales.orders WHERE region = :region\n",
+					AuthRequired: []string{"my-google-auth-service"},
+					Parameters: []tools.Parameter{
+						tools.NewStringParameter("region", "some description"),
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestBindParams(t *testing.T) {
+	tcs := []struct {
+		desc      string
+		statement string
+		params    []any
+		want      string
+	}{
+		{
+			desc:      "scalar values",
+			statement: "SELECT * FROM t WHERE a = ? AND b = ? AND c = ? AND d = ? AND e = ?",
+			params:    []any{1, 2.5, true, nil, "x"},
+			want:      "SELECT * FROM t WHERE a = 1 AND b = 2.5 AND c = TRUE AND d = NULL AND e = 'x'",
+		},
+		{
+			desc:      "strings are escaped",
+			statement: "SELECT * FROM t WHERE name = ?",
+			params:    []any{`x' OR '1'='1\`},
+			want:      `SELECT * FROM t WHERE name = 'x\' OR \'1\'=\'1\\'`,
+		},
+		{
+			desc:      "array",
+			statement: "SELECT * FROM t WHERE array_contains(?, region)",
+			params:    []any{[]any{"us", "eu"}},
+			want:      "SELECT * FROM t WHERE array_contains(array('us', 'eu'), region)",
+		},
+		{
+			desc:      "placeholders in literals, identifiers and comments",
+			statement: "SELECT '?', \"a\\\"?\", `b``?` -- ?\n/* ? */ FROM t WHERE a = ?",
+			params:    []any{"x"},
+			want:      "SELECT '?', \"a\\\"?\", `b``?` -- ?\n/* ? */ FROM t WHERE a = 'x'",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := hivesql.BindParams(tc.statement, tc.params)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect statement: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestFailBindParams(t *testing.T) {
+	tcs := []struct {
+		desc      string
+		statement string
+		params    []any
+		err       string
+	}{
+		{
+			desc:      "too few parameters",
+			statement: "SELECT * FROM t WHERE a = ? AND b = ?",
+			params:    []any{1},
+			err:       "statement has more placeholders than the 1 parameters",
+		},
+		{
+			desc:      "too many parameters",
+			statement: "SELECT * FROM t WHERE a = ?",
+			params:    []any{1, 2},
+			err:       "statement has 1 placeholders, but 2 parameters were given",
+		},
+		{
+			desc:      "unsupported type",
+			statement: "SELECT * FROM t WHERE a = ?",
+			params:    []any{map[string]any{}},
+			err:       "parameter 1: unsupported type map[string]interface {}",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := hivesql.BindParams(tc.statement, tc.params)
+			if err == nil || err.Error() != tc.err {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.err)
+			}
+		})
+	}
+}
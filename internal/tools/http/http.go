@@ -27,6 +27,7 @@ import (
 	"maps"
 	"text/template"
 
+	"github.com/antchfx/xpath"
 	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	httpsrc "github.com/googleapis/genai-toolbox/internal/sources/http"
@@ -63,6 +64,10 @@ type Config struct {
 	QueryParams  tools.Parameters  `yaml:"queryParams"`
 	BodyParams   tools.Parameters  `yaml:"bodyParams"`
 	HeaderParams tools.Parameters  `yaml:"headerParams"`
+
+	RequestFormat RequestFormat     `yaml:"requestFormat"`
+	ResponseXPath string            `yaml:"responseXPath"`
+	XMLNamespaces map[string]string `yaml:"xmlNamespaces"`
 }
 
 // validate interface
@@ -100,6 +105,25 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		return nil, err
 	}
 
+	// Compile the XPath used to extract the response, if any
+	var responseXPath *xpath.Expr
+	if cfg.ResponseXPath != "" {
+		responseXPath, err = compileXPath(cfg.ResponseXPath, cfg.XMLNamespaces)
+		if err != nil {
+			return nil, fmt.Errorf("unable to compile responseXPath %q: %w", cfg.ResponseXPath, err)
+		}
+	}
+
+	requestFormat := cfg.RequestFormat
+	if requestFormat == "" {
+		requestFormat = RequestFormatJSON
+	}
+	if requestFormat == RequestFormatXML {
+		if _, ok := headerValue(combinedHeaders, "Content-Type"); !ok {
+			combinedHeaders["Content-Type"] = "text/xml; charset=utf-8"
+		}
+	}
+
 	// Create Toolbox manifest
 	paramManifest := allParameters.Manifest()
 
@@ -131,6 +155,8 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		DefaultQueryParams: s.QueryParams,
 		Client:             s.Client,
 		AllParams:          allParameters,
+		RequestFormat:      requestFormat,
+		ResponseXPath:      responseXPath,
 		manifest:           tools.Manifest{Description: cfg.Description, Parameters: paramManifest, AuthRequired: cfg.AuthRequired},
 		mcpManifest:        mcpManifest,
 	}, nil
@@ -158,18 +184,38 @@ type Tool struct {
 	HeaderParams tools.Parameters `yaml:"headerParams"`
 	AllParams    tools.Parameters `yaml:"allParams"`
 
+	RequestFormat RequestFormat `yaml:"requestFormat"`
+	ResponseXPath *xpath.Expr
+
 	Client      *http.Client
 	manifest    tools.Manifest
 	mcpManifest tools.McpManifest
 }
 
-// Helper function to generate the HTTP request body upon Tool invocation.
-func getRequestBody(bodyParams tools.Parameters, requestBodyPayload string, paramsMap map[string]any) (string, error) {
+// Helper function to generate the HTTP request body upon Tool invocation. The
+// body can use the path parameters as well as the body parameters, so that
+// values such as a project ID in both the path and the body are only passed
+// once.
+func getRequestBody(pathParams, bodyParams tools.Parameters, requestBodyPayload string, requestFormat RequestFormat, paramsMap map[string]any) (string, error) {
+	pathParamValues, err := tools.GetParams(pathParams, paramsMap)
+	if err != nil {
+		return "", err
+	}
 	bodyParamValues, err := tools.GetParams(bodyParams, paramsMap)
 	if err != nil {
 		return "", err
 	}
-	bodyParamsMap := bodyParamValues.AsMap()
+	bodyParamsMap := pathParamValues.AsMap()
+	maps.Copy(bodyParamsMap, bodyParamValues.AsMap())
+
+	// XML bodies are populated with escaped values, so parameters can't
+	// change the structure of the document
+	if requestFormat == RequestFormatXML {
+		bodyParamsMap, err = escapeXMLValues(bodyParamsMap)
+		if err != nil {
+			return "", err
+		}
+	}
 
 	requestBodyStr, err := tools.PopulateTemplateWithJSON("HTTPToolRequestBody", requestBodyPayload, bodyParamsMap)
 	if err != nil {
@@ -224,6 +270,16 @@ func getURL(baseURL, path string, pathParams, queryParams tools.Parameters, defa
 	return parsedURL.String(), nil
 }
 
+// Helper function to look up a header regardless of the case of its name.
+func headerValue(headers map[string]string, name string) (string, bool) {
+	for k, v := range headers {
+		if strings.EqualFold(k, name) {
+			return v, true
+		}
+	}
+	return "", false
+}
+
 // Helper function to generate the HTTP headers upon Tool invocation.
 func getHeaders(headerParams tools.Parameters, defaultHeaders map[string]string, paramsMap map[string]any) (map[string]string, error) {
 	// Populate header params
@@ -246,7 +302,7 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 	paramsMap := params.AsMap()
 
 	// Calculate request body
-	requestBody, err := getRequestBody(t.BodyParams, t.RequestBody, paramsMap)
+	requestBody, err := getRequestBody(t.PathParams, t.BodyParams, t.RequestBody, t.RequestFormat, paramsMap)
 	if err != nil {
 		return nil, fmt.Errorf("error populating request body: %s", err)
 	}
@@ -282,9 +338,16 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 		return nil, err
 	}
 	if resp.StatusCode != http.StatusOK {
+		if fault := soapFault(body); fault != "" {
+			return nil, fmt.Errorf("unexpected status code: %d, SOAP fault: %s", resp.StatusCode, fault)
+		}
 		return nil, fmt.Errorf("unexpected status code: %d, response body: %s", resp.StatusCode, string(body))
 	}
 
+	if t.ResponseXPath != nil {
+		return extractXPath(body, t.ResponseXPath)
+	}
+
 	var data any
 	if err = json.Unmarshal(body, &data); err != nil {
 		// if unable to unmarshal data, return result as string.
@@ -15,12 +15,18 @@
 package http_test
 
 import (
+	"context"
+	"io"
+	nethttp "net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 
 	yaml "github.com/goccy/go-yaml"
 	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	httpsrc "github.com/googleapis/genai-toolbox/internal/sources/http"
 	"github.com/googleapis/genai-toolbox/internal/testutils"
 	"github.com/googleapis/genai-toolbox/internal/tools"
 	http "github.com/googleapis/genai-toolbox/internal/tools/http"
@@ -137,6 +143,52 @@ func TestParseFromYamlHTTP(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "xml example",
+			in: `
+			tools:
+				example_tool:
+					kind: http
+					source: my-instance
+					method: POST
+					path: /CurrencyService
+					description: some description
+					requestFormat: XML
+					requestBody: |
+						<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">
+							<soap:Body><GetRate><Currency>{{.currency}}</Currency></GetRate></soap:Body>
+						</soap:Envelope>
+					bodyParams:
+						- name: currency
+						  type: string
+						  description: currency code
+					headers:
+						SOAPAction: GetRate
+					responseXPath: //c:Rate
+					xmlNamespaces:
+						c: http://example.com/currency
+			`,
+			want: server.ToolConfigs{
+				"example_tool": http.Config{
+					Name:          "example_tool",
+					Kind:          "http",
+					Source:        "my-instance",
+					Method:        "POST",
+					Path:          "/CurrencyService",
+					Description:   "some description",
+					AuthRequired:  []string{},
+					RequestFormat: http.RequestFormatXML,
+					RequestBody: `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">
+  <soap:Body><GetRate><Currency>{{.currency}}</Currency></GetRate></soap:Body>
+</soap:Envelope>
+`,
+					BodyParams:    []tools.Parameter{tools.NewStringParameter("currency", "currency code")},
+					Headers:       map[string]string{"SOAPAction": "GetRate"},
+					ResponseXPath: "//c:Rate",
+					XMLNamespaces: map[string]string{"c": "http://example.com/currency"},
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -210,6 +262,20 @@ func TestFailParseFromYamlHTTP(t *testing.T) {
 			`,
 			err: `GOT is not a valid http method`,
 		},
+		{
+			desc: "Invalid request format",
+			in: `
+			tools:
+				example_tool:
+					kind: http
+					source: my-instance
+					method: POST
+					path: /service
+					description: some description
+					requestFormat: yaml
+			`,
+			err: `yaml is not a valid request format: must be one of "json", or "xml"`,
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -229,3 +295,171 @@ func TestFailParseFromYamlHTTP(t *testing.T) {
 	}
 
 }
+
+func TestInvokeXML(t *testing.T) {
+	const rateResponse = `<?xml version="1.0"?>
+<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">
+  <soap:Body>
+    <GetRateResponse xmlns="http://example.com/currency">
+      <Rate currency="EUR">0.92</Rate>
+      <Rate currency="GBP">0.79</Rate>
+    </GetRateResponse>
+  </soap:Body>
+</soap:Envelope>`
+	const faultResponse = `<?xml version="1.0"?>
+<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">
+  <soap:Body>
+    <soap:Fault><faultcode>soap:Client</faultcode><faultstring>Unknown currency</faultstring></soap:Fault>
+  </soap:Body>
+</soap:Envelope>`
+
+	var gotBody, gotContentType string
+	ts := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		b, _ := io.ReadAll(r.Body)
+		gotBody = string(b)
+		gotContentType = r.Header.Get("Content-Type")
+		if strings.Contains(gotBody, "XXX") {
+			w.WriteHeader(nethttp.StatusInternalServerError)
+			_, _ = w.Write([]byte(faultResponse))
+			return
+		}
+		_, _ = w.Write([]byte(rateResponse))
+	}))
+	defer ts.Close()
+
+	srcs := map[string]sources.Source{
+		"my-instance": &httpsrc.Source{Name: "my-instance", Kind: httpsrc.SourceKind, BaseURL: ts.URL, Client: ts.Client()},
+	}
+	tcs := []struct {
+		desc     string
+		xpath    string
+		currency string
+		wantBody string
+		want     any
+		wantErr  string
+	}{
+		{
+			desc:     "node set",
+			xpath:    "//c:Rate",
+			currency: "EUR",
+			wantBody: "<GetRate><Currency>EUR</Currency></GetRate>",
+			want: []any{
+				map[string]any{"@currency": "EUR", "#text": "0.92"},
+				map[string]any{"@currency": "GBP", "#text": "0.79"},
+			},
+		},
+		{
+			desc:     "text nodes",
+			xpath:    "//c:Rate/text()",
+			currency: "EUR",
+			wantBody: "<GetRate><Currency>EUR</Currency></GetRate>",
+			want:     []any{"0.92", "0.79"},
+		},
+		{
+			desc:     "scalar expression",
+			xpath:    "count(//c:Rate)",
+			currency: "EUR",
+			wantBody: "<GetRate><Currency>EUR</Currency></GetRate>",
+			want:     float64(2),
+		},
+		{
+			desc:     "escaped parameter",
+			xpath:    "//c:Rate[@currency='GBP']/text()",
+			currency: "</Currency><Admin>true</Admin>",
+			wantBody: "<GetRate><Currency>&lt;/Currency&gt;&lt;Admin&gt;true&lt;/Admin&gt;</Currency></GetRate>",
+			want:     []any{"0.79"},
+		},
+		{
+			desc:     "soap fault",
+			xpath:    "//c:Rate",
+			currency: "XXX",
+			wantBody: "<GetRate><Currency>XXX</Currency></GetRate>",
+			wantErr:  "unexpected status code: 500, SOAP fault: Unknown currency",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := http.Config{
+				Name:          "example_tool",
+				Kind:          "http",
+				Source:        "my-instance",
+				Method:        "POST",
+				Description:   "some description",
+				RequestFormat: http.RequestFormatXML,
+				RequestBody:   "<GetRate><Currency>{{.currency}}</Currency></GetRate>",
+				BodyParams:    tools.Parameters{tools.NewStringParameter("currency", "currency code")},
+				ResponseXPath: tc.xpath,
+				XMLNamespaces: map[string]string{"c": "http://example.com/currency"},
+			}
+			tool, err := cfg.Initialize(srcs)
+			if err != nil {
+				t.Fatalf("unable to initialize tool: %s", err)
+			}
+			params, err := tool.ParseParams(map[string]any{"currency": tc.currency}, nil)
+			if err != nil {
+				t.Fatalf("unable to parse params: %s", err)
+			}
+			got, err := tool.Invoke(context.Background(), params)
+			if gotBody != tc.wantBody {
+				t.Fatalf("unexpected request body: got %q, want %q", gotBody, tc.wantBody)
+			}
+			if gotContentType != "text/xml; charset=utf-8" {
+				t.Fatalf("unexpected content type: %q", gotContentType)
+			}
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect result: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInvokeBodyPathParams(t *testing.T) {
+	var gotPath, gotBody string
+	ts := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		b, _ := io.ReadAll(r.Body)
+		gotPath, gotBody = r.URL.Path, string(b)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+
+	srcs := map[string]sources.Source{
+		"my-instance": &httpsrc.Source{Name: "my-instance", Kind: httpsrc.SourceKind, BaseURL: ts.URL, Client: ts.Client()},
+	}
+	cfg := http.Config{
+		Name:        "example_tool",
+		Kind:        "http",
+		Source:      "my-instance",
+		Method:      "POST",
+		Path:        "/projects/{{.projectId}}/clusters",
+		Description: "some description",
+		RequestBody: `{"network": "projects/{{.projectId}}/global/networks/{{.network}}"}`,
+		PathParams:  tools.Parameters{tools.NewStringParameter("projectId", "project id")},
+		BodyParams:  tools.Parameters{tools.NewStringParameter("network", "network name")},
+	}
+	tool, err := cfg.Initialize(srcs)
+	if err != nil {
+		t.Fatalf("unable to initialize tool: %s", err)
+	}
+	params, err := tool.ParseParams(map[string]any{"projectId": "my-project", "network": "default"}, nil)
+	if err != nil {
+		t.Fatalf("unable to parse params: %s", err)
+	}
+	if _, err := tool.Invoke(context.Background(), params); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := "/projects/my-project/clusters"; gotPath != want {
+		t.Fatalf("unexpected path: got %q, want %q", gotPath, want)
+	}
+	if want := `{"network": "projects/my-project/global/networks/default"}`; gotBody != want {
+		t.Fatalf("unexpected request body: got %q, want %q", gotBody, want)
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/antchfx/xpath"
+)
+
+// RequestFormat is the format of the request body of an HTTP tool.
+type RequestFormat string
+
+const (
+	RequestFormatJSON RequestFormat = "json"
+	RequestFormatXML  RequestFormat = "xml"
+)
+
+func (f *RequestFormat) UnmarshalYAML(ctx context.Context, unmarshal func(interface{}) error) error {
+	var format string
+	if err := unmarshal(&format); err != nil {
+		return fmt.Errorf(`error unmarshalling request format: %s`, err)
+	}
+	switch RequestFormat(strings.ToLower(format)) {
+	case RequestFormatJSON, RequestFormatXML:
+		*f = RequestFormat(strings.ToLower(format))
+		return nil
+	}
+	return fmt.Errorf(`%s is not a valid request format: must be one of "json", or "xml"`, format)
+}
+
+// escapeXMLValues returns a copy of the params with every value escaped as
+// XML character data, so parameters can't inject elements into the body.
+// Array values are escaped element-wise so they can be ranged over.
+func escapeXMLValues(params map[string]any) (map[string]any, error) {
+	escaped := make(map[string]any, len(params))
+	for k, v := range params {
+		ev, err := escapeXMLValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("unable to escape parameter %q: %w", k, err)
+		}
+		escaped[k] = ev
+	}
+	return escaped, nil
+}
+
+func escapeXMLValue(v any) (any, error) {
+	switch val := v.(type) {
+	case nil:
+		return "", nil
+	case []any:
+		out := make([]any, 0, len(val))
+		for _, item := range val {
+			ev, err := escapeXMLValue(item)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, ev)
+		}
+		return out, nil
+	default:
+		var b bytes.Buffer
+		if err := xml.EscapeText(&b, []byte(fmt.Sprint(val))); err != nil {
+			return nil, err
+		}
+		return b.String(), nil
+	}
+}
+
+// compileXPath compiles an XPath expression, resolving prefixes with the
+// given namespace bindings.
+func compileXPath(expr string, namespaces map[string]string) (*xpath.Expr, error) {
+	if len(namespaces) == 0 {
+		return xpath.Compile(expr)
+	}
+	return xpath.CompileWithNS(expr, namespaces)
+}
+
+// extractXPath evaluates expr against an XML document. Expressions returning
+// a number, string or boolean (e.g. "count(//item)") return that value, while
+// node sets return a list with one entry per matching node.
+func extractXPath(body []byte, expr *xpath.Expr) (any, error) {
+	doc, err := xmlquery.Parse(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse XML response: %w", err)
+	}
+	result := expr.Evaluate(xmlquery.CreateXPathNavigator(doc))
+	iter, ok := result.(*xpath.NodeIterator)
+	if !ok {
+		return result, nil
+	}
+	values := make([]any, 0)
+	for iter.MoveNext() {
+		node := iter.Current().(*xmlquery.NodeNavigator).Current()
+		values = append(values, xmlNodeToValue(node))
+	}
+	return values, nil
+}
+
+// xmlNodeToValue converts an XML node to a JSON friendly value. Elements with
+// only text become strings, while elements with attributes or child elements
+// become objects keyed by element name, with attributes prefixed by "@" and
+// repeated elements collected in a list.
+func xmlNodeToValue(n *xmlquery.Node) any {
+	switch n.Type {
+	case xmlquery.AttributeNode, xmlquery.TextNode, xmlquery.CharDataNode:
+		return n.InnerText()
+	case xmlquery.DocumentNode:
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type == xmlquery.ElementNode {
+				return map[string]any{c.Data: xmlNodeToValue(c)}
+			}
+		}
+		return nil
+	}
+
+	obj := make(map[string]any)
+	for _, attr := range n.Attr {
+		if attr.Name.Space == "xmlns" || (attr.Name.Space == "" && attr.Name.Local == "xmlns") {
+			continue
+		}
+		obj["@"+attr.Name.Local] = attr.Value
+	}
+	hasChildElements := false
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type != xmlquery.ElementNode {
+			continue
+		}
+		hasChildElements = true
+		v := xmlNodeToValue(c)
+		switch existing := obj[c.Data].(type) {
+		case nil:
+			obj[c.Data] = v
+		case []any:
+			obj[c.Data] = append(existing, v)
+		default:
+			obj[c.Data] = []any{existing, v}
+		}
+	}
+	if !hasChildElements {
+		text := strings.TrimSpace(n.InnerText())
+		if len(obj) == 0 {
+			return text
+		}
+		if text != "" {
+			obj["#text"] = text
+		}
+	}
+	return obj
+}
+
+var soapFaultExpr = xpath.MustCompile(`//*[local-name()='Fault']//*[local-name()='faultstring' or local-name()='Text']`)
+
+// soapFault returns the fault string of a SOAP 1.1 or 1.2 fault response, or
+// an empty string if body isn't a SOAP fault.
+func soapFault(body []byte) string {
+	doc, err := xmlquery.Parse(bytes.NewReader(body))
+	if err != nil {
+		return ""
+	}
+	node := xmlquery.QuerySelector(doc, soapFaultExpr)
+	if node == nil {
+		return ""
+	}
+	return strings.TrimSpace(node.InnerText())
+}
@@ -0,0 +1,111 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Dialect determines how identifiers are quoted in a SQL statement, and how
+// statements are lexed by CheckReadOnly.
+type Dialect string
+
+const (
+	// DialectANSI quotes identifiers with double quotes, as used by
+	// PostgreSQL, SQLite, DuckDB and Spanner's PostgreSQL dialect.
+	DialectANSI Dialect = "ansi"
+	// DialectMySQL quotes identifiers with backticks, as used by MySQL,
+	// TiDB, OceanBase and Hive.
+	DialectMySQL Dialect = "mysql"
+	// DialectMSSQL quotes identifiers with square brackets, as used by
+	// SQL Server.
+	DialectMSSQL Dialect = "mssql"
+	// DialectGoogleSQL quotes identifiers with backticks and escapes with a
+	// backslash, as used by BigQuery, Spanner, Bigtable and ClickHouse.
+	DialectGoogleSQL Dialect = "googlesql"
+	// DialectSnowflake quotes identifiers with double quotes, and escapes
+	// strings with backslashes, as used by Snowflake.
+	DialectSnowflake Dialect = "snowflake"
+)
+
+// QuoteIdentifier quotes a single identifier, such as a table or column name,
+// so that it can't be used to inject SQL. Dots are quoted as part of the name;
+// use QuoteQualifiedIdentifier for names such as schema.table.
+func QuoteIdentifier(dialect Dialect, name string) (string, error) {
+	if name == "" {
+		return "", fmt.Errorf("identifier must not be empty")
+	}
+	if strings.ContainsRune(name, 0) {
+		return "", fmt.Errorf("identifier %q must not contain a null character", name)
+	}
+	switch dialect {
+	case DialectANSI, DialectSnowflake:
+		return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`, nil
+	case DialectMySQL:
+		return "`" + strings.ReplaceAll(name, "`", "``") + "`", nil
+	case DialectMSSQL:
+		return "[" + strings.ReplaceAll(name, "]", "]]") + "]", nil
+	case DialectGoogleSQL:
+		return "`" + googleSQLIdentifierEscaper.Replace(name) + "`", nil
+	case "":
+		return "", fmt.Errorf("identifier quoting is not supported by this tool")
+	default:
+		return "", fmt.Errorf("unknown dialect %q", dialect)
+	}
+}
+
+var googleSQLIdentifierEscaper = strings.NewReplacer(`\`, `\\`, "`", "\\`")
+
+// QuoteQualifiedIdentifier splits name on dots and quotes each part, so
+// "sales.orders" becomes a reference to the orders table in the sales schema.
+func QuoteQualifiedIdentifier(dialect Dialect, name string) (string, error) {
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		quoted, err := QuoteIdentifier(dialect, part)
+		if err != nil {
+			return "", err
+		}
+		parts[i] = quoted
+	}
+	return strings.Join(parts, "."), nil
+}
+
+// identTemplateFunc returns the "ident" template function, which quotes a
+// string, or each string in an array joined with commas, as identifiers.
+func identTemplateFunc(dialect Dialect) func(any) (string, error) {
+	return func(v any) (string, error) {
+		switch v := v.(type) {
+		case string:
+			return QuoteQualifiedIdentifier(dialect, v)
+		case []any:
+			quoted := make([]string, 0, len(v))
+			for _, item := range v {
+				s, ok := item.(string)
+				if !ok {
+					return "", fmt.Errorf("ident only supports strings and string arrays")
+				}
+				q, err := QuoteQualifiedIdentifier(dialect, s)
+				if err != nil {
+					return "", err
+				}
+				quoted = append(quoted, q)
+			}
+			return strings.Join(quoted, ", "), nil
+		default:
+			return "", fmt.Errorf("ident only supports strings and string arrays")
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"testing"
+
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+func TestQuoteIdentifier(t *testing.T) {
+	tcs := []struct {
+		desc    string
+		dialect tools.Dialect
+		in      string
+		want    string
+	}{
+		{desc: "ansi", dialect: tools.DialectANSI, in: "users", want: `"users"`},
+		{desc: "ansi escape", dialect: tools.DialectANSI, in: `a"; DROP TABLE x; --`, want: `"a""; DROP TABLE x; --"`},
+		{desc: "mysql escape", dialect: tools.DialectMySQL, in: "a`b", want: "`a``b`"},
+		{desc: "mssql escape", dialect: tools.DialectMSSQL, in: "a]b", want: "[a]]b]"},
+		{desc: "googlesql escape", dialect: tools.DialectGoogleSQL, in: "a`b\\c", want: "`a\\`b\\\\c`"},
+		{desc: "snowflake escape", dialect: tools.DialectSnowflake, in: `a"b`, want: `"a""b"`},
+		{desc: "dots are part of the name", dialect: tools.DialectANSI, in: "a.b", want: `"a.b"`},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := tools.QuoteIdentifier(tc.dialect, tc.in)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tc.want {
+				t.Fatalf("incorrect quoting: got %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestQuoteQualifiedIdentifier(t *testing.T) {
+	got, err := tools.QuoteQualifiedIdentifier(tools.DialectMSSQL, "dbo.orders")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := "[dbo].[orders]"; got != want {
+		t.Fatalf("incorrect quoting: got %s, want %s", got, want)
+	}
+}
+
+func TestFailQuoteIdentifier(t *testing.T) {
+	tcs := []struct {
+		desc    string
+		dialect tools.Dialect
+		in      string
+		err     string
+	}{
+		{desc: "empty", dialect: tools.DialectANSI, in: "", err: "identifier must not be empty"},
+		{desc: "null character", dialect: tools.DialectANSI, in: "a\x00b", err: `identifier "a\x00b" must not contain a null character`},
+		{desc: "no dialect", in: "users", err: "identifier quoting is not supported by this tool"},
+		{desc: "unknown dialect", dialect: "oracle", in: "users", err: `unknown dialect "oracle"`},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := tools.QuoteIdentifier(tc.dialect, tc.in)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if err.Error() != tc.err {
+				t.Fatalf("unexpected error: got %q, want %q", err, tc.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,256 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package influxdbflux
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/influxdb"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+)
+
+const kind string = "influxdb-flux"
+const startKey string = "start"
+const stopKey string = "stop"
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	InfluxDBClient() influxdb2.Client
+	InfluxDBOrg() string
+}
+
+// validate compatible sources are still compatible
+var _ compatibleSource = &influxdb.Source{}
+
+var compatibleSources = [...]string{influxdb.SourceKind}
+
+type Config struct {
+	Name         string           `yaml:"name" validate:"required"`
+	Kind         string           `yaml:"kind" validate:"required"`
+	Source       string           `yaml:"source" validate:"required"`
+	Description  string           `yaml:"description" validate:"required"`
+	Statement    string           `yaml:"statement" validate:"required"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(compatibleSource)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
+	}
+
+	// the time range is always bound, so it can't be redefined by the user
+	for _, p := range cfg.Parameters {
+		if p.GetName() == startKey || p.GetName() == stopKey {
+			return nil, fmt.Errorf("parameter name %q is reserved for the time range", p.GetName())
+		}
+	}
+	startParameter := tools.NewStringParameter(startKey, "The start of the time range, as an RFC 3339 datetime (e.g. 2025-01-01T00:00:00Z).")
+	stopParameter := tools.NewStringParameterWithDefault(stopKey, "", "The end of the time range, as an RFC 3339 datetime (e.g. 2025-01-02T00:00:00Z). Defaults to now.")
+	allParameters := append(tools.Parameters{startParameter, stopParameter}, cfg.Parameters...)
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: allParameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   cfg.Parameters,
+		AllParams:    allParameters,
+		Statement:    cfg.Statement,
+		AuthRequired: cfg.AuthRequired,
+		Client:       s.InfluxDBClient(),
+		Org:          s.InfluxDBOrg(),
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: allParameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:  mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+	AllParams    tools.Parameters `yaml:"allParams"`
+
+	Client      influxdb2.Client
+	Org         string
+	Statement   string
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	paramsMap := params.AsMap()
+	start, err := parseDatetime(startKey, paramsMap[startKey])
+	if err != nil {
+		return nil, err
+	}
+	stop := time.Now().UTC()
+	if s, ok := paramsMap[stopKey].(string); ok && s != "" {
+		stop, err = parseDatetime(stopKey, s)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !start.Before(stop) {
+		return nil, fmt.Errorf("parameter %q must be before %q", startKey, stopKey)
+	}
+
+	newParams, err := tools.GetParams(t.Parameters, paramsMap)
+	if err != nil {
+		return nil, fmt.Errorf("unable to extract standard params %w", err)
+	}
+	values := append(tools.ParamValues{{Name: startKey, Value: start}, {Name: stopKey, Value: stop}}, newParams...)
+	query, err := BuildQuery(t.Statement, values)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := t.Client.QueryAPI(t.Org).Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("unable to execute query: %w", err)
+	}
+	defer result.Close()
+
+	var out []any
+	for result.Next() {
+		out = append(out, result.Record().Values())
+	}
+	if err := result.Err(); err != nil {
+		return nil, fmt.Errorf("unable to parse row: %w", err)
+	}
+	return out, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.AllParams, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
+
+func parseDatetime(name string, v any) (time.Time, error) {
+	s, ok := v.(string)
+	if !ok {
+		return time.Time{}, fmt.Errorf("parameter %q must be a string", name)
+	}
+	d, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parameter %q must be an RFC 3339 datetime: %w", name, err)
+	}
+	return d.UTC(), nil
+}
+
+// BuildQuery binds the parameter values to the `params` record used by the
+// statement, e.g. `range(start: params.start, stop: params.stop)`. Values are
+// encoded as Flux literals so they can't change the structure of the query.
+func BuildQuery(statement string, values tools.ParamValues) (string, error) {
+	fields := make([]string, 0, len(values))
+	for _, p := range values {
+		lit, err := fluxLiteral(p.Value)
+		if err != nil {
+			return "", fmt.Errorf("unable to bind parameter %q: %w", p.Name, err)
+		}
+		fields = append(fields, fmt.Sprintf("%s: %s", p.Name, lit))
+	}
+	return fmt.Sprintf("params = {%s}\n\n%s", strings.Join(fields, ", "), statement), nil
+}
+
+var fluxStringEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "${", `\${`)
+
+func fluxLiteral(v any) (string, error) {
+	switch v := v.(type) {
+	case string:
+		return `"` + fluxStringEscaper.Replace(v) + `"`, nil
+	case int:
+		return strconv.Itoa(v), nil
+	case float64:
+		s := strconv.FormatFloat(v, 'f', -1, 64)
+		// float literals in Flux require a decimal point
+		if !strings.Contains(s, ".") {
+			s += ".0"
+		}
+		return s, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	case []any:
+		elems := make([]string, 0, len(v))
+		for _, e := range v {
+			lit, err := fluxLiteral(e)
+			if err != nil {
+				return "", err
+			}
+			elems = append(elems, lit)
+		}
+		return "[" + strings.Join(elems, ", ") + "]", nil
+	default:
+		return "", fmt.Errorf("unsupported type %T", v)
+	}
+}
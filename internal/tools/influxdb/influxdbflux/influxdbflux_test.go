@@ -0,0 +1,141 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package influxdbflux_test
+
+import (
+	"testing"
+	"time"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/influxdb/influxdbflux"
+)
+
+func TestParseFromYamlInfluxDBFlux(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: influxdb-flux
+					source: my-influxdb-instance
+					description: some description
+					statement: |
+						from(bucket: "metrics")
+							|> range(start: params.start, stop: params.stop)
+							|> filter(fn: (r) => r.host == params.host)
+					authRequired:
+						- my-google-auth-service
+					parameters:
+						- name: host
+						  type: string
+						  description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": influxdbflux.Config{
+					Name:         "example_tool",
+					Kind:         "influxdb-flux",
+					Source:       "my-influxdb-instance",
+					Description:  "some description",
+					Statement:    "from(bucket: \"metrics\")\n  |> range(start: params.start, stop: params.stop)\n  |> filter(fn: (r) => r.host == params.host)\n",
+					AuthRequired: []string{"my-google-auth-service"},
+					Parameters: []tools.Parameter{
+						tools.NewStringParameter("host", "some description"),
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestBuildQuery(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	tcs := []struct {
+		desc   string
+		values tools.ParamValues
+		want   string
+	}{
+		{
+			desc: "time range",
+			values: tools.ParamValues{
+				{Name: "start", Value: start},
+				{Name: "stop", Value: start.Add(90 * time.Minute)},
+			},
+			want: "params = {start: 2025-01-01T00:00:00Z, stop: 2025-01-01T01:30:00Z}\n\nSTATEMENT",
+		},
+		{
+			desc: "scalar values",
+			values: tools.ParamValues{
+				{Name: "limit", Value: 10},
+				{Name: "threshold", Value: float64(2)},
+				{Name: "ratio", Value: 0.5},
+				{Name: "desc", Value: true},
+			},
+			want: "params = {limit: 10, threshold: 2.0, ratio: 0.5, desc: true}\n\nSTATEMENT",
+		},
+		{
+			desc: "strings are escaped",
+			values: tools.ParamValues{
+				{Name: "host", Value: `a"} |> drop() ${x} \`},
+			},
+			want: "params = {host: \"a\\\"} |> drop() \\${x} \\\\\"}\n\nSTATEMENT",
+		},
+		{
+			desc: "array",
+			values: tools.ParamValues{
+				{Name: "hosts", Value: []any{"a", "b"}},
+			},
+			want: "params = {hosts: [\"a\", \"b\"]}\n\nSTATEMENT",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := influxdbflux.BuildQuery("STATEMENT", tc.values)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect query: diff %v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"net/http"
+)
+
+// InvocationContext describes the request a tool is invoked for, so that tools
+// can log, tag queries, or enforce limits with awareness of the caller.
+type InvocationContext struct {
+	// RequestID identifies the request, from its X-Request-Id header if the
+	// client sent one.
+	RequestID string
+	// SessionID is the ID of the MCP session of the request, if any.
+	SessionID string
+	// ToolName is the name of the tool invoked.
+	ToolName string
+	// Caller identifies the caller by the email, or else the subject, verified
+	// by the first of AuthServices that has one. It is empty for anonymous
+	// callers.
+	Caller string
+	// AuthServices are the names of the auth services that verified the
+	// caller, in order.
+	AuthServices []string
+	// Claims are the claims verified by each of AuthServices.
+	Claims map[string]map[string]any
+	// Client is the MCP client, as it described itself when initializing the
+	// session.
+	Client ClientInfo
+	// Headers are the headers of the request the server is configured to pass
+	// to tools.
+	Headers http.Header
+}
+
+// ClientInfo is the name and version of an MCP client.
+type ClientInfo struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type invocationContextKey struct{}
+
+// WithInvocationContext adds the context of a tool invocation to ctx.
+func WithInvocationContext(ctx context.Context, ic InvocationContext) context.Context {
+	return context.WithValue(ctx, invocationContextKey{}, ic)
+}
+
+// InvocationContextFromContext returns the context of the tool invocation of
+// ctx, or a zero InvocationContext if there is none.
+func InvocationContextFromContext(ctx context.Context) InvocationContext {
+	ic, _ := ctx.Value(invocationContextKey{}).(InvocationContext)
+	return ic
+}
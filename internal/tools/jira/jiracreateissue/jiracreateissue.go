@@ -0,0 +1,190 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jiracreateissue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	jirads "github.com/googleapis/genai-toolbox/internal/sources/jira"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+const kind string = "jira-create-issue"
+const summaryKey string = "summary"
+const descriptionKey string = "description"
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	JiraBaseURL() string
+	JiraRequest(ctx context.Context, method, path string, body any) ([]byte, error)
+}
+
+// validate compatible sources are still compatible
+var _ compatibleSource = &jirads.Source{}
+
+var compatibleSources = [...]string{jirads.SourceKind}
+
+type Config struct {
+	Name         string   `yaml:"name" validate:"required"`
+	Kind         string   `yaml:"kind" validate:"required"`
+	Source       string   `yaml:"source" validate:"required"`
+	Description  string   `yaml:"description" validate:"required"`
+	AuthRequired []string `yaml:"authRequired"`
+	Project      string   `yaml:"project" validate:"required"`
+	IssueType    string   `yaml:"issueType"`
+	Labels       []string `yaml:"labels"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(compatibleSource)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
+	}
+
+	issueType := cfg.IssueType
+	if issueType == "" {
+		issueType = "Task"
+	}
+
+	summaryParameter := tools.NewStringParameter(summaryKey, "A one line summary of the issue.")
+	descriptionParameter := tools.NewStringParameterWithDefault(descriptionKey, "", "A detailed description of the issue, such as the queries that were run and their results.")
+	parameters := tools.Parameters{summaryParameter, descriptionParameter}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   parameters,
+		AuthRequired: cfg.AuthRequired,
+		Project:      cfg.Project,
+		IssueType:    issueType,
+		Labels:       cfg.Labels,
+		Source:       s,
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:  mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+	Project      string           `yaml:"project"`
+	IssueType    string           `yaml:"issueType"`
+	Labels       []string         `yaml:"labels"`
+
+	Source      compatibleSource
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	mapParams := params.AsMap()
+	summary, ok := mapParams[summaryKey].(string)
+	if !ok || summary == "" {
+		return nil, fmt.Errorf("invalid or missing '%s' parameter; expected a non-empty string", summaryKey)
+	}
+	description, _ := mapParams[descriptionKey].(string)
+
+	fields := map[string]any{
+		"project":   map[string]any{"key": t.Project},
+		"issuetype": map[string]any{"name": t.IssueType},
+		"summary":   summary,
+	}
+	// version 2 of the API accepts descriptions as plain text rather than
+	// Atlassian Document Format
+	if description != "" {
+		fields["description"] = description
+	}
+	if len(t.Labels) > 0 {
+		fields["labels"] = t.Labels
+	}
+
+	respBody, err := t.Source.JiraRequest(ctx, http.MethodPost, "/rest/api/2/issue", map[string]any{"fields": fields})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create issue: %w", err)
+	}
+	var resp struct {
+		ID  string `json:"id"`
+		Key string `json:"key"`
+	}
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return map[string]any{
+		"id":  resp.ID,
+		"key": resp.Key,
+		"url": t.Source.JiraBaseURL() + "/browse/" + resp.Key,
+	}, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
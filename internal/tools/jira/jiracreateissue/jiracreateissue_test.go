@@ -0,0 +1,138 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jiracreateissue_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	jirads "github.com/googleapis/genai-toolbox/internal/sources/jira"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools/jira/jiracreateissue"
+)
+
+func TestParseFromYamlJiraCreateIssue(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: jira-create-issue
+					source: my-jira
+					description: some description
+					project: OPS
+					issueType: Bug
+					labels:
+						- data-quality
+			`,
+			want: server.ToolConfigs{
+				"example_tool": jiracreateissue.Config{
+					Name:         "example_tool",
+					Kind:         "jira-create-issue",
+					Source:       "my-jira",
+					Description:  "some description",
+					AuthRequired: []string{},
+					Project:      "OPS",
+					IssueType:    "Bug",
+					Labels:       []string{"data-quality"},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInvoke(t *testing.T) {
+	var gotPath, gotUser, gotPassword string
+	var gotBody map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotUser, gotPassword, _ = r.BasicAuth()
+		if err := json.NewDecoder(r.Body).Decode(&gotBody); err != nil {
+			t.Errorf("unable to decode request: %s", err)
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id": "10001", "key": "OPS-42", "self": "ignored"}`))
+	}))
+	defer srv.Close()
+
+	srcs := map[string]sources.Source{
+		"my-jira": &jirads.Source{Name: "my-jira", Kind: jirads.SourceKind, BaseURL: srv.URL, Email: "bot@example.com", APIToken: "token", Client: srv.Client()},
+	}
+	cfg := jiracreateissue.Config{Name: "example_tool", Kind: "jira-create-issue", Source: "my-jira", Description: "some description", Project: "OPS"}
+	tool, err := cfg.Initialize(srcs)
+	if err != nil {
+		t.Fatalf("unable to initialize tool: %s", err)
+	}
+	params, err := tool.ParseParams(map[string]any{"summary": "orders has duplicate rows"}, nil)
+	if err != nil {
+		t.Fatalf("unable to parse params: %s", err)
+	}
+	got, err := tool.Invoke(context.Background(), params)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if gotPath != "/rest/api/2/issue" {
+		t.Errorf("incorrect path: %q", gotPath)
+	}
+	if gotUser != "bot@example.com" || gotPassword != "token" {
+		t.Errorf("incorrect credentials: %q, %q", gotUser, gotPassword)
+	}
+	wantBody := map[string]any{
+		"fields": map[string]any{
+			"project":   map[string]any{"key": "OPS"},
+			"issuetype": map[string]any{"name": "Task"},
+			"summary":   "orders has duplicate rows",
+		},
+	}
+	if diff := cmp.Diff(wantBody, gotBody); diff != "" {
+		t.Errorf("incorrect request: diff %v", diff)
+	}
+	want := map[string]any{"id": "10001", "key": "OPS-42", "url": srv.URL + "/browse/OPS-42"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("incorrect result: diff %v", diff)
+	}
+}
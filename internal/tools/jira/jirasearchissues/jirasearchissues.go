@@ -0,0 +1,200 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jirasearchissues
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	jirads "github.com/googleapis/genai-toolbox/internal/sources/jira"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+const kind string = "jira-search-issues"
+const jqlKey string = "jql"
+const maxResultsKey string = "maxResults"
+const pageTokenKey string = "pageToken"
+
+// defaultFields are the issue fields returned when fields is not configured.
+var defaultFields = []string{"summary", "status", "issuetype", "priority", "assignee", "reporter", "created", "updated"}
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	JiraBaseURL() string
+	JiraRequest(ctx context.Context, method, path string, body any) ([]byte, error)
+}
+
+// validate compatible sources are still compatible
+var _ compatibleSource = &jirads.Source{}
+
+var compatibleSources = [...]string{jirads.SourceKind}
+
+type Config struct {
+	Name         string   `yaml:"name" validate:"required"`
+	Kind         string   `yaml:"kind" validate:"required"`
+	Source       string   `yaml:"source" validate:"required"`
+	Description  string   `yaml:"description" validate:"required"`
+	AuthRequired []string `yaml:"authRequired"`
+	Fields       []string `yaml:"fields"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(compatibleSource)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
+	}
+
+	fields := cfg.Fields
+	if len(fields) == 0 {
+		fields = defaultFields
+	}
+
+	jqlParameter := tools.NewStringParameter(jqlKey, "The JQL query used to search for issues, for example `project = OPS AND status != Done ORDER BY created DESC`.")
+	maxResultsParameter := tools.NewIntParameterWithDefault(maxResultsKey, 20, "The maximum number of issues to return.")
+	pageTokenParameter := tools.NewStringParameterWithDefault(pageTokenKey, "", "The nextPageToken returned by a previous call, used to fetch the next page of results.")
+	parameters := tools.Parameters{jqlParameter, maxResultsParameter, pageTokenParameter}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   parameters,
+		AuthRequired: cfg.AuthRequired,
+		Fields:       fields,
+		Source:       s,
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:  mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+	Fields       []string         `yaml:"fields"`
+
+	Source      compatibleSource
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	mapParams := params.AsMap()
+	jql, ok := mapParams[jqlKey].(string)
+	if !ok || jql == "" {
+		return nil, fmt.Errorf("invalid or missing '%s' parameter; expected a non-empty string", jqlKey)
+	}
+	maxResults, _ := mapParams[maxResultsKey].(int)
+	pageToken, _ := mapParams[pageTokenKey].(string)
+
+	body := map[string]any{
+		"jql":        jql,
+		"maxResults": maxResults,
+		"fields":     t.Fields,
+	}
+	if pageToken != "" {
+		body["nextPageToken"] = pageToken
+	}
+	respBody, err := t.Source.JiraRequest(ctx, http.MethodPost, "/rest/api/3/search/jql", body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search issues: %w", err)
+	}
+
+	var resp struct {
+		Issues []struct {
+			Key    string         `json:"key"`
+			Fields map[string]any `json:"fields"`
+		} `json:"issues"`
+		NextPageToken string `json:"nextPageToken"`
+	}
+	decoder := json.NewDecoder(bytes.NewReader(respBody))
+	decoder.UseNumber()
+	if err := decoder.Decode(&resp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	issues := make([]any, 0, len(resp.Issues))
+	for _, issue := range resp.Issues {
+		issues = append(issues, map[string]any{
+			"key":    issue.Key,
+			"url":    t.Source.JiraBaseURL() + "/browse/" + issue.Key,
+			"fields": issue.Fields,
+		})
+	}
+	result := map[string]any{"issues": issues}
+	if resp.NextPageToken != "" {
+		result["nextPageToken"] = resp.NextPageToken
+	}
+	return result, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
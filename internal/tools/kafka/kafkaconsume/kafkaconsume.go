@@ -0,0 +1,445 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaconsume
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	kafkads "github.com/googleapis/genai-toolbox/internal/sources/kafka"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/segmentio/kafka-go"
+)
+
+const kind string = "kafka-consume"
+
+const maxMessagesKey string = "maxMessages"
+
+// Positions the messages are read from.
+const (
+	StartFromLatest   = "latest"
+	StartFromEarliest = "earliest"
+)
+
+// fetchMaxBytes bounds the size of the records returned by a fetch.
+const fetchMaxBytes = 1 << 20
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name, MaxMessages: 10, Timeout: "5s", StartFrom: StartFromLatest} // Defaults
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	KafkaClient() *kafka.Client
+}
+
+// validate compatible sources are still compatible
+var _ compatibleSource = &kafkads.Source{}
+
+var compatibleSources = [...]string{kafkads.SourceKind}
+
+type Config struct {
+	Name        string `yaml:"name" validate:"required"`
+	Kind        string `yaml:"kind" validate:"required"`
+	Source      string `yaml:"source" validate:"required"`
+	Description string `yaml:"description" validate:"required"`
+	Topic       string `yaml:"topic" validate:"required"`
+	// MaxMessages is the maximum number of messages returned by an
+	// invocation, which can ask for fewer.
+	MaxMessages int `yaml:"maxMessages" validate:"gte=1"`
+	// Timeout bounds the time spent reading the topic.
+	Timeout string `yaml:"timeout"`
+	// StartFrom is either "latest", to read the most recent messages of the
+	// topic, or "earliest", to read its oldest messages.
+	StartFrom    string   `yaml:"startFrom" validate:"oneof=latest earliest"`
+	AuthRequired []string `yaml:"authRequired"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(compatibleSource)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
+	}
+
+	timeout, err := time.ParseDuration(cfg.Timeout)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse Timeout string as time.Duration: %s", err)
+	}
+
+	// the bounds of the parameter are part of its schema, and checked when
+	// the parameters are parsed
+	minMessages, maxMessages := 1, cfg.MaxMessages
+	maxMessagesParameter := tools.NewIntParameterWithDefault(maxMessagesKey, cfg.MaxMessages, fmt.Sprintf("The number of messages to read, at most %d.", cfg.MaxMessages))
+	maxMessagesParameter.Minimum = &minMessages
+	maxMessagesParameter.Maximum = &maxMessages
+	parameters := tools.Parameters{maxMessagesParameter}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   parameters,
+		AuthRequired: cfg.AuthRequired,
+		Topic:        cfg.Topic,
+		MaxMessages:  cfg.MaxMessages,
+		Timeout:      timeout,
+		StartFrom:    cfg.StartFrom,
+		Client:       s.KafkaClient(),
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:  mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+
+	Topic       string
+	MaxMessages int
+	Timeout     time.Duration
+	StartFrom   string
+	Client      *kafka.Client
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+// Invoke reads the messages of the topic without joining a consumer group,
+// so that no offset is committed and invocations don't affect the consumers
+// of the topic. Up to maxMessages messages are read from each partition, and
+// the oldest or most recent of them are returned, ordered by timestamp.
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	maxMessages, ok := params.AsMap()[maxMessagesKey].(int)
+	if !ok {
+		return nil, fmt.Errorf("invalid or missing '%s' parameter; expected an integer", maxMessagesKey)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, t.Timeout)
+	defer cancel()
+
+	ranges, err := t.offsetRanges(ctx, maxMessages)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		messages []message
+		errs     []error
+	)
+	for _, r := range ranges {
+		wg.Add(1)
+		go func(r offsetRange) {
+			defer wg.Done()
+			partitionMessages, err := t.fetch(ctx, r)
+			mu.Lock()
+			defer mu.Unlock()
+			messages = append(messages, partitionMessages...)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("unable to read partition %d: %w", r.partition, err))
+			}
+		}(r)
+	}
+	wg.Wait()
+	if ctx.Err() != nil {
+		return nil, fmt.Errorf("reading topic %q timed out after %s", t.Topic, t.Timeout)
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(messages, func(i, j int) bool {
+		a, b := messages[i], messages[j]
+		if !a.time.Equal(b.time) {
+			return a.time.Before(b.time)
+		}
+		if a.partition != b.partition {
+			return a.partition < b.partition
+		}
+		return a.offset < b.offset
+	})
+	if len(messages) > maxMessages {
+		if t.StartFrom == StartFromEarliest {
+			messages = messages[:maxMessages]
+		} else {
+			messages = messages[len(messages)-maxMessages:]
+		}
+	}
+
+	out := make([]any, 0, len(messages))
+	for _, m := range messages {
+		out = append(out, m.result())
+	}
+	return out, nil
+}
+
+// offsetRange is the range of offsets read from a partition, end excluded.
+type offsetRange struct {
+	partition  int
+	start, end int64
+}
+
+// offsetRanges returns the offsets of the messages read from each partition
+// of the topic, which are the first or last maxMessages messages of the
+// partition.
+func (t Tool) offsetRanges(ctx context.Context, maxMessages int) ([]offsetRange, error) {
+	meta, err := t.Client.Metadata(ctx, &kafka.MetadataRequest{Topics: []string{t.Topic}})
+	if err != nil {
+		return nil, fmt.Errorf("unable to read metadata of topic %q: %w", t.Topic, err)
+	}
+	var partitions []int
+	for _, topic := range meta.Topics {
+		if topic.Name != t.Topic {
+			continue
+		}
+		if topic.Error != nil {
+			return nil, fmt.Errorf("unable to read metadata of topic %q: %w", t.Topic, topic.Error)
+		}
+		for _, p := range topic.Partitions {
+			partitions = append(partitions, p.ID)
+		}
+	}
+	if len(partitions) == 0 {
+		return nil, fmt.Errorf("topic %q has no partitions", t.Topic)
+	}
+
+	// a partition can't be listed twice in a request, so the first and last
+	// offsets are listed separately
+	first, err := t.listOffsets(ctx, partitions, kafka.FirstOffset)
+	if err != nil {
+		return nil, err
+	}
+	last, err := t.listOffsets(ctx, partitions, kafka.LastOffset)
+	if err != nil {
+		return nil, err
+	}
+
+	ranges := make([]offsetRange, 0, len(partitions))
+	for _, p := range partitions {
+		r := offsetRange{partition: p, start: first[p], end: last[p]}
+		if r.end-r.start > int64(maxMessages) {
+			if t.StartFrom == StartFromEarliest {
+				r.end = r.start + int64(maxMessages)
+			} else {
+				r.start = r.end - int64(maxMessages)
+			}
+		}
+		if r.start < r.end {
+			ranges = append(ranges, r)
+		}
+	}
+	return ranges, nil
+}
+
+// listOffsets returns the first or last offset of each partition, the last
+// offset being the offset of the next message written to the partition.
+func (t Tool) listOffsets(ctx context.Context, partitions []int, timestamp int64) (map[int]int64, error) {
+	requests := make([]kafka.OffsetRequest, 0, len(partitions))
+	for _, p := range partitions {
+		requests = append(requests, kafka.OffsetRequest{Partition: p, Timestamp: timestamp})
+	}
+	res, err := t.Client.ListOffsets(ctx, &kafka.ListOffsetsRequest{Topics: map[string][]kafka.OffsetRequest{t.Topic: requests}})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list offsets of topic %q: %w", t.Topic, err)
+	}
+	offsets := make(map[int]int64, len(partitions))
+	for _, p := range res.Topics[t.Topic] {
+		if p.Error != nil {
+			return nil, fmt.Errorf("unable to list offsets of partition %d: %w", p.Partition, p.Error)
+		}
+		if timestamp == kafka.FirstOffset {
+			offsets[p.Partition] = p.FirstOffset
+		} else {
+			offsets[p.Partition] = p.LastOffset
+		}
+	}
+	return offsets, nil
+}
+
+// fetch reads the messages of a range of offsets of a partition. Fetches
+// return whole batches of records, which can start before the requested
+// offset.
+func (t Tool) fetch(ctx context.Context, r offsetRange) ([]message, error) {
+	var messages []message
+	offset := r.start
+	for offset < r.end {
+		res, err := t.Client.Fetch(ctx, &kafka.FetchRequest{
+			Topic:     t.Topic,
+			Partition: r.partition,
+			Offset:    offset,
+			MinBytes:  1,
+			MaxBytes:  fetchMaxBytes,
+		})
+		if err != nil {
+			return messages, err
+		}
+		if res.Error != nil {
+			return messages, res.Error
+		}
+
+		next := offset
+		for {
+			rec, err := res.Records.ReadRecord()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return messages, err
+			}
+			if rec.Offset < offset {
+				continue
+			}
+			if rec.Offset >= r.end {
+				break
+			}
+			m, err := newMessage(r.partition, rec)
+			if err != nil {
+				return messages, err
+			}
+			messages = append(messages, m)
+			next = rec.Offset + 1
+		}
+		if next == offset {
+			// the remaining offsets were removed, e.g. by compaction
+			break
+		}
+		offset = next
+	}
+	return messages, nil
+}
+
+type message struct {
+	partition int
+	offset    int64
+	time      time.Time
+	key       []byte
+	value     []byte
+	headers   []kafka.Header
+}
+
+func newMessage(partition int, rec *kafka.Record) (message, error) {
+	key, err := kafka.ReadAll(rec.Key)
+	if err != nil {
+		return message{}, fmt.Errorf("unable to read key of offset %d: %w", rec.Offset, err)
+	}
+	value, err := kafka.ReadAll(rec.Value)
+	if err != nil {
+		return message{}, fmt.Errorf("unable to read value of offset %d: %w", rec.Offset, err)
+	}
+	headers := make([]kafka.Header, len(rec.Headers))
+	// the headers of a record can be reused by the next one
+	copy(headers, rec.Headers)
+	return message{
+		partition: partition,
+		offset:    rec.Offset,
+		time:      rec.Time.UTC(),
+		key:       key,
+		value:     value,
+		headers:   headers,
+	}, nil
+}
+
+// result returns the message as a map. Values that are JSON are decoded,
+// and keys, values and headers that aren't text are returned as bytes.
+func (m message) result() map[string]any {
+	var value any = decodeBytes(m.value)
+	if json.Valid(m.value) {
+		_ = json.Unmarshal(m.value, &value)
+	}
+	headers := make(map[string]any, len(m.headers))
+	for _, h := range m.headers {
+		headers[h.Key] = decodeBytes(h.Value)
+	}
+	return map[string]any{
+		"partition": m.partition,
+		"offset":    m.offset,
+		"timestamp": m.time,
+		"key":       decodeBytes(m.key),
+		"value":     value,
+		"headers":   headers,
+	}
+}
+
+// decodeBytes returns b as a string if it is valid UTF-8, and nil if it is
+// nil.
+func decodeBytes(b []byte) any {
+	if b == nil {
+		return nil
+	}
+	if utf8.Valid(b) {
+		return string(b)
+	}
+	return b
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
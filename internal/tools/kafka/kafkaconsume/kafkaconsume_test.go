@@ -0,0 +1,193 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaconsume_test
+
+import (
+	"strings"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	kafkads "github.com/googleapis/genai-toolbox/internal/sources/kafka"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools/kafka/kafkaconsume"
+)
+
+func TestParseFromYamlKafkaConsume(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: kafka-consume
+					source: my-kafka
+					description: some description
+					topic: orders
+			`,
+			want: server.ToolConfigs{
+				"example_tool": kafkaconsume.Config{
+					Name:         "example_tool",
+					Kind:         "kafka-consume",
+					Source:       "my-kafka",
+					Description:  "some description",
+					Topic:        "orders",
+					MaxMessages:  10,
+					Timeout:      "5s",
+					StartFrom:    "latest",
+					AuthRequired: []string{},
+				},
+			},
+		},
+		{
+			desc: "with bounds",
+			in: `
+			tools:
+				example_tool:
+					kind: kafka-consume
+					source: my-kafka
+					description: some description
+					topic: orders
+					maxMessages: 100
+					timeout: 30s
+					startFrom: earliest
+			`,
+			want: server.ToolConfigs{
+				"example_tool": kafkaconsume.Config{
+					Name:         "example_tool",
+					Kind:         "kafka-consume",
+					Source:       "my-kafka",
+					Description:  "some description",
+					Topic:        "orders",
+					MaxMessages:  100,
+					Timeout:      "30s",
+					StartFrom:    "earliest",
+					AuthRequired: []string{},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestFailParseFromYamlKafkaConsume(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		err  string
+	}{
+		{
+			desc: "invalid startFrom",
+			in: `
+			tools:
+				example_tool:
+					kind: kafka-consume
+					source: my-kafka
+					description: some description
+					topic: orders
+					startFrom: middle
+			`,
+			err: "Field validation for 'StartFrom' failed on the 'oneof' tag",
+		},
+		{
+			desc: "no messages",
+			in: `
+			tools:
+				example_tool:
+					kind: kafka-consume
+					source: my-kafka
+					description: some description
+					topic: orders
+					maxMessages: 0
+			`,
+			err: "Field validation for 'MaxMessages' failed on the 'gte' tag",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err == nil {
+				t.Fatalf("expect parsing to fail")
+			}
+			if !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("unexpected error: got %q, want it to contain %q", err.Error(), tc.err)
+			}
+		})
+	}
+}
+
+func TestMaxMessagesParameter(t *testing.T) {
+	srcs := map[string]sources.Source{
+		"my-kafka": &kafkads.Source{Name: "my-kafka", Kind: kafkads.SourceKind},
+	}
+	cfg := kafkaconsume.Config{Name: "example_tool", Kind: "kafka-consume", Source: "my-kafka", Description: "some description", Topic: "orders", MaxMessages: 10, Timeout: "5s", StartFrom: "latest"}
+	tool, err := cfg.Initialize(srcs)
+	if err != nil {
+		t.Fatalf("unable to initialize tool: %s", err)
+	}
+
+	params, err := tool.ParseParams(map[string]any{}, nil)
+	if err != nil {
+		t.Fatalf("unable to parse params: %s", err)
+	}
+	if diff := cmp.Diff(map[string]any{"maxMessages": 10}, params.AsMap()); diff != "" {
+		t.Errorf("incorrect default: diff %v", diff)
+	}
+
+	// more messages than the configured maximum are refused before the
+	// brokers are contacted
+	_, err = tool.ParseParams(map[string]any{"maxMessages": 11}, nil)
+	want := "11 is greater than the maximum 10"
+	if err == nil || !strings.Contains(err.Error(), want) {
+		t.Fatalf("unexpected error: got %v, want it to contain %q", err, want)
+	}
+
+	m := tool.Manifest().Parameters[0]
+	if m.Minimum == nil || *m.Minimum != 1 || m.Maximum == nil || *m.Maximum != 10 {
+		t.Errorf("incorrect bounds in manifest: got %v and %v, want 1 and 10", m.Minimum, m.Maximum)
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkapublish
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	kafkads "github.com/googleapis/genai-toolbox/internal/sources/kafka"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/segmentio/kafka-go"
+)
+
+const kind string = "kafka-publish"
+
+const (
+	keyKey   string = "key"
+	valueKey string = "value"
+)
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	KafkaClient() *kafka.Client
+}
+
+// validate compatible sources are still compatible
+var _ compatibleSource = &kafkads.Source{}
+
+var compatibleSources = [...]string{kafkads.SourceKind}
+
+type Config struct {
+	Name        string `yaml:"name" validate:"required"`
+	Kind        string `yaml:"kind" validate:"required"`
+	Source      string `yaml:"source" validate:"required"`
+	Description string `yaml:"description" validate:"required"`
+	Topic       string `yaml:"topic" validate:"required"`
+	// Headers are added to every message published by the tool.
+	Headers      map[string]string `yaml:"headers"`
+	AuthRequired []string          `yaml:"authRequired"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(compatibleSource)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
+	}
+
+	valueParameter := tools.NewStringParameter(valueKey, "The value of the message to publish.")
+	keyParameter := tools.NewStringParameterWithRequired(keyKey, "The key of the message. Messages with the same key are published to the same partition.", false)
+	parameters := tools.Parameters{valueParameter, keyParameter}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: parameters.McpManifest(),
+	}
+
+	// headers are sorted so that messages carry them in a stable order
+	names := make([]string, 0, len(cfg.Headers))
+	for name := range cfg.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	headers := make([]kafka.Header, 0, len(names))
+	for _, name := range names {
+		headers = append(headers, kafka.Header{Key: name, Value: []byte(cfg.Headers[name])})
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   parameters,
+		AuthRequired: cfg.AuthRequired,
+		Topic:        cfg.Topic,
+		Headers:      headers,
+		Client:       s.KafkaClient(),
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:  mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+
+	Topic       string
+	Headers     []kafka.Header
+	Client      *kafka.Client
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	paramsMap := params.AsMap()
+	value, ok := paramsMap[valueKey].(string)
+	if !ok {
+		return nil, fmt.Errorf("invalid or missing '%s' parameter; expected a string", valueKey)
+	}
+	msg := kafka.Message{Value: []byte(value), Headers: t.Headers}
+	if key, ok := paramsMap[keyKey].(string); ok && key != "" {
+		msg.Key = []byte(key)
+	}
+
+	// the writer reports the partition and offset of the message to its
+	// completion function, so a writer is created for each invocation. It
+	// flushes the message at once rather than waiting for a batch.
+	var written kafka.Message
+	w := &kafka.Writer{
+		Addr:         t.Client.Addr,
+		Topic:        t.Topic,
+		Transport:    t.Client.Transport,
+		Balancer:     &kafka.Hash{},
+		BatchSize:    1,
+		RequiredAcks: kafka.RequireAll,
+		Completion: func(messages []kafka.Message, err error) {
+			if err == nil && len(messages) > 0 {
+				written = messages[0]
+			}
+		},
+	}
+	defer w.Close()
+
+	if err := w.WriteMessages(ctx, msg); err != nil {
+		return nil, fmt.Errorf("unable to publish message: %w", err)
+	}
+	return map[string]any{
+		"topic":     t.Topic,
+		"partition": written.Partition,
+		"offset":    written.Offset,
+	}, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
@@ -0,0 +1,126 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkapublish_test
+
+import (
+	"strings"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools/kafka/kafkapublish"
+)
+
+func TestParseFromYamlKafkaPublish(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: kafka-publish
+					source: my-kafka
+					description: some description
+					topic: orders
+			`,
+			want: server.ToolConfigs{
+				"example_tool": kafkapublish.Config{
+					Name:         "example_tool",
+					Kind:         "kafka-publish",
+					Source:       "my-kafka",
+					Description:  "some description",
+					Topic:        "orders",
+					AuthRequired: []string{},
+				},
+			},
+		},
+		{
+			desc: "with headers",
+			in: `
+			tools:
+				example_tool:
+					kind: kafka-publish
+					source: my-kafka
+					description: some description
+					topic: orders
+					headers:
+						origin: toolbox
+					authRequired:
+						- my-google-auth-service
+			`,
+			want: server.ToolConfigs{
+				"example_tool": kafkapublish.Config{
+					Name:         "example_tool",
+					Kind:         "kafka-publish",
+					Source:       "my-kafka",
+					Description:  "some description",
+					Topic:        "orders",
+					Headers:      map[string]string{"origin": "toolbox"},
+					AuthRequired: []string{"my-google-auth-service"},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestFailParseFromYamlKafkaPublish(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	in := `
+	tools:
+		example_tool:
+			kind: kafka-publish
+			source: my-kafka
+			description: some description
+	`
+	got := struct {
+		Tools server.ToolConfigs `yaml:"tools"`
+	}{}
+	err = yaml.UnmarshalContext(ctx, testutils.FormatYaml(in), &got)
+	if err == nil {
+		t.Fatalf("expect parsing to fail")
+	}
+	want := "Field validation for 'Topic' failed on the 'required' tag"
+	if !strings.Contains(err.Error(), want) {
+		t.Fatalf("unexpected error: got %q, want it to contain %q", err.Error(), want)
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"maps"
+
+	"github.com/googleapis/genai-toolbox/internal/tools/schema"
+	"golang.org/x/text/language"
+)
+
+// NormalizeLocale returns the canonical form of a locale used as the key of
+// localized descriptions, e.g. "pt_BR" becomes "pt-br".
+func NormalizeLocale(locale string) string {
+	return schema.NormalizeLocale(locale)
+}
+
+// ExtractDescriptions removes the description_<locale> fields from a raw
+// config and returns them by normalized locale.
+func ExtractDescriptions(v map[string]any) (map[string]string, error) {
+	return schema.ExtractDescriptions(v)
+}
+
+// ParseAcceptLanguage returns the normalized locales of an Accept-Language
+// header, in order of preference. Invalid headers are ignored.
+func ParseAcceptLanguage(header string) []string {
+	tags, _, err := language.ParseAcceptLanguage(header)
+	if err != nil {
+		return nil
+	}
+	locales := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		locales = append(locales, NormalizeLocale(tag.String()))
+	}
+	return locales
+}
+
+// Localize returns the manifest with its descriptions in the first of
+// locales available.
+func (m Manifest) Localize(locales []string) Manifest {
+	if len(locales) == 0 {
+		return m
+	}
+	m.Description = schema.LocalizedDescription(m.Description, m.Descriptions, locales)
+	params := make([]ParameterManifest, 0, len(m.Parameters))
+	for _, p := range m.Parameters {
+		params = append(params, p.Localize(locales))
+	}
+	m.Parameters = params
+	return m
+}
+
+// Localize returns the MCP manifest with its descriptions in the first of
+// locales available.
+func (m McpManifest) Localize(locales []string) McpManifest {
+	if len(locales) == 0 {
+		return m
+	}
+	m.Description = schema.LocalizedDescription(m.Description, m.Descriptions, locales)
+	props := make(map[string]ParameterMcpManifest, len(m.InputSchema.Properties))
+	for name, p := range m.InputSchema.Properties {
+		props[name] = p.Localize(locales)
+	}
+	m.InputSchema.Properties = props
+	return m
+}
+
+// Localize returns the toolset manifest with the descriptions of its tools in
+// the first of locales available.
+func (m ToolsetManifest) Localize(locales []string) ToolsetManifest {
+	if len(locales) == 0 {
+		return m
+	}
+	toolsManifest := make(map[string]Manifest, len(m.ToolsManifest))
+	for name, tm := range m.ToolsManifest {
+		toolsManifest[name] = tm.Localize(locales)
+	}
+	m.ToolsManifest = toolsManifest
+	return m
+}
+
+// LocalizeMcpManifests returns the MCP manifests with their descriptions in
+// the first of locales available.
+func LocalizeMcpManifests(ms []McpManifest, locales []string) []McpManifest {
+	if len(locales) == 0 {
+		return ms
+	}
+	rtn := make([]McpManifest, 0, len(ms))
+	for _, m := range ms {
+		rtn = append(rtn, m.Localize(locales))
+	}
+	return rtn
+}
+
+// appendToDescriptions returns a copy of the localized descriptions with
+// suffix appended to each of them.
+func appendToDescriptions(descs map[string]string, suffix string) map[string]string {
+	if descs == nil {
+		return nil
+	}
+	rtn := maps.Clone(descs)
+	for locale, d := range rtn {
+		rtn[locale] = d + suffix
+	}
+	return rtn
+}
@@ -0,0 +1,152 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+func TestExtractDescriptions(t *testing.T) {
+	v := map[string]any{
+		"name":              "foo",
+		"description":       "some description",
+		"description_ja":    "説明",
+		"description_pt_BR": "descrição",
+	}
+	got, err := tools.ExtractDescriptions(v)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := map[string]string{"ja": "説明", "pt-br": "descrição"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect descriptions: diff %v", diff)
+	}
+	if diff := cmp.Diff(map[string]any{"name": "foo", "description": "some description"}, v); diff != "" {
+		t.Fatalf("localized descriptions were not removed: diff %v", diff)
+	}
+
+	if _, err := tools.ExtractDescriptions(map[string]any{"description_ja": 1}); err == nil {
+		t.Fatalf("expected an error for a non-string description")
+	}
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tcs := []struct {
+		desc   string
+		header string
+		want   []string
+	}{
+		{
+			desc:   "empty",
+			header: "",
+			want:   []string{},
+		},
+		{
+			desc:   "ordered by quality",
+			header: "en;q=0.5, ja-JP, fr;q=0.8",
+			want:   []string{"ja-jp", "fr", "en"},
+		},
+		{
+			desc:   "invalid",
+			header: "=;;",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := tools.ParseAcceptLanguage(tc.header)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect locales: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestManifestLocalize(t *testing.T) {
+	p := tools.NewStringParameter("airline", "Airline code")
+	p.Descriptions = map[string]string{"ja": "航空会社コード"}
+	items := tools.NewStringParameter("city", "A city")
+	items.Descriptions = map[string]string{"ja-jp": "都市"}
+	arr := tools.NewArrayParameter("cities", "Cities", items)
+	params := tools.Parameters{p, arr}
+
+	m := tools.Manifest{
+		Description:  "Search flights",
+		Descriptions: map[string]string{"ja": "フライトを検索"},
+		Parameters:   params.Manifest(),
+	}
+	mcp := tools.McpManifest{
+		Name:         "search",
+		Description:  "Search flights",
+		Descriptions: map[string]string{"ja": "フライトを検索"},
+		InputSchema:  params.McpManifest(),
+	}
+
+	tcs := []struct {
+		desc      string
+		locales   []string
+		wantTool  string
+		wantParam string
+		wantItems string
+	}{
+		{
+			desc:      "no locale",
+			wantTool:  "Search flights",
+			wantParam: "Airline code",
+			wantItems: "A city",
+		},
+		{
+			desc:      "base language fallback",
+			locales:   []string{"ja-jp"},
+			wantTool:  "フライトを検索",
+			wantParam: "航空会社コード",
+			wantItems: "都市",
+		},
+		{
+			desc:      "first available locale",
+			locales:   []string{"fr", "ja"},
+			wantTool:  "フライトを検索",
+			wantParam: "航空会社コード",
+			wantItems: "A city",
+		},
+		{
+			desc:      "unavailable locale",
+			locales:   []string{"fr"},
+			wantTool:  "Search flights",
+			wantParam: "Airline code",
+			wantItems: "A city",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := m.Localize(tc.locales)
+			if got.Description != tc.wantTool || got.Parameters[0].Description != tc.wantParam || got.Parameters[1].Items.Description != tc.wantItems {
+				t.Fatalf("incorrect manifest descriptions: got %q, %q, %q", got.Description, got.Parameters[0].Description, got.Parameters[1].Items.Description)
+			}
+			gotMcp := mcp.Localize(tc.locales)
+			props := gotMcp.InputSchema.Properties
+			if gotMcp.Description != tc.wantTool || props["airline"].Description != tc.wantParam || props["cities"].Items.Description != tc.wantItems {
+				t.Fatalf("incorrect mcp manifest descriptions: got %q, %q, %q", gotMcp.Description, props["airline"].Description, props["cities"].Items.Description)
+			}
+		})
+	}
+
+	// the original manifests must not be modified
+	if m.Parameters[0].Description != "Airline code" || mcp.InputSchema.Properties["airline"].Description != "Airline code" {
+		t.Fatalf("localizing modified the original manifest")
+	}
+}
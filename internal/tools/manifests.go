@@ -0,0 +1,37 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+// MemoizeManifests returns t with its manifests built once, instead of by
+// every call, since they don't change once the tool is initialized. Tools are
+// wrapped with it last, so that the manifests include the changes of every
+// other wrapper.
+func MemoizeManifests(t Tool) Tool {
+	return toolWithManifests{Tool: t, manifest: t.Manifest(), mcpManifest: t.McpManifest()}
+}
+
+type toolWithManifests struct {
+	Tool
+	manifest    Manifest
+	mcpManifest McpManifest
+}
+
+func (t toolWithManifests) Manifest() Manifest {
+	return t.manifest
+}
+
+func (t toolWithManifests) McpManifest() McpManifest {
+	return t.mcpManifest
+}
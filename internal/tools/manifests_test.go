@@ -0,0 +1,54 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+// countingTool counts the calls building its manifests.
+type countingTool struct {
+	mockTool
+	calls *int
+}
+
+func (t countingTool) Manifest() tools.Manifest {
+	*t.calls++
+	return t.mockTool.Manifest()
+}
+
+func (t countingTool) McpManifest() tools.McpManifest {
+	*t.calls++
+	return t.mockTool.McpManifest()
+}
+
+func TestMemoizeManifests(t *testing.T) {
+	calls := 0
+	tool := tools.MemoizeManifests(countingTool{calls: &calls})
+	for i := 0; i < 3; i++ {
+		if diff := cmp.Diff(mockTool{}.Manifest(), tool.Manifest()); diff != "" {
+			t.Fatalf("incorrect manifest: diff %v", diff)
+		}
+		if diff := cmp.Diff(mockTool{}.McpManifest(), tool.McpManifest()); diff != "" {
+			t.Fatalf("incorrect MCP manifest: diff %v", diff)
+		}
+	}
+	if calls != 2 {
+		t.Fatalf("manifests were built %d times, want once each", calls)
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"time"
+)
+
+// ResultMetadata describes the execution of a tool invocation. It is returned
+// to clients alongside the result with the typed result envelope, so that
+// they don't need to parse the logs of the server for it.
+type ResultMetadata struct {
+	// RowCount is the number of rows of the result, or 1 if the result isn't
+	// a list of rows.
+	RowCount int `json:"rowCount"`
+	// ExecutionMs is how long the tool took to run, in milliseconds.
+	ExecutionMs int64 `json:"executionMs"`
+	// Source is the name of the source the tool ran on, if any.
+	Source string `json:"source,omitempty"`
+	// Truncated is whether the result was truncated, such as to its first
+	// rows or to a page of them.
+	Truncated bool `json:"truncated"`
+}
+
+// SourceTool is implemented by tools that know the name of their source.
+type SourceTool interface {
+	SourceName() string
+}
+
+type resultMetadataKey struct{}
+
+// WithResultMetadata returns a context whose tool invocations return their
+// ResultMetadata alongside their results.
+func WithResultMetadata(ctx context.Context) context.Context {
+	return context.WithValue(ctx, resultMetadataKey{}, true)
+}
+
+// ReturnsResultMetadata returns whether the tool invocations of the context
+// return their ResultMetadata.
+func ReturnsResultMetadata(ctx context.Context) bool {
+	v, _ := ctx.Value(resultMetadataKey{}).(bool)
+	return v
+}
+
+// NewResultMetadata returns the metadata of the invocation of t that returned
+// res after elapsed, and whose warnings ctx collected.
+func NewResultMetadata(ctx context.Context, t Tool, res any, elapsed time.Duration) ResultMetadata {
+	m := ResultMetadata{
+		RowCount:    1,
+		ExecutionMs: elapsed.Milliseconds(),
+		Truncated:   Truncated(ctx),
+	}
+	if rows, ok := res.([]any); ok {
+		m.RowCount = len(rows)
+	} else if res == nil {
+		m.RowCount = 0
+	}
+	if st, ok := t.(SourceTool); ok {
+		m.Source = st.SourceName()
+	}
+	return m
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+// sourceTool is a tool running on the source my-source.
+type sourceTool struct {
+	tools.Tool
+}
+
+func (sourceTool) SourceName() string {
+	return "my-source"
+}
+
+func TestNewResultMetadata(t *testing.T) {
+	tcs := []struct {
+		desc      string
+		tool      tools.Tool
+		res       any
+		truncated bool
+		want      tools.ResultMetadata
+	}{
+		{
+			desc: "rows",
+			res:  []any{1, 2, 3},
+			want: tools.ResultMetadata{RowCount: 3, ExecutionMs: 1500},
+		},
+		{
+			desc: "nil",
+			want: tools.ResultMetadata{ExecutionMs: 1500},
+		},
+		{
+			desc: "not rows",
+			res:  map[string]any{"rows": []any{1, 2}},
+			want: tools.ResultMetadata{RowCount: 1, ExecutionMs: 1500},
+		},
+		{
+			desc:      "truncated",
+			tool:      sourceTool{},
+			res:       []any{},
+			truncated: true,
+			want:      tools.ResultMetadata{ExecutionMs: 1500, Source: "my-source", Truncated: true},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctx := tools.WithWarnings(context.Background())
+			if tc.truncated {
+				tools.SetTruncated(ctx)
+			}
+			got := tools.NewResultMetadata(ctx, tc.tool, tc.res, 1500*time.Millisecond)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect metadata: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestReturnsResultMetadata(t *testing.T) {
+	if tools.ReturnsResultMetadata(context.Background()) {
+		t.Fatalf("expected no metadata by default")
+	}
+	if !tools.ReturnsResultMetadata(tools.WithResultMetadata(context.Background())) {
+		t.Fatalf("expected metadata to be returned")
+	}
+}
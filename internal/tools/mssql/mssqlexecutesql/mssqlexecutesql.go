@@ -21,10 +21,12 @@ import (
 
 	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/azuresql"
 	"github.com/googleapis/genai-toolbox/internal/sources/cloudsqlmssql"
 	"github.com/googleapis/genai-toolbox/internal/sources/mssql"
 	"github.com/googleapis/genai-toolbox/internal/tools"
 	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/googleapis/genai-toolbox/internal/util/normalize"
 )
 
 const kind string = "mssql-execute-sql"
@@ -48,10 +50,11 @@ type compatibleSource interface {
 }
 
 // validate compatible sources are still compatible
+var _ compatibleSource = &azuresql.Source{}
 var _ compatibleSource = &cloudsqlmssql.Source{}
 var _ compatibleSource = &mssql.Source{}
 
-var compatibleSources = [...]string{cloudsqlmssql.SourceKind, mssql.SourceKind}
+var compatibleSources = [...]string{azuresql.SourceKind, cloudsqlmssql.SourceKind, mssql.SourceKind}
 
 type Config struct {
 	Name         string   `yaml:"name" validate:"required"`
@@ -59,6 +62,7 @@ type Config struct {
 	Source       string   `yaml:"source" validate:"required"`
 	Description  string   `yaml:"description" validate:"required"`
 	AuthRequired []string `yaml:"authRequired"`
+	ReadOnly     bool     `yaml:"readOnly"`
 }
 
 // validate interface
@@ -96,6 +100,7 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		Kind:         kind,
 		Parameters:   parameters,
 		AuthRequired: cfg.AuthRequired,
+		ReadOnly:     cfg.ReadOnly || sources.IsReadOnly(rawS),
 		Pool:         s.MSSQLDB(),
 		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
 		mcpManifest:  mcpManifest,
@@ -111,6 +116,7 @@ type Tool struct {
 	Kind         string           `yaml:"kind"`
 	AuthRequired []string         `yaml:"authRequired"`
 	Parameters   tools.Parameters `yaml:"parameters"`
+	ReadOnly     bool             `yaml:"readOnly"`
 
 	Pool        *sql.DB
 	manifest    tools.Manifest
@@ -123,6 +129,11 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 	if !ok {
 		return nil, fmt.Errorf("unable to get cast %s", paramsMap["sql"])
 	}
+	if t.ReadOnly {
+		if err := tools.CheckReadOnly(tools.DialectMSSQL, sql); err != nil {
+			return nil, err
+		}
+	}
 
 	// Log the query executed for debugging.
 	logger, err := util.LoggerFromContext(ctx)
@@ -131,7 +142,7 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 	}
 	logger.DebugContext(ctx, "executing `%s` tool query: %s", kind, sql)
 
-	results, err := t.Pool.QueryContext(ctx, sql)
+	results, err := t.Pool.QueryContext(ctx, tools.TagStatement(ctx, sql))
 	if err != nil {
 		return nil, fmt.Errorf("unable to execute query: %w", err)
 	}
@@ -144,6 +155,11 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 
 	var out []any
 	if err == nil && len(cols) > 0 {
+		colTypes, err := results.ColumnTypes()
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch column types: %w", err)
+		}
+
 		// create an array of values for each column, which can be re-used to scan each row
 		rawValues := make([]any, len(cols))
 		values := make([]any, len(cols))
@@ -152,6 +168,9 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 		}
 
 		for results.Next() {
+			if tools.RowLimitReached(ctx, len(out)) {
+				break
+			}
 			scanErr := results.Scan(values...)
 			if scanErr != nil {
 				return nil, fmt.Errorf("unable to parse row: %w", scanErr)
@@ -159,6 +178,10 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 			vMap := make(map[string]any)
 			for i, name := range cols {
 				vMap[name] = rawValues[i]
+				// the driver returns the text of decimals as bytes
+				if b, ok := rawValues[i].([]byte); ok && isDecimalType(colTypes[i].DatabaseTypeName()) {
+					vMap[name] = normalize.Decimal(b)
+				}
 			}
 			out = append(out, vMap)
 		}
@@ -188,3 +211,13 @@ func (t Tool) McpManifest() tools.McpManifest {
 func (t Tool) Authorized(verifiedAuthServices []string) bool {
 	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
 }
+
+// isDecimalType reports whether a column of the SQL Server type name holds
+// exact decimal numbers.
+func isDecimalType(name string) bool {
+	switch name {
+	case "DECIMAL", "NUMERIC", "MONEY", "SMALLMONEY":
+		return true
+	}
+	return false
+}
@@ -22,9 +22,11 @@ import (
 
 	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/azuresql"
 	"github.com/googleapis/genai-toolbox/internal/sources/cloudsqlmssql"
 	"github.com/googleapis/genai-toolbox/internal/sources/mssql"
 	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util/normalize"
 )
 
 const kind string = "mssql-sql"
@@ -48,10 +50,11 @@ type compatibleSource interface {
 }
 
 // validate compatible sources are still compatible
+var _ compatibleSource = &azuresql.Source{}
 var _ compatibleSource = &cloudsqlmssql.Source{}
 var _ compatibleSource = &mssql.Source{}
 
-var compatibleSources = [...]string{cloudsqlmssql.SourceKind, mssql.SourceKind}
+var compatibleSources = [...]string{azuresql.SourceKind, cloudsqlmssql.SourceKind, mssql.SourceKind}
 
 type Config struct {
 	Name               string           `yaml:"name" validate:"required"`
@@ -65,12 +68,20 @@ type Config struct {
 }
 
 // validate interface
-var _ tools.ToolConfig = Config{}
+var _ tools.StatementToolConfig = Config{}
 
 func (cfg Config) ToolConfigKind() string {
 	return kind
 }
 
+func (cfg Config) ToolSource() string {
+	return cfg.Source
+}
+
+func (cfg Config) ToolStatement() string {
+	return cfg.Statement
+}
+
 func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
 	// verify source exists
 	rawS, ok := srcs[cfg.Source]
@@ -130,7 +141,7 @@ type Tool struct {
 
 func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
 	paramsMap := params.AsMap()
-	newStatement, err := tools.ResolveTemplateParams(t.TemplateParameters, t.Statement, paramsMap)
+	newStatement, err := tools.ResolveTemplateParams(tools.DialectMSSQL, t.TemplateParameters, t.Statement, paramsMap)
 	if err != nil {
 		return nil, fmt.Errorf("unable to extract template params %w", err)
 	}
@@ -140,6 +151,12 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 		return nil, fmt.Errorf("unable to extract standard params %w", err)
 	}
 
+	newStatement, _, err = tools.ConvertPlaceholders(tools.PlaceholderAt, newStatement, newParams)
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert placeholders: %w", err)
+	}
+	newStatement = tools.TagStatement(ctx, newStatement)
+
 	namedArgs := make([]any, 0, len(newParams))
 	// To support both named args (e.g @id) and positional args (e.g @p1), check
 	// if arg name is contained in the statement.
@@ -157,12 +174,18 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 	if err != nil {
 		return nil, fmt.Errorf("unable to execute query: %w", err)
 	}
+	defer rows.Close()
 
 	cols, err := rows.Columns()
 	if err != nil {
 		return nil, fmt.Errorf("unable to fetch column types: %w", err)
 	}
 
+	colTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch column types: %w", err)
+	}
+
 	// create an array of values for each column, which can be re-used to scan each row
 	rawValues := make([]any, len(cols))
 	values := make([]any, len(cols))
@@ -172,6 +195,9 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 
 	var out []any
 	for rows.Next() {
+		if tools.RowLimitReached(ctx, len(out)) {
+			break
+		}
 		err = rows.Scan(values...)
 		if err != nil {
 			return nil, fmt.Errorf("unable to parse row: %w", err)
@@ -179,6 +205,10 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 		vMap := make(map[string]any)
 		for i, name := range cols {
 			vMap[name] = rawValues[i]
+			// the driver returns the text of decimals as bytes
+			if b, ok := rawValues[i].([]byte); ok && isDecimalType(colTypes[i].DatabaseTypeName()) {
+				vMap[name] = normalize.Decimal(b)
+			}
 		}
 		out = append(out, vMap)
 	}
@@ -210,3 +240,13 @@ func (t Tool) McpManifest() tools.McpManifest {
 func (t Tool) Authorized(verifiedAuthServices []string) bool {
 	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
 }
+
+// isDecimalType reports whether a column of the SQL Server type name holds
+// exact decimal numbers.
+func isDecimalType(name string) bool {
+	switch name {
+	case "DECIMAL", "NUMERIC", "MONEY", "SMALLMONEY":
+		return true
+	}
+	return false
+}
@@ -15,12 +15,14 @@
 package mysqlexecutesql_test
 
 import (
+	"context"
 	"testing"
 
 	yaml "github.com/goccy/go-yaml"
 	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/genai-toolbox/internal/server"
 	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
 	"github.com/googleapis/genai-toolbox/internal/tools/mysql/mysqlexecutesql"
 )
 
@@ -56,6 +58,27 @@ func TestParseFromYamlExecuteSql(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "read only",
+			in: `
+			tools:
+				example_tool:
+					kind: mysql-execute-sql
+					source: my-instance
+					description: some description
+					readOnly: true
+			`,
+			want: server.ToolConfigs{
+				"example_tool": mysqlexecutesql.Config{
+					Name:         "example_tool",
+					Kind:         "mysql-execute-sql",
+					Source:       "my-instance",
+					Description:  "some description",
+					AuthRequired: []string{},
+					ReadOnly:     true,
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -74,3 +97,12 @@ func TestParseFromYamlExecuteSql(t *testing.T) {
 	}
 
 }
+
+func TestInvokeReadOnly(t *testing.T) {
+	// statements writing to the database are rejected before reaching it
+	tool := mysqlexecutesql.Tool{ReadOnly: true}
+	params := tools.ParamValues{{Name: "sql", Value: "DELETE FROM users"}}
+	if _, err := tool.Invoke(context.Background(), params); err == nil {
+		t.Fatalf("expected the statement to be rejected")
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/sources/cloudsqlmysql"
 	"github.com/googleapis/genai-toolbox/internal/sources/mysql"
 	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util/normalize"
 )
 
 const kind string = "mysql-sql"
@@ -65,12 +66,20 @@ type Config struct {
 }
 
 // validate interface
-var _ tools.ToolConfig = Config{}
+var _ tools.StatementToolConfig = Config{}
 
 func (cfg Config) ToolConfigKind() string {
 	return kind
 }
 
+func (cfg Config) ToolSource() string {
+	return cfg.Source
+}
+
+func (cfg Config) ToolStatement() string {
+	return cfg.Statement
+}
+
 func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
 	// verify source exists
 	rawS, ok := srcs[cfg.Source]
@@ -113,6 +122,7 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 
 // validate interface
 var _ tools.Tool = Tool{}
+var _ tools.CompletionQuerier = Tool{}
 
 type Tool struct {
 	Name               string           `yaml:"name"`
@@ -130,7 +140,7 @@ type Tool struct {
 
 func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
 	paramsMap := params.AsMap()
-	newStatement, err := tools.ResolveTemplateParams(t.TemplateParameters, t.Statement, paramsMap)
+	newStatement, err := tools.ResolveTemplateParams(tools.DialectMySQL, t.TemplateParameters, t.Statement, paramsMap)
 	if err != nil {
 		return nil, fmt.Errorf("unable to extract template params %w", err)
 	}
@@ -140,7 +150,11 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 		return nil, fmt.Errorf("unable to extract standard params %w", err)
 	}
 
-	sliceParams := newParams.AsSlice()
+	newStatement, sliceParams, err := tools.ConvertPlaceholders(tools.PlaceholderQuestion, newStatement, newParams)
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert placeholders: %w", err)
+	}
+	newStatement = tools.TagStatement(ctx, newStatement)
 	results, err := t.Pool.QueryContext(ctx, newStatement, sliceParams...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to execute query: %w", err)
@@ -166,6 +180,9 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 
 	var out []any
 	for results.Next() {
+		if tools.RowLimitReached(ctx, len(out)) {
+			break
+		}
 		err := results.Scan(values...)
 		if err != nil {
 			return nil, fmt.Errorf("unable to parse row: %w", err)
@@ -189,6 +206,8 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 					return nil, fmt.Errorf("unable to unmarshal json data %s", val)
 				}
 				vMap[name] = unmarshaledData
+			case "DECIMAL":
+				vMap[name] = normalize.Decimal(val.([]byte))
 			case "TEXT", "VARCHAR", "NVARCHAR":
 				vMap[name] = string(val.([]byte))
 			default:
@@ -205,6 +224,40 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 	return out, nil
 }
 
+// QueryCompletions runs the completion statement of a parameter.
+func (t Tool) QueryCompletions(ctx context.Context, statement string) ([]string, error) {
+	results, err := t.Pool.QueryContext(ctx, statement)
+	if err != nil {
+		return nil, fmt.Errorf("unable to execute query: %w", err)
+	}
+	defer results.Close()
+
+	cols, err := results.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve rows column name: %w", err)
+	}
+	rawValues := make([]any, len(cols))
+	row := make([]any, len(cols))
+	for i := range rawValues {
+		row[i] = &rawValues[i]
+	}
+
+	values := []string{}
+	for results.Next() {
+		if err := results.Scan(row...); err != nil {
+			return nil, fmt.Errorf("unable to parse row: %w", err)
+		}
+		switch v := rawValues[0].(type) {
+		case nil:
+		case []byte:
+			values = append(values, string(v))
+		default:
+			values = append(values, fmt.Sprint(v))
+		}
+	}
+	return values, results.Err()
+}
+
 func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
 	return tools.ParseParams(t.AllParams, data, claims)
 }
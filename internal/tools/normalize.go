@@ -0,0 +1,56 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+
+	"github.com/googleapis/genai-toolbox/internal/util/normalize"
+)
+
+// NormalizeResults returns t with the driver specific values of its results,
+// such as decimals and dates, converted to consistent JSON representations
+// shared by all tools. Decimals and big integers are returned as set by the
+// tool's numberFormat option, or defaultFormat if it isn't set. NULL columns
+// are omitted from rows if the tool's nullColumns option is omit.
+func NormalizeResults(cfg ToolConfig, t Tool, defaultFormat normalize.NumberFormat) Tool {
+	format := defaultFormat
+	var nulls normalize.NullColumns
+	if oc, ok := cfg.(ConfigWithOptions); ok {
+		if oc.Options.NumberFormat != "" {
+			format = oc.Options.NumberFormat
+		}
+		nulls = oc.Options.NullColumns
+	}
+	return toolWithNormalizedResults{Tool: t, format: format, nulls: nulls}
+}
+
+type toolWithNormalizedResults struct {
+	Tool
+	format normalize.NumberFormat
+	nulls  normalize.NullColumns
+}
+
+func (t toolWithNormalizedResults) Invoke(ctx context.Context, params ParamValues) (any, error) {
+	res, err := t.Tool.Invoke(ctx, params)
+	if err != nil {
+		return res, err
+	}
+	res = normalize.Value(res, t.format)
+	if t.nulls == normalize.OmitNullColumns {
+		res = normalize.OmitNulls(res)
+	}
+	return res, nil
+}
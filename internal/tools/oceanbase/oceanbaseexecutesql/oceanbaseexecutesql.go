@@ -23,6 +23,7 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/sources/oceanbase"
 	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util/normalize"
 )
 
 const kind string = "oceanbase-execute-sql"
@@ -122,7 +123,7 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 		return nil, fmt.Errorf("unable to get cast %s", sliceParams[0])
 	}
 
-	results, err := t.Pool.QueryContext(ctx, sqlStr)
+	results, err := t.Pool.QueryContext(ctx, tools.TagStatement(ctx, sqlStr))
 	if err != nil {
 		return nil, fmt.Errorf("unable to execute query: %w", err)
 	}
@@ -147,6 +148,9 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 
 	var out []any
 	for results.Next() {
+		if tools.RowLimitReached(ctx, len(out)) {
+			break
+		}
 		err := results.Scan(values...)
 		if err != nil {
 			return nil, fmt.Errorf("unable to parse row: %w", err)
@@ -162,6 +166,8 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 			// oceanbase driver returns []uint8 type for "TEXT", "VARCHAR", and "NVARCHAR"
 			// we'll need to cast it back to string
 			switch colTypes[i].DatabaseTypeName() {
+			case "DECIMAL":
+				vMap[name] = normalize.Decimal(val.([]byte))
 			case "TEXT", "VARCHAR", "NVARCHAR":
 				vMap[name] = string(val.([]byte))
 			default:
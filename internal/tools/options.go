@@ -0,0 +1,294 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/tools/schema"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/googleapis/genai-toolbox/internal/util/normalize"
+)
+
+// Options are fields supported by every kind of tool. They are handled by
+// Toolbox rather than by each tool.
+type Options struct {
+	// Examples are example arguments for the tool, emitted in its manifests.
+	Examples []map[string]any `yaml:"examples"`
+	// EnrichDescription appends the columns of the tables referenced by the
+	// tool's statement to its description. See EnrichDescription.
+	EnrichDescription bool `yaml:"enrichDescription"`
+	// Tags are labels used to filter the tools listed to clients.
+	Tags []string `yaml:"tags" validate:"dive,required"`
+	// Descriptions are the localized descriptions of the tool by locale, set
+	// from the description_<locale> fields.
+	Descriptions map[string]string `yaml:"-"`
+	// Deprecated marks the tool as deprecated, explaining what to use instead.
+	// Invocations of the tool return it as a warning.
+	Deprecated string `yaml:"deprecated"`
+	// SlowThreshold is a duration after which invocations of the tool return
+	// a warning that they were slow.
+	SlowThreshold string `yaml:"slowThreshold"`
+	// RejectUnknownParameters rejects invocations with parameters the tool
+	// doesn't declare. If unset, the server's default applies.
+	RejectUnknownParameters *bool `yaml:"rejectUnknownParameters"`
+	// NumberFormat is how the tool returns decimals and big integers in its
+	// results. If unset, the server's default applies. See NormalizeResults.
+	NumberFormat normalize.NumberFormat `yaml:"numberFormat" validate:"omitempty,oneof=string number"`
+	// NullColumns is whether columns that are NULL are returned as explicit
+	// nulls or omitted from the rows of the tool's results. See
+	// NormalizeResults.
+	NullColumns normalize.NullColumns `yaml:"nullColumns" validate:"omitempty,oneof=include omit"`
+	// SQLComment tags the SQL statements the tool sends to its source with a
+	// comment describing the invocation. If unset, the server's default
+	// applies. See TagStatement.
+	SQLComment *bool `yaml:"sqlComment"`
+	// Enabled is false for tools taken out of service. They are initialized,
+	// but not listed and can't be invoked until they are enabled at runtime.
+	Enabled *bool `yaml:"enabled"`
+	// RowFilter is a predicate removing the rows of the tool's results the
+	// caller isn't allowed to see. See FilterRows.
+	RowFilter string `yaml:"rowFilter"`
+	// ColumnProjection restricts the columns of the tool's results by the
+	// roles of the caller. See ProjectColumns.
+	ColumnProjection *ColumnProjection `yaml:"columnProjection"`
+	// ResultSchema are the columns the rows of the tool's results are
+	// expected to have. See CheckContract.
+	ResultSchema []ResultColumn `yaml:"resultSchema" validate:"dive"`
+	// MaxRows is the largest number of rows returned by the tool, and
+	// PageSize the number of rows returned by each page of its results. See
+	// PaginateRows.
+	MaxRows  int `yaml:"maxRows" validate:"gte=0"`
+	PageSize int `yaml:"pageSize" validate:"gte=0"`
+}
+
+// optionKeys are the keys of Options in a tool config.
+var optionKeys = []string{"examples", "enrichDescription", "tags", "deprecated", "slowThreshold", "rejectUnknownParameters", "numberFormat", "nullColumns", "sqlComment", "enabled", "rowFilter", "columnProjection", "resultSchema", "maxRows", "pageSize"}
+
+// reservedOptionKeys are the keys of Options decoded by tools of a kind
+// rather than as options, by kind.
+var reservedOptionKeys = make(map[string][]string)
+
+// ReserveOptionKey keeps key in the configs of the tools of kind, for tool
+// kinds which decode a field named as an option themselves, such as kinds
+// which had the field before the option was added. It is typically called
+// from an init() function in the tool's package.
+func ReserveOptionKey(kind, key string) {
+	reservedOptionKeys[kind] = append(reservedOptionKeys[kind], key)
+}
+
+// IsZero reports whether no option is set.
+func (o Options) IsZero() bool {
+	return len(o.Examples) == 0 && !o.EnrichDescription && len(o.Tags) == 0 && len(o.Descriptions) == 0 && o.Deprecated == "" && o.SlowThreshold == "" && o.RejectUnknownParameters == nil && o.NumberFormat == "" && o.NullColumns == "" && o.SQLComment == nil && o.Enabled == nil && o.RowFilter == "" && o.ColumnProjection == nil && len(o.ResultSchema) == 0 && o.MaxRows == 0 && o.PageSize == 0
+}
+
+// ExtractOptions removes the fields of Options from a raw tool config and
+// returns them, so that the rest of the config can be decoded by the tool.
+func ExtractOptions(ctx context.Context, v map[string]any) (Options, error) {
+	var opts Options
+	descs, err := ExtractDescriptions(v)
+	if err != nil {
+		return opts, err
+	}
+	opts.Descriptions = descs
+
+	kind, _ := v["kind"].(string)
+	raw := make(map[string]any)
+	for _, key := range optionKeys {
+		if slices.Contains(reservedOptionKeys[kind], key) {
+			continue
+		}
+		if val, ok := v[key]; ok {
+			raw[key] = val
+			delete(v, key)
+		}
+	}
+	if len(raw) == 0 {
+		return opts, nil
+	}
+	dec, err := util.NewStrictDecoder(raw)
+	if err != nil {
+		return opts, fmt.Errorf("error creating decoder: %w", err)
+	}
+	if err := dec.DecodeContext(ctx, &opts); err != nil {
+		return opts, err
+	}
+	if opts.SlowThreshold != "" {
+		if _, err := time.ParseDuration(opts.SlowThreshold); err != nil {
+			return opts, fmt.Errorf("unable to parse slowThreshold as time.Duration: %w", err)
+		}
+	}
+	if opts.RowFilter != "" {
+		if _, err := parseRowFilter(opts.RowFilter); err != nil {
+			return opts, err
+		}
+	}
+	return opts, nil
+}
+
+// ConfigWithOptions is a ToolConfig with Options applied to the tool it
+// initializes.
+type ConfigWithOptions struct {
+	ToolConfig
+	Options Options
+}
+
+// validate interface
+var _ ShellToolConfig = ConfigWithOptions{}
+
+// WithOptions returns a ToolConfig applying opts to the tool initialized by
+// cfg, or cfg itself if no option is set.
+func WithOptions(cfg ToolConfig, opts Options) ToolConfig {
+	if opts.IsZero() {
+		return cfg
+	}
+	return ConfigWithOptions{ToolConfig: cfg, Options: opts}
+}
+
+// WithDefaultRejectUnknownParameters returns a ToolConfig rejecting unknown
+// parameters, unless cfg sets rejectUnknownParameters itself.
+func WithDefaultRejectUnknownParameters(cfg ToolConfig) ToolConfig {
+	reject := true
+	oc, ok := cfg.(ConfigWithOptions)
+	if !ok {
+		return WithOptions(cfg, Options{RejectUnknownParameters: &reject})
+	}
+	if oc.Options.RejectUnknownParameters == nil {
+		oc.Options.RejectUnknownParameters = &reject
+	}
+	return oc
+}
+
+// WithDefaultSQLComment returns a ToolConfig tagging SQL statements with
+// comments, unless cfg sets sqlComment itself.
+func WithDefaultSQLComment(cfg ToolConfig) ToolConfig {
+	tag := true
+	oc, ok := cfg.(ConfigWithOptions)
+	if !ok {
+		return WithOptions(cfg, Options{SQLComment: &tag})
+	}
+	if oc.Options.SQLComment == nil {
+		oc.Options.SQLComment = &tag
+	}
+	return oc
+}
+
+// Enabled reports whether the tool of cfg is enabled by its configuration.
+func Enabled(cfg ToolConfig) bool {
+	oc, ok := cfg.(ConfigWithOptions)
+	return !ok || oc.Options.Enabled == nil || *oc.Options.Enabled
+}
+
+func (c ConfigWithOptions) Initialize(srcs map[string]sources.Source) (Tool, error) {
+	t, err := c.ToolConfig.Initialize(srcs)
+	if err != nil {
+		return nil, err
+	}
+	// the threshold was validated by ExtractOptions
+	slowThreshold, _ := time.ParseDuration(c.Options.SlowThreshold)
+	return toolWithOptions{Tool: t, options: c.Options, slowThreshold: slowThreshold}, nil
+}
+
+// RunsShellCommands forwards to the wrapped config, so that shell tools with
+// options are still detected.
+func (c ConfigWithOptions) RunsShellCommands() bool {
+	sc, ok := c.ToolConfig.(ShellToolConfig)
+	return ok && sc.RunsShellCommands()
+}
+
+type toolWithOptions struct {
+	Tool
+	options       Options
+	slowThreshold time.Duration
+}
+
+func (t toolWithOptions) Invoke(ctx context.Context, params ParamValues) (any, error) {
+	if t.options.Deprecated != "" {
+		AddWarning(ctx, "this tool is deprecated: %s", t.options.Deprecated)
+	}
+	if t.options.SQLComment != nil && *t.options.SQLComment {
+		ctx = withSQLComment(ctx)
+	}
+	start := time.Now()
+	res, err := t.Tool.Invoke(ctx, params)
+	if elapsed := time.Since(start); t.slowThreshold > 0 && elapsed > t.slowThreshold {
+		AddWarning(ctx, "invocation took %s, longer than the slow threshold of %s", elapsed.Round(time.Millisecond), t.slowThreshold)
+	}
+	return res, err
+}
+
+func (t toolWithOptions) ParseParams(data map[string]any, claims map[string]map[string]any) (ParamValues, error) {
+	params, err := t.Tool.ParseParams(data, claims)
+	if t.options.RejectUnknownParameters == nil || !*t.options.RejectUnknownParameters {
+		return params, err
+	}
+	// unknown parameters are reported along with any other invalid parameter
+	var errs ParamErrors
+	if err != nil && !errors.As(err, &errs) {
+		return nil, err
+	}
+	errs = append(errs, unknownParamErrors(t.Manifest(), data)...)
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return params, nil
+}
+
+// unknownParamErrors returns an error for each key of data which isn't a
+// parameter of the manifest.
+func unknownParamErrors(m Manifest, data map[string]any) ParamErrors {
+	names := make([]string, 0, len(m.Parameters))
+	for _, p := range m.Parameters {
+		names = append(names, p.Name)
+	}
+	var unknown []string
+	for k := range data {
+		if !slices.Contains(names, k) {
+			unknown = append(unknown, k)
+		}
+	}
+	// sorted so the errors are stable
+	slices.Sort(unknown)
+	var errs ParamErrors
+	for _, k := range unknown {
+		err := fmt.Errorf("parameter %q is not a parameter of this tool, which accepts %q", k, names)
+		if len(names) == 0 {
+			err = fmt.Errorf("parameter %q is not a parameter of this tool, which accepts no parameters", k)
+		}
+		errs = append(errs, schema.NewParamError(k, ParamErrorUnknown, err))
+	}
+	return errs
+}
+
+func (t toolWithOptions) Manifest() Manifest {
+	m := t.Tool.Manifest()
+	m.Examples = t.options.Examples
+	m.Tags = t.options.Tags
+	m.Deprecated = t.options.Deprecated
+	m.Descriptions = t.options.Descriptions
+	return m
+}
+
+func (t toolWithOptions) McpManifest() McpManifest {
+	m := t.Tool.McpManifest()
+	m.InputSchema.Examples = t.options.Examples
+	m.Descriptions = t.options.Descriptions
+	return m
+}
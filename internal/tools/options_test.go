@@ -0,0 +1,249 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+type mockToolConfig struct {
+	shell bool
+}
+
+func (c mockToolConfig) ToolConfigKind() string { return "mock" }
+
+func (c mockToolConfig) Initialize(map[string]sources.Source) (tools.Tool, error) {
+	return mockTool{}, nil
+}
+
+func (c mockToolConfig) RunsShellCommands() bool { return c.shell }
+
+type mockTool struct{}
+
+func (mockTool) Invoke(context.Context, tools.ParamValues) (any, error) { return nil, nil }
+func (mockTool) ParseParams(map[string]any, map[string]map[string]any) (tools.ParamValues, error) {
+	return nil, nil
+}
+func (mockTool) Authorized([]string) bool { return true }
+func (mockTool) Manifest() tools.Manifest {
+	return tools.Manifest{Description: "some description"}
+}
+func (mockTool) McpManifest() tools.McpManifest {
+	return tools.McpManifest{Name: "mock", InputSchema: tools.McpToolsSchema{Type: "object"}}
+}
+
+func TestExtractOptions(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	v := map[string]any{
+		"kind":           "mock",
+		"examples":       []any{map[string]any{"id": 1}},
+		"description_ja": "説明",
+		"tags":           []any{"reporting"},
+	}
+	got, err := tools.ExtractOptions(ctx, v)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := tools.Options{
+		Examples:     []map[string]any{{"id": uint64(1)}},
+		Tags:         []string{"reporting"},
+		Descriptions: map[string]string{"ja": "説明"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect options: diff %v", diff)
+	}
+	if diff := cmp.Diff(map[string]any{"kind": "mock"}, v); diff != "" {
+		t.Fatalf("options were not removed from the config: diff %v", diff)
+	}
+
+	if _, err := tools.ExtractOptions(ctx, map[string]any{"examples": "foo"}); err == nil {
+		t.Fatalf("expected an error for invalid examples")
+	}
+	if _, err := tools.ExtractOptions(ctx, map[string]any{"tags": []any{""}}); err == nil {
+		t.Fatalf("expected an error for an empty tag")
+	}
+	if _, err := tools.ExtractOptions(ctx, map[string]any{"slowThreshold": "soon"}); err == nil {
+		t.Fatalf("expected an error for an invalid slowThreshold")
+	}
+	if _, err := tools.ExtractOptions(ctx, map[string]any{"numberFormat": "float"}); err == nil {
+		t.Fatalf("expected an error for an invalid numberFormat")
+	}
+	if _, err := tools.ExtractOptions(ctx, map[string]any{"nullColumns": "skip"}); err == nil {
+		t.Fatalf("expected an error for an invalid nullColumns")
+	}
+	if _, err := tools.ExtractOptions(ctx, map[string]any{"pageSize": -1}); err == nil {
+		t.Fatalf("expected an error for a negative pageSize")
+	}
+	if _, err := tools.ExtractOptions(ctx, map[string]any{"rowFilter": "{{ eq .row.owner"}); err == nil {
+		t.Fatalf("expected an error for an invalid rowFilter")
+	}
+	if _, err := tools.ExtractOptions(ctx, map[string]any{"columnProjection": map[string]any{"roles": map[string]any{"analyst": map[string]any{}}}}); err == nil {
+		t.Fatalf("expected an error for a columnProjection without roleClaim")
+	}
+	if _, err := tools.ExtractOptions(ctx, map[string]any{"columnProjection": map[string]any{"roleClaim": "role", "roles": map[string]any{"agent": map[string]any{"allow": []any{"id"}, "deny": []any{"ssn"}}}}}); err == nil {
+		t.Fatalf("expected an error for a columnProjection allowing and denying columns")
+	}
+	if _, err := tools.ExtractOptions(ctx, map[string]any{"resultSchema": []any{map[string]any{"name": "id", "type": "int"}}}); err == nil {
+		t.Fatalf("expected an error for an invalid resultSchema type")
+	}
+}
+
+func TestWithOptions(t *testing.T) {
+	cfg := mockToolConfig{}
+	if got := tools.WithOptions(cfg, tools.Options{}); got != cfg {
+		t.Fatalf("expected the config to be returned as is without options")
+	}
+
+	examples := []map[string]any{{"id": 1}, {"id": 2}}
+	wrapped := tools.WithOptions(cfg, tools.Options{Examples: examples})
+	tool, err := wrapped.Initialize(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	wantManifest := tools.Manifest{Description: "some description", Examples: examples}
+	if diff := cmp.Diff(wantManifest, tool.Manifest()); diff != "" {
+		t.Fatalf("incorrect manifest: diff %v", diff)
+	}
+	wantMcpManifest := tools.McpManifest{Name: "mock", InputSchema: tools.McpToolsSchema{Type: "object", Examples: examples}}
+	if diff := cmp.Diff(wantMcpManifest, tool.McpManifest()); diff != "" {
+		t.Fatalf("incorrect mcp manifest: diff %v", diff)
+	}
+
+	// shell tools must still be detected once wrapped
+	shell := tools.WithOptions(mockToolConfig{shell: true}, tools.Options{Examples: examples})
+	if sc, ok := shell.(tools.ShellToolConfig); !ok || !sc.RunsShellCommands() {
+		t.Fatalf("expected the wrapped config to run shell commands")
+	}
+}
+
+func TestEnabled(t *testing.T) {
+	disabled, enabled := false, true
+	cfg := mockToolConfig{}
+	if !tools.Enabled(cfg) {
+		t.Fatalf("expected tools to be enabled by default")
+	}
+	if !tools.Enabled(tools.WithOptions(cfg, tools.Options{Enabled: &enabled})) {
+		t.Fatalf("expected the tool to be enabled")
+	}
+	if tools.Enabled(tools.WithOptions(cfg, tools.Options{Enabled: &disabled})) {
+		t.Fatalf("expected the tool to be disabled")
+	}
+}
+
+func TestWithOptionsWarnings(t *testing.T) {
+	cfg := tools.WithOptions(mockToolConfig{}, tools.Options{Deprecated: "use new_tool instead", SlowThreshold: "1ns"})
+	tool, err := cfg.Initialize(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := tool.Manifest().Deprecated; got != "use new_tool instead" {
+		t.Fatalf("incorrect deprecation in manifest: %q", got)
+	}
+
+	ctx := tools.WithWarnings(context.Background())
+	if _, err := tool.Invoke(ctx, nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	got := tools.Warnings(ctx)
+	if len(got) != 2 || got[0] != "this tool is deprecated: use new_tool instead" || !strings.HasPrefix(got[1], "invocation took ") {
+		t.Fatalf("unexpected warnings: %q", got)
+	}
+}
+
+// paramsTool is a tool declaring a single parameter.
+type paramsTool struct {
+	mockTool
+}
+
+func (paramsTool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(tools.Parameters{tools.NewIntParameter("limit", "max rows")}, data, claims)
+}
+
+func (paramsTool) Manifest() tools.Manifest {
+	return tools.Manifest{Parameters: []tools.ParameterManifest{{Name: "limit", Type: "integer", Required: true}}}
+}
+
+type paramsToolConfig struct {
+	mockToolConfig
+}
+
+func (paramsToolConfig) Initialize(map[string]sources.Source) (tools.Tool, error) {
+	return paramsTool{}, nil
+}
+
+func TestRejectUnknownParameters(t *testing.T) {
+	reject := false
+	tcs := []struct {
+		desc    string
+		cfg     tools.ToolConfig
+		in      map[string]any
+		wantErr []string
+	}{
+		{
+			desc: "unknown parameters ignored by default",
+			cfg:  paramsToolConfig{},
+			in:   map[string]any{"limit": 1, "lmit": 1},
+		},
+		{
+			desc:    "unknown parameters rejected",
+			cfg:     tools.WithDefaultRejectUnknownParameters(paramsToolConfig{}),
+			in:      map[string]any{"limit": 1, "lmit": 1, "order": "asc"},
+			wantErr: []string{"lmit", "order"},
+		},
+		{
+			desc:    "reported with other invalid parameters",
+			cfg:     tools.WithDefaultRejectUnknownParameters(paramsToolConfig{}),
+			in:      map[string]any{"lmit": 1},
+			wantErr: []string{"limit", "lmit"},
+		},
+		{
+			desc: "tool overrides the default",
+			cfg:  tools.WithDefaultRejectUnknownParameters(tools.WithOptions(paramsToolConfig{}, tools.Options{RejectUnknownParameters: &reject})),
+			in:   map[string]any{"limit": 1, "lmit": 1},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			tool, err := tc.cfg.Initialize(nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			_, err = tool.ParseParams(tc.in, nil)
+			var got []string
+			var paramErrs tools.ParamErrors
+			if errors.As(err, &paramErrs) {
+				for _, pe := range paramErrs {
+					got = append(got, pe.Name)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.wantErr, got); diff != "" {
+				t.Fatalf("incorrect rejected parameters: diff %v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oraclesql_test
+
+import (
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/oracle/oraclesql"
+)
+
+func TestParseFromYamlOracle(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: oracle-sql
+					source: my-oracle-instance
+					description: some description
+					statement: |
+						SELECT * FROM SALES.ORDERS WHERE REGION = :region
+					authRequired:
+						- my-google-auth-service
+					parameters:
+						- name: region
+						  type: string
+						  description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": oraclesql.Config{
+					Name:         "example_tool",
+					Kind:         "oracle-sql",
+					Source:       "my-oracle-instance",
+					Description:  "some description",
+					Statement:    "SELECT * FROM SALES.ORDERS WHERE REGION = :region\n",
+					AuthRequired: []string{"my-google-auth-service"},
+					Parameters: []tools.Parameter{
+						tools.NewStringParameter("region", "some description"),
+					},
+				},
+			},
+		},
+		{
+			desc: "with template parameters",
+			in: `
+			tools:
+				example_tool:
+					kind: oracle-sql
+					source: my-oracle-instance
+					description: some description
+					statement: |
+						SELECT * FROM {{.tableName}}
+					templateParameters:
+						- name: tableName
+						  type: string
+						  description: The table to select from.
+			`,
+			want: server.ToolConfigs{
+				"example_tool": oraclesql.Config{
+					Name:         "example_tool",
+					Kind:         "oracle-sql",
+					Source:       "my-oracle-instance",
+					Description:  "some description",
+					Statement:    "SELECT * FROM {{.tableName}}\n",
+					AuthRequired: []string{},
+					TemplateParameters: []tools.Parameter{
+						tools.NewStringParameter("tableName", "The table to select from."),
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
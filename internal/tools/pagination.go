@@ -0,0 +1,216 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/googleapis/genai-toolbox/internal/tools/schema"
+)
+
+// CursorParameter is the parameter added to tools with the pageSize option,
+// which clients set to the cursor of the page of rows to fetch.
+const CursorParameter = "cursor"
+
+// PaginateRows returns t with the rows of its results limited by the tool's
+// maxRows and pageSize options, or t itself if neither is set. Rows past
+// maxRows are dropped with a warning. With pageSize, a page of at most
+// pageSize rows is returned along with the cursor of the next page, which
+// clients pass as the cursor parameter of the next invocation, with the same
+// other parameters.
+//
+// Tools reading rows one at a time stop once they have read the rows needed,
+// according to RowLimitReached: one past maxRows, or one past the page, to
+// know whether there are more. Every page runs the tool again, so its results
+// should be in a stable order, e.g. with an ORDER BY clause. Results which
+// aren't a list are returned as is.
+func PaginateRows(cfg ToolConfig, t Tool) (Tool, error) {
+	oc, ok := cfg.(ConfigWithOptions)
+	if !ok || (oc.Options.MaxRows == 0 && oc.Options.PageSize == 0) {
+		return t, nil
+	}
+	if oc.Options.PageSize > 0 {
+		for _, p := range t.Manifest().Parameters {
+			if p.Name == CursorParameter {
+				return nil, fmt.Errorf("pageSize adds the %q parameter, which the tool already has", CursorParameter)
+			}
+		}
+	}
+	return toolWithPages{Tool: t, maxRows: oc.Options.MaxRows, pageSize: oc.Options.PageSize}, nil
+}
+
+type rowLimitKey struct{}
+
+// withRowLimit returns a context in which tools read at most n rows, or every
+// row if n is 0.
+func withRowLimit(ctx context.Context, n int) context.Context {
+	return context.WithValue(ctx, rowLimitKey{}, n)
+}
+
+// RowLimitReached returns whether a tool invoked with ctx, which has read n
+// rows, has read every row it needs to return. Tools reading rows one at a
+// time, such as from SQL queries, stop reading once it does, so that the rows
+// past maxRows, or past the page of pageSize rows, aren't read.
+func RowLimitReached(ctx context.Context, n int) bool {
+	limit, _ := ctx.Value(rowLimitKey{}).(int)
+	return limit > 0 && n >= limit
+}
+
+type toolWithPages struct {
+	Tool
+	maxRows  int
+	pageSize int
+}
+
+const cursorDescription = "The cursor of the page of rows to fetch, returned by the previous invocation with the same other parameters. Omit it for the first page."
+
+func (t toolWithPages) Manifest() Manifest {
+	m := t.Tool.Manifest()
+	if t.pageSize > 0 {
+		m.Parameters = append(slices.Clone(m.Parameters), ParameterManifest{
+			Name:         CursorParameter,
+			Type:         "string",
+			Description:  cursorDescription,
+			AuthServices: []string{},
+		})
+	}
+	return m
+}
+
+func (t toolWithPages) McpManifest() McpManifest {
+	m := t.Tool.McpManifest()
+	if t.pageSize > 0 {
+		props := maps.Clone(m.InputSchema.Properties)
+		if props == nil {
+			props = make(map[string]ParameterMcpManifest)
+		}
+		props[CursorParameter] = ParameterMcpManifest{Type: "string", Description: cursorDescription}
+		m.InputSchema.Properties = props
+	}
+	return m
+}
+
+// ParseParams parses the cursor, which is appended to the parameters of the
+// tool as the offset of the page.
+func (t toolWithPages) ParseParams(data map[string]any, claims map[string]map[string]any) (ParamValues, error) {
+	v, ok := data[CursorParameter]
+	if t.pageSize == 0 || !ok {
+		return t.Tool.ParseParams(data, claims)
+	}
+	data = maps.Clone(data)
+	delete(data, CursorParameter)
+	params, err := t.Tool.ParseParams(data, claims)
+	if err != nil {
+		return nil, err
+	}
+	cursor, ok := v.(string)
+	if !ok {
+		return nil, ParamErrors{schema.NewParamError(CursorParameter, ParamErrorTypeMismatch, &ParseTypeError{Name: CursorParameter, Type: "string", Value: v})}
+	}
+	// clients can build cursors, so their offsets are bounded: the read limit
+	// mustn't overflow, which would lift it, and pages past maxRows are empty
+	maxOffset := math.MaxInt - t.pageSize - 1
+	if t.maxRows > 0 {
+		maxOffset = t.maxRows
+	}
+	offset, err := parseCursor(cursor, params, maxOffset)
+	if err != nil {
+		return nil, ParamErrors{schema.NewParamError(CursorParameter, ParamErrorInvalid, err)}
+	}
+	return append(params, ParamValue{Name: CursorParameter, Value: offset}), nil
+}
+
+func (t toolWithPages) Invoke(ctx context.Context, params ParamValues) (any, error) {
+	offset := 0
+	if n := len(params); n > 0 && params[n-1].Name == CursorParameter {
+		offset, _ = params[n-1].Value.(int)
+		params = params[:n-1]
+	}
+	// one more row than returned is read, to know whether there are more
+	limit := 0
+	if t.pageSize > 0 {
+		limit = offset + t.pageSize + 1
+	}
+	if t.maxRows > 0 && (limit == 0 || limit > t.maxRows+1) {
+		limit = t.maxRows + 1
+	}
+	res, err := t.Tool.Invoke(withRowLimit(ctx, limit), params)
+	if err != nil {
+		return res, err
+	}
+	rows, ok := res.([]any)
+	if !ok {
+		return res, nil
+	}
+	if t.maxRows > 0 && len(rows) > t.maxRows {
+		AddWarning(ctx, "the result was limited to its first %d rows", t.maxRows)
+		SetTruncated(ctx)
+		rows = rows[:t.maxRows]
+	}
+	if t.pageSize == 0 {
+		return rows, nil
+	}
+	page := rows[min(offset, len(rows)):]
+	if len(page) > t.pageSize {
+		page = page[:t.pageSize]
+		next := formatCursor(offset+t.pageSize, params)
+		SetNextCursor(ctx, next)
+		SetTruncated(ctx)
+		AddWarning(ctx, "only rows %d to %d were returned; invoke the tool again with the cursor %q for the next rows", offset+1, offset+t.pageSize, next)
+	}
+	return page, nil
+}
+
+// formatCursor returns the cursor of the page at offset of the results of an
+// invocation with params.
+func formatCursor(offset int, params ParamValues) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset) + ":" + paramsDigest(params)))
+}
+
+// parseCursor returns the offset of cursor, or an error if it isn't a cursor
+// of the results of an invocation with params, or its offset is past
+// maxOffset.
+func parseCursor(cursor string, params ParamValues, maxOffset int) (int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cursor %q", cursor)
+	}
+	o, digest, ok := strings.Cut(string(b), ":")
+	offset, err := strconv.Atoi(o)
+	if !ok || err != nil || offset < 0 || offset > maxOffset {
+		return 0, fmt.Errorf("invalid cursor %q", cursor)
+	}
+	if digest != paramsDigest(params) {
+		return 0, fmt.Errorf("cursor %q was returned by an invocation with other parameters", cursor)
+	}
+	return offset, nil
+}
+
+// paramsDigest returns a digest of params, binding cursors to them.
+func paramsDigest(params ParamValues) string {
+	b, _ := json.Marshal(params)
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,278 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+// queryTool is a tool with a "q" parameter returning res.
+type queryTool struct {
+	rowsTool
+}
+
+func (queryTool) ParseParams(data map[string]any, _ map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParamValues{{Name: "q", Value: data["q"]}}, nil
+}
+
+func (queryTool) Manifest() tools.Manifest {
+	return tools.Manifest{Parameters: []tools.ParameterManifest{{Name: "q", Type: "string"}}}
+}
+
+func TestPaginateRows(t *testing.T) {
+	rows := []any{
+		map[string]any{"id": 1}, map[string]any{"id": 2}, map[string]any{"id": 3},
+		map[string]any{"id": 4}, map[string]any{"id": 5},
+	}
+	cfg := tools.WithOptions(mockToolConfig{}, tools.Options{MaxRows: 4, PageSize: 3})
+	tool, err := tools.PaginateRows(cfg, queryTool{rowsTool{res: rows}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := tool.Manifest().Parameters; len(got) != 2 || got[1].Name != tools.CursorParameter || got[1].Required {
+		t.Fatalf("expected an optional cursor parameter, got %v", got)
+	}
+	if _, ok := tool.McpManifest().InputSchema.Properties[tools.CursorParameter]; !ok {
+		t.Fatalf("expected a cursor property in the MCP manifest")
+	}
+
+	invoke := func(data map[string]any) (any, string, []string, bool) {
+		t.Helper()
+		params, err := tool.ParseParams(data, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		ctx := tools.WithWarnings(context.Background())
+		res, err := tool.Invoke(ctx, params)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		return res, tools.NextCursor(ctx), tools.Warnings(ctx), tools.Truncated(ctx)
+	}
+
+	// the first page
+	res, next, warnings, truncated := invoke(map[string]any{"q": "x"})
+	if diff := cmp.Diff(rows[:3], res); diff != "" {
+		t.Fatalf("incorrect first page: diff %v", diff)
+	}
+	if next == "" {
+		t.Fatalf("expected a cursor for the next page")
+	}
+	if len(warnings) != 2 {
+		t.Fatalf("expected warnings for maxRows and the next page, got %q", warnings)
+	}
+	if !truncated {
+		t.Fatalf("expected the first page to be truncated")
+	}
+
+	// the last page stops at maxRows
+	res, last, _, _ := invoke(map[string]any{"q": "x", "cursor": next})
+	if diff := cmp.Diff(rows[3:4], res); diff != "" {
+		t.Fatalf("incorrect last page: diff %v", diff)
+	}
+	if last != "" {
+		t.Fatalf("unexpected cursor after the last page: %q", last)
+	}
+
+	// cursors are bound to the other parameters
+	var errs tools.ParamErrors
+	if _, err := tool.ParseParams(map[string]any{"q": "y", "cursor": next}, nil); !errors.As(err, &errs) || errs[0].Reason != tools.ParamErrorInvalid {
+		t.Fatalf("expected an invalid cursor error, got %v", err)
+	}
+	if _, err := tool.ParseParams(map[string]any{"q": "x", "cursor": "not a cursor"}, nil); !errors.As(err, &errs) || errs[0].Name != tools.CursorParameter {
+		t.Fatalf("expected an invalid cursor error, got %v", err)
+	}
+}
+
+func TestPaginateRowsOptions(t *testing.T) {
+	tool := rowsTool{res: []any{"a", "b"}}
+	got, err := tools.PaginateRows(tools.WithOptions(mockToolConfig{}, tools.Options{Tags: []string{"a"}}), tool)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, ok := got.(rowsTool); !ok {
+		t.Fatalf("expected the tool to be returned as is, got %T", got)
+	}
+
+	// rows past maxRows are dropped without paging
+	got, err = tools.PaginateRows(tools.WithOptions(mockToolConfig{}, tools.Options{MaxRows: 1}), tool)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(got.Manifest().Parameters) != 0 {
+		t.Fatalf("unexpected parameters: %v", got.Manifest().Parameters)
+	}
+	res, err := got.Invoke(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff := cmp.Diff([]any{"a"}, res); diff != "" {
+		t.Fatalf("incorrect result: diff %v", diff)
+	}
+
+	// the cursor parameter can't shadow a parameter of the tool
+	_, err = tools.PaginateRows(tools.WithOptions(mockToolConfig{}, tools.Options{PageSize: 1}), cursorTool{})
+	if err == nil {
+		t.Fatalf("expected an error for a tool with a cursor parameter")
+	}
+}
+
+// readingTool is a tool reading its rows one at a time, as SQL tools do,
+// which records the number of rows it read.
+type readingTool struct {
+	queryTool
+	read *int
+}
+
+func (t readingTool) Invoke(ctx context.Context, _ tools.ParamValues) (any, error) {
+	var out []any
+	for _, row := range t.res.([]any) {
+		if tools.RowLimitReached(ctx, len(out)) {
+			break
+		}
+		out = append(out, row)
+	}
+	*t.read = len(out)
+	return out, nil
+}
+
+func TestPaginateRowsLimit(t *testing.T) {
+	rows := make([]any, 100)
+	for i := range rows {
+		rows[i] = map[string]any{"id": i}
+	}
+	var read int
+	inner := readingTool{queryTool: queryTool{rowsTool{res: rows}}, read: &read}
+	tcs := []struct {
+		desc   string
+		opts   tools.Options
+		filter bool
+		cursor bool
+		want   int
+	}{
+		{desc: "maxRows", opts: tools.Options{MaxRows: 10}, want: 11},
+		{desc: "first page", opts: tools.Options{MaxRows: 10, PageSize: 3}, want: 4},
+		{desc: "second page", opts: tools.Options{MaxRows: 10, PageSize: 3}, cursor: true, want: 7},
+		{desc: "page past maxRows", opts: tools.Options{MaxRows: 4, PageSize: 3}, cursor: true, want: 5},
+		{desc: "row filter", opts: tools.Options{MaxRows: 10, RowFilter: "{{ true }}"}, filter: true, want: 100},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := tools.WithOptions(mockToolConfig{}, tc.opts)
+			var tool tools.Tool = inner
+			if tc.filter {
+				tool = tools.FilterRows(cfg, tool)
+			}
+			tool, err := tools.PaginateRows(cfg, tool)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			data := map[string]any{"q": "x"}
+			if tc.cursor {
+				ctx := tools.WithWarnings(context.Background())
+				params, _ := tool.ParseParams(data, nil)
+				if _, err := tool.Invoke(ctx, params); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				data["cursor"] = tools.NextCursor(ctx)
+			}
+			params, err := tool.ParseParams(data, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if _, err := tool.Invoke(context.Background(), params); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if read != tc.want {
+				t.Fatalf("unexpected number of rows read: got %d, want %d", read, tc.want)
+			}
+		})
+	}
+}
+
+// forgeCursor returns a cursor of the page at offset of the results of an
+// invocation with params, built as a client could.
+func forgeCursor(offset int, params tools.ParamValues) string {
+	b, _ := json.Marshal(params)
+	sum := sha256.Sum256(b)
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset) + ":" + hex.EncodeToString(sum[:8])))
+}
+
+func TestPaginateRowsCursorOffset(t *testing.T) {
+	rows := make([]any, 100)
+	for i := range rows {
+		rows[i] = map[string]any{"id": i}
+	}
+	params := tools.ParamValues{{Name: "q", Value: "x"}}
+	tcs := []struct {
+		desc   string
+		opts   tools.Options
+		offset int
+		valid  bool
+	}{
+		{desc: "offset at maxRows", opts: tools.Options{MaxRows: 10, PageSize: 3}, offset: 10, valid: true},
+		{desc: "offset past maxRows", opts: tools.Options{MaxRows: 10, PageSize: 3}, offset: 11},
+		{desc: "largest offset", opts: tools.Options{PageSize: 3}, offset: math.MaxInt - 4, valid: true},
+		{desc: "overflowing offset", opts: tools.Options{PageSize: 3}, offset: math.MaxInt},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			var read int
+			inner := readingTool{queryTool: queryTool{rowsTool{res: rows}}, read: &read}
+			tool, err := tools.PaginateRows(tools.WithOptions(mockToolConfig{}, tc.opts), inner)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			got, err := tool.ParseParams(map[string]any{"q": "x", "cursor": forgeCursor(tc.offset, params)}, nil)
+			if !tc.valid {
+				var errs tools.ParamErrors
+				if !errors.As(err, &errs) || errs[0].Reason != tools.ParamErrorInvalid {
+					t.Fatalf("expected an invalid cursor error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			res, err := tool.Invoke(context.Background(), got)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if page, _ := res.([]any); len(page) != 0 {
+				t.Fatalf("expected an empty page, got %v", page)
+			}
+		})
+	}
+}
+
+// cursorTool is a tool with its own cursor parameter.
+type cursorTool struct {
+	queryTool
+}
+
+func (cursorTool) Manifest() tools.Manifest {
+	return tools.Manifest{Parameters: []tools.ParameterManifest{{Name: tools.CursorParameter, Type: "string"}}}
+}
@@ -0,0 +1,196 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// PlaceholderStyle is how a database expects parameters in a statement.
+type PlaceholderStyle int
+
+const (
+	// PlaceholderDollar numbers parameters by their position, e.g. $1, as
+	// used by PostgreSQL.
+	PlaceholderDollar PlaceholderStyle = iota
+	// PlaceholderQuestion binds parameters in the order of their ?
+	// placeholders, as used by MySQL and SQLite.
+	PlaceholderQuestion
+	// PlaceholderAt names parameters, e.g. @name, as used by SQL Server.
+	PlaceholderAt
+	// PlaceholderColon keeps the :name placeholders, and binds parameters by
+	// name, as used by Oracle.
+	PlaceholderColon
+)
+
+// ConvertPlaceholders rewrites the :name placeholders of the parameters in
+// statement to style, and returns the rewritten statement with the arguments
+// to execute it with. PlaceholderColon arguments are sql.NamedArg values of
+// the parameters used by the statement. Placeholders in string literals,
+// quoted identifiers and comments, and names that aren't parameters, are left
+// as is. Statements without named placeholders are returned unchanged with
+// the parameters in order, so statements written for the database's own
+// style keep working.
+func ConvertPlaceholders(style PlaceholderStyle, statement string, params ParamValues) (string, []any, error) {
+	positions := make(map[string]int, len(params))
+	values := make(map[string]any, len(params))
+	for i, p := range params {
+		positions[p.Name] = i + 1
+		values[p.Name] = p.Value
+	}
+
+	var b strings.Builder
+	var named []any
+	used := make(map[string]bool, len(params))
+	var numNamed, numPositional int
+	for i := 0; i < len(statement); {
+		c := statement[i]
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			end := quotedEnd(statement, i, c, c)
+			b.WriteString(statement[i:end])
+			i = end
+		case c == '[' && style == PlaceholderAt:
+			end := quotedEnd(statement, i, '[', ']')
+			b.WriteString(statement[i:end])
+			i = end
+		case strings.HasPrefix(statement[i:], "--"):
+			end := strings.IndexByte(statement[i:], '\n')
+			if end < 0 {
+				end = len(statement) - i
+			}
+			b.WriteString(statement[i : i+end])
+			i += end
+		case strings.HasPrefix(statement[i:], "/*"):
+			end := strings.Index(statement[i+2:], "*/")
+			if end < 0 {
+				end = len(statement)
+			} else {
+				end += i + 4
+			}
+			b.WriteString(statement[i:end])
+			i = end
+		case c == '$' && style == PlaceholderDollar:
+			end := dollarQuotedEnd(statement, i)
+			b.WriteString(statement[i:end])
+			i = end
+		case c == '?' && style == PlaceholderQuestion:
+			numPositional++
+			b.WriteByte(c)
+			i++
+		case strings.HasPrefix(statement[i:], "::"):
+			// a PostgreSQL cast, such as $1::int
+			b.WriteString("::")
+			i += 2
+		case c == ':':
+			end := i + 1
+			for end < len(statement) && isIdentifierChar(statement[end], end == i+1) {
+				end++
+			}
+			name := statement[i+1 : end]
+			pos, ok := positions[name]
+			if !ok {
+				b.WriteString(statement[i:end])
+				i = end
+				continue
+			}
+			numNamed++
+			used[name] = true
+			switch style {
+			case PlaceholderDollar:
+				fmt.Fprintf(&b, "$%d", pos)
+			case PlaceholderQuestion:
+				b.WriteByte('?')
+				named = append(named, values[name])
+			case PlaceholderAt:
+				b.WriteString("@" + name)
+			case PlaceholderColon:
+				b.WriteString(statement[i:end])
+			}
+			i = end
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+
+	if numNamed == 0 {
+		return statement, params.AsSlice(), nil
+	}
+	if style == PlaceholderQuestion {
+		if numPositional > 0 {
+			return "", nil, fmt.Errorf("statement mixes named (:name) and positional (?) placeholders")
+		}
+		return b.String(), named, nil
+	}
+	if style == PlaceholderColon {
+		for _, p := range params {
+			if used[p.Name] {
+				named = append(named, sql.Named(p.Name, p.Value))
+			}
+		}
+		return b.String(), named, nil
+	}
+	return b.String(), params.AsSlice(), nil
+}
+
+// quotedEnd returns the index after the quoted section of s starting at i,
+// where a doubled closing quote is an escaped quote.
+func quotedEnd(s string, i int, open, close byte) int {
+	for j := i + 1; j < len(s); j++ {
+		if s[j] != close {
+			continue
+		}
+		if open == close && j+1 < len(s) && s[j+1] == close {
+			j++
+			continue
+		}
+		return j + 1
+	}
+	return len(s)
+}
+
+// dollarQuotedEnd returns the index after the dollar quoted string of s
+// starting at i, such as $$body$$ or $tag$body$tag$. If s doesn't start a
+// dollar quoted string at i, such as for a $1 placeholder, it returns i+1.
+func dollarQuotedEnd(s string, i int) int {
+	j := i + 1
+	for j < len(s) && isIdentifierChar(s[j], j == i+1) {
+		j++
+	}
+	if j >= len(s) || s[j] != '$' {
+		return i + 1
+	}
+	tag := s[i : j+1]
+	end := strings.Index(s[j+1:], tag)
+	if end < 0 {
+		return len(s)
+	}
+	return j + 1 + end + len(tag)
+}
+
+// isIdentifierChar reports whether c can be part of an unquoted identifier,
+// where identifiers can't start with a digit.
+func isIdentifierChar(c byte, first bool) bool {
+	switch {
+	case c == '_', 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z':
+		return true
+	case '0' <= c && c <= '9':
+		return !first
+	}
+	return false
+}
@@ -0,0 +1,135 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+func TestConvertPlaceholders(t *testing.T) {
+	params := tools.ParamValues{
+		{Name: "airline", Value: "CY"},
+		{Name: "number", Value: 123},
+	}
+	tcs := []struct {
+		desc      string
+		style     tools.PlaceholderStyle
+		statement string
+		want      string
+		wantArgs  []any
+	}{
+		{
+			desc:      "dollar",
+			style:     tools.PlaceholderDollar,
+			statement: "SELECT * FROM flights WHERE number = :number AND airline = :airline",
+			want:      "SELECT * FROM flights WHERE number = $2 AND airline = $1",
+			wantArgs:  []any{"CY", 123},
+		},
+		{
+			desc:      "question in order of use",
+			style:     tools.PlaceholderQuestion,
+			statement: "SELECT * FROM flights WHERE number = :number AND airline = :airline OR code = :airline",
+			want:      "SELECT * FROM flights WHERE number = ? AND airline = ? OR code = ?",
+			wantArgs:  []any{123, "CY", "CY"},
+		},
+		{
+			desc:      "at",
+			style:     tools.PlaceholderAt,
+			statement: "SELECT * FROM [my:airline] WHERE airline = :airline",
+			want:      "SELECT * FROM [my:airline] WHERE airline = @airline",
+			wantArgs:  []any{"CY", 123},
+		},
+		{
+			desc:      "strings, identifiers and comments are skipped",
+			style:     tools.PlaceholderDollar,
+			statement: "SELECT ':airline', \":airline\" -- :airline\n/* :airline */ FROM flights WHERE airline = :airline",
+			want:      "SELECT ':airline', \":airline\" -- :airline\n/* :airline */ FROM flights WHERE airline = $1",
+			wantArgs:  []any{"CY", 123},
+		},
+		{
+			desc:      "escaped quotes",
+			style:     tools.PlaceholderQuestion,
+			statement: "SELECT 'it''s :airline' WHERE airline = :airline",
+			want:      "SELECT 'it''s :airline' WHERE airline = ?",
+			wantArgs:  []any{"CY"},
+		},
+		{
+			desc:      "casts and dollar quotes",
+			style:     tools.PlaceholderDollar,
+			statement: "SELECT $$:airline$$, $fn$:number$fn$, :number::text",
+			want:      "SELECT $$:airline$$, $fn$:number$fn$, $2::text",
+			wantArgs:  []any{"CY", 123},
+		},
+		{
+			desc:      "unknown names are left as is",
+			style:     tools.PlaceholderQuestion,
+			statement: "SET @x := 1; SELECT :other, :airline",
+			want:      "SET @x := 1; SELECT :other, ?",
+			wantArgs:  []any{"CY"},
+		},
+		{
+			desc:      "colon binds used parameters by name",
+			style:     tools.PlaceholderColon,
+			statement: "SELECT * FROM flights WHERE airline = :airline OR code = :airline",
+			want:      "SELECT * FROM flights WHERE airline = :airline OR code = :airline",
+			wantArgs:  []any{sql.Named("airline", "CY")},
+		},
+		{
+			desc:      "colon positional statements are unchanged",
+			style:     tools.PlaceholderColon,
+			statement: "SELECT * FROM flights WHERE airline = :1 AND number = :2",
+			want:      "SELECT * FROM flights WHERE airline = :1 AND number = :2",
+			wantArgs:  []any{"CY", 123},
+		},
+		{
+			desc:      "positional statements are unchanged",
+			style:     tools.PlaceholderQuestion,
+			statement: "SELECT * FROM flights WHERE airline = ? AND number = ?",
+			want:      "SELECT * FROM flights WHERE airline = ? AND number = ?",
+			wantArgs:  []any{"CY", 123},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, gotArgs, err := tools.ConvertPlaceholders(tc.style, tc.statement, params)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect statement: diff %v", diff)
+			}
+			if diff := cmp.Diff(tc.wantArgs, gotArgs, cmpopts.EquateComparable(sql.NamedArg{})); diff != "" {
+				t.Fatalf("incorrect args: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestFailConvertPlaceholders(t *testing.T) {
+	params := tools.ParamValues{{Name: "airline", Value: "CY"}}
+	_, _, err := tools.ConvertPlaceholders(tools.PlaceholderQuestion, "SELECT * FROM flights WHERE airline = :airline AND number = ?", params)
+	if err == nil {
+		t.Fatalf("expected an error for mixed placeholders")
+	}
+	want := "statement mixes named (:name) and positional (?) placeholders"
+	if err.Error() != want {
+		t.Fatalf("unexpected error: got %q, want %q", err, want)
+	}
+}
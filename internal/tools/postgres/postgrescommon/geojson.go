@@ -0,0 +1,237 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgrescommon
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// WKB geometry types, and the flags of the extended WKB used by PostGIS.
+const (
+	wkbPoint              = 1
+	wkbLineString         = 2
+	wkbPolygon            = 3
+	wkbMultiPoint         = 4
+	wkbMultiLineString    = 5
+	wkbMultiPolygon       = 6
+	wkbGeometryCollection = 7
+
+	ewkbZ    = 0x80000000
+	ewkbM    = 0x40000000
+	ewkbSRID = 0x20000000
+)
+
+var geometryTypes = map[uint32]string{
+	wkbPoint:              "Point",
+	wkbLineString:         "LineString",
+	wkbPolygon:            "Polygon",
+	wkbMultiPoint:         "MultiPoint",
+	wkbMultiLineString:    "MultiLineString",
+	wkbMultiPolygon:       "MultiPolygon",
+	wkbGeometryCollection: "GeometryCollection",
+}
+
+// GeoJSON decodes the (E)WKB geometry b to a GeoJSON geometry object. M
+// coordinates, which GeoJSON doesn't support, and SRIDs are dropped.
+func GeoJSON(b []byte) (map[string]any, error) {
+	r := wkbReader{b: b}
+	g, err := r.geometry()
+	if err != nil {
+		return nil, err
+	}
+	if r.pos != len(b) {
+		return nil, fmt.Errorf("invalid WKB: %d trailing bytes", len(b)-r.pos)
+	}
+	return g, nil
+}
+
+type wkbReader struct {
+	b     []byte
+	pos   int
+	order binary.ByteOrder
+}
+
+func (r *wkbReader) uint32() (uint32, error) {
+	if r.pos+4 > len(r.b) {
+		return 0, fmt.Errorf("invalid WKB: unexpected end")
+	}
+	v := r.order.Uint32(r.b[r.pos:])
+	r.pos += 4
+	return v, nil
+}
+
+func (r *wkbReader) float64() (float64, error) {
+	if r.pos+8 > len(r.b) {
+		return 0, fmt.Errorf("invalid WKB: unexpected end")
+	}
+	v := math.Float64frombits(r.order.Uint64(r.b[r.pos:]))
+	r.pos += 8
+	return v, nil
+}
+
+func (r *wkbReader) geometry() (map[string]any, error) {
+	if r.pos >= len(r.b) {
+		return nil, fmt.Errorf("invalid WKB: unexpected end")
+	}
+	switch r.b[r.pos] {
+	case 0:
+		r.order = binary.BigEndian
+	case 1:
+		r.order = binary.LittleEndian
+	default:
+		return nil, fmt.Errorf("invalid WKB: unknown byte order %d", r.b[r.pos])
+	}
+	r.pos++
+
+	typ, err := r.uint32()
+	if err != nil {
+		return nil, err
+	}
+	hasZ, hasM := typ&ewkbZ != 0, typ&ewkbM != 0
+	if typ&ewkbSRID != 0 {
+		if _, err := r.uint32(); err != nil {
+			return nil, err
+		}
+	}
+	typ &^= ewkbZ | ewkbM | ewkbSRID
+	// ISO WKB encodes dimensions in the thousands of the type
+	switch typ / 1000 {
+	case 1:
+		hasZ = true
+	case 2:
+		hasM = true
+	case 3:
+		hasZ, hasM = true, true
+	}
+	typ %= 1000
+	name, ok := geometryTypes[typ]
+	if !ok {
+		return nil, fmt.Errorf("invalid WKB: unknown geometry type %d", typ)
+	}
+	dims := 2
+	if hasZ {
+		dims++
+	}
+	if hasM {
+		dims++
+	}
+
+	if typ == wkbGeometryCollection {
+		n, err := r.uint32()
+		if err != nil {
+			return nil, err
+		}
+		geometries := make([]any, 0, n)
+		for i := uint32(0); i < n; i++ {
+			g, err := r.geometry()
+			if err != nil {
+				return nil, err
+			}
+			geometries = append(geometries, g)
+		}
+		return map[string]any{"type": name, "geometries": geometries}, nil
+	}
+
+	coordinates, err := r.coordinates(typ, dims, hasZ)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"type": name, "coordinates": coordinates}, nil
+}
+
+// coordinates reads the coordinates of a geometry of the type typ, whose
+// points have dims values.
+func (r *wkbReader) coordinates(typ uint32, dims int, hasZ bool) (any, error) {
+	switch typ {
+	case wkbPoint:
+		p, err := r.point(dims, hasZ)
+		if err != nil {
+			return nil, err
+		}
+		// empty points are encoded with NaN coordinates
+		if f, _ := p[0].(float64); math.IsNaN(f) {
+			return []any{}, nil
+		}
+		return p, nil
+	case wkbLineString:
+		return r.points(dims, hasZ)
+	case wkbPolygon:
+		n, err := r.uint32()
+		if err != nil {
+			return nil, err
+		}
+		rings := make([]any, 0, n)
+		for i := uint32(0); i < n; i++ {
+			ring, err := r.points(dims, hasZ)
+			if err != nil {
+				return nil, err
+			}
+			rings = append(rings, ring)
+		}
+		return rings, nil
+	default:
+		// multi geometries are a list of complete geometries
+		n, err := r.uint32()
+		if err != nil {
+			return nil, err
+		}
+		parts := make([]any, 0, n)
+		for i := uint32(0); i < n; i++ {
+			g, err := r.geometry()
+			if err != nil {
+				return nil, err
+			}
+			parts = append(parts, g["coordinates"])
+		}
+		return parts, nil
+	}
+}
+
+func (r *wkbReader) points(dims int, hasZ bool) ([]any, error) {
+	n, err := r.uint32()
+	if err != nil {
+		return nil, err
+	}
+	points := make([]any, 0, n)
+	for i := uint32(0); i < n; i++ {
+		p, err := r.point(dims, hasZ)
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, p)
+	}
+	return points, nil
+}
+
+// point reads a point with dims values, keeping X, Y and, if hasZ, Z.
+func (r *wkbReader) point(dims int, hasZ bool) ([]any, error) {
+	keep := 2
+	if hasZ {
+		keep = 3
+	}
+	p := make([]any, 0, keep)
+	for i := 0; i < dims; i++ {
+		v, err := r.float64()
+		if err != nil {
+			return nil, err
+		}
+		if i < keep {
+			p = append(p, v)
+		}
+	}
+	return p, nil
+}
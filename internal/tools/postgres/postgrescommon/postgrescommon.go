@@ -0,0 +1,243 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package postgrescommon decodes the rows of Postgres query results to JSON
+// friendly values, shared by the tools of Postgres compatible sources.
+package postgrescommon
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// RowMap returns the current row of rows as a map of column names to values.
+// Compared to rows.Values, json and jsonb columns keep the precision of their
+// numbers, multidimensional arrays are nested rather than flattened, arrays of
+// types unknown to the driver, such as enums, are decoded to arrays rather than
+// their text representation, and, if geoJSON is set, PostGIS geometries are
+// decoded to GeoJSON rather than hex encoded WKB.
+func RowMap(rows pgx.Rows, geoJSON bool) (map[string]any, error) {
+	values, err := rows.Values()
+	if err != nil {
+		return nil, err
+	}
+	raw := rows.RawValues()
+	var typeMap *pgtype.Map
+	if conn := rows.Conn(); conn != nil {
+		typeMap = conn.TypeMap()
+	}
+
+	vMap := make(map[string]any, len(values))
+	for i, f := range rows.FieldDescriptions() {
+		v := values[i]
+		if v != nil && i < len(raw) {
+			v, err = decode(typeMap, f, raw[i], v, geoJSON)
+			if err != nil {
+				return nil, fmt.Errorf("unable to decode column %q: %w", f.Name, err)
+			}
+		}
+		vMap[f.Name] = v
+	}
+	return vMap, nil
+}
+
+// decode returns the value v of the field f, decoded by pgx from src, with
+// the types pgx decodes lossily decoded from src again.
+func decode(typeMap *pgtype.Map, f pgconn.FieldDescription, src []byte, v any, geoJSON bool) (any, error) {
+	switch f.DataTypeOID {
+	case pgtype.JSONOID, pgtype.JSONBOID:
+		return decodeJSON(f, src)
+	}
+	if typeMap == nil {
+		return v, nil
+	}
+	dt, ok := typeMap.TypeForOID(f.DataTypeOID)
+	if !ok {
+		// types unknown to pgx, such as enums and PostGIS types, are returned
+		// as their text representation
+		s, ok := v.(string)
+		if !ok {
+			return v, nil
+		}
+		if geoJSON {
+			if g, err := GeoJSONFromHex(s); err == nil {
+				return g, nil
+			}
+		}
+		if strings.HasPrefix(s, "{") || strings.HasPrefix(s, "[") {
+			if arr, err := ParseArray(s); err == nil {
+				return arr, nil
+			}
+		}
+		return v, nil
+	}
+	if _, ok := dt.Codec.(*pgtype.ArrayCodec); ok {
+		var arr pgtype.Array[any]
+		if err := typeMap.Scan(f.DataTypeOID, f.Format, src, &arr); err != nil {
+			return nil, err
+		}
+		return nest(arr.Elements, arr.Dims), nil
+	}
+	return v, nil
+}
+
+// decodeJSON decodes the json or jsonb value src, keeping the precision of
+// numbers.
+func decodeJSON(f pgconn.FieldDescription, src []byte) (any, error) {
+	if f.DataTypeOID == pgtype.JSONBOID && f.Format == pgtype.BinaryFormatCode {
+		// binary jsonb is prefixed by a version byte
+		if len(src) == 0 || src[0] != 1 {
+			return nil, fmt.Errorf("unsupported jsonb version")
+		}
+		src = src[1:]
+	}
+	d := json.NewDecoder(bytes.NewReader(src))
+	d.UseNumber()
+	var v any
+	if err := d.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// nest returns the flat elements of an array with the dimensions dims as
+// nested arrays.
+func nest(elements []any, dims []pgtype.ArrayDimension) []any {
+	if len(elements) == 0 {
+		return []any{}
+	}
+	if len(dims) <= 1 {
+		return elements
+	}
+	size := len(elements) / int(dims[0].Length)
+	out := make([]any, dims[0].Length)
+	for i := range out {
+		out[i] = nest(elements[i*size:(i+1)*size], dims[1:])
+	}
+	return out
+}
+
+// ParseArray parses the text representation of a Postgres array, such as
+// {a,"b c",NULL,{d}}, returning its elements as strings or nested arrays.
+func ParseArray(s string) ([]any, error) {
+	// skip the optional dimension decoration, such as [1:2]=
+	if strings.HasPrefix(s, "[") {
+		i := strings.Index(s, "=")
+		if i < 0 {
+			return nil, fmt.Errorf("invalid array %q", s)
+		}
+		s = s[i+1:]
+	}
+	p := arrayParser{s: s}
+	arr, err := p.array()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(p.s) {
+		return nil, fmt.Errorf("invalid array %q: unexpected %q", s, p.s[p.pos:])
+	}
+	return arr, nil
+}
+
+type arrayParser struct {
+	s   string
+	pos int
+}
+
+func (p *arrayParser) array() ([]any, error) {
+	if p.pos >= len(p.s) || p.s[p.pos] != '{' {
+		return nil, fmt.Errorf("invalid array %q: expected '{'", p.s)
+	}
+	p.pos++
+	out := []any{}
+	if p.pos < len(p.s) && p.s[p.pos] == '}' {
+		p.pos++
+		return out, nil
+	}
+	for {
+		elem, err := p.element()
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, elem)
+		if p.pos >= len(p.s) {
+			return nil, fmt.Errorf("invalid array %q: unterminated", p.s)
+		}
+		switch p.s[p.pos] {
+		case ',':
+			p.pos++
+		case '}':
+			p.pos++
+			return out, nil
+		default:
+			return nil, fmt.Errorf("invalid array %q: unexpected %q", p.s, p.s[p.pos])
+		}
+	}
+}
+
+func (p *arrayParser) element() (any, error) {
+	if p.pos >= len(p.s) {
+		return nil, fmt.Errorf("invalid array %q: unterminated", p.s)
+	}
+	switch p.s[p.pos] {
+	case '{':
+		return p.array()
+	case '"':
+		p.pos++
+		var b strings.Builder
+		for p.pos < len(p.s) {
+			c := p.s[p.pos]
+			p.pos++
+			switch c {
+			case '\\':
+				if p.pos < len(p.s) {
+					b.WriteByte(p.s[p.pos])
+					p.pos++
+				}
+			case '"':
+				return b.String(), nil
+			default:
+				b.WriteByte(c)
+			}
+		}
+		return nil, fmt.Errorf("invalid array %q: unterminated string", p.s)
+	default:
+		start := p.pos
+		for p.pos < len(p.s) && p.s[p.pos] != ',' && p.s[p.pos] != '}' {
+			p.pos++
+		}
+		elem := strings.TrimSpace(p.s[start:p.pos])
+		if strings.EqualFold(elem, "NULL") {
+			return nil, nil
+		}
+		return elem, nil
+	}
+}
+
+// GeoJSONFromHex decodes the hex encoded (E)WKB s, the text representation of
+// PostGIS geometries, to GeoJSON.
+func GeoJSONFromHex(s string) (map[string]any, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return GeoJSON(b)
+}
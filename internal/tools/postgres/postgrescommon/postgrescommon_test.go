@@ -0,0 +1,123 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgrescommon_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/tools/postgres/postgrescommon"
+)
+
+func TestParseArray(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		want []any
+	}{
+		{desc: "empty", in: "{}", want: []any{}},
+		{desc: "elements", in: "{happy,sad}", want: []any{"happy", "sad"}},
+		{desc: "quoted and null", in: `{"a b","c,\"d\"",NULL}`, want: []any{"a b", `c,"d"`, nil}},
+		{desc: "nested", in: "{{a,b},{c,d}}", want: []any{[]any{"a", "b"}, []any{"c", "d"}}},
+		{desc: "dimension decoration", in: "[0:1]={a,b}", want: []any{"a", "b"}},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := postgrescommon.ParseArray(tc.in)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect array: diff %v", diff)
+			}
+		})
+	}
+
+	for _, in := range []string{"{a,b", "{a}b", "a,b", `{"a}`} {
+		if _, err := postgrescommon.ParseArray(in); err == nil {
+			t.Fatalf("expected an error for %q", in)
+		}
+	}
+}
+
+func TestGeoJSONFromHex(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		want map[string]any
+	}{
+		{
+			desc: "point",
+			// SELECT ST_GeomFromText('POINT(1 2)')
+			in:   "0101000000000000000000F03F0000000000000040",
+			want: map[string]any{"type": "Point", "coordinates": []any{1.0, 2.0}},
+		},
+		{
+			desc: "point with srid",
+			// SELECT ST_GeomFromText('POINT(1 2)', 4326)
+			in:   "0101000020E6100000000000000000F03F0000000000000040",
+			want: map[string]any{"type": "Point", "coordinates": []any{1.0, 2.0}},
+		},
+		{
+			desc: "point with z",
+			// SELECT ST_GeomFromText('POINT Z(1 2 3)')
+			in:   "0101000080000000000000F03F00000000000000400000000000000840",
+			want: map[string]any{"type": "Point", "coordinates": []any{1.0, 2.0, 3.0}},
+		},
+		{
+			desc: "empty point",
+			// SELECT ST_GeomFromText('POINT EMPTY')
+			in:   "0101000000000000000000F87F000000000000F87F",
+			want: map[string]any{"type": "Point", "coordinates": []any{}},
+		},
+		{
+			desc: "line string",
+			// SELECT ST_GeomFromText('LINESTRING(0 0, 1 1)')
+			in:   "01020000000200000000000000000000000000000000000000000000000000F03F000000000000F03F",
+			want: map[string]any{"type": "LineString", "coordinates": []any{[]any{0.0, 0.0}, []any{1.0, 1.0}}},
+		},
+		{
+			desc: "multi point",
+			// SELECT ST_GeomFromText('MULTIPOINT(0 0, 1 1)')
+			in:   "0104000000020000000101000000000000000000000000000000000000000101000000000000000000F03F000000000000F03F",
+			want: map[string]any{"type": "MultiPoint", "coordinates": []any{[]any{0.0, 0.0}, []any{1.0, 1.0}}},
+		},
+		{
+			desc: "geometry collection",
+			// SELECT ST_GeomFromText('GEOMETRYCOLLECTION(POINT(1 2))')
+			in: "0107000000010000000101000000000000000000F03F0000000000000040",
+			want: map[string]any{"type": "GeometryCollection", "geometries": []any{
+				map[string]any{"type": "Point", "coordinates": []any{1.0, 2.0}},
+			}},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := postgrescommon.GeoJSONFromHex(tc.in)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect GeoJSON: diff %v", diff)
+			}
+		})
+	}
+
+	for _, in := range []string{"happy", "0101000000000000000000F03F", "0101000000000000000000F03F000000000000004000", "0109000000"} {
+		if _, err := postgrescommon.GeoJSONFromHex(in); err == nil {
+			t.Fatalf("expected an error for %q", in)
+		}
+	}
+}
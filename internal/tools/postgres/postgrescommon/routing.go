@@ -0,0 +1,45 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgrescommon
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Routings of the statements of a tool, to the primary or to a replica.
+const (
+	RoutingRead  string = "read"
+	RoutingWrite string = "write"
+)
+
+// readPooler is implemented by the sources with read replicas.
+type readPooler interface {
+	PostgresReadPool(ctx context.Context) *pgxpool.Pool
+}
+
+// ReadPool returns the function choosing the pool of a tool with routing
+// on source, or nil if the statements of the tool run on the primary.
+// Reads on a source without replicas run on the primary.
+func ReadPool(source any, routing string) func(context.Context) *pgxpool.Pool {
+	if routing != RoutingRead {
+		return nil
+	}
+	if s, ok := source.(readPooler); ok {
+		return s.PostgresReadPool
+	}
+	return nil
+}
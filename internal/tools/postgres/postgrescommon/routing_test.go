@@ -0,0 +1,62 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgrescommon_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/googleapis/genai-toolbox/internal/tools/postgres/postgrescommon"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type replicatedSource struct {
+	readPool *pgxpool.Pool
+}
+
+func (s replicatedSource) PostgresReadPool(context.Context) *pgxpool.Pool {
+	return s.readPool
+}
+
+type primarySource struct{}
+
+func TestReadPool(t *testing.T) {
+	replicated := replicatedSource{readPool: &pgxpool.Pool{}}
+	tcs := []struct {
+		desc    string
+		source  any
+		routing string
+		want    *pgxpool.Pool
+	}{
+		{desc: "read", source: replicated, routing: postgrescommon.RoutingRead, want: replicated.readPool},
+		{desc: "write", source: replicated, routing: postgrescommon.RoutingWrite},
+		{desc: "default", source: replicated, routing: ""},
+		{desc: "read without replicas", source: primarySource{}, routing: postgrescommon.RoutingRead},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := postgrescommon.ReadPool(tc.source, tc.routing)
+			if tc.want == nil {
+				if got != nil {
+					t.Fatalf("expected statements to run on the primary")
+				}
+				return
+			}
+			if got == nil || got(context.Background()) != tc.want {
+				t.Fatalf("expected statements to run on the read pool")
+			}
+		})
+	}
+}
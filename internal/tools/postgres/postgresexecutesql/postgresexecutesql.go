@@ -24,6 +24,7 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/sources/cloudsqlpg"
 	"github.com/googleapis/genai-toolbox/internal/sources/postgres"
 	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/postgres/postgrescommon"
 	"github.com/googleapis/genai-toolbox/internal/util"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -61,6 +62,9 @@ type Config struct {
 	Source       string   `yaml:"source" validate:"required"`
 	Description  string   `yaml:"description" validate:"required"`
 	AuthRequired []string `yaml:"authRequired"`
+	Routing      string   `yaml:"routing" validate:"omitempty,oneof=read write"`
+	GeoJSON      bool     `yaml:"geoJSON"`
+	ReadOnly     bool     `yaml:"readOnly"`
 }
 
 // validate interface
@@ -98,7 +102,10 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		Kind:         kind,
 		Parameters:   parameters,
 		AuthRequired: cfg.AuthRequired,
+		GeoJSON:      cfg.GeoJSON,
+		ReadOnly:     cfg.ReadOnly || sources.IsReadOnly(rawS),
 		Pool:         s.PostgresPool(),
+		readPool:     postgrescommon.ReadPool(rawS, cfg.Routing),
 		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
 		mcpManifest:  mcpManifest,
 	}
@@ -113,8 +120,11 @@ type Tool struct {
 	Kind         string           `yaml:"kind"`
 	AuthRequired []string         `yaml:"authRequired"`
 	Parameters   tools.Parameters `yaml:"parameters"`
+	GeoJSON      bool             `yaml:"geoJSON"`
+	ReadOnly     bool             `yaml:"readOnly"`
 
 	Pool        *pgxpool.Pool
+	readPool    func(context.Context) *pgxpool.Pool
 	manifest    tools.Manifest
 	mcpManifest tools.McpManifest
 }
@@ -125,6 +135,11 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 	if !ok {
 		return nil, fmt.Errorf("unable to get cast %s", paramsMap["sql"])
 	}
+	if t.ReadOnly {
+		if err := tools.CheckReadOnly(tools.DialectANSI, sql); err != nil {
+			return nil, err
+		}
+	}
 	// Log the query executed for debugging.
 	logger, err := util.LoggerFromContext(ctx)
 	if err != nil {
@@ -132,29 +147,35 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 	}
 	logger.DebugContext(ctx, "executing `%s` tool query: %s", kind, sql)
 
-	results, err := t.Pool.Query(ctx, sql)
+	results, err := t.pool(ctx).Query(ctx, tools.TagStatement(ctx, sql))
 	if err != nil {
 		return nil, fmt.Errorf("unable to execute query: %w", err)
 	}
-
-	fields := results.FieldDescriptions()
+	defer results.Close()
 
 	var out []any
 	for results.Next() {
-		v, err := results.Values()
+		if tools.RowLimitReached(ctx, len(out)) {
+			break
+		}
+		vMap, err := postgrescommon.RowMap(results, t.GeoJSON)
 		if err != nil {
 			return nil, fmt.Errorf("unable to parse row: %w", err)
 		}
-		vMap := make(map[string]any)
-		for i, f := range fields {
-			vMap[f.Name] = v[i]
-		}
 		out = append(out, vMap)
 	}
 
 	return out, nil
 }
 
+// pool returns the pool to run the statements of the tool on.
+func (t Tool) pool(ctx context.Context) *pgxpool.Pool {
+	if t.readPool != nil {
+		return t.readPool(ctx)
+	}
+	return t.Pool
+}
+
 func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
 	return tools.ParseParams(t.Parameters, data, claims)
 }
@@ -56,6 +56,69 @@ func TestParseFromYamlExecuteSql(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "read only",
+			in: `
+			tools:
+				example_tool:
+					kind: postgres-execute-sql
+					source: my-instance
+					description: some description
+					readOnly: true
+			`,
+			want: server.ToolConfigs{
+				"example_tool": postgresexecutesql.Config{
+					Name:         "example_tool",
+					Kind:         "postgres-execute-sql",
+					Source:       "my-instance",
+					Description:  "some description",
+					AuthRequired: []string{},
+					ReadOnly:     true,
+				},
+			},
+		},
+		{
+			desc: "with geoJSON",
+			in: `
+			tools:
+				example_tool:
+					kind: postgres-execute-sql
+					source: my-instance
+					description: some description
+					geoJSON: true
+			`,
+			want: server.ToolConfigs{
+				"example_tool": postgresexecutesql.Config{
+					Name:         "example_tool",
+					Kind:         "postgres-execute-sql",
+					Source:       "my-instance",
+					Description:  "some description",
+					AuthRequired: []string{},
+					GeoJSON:      true,
+				},
+			},
+		},
+		{
+			desc: "with routing",
+			in: `
+			tools:
+				example_tool:
+					kind: postgres-execute-sql
+					source: my-instance
+					description: some description
+					routing: read
+			`,
+			want: server.ToolConfigs{
+				"example_tool": postgresexecutesql.Config{
+					Name:         "example_tool",
+					Kind:         "postgres-execute-sql",
+					Source:       "my-instance",
+					Description:  "some description",
+					AuthRequired: []string{},
+					Routing:      "read",
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -24,6 +24,7 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/sources/cloudsqlpg"
 	"github.com/googleapis/genai-toolbox/internal/sources/postgres"
 	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/postgres/postgrescommon"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -61,17 +62,27 @@ type Config struct {
 	Description        string           `yaml:"description" validate:"required"`
 	Statement          string           `yaml:"statement" validate:"required"`
 	AuthRequired       []string         `yaml:"authRequired"`
+	Routing            string           `yaml:"routing" validate:"omitempty,oneof=read write"`
 	Parameters         tools.Parameters `yaml:"parameters"`
 	TemplateParameters tools.Parameters `yaml:"templateParameters"`
+	GeoJSON            bool             `yaml:"geoJSON"`
 }
 
 // validate interface
-var _ tools.ToolConfig = Config{}
+var _ tools.StatementToolConfig = Config{}
 
 func (cfg Config) ToolConfigKind() string {
 	return kind
 }
 
+func (cfg Config) ToolSource() string {
+	return cfg.Source
+}
+
+func (cfg Config) ToolStatement() string {
+	return cfg.Statement
+}
+
 func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
 	// verify source exists
 	rawS, ok := srcs[cfg.Source]
@@ -105,7 +116,9 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		AllParams:          allParameters,
 		Statement:          cfg.Statement,
 		AuthRequired:       cfg.AuthRequired,
+		GeoJSON:            cfg.GeoJSON,
 		Pool:               s.PostgresPool(),
+		readPool:           postgrescommon.ReadPool(rawS, cfg.Routing),
 		manifest:           tools.Manifest{Description: cfg.Description, Parameters: paramManifest, AuthRequired: cfg.AuthRequired},
 		mcpManifest:        mcpManifest,
 	}
@@ -114,6 +127,7 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 
 // validate interface
 var _ tools.Tool = Tool{}
+var _ tools.CompletionQuerier = Tool{}
 
 type Tool struct {
 	Name               string           `yaml:"name"`
@@ -122,8 +136,10 @@ type Tool struct {
 	Parameters         tools.Parameters `yaml:"parameters"`
 	TemplateParameters tools.Parameters `yaml:"templateParameters"`
 	AllParams          tools.Parameters `yaml:"allParams"`
+	GeoJSON            bool             `yaml:"geoJSON"`
 
 	Pool        *pgxpool.Pool
+	readPool    func(context.Context) *pgxpool.Pool
 	Statement   string
 	manifest    tools.Manifest
 	mcpManifest tools.McpManifest
@@ -131,7 +147,7 @@ type Tool struct {
 
 func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
 	paramsMap := params.AsMap()
-	newStatement, err := tools.ResolveTemplateParams(t.TemplateParameters, t.Statement, paramsMap)
+	newStatement, err := tools.ResolveTemplateParams(tools.DialectANSI, t.TemplateParameters, t.Statement, paramsMap)
 	if err != nil {
 		return nil, fmt.Errorf("unable to extract template params %w", err)
 	}
@@ -140,30 +156,61 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 	if err != nil {
 		return nil, fmt.Errorf("unable to extract standard params %w", err)
 	}
-	sliceParams := newParams.AsSlice()
-	results, err := t.Pool.Query(ctx, newStatement, sliceParams...)
+	newStatement, sliceParams, err := tools.ConvertPlaceholders(tools.PlaceholderDollar, newStatement, newParams)
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert placeholders: %w", err)
+	}
+	newStatement = tools.TagStatement(ctx, newStatement)
+	results, err := t.pool(ctx).Query(ctx, newStatement, sliceParams...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to execute query: %w", err)
 	}
-
-	fields := results.FieldDescriptions()
+	defer results.Close()
 
 	var out []any
 	for results.Next() {
-		v, err := results.Values()
+		if tools.RowLimitReached(ctx, len(out)) {
+			break
+		}
+		vMap, err := postgrescommon.RowMap(results, t.GeoJSON)
 		if err != nil {
 			return nil, fmt.Errorf("unable to parse row: %w", err)
 		}
-		vMap := make(map[string]any)
-		for i, f := range fields {
-			vMap[f.Name] = v[i]
-		}
 		out = append(out, vMap)
 	}
 
 	return out, nil
 }
 
+// pool returns the pool to run the statements of the tool on.
+func (t Tool) pool(ctx context.Context) *pgxpool.Pool {
+	if t.readPool != nil {
+		return t.readPool(ctx)
+	}
+	return t.Pool
+}
+
+// QueryCompletions runs the completion statement of a parameter.
+func (t Tool) QueryCompletions(ctx context.Context, statement string) ([]string, error) {
+	results, err := t.pool(ctx).Query(ctx, statement)
+	if err != nil {
+		return nil, fmt.Errorf("unable to execute query: %w", err)
+	}
+	defer results.Close()
+
+	values := []string{}
+	for results.Next() {
+		row, err := results.Values()
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse row: %w", err)
+		}
+		if len(row) > 0 && row[0] != nil {
+			values = append(values, fmt.Sprint(row[0]))
+		}
+	}
+	return values, results.Err()
+}
+
 func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
 	return tools.ParseParams(t.AllParams, data, claims)
 }
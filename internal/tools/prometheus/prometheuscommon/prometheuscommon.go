@@ -0,0 +1,125 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheuscommon
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// queryData is the `data` field returned by the query and query_range endpoints.
+type queryData struct {
+	ResultType string          `json:"resultType"`
+	Result     json.RawMessage `json:"result"`
+}
+
+// sample is a [<unix_time>, "<value>"] pair.
+type sample [2]any
+
+type series struct {
+	Metric map[string]string `json:"metric"`
+	Value  *sample           `json:"value"`
+	Values []sample          `json:"values"`
+}
+
+// DecodeResult converts the result of a query into a list of series with
+// RFC 3339 timestamps and numeric values.
+func DecodeResult(data json.RawMessage) (any, error) {
+	var d queryData
+	if err := json.Unmarshal(data, &d); err != nil {
+		return nil, fmt.Errorf("unable to decode query result: %w", err)
+	}
+
+	switch d.ResultType {
+	case "vector", "matrix":
+		var result []series
+		if err := json.Unmarshal(d.Result, &result); err != nil {
+			return nil, fmt.Errorf("unable to decode %s result: %w", d.ResultType, err)
+		}
+		out := make([]any, 0, len(result))
+		for _, s := range result {
+			vMap := map[string]any{"metric": s.Metric}
+			if s.Value != nil {
+				v, err := decodeSample(*s.Value)
+				if err != nil {
+					return nil, err
+				}
+				vMap["timestamp"], vMap["value"] = v["timestamp"], v["value"]
+			}
+			if s.Values != nil {
+				values := make([]any, 0, len(s.Values))
+				for _, sv := range s.Values {
+					v, err := decodeSample(sv)
+					if err != nil {
+						return nil, err
+					}
+					values = append(values, v)
+				}
+				vMap["values"] = values
+			}
+			out = append(out, vMap)
+		}
+		return out, nil
+	case "scalar", "string":
+		var s sample
+		if err := json.Unmarshal(d.Result, &s); err != nil {
+			return nil, fmt.Errorf("unable to decode %s result: %w", d.ResultType, err)
+		}
+		if d.ResultType == "string" {
+			ts, err := decodeTimestamp(s[0])
+			if err != nil {
+				return nil, err
+			}
+			return map[string]any{"timestamp": ts, "value": s[1]}, nil
+		}
+		return decodeSample(s)
+	default:
+		return nil, fmt.Errorf("unsupported result type %q", d.ResultType)
+	}
+}
+
+func decodeSample(s sample) (map[string]any, error) {
+	ts, err := decodeTimestamp(s[0])
+	if err != nil {
+		return nil, err
+	}
+	raw, ok := s[1].(string)
+	if !ok {
+		return nil, fmt.Errorf("unexpected sample value %v", s[1])
+	}
+	f, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse sample value %q: %w", raw, err)
+	}
+	var value any = f
+	// NaN and Inf can't be represented in JSON, so they're kept as strings
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		value = raw
+	}
+	return map[string]any{"timestamp": ts, "value": value}, nil
+}
+
+func decodeTimestamp(v any) (string, error) {
+	f, ok := v.(float64)
+	if !ok {
+		return "", fmt.Errorf("unexpected sample timestamp %v", v)
+	}
+	sec, frac := math.Modf(f)
+	t := time.Unix(int64(sec), int64(math.Round(frac*1e3))*int64(time.Millisecond)).UTC()
+	return t.Format(time.RFC3339Nano), nil
+}
@@ -0,0 +1,77 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheuscommon_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/tools/prometheus/prometheuscommon"
+)
+
+func TestDecodeResult(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		want any
+	}{
+		{
+			desc: "vector",
+			in:   `{"resultType":"vector","result":[{"metric":{"job":"api"},"value":[1735689600.5,"42"]}]}`,
+			want: []any{
+				map[string]any{
+					"metric":    map[string]string{"job": "api"},
+					"timestamp": "2025-01-01T00:00:00.5Z",
+					"value":     float64(42),
+				},
+			},
+		},
+		{
+			desc: "matrix",
+			in:   `{"resultType":"matrix","result":[{"metric":{},"values":[[1735689600,"1.5"],[1735689660,"NaN"]]}]}`,
+			want: []any{
+				map[string]any{
+					"metric": map[string]string{},
+					"values": []any{
+						map[string]any{"timestamp": "2025-01-01T00:00:00Z", "value": 1.5},
+						map[string]any{"timestamp": "2025-01-01T00:01:00Z", "value": "NaN"},
+					},
+				},
+			},
+		},
+		{
+			desc: "scalar",
+			in:   `{"resultType":"scalar","result":[1735689600,"3"]}`,
+			want: map[string]any{"timestamp": "2025-01-01T00:00:00Z", "value": float64(3)},
+		},
+		{
+			desc: "empty vector",
+			in:   `{"resultType":"vector","result":[]}`,
+			want: []any{},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := prometheuscommon.DecodeResult(json.RawMessage(tc.in))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect result: diff %v", diff)
+			}
+		})
+	}
+}
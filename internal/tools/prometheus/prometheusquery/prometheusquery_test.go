@@ -0,0 +1,105 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusquery_test
+
+import (
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/prometheus/prometheusquery"
+)
+
+func TestParseFromYamlPrometheusQuery(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: prometheus-query
+					source: my-prometheus-instance
+					description: some description
+					statement: sum(rate(http_requests_total[5m]))
+			`,
+			want: server.ToolConfigs{
+				"example_tool": prometheusquery.Config{
+					Name:         "example_tool",
+					Kind:         "prometheus-query",
+					Source:       "my-prometheus-instance",
+					Description:  "some description",
+					Statement:    "sum(rate(http_requests_total[5m]))",
+					AuthRequired: []string{},
+				},
+			},
+		},
+		{
+			desc: "with template parameters",
+			in: `
+			tools:
+				example_tool:
+					kind: prometheus-query
+					source: my-prometheus-instance
+					description: some description
+					statement: sum by (instance) (rate(http_requests_total{job="{{.job}}"}[5m]))
+					authRequired:
+						- my-google-auth-service
+					templateParameters:
+						- name: job
+						  type: string
+						  description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": prometheusquery.Config{
+					Name:         "example_tool",
+					Kind:         "prometheus-query",
+					Source:       "my-prometheus-instance",
+					Description:  "some description",
+					Statement:    "sum by (instance) (rate(http_requests_total{job=\"{{.job}}\"}[5m]))",
+					AuthRequired: []string{"my-google-auth-service"},
+					TemplateParameters: []tools.Parameter{
+						tools.NewStringParameter("job", "some description"),
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
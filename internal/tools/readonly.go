@@ -0,0 +1,320 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// readOnlyStatements are the keywords starting the statements allowed in
+// read-only mode.
+var readOnlyStatements = []string{"SELECT", "WITH", "VALUES", "TABLE", "SHOW", "EXPLAIN", "DESCRIBE", "DESC"}
+
+// googleSQLReadOnlyStatements are the keywords starting the statements also
+// allowed in read-only mode in GoogleSQL, such as the queries of Spanner
+// Graph.
+var googleSQLReadOnlyStatements = []string{"GRAPH"}
+
+// writeKeywords are the keywords rejected anywhere in a statement in
+// read-only mode, since they can write from within an allowed statement,
+// such as a data-modifying CTE, SELECT ... INTO or EXPLAIN ANALYZE.
+var writeKeywords = []string{
+	"INSERT", "UPDATE", "DELETE", "MERGE", "UPSERT", "REPLACE", "INTO", "TRUNCATE",
+	"CREATE", "ALTER", "DROP", "RENAME", "GRANT", "REVOKE",
+	"CALL", "EXEC", "EXECUTE", "ANALYZE",
+}
+
+// sqlServerWriteKeywords are the statements SQL Server runs after another one
+// without a separating semicolon, which are rejected anywhere as well.
+var sqlServerWriteKeywords = []string{"BACKUP", "RESTORE", "DBCC", "KILL", "SHUTDOWN", "RECONFIGURE", "DENY", "BULK", "WRITETEXT", "UPDATETEXT"}
+
+// writeFunctions are the write keywords which are also the names of
+// functions, when followed by a parenthesis.
+var writeFunctions = []string{"REPLACE", "INSERT"}
+
+// CheckReadOnly returns an error if the statements of sql, in dialect, may
+// write to the database. Each statement must be a query, such as a SELECT,
+// and mustn't contain keywords of statements writing to the database, such as
+// INSERT, UPDATE, DELETE, DROP or ALTER, outside of strings, quoted
+// identifiers and comments. In GoogleSQL, statements may start with hints,
+// such as @{USE_ADDITIONAL_PARALLELISM=TRUE}.
+//
+// It is a safeguard for tools executing the statements of clients, rather
+// than a guarantee: functions with side effects aren't detected, so these
+// tools should also connect with a user only allowed to read.
+func CheckReadOnly(dialect Dialect, sql string) error {
+	tokens, err := lexSQL(dialect, sql)
+	if err != nil {
+		return fmt.Errorf("unable to check statement in read-only mode: %w", err)
+	}
+	allowed, rejected := readOnlyStatements, writeKeywords
+	switch dialect {
+	case DialectMSSQL:
+		rejected = slices.Concat(writeKeywords, sqlServerWriteKeywords)
+	case DialectGoogleSQL:
+		allowed = slices.Concat(readOnlyStatements, googleSQLReadOnlyStatements)
+	}
+	start, hint := true, false
+	for i, tok := range tokens {
+		switch {
+		case hint:
+			hint = tok != "}"
+		case tok == ";":
+			start = true
+		case tok == "(":
+		case start && dialect == DialectGoogleSQL && tok == "@" && i+1 < len(tokens) && tokens[i+1] == "{":
+			hint = true
+		case start:
+			if !slices.Contains(allowed, tok) {
+				return fmt.Errorf("%s statements are not allowed in read-only mode", tok)
+			}
+			start = false
+		case slices.Contains(rejected, tok):
+			if slices.Contains(writeFunctions, tok) && i+1 < len(tokens) && tokens[i+1] == "(" {
+				continue
+			}
+			return fmt.Errorf("%s is not allowed in read-only mode", tok)
+		}
+	}
+	return nil
+}
+
+// lexSQL returns the tokens of sql in dialect that matter to CheckReadOnly:
+// words, uppercased, and punctuation, one character at a time. Strings,
+// quoted identifiers, comments and whitespace are skipped.
+//
+// DialectANSI is lexed as PostgreSQL, with dollar-quoted strings, E'...'
+// strings and nested comments. Strings whose end depends on settings of the
+// database, such as backslashes escaping quotes with
+// standard_conforming_strings off in PostgreSQL, are rejected rather than
+// guessed. DialectSnowflake has // comments, $$ strings and comments which
+// don't nest.
+func lexSQL(dialect Dialect, sql string) ([]string, error) {
+	var tokens []string
+	for i := 0; i < len(sql); {
+		c := sql[i]
+		rest := sql[i:]
+		var n int
+		var err error
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f' || c == '\v':
+			n = 1
+		case strings.HasPrefix(rest, "--") && (dialect != DialectMySQL || len(rest) == 2 || isSpaceOrControl(rest[2])):
+			n = lineCommentLen(rest)
+		case c == '#' && (dialect == DialectMySQL || dialect == DialectGoogleSQL):
+			n = lineCommentLen(rest)
+		case strings.HasPrefix(rest, "//") && dialect == DialectSnowflake:
+			n = lineCommentLen(rest)
+		case strings.HasPrefix(rest, "/*!") && dialect == DialectMySQL:
+			// the contents of executable comments are statements
+			n = 3
+			for n < len(rest) && '0' <= rest[n] && rest[n] <= '9' {
+				n++
+			}
+		case strings.HasPrefix(rest, "/*"):
+			n, err = blockCommentLen(rest, dialect == DialectANSI)
+		case c == '\'' || c == '"':
+			backslashes := backslashLiteral
+			switch {
+			case dialect == DialectGoogleSQL, dialect == DialectSnowflake && c == '\'':
+				backslashes = backslashEscapes
+			case dialect == DialectMySQL, dialect == DialectANSI && c == '\'':
+				backslashes = backslashAmbiguous
+			}
+			n, err = quotedLen(rest, dialect, backslashes)
+		case c == '`' && dialect == DialectMySQL:
+			n, err = quotedLen(rest, dialect, backslashLiteral)
+		case c == '`' && dialect == DialectGoogleSQL:
+			n, err = quotedLen(rest, dialect, backslashEscapes)
+		case c == '[' && dialect == DialectMSSQL:
+			n, err = closedLen(rest, ']')
+		case c == '$' && dialect == DialectANSI && dollarTag(rest) != "":
+			tag := dollarTag(rest)
+			end := strings.Index(rest[len(tag):], tag)
+			if end < 0 {
+				err = fmt.Errorf("unterminated dollar-quoted string")
+			}
+			n = len(tag) + end + len(tag)
+		case strings.HasPrefix(rest, "$$") && dialect == DialectSnowflake:
+			end := strings.Index(rest[2:], "$$")
+			if end < 0 {
+				err = fmt.Errorf("unterminated dollar-quoted string")
+			}
+			n = 2 + end + 2
+		case isWordStart(rest):
+			n = wordLen(rest)
+			word := rest[:n]
+			// E'...' strings of PostgreSQL escape with backslashes
+			if dialect == DialectANSI && strings.EqualFold(word, "E") && n < len(rest) && rest[n] == '\'' {
+				var m int
+				m, err = quotedLen(rest[n:], dialect, backslashEscapes)
+				n += m
+				break
+			}
+			tokens = append(tokens, strings.ToUpper(word))
+		default:
+			n = 1
+			tokens = append(tokens, string(c))
+		}
+		if err != nil {
+			return nil, err
+		}
+		i += n
+	}
+	return tokens, nil
+}
+
+func isSpaceOrControl(c byte) bool {
+	return c <= ' '
+}
+
+func isWordStart(s string) bool {
+	r, _ := utf8.DecodeRuneInString(s)
+	return r == '_' || r == '@' || unicode.IsLetter(r)
+}
+
+// wordLen returns the length of the word starting s, which continues with
+// letters, digits, $ and _.
+func wordLen(s string) int {
+	n := 0
+	for n < len(s) {
+		r, size := utf8.DecodeRuneInString(s[n:])
+		if n > 0 && !(r == '_' || r == '$' || r == '@' || unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			break
+		}
+		n += size
+	}
+	return n
+}
+
+// lineCommentLen returns the length of the comment starting s, up to the end
+// of its line.
+func lineCommentLen(s string) int {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return i + 1
+	}
+	return len(s)
+}
+
+// blockCommentLen returns the length of the /* comment starting s, which
+// may contain nested comments if nested.
+func blockCommentLen(s string, nested bool) (int, error) {
+	depth := 0
+	for i := 0; i+1 < len(s); i++ {
+		switch {
+		case s[i] == '/' && s[i+1] == '*':
+			if depth == 0 || nested {
+				depth++
+			}
+			i++
+		case s[i] == '*' && s[i+1] == '/':
+			depth--
+			i++
+			if depth == 0 {
+				return i + 1, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("unterminated comment")
+}
+
+// backslashes is how backslashes are processed in a quoted string.
+type backslashes int
+
+const (
+	// backslashLiteral strings don't escape with backslashes.
+	backslashLiteral backslashes = iota
+	// backslashEscapes strings escape the next character with a backslash.
+	backslashEscapes
+	// backslashAmbiguous strings escape with backslashes depending on
+	// settings of the database, so quotes preceded by a backslash are
+	// rejected, since either end of the string may be right.
+	backslashAmbiguous
+)
+
+// quotedLen returns the length of the string or identifier starting s,
+// delimited by its first character, which is escaped by doubling it, or
+// with backslashes as set.
+func quotedLen(s string, dialect Dialect, backslashes backslashes) (int, error) {
+	q := s[0]
+	// triple-quoted strings of GoogleSQL
+	if dialect == DialectGoogleSQL && q != '`' && len(s) >= 3 && s[1] == q && s[2] == q {
+		delim := s[:3]
+		for i := 3; i < len(s); i++ {
+			if s[i] == '\\' {
+				i++
+				continue
+			}
+			if strings.HasPrefix(s[i:], delim) {
+				return i + 3, nil
+			}
+		}
+		return 0, fmt.Errorf("unterminated string")
+	}
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			switch {
+			case backslashes == backslashAmbiguous && i+1 < len(s) && s[i+1] == q:
+				return 0, fmt.Errorf("ambiguous backslash before a quote in string")
+			case backslashes != backslashLiteral:
+				i++
+			}
+		case q:
+			if i+1 < len(s) && s[i+1] == q && dialect != DialectGoogleSQL {
+				i++
+				continue
+			}
+			return i + 1, nil
+		}
+	}
+	return 0, fmt.Errorf("unterminated string")
+}
+
+// closedLen returns the length of the identifier starting s, up to close,
+// which is escaped by doubling it.
+func closedLen(s string, close byte) (int, error) {
+	for i := 1; i < len(s); i++ {
+		if s[i] == close {
+			if i+1 < len(s) && s[i+1] == close {
+				i++
+				continue
+			}
+			return i + 1, nil
+		}
+	}
+	return 0, fmt.Errorf("unterminated identifier")
+}
+
+// dollarTag returns the $tag$ starting s, or "" if s doesn't start a
+// dollar-quoted string, such as with a positional parameter like $1.
+func dollarTag(s string) string {
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '$':
+			return s[:i+1]
+		case c == '_' || c >= 0x80 || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z':
+		case '0' <= c && c <= '9' && i > 1:
+		default:
+			return ""
+		}
+	}
+	return ""
+}
@@ -0,0 +1,93 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"testing"
+
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+func TestCheckReadOnly(t *testing.T) {
+	tcs := []struct {
+		desc    string
+		dialect tools.Dialect
+		sql     string
+		allowed bool
+	}{
+		{desc: "select", dialect: tools.DialectANSI, sql: "SELECT * FROM users WHERE id = $1;", allowed: true},
+		{desc: "lowercase select", dialect: tools.DialectMySQL, sql: "select id from users", allowed: true},
+		{desc: "cte", dialect: tools.DialectANSI, sql: "WITH a AS (SELECT 1) SELECT * FROM a", allowed: true},
+		{desc: "parenthesized select", dialect: tools.DialectANSI, sql: "(SELECT 1) UNION (SELECT 2)", allowed: true},
+		{desc: "show", dialect: tools.DialectMySQL, sql: "SHOW TABLES", allowed: true},
+		{desc: "explain", dialect: tools.DialectANSI, sql: "EXPLAIN SELECT 1", allowed: true},
+		{desc: "several selects", dialect: tools.DialectMySQL, sql: "SELECT 1; SELECT 2;", allowed: true},
+		{desc: "keyword in string", dialect: tools.DialectANSI, sql: "SELECT 'DROP TABLE users' AS q", allowed: true},
+		{desc: "keyword in quoted identifier", dialect: tools.DialectANSI, sql: `SELECT "update" FROM t`, allowed: true},
+		{desc: "keyword in mysql identifier", dialect: tools.DialectMySQL, sql: "SELECT `delete` FROM t", allowed: true},
+		{desc: "keyword in mssql identifier", dialect: tools.DialectMSSQL, sql: "SELECT [drop] FROM t", allowed: true},
+		{desc: "keyword in comment", dialect: tools.DialectANSI, sql: "SELECT 1 -- then DELETE\n/* and DROP */", allowed: true},
+		{desc: "keyword in dollar-quoted string", dialect: tools.DialectANSI, sql: "SELECT $tag$ '; DROP TABLE t; $tag$", allowed: true},
+		{desc: "keyword in googlesql triple-quoted string", dialect: tools.DialectGoogleSQL, sql: `SELECT """it's "; DROP""" AS s`, allowed: true},
+		{desc: "replace function", dialect: tools.DialectMySQL, sql: "SELECT REPLACE(name, 'a', 'b') FROM t", allowed: true},
+		{desc: "escaped quote", dialect: tools.DialectGoogleSQL, sql: `SELECT 'it\'s; DROP TABLE t'`, allowed: true},
+		{desc: "doubled quote", dialect: tools.DialectANSI, sql: "SELECT 'it''s; DROP TABLE t'", allowed: true},
+		{desc: "mssql backslash is literal", dialect: tools.DialectMSSQL, sql: `SELECT 'C:\'`, allowed: true},
+		{desc: "postgres escape string", dialect: tools.DialectANSI, sql: `SELECT E'it\'s; DROP TABLE t'`, allowed: true},
+		{desc: "snowflake escaped quote", dialect: tools.DialectSnowflake, sql: `SELECT 'it\'s; DROP TABLE t'`, allowed: true},
+		{desc: "keyword in snowflake dollar-quoted string", dialect: tools.DialectSnowflake, sql: "SELECT $$ '; DROP TABLE t; $$", allowed: true},
+		{desc: "keyword in snowflake comment", dialect: tools.DialectSnowflake, sql: "SELECT 1 // then DELETE '\n", allowed: true},
+		{desc: "googlesql statement hint", dialect: tools.DialectGoogleSQL, sql: "@{USE_ADDITIONAL_PARALLELISM=TRUE} SELECT * FROM t", allowed: true},
+		{desc: "googlesql graph query", dialect: tools.DialectGoogleSQL, sql: "GRAPH FinGraph MATCH (p:Person) RETURN p.name", allowed: true},
+		{desc: "insert", dialect: tools.DialectANSI, sql: "INSERT INTO t VALUES (1)"},
+		{desc: "update", dialect: tools.DialectMySQL, sql: "update t set a = 1"},
+		{desc: "delete", dialect: tools.DialectMSSQL, sql: "DELETE FROM t"},
+		{desc: "drop", dialect: tools.DialectGoogleSQL, sql: "DROP TABLE t"},
+		{desc: "alter", dialect: tools.DialectANSI, sql: "ALTER TABLE t ADD COLUMN c int"},
+		{desc: "second statement", dialect: tools.DialectANSI, sql: "SELECT 1; DROP TABLE t"},
+		{desc: "data-modifying cte", dialect: tools.DialectANSI, sql: "WITH d AS (DELETE FROM t RETURNING *) SELECT * FROM d"},
+		{desc: "select into", dialect: tools.DialectMSSQL, sql: "SELECT * INTO copy FROM t"},
+		{desc: "explain analyze", dialect: tools.DialectANSI, sql: "EXPLAIN ANALYZE DELETE FROM t"},
+		{desc: "mssql statement without separator", dialect: tools.DialectMSSQL, sql: "SELECT 1 SHUTDOWN"},
+		{desc: "mssql writetext without separator", dialect: tools.DialectMSSQL, sql: "SELECT 1 WRITETEXT t.c @p 'x'"},
+		{desc: "mssql updatetext without separator", dialect: tools.DialectMSSQL, sql: "SELECT 1 UPDATETEXT t.c @p 0 NULL 'x'"},
+		{desc: "mssql dynamic sql", dialect: tools.DialectMSSQL, sql: "SELECT 1 EXEC('DROP TABLE t')"},
+		{desc: "mysql executable comment", dialect: tools.DialectMySQL, sql: "SELECT 1 /*!50000 INTO OUTFILE '/tmp/x' */"},
+		{desc: "mysql -- needs a space", dialect: tools.DialectMySQL, sql: "SELECT 1 --1\nINTO OUTFILE '/tmp/x'"},
+		{desc: "mysql --x isn't a comment", dialect: tools.DialectMySQL, sql: "SELECT 1 --1 INTO OUTFILE '/tmp/x'"},
+		{desc: "mysql comments don't nest", dialect: tools.DialectMySQL, sql: "SELECT 1 /* /* */ INTO OUTFILE '/tmp/x' -- */"},
+		{desc: "postgres comments nest", dialect: tools.DialectANSI, sql: "SELECT 1 /* /* */ DROP TABLE t; */", allowed: true},
+		{desc: "postgres backslash before quote", dialect: tools.DialectANSI, sql: `SELECT 'a\' ; DROP TABLE t; -- '`},
+		{desc: "mysql backslash before quote", dialect: tools.DialectMySQL, sql: `SELECT 'a\' INTO OUTFILE '/tmp/x' -- '`},
+		{desc: "snowflake comments don't nest", dialect: tools.DialectSnowflake, sql: "SELECT 1 /* /* */ ; DROP TABLE t; -- */"},
+		{desc: "snowflake identifiers don't escape", dialect: tools.DialectSnowflake, sql: `SELECT "a\"; DROP TABLE t; -- "`},
+		{desc: "googlesql hint before a write", dialect: tools.DialectGoogleSQL, sql: "@{PDML_MAX_PARALLELISM=10} DELETE FROM t WHERE true"},
+		{desc: "graph is a googlesql statement only", dialect: tools.DialectANSI, sql: "GRAPH g MATCH (n) RETURN n"},
+		{desc: "unterminated string", dialect: tools.DialectANSI, sql: "SELECT 'a"},
+		{desc: "unterminated comment", dialect: tools.DialectANSI, sql: "SELECT 1 /* DROP"},
+		{desc: "positional parameter isn't a dollar quote", dialect: tools.DialectANSI, sql: "SELECT $1; DROP TABLE t; SELECT $1"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tools.CheckReadOnly(tc.dialect, tc.sql)
+			if tc.allowed && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !tc.allowed && err == nil {
+				t.Fatalf("expected %q to be rejected", tc.sql)
+			}
+		})
+	}
+}
@@ -16,11 +16,13 @@ package redis
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	redissrc "github.com/googleapis/genai-toolbox/internal/sources/redis"
 	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/rediscommon"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/redis/go-redis/v9"
 )
@@ -56,6 +58,7 @@ type Config struct {
 	Source       string           `yaml:"source" validate:"required"`
 	Description  string           `yaml:"description" validate:"required"`
 	Commands     [][]string       `yaml:"commands" validate:"required"`
+	KeyPrefixes  []string         `yaml:"keyPrefixes"`
 	AuthRequired []string         `yaml:"authRequired"`
 	Parameters   tools.Parameters `yaml:"parameters"`
 }
@@ -80,6 +83,18 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
 	}
 
+	if len(cfg.KeyPrefixes) > 0 {
+		if err := rediscommon.ValidateKeyPrefixes(cfg.KeyPrefixes); err != nil {
+			return nil, err
+		}
+		for _, cmd := range cfg.Commands {
+			// commands named by a parameter are checked when invoked
+			if len(cmd) > 0 && !strings.HasPrefix(cmd[0], "$") && !rediscommon.SupportsCommand(cmd[0]) {
+				return nil, fmt.Errorf("command %q is not allowed with keyPrefixes: its keys can't be checked", cmd[0])
+			}
+		}
+	}
+
 	mcpManifest := tools.McpManifest{
 		Name:        cfg.Name,
 		Description: cfg.Description,
@@ -92,6 +107,7 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		Kind:         kind,
 		Parameters:   cfg.Parameters,
 		Commands:     cfg.Commands,
+		KeyPrefixes:  cfg.KeyPrefixes,
 		AuthRequired: cfg.AuthRequired,
 		Client:       s.RedisClient(),
 		manifest:     tools.Manifest{Description: cfg.Description, Parameters: cfg.Parameters.Manifest(), AuthRequired: cfg.AuthRequired},
@@ -111,6 +127,7 @@ type Tool struct {
 
 	Client      redissrc.RedisClient
 	Commands    [][]string
+	KeyPrefixes []string
 	manifest    tools.Manifest
 	mcpManifest tools.McpManifest
 }
@@ -121,10 +138,23 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 		return nil, fmt.Errorf("error replacing commands' parameters: %s", err)
 	}
 
+	// Check the keys of every command before executing any
+	if len(t.KeyPrefixes) > 0 {
+		for i, cmd := range cmds {
+			if err := rediscommon.CheckKeys(cmd, t.KeyPrefixes); err != nil {
+				return nil, fmt.Errorf("command at index %d was rejected: %w", i, err)
+			}
+		}
+	}
+
 	// Execute commands
 	responses := make([]*redis.Cmd, len(cmds))
 	for i, cmd := range cmds {
-		responses[i] = t.Client.Do(ctx, cmd...)
+		args := make([]any, len(cmd))
+		for j, arg := range cmd {
+			args[j] = arg
+		}
+		responses[i] = t.Client.Do(ctx, args...)
 	}
 	// Parse responses
 	out := make([]any, len(t.Commands))
@@ -178,16 +208,16 @@ func (t Tool) Authorized(verifiedAuthServices []string) bool {
 
 // replaceCommandsParams is a helper function to replace parameters in the commands
 
-func replaceCommandsParams(commands [][]string, params tools.Parameters, paramValues tools.ParamValues) ([][]any, error) {
+func replaceCommandsParams(commands [][]string, params tools.Parameters, paramValues tools.ParamValues) ([][]string, error) {
 	paramMap := paramValues.AsMapWithDollarPrefix()
 	typeMap := make(map[string]string, len(params))
 	for _, p := range params {
 		placeholder := "$" + p.GetName()
 		typeMap[placeholder] = p.GetType()
 	}
-	newCommands := make([][]any, len(commands))
+	newCommands := make([][]string, len(commands))
 	for i, cmd := range commands {
-		newCmd := make([]any, 0)
+		newCmd := make([]string, 0)
 		for _, part := range cmd {
 			v, ok := paramMap[part]
 			if !ok {
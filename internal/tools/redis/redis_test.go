@@ -65,6 +65,31 @@ func TestParseFromYamlRedis(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "with key prefixes",
+			in: `
+			tools:
+				redis_tool:
+					kind: redis
+					source: my-redis-instance
+					description: some description
+					commands:
+						- [GET, agent:last]
+					keyPrefixes:
+						- "agent:"
+			`,
+			want: server.ToolConfigs{
+				"redis_tool": redis.Config{
+					Name:         "redis_tool",
+					Kind:         "redis",
+					Source:       "my-redis-instance",
+					Description:  "some description",
+					AuthRequired: []string{},
+					Commands:     [][]string{{"GET", "agent:last"}},
+					KeyPrefixes:  []string{"agent:"},
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
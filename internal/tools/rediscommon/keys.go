@@ -0,0 +1,161 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rediscommon contains utilities shared by the tools of Redis and
+// Valkey sources.
+package rediscommon
+
+import (
+	"fmt"
+	"strings"
+)
+
+// keyPositions is where the keys of a command are in its arguments.
+type keyPositions int
+
+const (
+	// noKeys commands don't access keys.
+	noKeys keyPositions = iota
+	// firstKey commands access the key of their first argument.
+	firstKey
+	// firstTwoKeys commands access the keys of their first two arguments.
+	firstTwoKeys
+	// allKeys commands access the keys of all their arguments.
+	allKeys
+	// keyValuePairs commands access the keys of their odd arguments, which
+	// are followed by values.
+	keyValuePairs
+	// keysPattern commands access the keys matching the pattern of their
+	// first argument.
+	keysPattern
+	// scanPattern commands access the keys matching the pattern of their
+	// MATCH option.
+	scanPattern
+)
+
+// commandKeys are the commands supported by tools restricted to key
+// prefixes, by name. Other commands, such as FLUSHDB or EVAL, are rejected
+// since their keys can't be checked.
+var commandKeys = map[string]keyPositions{}
+
+func init() {
+	for positions, names := range map[keyPositions]string{
+		noKeys: "PING ECHO TIME",
+		firstKey: "GET SET SETNX SETEX PSETEX GETSET GETDEL GETEX APPEND STRLEN INCR INCRBY INCRBYFLOAT DECR DECRBY GETRANGE SETRANGE " +
+			"GETBIT SETBIT BITCOUNT BITPOS EXPIRE EXPIREAT PEXPIRE PEXPIREAT TTL PTTL PERSIST TYPE " +
+			"HSET HSETNX HGET HMSET HMGET HDEL HEXISTS HGETALL HKEYS HVALS HLEN HINCRBY HINCRBYFLOAT HSTRLEN HRANDFIELD HSCAN " +
+			"LPUSH RPUSH LPUSHX RPUSHX LPOP RPOP LLEN LRANGE LINDEX LSET LREM LTRIM LINSERT LPOS " +
+			"SADD SREM SMEMBERS SISMEMBER SMISMEMBER SCARD SPOP SRANDMEMBER SSCAN " +
+			"ZADD ZREM ZSCORE ZMSCORE ZINCRBY ZCARD ZCOUNT ZRANGE ZRANGEBYSCORE ZREVRANGE ZREVRANGEBYSCORE ZRANK ZREVRANK " +
+			"ZREMRANGEBYRANK ZREMRANGEBYSCORE ZRANGEBYLEX ZLEXCOUNT ZPOPMIN ZPOPMAX ZRANDMEMBER ZSCAN " +
+			"PFADD GEOADD GEOPOS GEODIST GEOHASH GEOSEARCH XADD XLEN XRANGE XREVRANGE XDEL XTRIM " +
+			"JSON.GET JSON.SET JSON.DEL JSON.TYPE JSON.STRLEN JSON.ARRAPPEND JSON.ARRLEN JSON.OBJKEYS JSON.NUMINCRBY",
+		firstTwoKeys:  "RENAME RENAMENX SMOVE LMOVE RPOPLPUSH GEOSEARCHSTORE",
+		allKeys:       "DEL UNLINK EXISTS MGET TOUCH SINTER SUNION SDIFF SINTERSTORE SUNIONSTORE SDIFFSTORE PFCOUNT PFMERGE",
+		keyValuePairs: "MSET MSETNX",
+		keysPattern:   "KEYS",
+		scanPattern:   "SCAN",
+	} {
+		for _, name := range strings.Fields(names) {
+			commandKeys[name] = positions
+		}
+	}
+}
+
+// globChars are the characters with a special meaning in key patterns.
+const globChars = `*?[]\`
+
+// ValidateKeyPrefixes checks the key prefixes of a tool, which can't
+// contain characters with a special meaning in key patterns.
+func ValidateKeyPrefixes(prefixes []string) error {
+	for _, p := range prefixes {
+		if p == "" {
+			return fmt.Errorf("key prefixes must not be empty")
+		}
+		if strings.ContainsAny(p, globChars) {
+			return fmt.Errorf("key prefix %q must not contain any of %q", p, globChars)
+		}
+	}
+	return nil
+}
+
+// SupportsCommand reports whether the keys of the command name can be
+// checked against key prefixes.
+func SupportsCommand(name string) bool {
+	_, ok := commandKeys[strings.ToUpper(name)]
+	return ok
+}
+
+// CheckKeys checks that the command cmd, with its arguments, only accesses
+// keys starting with one of prefixes.
+func CheckKeys(cmd []string, prefixes []string) error {
+	if len(cmd) == 0 {
+		return fmt.Errorf("empty command")
+	}
+	name, args := strings.ToUpper(cmd[0]), cmd[1:]
+	positions, ok := commandKeys[name]
+	if !ok {
+		return fmt.Errorf("command %q is not allowed: its keys can't be checked against the key prefixes", cmd[0])
+	}
+
+	var keys []string
+	switch positions {
+	case firstKey:
+		keys = args[:min(1, len(args))]
+	case firstTwoKeys:
+		keys = args[:min(2, len(args))]
+	case allKeys:
+		keys = args
+	case keyValuePairs:
+		for i := 0; i < len(args); i += 2 {
+			keys = append(keys, args[i])
+		}
+	case keysPattern:
+		if len(args) == 0 || !hasPrefix(args[0], prefixes) {
+			return fmt.Errorf("command %q requires a pattern starting with one of %q", cmd[0], prefixes)
+		}
+	case scanPattern:
+		if pattern, ok := scanMatch(args); !ok || !hasPrefix(pattern, prefixes) {
+			return fmt.Errorf("command %q requires a MATCH pattern starting with one of %q", cmd[0], prefixes)
+		}
+	}
+	for _, k := range keys {
+		if !hasPrefix(k, prefixes) {
+			return fmt.Errorf("key %q of command %q does not start with one of %q", k, cmd[0], prefixes)
+		}
+	}
+	return nil
+}
+
+// scanMatch returns the pattern of the MATCH option of the arguments of a
+// SCAN command.
+func scanMatch(args []string) (string, bool) {
+	pattern, found := "", false
+	// the first argument is the cursor, followed by option and value pairs
+	for i := 1; i+1 < len(args); i += 2 {
+		if strings.EqualFold(args[i], "MATCH") {
+			pattern, found = args[i+1], true
+		}
+	}
+	return pattern, found
+}
+
+func hasPrefix(key string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(key, p) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rediscommon_test
+
+import (
+	"testing"
+
+	"github.com/googleapis/genai-toolbox/internal/tools/rediscommon"
+)
+
+func TestCheckKeys(t *testing.T) {
+	prefixes := []string{"agent:", "scratch:"}
+	tcs := []struct {
+		desc    string
+		cmd     []string
+		wantErr string
+	}{
+		{
+			desc: "first key",
+			cmd:  []string{"HSET", "agent:1", "field", "value"},
+		},
+		{
+			desc: "lower case command",
+			cmd:  []string{"get", "scratch:notes"},
+		},
+		{
+			desc:    "first key not allowed",
+			cmd:     []string{"GET", "session:1"},
+			wantErr: `key "session:1" of command "GET" does not start with one of ["agent:" "scratch:"]`,
+		},
+		{
+			desc: "values are not keys",
+			cmd:  []string{"SET", "agent:1", "session:1"},
+		},
+		{
+			desc:    "all keys",
+			cmd:     []string{"DEL", "agent:1", "session:1"},
+			wantErr: `key "session:1" of command "DEL" does not start with one of ["agent:" "scratch:"]`,
+		},
+		{
+			desc: "key value pairs",
+			cmd:  []string{"MSET", "agent:1", "session:1", "agent:2", "session:2"},
+		},
+		{
+			desc:    "key value pairs not allowed",
+			cmd:     []string{"MSET", "agent:1", "a", "session:2", "b"},
+			wantErr: `key "session:2" of command "MSET" does not start with one of ["agent:" "scratch:"]`,
+		},
+		{
+			desc:    "first two keys",
+			cmd:     []string{"RENAME", "agent:1", "session:1"},
+			wantErr: `key "session:1" of command "RENAME" does not start with one of ["agent:" "scratch:"]`,
+		},
+		{
+			desc: "keys pattern",
+			cmd:  []string{"KEYS", "agent:*"},
+		},
+		{
+			desc:    "keys pattern not allowed",
+			cmd:     []string{"KEYS", "*"},
+			wantErr: `command "KEYS" requires a pattern starting with one of ["agent:" "scratch:"]`,
+		},
+		{
+			desc: "scan pattern",
+			cmd:  []string{"SCAN", "0", "COUNT", "100", "MATCH", "agent:*"},
+		},
+		{
+			desc:    "scan without pattern",
+			cmd:     []string{"SCAN", "0", "COUNT", "100"},
+			wantErr: `command "SCAN" requires a MATCH pattern starting with one of ["agent:" "scratch:"]`,
+		},
+		{
+			desc: "no keys",
+			cmd:  []string{"PING"},
+		},
+		{
+			desc:    "unchecked command",
+			cmd:     []string{"FLUSHDB"},
+			wantErr: `command "FLUSHDB" is not allowed: its keys can't be checked against the key prefixes`,
+		},
+		{
+			desc:    "numkeys command",
+			cmd:     []string{"EVAL", "return 1", "1", "agent:1"},
+			wantErr: `command "EVAL" is not allowed: its keys can't be checked against the key prefixes`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := rediscommon.CheckKeys(tc.cmd, prefixes)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.wantErr {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateKeyPrefixes(t *testing.T) {
+	if err := rediscommon.ValidateKeyPrefixes([]string{"agent:"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := `key prefix "agent*" must not contain any of "*?[]\\"`
+	if err := rediscommon.ValidateKeyPrefixes([]string{"agent*"}); err == nil || err.Error() != want {
+		t.Fatalf("unexpected error: got %v, want %q", err, want)
+	}
+}
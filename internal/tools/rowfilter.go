@@ -0,0 +1,148 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+	"text/template"
+)
+
+// noRowsMessage is the result of tools whose query returned no rows.
+const noRowsMessage = "The query returned 0 rows."
+
+// parseRowFilter parses the rowFilter option of a tool. Missing claims and
+// columns fail the evaluation, rather than being empty and so equal to each
+// other or to NULL columns.
+func parseRowFilter(filter string) (*template.Template, error) {
+	tmpl, err := template.New("rowFilter").Option("missingkey=error").Parse(filter)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse rowFilter: %w", err)
+	}
+	return tmpl, nil
+}
+
+// FilterRows returns t with the rows of its results filtered by the tool's
+// rowFilter option, or t itself if it isn't set. The filter is a Go template
+// evaluated for each row, with the row as .row, the claims verified by the
+// caller's auth services as .claims, and the parameters of the invocation as
+// .params. Rows are kept if it evaluates to "true" and removed if it
+// evaluates to "false".
+//
+// Rows are the result itself if it is a map, or the maps of the result if it
+// is a list. Results without rows, which are nil or "The query returned 0
+// rows.", are returned as is. Any other result, including other strings,
+// fails rather than bypassing the filter.
+func FilterRows(cfg ToolConfig, t Tool) Tool {
+	oc, ok := cfg.(ConfigWithOptions)
+	if !ok || oc.Options.RowFilter == "" {
+		return t
+	}
+	// the filter was validated by ExtractOptions
+	filter, _ := parseRowFilter(oc.Options.RowFilter)
+	return toolWithRowFilter{Tool: t, filter: filter}
+}
+
+type toolWithRowFilter struct {
+	Tool
+	filter *template.Template
+}
+
+func (t toolWithRowFilter) Invoke(ctx context.Context, params ParamValues) (any, error) {
+	// rows are limited once filtered, so every row is read
+	res, err := t.Tool.Invoke(withRowLimit(ctx, 0), params)
+	if err != nil {
+		return res, err
+	}
+	if s, ok := res.(string); ok && s != noRowsMessage {
+		return nil, fmt.Errorf("unable to apply rowFilter: results must be rows, got string")
+	}
+	data := map[string]any{
+		"claims": callerClaims(InvocationContextFromContext(ctx)),
+		"params": params.AsMap(),
+	}
+	return mapRows(res, "rowFilter", func(row map[string]any) (map[string]any, error) {
+		keep, err := t.keep(data, row)
+		if err != nil || !keep {
+			return nil, err
+		}
+		return row, nil
+	})
+}
+
+// mapRows returns the result res with each of its rows replaced by f(row),
+// or removed if it is nil. Rows are res itself if it is a map, or the maps of
+// res if it is a list. Results without rows, which are nil or messages such
+// as "The query returned 0 rows.", are returned as is. Other results fail
+// with an error naming the option applied.
+func mapRows(res any, option string, f func(row map[string]any) (map[string]any, error)) (any, error) {
+	switch res := res.(type) {
+	case nil, string:
+		return res, nil
+	case map[string]any:
+		row, err := f(res)
+		if err != nil || row == nil {
+			return nil, err
+		}
+		return row, nil
+	case []any:
+		out := make([]any, 0, len(res))
+		for _, item := range res {
+			row, ok := item.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("unable to apply %s: results must be rows, got %T", option, item)
+			}
+			row, err := f(row)
+			if err != nil {
+				return nil, err
+			}
+			if row != nil {
+				out = append(out, row)
+			}
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("unable to apply %s: results must be rows, got %T", option, res)
+	}
+}
+
+// keep evaluates the filter for row.
+func (t toolWithRowFilter) keep(data map[string]any, row map[string]any) (bool, error) {
+	data["row"] = row
+	var b strings.Builder
+	if err := t.filter.Execute(&b, data); err != nil {
+		return false, fmt.Errorf("unable to evaluate rowFilter: %w", err)
+	}
+	switch s := strings.TrimSpace(b.String()); s {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	default:
+		return false, fmt.Errorf(`rowFilter must evaluate to "true" or "false", got %q`, s)
+	}
+}
+
+// callerClaims returns the claims verified by the auth services of ic, with
+// the claims of the first auth service taking precedence, as for the Caller.
+func callerClaims(ic InvocationContext) map[string]any {
+	claims := make(map[string]any)
+	for i := len(ic.AuthServices) - 1; i >= 0; i-- {
+		maps.Copy(claims, ic.Claims[ic.AuthServices[i]])
+	}
+	return claims
+}
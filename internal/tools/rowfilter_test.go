@@ -0,0 +1,160 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+// rowsTool is a tool returning res.
+type rowsTool struct {
+	mockTool
+	res any
+}
+
+func (t rowsTool) Invoke(context.Context, tools.ParamValues) (any, error) { return t.res, nil }
+
+func TestFilterRows(t *testing.T) {
+	rows := []any{
+		map[string]any{"id": 1, "owner_email": "alice@example.com"},
+		map[string]any{"id": 2, "owner_email": "bob@example.com"},
+		map[string]any{"id": 3, "owner_email": "carol@example.com"},
+	}
+	alice := tools.InvocationContext{
+		AuthServices: []string{"my-google-auth", "other-auth"},
+		Claims: map[string]map[string]any{
+			"my-google-auth": {"email": "alice@example.com"},
+			"other-auth":     {"email": "bob@example.com", "role": "admin"},
+		},
+	}
+	ownerFilter := "{{ eq .row.owner_email .claims.email }}"
+	tcs := []struct {
+		desc   string
+		filter string
+		res    any
+		ic     tools.InvocationContext
+		want   any
+		err    string
+	}{
+		{
+			desc:   "rows of the caller",
+			filter: ownerFilter,
+			res:    rows,
+			ic:     alice,
+			want:   []any{rows[0]},
+		},
+		{
+			desc:   "claims of other auth services",
+			filter: `{{ or (eq .row.owner_email .claims.email) (eq .claims.role "admin") }}`,
+			res:    rows,
+			ic:     alice,
+			want:   rows,
+		},
+		{
+			desc:   "anonymous caller",
+			filter: ownerFilter,
+			res:    rows,
+			err:    `unable to evaluate rowFilter: template: rowFilter:1:30: executing "rowFilter" at <.claims.email>: map has no entry for key "email"`,
+		},
+		{
+			desc:   "missing claim and NULL column",
+			filter: ownerFilter,
+			res:    []any{map[string]any{"id": 1, "owner_email": nil}},
+			err:    `unable to evaluate rowFilter: template: rowFilter:1:30: executing "rowFilter" at <.claims.email>: map has no entry for key "email"`,
+		},
+		{
+			desc:   "NULL column",
+			filter: ownerFilter,
+			res:    []any{map[string]any{"id": 1, "owner_email": nil}},
+			ic:     alice,
+			want:   []any{},
+		},
+		{
+			desc:   "misspelled column",
+			filter: "{{ eq .row.owner .claims.email }}",
+			res:    rows,
+			ic:     alice,
+			err:    `unable to evaluate rowFilter: template: rowFilter:1:10: executing "rowFilter" at <.row.owner>: map has no entry for key "owner"`,
+		},
+		{
+			desc:   "parameters",
+			filter: "{{ ne .row.id .params.id }}",
+			res:    []any{map[string]any{"id": int64(1)}, map[string]any{"id": int64(2)}},
+			want:   []any{map[string]any{"id": int64(2)}},
+		},
+		{
+			desc:   "single row",
+			filter: ownerFilter,
+			res:    rows[1],
+			ic:     alice,
+			want:   nil,
+		},
+		{
+			desc:   "message",
+			filter: ownerFilter,
+			res:    "The query returned 0 rows.",
+			want:   "The query returned 0 rows.",
+		},
+		{
+			desc:   "string result",
+			filter: ownerFilter,
+			res:    "alice@example.com,bob@example.com",
+			ic:     alice,
+			err:    "unable to apply rowFilter: results must be rows, got string",
+		},
+		{
+			desc:   "not a predicate",
+			filter: "{{ .row.owner_email }}",
+			res:    rows,
+			err:    `rowFilter must evaluate to "true" or "false", got "alice@example.com"`,
+		},
+		{
+			desc:   "not rows",
+			filter: ownerFilter,
+			res:    []any{"alice@example.com"},
+			err:    "unable to apply rowFilter: results must be rows, got string",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := tools.WithOptions(mockToolConfig{}, tools.Options{RowFilter: tc.filter})
+			tool := tools.FilterRows(cfg, rowsTool{res: tc.res})
+			ctx := tools.WithInvocationContext(context.Background(), tc.ic)
+			params := tools.ParamValues{{Name: "id", Value: int64(1)}}
+			got, err := tool.Invoke(ctx, params)
+			if tc.err != "" {
+				if err == nil || err.Error() != tc.err {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect result: diff %v", diff)
+			}
+		})
+	}
+
+	// tools without a filter are returned as is
+	if got, ok := tools.FilterRows(mockToolConfig{}, rowsTool{res: rows}).(rowsTool); !ok {
+		t.Fatalf("unexpected tool: %#v", got)
+	}
+}
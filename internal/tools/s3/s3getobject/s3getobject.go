@@ -0,0 +1,201 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s3getobject
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"unicode/utf8"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	s3ds "github.com/googleapis/genai-toolbox/internal/sources/s3"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+const kind string = "s3-get-object"
+const bucketKey string = "bucket"
+const keyKey string = "key"
+
+// defaultMaxBytes is the largest object returned when maxBytes is not configured.
+const defaultMaxBytes int64 = 10 << 20
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	S3Client() *s3.Client
+}
+
+// validate compatible sources are still compatible
+var _ compatibleSource = &s3ds.Source{}
+
+var compatibleSources = [...]string{s3ds.SourceKind}
+
+type Config struct {
+	Name         string   `yaml:"name" validate:"required"`
+	Kind         string   `yaml:"kind" validate:"required"`
+	Source       string   `yaml:"source" validate:"required"`
+	Description  string   `yaml:"description" validate:"required"`
+	AuthRequired []string `yaml:"authRequired"`
+	MaxBytes     int64    `yaml:"maxBytes"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(compatibleSource)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
+	}
+
+	maxBytes := cfg.MaxBytes
+	if maxBytes <= 0 {
+		maxBytes = defaultMaxBytes
+	}
+
+	bucketParameter := tools.NewStringParameter(bucketKey, "The name of the bucket containing the object.")
+	keyParameter := tools.NewStringParameter(keyKey, "The key of the object to retrieve.")
+	parameters := tools.Parameters{bucketParameter, keyParameter}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   parameters,
+		AuthRequired: cfg.AuthRequired,
+		MaxBytes:     maxBytes,
+		Client:       s.S3Client(),
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:  mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+	MaxBytes     int64            `yaml:"maxBytes"`
+
+	Client      *s3.Client
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	mapParams := params.AsMap()
+	bucket, ok := mapParams[bucketKey].(string)
+	if !ok || bucket == "" {
+		return nil, fmt.Errorf("invalid or missing '%s' parameter; expected a non-empty string", bucketKey)
+	}
+	key, ok := mapParams[keyKey].(string)
+	if !ok || key == "" {
+		return nil, fmt.Errorf("invalid or missing '%s' parameter; expected a non-empty string", keyKey)
+	}
+
+	out, err := t.Client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object %q from bucket %q: %w", key, bucket, err)
+	}
+	defer out.Body.Close()
+
+	if size := aws.ToInt64(out.ContentLength); size > t.MaxBytes {
+		return nil, fmt.Errorf("object %q is %d bytes, which exceeds the limit of %d bytes", key, size, t.MaxBytes)
+	}
+
+	// read one byte past the limit to detect objects without a content length
+	body, err := io.ReadAll(io.LimitReader(out.Body, t.MaxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object %q: %w", key, err)
+	}
+	if int64(len(body)) > t.MaxBytes {
+		return nil, fmt.Errorf("object %q exceeds the limit of %d bytes", key, t.MaxBytes)
+	}
+
+	result := map[string]any{
+		"bucket":      bucket,
+		"key":         key,
+		"contentType": aws.ToString(out.ContentType),
+		"size":        len(body),
+	}
+	if out.LastModified != nil {
+		result["lastModified"] = *out.LastModified
+	}
+	// binary objects are returned base64 encoded since they can't be represented as JSON strings
+	if utf8.Valid(body) {
+		result["content"] = string(body)
+	} else {
+		result["content"] = base64.StdEncoding.EncodeToString(body)
+		result["encoding"] = "base64"
+	}
+	return result, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
@@ -0,0 +1,188 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s3listobjects
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	s3ds "github.com/googleapis/genai-toolbox/internal/sources/s3"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+const kind string = "s3-list-objects"
+const bucketKey string = "bucket"
+const prefixKey string = "prefix"
+const maxKeysKey string = "maxKeys"
+const continuationTokenKey string = "continuationToken"
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	S3Client() *s3.Client
+}
+
+// validate compatible sources are still compatible
+var _ compatibleSource = &s3ds.Source{}
+
+var compatibleSources = [...]string{s3ds.SourceKind}
+
+type Config struct {
+	Name         string   `yaml:"name" validate:"required"`
+	Kind         string   `yaml:"kind" validate:"required"`
+	Source       string   `yaml:"source" validate:"required"`
+	Description  string   `yaml:"description" validate:"required"`
+	AuthRequired []string `yaml:"authRequired"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(compatibleSource)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
+	}
+
+	bucketParameter := tools.NewStringParameter(bucketKey, "The name of the bucket to list objects from.")
+	prefixParameter := tools.NewStringParameterWithDefault(prefixKey, "", "Only list objects whose key begins with this prefix.")
+	maxKeysParameter := tools.NewIntParameterWithDefault(maxKeysKey, 1000, "The maximum number of objects to return.")
+	continuationTokenParameter := tools.NewStringParameterWithDefault(continuationTokenKey, "", "The continuation token returned by a previous call, used to fetch the next page of results.")
+	parameters := tools.Parameters{bucketParameter, prefixParameter, maxKeysParameter, continuationTokenParameter}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   parameters,
+		AuthRequired: cfg.AuthRequired,
+		Client:       s.S3Client(),
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:  mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+
+	Client      *s3.Client
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	mapParams := params.AsMap()
+	bucket, ok := mapParams[bucketKey].(string)
+	if !ok || bucket == "" {
+		return nil, fmt.Errorf("invalid or missing '%s' parameter; expected a non-empty string", bucketKey)
+	}
+	prefix, _ := mapParams[prefixKey].(string)
+	maxKeys, _ := mapParams[maxKeysKey].(int)
+	continuationToken, _ := mapParams[continuationTokenKey].(string)
+
+	input := &s3.ListObjectsV2Input{
+		Bucket:  aws.String(bucket),
+		MaxKeys: aws.Int32(int32(maxKeys)),
+	}
+	if prefix != "" {
+		input.Prefix = aws.String(prefix)
+	}
+	if continuationToken != "" {
+		input.ContinuationToken = aws.String(continuationToken)
+	}
+
+	out, err := t.Client.ListObjectsV2(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list objects in bucket %q: %w", bucket, err)
+	}
+
+	objects := make([]any, 0, len(out.Contents))
+	for _, o := range out.Contents {
+		objData := map[string]any{
+			"key":  aws.ToString(o.Key),
+			"size": aws.ToInt64(o.Size),
+			"etag": aws.ToString(o.ETag),
+		}
+		if o.LastModified != nil {
+			objData["lastModified"] = *o.LastModified
+		}
+		objects = append(objects, objData)
+	}
+
+	result := map[string]any{
+		"objects":     objects,
+		"isTruncated": aws.ToBool(out.IsTruncated),
+	}
+	if out.NextContinuationToken != nil {
+		result["nextContinuationToken"] = *out.NextContinuationToken
+	}
+	return result, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"fmt"
+	"strings"
+)
+
+// descriptionKeyPrefix prefixes the keys of localized descriptions, such as
+// description_ja.
+const descriptionKeyPrefix = "description_"
+
+// NormalizeLocale returns the canonical form of a locale used as the key of
+// localized descriptions, e.g. "pt_BR" becomes "pt-br".
+func NormalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
+
+// ExtractDescriptions removes the description_<locale> fields from a raw
+// config and returns them by normalized locale.
+func ExtractDescriptions(v map[string]any) (map[string]string, error) {
+	var descs map[string]string
+	for key, val := range v {
+		locale, ok := strings.CutPrefix(key, descriptionKeyPrefix)
+		if !ok {
+			continue
+		}
+		if locale == "" {
+			return nil, fmt.Errorf("%q is missing a locale", key)
+		}
+		desc, ok := val.(string)
+		if !ok {
+			return nil, fmt.Errorf("%q must be a string", key)
+		}
+		if descs == nil {
+			descs = make(map[string]string)
+		}
+		descs[NormalizeLocale(locale)] = desc
+		delete(v, key)
+	}
+	return descs, nil
+}
+
+// LocalizedDescription returns the description for the first of locales with
+// a localized description, falling back to the base language of each locale
+// (e.g. "ja" for "ja-jp"), or desc if none matches.
+func LocalizedDescription(desc string, descs map[string]string, locales []string) string {
+	if len(descs) == 0 {
+		return desc
+	}
+	for _, locale := range locales {
+		if d, ok := descs[locale]; ok {
+			return d
+		}
+		if base, _, ok := strings.Cut(locale, "-"); ok {
+			if d, ok := descs[base]; ok {
+				return d
+			}
+		}
+	}
+	return desc
+}
+
+// Localize returns the parameter manifest with its descriptions in the first
+// of locales available.
+func (p ParameterManifest) Localize(locales []string) ParameterManifest {
+	p.Description = LocalizedDescription(p.Description, p.Descriptions, locales)
+	if p.Items != nil {
+		items := p.Items.Localize(locales)
+		p.Items = &items
+	}
+	return p
+}
+
+// Localize returns the parameter MCP manifest with its descriptions in the
+// first of locales available.
+func (p ParameterMcpManifest) Localize(locales []string) ParameterMcpManifest {
+	p.Description = LocalizedDescription(p.Description, p.Descriptions, locales)
+	if p.Items != nil {
+		items := p.Items.Localize(locales)
+		p.Items = &items
+	}
+	return p
+}
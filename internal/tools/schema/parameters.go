@@ -0,0 +1,1734 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+const (
+	typeString = "string"
+	typeInt    = "integer"
+	typeFloat  = "float"
+	typeBool   = "boolean"
+	typeArray  = "array"
+	typeMap    = "map"
+)
+
+// Limits of the values of parameters. Values are provided by agents, so
+// they're checked before they're walked or copied, to reject pathological
+// payloads rather than allocate for them.
+const (
+	// MaxNestingDepth is the maximum number of arrays and maps nested in a
+	// value.
+	MaxNestingDepth = 32
+	// MaxArrayLength is the maximum number of elements of an array, or
+	// entries of a map.
+	MaxArrayLength = 10000
+	// MaxStringSize is the maximum size of a string, in bytes.
+	MaxStringSize = 1 << 20
+	// MaxRequestSize is the maximum size of a request carrying values, in
+	// bytes. Requests are read up to it before they're decoded.
+	MaxRequestSize = 16 << 20
+)
+
+// Limits exceeded by a value, as reported by a LimitError.
+const (
+	LimitNestingDepth = "nesting depth"
+	LimitArrayLength  = "array length"
+	LimitStringSize   = "string size"
+)
+
+// maxErrorValueSize is the maximum size of a value quoted in an error.
+const maxErrorValueSize = 100
+
+// ParamValues is an ordered list of ParamValue
+type ParamValues []ParamValue
+
+// ParamValue represents the parameter's name and value.
+type ParamValue struct {
+	Name  string
+	Value any
+}
+
+// AsSlice returns a slice of the Param's values (in order).
+func (p ParamValues) AsSlice() []any {
+	params := []any{}
+
+	for _, p := range p {
+		params = append(params, p.Value)
+	}
+	return params
+}
+
+// AsMap returns a map of ParamValue's names to values.
+func (p ParamValues) AsMap() map[string]interface{} {
+	params := make(map[string]interface{})
+	for _, p := range p {
+		params[p.Name] = p.Value
+	}
+	return params
+}
+
+// AsMapByOrderedKeys returns a map of a key's position to it's value, as necessary for Spanner PSQL.
+// Example { $1 -> "value1", $2 -> "value2" }
+func (p ParamValues) AsMapByOrderedKeys() map[string]interface{} {
+	params := make(map[string]interface{})
+
+	for i, p := range p {
+		key := fmt.Sprintf("p%d", i+1)
+		params[key] = p.Value
+	}
+	return params
+}
+
+// AsMapWithDollarPrefix ensures all keys are prefixed with a dollar sign for Dgraph.
+// Example:
+// Input:  {"role": "admin", "$age": 30}
+// Output: {"$role": "admin", "$age": 30}
+func (p ParamValues) AsMapWithDollarPrefix() map[string]interface{} {
+	params := make(map[string]interface{})
+
+	for _, param := range p {
+		key := param.Name
+		if !strings.HasPrefix(key, "$") {
+			key = "$" + key
+		}
+		params[key] = param.Value
+	}
+	return params
+}
+
+func parseFromAuthService(paramAuthServices []ParamAuthService, claimsMap map[string]map[string]any) (any, error) {
+	// parse a parameter from claims using its specified auth services
+	for _, a := range paramAuthServices {
+		claims, ok := claimsMap[a.Name]
+		if !ok {
+			// not validated for this authservice, skip to the next one
+			continue
+		}
+		v, ok := claims[a.Field]
+		if !ok {
+			// claims do not contain specified field
+			return nil, fmt.Errorf("no field named %s in claims", a.Field)
+		}
+		return v, nil
+	}
+	return nil, fmt.Errorf("missing or invalid authentication header")
+}
+
+// CheckParamRequired checks if a parameter is required based on the required and default field.
+func CheckParamRequired(required bool, defaultV any) bool {
+	return required && defaultV == nil
+}
+
+// ParseParams is a helper function for parsing Parameters from an arbitraryJSON object.
+func ParseParams(ps Parameters, data map[string]any, claimsMap map[string]map[string]any) (ParamValues, error) {
+	params := make([]ParamValue, 0, len(ps))
+	// every parameter is validated so all problems can be fixed at once
+	var errs ParamErrors
+	for _, p := range ps {
+		var v, newV any
+		var err error
+		paramAuthServices := p.GetAuthServices()
+		name := p.GetName()
+		if len(paramAuthServices) == 0 {
+			// parse non auth-required parameter
+			var ok bool
+			v, ok = data[name]
+			if !ok {
+				v = p.GetDefault()
+				// if the parameter is required and no value given, record an error
+				if CheckParamRequired(p.GetRequired(), v) {
+					errs = append(errs, NewParamError(name, ParamErrorMissing, fmt.Errorf("parameter %q is required", name)))
+					continue
+				}
+			}
+		} else {
+			// parse authenticated parameter
+			v, err = parseFromAuthService(paramAuthServices, claimsMap)
+			if err != nil {
+				errs = append(errs, NewParamError(name, ParamErrorUnauthenticated, fmt.Errorf("error parsing authenticated parameter %q: %w", name, err)))
+				continue
+			}
+		}
+		if v != nil {
+			newV, err = p.Parse(v)
+			if err != nil {
+				reason := ParamErrorInvalid
+				var typeErr *ParseTypeError
+				var limitErr *LimitError
+				switch {
+				case errors.As(err, &typeErr):
+					reason = ParamErrorTypeMismatch
+				case errors.As(err, &limitErr):
+					reason = ParamErrorTooLarge
+				}
+				errs = append(errs, NewParamError(name, reason, fmt.Errorf("unable to parse value for %q: %w", name, err)))
+				continue
+			}
+		}
+		params = append(params, ParamValue{Name: name, Value: newV})
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return params, nil
+}
+
+// helper function to convert a string array parameter to a comma separated string
+func ConvertArrayParamToString(param any) (string, error) {
+	switch v := param.(type) {
+	case []any:
+		var stringValues []string
+		for _, item := range v {
+			stringVal, ok := item.(string)
+			if !ok {
+				return "", fmt.Errorf("templateParameter only supports string arrays")
+			}
+			stringValues = append(stringValues, stringVal)
+		}
+		return strings.Join(stringValues, ", "), nil
+	default:
+		return "", fmt.Errorf("invalid parameter type, expected array of type string")
+	}
+}
+
+// GetParams return the ParamValues that are associated with the Parameters.
+func GetParams(params Parameters, paramValuesMap map[string]any) (ParamValues, error) {
+	resultParamValues := make(ParamValues, 0)
+	for _, p := range params {
+		k := p.GetName()
+		v, ok := paramValuesMap[k]
+		if !ok {
+			return nil, fmt.Errorf("missing parameter %s", k)
+		}
+		resultParamValues = append(resultParamValues, ParamValue{Name: k, Value: v})
+	}
+	return resultParamValues, nil
+}
+
+// Parameter is a parameter of a tool, which parses and validates the values
+// of invocations.
+type Parameter interface {
+	// Note: It's typically not idiomatic to include "Get" in the function name,
+	// but this is done to differentiate it from the fields in CommonParameter.
+	GetName() string
+	GetType() string
+	GetDefault() any
+	GetRequired() bool
+	GetAuthServices() []ParamAuthService
+	GetEnum() []any
+	Parse(any) (any, error)
+	Manifest() ParameterManifest
+	McpManifest() ParameterMcpManifest
+}
+
+// McpToolsSchema is the representation of input schema for McpManifest.
+type McpToolsSchema struct {
+	Type       string                          `json:"type"`
+	Properties map[string]ParameterMcpManifest `json:"properties"`
+	Required   []string                        `json:"required"`
+	Examples   []map[string]any                `json:"examples,omitempty"`
+}
+
+// Parameters is a type used to allow unmarshal a list of parameters
+type Parameters []Parameter
+
+func (c *Parameters) UnmarshalYAML(ctx context.Context, unmarshal func(interface{}) error) error {
+	*c = make(Parameters, 0)
+	var rawList []util.DelayedUnmarshaler
+	if err := unmarshal(&rawList); err != nil {
+		return err
+	}
+	for _, u := range rawList {
+		p, err := parseParamFromDelayedUnmarshaler(ctx, &u)
+		if err != nil {
+			return err
+		}
+		(*c) = append((*c), p)
+	}
+	return nil
+}
+
+// ParameterFactory defines the signature for a function that creates an
+// empty Parameter of a type, which the config of a parameter is decoded into.
+// Parameters embedding CommonParameter get its localized descriptions and
+// validation of enum values, and Parameters implementing ConfigValidator are
+// validated once decoded.
+type ParameterFactory func() Parameter
+
+// ConfigValidator is implemented by Parameters which validate their config,
+// such as the bounds of their values, once decoded.
+type ConfigValidator interface {
+	ValidateConfig() error
+}
+
+var parameterRegistry = make(map[string]ParameterFactory)
+
+func init() {
+	for typeName, factory := range map[string]ParameterFactory{
+		typeString: func() Parameter { return &StringParameter{} },
+		typeInt:    func() Parameter { return &IntParameter{} },
+		typeFloat:  func() Parameter { return &FloatParameter{} },
+		typeBool:   func() Parameter { return &BooleanParameter{} },
+		typeArray:  func() Parameter { return &ArrayParameter{} },
+		typeMap:    func() Parameter { return &MapParameter{} },
+	} {
+		Register(typeName, factory)
+	}
+}
+
+// Register allows packages to add types of parameters, such as "geojson",
+// which is the value of the "type" field of the parameters of that type. This
+// is typically called from an init() function. It returns true if the
+// registration was successful, and false if a parameter type with the same
+// name was already registered.
+func Register(typeName string, factory ParameterFactory) bool {
+	if _, exists := parameterRegistry[typeName]; exists {
+		// Parameter type with this name already exists, do not overwrite.
+		return false
+	}
+	parameterRegistry[typeName] = factory
+	return true
+}
+
+// parseParamFromDelayedUnmarshaler is a helper function that is required to parse
+// parameters because there are multiple different types
+func parseParamFromDelayedUnmarshaler(ctx context.Context, u *util.DelayedUnmarshaler) (Parameter, error) {
+	var p map[string]any
+	err := u.Unmarshal(&p)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing parameters: %w", err)
+	}
+
+	t, ok := p["type"]
+	if !ok {
+		return nil, fmt.Errorf("parameter is missing 'type' field: %w", err)
+	}
+
+	descs, err := ExtractDescriptions(p)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing parameters: %w", err)
+	}
+
+	dec, err := util.NewStrictDecoder(p)
+	if err != nil {
+		return nil, fmt.Errorf("error creating decoder: %w", err)
+	}
+	logger, err := util.LoggerFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	typeName, _ := t.(string)
+	factory, ok := parameterRegistry[typeName]
+	if !ok {
+		return nil, fmt.Errorf("%q is not valid type for a parameter", t)
+	}
+	a := factory()
+	if err := dec.DecodeContext(ctx, a); err != nil {
+		return nil, fmt.Errorf("unable to parse as %q: %w", t, err)
+	}
+	if c, ok := a.(interface{ common() *CommonParameter }); ok {
+		cp := c.common()
+		if cp.AuthSources != nil {
+			logger.WarnContext(ctx, "`authSources` is deprecated, use `authServices` for parameters instead")
+			cp.AuthServices = append(cp.AuthServices, cp.AuthSources...)
+			cp.AuthSources = nil
+		}
+		cp.Descriptions = descs
+	}
+	if v, ok := a.(ConfigValidator); ok {
+		if err := v.ValidateConfig(); err != nil {
+			return nil, fmt.Errorf("invalid constraints for parameter %q: %w", a.GetName(), err)
+		}
+	}
+	return checkEnumValues(a)
+}
+
+// checkEnumValues returns p, or an error if its enum values, or its default
+// value, aren't valid values of p.
+func checkEnumValues(p Parameter) (Parameter, error) {
+	if len(p.GetEnum()) == 0 {
+		return p, nil
+	}
+	for _, e := range p.GetEnum() {
+		if err := parseAsJSON(p, e); err != nil {
+			return nil, fmt.Errorf("invalid enum value for parameter %q: %w", p.GetName(), err)
+		}
+	}
+	if d := p.GetDefault(); d != nil {
+		if err := parseAsJSON(p, d); err != nil {
+			return nil, fmt.Errorf("invalid default value for parameter %q: %w", p.GetName(), err)
+		}
+	}
+	return p, nil
+}
+
+// parseAsJSON parses v with p, as if it was sent by a client as JSON.
+func parseAsJSON(p Parameter, v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var jv any
+	if err := dec.Decode(&jv); err != nil {
+		return err
+	}
+	_, err = p.Parse(jv)
+	return err
+}
+
+func (ps Parameters) Manifest() []ParameterManifest {
+	rtn := make([]ParameterManifest, 0, len(ps))
+	for _, p := range ps {
+		rtn = append(rtn, p.Manifest())
+	}
+	return rtn
+}
+
+func (ps Parameters) McpManifest() McpToolsSchema {
+	properties := make(map[string]ParameterMcpManifest)
+	required := make([]string, 0)
+
+	for _, p := range ps {
+		name := p.GetName()
+		properties[name] = p.McpManifest()
+		// parameters that doesn't have a default value are added to the required field
+		if CheckParamRequired(p.GetRequired(), p.GetDefault()) {
+			required = append(required, name)
+		}
+	}
+
+	return McpToolsSchema{
+		Type:       "object",
+		Properties: properties,
+		Required:   required,
+	}
+}
+
+// ParameterManifest represents parameters when served as part of a ToolManifest.
+type ParameterManifest struct {
+	Name                 string             `json:"name"`
+	Type                 string             `json:"type"`
+	Required             bool               `json:"required"`
+	Description          string             `json:"description"`
+	AuthServices         []string           `json:"authSources"`
+	Items                *ParameterManifest `json:"items,omitempty"`
+	AdditionalProperties any                `json:"additionalProperties,omitempty"`
+	Examples             []any              `json:"examples,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	// Descriptions are the localized descriptions of the parameter by locale.
+	Descriptions map[string]string `json:"-"`
+	// Completion are the suggestions of values of the parameter.
+	Completion *ParamCompletion `json:"-"`
+}
+
+// ParameterMcpManifest represents properties when served as part of a ToolMcpManifest.
+type ParameterMcpManifest struct {
+	Type                 string                `json:"type"`
+	Description          string                `json:"description"`
+	Items                *ParameterMcpManifest `json:"items,omitempty"`
+	AdditionalProperties any                   `json:"additionalProperties,omitempty"`
+	Examples             []any                 `json:"examples,omitempty"`
+	Enum                 []any                 `json:"enum,omitempty"`
+	Minimum              *float64              `json:"minimum,omitempty"`
+	Maximum              *float64              `json:"maximum,omitempty"`
+	MinLength            *int                  `json:"minLength,omitempty"`
+	MaxLength            *int                  `json:"maxLength,omitempty"`
+	Pattern              string                `json:"pattern,omitempty"`
+	// Descriptions are the localized descriptions of the parameter by locale.
+	Descriptions map[string]string `json:"-"`
+}
+
+// CommonParameter are default fields that are emebdding in most Parameter implementations. Embedding this stuct will give the object Name() and Type() functions.
+type CommonParameter struct {
+	Name         string             `yaml:"name" validate:"required"`
+	Type         string             `yaml:"type" validate:"required"`
+	Desc         string             `yaml:"description" validate:"required"`
+	Required     *bool              `yaml:"required"`
+	AuthServices []ParamAuthService `yaml:"authServices"`
+	AuthSources  []ParamAuthService `yaml:"authSources"` // Deprecated: Kept for compatibility.
+	Examples     []any              `yaml:"examples"`
+	// Enum are the only values the parameter accepts, if set.
+	Enum []any `yaml:"enum"`
+	// Completion are the suggestions of values of the parameter, offered to
+	// clients through MCP completion.
+	Completion *ParamCompletion `yaml:"completion"`
+	// Descriptions are the localized descriptions of the parameter by locale,
+	// set from the description_<locale> fields.
+	Descriptions map[string]string `yaml:"-"`
+}
+
+// GetName returns the name specified for the Parameter.
+func (p *CommonParameter) GetName() string {
+	return p.Name
+}
+
+// GetType returns the type specified for the Parameter.
+func (p *CommonParameter) GetType() string {
+	return p.Type
+}
+
+// common returns the CommonParameter, for the parameters embedding it.
+func (p *CommonParameter) common() *CommonParameter {
+	return p
+}
+
+// GetEnum returns the values the Parameter is restricted to, if any.
+func (p *CommonParameter) GetEnum() []any {
+	return p.Enum
+}
+
+// checkEnum returns an error if the Parameter is restricted to values other
+// than v. Values are compared as JSON, as sent by clients.
+func (p *CommonParameter) checkEnum(v any) error {
+	if len(p.Enum) == 0 {
+		return nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	for _, e := range p.Enum {
+		if eb, err := json.Marshal(e); err == nil && bytes.Equal(b, eb) {
+			return nil
+		}
+	}
+	if len(b) > maxErrorValueSize {
+		b = append(bytes.ToValidUTF8(b[:maxErrorValueSize:maxErrorValueSize], nil), "..."...)
+	}
+	allowed, _ := json.Marshal(p.Enum)
+	return fmt.Errorf("%s is not one of the allowed values %s", b, allowed)
+}
+
+// GetRequired returns the type specified for the Parameter.
+func (p *CommonParameter) GetRequired() bool {
+	// parameters are defaulted to required
+	if p.Required == nil {
+		return true
+	}
+	return *p.Required
+}
+
+// McpManifest returns the MCP manifest for the Parameter.
+func (p *CommonParameter) McpManifest() ParameterMcpManifest {
+	return ParameterMcpManifest{
+		Type:         p.Type,
+		Description:  p.Desc,
+		Examples:     p.Examples,
+		Descriptions: p.Descriptions,
+		Enum:         p.Enum,
+	}
+}
+
+// ParseTypeError is a custom error for incorrectly typed Parameters.
+type ParseTypeError struct {
+	Name  string
+	Type  string
+	Value any
+}
+
+func (e ParseTypeError) Error() string {
+	return fmt.Sprintf("%s not type %q", quoteValue(e.Value), e.Type)
+}
+
+// quoteValue quotes v for an error, truncated so that a large value doesn't
+// make a large error. Arrays and maps are only described by their size, as
+// formatting them could allocate as much as their nested values.
+func quoteValue(v any) string {
+	switch v := v.(type) {
+	case []any:
+		return fmt.Sprintf("array of %d elements", len(v))
+	case map[string]any:
+		return fmt.Sprintf("map of %d entries", len(v))
+	case string:
+		if len(v) > maxErrorValueSize {
+			return fmt.Sprintf("%q...", strings.ToValidUTF8(v[:maxErrorValueSize], ""))
+		}
+	}
+	return fmt.Sprintf("%q", v)
+}
+
+// LimitError is returned when a value exceeds one of the limits of the
+// values of parameters.
+type LimitError struct {
+	// Limit is the limit exceeded, e.g. LimitStringSize.
+	Limit string
+	Max   int
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("value exceeds the maximum %s of %d", e.Limit, e.Max)
+}
+
+// checkLimits returns a *LimitError if v, or a value nested in it, exceeds
+// the limits of the values of parameters. depth is the nesting depth of v,
+// starting at 1.
+func checkLimits(v any, depth int) error {
+	switch v := v.(type) {
+	case string:
+		if len(v) > MaxStringSize {
+			return &LimitError{Limit: LimitStringSize, Max: MaxStringSize}
+		}
+	case []any:
+		if depth > MaxNestingDepth {
+			return &LimitError{Limit: LimitNestingDepth, Max: MaxNestingDepth}
+		}
+		if len(v) > MaxArrayLength {
+			return &LimitError{Limit: LimitArrayLength, Max: MaxArrayLength}
+		}
+		for _, e := range v {
+			if err := checkLimits(e, depth+1); err != nil {
+				return err
+			}
+		}
+	case map[string]any:
+		if depth > MaxNestingDepth {
+			return &LimitError{Limit: LimitNestingDepth, Max: MaxNestingDepth}
+		}
+		if len(v) > MaxArrayLength {
+			return &LimitError{Limit: LimitArrayLength, Max: MaxArrayLength}
+		}
+		for k, e := range v {
+			if len(k) > MaxStringSize {
+				return &LimitError{Limit: LimitStringSize, Max: MaxStringSize}
+			}
+			if err := checkLimits(e, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// CheckNestingDepth returns a *LimitError if the arrays and objects of the
+// JSON document data are nested more than depth levels deep. It scans data
+// without decoding it, so that deeply nested requests are rejected before
+// they're allocated. Invalid documents are left to the decoder to report.
+func CheckNestingDepth(data []byte, depth int) error {
+	n := 0
+	inString, escaped := false, false
+	for _, c := range data {
+		switch {
+		case escaped:
+			escaped = false
+		case inString:
+			switch c {
+			case '\\':
+				escaped = true
+			case '"':
+				inString = false
+			}
+		case c == '"':
+			inString = true
+		case c == '[' || c == '{':
+			n++
+			if n > depth {
+				return &LimitError{Limit: LimitNestingDepth, Max: depth}
+			}
+		case c == ']' || c == '}':
+			n--
+		}
+	}
+	return nil
+}
+
+// Reasons a parameter was rejected by ParseParams.
+const (
+	ParamErrorMissing         = "missing"
+	ParamErrorTypeMismatch    = "type_mismatch"
+	ParamErrorInvalid         = "invalid"
+	ParamErrorUnauthenticated = "unauthenticated"
+	ParamErrorUnknown         = "unknown"
+	ParamErrorTooLarge        = "too_large"
+)
+
+// ParamError describes why a single parameter was rejected.
+type ParamError struct {
+	Name    string `json:"name"`
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+	err     error
+}
+
+// NewParamError returns the ParamError of the parameter name rejected for
+// reason with err.
+func NewParamError(name, reason string, err error) ParamError {
+	return ParamError{Name: name, Reason: reason, Message: err.Error(), err: err}
+}
+
+func (e ParamError) Error() string {
+	return e.Message
+}
+
+func (e ParamError) Unwrap() error {
+	return e.err
+}
+
+// ParamErrors is returned by ParseParams and lists every rejected parameter,
+// so that a caller can correct all of them in a single retry.
+type ParamErrors []ParamError
+
+func (e ParamErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, pe := range e {
+		msgs = append(msgs, pe.Message)
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e ParamErrors) Unwrap() []error {
+	errs := make([]error, 0, len(e))
+	for _, pe := range e {
+		errs = append(errs, pe)
+	}
+	return errs
+}
+
+type ParamAuthService struct {
+	Name  string `yaml:"name"`
+	Field string `yaml:"field"`
+}
+
+// ParamCompletion configures the suggestions of values of a parameter, either
+// a static list of values or a statement whose first column lists them.
+type ParamCompletion struct {
+	Values    []string `yaml:"values" validate:"required_without=Statement"`
+	Statement string   `yaml:"statement" validate:"excluded_with=Values"`
+}
+
+// NewStringParameter is a convenience function for initializing a StringParameter.
+func NewStringParameter(name string, desc string) *StringParameter {
+	return &StringParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         typeString,
+			Desc:         desc,
+			AuthServices: nil,
+		},
+	}
+}
+
+// NewStringParameterWithDefault is a convenience function for initializing a StringParameter with default value.
+func NewStringParameterWithDefault(name string, defaultV, desc string) *StringParameter {
+	return &StringParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         typeString,
+			Desc:         desc,
+			AuthServices: nil,
+		},
+		Default: &defaultV,
+	}
+}
+
+// NewStringParameterWithRequired is a convenience function for initializing a StringParameter.
+func NewStringParameterWithRequired(name string, desc string, required bool) *StringParameter {
+	return &StringParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         typeString,
+			Desc:         desc,
+			Required:     &required,
+			AuthServices: nil,
+		},
+	}
+}
+
+// NewStringParameterWithAuth is a convenience function for initializing a StringParameter with a list of ParamAuthService.
+func NewStringParameterWithAuth(name string, desc string, authServices []ParamAuthService) *StringParameter {
+	return &StringParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         typeString,
+			Desc:         desc,
+			AuthServices: authServices,
+		},
+	}
+}
+
+var _ Parameter = &StringParameter{}
+
+// StringParameter is a parameter representing the "string" type.
+type StringParameter struct {
+	CommonParameter `yaml:",inline"`
+	Default         *string `yaml:"default"`
+	// MinLength and MaxLength are the bounds of the length of the value, in
+	// characters, if set.
+	MinLength *int `yaml:"minLength"`
+	MaxLength *int `yaml:"maxLength"`
+	// Pattern is a regular expression the value must match, if set. As in
+	// JSON Schema, it isn't anchored.
+	Pattern string `yaml:"pattern"`
+}
+
+// Parse casts the value "v" as a "string".
+func (p *StringParameter) Parse(v any) (any, error) {
+	newV, ok := v.(string)
+	if !ok {
+		return nil, &ParseTypeError{p.Name, p.Type, v}
+	}
+	if err := checkLimits(newV, 1); err != nil {
+		return nil, err
+	}
+	if err := p.checkEnum(newV); err != nil {
+		return nil, err
+	}
+	if err := p.checkBounds(newV); err != nil {
+		return nil, err
+	}
+	return newV, nil
+}
+
+// ValidateConfig returns an error if the bounds or pattern of the
+// StringParameter are invalid, or its default value is out of them.
+func (p *StringParameter) ValidateConfig() error {
+	if (p.MinLength != nil && *p.MinLength < 0) || (p.MaxLength != nil && *p.MaxLength < 0) {
+		return fmt.Errorf("minLength and maxLength must not be negative")
+	}
+	if p.MinLength != nil && p.MaxLength != nil && *p.MinLength > *p.MaxLength {
+		return fmt.Errorf("minLength %d is greater than maxLength %d", *p.MinLength, *p.MaxLength)
+	}
+	if _, err := compilePattern(p.Pattern); err != nil {
+		return fmt.Errorf("invalid pattern: %w", err)
+	}
+	if p.Default != nil {
+		if err := p.checkBounds(*p.Default); err != nil {
+			return fmt.Errorf("invalid default value: %w", err)
+		}
+	}
+	return nil
+}
+
+// checkBounds returns an error if v is out of the bounds of the
+// StringParameter, or doesn't match its pattern.
+func (p *StringParameter) checkBounds(v string) error {
+	n := utf8.RuneCountInString(v)
+	if p.MinLength != nil && n < *p.MinLength {
+		return fmt.Errorf("%s is shorter than the minimum length %d", quoteValue(v), *p.MinLength)
+	}
+	if p.MaxLength != nil && n > *p.MaxLength {
+		return fmt.Errorf("%s is longer than the maximum length %d", quoteValue(v), *p.MaxLength)
+	}
+	if p.Pattern != "" {
+		re, err := compilePattern(p.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern: %w", err)
+		}
+		if !re.MatchString(v) {
+			return fmt.Errorf("%s does not match the pattern %q", quoteValue(v), p.Pattern)
+		}
+	}
+	return nil
+}
+
+// compiledPatterns caches the compiled patterns of string parameters by
+// pattern, so that they're compiled once when the configuration is validated
+// rather than on every invocation.
+var compiledPatterns sync.Map
+
+// compilePattern returns the compiled regular expression of pattern,
+// compiling it on first use.
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if re, ok := compiledPatterns.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	compiledPatterns.Store(pattern, re)
+	return re, nil
+}
+
+func (p *StringParameter) GetAuthServices() []ParamAuthService {
+	return p.AuthServices
+}
+
+func (p *StringParameter) GetDefault() any {
+	if p.Default == nil {
+		return nil
+	}
+	return *p.Default
+}
+
+// Manifest returns the manifest for the StringParameter.
+func (p *StringParameter) Manifest() ParameterManifest {
+	// only list ParamAuthService names (without fields) in manifest
+	authNames := make([]string, len(p.AuthServices))
+	for i, a := range p.AuthServices {
+		authNames[i] = a.Name
+	}
+	r := CheckParamRequired(p.GetRequired(), p.GetDefault())
+	return ParameterManifest{
+		Name:         p.Name,
+		Type:         p.Type,
+		Required:     r,
+		Description:  p.Desc,
+		AuthServices: authNames,
+		Examples:     p.Examples,
+		Descriptions: p.Descriptions,
+		Enum:         p.Enum,
+		MinLength:    p.MinLength,
+		MaxLength:    p.MaxLength,
+		Pattern:      p.Pattern,
+		Completion:   p.Completion,
+	}
+}
+
+// McpManifest returns the MCP manifest for the StringParameter.
+func (p *StringParameter) McpManifest() ParameterMcpManifest {
+	m := p.CommonParameter.McpManifest()
+	m.MinLength = p.MinLength
+	m.MaxLength = p.MaxLength
+	m.Pattern = p.Pattern
+	return m
+}
+
+// NewIntParameter is a convenience function for initializing a IntParameter.
+func NewIntParameter(name string, desc string) *IntParameter {
+	return &IntParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         typeInt,
+			Desc:         desc,
+			AuthServices: nil,
+		},
+	}
+}
+
+// NewIntParameterWithDefault is a convenience function for initializing a IntParameter with default value.
+func NewIntParameterWithDefault(name string, defaultV int, desc string) *IntParameter {
+	return &IntParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         typeInt,
+			Desc:         desc,
+			AuthServices: nil,
+		},
+		Default: &defaultV,
+	}
+}
+
+// NewIntParameterWithRequired is a convenience function for initializing a IntParameter.
+func NewIntParameterWithRequired(name string, desc string, required bool) *IntParameter {
+	return &IntParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         typeInt,
+			Desc:         desc,
+			Required:     &required,
+			AuthServices: nil,
+		},
+	}
+}
+
+// NewIntParameterWithAuth is a convenience function for initializing a IntParameter with a list of ParamAuthService.
+func NewIntParameterWithAuth(name string, desc string, authServices []ParamAuthService) *IntParameter {
+	return &IntParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         typeInt,
+			Desc:         desc,
+			AuthServices: authServices,
+		},
+	}
+}
+
+var _ Parameter = &IntParameter{}
+
+// IntParameter is a parameter representing the "int" type.
+type IntParameter struct {
+	CommonParameter `yaml:",inline"`
+	Default         *int `yaml:"default"`
+	// Minimum and Maximum are the inclusive bounds of the value, if set.
+	Minimum *int `yaml:"minimum"`
+	Maximum *int `yaml:"maximum"`
+}
+
+func (p *IntParameter) Parse(v any) (any, error) {
+	var out int
+	switch newV := v.(type) {
+	default:
+		return nil, &ParseTypeError{p.Name, p.Type, v}
+	case int:
+		out = int(newV)
+	case int32:
+		out = int(newV)
+	case int64:
+		out = int(newV)
+	case json.Number:
+		newI, err := newV.Int64()
+		if err != nil {
+			return nil, &ParseTypeError{p.Name, p.Type, v}
+		}
+		out = int(newI)
+	}
+	if err := p.checkEnum(out); err != nil {
+		return nil, err
+	}
+	if err := p.checkBounds(out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ValidateConfig returns an error if the bounds of the IntParameter are
+// invalid, or its default value is out of them.
+func (p *IntParameter) ValidateConfig() error {
+	if p.Minimum != nil && p.Maximum != nil && *p.Minimum > *p.Maximum {
+		return fmt.Errorf("minimum %d is greater than maximum %d", *p.Minimum, *p.Maximum)
+	}
+	if p.Default != nil {
+		if err := p.checkBounds(*p.Default); err != nil {
+			return fmt.Errorf("invalid default value: %w", err)
+		}
+	}
+	return nil
+}
+
+// checkBounds returns an error if v is out of the bounds of the IntParameter.
+func (p *IntParameter) checkBounds(v int) error {
+	if p.Minimum != nil && v < *p.Minimum {
+		return fmt.Errorf("%d is less than the minimum %d", v, *p.Minimum)
+	}
+	if p.Maximum != nil && v > *p.Maximum {
+		return fmt.Errorf("%d is greater than the maximum %d", v, *p.Maximum)
+	}
+	return nil
+}
+
+func (p *IntParameter) GetAuthServices() []ParamAuthService {
+	return p.AuthServices
+}
+
+func (p *IntParameter) GetDefault() any {
+	if p.Default == nil {
+		return nil
+	}
+	return *p.Default
+}
+
+// Manifest returns the manifest for the IntParameter.
+func (p *IntParameter) Manifest() ParameterManifest {
+	// only list ParamAuthService names (without fields) in manifest
+	authNames := make([]string, len(p.AuthServices))
+	for i, a := range p.AuthServices {
+		authNames[i] = a.Name
+	}
+	r := CheckParamRequired(p.GetRequired(), p.GetDefault())
+	return ParameterManifest{
+		Name:         p.Name,
+		Type:         p.Type,
+		Required:     r,
+		Description:  p.Desc,
+		AuthServices: authNames,
+		Examples:     p.Examples,
+		Descriptions: p.Descriptions,
+		Enum:         p.Enum,
+		Minimum:      intBound(p.Minimum),
+		Maximum:      intBound(p.Maximum),
+		Completion:   p.Completion,
+	}
+}
+
+// McpManifest returns the MCP manifest for the IntParameter.
+func (p *IntParameter) McpManifest() ParameterMcpManifest {
+	m := p.CommonParameter.McpManifest()
+	m.Minimum = intBound(p.Minimum)
+	m.Maximum = intBound(p.Maximum)
+	return m
+}
+
+// intBound returns the bound b of an IntParameter as a bound of a manifest.
+func intBound(b *int) *float64 {
+	if b == nil {
+		return nil
+	}
+	f := float64(*b)
+	return &f
+}
+
+// NewFloatParameter is a convenience function for initializing a FloatParameter.
+func NewFloatParameter(name string, desc string) *FloatParameter {
+	return &FloatParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         typeFloat,
+			Desc:         desc,
+			AuthServices: nil,
+		},
+	}
+}
+
+// NewFloatParameterWithDefault is a convenience function for initializing a FloatParameter with default value.
+func NewFloatParameterWithDefault(name string, defaultV float64, desc string) *FloatParameter {
+	return &FloatParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         typeFloat,
+			Desc:         desc,
+			AuthServices: nil,
+		},
+		Default: &defaultV,
+	}
+}
+
+// NewFloatParameterWithRequired is a convenience function for initializing a FloatParameter.
+func NewFloatParameterWithRequired(name string, desc string, required bool) *FloatParameter {
+	return &FloatParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         typeFloat,
+			Desc:         desc,
+			Required:     &required,
+			AuthServices: nil,
+		},
+	}
+}
+
+// NewFloatParameterWithAuth is a convenience function for initializing a FloatParameter with a list of ParamAuthService.
+func NewFloatParameterWithAuth(name string, desc string, authServices []ParamAuthService) *FloatParameter {
+	return &FloatParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         typeFloat,
+			Desc:         desc,
+			AuthServices: authServices,
+		},
+	}
+}
+
+var _ Parameter = &FloatParameter{}
+
+// FloatParameter is a parameter representing the "float" type.
+type FloatParameter struct {
+	CommonParameter `yaml:",inline"`
+	Default         *float64 `yaml:"default"`
+	// Minimum and Maximum are the inclusive bounds of the value, if set.
+	Minimum *float64 `yaml:"minimum"`
+	Maximum *float64 `yaml:"maximum"`
+}
+
+func (p *FloatParameter) Parse(v any) (any, error) {
+	var out float64
+	switch newV := v.(type) {
+	default:
+		return nil, &ParseTypeError{p.Name, p.Type, v}
+	case float32:
+		out = float64(newV)
+	case float64:
+		out = newV
+	case json.Number:
+		newI, err := newV.Float64()
+		if err != nil {
+			return nil, &ParseTypeError{p.Name, p.Type, v}
+		}
+		out = float64(newI)
+	}
+	if err := p.checkEnum(out); err != nil {
+		return nil, err
+	}
+	if err := p.checkBounds(out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ValidateConfig returns an error if the bounds of the FloatParameter are
+// invalid, or its default value is out of them.
+func (p *FloatParameter) ValidateConfig() error {
+	if p.Minimum != nil && p.Maximum != nil && *p.Minimum > *p.Maximum {
+		return fmt.Errorf("minimum %v is greater than maximum %v", *p.Minimum, *p.Maximum)
+	}
+	if p.Default != nil {
+		if err := p.checkBounds(*p.Default); err != nil {
+			return fmt.Errorf("invalid default value: %w", err)
+		}
+	}
+	return nil
+}
+
+// checkBounds returns an error if v is out of the bounds of the
+// FloatParameter.
+func (p *FloatParameter) checkBounds(v float64) error {
+	if p.Minimum != nil && v < *p.Minimum {
+		return fmt.Errorf("%v is less than the minimum %v", v, *p.Minimum)
+	}
+	if p.Maximum != nil && v > *p.Maximum {
+		return fmt.Errorf("%v is greater than the maximum %v", v, *p.Maximum)
+	}
+	return nil
+}
+
+func (p *FloatParameter) GetAuthServices() []ParamAuthService {
+	return p.AuthServices
+}
+
+func (p *FloatParameter) GetDefault() any {
+	if p.Default == nil {
+		return nil
+	}
+	return *p.Default
+}
+
+// Manifest returns the manifest for the FloatParameter.
+func (p *FloatParameter) Manifest() ParameterManifest {
+	// only list ParamAuthService names (without fields) in manifest
+	authNames := make([]string, len(p.AuthServices))
+	for i, a := range p.AuthServices {
+		authNames[i] = a.Name
+	}
+	r := CheckParamRequired(p.GetRequired(), p.GetDefault())
+	return ParameterManifest{
+		Name:         p.Name,
+		Type:         p.Type,
+		Required:     r,
+		Description:  p.Desc,
+		AuthServices: authNames,
+		Examples:     p.Examples,
+		Descriptions: p.Descriptions,
+		Enum:         p.Enum,
+		Minimum:      p.Minimum,
+		Maximum:      p.Maximum,
+		Completion:   p.Completion,
+	}
+}
+
+// McpManifest returns the MCP manifest for the FloatParameter.
+// json schema only allow numeric types of 'integer' and 'number'.
+func (p *FloatParameter) McpManifest() ParameterMcpManifest {
+	return ParameterMcpManifest{
+		Type:         "number",
+		Description:  p.Desc,
+		Examples:     p.Examples,
+		Descriptions: p.Descriptions,
+		Enum:         p.Enum,
+		Minimum:      p.Minimum,
+		Maximum:      p.Maximum,
+	}
+}
+
+// NewBooleanParameter is a convenience function for initializing a BooleanParameter.
+func NewBooleanParameter(name string, desc string) *BooleanParameter {
+	return &BooleanParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         typeBool,
+			Desc:         desc,
+			AuthServices: nil,
+		},
+	}
+}
+
+// NewBooleanParameterWithDefault is a convenience function for initializing a BooleanParameter with default value.
+func NewBooleanParameterWithDefault(name string, defaultV bool, desc string) *BooleanParameter {
+	return &BooleanParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         typeBool,
+			Desc:         desc,
+			AuthServices: nil,
+		},
+		Default: &defaultV,
+	}
+}
+
+// NewBooleanParameterWithRequired is a convenience function for initializing a BooleanParameter.
+func NewBooleanParameterWithRequired(name string, desc string, required bool) *BooleanParameter {
+	return &BooleanParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         typeBool,
+			Desc:         desc,
+			Required:     &required,
+			AuthServices: nil,
+		},
+	}
+}
+
+// NewBooleanParameterWithAuth is a convenience function for initializing a BooleanParameter with a list of ParamAuthService.
+func NewBooleanParameterWithAuth(name string, desc string, authServices []ParamAuthService) *BooleanParameter {
+	return &BooleanParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         typeBool,
+			Desc:         desc,
+			AuthServices: authServices,
+		},
+	}
+}
+
+var _ Parameter = &BooleanParameter{}
+
+// BooleanParameter is a parameter representing the "boolean" type.
+type BooleanParameter struct {
+	CommonParameter `yaml:",inline"`
+	Default         *bool `yaml:"default"`
+}
+
+func (p *BooleanParameter) Parse(v any) (any, error) {
+	newV, ok := v.(bool)
+	if !ok {
+		return nil, &ParseTypeError{p.Name, p.Type, v}
+	}
+	if err := p.checkEnum(newV); err != nil {
+		return nil, err
+	}
+	return newV, nil
+}
+
+func (p *BooleanParameter) GetAuthServices() []ParamAuthService {
+	return p.AuthServices
+}
+
+func (p *BooleanParameter) GetDefault() any {
+	if p.Default == nil {
+		return nil
+	}
+	return *p.Default
+}
+
+// Manifest returns the manifest for the BooleanParameter.
+func (p *BooleanParameter) Manifest() ParameterManifest {
+	// only list ParamAuthService names (without fields) in manifest
+	authNames := make([]string, len(p.AuthServices))
+	for i, a := range p.AuthServices {
+		authNames[i] = a.Name
+	}
+	r := CheckParamRequired(p.GetRequired(), p.GetDefault())
+	return ParameterManifest{
+		Name:         p.Name,
+		Type:         p.Type,
+		Required:     r,
+		Description:  p.Desc,
+		AuthServices: authNames,
+		Examples:     p.Examples,
+		Descriptions: p.Descriptions,
+		Enum:         p.Enum,
+		Completion:   p.Completion,
+	}
+}
+
+// NewArrayParameter is a convenience function for initializing a ArrayParameter.
+func NewArrayParameter(name string, desc string, items Parameter) *ArrayParameter {
+	return &ArrayParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         typeArray,
+			Desc:         desc,
+			AuthServices: nil,
+		},
+		Items: items,
+	}
+}
+
+// NewArrayParameterWithDefault is a convenience function for initializing a ArrayParameter with default value.
+func NewArrayParameterWithDefault(name string, defaultV []any, desc string, items Parameter) *ArrayParameter {
+	return &ArrayParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         typeArray,
+			Desc:         desc,
+			AuthServices: nil,
+		},
+		Items:   items,
+		Default: &defaultV,
+	}
+}
+
+// NewArrayParameterWithRequired is a convenience function for initializing a ArrayParameter with default value.
+func NewArrayParameterWithRequired(name string, desc string, required bool, items Parameter) *ArrayParameter {
+	return &ArrayParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         typeArray,
+			Desc:         desc,
+			Required:     &required,
+			AuthServices: nil,
+		},
+		Items: items,
+	}
+}
+
+// NewArrayParameterWithAuth is a convenience function for initializing a ArrayParameter with a list of ParamAuthService.
+func NewArrayParameterWithAuth(name string, desc string, items Parameter, authServices []ParamAuthService) *ArrayParameter {
+	return &ArrayParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         typeArray,
+			Desc:         desc,
+			AuthServices: authServices,
+		},
+		Items: items,
+	}
+}
+
+var _ Parameter = &ArrayParameter{}
+
+// ArrayParameter is a parameter representing the "array" type.
+type ArrayParameter struct {
+	CommonParameter `yaml:",inline"`
+	Default         *[]any    `yaml:"default"`
+	Items           Parameter `yaml:"items"`
+}
+
+func (p *ArrayParameter) UnmarshalYAML(ctx context.Context, unmarshal func(interface{}) error) error {
+	var rawItem struct {
+		CommonParameter `yaml:",inline"`
+		Default         *[]any                  `yaml:"default"`
+		Items           util.DelayedUnmarshaler `yaml:"items"`
+	}
+	if err := unmarshal(&rawItem); err != nil {
+		return err
+	}
+	p.CommonParameter = rawItem.CommonParameter
+	p.Default = rawItem.Default
+	i, err := parseParamFromDelayedUnmarshaler(ctx, &rawItem.Items)
+	if err != nil {
+		return fmt.Errorf("unable to parse 'items' field: %w", err)
+	}
+	if i.GetAuthServices() != nil && len(i.GetAuthServices()) != 0 {
+		return fmt.Errorf("nested items should not have auth services")
+	}
+	p.Items = i
+
+	return nil
+}
+
+func (p *ArrayParameter) Parse(v any) (any, error) {
+	arrVal, ok := v.([]any)
+	if !ok {
+		return nil, &ParseTypeError{p.Name, p.Type, v}
+	}
+	if len(arrVal) > MaxArrayLength {
+		return nil, &LimitError{Limit: LimitArrayLength, Max: MaxArrayLength}
+	}
+	rtn := make([]any, 0, len(arrVal))
+	for idx, val := range arrVal {
+		val, err := p.Items.Parse(val)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse element #%d: %w", idx, err)
+		}
+		rtn = append(rtn, val)
+	}
+	if err := p.checkEnum(rtn); err != nil {
+		return nil, err
+	}
+	return rtn, nil
+}
+
+func (p *ArrayParameter) GetAuthServices() []ParamAuthService {
+	return p.AuthServices
+}
+
+func (p *ArrayParameter) GetDefault() any {
+	if p.Default == nil {
+		return nil
+	}
+	return *p.Default
+}
+
+func (p *ArrayParameter) GetItems() Parameter {
+	return p.Items
+}
+
+// Manifest returns the manifest for the ArrayParameter.
+func (p *ArrayParameter) Manifest() ParameterManifest {
+	// only list ParamAuthService names (without fields) in manifest
+	authNames := make([]string, len(p.AuthServices))
+	for i, a := range p.AuthServices {
+		authNames[i] = a.Name
+	}
+	items := p.Items.Manifest()
+	// if required value is true, or there's no default value
+	r := CheckParamRequired(p.GetRequired(), p.GetDefault())
+	items.Required = r
+	return ParameterManifest{
+		Name:         p.Name,
+		Type:         p.Type,
+		Required:     r,
+		Description:  p.Desc,
+		AuthServices: authNames,
+		Items:        &items,
+		Examples:     p.Examples,
+		Descriptions: p.Descriptions,
+		Enum:         p.Enum,
+	}
+}
+
+// McpManifest returns the MCP manifest for the ArrayParameter.
+func (p *ArrayParameter) McpManifest() ParameterMcpManifest {
+	// only list ParamAuthService names (without fields) in manifest
+	authNames := make([]string, len(p.AuthServices))
+	for i, a := range p.AuthServices {
+		authNames[i] = a.Name
+	}
+	items := p.Items.McpManifest()
+	return ParameterMcpManifest{
+		Type:         p.Type,
+		Description:  p.Desc,
+		Items:        &items,
+		Examples:     p.Examples,
+		Descriptions: p.Descriptions,
+		Enum:         p.Enum,
+	}
+}
+
+// MapParameter is a parameter representing a map with string keys. If ValueType is
+// specified (e.g., "string"), values are validated against that type. If ValueType
+// is empty, it is treated as a generic map[string]any.
+type MapParameter struct {
+	CommonParameter `yaml:",inline"`
+	Default         *map[string]any `yaml:"default,omitempty"`
+	ValueType       string          `yaml:"valueType,omitempty"`
+}
+
+// Ensure MapParameter implements the Parameter interface.
+var _ Parameter = &MapParameter{}
+
+// NewMapParameter is a convenience function for initializing a MapParameter.
+func NewMapParameter(name string, desc string, valueType string) *MapParameter {
+	return &MapParameter{
+		CommonParameter: CommonParameter{
+			Name: name,
+			Type: "map",
+			Desc: desc,
+		},
+		ValueType: valueType,
+	}
+}
+
+// NewMapParameterWithDefault is a convenience function for initializing a MapParameter with a default value.
+func NewMapParameterWithDefault(name string, defaultV map[string]any, desc string, valueType string) *MapParameter {
+	return &MapParameter{
+		CommonParameter: CommonParameter{
+			Name: name,
+			Type: "map",
+			Desc: desc,
+		},
+		ValueType: valueType,
+		Default:   &defaultV,
+	}
+}
+
+// NewMapParameterWithRequired is a convenience function for initializing a MapParameter as required.
+func NewMapParameterWithRequired(name string, desc string, required bool, valueType string) *MapParameter {
+	return &MapParameter{
+		CommonParameter: CommonParameter{
+			Name:     name,
+			Type:     "map",
+			Desc:     desc,
+			Required: &required,
+		},
+		ValueType: valueType,
+	}
+}
+
+// NewMapParameterWithAuth is a convenience function for initializing a MapParameter with auth services.
+func NewMapParameterWithAuth(name string, desc string, valueType string, authServices []ParamAuthService) *MapParameter {
+	return &MapParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         "map",
+			Desc:         desc,
+			AuthServices: authServices,
+		},
+		ValueType: valueType,
+	}
+}
+
+// UnmarshalYAML handles parsing the MapParameter from YAML input.
+func (p *MapParameter) UnmarshalYAML(ctx context.Context, unmarshal func(interface{}) error) error {
+	var rawItem struct {
+		CommonParameter `yaml:",inline"`
+		Default         *map[string]any `yaml:"default"`
+		ValueType       string          `yaml:"valueType"`
+	}
+	if err := unmarshal(&rawItem); err != nil {
+		return err
+	}
+
+	// Validate `ValueType` to be one of the supported basic types
+	if rawItem.ValueType != "" {
+		if _, err := getPrototypeParameter(rawItem.ValueType); err != nil {
+			return err
+		}
+	}
+
+	p.CommonParameter = rawItem.CommonParameter
+	p.Default = rawItem.Default
+	p.ValueType = rawItem.ValueType
+	return nil
+}
+
+// getPrototypeParameter is a helper factory to create a temporary parameter
+// based on a type string for parsing and manifest generation.
+func getPrototypeParameter(typeName string) (Parameter, error) {
+	switch typeName {
+	case "string":
+		return NewStringParameter("", ""), nil
+	case "integer":
+		return NewIntParameter("", ""), nil
+	case "boolean":
+		return NewBooleanParameter("", ""), nil
+	case "float":
+		return NewFloatParameter("", ""), nil
+	default:
+		return nil, fmt.Errorf("unsupported valueType %q for map parameter", typeName)
+	}
+}
+
+// Parse validates and parses an incoming value for the map parameter.
+func (p *MapParameter) Parse(v any) (any, error) {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return nil, &ParseTypeError{p.Name, p.Type, v}
+	}
+	// for generic maps, convert json.Numbers to their corresponding types
+	if p.ValueType == "" {
+		// the values of generic maps are arbitrary JSON, so their nesting
+		// is bounded before they're walked
+		if err := checkLimits(m, 1); err != nil {
+			return nil, err
+		}
+		convertedData, err := util.ConvertNumbers(m)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse integer or float values in map: %s", err)
+		}
+		convertedMap, ok := convertedData.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("internal error: ConvertNumbers should return a map, but got type %T", convertedData)
+		}
+		if err := p.checkEnum(convertedMap); err != nil {
+			return nil, err
+		}
+		return convertedMap, nil
+	}
+
+	// Otherwise, get a prototype and parse each value in the map.
+	prototype, err := getPrototypeParameter(p.ValueType)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(m) > MaxArrayLength {
+		return nil, &LimitError{Limit: LimitArrayLength, Max: MaxArrayLength}
+	}
+	rtn := make(map[string]any, len(m))
+	for key, val := range m {
+		parsedVal, err := prototype.Parse(val)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse value for key %s: %w", quoteValue(key), err)
+		}
+		rtn[key] = parsedVal
+	}
+	if err := p.checkEnum(rtn); err != nil {
+		return nil, err
+	}
+	return rtn, nil
+}
+
+func (p *MapParameter) GetAuthServices() []ParamAuthService {
+	return p.AuthServices
+}
+
+func (p *MapParameter) GetDefault() any {
+	if p.Default == nil {
+		return nil
+	}
+	return *p.Default
+}
+
+func (p *MapParameter) GetValueType() string {
+	return p.ValueType
+}
+
+// Manifest returns the manifest for the MapParameter.
+func (p *MapParameter) Manifest() ParameterManifest {
+	authNames := make([]string, len(p.AuthServices))
+	for i, a := range p.AuthServices {
+		authNames[i] = a.Name
+	}
+	r := CheckParamRequired(p.GetRequired(), p.GetDefault())
+
+	var additionalProperties any
+	if p.ValueType != "" {
+		_, err := getPrototypeParameter(p.ValueType)
+		if err != nil {
+			panic(err)
+		}
+		valueSchema := map[string]any{"type": p.ValueType}
+		additionalProperties = valueSchema
+	} else {
+		// If no valueType is given, allow any properties.
+		additionalProperties = true
+	}
+
+	return ParameterManifest{
+		Name:                 p.Name,
+		Type:                 "object",
+		Required:             r,
+		Description:          p.Desc,
+		AuthServices:         authNames,
+		AdditionalProperties: additionalProperties,
+		Examples:             p.Examples,
+		Descriptions:         p.Descriptions,
+		Enum:                 p.Enum,
+	}
+}
+
+// McpManifest returns the MCP manifest for the MapParameter.
+func (p *MapParameter) McpManifest() ParameterMcpManifest {
+	var additionalProperties any
+	if p.ValueType != "" {
+		_, err := getPrototypeParameter(p.ValueType)
+		if err != nil {
+			panic(err)
+		}
+		valueSchema := map[string]any{"type": p.ValueType}
+		additionalProperties = valueSchema
+	} else {
+		// If no valueType is given, allow any properties.
+		additionalProperties = true
+	}
+
+	return ParameterMcpManifest{
+		Type:                 "object",
+		Description:          p.Desc,
+		AdditionalProperties: additionalProperties,
+		Examples:             p.Examples,
+		Descriptions:         p.Descriptions,
+		Enum:                 p.Enum,
+	}
+}
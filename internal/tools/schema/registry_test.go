@@ -0,0 +1,121 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools/schema"
+)
+
+// pointParameter is a custom parameter type of "lat,lng" strings.
+type pointParameter struct {
+	schema.StringParameter `yaml:",inline"`
+}
+
+func (p *pointParameter) Parse(v any) (any, error) {
+	s, err := p.StringParameter.Parse(v)
+	if err != nil {
+		return nil, err
+	}
+	var lat, lng float64
+	if _, err := fmt.Sscanf(s.(string), "%g,%g", &lat, &lng); err != nil {
+		return nil, fmt.Errorf("%q is not a point: %w", s, err)
+	}
+	return []float64{lat, lng}, nil
+}
+
+func init() {
+	schema.Register("point", func() schema.Parameter { return &pointParameter{} })
+}
+
+func TestRegister(t *testing.T) {
+	if schema.Register("string", func() schema.Parameter { return &pointParameter{} }) {
+		t.Fatalf("expected registering a built-in type to fail")
+	}
+
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	data, err := yaml.Marshal([]map[string]any{
+		{
+			"name":           "location",
+			"type":           "point",
+			"description":    "a point",
+			"description_ja": "地点",
+			"maxLength":      32,
+		},
+	})
+	if err != nil {
+		t.Fatalf("unable to marshal input to yaml: %s", err)
+	}
+	var got schema.Parameters
+	if err := yaml.UnmarshalContext(ctx, data, &got); err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+	maxLength := 32
+	want := schema.Parameters{
+		&pointParameter{schema.StringParameter{
+			CommonParameter: schema.CommonParameter{
+				Name:         "location",
+				Type:         "point",
+				Desc:         "a point",
+				Descriptions: map[string]string{"ja": "地点"},
+			},
+			MaxLength: &maxLength,
+		}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect parse: diff %v", diff)
+	}
+
+	values, err := schema.ParseParams(got, map[string]any{"location": "35.68,139.76"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(schema.ParamValues{{Name: "location", Value: []float64{35.68, 139.76}}}, values); diff != "" {
+		t.Fatalf("incorrect values: diff %v", diff)
+	}
+	if _, err := schema.ParseParams(got, map[string]any{"location": "tokyo"}, nil); err == nil {
+		t.Fatalf("expected an invalid point to fail")
+	}
+}
+
+func TestRegisterUnknownType(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	data, err := yaml.Marshal([]map[string]any{
+		{"name": "shape", "type": "geojson", "description": "a shape"},
+	})
+	if err != nil {
+		t.Fatalf("unable to marshal input to yaml: %s", err)
+	}
+	var got schema.Parameters
+	err = yaml.UnmarshalContext(ctx, data, &got)
+	if err == nil {
+		t.Fatalf("expected an unregistered type to fail")
+	}
+	if want := `"geojson" is not valid type for a parameter`; !strings.Contains(err.Error(), want) {
+		t.Fatalf("unexpected error: got %q, want to contain %q", err, want)
+	}
+}
@@ -0,0 +1,288 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemasearch
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/googleapis/genai-toolbox/internal/sources"
+)
+
+// BM25 parameters, with their usual values.
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// rrfK is the constant of reciprocal rank fusion, damping the weight of the
+// top ranks.
+const rrfK = 60
+
+// Weights of the terms found in each part of a table, so that a match on the
+// table name counts more than one on a column comment.
+const (
+	tableNameWeight     = 3
+	tableCommentWeight  = 2
+	columnNameWeight    = 1.5
+	columnCommentWeight = 1
+	schemaNameWeight    = 1
+)
+
+// stopWords are common words of questions that don't help to find tables.
+var stopWords = map[string]bool{
+	"a": true, "all": true, "an": true, "and": true, "are": true, "by": true,
+	"do": true, "does": true, "each": true, "for": true, "from": true,
+	"get": true, "has": true, "have": true, "how": true, "i": true, "in": true,
+	"is": true, "it": true, "list": true, "many": true, "me": true, "my": true,
+	"of": true, "on": true, "or": true, "per": true, "show": true,
+	"that": true, "the": true, "their": true, "there": true, "to": true,
+	"was": true, "we": true, "were": true, "what": true, "when": true,
+	"where": true, "which": true, "who": true, "with": true,
+}
+
+// Result is a table matching a question.
+type Result struct {
+	Table   string           `json:"table"`
+	Comment string           `json:"comment,omitempty"`
+	Columns []sources.Column `json:"columns"`
+	Score   float64          `json:"score"`
+}
+
+// Index ranks the tables of a database by relevance to a question. Tables
+// are ranked by BM25 over the terms of their names and comments, fused with
+// the similarity of their embeddings when available.
+type Index struct {
+	tables  []sources.Table
+	terms   []map[string]float64
+	lengths []float64
+	avgLen  float64
+	df      map[string]int
+	// vectors are the embeddings of the tables, if any.
+	vectors [][]float64
+}
+
+// NewIndex indexes the names and comments of tables.
+func NewIndex(tables []sources.Table) *Index {
+	idx := &Index{
+		tables:  tables,
+		terms:   make([]map[string]float64, len(tables)),
+		lengths: make([]float64, len(tables)),
+		df:      make(map[string]int),
+	}
+	var total float64
+	for i, t := range tables {
+		tf := make(map[string]float64)
+		add := func(text string, weight float64) {
+			for _, term := range Tokenize(text) {
+				tf[term] += weight
+			}
+		}
+		add(t.Name, tableNameWeight)
+		add(t.Comment, tableCommentWeight)
+		add(t.Schema, schemaNameWeight)
+		for _, c := range t.Columns {
+			add(c.Name, columnNameWeight)
+			add(c.Comment, columnCommentWeight)
+		}
+		for term, w := range tf {
+			idx.df[term]++
+			idx.lengths[i] += w
+		}
+		idx.terms[i] = tf
+		total += idx.lengths[i]
+	}
+	if len(tables) > 0 {
+		idx.avgLen = total / float64(len(tables))
+	}
+	return idx
+}
+
+// Tokenize splits text into lower case, singular terms, splitting snake and
+// camel case identifiers into words and dropping stop words.
+func Tokenize(text string) []string {
+	var terms []string
+	var word []rune
+	flush := func() {
+		if len(word) == 0 {
+			return
+		}
+		term := stem(strings.ToLower(string(word)))
+		word = word[:0]
+		if !stopWords[term] {
+			terms = append(terms, term)
+		}
+	}
+	runes := []rune(text)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+			continue
+		}
+		// split camel case, keeping acronyms such as "ID" in "userID" whole
+		if unicode.IsUpper(r) && i > 0 && len(word) > 0 {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				flush()
+			}
+		}
+		word = append(word, r)
+	}
+	flush()
+	return terms
+}
+
+// stem reduces the plural of English words to their singular, which is the
+// most common difference between the words of a question and table names.
+func stem(term string) string {
+	switch {
+	case len(term) <= 3:
+		return term
+	case strings.HasSuffix(term, "ies"):
+		return term[:len(term)-3] + "y"
+	case strings.HasSuffix(term, "sses"), strings.HasSuffix(term, "xes"), strings.HasSuffix(term, "ches"), strings.HasSuffix(term, "shes"):
+		return term[:len(term)-2]
+	case strings.HasSuffix(term, "ss"), strings.HasSuffix(term, "us"), strings.HasSuffix(term, "is"):
+		return term
+	case strings.HasSuffix(term, "s"):
+		return term[:len(term)-1]
+	}
+	return term
+}
+
+// lexicalScores returns the BM25 score of each table for the question.
+func (idx *Index) lexicalScores(question string) []float64 {
+	scores := make([]float64, len(idx.tables))
+	n := float64(len(idx.tables))
+	seen := make(map[string]bool)
+	for _, term := range Tokenize(question) {
+		if seen[term] {
+			continue
+		}
+		seen[term] = true
+		df := idx.df[term]
+		if df == 0 {
+			continue
+		}
+		idf := math.Log(1 + (n-float64(df)+0.5)/(float64(df)+0.5))
+		for i, tf := range idx.terms {
+			f := tf[term]
+			if f == 0 {
+				continue
+			}
+			norm := bm25K1 * (1 - bm25B + bm25B*idx.lengths[i]/idx.avgLen)
+			scores[i] += idf * f * (bm25K1 + 1) / (f + norm)
+		}
+	}
+	return scores
+}
+
+// Search returns up to limit tables matching the question, the most relevant
+// first. vector is the embedding of the question, if the tables have
+// embeddings. Tables that neither match a term of the question nor are
+// similar to it are never returned.
+func (idx *Index) Search(question string, vector []float64, limit int) []Result {
+	scores := idx.lexicalScores(question)
+	if vector != nil && idx.vectors != nil {
+		similarities := make([]float64, len(idx.tables))
+		for i, v := range idx.vectors {
+			similarities[i] = cosine(vector, v)
+		}
+		scores = fuse(scores, similarities)
+	}
+
+	order := make([]int, 0, len(scores))
+	for i, s := range scores {
+		if s > 0 {
+			order = append(order, i)
+		}
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return scores[order[a]] > scores[order[b]]
+	})
+	if len(order) > limit {
+		order = order[:limit]
+	}
+
+	results := make([]Result, 0, len(order))
+	for _, i := range order {
+		t := idx.tables[i]
+		results = append(results, Result{
+			Table:   t.QualifiedName(),
+			Comment: t.Comment,
+			Columns: t.Columns,
+			Score:   math.Round(scores[i]*10000) / 10000,
+		})
+	}
+	return results
+}
+
+// fuse combines the rankings of the lexical scores and the similarities with
+// reciprocal rank fusion, which doesn't depend on the scale of either score.
+func fuse(lexical, similarities []float64) []float64 {
+	fused := make([]float64, len(lexical))
+	for _, scores := range [][]float64{lexical, similarities} {
+		order := make([]int, len(scores))
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(a, b int) bool {
+			return scores[order[a]] > scores[order[b]]
+		})
+		for rank, i := range order {
+			// tables only gain from the rankings they score in
+			if scores[i] > 0 {
+				fused[i] += 1 / float64(rrfK+rank+1)
+			}
+		}
+	}
+	return fused
+}
+
+func cosine(a, b []float64) float64 {
+	if len(a) != len(b) {
+		return 0
+	}
+	var dot, na, nb float64
+	for i := range a {
+		dot += a[i] * b[i]
+		na += a[i] * a[i]
+		nb += b[i] * b[i]
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
+
+// embeddingText is the text embedded for a table.
+func embeddingText(t sources.Table) string {
+	var sb strings.Builder
+	sb.WriteString("Table " + t.QualifiedName())
+	if t.Comment != "" {
+		sb.WriteString(": " + t.Comment)
+	}
+	sb.WriteString("\nColumns:")
+	for _, c := range t.Columns {
+		sb.WriteString("\n- " + c.Name + " (" + c.Type + ")")
+		if c.Comment != "" {
+			sb.WriteString(": " + c.Comment)
+		}
+	}
+	return sb.String()
+}
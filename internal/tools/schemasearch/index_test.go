@@ -0,0 +1,127 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemasearch_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/tools/schemasearch"
+)
+
+func TestTokenize(t *testing.T) {
+	tcs := []struct {
+		in   string
+		want []string
+	}{
+		{in: "customer_orders", want: []string{"customer", "order"}},
+		{in: "customerOrders", want: []string{"customer", "order"}},
+		{in: "userID", want: []string{"user", "id"}},
+		{in: "HTTPRequests", want: []string{"http", "request"}},
+		{in: "How many categories were shipped by address?", want: []string{"category", "shipped", "address"}},
+		{in: "boxes, batches & status", want: []string{"box", "batch", "status"}},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.in, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, schemasearch.Tokenize(tc.in)); diff != "" {
+				t.Fatalf("incorrect terms: diff %v", diff)
+			}
+		})
+	}
+}
+
+var testTables = []sources.Table{
+	{
+		Schema:  "public",
+		Name:    "orders",
+		Comment: "Purchases placed by customers",
+		Columns: []sources.Column{
+			{Name: "id", Type: "integer"},
+			{Name: "customer_id", Type: "integer"},
+			{Name: "total", Type: "numeric", Comment: "Amount paid, in dollars"},
+		},
+	},
+	{
+		Schema: "public",
+		Name:   "customers",
+		Columns: []sources.Column{
+			{Name: "id", Type: "integer"},
+			{Name: "name", Type: "text"},
+			{Name: "email", Type: "text"},
+		},
+	},
+	{
+		Schema:  "inventory",
+		Name:    "products",
+		Comment: "Items for sale",
+		Columns: []sources.Column{
+			{Name: "sku", Type: "text"},
+			{Name: "price", Type: "numeric"},
+		},
+	},
+}
+
+func TestSearch(t *testing.T) {
+	idx := schemasearch.NewIndex(testTables)
+	tcs := []struct {
+		desc     string
+		question string
+		limit    int
+		want     []string
+	}{
+		{
+			desc:     "table name",
+			question: "How many orders were placed last month?",
+			limit:    5,
+			want:     []string{"public.orders"},
+		},
+		{
+			desc:     "table name before column name",
+			question: "customer emails",
+			limit:    5,
+			want:     []string{"public.customers", "public.orders"},
+		},
+		{
+			desc:     "comment",
+			question: "items for sale",
+			limit:    5,
+			want:     []string{"inventory.products"},
+		},
+		{
+			desc:     "limit",
+			question: "customer emails",
+			limit:    1,
+			want:     []string{"public.customers"},
+		},
+		{
+			desc:     "no match",
+			question: "weather forecast",
+			limit:    5,
+			want:     []string{},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := []string{}
+			for _, r := range idx.Search(tc.question, nil, tc.limit) {
+				got = append(got, r.Table)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect tables: diff %v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemasearch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+const kind string = "schema-search"
+const questionKey string = "question"
+const limitKey string = "limit"
+
+// defaultMaxResults is the number of tables returned when maxResults is not
+// configured.
+const defaultMaxResults = 5
+
+// indexTimeout bounds the time spent listing and embedding the schema when
+// the tool is loaded.
+const indexTimeout = 2 * time.Minute
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name, MaxResults: defaultMaxResults}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name         string   `yaml:"name" validate:"required"`
+	Kind         string   `yaml:"kind" validate:"required"`
+	Source       string   `yaml:"source" validate:"required"`
+	Description  string   `yaml:"description" validate:"required"`
+	AuthRequired []string `yaml:"authRequired"`
+	// Schemas limits the indexed tables to the ones in these schemas.
+	Schemas    []string `yaml:"schemas" validate:"dive,required"`
+	MaxResults int      `yaml:"maxResults" validate:"gte=1"`
+	// Embedding optionally embeds the tables and questions, so that tables
+	// are also found by meaning rather than only by the words they contain.
+	Embedding *EmbeddingConfig `yaml:"embedding"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(sources.SchemaLister)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source %q of kind %q can't list its schema", kind, cfg.Source, rawS.SourceKind())
+	}
+
+	// the schema is indexed once, when the tool is loaded
+	ctx, cancel := context.WithTimeout(context.Background(), indexTimeout)
+	defer cancel()
+	tables, err := s.ListSchema(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list the schema of source %q: %w", cfg.Source, err)
+	}
+	if len(cfg.Schemas) > 0 {
+		var filtered []sources.Table
+		for _, t := range tables {
+			if slices.Contains(cfg.Schemas, t.Schema) {
+				filtered = append(filtered, t)
+			}
+		}
+		tables = filtered
+	}
+
+	index := NewIndex(tables)
+	client := &http.Client{}
+	if cfg.Embedding != nil && len(tables) > 0 {
+		texts := make([]string, len(tables))
+		for i, t := range tables {
+			texts[i] = embeddingText(t)
+		}
+		index.vectors, err = cfg.Embedding.embed(ctx, client, texts)
+		if err != nil {
+			return nil, fmt.Errorf("unable to embed the schema of source %q: %w", cfg.Source, err)
+		}
+	}
+
+	questionParameter := tools.NewStringParameter(questionKey, "A natural language question, or keywords, describing the data to find.")
+	limitParameter := tools.NewIntParameterWithDefault(limitKey, cfg.MaxResults, "The maximum number of tables to return.")
+	parameters := tools.Parameters{questionParameter, limitParameter}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   parameters,
+		AuthRequired: cfg.AuthRequired,
+		Index:        index,
+		embedding:    cfg.Embedding,
+		client:       client,
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:  mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+
+	Index       *Index
+	embedding   *EmbeddingConfig
+	client      *http.Client
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	mapParams := params.AsMap()
+	question, ok := mapParams[questionKey].(string)
+	if !ok || question == "" {
+		return nil, fmt.Errorf("invalid or missing '%s' parameter; expected a non-empty string", questionKey)
+	}
+	limit, ok := mapParams[limitKey].(int)
+	if !ok || limit < 1 {
+		return nil, fmt.Errorf("invalid '%s' parameter; expected a positive integer", limitKey)
+	}
+
+	var vector []float64
+	if t.embedding != nil && t.Index.vectors != nil {
+		vectors, err := t.embedding.embed(ctx, t.client, []string{question})
+		if err != nil {
+			return nil, fmt.Errorf("unable to embed question: %w", err)
+		}
+		vector = vectors[0]
+	}
+
+	return t.Index.Search(question, vector, limit), nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
@@ -0,0 +1,216 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemasearch_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/schemasearch"
+)
+
+func TestParseFromYamlSchemaSearch(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: schema-search
+					source: my-pg-instance
+					description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": schemasearch.Config{
+					Name:         "example_tool",
+					Kind:         "schema-search",
+					Source:       "my-pg-instance",
+					Description:  "some description",
+					AuthRequired: []string{},
+					MaxResults:   5,
+				},
+			},
+		},
+		{
+			desc: "with embedding",
+			in: `
+			tools:
+				example_tool:
+					kind: schema-search
+					source: my-pg-instance
+					description: some description
+					schemas:
+						- public
+					maxResults: 3
+					embedding:
+						url: https://api.openai.com/v1/embeddings
+						model: text-embedding-3-small
+						apiKey: my-key
+			`,
+			want: server.ToolConfigs{
+				"example_tool": schemasearch.Config{
+					Name:         "example_tool",
+					Kind:         "schema-search",
+					Source:       "my-pg-instance",
+					Description:  "some description",
+					AuthRequired: []string{},
+					Schemas:      []string{"public"},
+					MaxResults:   3,
+					Embedding: &schemasearch.EmbeddingConfig{
+						URL:    "https://api.openai.com/v1/embeddings",
+						Model:  "text-embedding-3-small",
+						APIKey: "my-key",
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+type fakeSource struct {
+	tables []sources.Table
+}
+
+func (s fakeSource) SourceKind() string {
+	return "fake"
+}
+
+func (s fakeSource) ListSchema(ctx context.Context) ([]sources.Table, error) {
+	return s.tables, nil
+}
+
+func invoke(t *testing.T, tool tools.Tool, question string) []string {
+	t.Helper()
+	params, err := tool.ParseParams(map[string]any{"question": question}, nil)
+	if err != nil {
+		t.Fatalf("unable to parse params: %s", err)
+	}
+	res, err := tool.Invoke(context.Background(), params)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	got := []string{}
+	for _, r := range res.([]schemasearch.Result) {
+		got = append(got, r.Table)
+	}
+	return got
+}
+
+func TestInvokeSchemaSearch(t *testing.T) {
+	srcs := map[string]sources.Source{"my-source": fakeSource{tables: testTables}}
+	cfg := schemasearch.Config{
+		Name:        "search",
+		Kind:        "schema-search",
+		Source:      "my-source",
+		Description: "some description",
+		Schemas:     []string{"public"},
+		MaxResults:  5,
+	}
+	tool, err := cfg.Initialize(srcs)
+	if err != nil {
+		t.Fatalf("unable to initialize tool: %s", err)
+	}
+	// tables outside of the configured schemas aren't indexed
+	if diff := cmp.Diff([]string{}, invoke(t, tool, "items for sale")); diff != "" {
+		t.Fatalf("incorrect tables: diff %v", diff)
+	}
+	if diff := cmp.Diff([]string{"public.customers", "public.orders"}, invoke(t, tool, "customer emails")); diff != "" {
+		t.Fatalf("incorrect tables: diff %v", diff)
+	}
+}
+
+func TestInvokeSchemaSearchWithEmbedding(t *testing.T) {
+	// embeds texts about selling products close to each other
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer my-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var req struct {
+			Model string   `json:"model"`
+			Input []string `json:"input"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Model != "my-model" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		data := []map[string]any{}
+		for i, in := range req.Input {
+			v := []float64{1, 0}
+			if strings.Contains(in, "sale") || strings.Contains(in, "sell") {
+				v = []float64{0, 1}
+			}
+			data = append(data, map[string]any{"index": i, "embedding": v})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}))
+	defer ts.Close()
+
+	srcs := map[string]sources.Source{"my-source": fakeSource{tables: testTables}}
+	cfg := schemasearch.Config{
+		Name:        "search",
+		Kind:        "schema-search",
+		Source:      "my-source",
+		Description: "some description",
+		MaxResults:  1,
+		Embedding:   &schemasearch.EmbeddingConfig{URL: ts.URL, Model: "my-model", APIKey: "my-key"},
+	}
+	tool, err := cfg.Initialize(srcs)
+	if err != nil {
+		t.Fatalf("unable to initialize tool: %s", err)
+	}
+	// no term of the question is in the schema
+	if diff := cmp.Diff([]string{"inventory.products"}, invoke(t, tool, "what do we sell?")); diff != "" {
+		t.Fatalf("incorrect tables: diff %v", diff)
+	}
+
+	cfg.Embedding.APIKey = "wrong-key"
+	if _, err := cfg.Initialize(srcs); err == nil || !strings.Contains(err.Error(), "unexpected status code: 401") {
+		t.Fatalf("expect embedding to fail with an unauthorized error, got %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scratchpadexecutesql_test
+
+import (
+	"context"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/scratchpad"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/scratchpad/scratchpadexecutesql"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestParseFromYamlScratchpadExecuteSql(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: scratchpad-execute-sql
+					source: my-scratchpad
+					description: some description
+					authRequired:
+						- my-google-auth-service
+			`,
+			want: server.ToolConfigs{
+				"example_tool": scratchpadexecutesql.Config{
+					Name:         "example_tool",
+					Kind:         "scratchpad-execute-sql",
+					Source:       "my-scratchpad",
+					Description:  "some description",
+					AuthRequired: []string{"my-google-auth-service"},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInvoke(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	src, err := scratchpad.Config{Name: "my-scratchpad", Kind: scratchpad.SourceKind, Scope: scratchpad.ScopeServer, SessionTTL: "30m"}.Initialize(context.Background(), noop.NewTracerProvider().Tracer("test"))
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	tool, err := scratchpadexecutesql.Config{
+		Name:        "example_tool",
+		Kind:        "scratchpad-execute-sql",
+		Source:      "my-scratchpad",
+		Description: "some description",
+	}.Initialize(map[string]sources.Source{"my-scratchpad": src})
+	if err != nil {
+		t.Fatalf("unable to initialize tool: %s", err)
+	}
+
+	// each statement runs in a separate invocation, so the data must persist
+	// between them
+	statements := []string{
+		"CREATE TABLE results (id INTEGER, name TEXT)",
+		"INSERT INTO results VALUES (1, 'a'), (2, 'b')",
+	}
+	for _, s := range statements {
+		if _, err := tool.Invoke(ctx, tools.ParamValues{{Name: "sql", Value: s}}); err != nil {
+			t.Fatalf("unable to execute %q: %s", s, err)
+		}
+	}
+
+	got, err := tool.Invoke(ctx, tools.ParamValues{{Name: "sql", Value: "SELECT id, name FROM results ORDER BY id"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := []any{
+		map[string]any{"id": int64(1), "name": "a"},
+		map[string]any{"id": int64(2), "name": "b"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect result: diff %v", diff)
+	}
+
+	if _, err := tool.Invoke(ctx, tools.ParamValues{{Name: "sql", Value: "SELECT * FROM missing"}}); err == nil {
+		t.Fatalf("expected an error querying a missing table")
+	}
+}
@@ -0,0 +1,295 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scratchpadinsertrows
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/scratchpad"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+const kind string = "scratchpad-insert-rows"
+const tableKey string = "table"
+const rowsKey string = "rows"
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	ScratchpadClient() *scratchpad.Client
+}
+
+// validate compatible sources are still compatible
+var _ compatibleSource = &scratchpad.Source{}
+
+var compatibleSources = [...]string{scratchpad.SourceKind}
+
+type Config struct {
+	Name         string   `yaml:"name" validate:"required"`
+	Kind         string   `yaml:"kind" validate:"required"`
+	Source       string   `yaml:"source" validate:"required"`
+	Description  string   `yaml:"description" validate:"required"`
+	AuthRequired []string `yaml:"authRequired"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(compatibleSource)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
+	}
+
+	tableParameter := tools.NewStringParameter(tableKey, "The name of the scratchpad table to insert the rows into. The table is created if it doesn't exist.")
+	rowParameter := tools.NewMapParameter("row", "A row, mapping column names to values.", "")
+	rowsParameter := tools.NewArrayParameter(rowsKey, "The rows to insert. Columns missing from the table are added to it.", rowParameter)
+	parameters := tools.Parameters{tableParameter, rowsParameter}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   parameters,
+		AuthRequired: cfg.AuthRequired,
+		Client:       s.ScratchpadClient(),
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:  mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+
+	Client      *scratchpad.Client
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	mapParams := params.AsMap()
+	table, ok := mapParams[tableKey].(string)
+	if !ok || table == "" {
+		return nil, fmt.Errorf("invalid or missing '%s' parameter; expected a non-empty string", tableKey)
+	}
+	rawRows, ok := mapParams[rowsKey].([]any)
+	if !ok {
+		return nil, fmt.Errorf("invalid or missing '%s' parameter; expected an array of rows", rowsKey)
+	}
+
+	// collect the columns of all rows, sorted to create tables deterministically
+	rows := make([]map[string]any, 0, len(rawRows))
+	colSet := make(map[string]bool)
+	for i, r := range rawRows {
+		row, ok := r.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("row #%d is not an object", i)
+		}
+		for col := range row {
+			if col == "" {
+				return nil, fmt.Errorf("row #%d has an empty column name", i)
+			}
+			colSet[col] = true
+		}
+		rows = append(rows, row)
+	}
+	cols := make([]string, 0, len(colSet))
+	for col := range colSet {
+		cols = append(cols, col)
+	}
+	sort.Strings(cols)
+	if len(cols) == 0 {
+		return nil, fmt.Errorf("at least one row with a column is required")
+	}
+
+	db, err := t.Client.DB(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to begin transaction: %w", err)
+	}
+	// rolling back is a no-op once the transaction is committed
+	defer func() { _ = tx.Rollback() }()
+
+	if err := ensureTable(ctx, tx, table, cols); err != nil {
+		return nil, err
+	}
+
+	stmt, err := tx.PrepareContext(ctx, BuildInsert(table, cols))
+	if err != nil {
+		return nil, fmt.Errorf("unable to prepare insert: %w", err)
+	}
+	defer stmt.Close()
+
+	args := make([]any, len(cols))
+	for i, row := range rows {
+		for j, col := range cols {
+			args[j], err = toSQLValue(row[col])
+			if err != nil {
+				return nil, fmt.Errorf("unable to convert column %q of row #%d: %w", col, i, err)
+			}
+		}
+		if _, err := stmt.ExecContext(ctx, args...); err != nil {
+			return nil, fmt.Errorf("unable to insert row #%d: %w", i, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("unable to commit transaction: %w", err)
+	}
+	return map[string]any{
+		"table":        table,
+		"rowsInserted": len(rows),
+	}, nil
+}
+
+// ensureTable creates the table if it doesn't exist, and adds the columns
+// that are missing from it. Columns are created without a type, so SQLite
+// stores each value with its own type.
+func ensureTable(ctx context.Context, tx *sql.Tx, table string, cols []string) error {
+	quotedCols := make([]string, 0, len(cols))
+	for _, col := range cols {
+		quotedCols = append(quotedCols, quoteIdentifier(col))
+	}
+	create := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (%s)", quoteIdentifier(table), strings.Join(quotedCols, ", "))
+	if _, err := tx.ExecContext(ctx, create); err != nil {
+		return fmt.Errorf("unable to create table %q: %w", table, err)
+	}
+
+	rows, err := tx.QueryContext(ctx, "SELECT name FROM pragma_table_info(?)", table)
+	if err != nil {
+		return fmt.Errorf("unable to get columns of table %q: %w", table, err)
+	}
+	existing := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return fmt.Errorf("unable to get columns of table %q: %w", table, err)
+		}
+		existing[name] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("unable to get columns of table %q: %w", table, err)
+	}
+
+	for _, col := range cols {
+		if existing[col] {
+			continue
+		}
+		alter := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s", quoteIdentifier(table), quoteIdentifier(col))
+		if _, err := tx.ExecContext(ctx, alter); err != nil {
+			return fmt.Errorf("unable to add column %q to table %q: %w", col, table, err)
+		}
+	}
+	return nil
+}
+
+// BuildInsert returns an INSERT statement for the given table and columns,
+// with one positional placeholder per column.
+func BuildInsert(table string, cols []string) string {
+	quotedCols := make([]string, 0, len(cols))
+	placeholders := make([]string, 0, len(cols))
+	for _, col := range cols {
+		quotedCols = append(quotedCols, quoteIdentifier(col))
+		placeholders = append(placeholders, "?")
+	}
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", quoteIdentifier(table), strings.Join(quotedCols, ", "), strings.Join(placeholders, ", "))
+}
+
+// quoteIdentifier quotes a table or column name so that it can't be used to
+// inject SQL. Names that can't be quoted become empty, which makes the
+// statement fail to execute.
+func quoteIdentifier(name string) string {
+	quoted, _ := tools.QuoteIdentifier(tools.DialectANSI, name)
+	return quoted
+}
+
+// toSQLValue converts a JSON value to a value SQLite can store. Objects and
+// arrays are stored as JSON text, which can be queried with SQLite's JSON
+// functions.
+func toSQLValue(v any) (any, error) {
+	switch v.(type) {
+	case map[string]any, []any:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	}
+	return v, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
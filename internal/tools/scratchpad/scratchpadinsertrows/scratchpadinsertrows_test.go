@@ -0,0 +1,152 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scratchpadinsertrows_test
+
+import (
+	"context"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/scratchpad"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/scratchpad/scratchpadinsertrows"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestParseFromYamlScratchpadInsertRows(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: scratchpad-insert-rows
+					source: my-scratchpad
+					description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": scratchpadinsertrows.Config{
+					Name:         "example_tool",
+					Kind:         "scratchpad-insert-rows",
+					Source:       "my-scratchpad",
+					Description:  "some description",
+					AuthRequired: []string{},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestBuildInsert(t *testing.T) {
+	got := scratchpadinsertrows.BuildInsert(`my"table`, []string{"a", "b c"})
+	want := `INSERT INTO "my""table" ("a", "b c") VALUES (?, ?)`
+	if got != want {
+		t.Fatalf("incorrect statement: got %q, want %q", got, want)
+	}
+}
+
+func TestInvoke(t *testing.T) {
+	ctx := context.Background()
+	src, err := scratchpad.Config{Name: "my-scratchpad", Kind: scratchpad.SourceKind, Scope: scratchpad.ScopeServer, SessionTTL: "30m"}.Initialize(ctx, noop.NewTracerProvider().Tracer("test"))
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	tool, err := scratchpadinsertrows.Config{
+		Name:        "example_tool",
+		Kind:        "scratchpad-insert-rows",
+		Source:      "my-scratchpad",
+		Description: "some description",
+	}.Initialize(map[string]sources.Source{"my-scratchpad": src})
+	if err != nil {
+		t.Fatalf("unable to initialize tool: %s", err)
+	}
+
+	invoke := func(rows ...any) (any, error) {
+		return tool.Invoke(ctx, tools.ParamValues{
+			{Name: "table", Value: "orders"},
+			{Name: "rows", Value: rows},
+		})
+	}
+	got, err := invoke(
+		map[string]any{"id": 1, "customer": "a"},
+		map[string]any{"id": 2, "customer": "b", "tags": []any{"x", "y"}},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(map[string]any{"table": "orders", "rowsInserted": 2}, got); diff != "" {
+		t.Fatalf("incorrect result: diff %v", diff)
+	}
+	// a second call adds the new column to the existing table
+	if _, err := invoke(map[string]any{"id": 3, "total": 9.5}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := invoke(); err == nil {
+		t.Fatalf("expected an error inserting no rows")
+	}
+
+	db, err := src.(*scratchpad.Source).ScratchpadClient().DB(ctx)
+	if err != nil {
+		t.Fatalf("unable to get database: %s", err)
+	}
+	rows, err := db.QueryContext(ctx, `SELECT id, customer, tags, total FROM orders ORDER BY id`)
+	if err != nil {
+		t.Fatalf("unable to query: %s", err)
+	}
+	defer rows.Close()
+	var gotRows [][]any
+	for rows.Next() {
+		var id, customer, tags, total any
+		if err := rows.Scan(&id, &customer, &tags, &total); err != nil {
+			t.Fatalf("unable to scan: %s", err)
+		}
+		gotRows = append(gotRows, []any{id, customer, tags, total})
+	}
+	wantRows := [][]any{
+		{int64(1), "a", nil, nil},
+		{int64(2), "b", `["x","y"]`, nil},
+		{int64(3), nil, nil, 9.5},
+	}
+	if diff := cmp.Diff(wantRows, gotRows); diff != "" {
+		t.Fatalf("incorrect rows: diff %v", diff)
+	}
+}
@@ -0,0 +1,192 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicenowqueryrecords
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	servicenowds "github.com/googleapis/genai-toolbox/internal/sources/servicenow"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+const kind string = "servicenow-query-records"
+const queryKey string = "query"
+const limitKey string = "limit"
+const offsetKey string = "offset"
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	ServiceNowInstanceURL() string
+	ServiceNowRequest(ctx context.Context, method, path string, query url.Values, body any) ([]byte, error)
+}
+
+// validate compatible sources are still compatible
+var _ compatibleSource = &servicenowds.Source{}
+
+var compatibleSources = [...]string{servicenowds.SourceKind}
+
+type Config struct {
+	Name         string   `yaml:"name" validate:"required"`
+	Kind         string   `yaml:"kind" validate:"required"`
+	Source       string   `yaml:"source" validate:"required"`
+	Description  string   `yaml:"description" validate:"required"`
+	AuthRequired []string `yaml:"authRequired"`
+	Table        string   `yaml:"table"`
+	Fields       []string `yaml:"fields"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(compatibleSource)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
+	}
+
+	table := cfg.Table
+	if table == "" {
+		table = "incident"
+	}
+
+	queryParameter := tools.NewStringParameterWithDefault(queryKey, "", "An encoded query used to filter the records, for example `active=true^priority<=2^ORDERBYDESCsys_created_on`. Returns all records if empty.")
+	limitParameter := tools.NewIntParameterWithDefault(limitKey, 20, "The maximum number of records to return.")
+	offsetParameter := tools.NewIntParameterWithDefault(offsetKey, 0, "The number of records to skip, used to fetch the next page of results.")
+	parameters := tools.Parameters{queryParameter, limitParameter, offsetParameter}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   parameters,
+		AuthRequired: cfg.AuthRequired,
+		Table:        table,
+		Fields:       cfg.Fields,
+		Source:       s,
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:  mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+	Table        string           `yaml:"table"`
+	Fields       []string         `yaml:"fields"`
+
+	Source      compatibleSource
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	mapParams := params.AsMap()
+	query, _ := mapParams[queryKey].(string)
+	limit, _ := mapParams[limitKey].(int)
+	offset, _ := mapParams[offsetKey].(int)
+
+	// reference fields are returned as their display values instead of links
+	// to other records, which are more useful to an LLM
+	values := url.Values{
+		"sysparm_limit":                  {strconv.Itoa(limit)},
+		"sysparm_offset":                 {strconv.Itoa(offset)},
+		"sysparm_display_value":          {"true"},
+		"sysparm_exclude_reference_link": {"true"},
+	}
+	if query != "" {
+		values.Set("sysparm_query", query)
+	}
+	if len(t.Fields) > 0 {
+		values.Set("sysparm_fields", strings.Join(t.Fields, ","))
+	}
+
+	respBody, err := t.Source.ServiceNowRequest(ctx, http.MethodGet, "/api/now/table/"+url.PathEscape(t.Table), values, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query table %q: %w", t.Table, err)
+	}
+	var resp struct {
+		Result []any `json:"result"`
+	}
+	decoder := json.NewDecoder(bytes.NewReader(respBody))
+	decoder.UseNumber()
+	if err := decoder.Decode(&resp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if resp.Result == nil {
+		resp.Result = []any{}
+	}
+	return resp.Result, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
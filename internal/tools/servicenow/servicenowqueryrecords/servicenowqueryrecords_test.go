@@ -0,0 +1,136 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicenowqueryrecords_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	servicenowds "github.com/googleapis/genai-toolbox/internal/sources/servicenow"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools/servicenow/servicenowqueryrecords"
+)
+
+func TestParseFromYamlServiceNowQueryRecords(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: servicenow-query-records
+					source: my-servicenow
+					description: some description
+					table: problem
+					fields:
+						- number
+						- short_description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": servicenowqueryrecords.Config{
+					Name:         "example_tool",
+					Kind:         "servicenow-query-records",
+					Source:       "my-servicenow",
+					Description:  "some description",
+					AuthRequired: []string{},
+					Table:        "problem",
+					Fields:       []string{"number", "short_description"},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInvoke(t *testing.T) {
+	var gotPath, gotKey string
+	var gotQuery url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotQuery = r.URL.Query()
+		gotKey = r.Header.Get("x-sn-apikey")
+		_, _ = w.Write([]byte(`{"result": [{"number": "INC0010001", "short_description": "orders has duplicate rows"}]}`))
+	}))
+	defer srv.Close()
+
+	srcs := map[string]sources.Source{
+		"my-servicenow": &servicenowds.Source{Name: "my-servicenow", Kind: servicenowds.SourceKind, InstanceURL: srv.URL, APIKey: "key", Client: srv.Client()},
+	}
+	cfg := servicenowqueryrecords.Config{Name: "example_tool", Kind: "servicenow-query-records", Source: "my-servicenow", Description: "some description", Fields: []string{"number", "short_description"}}
+	tool, err := cfg.Initialize(srcs)
+	if err != nil {
+		t.Fatalf("unable to initialize tool: %s", err)
+	}
+	params, err := tool.ParseParams(map[string]any{"query": "active=true", "limit": json.Number("5")}, nil)
+	if err != nil {
+		t.Fatalf("unable to parse params: %s", err)
+	}
+	got, err := tool.Invoke(context.Background(), params)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if gotPath != "/api/now/table/incident" {
+		t.Errorf("incorrect path: %q", gotPath)
+	}
+	if gotKey != "key" {
+		t.Errorf("incorrect api key: %q", gotKey)
+	}
+	wantQuery := url.Values{
+		"sysparm_query":                  {"active=true"},
+		"sysparm_limit":                  {"5"},
+		"sysparm_offset":                 {"0"},
+		"sysparm_fields":                 {"number,short_description"},
+		"sysparm_display_value":          {"true"},
+		"sysparm_exclude_reference_link": {"true"},
+	}
+	if diff := cmp.Diff(wantQuery, gotQuery); diff != "" {
+		t.Errorf("incorrect query: diff %v", diff)
+	}
+	want := []any{map[string]any{"number": "INC0010001", "short_description": "orders has duplicate rows"}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("incorrect result: diff %v", diff)
+	}
+}
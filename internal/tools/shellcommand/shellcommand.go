@@ -0,0 +1,300 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shellcommand
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"text/template"
+	"time"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+const kind string = "shell-command"
+
+// defaultMaxOutputBytes is the largest output kept per stream when
+// maxOutputBytes is not configured.
+const defaultMaxOutputBytes int64 = 64 << 10
+
+// waitDelay is how long the output of a command is read for once it's killed
+// or exits.
+const waitDelay = time.Second
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name, Timeout: "30s"} // Default timeout
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name           string   `yaml:"name" validate:"required"`
+	Kind           string   `yaml:"kind" validate:"required"`
+	Description    string   `yaml:"description" validate:"required"`
+	Command        []string `yaml:"command" validate:"required,min=1"`
+	WorkingDir     string   `yaml:"workingDir"`
+	AllowedEnv     []string `yaml:"allowedEnv"`
+	Timeout        string   `yaml:"timeout"`
+	MaxOutputBytes int64    `yaml:"maxOutputBytes"`
+	// AllowLeadingDash allows parameters to render arguments starting with
+	// "-", which the command could otherwise parse as options.
+	AllowLeadingDash bool             `yaml:"allowLeadingDash"`
+	AuthRequired     []string         `yaml:"authRequired"`
+	Parameters       tools.Parameters `yaml:"parameters"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+var _ tools.ShellToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) RunsShellCommands() bool {
+	return true
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// the executable is fixed, only arguments can reference parameters
+	if strings.Contains(cfg.Command[0], "{{") {
+		return nil, fmt.Errorf("the executable %q can't be templated: only arguments can reference parameters", cfg.Command[0])
+	}
+	path, err := exec.LookPath(cfg.Command[0])
+	if err != nil {
+		return nil, fmt.Errorf("unable to find executable %q: %w", cfg.Command[0], err)
+	}
+
+	args := make([]*template.Template, 0, len(cfg.Command)-1)
+	dashArgs := make([]bool, 0, len(cfg.Command)-1)
+	for i, arg := range cfg.Command[1:] {
+		tmpl, err := template.New(fmt.Sprintf("arg%d", i+1)).Option("missingkey=error").Parse(arg)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse argument %q: %w", arg, err)
+		}
+		args = append(args, tmpl)
+		dashArgs = append(dashArgs, strings.HasPrefix(arg, "-"))
+	}
+
+	// every parameter is rendered into a single argument, so it must be a scalar
+	for _, p := range cfg.Parameters {
+		switch p.GetType() {
+		case "string", "integer", "float", "boolean":
+		default:
+			return nil, fmt.Errorf("parameter %q has type %q: only string, integer, float and boolean parameters are supported", p.GetName(), p.GetType())
+		}
+	}
+
+	if cfg.WorkingDir != "" {
+		info, err := os.Stat(cfg.WorkingDir)
+		if err != nil {
+			return nil, fmt.Errorf("invalid workingDir: %w", err)
+		}
+		if !info.IsDir() {
+			return nil, fmt.Errorf("invalid workingDir: %q is not a directory", cfg.WorkingDir)
+		}
+	}
+
+	timeout, err := time.ParseDuration(cfg.Timeout)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse Timeout string as time.Duration: %s", err)
+	}
+	maxOutputBytes := cfg.MaxOutputBytes
+	if maxOutputBytes <= 0 {
+		maxOutputBytes = defaultMaxOutputBytes
+	}
+
+	paramManifest := cfg.Parameters.Manifest()
+	if paramManifest == nil {
+		paramManifest = make([]tools.ParameterManifest, 0)
+	}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: cfg.Parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:             cfg.Name,
+		Kind:             kind,
+		Parameters:       cfg.Parameters,
+		AuthRequired:     cfg.AuthRequired,
+		Path:             path,
+		Args:             args,
+		WorkingDir:       cfg.WorkingDir,
+		AllowedEnv:       cfg.AllowedEnv,
+		Timeout:          timeout,
+		MaxOutputBytes:   maxOutputBytes,
+		AllowLeadingDash: cfg.AllowLeadingDash,
+		dashArgs:         dashArgs,
+		manifest:         tools.Manifest{Description: cfg.Description, Parameters: paramManifest, AuthRequired: cfg.AuthRequired},
+		mcpManifest:      mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+
+	Path             string
+	Args             []*template.Template
+	WorkingDir       string
+	AllowedEnv       []string
+	Timeout          time.Duration
+	MaxOutputBytes   int64
+	AllowLeadingDash bool
+	// dashArgs reports, for each argument, whether it starts with "-" in the
+	// command, rather than because of a parameter.
+	dashArgs    []bool
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	paramsMap := params.AsMap()
+	args := make([]string, 0, len(t.Args))
+	for i, tmpl := range t.Args {
+		var b bytes.Buffer
+		if err := tmpl.Execute(&b, paramsMap); err != nil {
+			return nil, fmt.Errorf("unable to render argument: %w", err)
+		}
+		// a parameter value such as "--output=/etc/x" would be an option of
+		// the command
+		arg := b.String()
+		if strings.HasPrefix(arg, "-") && !t.dashArgs[i] && !t.AllowLeadingDash {
+			return nil, fmt.Errorf("argument %d starts with a dash from a parameter value, which the command could parse as an option", i+1)
+		}
+		args = append(args, arg)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, t.Timeout)
+	defer cancel()
+
+	// the command is executed directly rather than through a shell, so
+	// arguments are never interpreted
+	cmd := exec.CommandContext(ctx, t.Path, args...)
+	cmd.Dir = t.WorkingDir
+	cmd.Env = allowedEnviron(t.AllowedEnv)
+	stdout := &limitedBuffer{limit: t.MaxOutputBytes}
+	stderr := &limitedBuffer{limit: t.MaxOutputBytes}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	// children of the command keeping its output open don't hold Run past
+	// the timeout
+	cmd.WaitDelay = waitDelay
+
+	err := cmd.Run()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return nil, fmt.Errorf("command timed out after %s", t.Timeout)
+	}
+	if ctx.Err() != nil {
+		return nil, fmt.Errorf("command was cancelled: %w", ctx.Err())
+	}
+	exitCode := 0
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+	case errors.As(err, &exitErr):
+		exitCode = exitErr.ExitCode()
+	default:
+		return nil, fmt.Errorf("unable to run command: %w", err)
+	}
+
+	if stdout.truncated || stderr.truncated {
+		tools.AddWarning(ctx, "command output exceeded %d bytes and was truncated", t.MaxOutputBytes)
+		tools.SetTruncated(ctx)
+	}
+	return map[string]any{
+		"exitCode":  exitCode,
+		"stdout":    stdout.String(),
+		"stderr":    stderr.String(),
+		"truncated": stdout.truncated || stderr.truncated,
+	}, nil
+}
+
+// allowedEnviron returns the variables of the Toolbox environment whose name
+// is in allowed. Other variables aren't passed to the command.
+func allowedEnviron(allowed []string) []string {
+	env := make([]string, 0, len(allowed))
+	for _, name := range allowed {
+		if v, ok := os.LookupEnv(name); ok {
+			env = append(env, name+"="+v)
+		}
+	}
+	return env
+}
+
+// limitedBuffer keeps the first limit bytes written to it and discards the
+// rest, so a chatty command can't exhaust memory.
+type limitedBuffer struct {
+	buf       bytes.Buffer
+	limit     int64
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	remaining := b.limit - int64(b.buf.Len())
+	if int64(len(p)) > remaining {
+		if remaining > 0 {
+			b.buf.Write(p[:remaining])
+		}
+		b.truncated = true
+		return len(p), nil
+	}
+	return b.buf.Write(p)
+}
+
+func (b *limitedBuffer) String() string {
+	return b.buf.String()
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
@@ -0,0 +1,266 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shellcommand_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/shellcommand"
+)
+
+func TestParseFromYamlShellCommand(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: shell-command
+					description: some description
+					command: ["git", "log", "-n", "{{.count}}", "--oneline"]
+					workingDir: /srv/repo
+					allowedEnv: ["HOME", "PATH"]
+					timeout: 10s
+					maxOutputBytes: 1024
+					parameters:
+						- name: count
+						  type: integer
+						  description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": shellcommand.Config{
+					Name:           "example_tool",
+					Kind:           "shell-command",
+					Description:    "some description",
+					Command:        []string{"git", "log", "-n", "{{.count}}", "--oneline"},
+					WorkingDir:     "/srv/repo",
+					AllowedEnv:     []string{"HOME", "PATH"},
+					Timeout:        "10s",
+					MaxOutputBytes: 1024,
+					AuthRequired:   []string{},
+					Parameters: []tools.Parameter{
+						tools.NewIntParameter("count", "some description"),
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInvoke(t *testing.T) {
+	t.Setenv("SHELLCOMMAND_ALLOWED", "visible")
+	t.Setenv("SHELLCOMMAND_SECRET", "hidden")
+
+	tcs := []struct {
+		desc    string
+		cfg     shellcommand.Config
+		data    map[string]any
+		want    any
+		wantErr string
+		// within, if set, is how long the invocation may take
+		within time.Duration
+	}{
+		{
+			desc: "arguments are not interpreted",
+			cfg: shellcommand.Config{
+				Command:    []string{"echo", "hello", "{{.name}}"},
+				Parameters: tools.Parameters{tools.NewStringParameter("name", "some description")},
+			},
+			data: map[string]any{"name": "$(whoami); ls *"},
+			want: map[string]any{"exitCode": 0, "stdout": "hello $(whoami); ls *\n", "stderr": "", "truncated": false},
+		},
+		{
+			desc: "parameters can't start options",
+			cfg: shellcommand.Config{
+				Command:    []string{"echo", "-n", "{{.name}}"},
+				Parameters: tools.Parameters{tools.NewStringParameter("name", "some description")},
+			},
+			data:    map[string]any{"name": "--output=/etc/x"},
+			wantErr: "argument 2 starts with a dash from a parameter value, which the command could parse as an option",
+		},
+		{
+			desc: "leading dash allowed",
+			cfg: shellcommand.Config{
+				Command:          []string{"echo", "{{.name}}", "x{{.name}}"},
+				AllowLeadingDash: true,
+				Parameters:       tools.Parameters{tools.NewStringParameter("name", "some description")},
+			},
+			data: map[string]any{"name": "-5"},
+			want: map[string]any{"exitCode": 0, "stdout": "-5 x-5\n", "stderr": "", "truncated": false},
+		},
+		{
+			desc: "environment allowlist",
+			cfg: shellcommand.Config{
+				Command:    []string{"sh", "-c", `echo "$SHELLCOMMAND_ALLOWED:$SHELLCOMMAND_SECRET"`},
+				AllowedEnv: []string{"SHELLCOMMAND_ALLOWED"},
+			},
+			want: map[string]any{"exitCode": 0, "stdout": "visible:\n", "stderr": "", "truncated": false},
+		},
+		{
+			desc: "non-zero exit code",
+			cfg: shellcommand.Config{
+				Command: []string{"sh", "-c", "echo oops >&2; exit 3"},
+			},
+			want: map[string]any{"exitCode": 3, "stdout": "", "stderr": "oops\n", "truncated": false},
+		},
+		{
+			desc: "output is truncated",
+			cfg: shellcommand.Config{
+				Command:        []string{"echo", "0123456789"},
+				MaxOutputBytes: 4,
+			},
+			want: map[string]any{"exitCode": 0, "stdout": "0123", "stderr": "", "truncated": true},
+		},
+		{
+			desc: "timeout",
+			cfg: shellcommand.Config{
+				Command: []string{"sleep", "5"},
+				Timeout: "100ms",
+			},
+			wantErr: "command timed out after 100ms",
+		},
+		{
+			desc: "timeout with a child keeping the output open",
+			cfg: shellcommand.Config{
+				Command: []string{"sh", "-c", "sleep 5 & sleep 5"},
+				Timeout: "100ms",
+			},
+			wantErr: "command timed out after 100ms",
+			within:  3 * time.Second,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := tc.cfg
+			cfg.Name = "example_tool"
+			cfg.Kind = "shell-command"
+			cfg.Description = "some description"
+			if cfg.Timeout == "" {
+				cfg.Timeout = "10s"
+			}
+			tool, err := cfg.Initialize(nil)
+			if err != nil {
+				t.Fatalf("unable to initialize tool: %s", err)
+			}
+			params, err := tool.ParseParams(tc.data, nil)
+			if err != nil {
+				t.Fatalf("unable to parse params: %s", err)
+			}
+			start := time.Now()
+			got, err := tool.Invoke(context.Background(), params)
+			if elapsed := time.Since(start); tc.within > 0 && elapsed > tc.within {
+				t.Fatalf("invocation took %s, want at most %s", elapsed, tc.within)
+			}
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect result: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInvokeCancelled(t *testing.T) {
+	cfg := shellcommand.Config{
+		Name:        "example_tool",
+		Kind:        "shell-command",
+		Description: "some description",
+		Command:     []string{"sleep", "5"},
+		Timeout:     "10s",
+	}
+	tool, err := cfg.Initialize(nil)
+	if err != nil {
+		t.Fatalf("unable to initialize tool: %s", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+	_, err = tool.Invoke(ctx, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected a cancellation error, got %v", err)
+	}
+}
+
+func TestFailInitialize(t *testing.T) {
+	tcs := []struct {
+		desc string
+		cfg  shellcommand.Config
+		err  string
+	}{
+		{
+			desc: "templated executable",
+			cfg:  shellcommand.Config{Command: []string{"{{.bin}}", "--version"}},
+			err:  `the executable "{{.bin}}" can't be templated: only arguments can reference parameters`,
+		},
+		{
+			desc: "array parameter",
+			cfg: shellcommand.Config{
+				Command:    []string{"echo", "{{.names}}"},
+				Parameters: tools.Parameters{tools.NewArrayParameter("names", "some description", tools.NewStringParameter("name", "some description"))},
+			},
+			err: `parameter "names" has type "array": only string, integer, float and boolean parameters are supported`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := tc.cfg
+			cfg.Name = "example_tool"
+			cfg.Kind = "shell-command"
+			cfg.Description = "some description"
+			cfg.Timeout = "10s"
+			_, err := cfg.Initialize(nil)
+			if err == nil || err.Error() != tc.err {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.err)
+			}
+		})
+	}
+}
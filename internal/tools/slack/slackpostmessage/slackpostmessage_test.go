@@ -0,0 +1,173 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slackpostmessage_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	slackds "github.com/googleapis/genai-toolbox/internal/sources/slack"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools/slack/slackpostmessage"
+)
+
+func TestParseFromYamlSlackPostMessage(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: slack-post-message
+					source: my-slack
+					description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": slackpostmessage.Config{
+					Name:         "example_tool",
+					Kind:         "slack-post-message",
+					Source:       "my-slack",
+					Description:  "some description",
+					AuthRequired: []string{},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInvoke(t *testing.T) {
+	var gotAuth string
+	var gotBody map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		gotBody = nil
+		if err := json.NewDecoder(r.Body).Decode(&gotBody); err != nil {
+			t.Errorf("unable to decode request: %s", err)
+		}
+		switch r.URL.Path {
+		case "/webhook":
+			_, _ = w.Write([]byte("ok"))
+		case "/api/chat.postMessage":
+			if gotBody["channel"] == "C-missing" {
+				_, _ = w.Write([]byte(`{"ok": false, "error": "channel_not_found"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"ok": true, "channel": "C0123456", "ts": "1700000000.000100"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	orig := slackpostmessage.PostMessageURL
+	slackpostmessage.PostMessageURL = srv.URL + "/api/chat.postMessage"
+	defer func() { slackpostmessage.PostMessageURL = orig }()
+
+	tcs := []struct {
+		desc     string
+		src      *slackds.Source
+		want     any
+		wantAuth string
+		wantBody map[string]any
+		wantErr  string
+	}{
+		{
+			desc:     "webhook",
+			src:      &slackds.Source{WebhookURL: srv.URL + "/webhook"},
+			want:     map[string]any{"posted": true},
+			wantBody: map[string]any{"text": "hello"},
+		},
+		{
+			desc:     "token",
+			src:      &slackds.Source{Token: "xoxb-token", Channel: "C0123456"},
+			want:     map[string]any{"posted": true, "channel": "C0123456", "ts": "1700000000.000100"},
+			wantAuth: "Bearer xoxb-token",
+			wantBody: map[string]any{"channel": "C0123456", "text": "hello"},
+		},
+		{
+			desc:    "api error",
+			src:     &slackds.Source{Token: "xoxb-token", Channel: "C-missing"},
+			wantErr: "failed to post message: channel_not_found",
+		},
+		{
+			desc:    "bad status",
+			src:     &slackds.Source{WebhookURL: srv.URL + "/missing"},
+			wantErr: "unexpected status code: 404, response body: 404 page not found\n",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			tc.src.Name, tc.src.Kind, tc.src.Client = "my-slack", slackds.SourceKind, srv.Client()
+			cfg := slackpostmessage.Config{Name: "example_tool", Kind: "slack-post-message", Source: "my-slack", Description: "some description"}
+			tool, err := cfg.Initialize(map[string]sources.Source{"my-slack": tc.src})
+			if err != nil {
+				t.Fatalf("unable to initialize tool: %s", err)
+			}
+			params, err := tool.ParseParams(map[string]any{"text": "hello"}, nil)
+			if err != nil {
+				t.Fatalf("unable to parse params: %s", err)
+			}
+			got, err := tool.Invoke(context.Background(), params)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("incorrect result: diff %v", diff)
+			}
+			if gotAuth != tc.wantAuth {
+				t.Errorf("incorrect authorization header: got %q, want %q", gotAuth, tc.wantAuth)
+			}
+			if diff := cmp.Diff(tc.wantBody, gotBody); diff != "" {
+				t.Errorf("incorrect request: diff %v", diff)
+			}
+		})
+	}
+}
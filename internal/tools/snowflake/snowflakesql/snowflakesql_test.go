@@ -0,0 +1,145 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snowflakesql_test
+
+import (
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources/snowflake"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/snowflake/snowflakesql"
+)
+
+func TestParseFromYamlSnowflake(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: snowflake-sql
+					source: my-snowflake
+					description: some description
+					statement: |
+						SELECT * FROM sales.orders WHERE region = :region
+					authRequired:
+						- my-google-auth-service
+					parameters:
+						- name: region
+						  type: string
+						  description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": snowflakesql.Config{
+					Name:         "example_tool",
+					Kind:         "snowflake-sql",
+					Source:       "my-snowflake",
+					Description:  "some description",
+					Statement:    "SELECT * FROM sales.orders WHERE region = :region\n",
+					AuthRequired: []string{"my-google-auth-service"},
+					Parameters: []tools.Parameter{
+						tools.NewStringParameter("region", "some description"),
+					},
+				},
+			},
+		},
+		{
+			desc: "with template parameters",
+			in: `
+			tools:
+				example_tool:
+					kind: snowflake-sql
+					source: my-snowflake
+					description: some description
+					statement: |
+						SELECT * FROM {{ident .tableName}}
+					templateParameters:
+						- name: tableName
+						  type: string
+						  description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": snowflakesql.Config{
+					Name:         "example_tool",
+					Kind:         "snowflake-sql",
+					Source:       "my-snowflake",
+					Description:  "some description",
+					Statement:    "SELECT * FROM {{ident .tableName}}\n",
+					AuthRequired: []string{},
+					TemplateParameters: []tools.Parameter{
+						tools.NewStringParameter("tableName", "some description"),
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestBind(t *testing.T) {
+	str := func(s string) *string { return &s }
+	tcs := []struct {
+		desc string
+		in   any
+		want snowflake.Binding
+	}{
+		{desc: "null", in: nil, want: snowflake.Binding{Type: "TEXT"}},
+		{desc: "string", in: "it's", want: snowflake.Binding{Type: "TEXT", Value: str("it's")}},
+		{desc: "int", in: 42, want: snowflake.Binding{Type: "FIXED", Value: str("42")}},
+		{desc: "float", in: 2.5, want: snowflake.Binding{Type: "REAL", Value: str("2.5")}},
+		{desc: "bool", in: true, want: snowflake.Binding{Type: "BOOLEAN", Value: str("true")}},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := snowflakesql.Bind(tc.in)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect binding: diff %v", diff)
+			}
+		})
+	}
+
+	if _, err := snowflakesql.Bind([]any{"us", "eu"}); err == nil {
+		t.Fatalf("expected arrays to be rejected")
+	}
+}
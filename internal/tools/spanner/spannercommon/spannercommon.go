@@ -0,0 +1,30 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spannercommon
+
+import (
+	"strings"
+
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+// Dialect returns how the statements of a database in the Spanner database
+// dialect are lexed and its identifiers quoted.
+func Dialect(databaseDialect string) tools.Dialect {
+	if strings.ToLower(databaseDialect) == "postgresql" {
+		return tools.DialectANSI
+	}
+	return tools.DialectGoogleSQL
+}
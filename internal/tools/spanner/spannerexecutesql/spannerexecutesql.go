@@ -23,6 +23,7 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	spannerdb "github.com/googleapis/genai-toolbox/internal/sources/spanner"
 	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/spanner/spannercommon"
 	"github.com/googleapis/genai-toolbox/internal/util"
 	"google.golang.org/api/iterator"
 )
@@ -93,15 +94,16 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 
 	// finish tool setup
 	t := Tool{
-		Name:         cfg.Name,
-		Kind:         kind,
-		Parameters:   parameters,
-		AuthRequired: cfg.AuthRequired,
-		ReadOnly:     cfg.ReadOnly,
-		Client:       s.SpannerClient(),
-		dialect:      s.DatabaseDialect(),
-		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
-		mcpManifest:  mcpManifest,
+		Name:            cfg.Name,
+		Kind:            kind,
+		Parameters:      parameters,
+		AuthRequired:    cfg.AuthRequired,
+		ReadOnly:        cfg.ReadOnly || sources.IsReadOnly(rawS),
+		Client:          s.SpannerClient(),
+		dialect:         s.DatabaseDialect(),
+		checkStatements: sources.IsReadOnly(rawS),
+		manifest:        tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:     mcpManifest,
 	}
 	return t, nil
 }
@@ -117,16 +119,20 @@ type Tool struct {
 	ReadOnly     bool             `yaml:"readOnly"`
 	Client       *spanner.Client
 	dialect      string
-	manifest     tools.Manifest
-	mcpManifest  tools.McpManifest
+	// checkStatements rejects statements writing to the database, when the
+	// source is read-only. The readOnly of the tool only runs them in a
+	// read-only transaction.
+	checkStatements bool
+	manifest        tools.Manifest
+	mcpManifest     tools.McpManifest
 }
 
 // processRows iterates over the spanner.RowIterator and converts each row to a map[string]any.
-func processRows(iter *spanner.RowIterator) ([]any, error) {
+func processRows(ctx context.Context, iter *spanner.RowIterator) ([]any, error) {
 	var out []any
 	defer iter.Stop()
 
-	for {
+	for !tools.RowLimitReached(ctx, len(out)) {
 		row, err := iter.Next()
 		if err == iterator.Done {
 			break
@@ -151,6 +157,11 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 	if !ok {
 		return nil, fmt.Errorf("unable to get cast %s", paramsMap["sql"])
 	}
+	if t.checkStatements {
+		if err := tools.CheckReadOnly(spannercommon.Dialect(t.dialect), sql); err != nil {
+			return nil, err
+		}
+	}
 
 	// Log the query executed for debugging.
 	logger, err := util.LoggerFromContext(ctx)
@@ -161,16 +172,16 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 
 	var results []any
 	var opErr error
-	stmt := spanner.Statement{SQL: sql}
+	stmt := spanner.Statement{SQL: tools.TagStatement(ctx, sql)}
 
 	if t.ReadOnly {
 		iter := t.Client.Single().Query(ctx, stmt)
-		results, opErr = processRows(iter)
+		results, opErr = processRows(ctx, iter)
 	} else {
 		_, opErr = t.Client.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
 			var err error
 			iter := txn.Query(ctx, stmt)
-			results, err = processRows(iter)
+			results, err = processRows(ctx, iter)
 			if err != nil {
 				return err
 			}
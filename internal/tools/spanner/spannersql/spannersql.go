@@ -24,6 +24,7 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	spannerdb "github.com/googleapis/genai-toolbox/internal/sources/spanner"
 	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/spanner/spannercommon"
 	"google.golang.org/api/iterator"
 )
 
@@ -144,11 +145,11 @@ func getMapParams(params tools.ParamValues, dialect string) (map[string]interfac
 }
 
 // processRows iterates over the spanner.RowIterator and converts each row to a map[string]any.
-func processRows(iter *spanner.RowIterator) ([]any, error) {
+func processRows(ctx context.Context, iter *spanner.RowIterator) ([]any, error) {
 	var out []any
 	defer iter.Stop()
 
-	for {
+	for !tools.RowLimitReached(ctx, len(out)) {
 		row, err := iter.Next()
 		if err == iterator.Done {
 			break
@@ -169,7 +170,7 @@ func processRows(iter *spanner.RowIterator) ([]any, error) {
 
 func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
 	paramsMap := params.AsMap()
-	newStatement, err := tools.ResolveTemplateParams(t.TemplateParameters, t.Statement, paramsMap)
+	newStatement, err := tools.ResolveTemplateParams(spannercommon.Dialect(t.dialect), t.TemplateParameters, t.Statement, paramsMap)
 	if err != nil {
 		return nil, fmt.Errorf("unable to extract template params %w", err)
 	}
@@ -210,17 +211,17 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 	var results []any
 	var opErr error
 	stmt := spanner.Statement{
-		SQL:    newStatement,
+		SQL:    tools.TagStatement(ctx, newStatement),
 		Params: mapParams,
 	}
 
 	if t.ReadOnly {
 		iter := t.Client.Single().Query(ctx, stmt)
-		results, opErr = processRows(iter)
+		results, opErr = processRows(ctx, iter)
 	} else {
 		_, opErr = t.Client.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
 			iter := txn.Query(ctx, stmt)
-			results, err = processRows(iter)
+			results, err = processRows(ctx, iter)
 			if err != nil {
 				return err
 			}
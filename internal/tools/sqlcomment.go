@@ -0,0 +1,82 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/googleapis/genai-toolbox/internal/telemetry"
+)
+
+type sqlCommentKey struct{}
+
+// withSQLComment marks the statements of the invocation of ctx to be tagged
+// by TagStatement.
+func withSQLComment(ctx context.Context) context.Context {
+	return context.WithValue(ctx, sqlCommentKey{}, true)
+}
+
+// TagStatement appends a comment to statement describing the invocation of
+// ctx, e.g. /* toolbox tool=my-tool caller=me@example.com request=1234 */, if
+// the tool is configured with sqlComment. This lets database administrators
+// attribute the load of queries, such as seen in pg_stat_statements, to
+// tools. The statement is returned unchanged otherwise.
+//
+// Trailing semicolons are removed and the comment is appended on its own line,
+// so that it doesn't end up in a trailing line comment.
+//
+// The returned statement is recorded in the invocation stats of ctx, so that
+// slow invocations can be logged along with it.
+func TagStatement(ctx context.Context, statement string) string {
+	tagged := tagStatement(ctx, statement)
+	telemetry.InvocationStatsFromContext(ctx).SetStatement(tagged)
+	return tagged
+}
+
+func tagStatement(ctx context.Context, statement string) string {
+	if tag, _ := ctx.Value(sqlCommentKey{}).(bool); !tag {
+		return statement
+	}
+	ic := InvocationContextFromContext(ctx)
+	var b strings.Builder
+	b.WriteString(strings.TrimRight(statement, " \t\r\n;"))
+	b.WriteString("\n/* toolbox")
+	for _, kv := range [][2]string{{"tool", ic.ToolName}, {"caller", ic.Caller}, {"request", ic.RequestID}} {
+		if kv[1] != "" {
+			fmt.Fprintf(&b, " %s=%s", kv[0], escapeCommentValue(kv[1]))
+		}
+	}
+	b.WriteString(" */")
+	return b.String()
+}
+
+// escapeCommentValue percent-encodes the bytes of v other than letters,
+// digits and -_.~@:+, so that values can't close the comment or contain
+// spaces.
+func escapeCommentValue(v string) string {
+	var b strings.Builder
+	for i := 0; i < len(v); i++ {
+		c := v[i]
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9', strings.IndexByte("-_.~@:+", c) >= 0:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+// statementTool returns the statement it would send to its source.
+type statementTool struct {
+	mockTool
+}
+
+func (statementTool) Invoke(ctx context.Context, _ tools.ParamValues) (any, error) {
+	return tools.TagStatement(ctx, "SELECT * FROM t -- all rows\n;\n"), nil
+}
+
+type statementToolConfig struct {
+	mockToolConfig
+}
+
+func (statementToolConfig) Initialize(map[string]sources.Source) (tools.Tool, error) {
+	return statementTool{}, nil
+}
+
+func TestTagStatement(t *testing.T) {
+	disabled := false
+	ctx := tools.WithInvocationContext(context.Background(), tools.InvocationContext{
+		ToolName:  "my-tool",
+		Caller:    "me@example.com",
+		RequestID: "a b*/",
+	})
+	tcs := []struct {
+		desc string
+		cfg  tools.ToolConfig
+		want string
+	}{
+		{
+			desc: "untagged by default",
+			cfg:  statementToolConfig{},
+			want: "SELECT * FROM t -- all rows\n;\n",
+		},
+		{
+			desc: "tagged",
+			cfg:  tools.WithDefaultSQLComment(statementToolConfig{}),
+			want: "SELECT * FROM t -- all rows\n/* toolbox tool=my-tool caller=me@example.com request=a%20b%2A%2F */",
+		},
+		{
+			desc: "tool overrides the default",
+			cfg:  tools.WithDefaultSQLComment(tools.WithOptions(statementToolConfig{}, tools.Options{SQLComment: &disabled})),
+			want: "SELECT * FROM t -- all rows\n;\n",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			tool, err := tc.cfg.Initialize(nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			got, err := tool.Invoke(ctx, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect statement: diff %v", diff)
+			}
+		})
+	}
+}
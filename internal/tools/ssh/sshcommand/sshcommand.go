@@ -0,0 +1,254 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sshcommand
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	sshsrc "github.com/googleapis/genai-toolbox/internal/sources/ssh"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+const kind string = "ssh-command"
+
+// defaultMaxOutputBytes is the largest output kept per stream when
+// maxOutputBytes is not configured.
+const defaultMaxOutputBytes int64 = 64 << 10
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name, Timeout: "60s"} // Default timeout
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	SSHClient() *sshsrc.Client
+}
+
+// validate compatible sources are still compatible
+var _ compatibleSource = &sshsrc.Source{}
+
+var compatibleSources = [...]string{sshsrc.SourceKind}
+
+type Config struct {
+	Name            string           `yaml:"name" validate:"required"`
+	Kind            string           `yaml:"kind" validate:"required"`
+	Source          string           `yaml:"source" validate:"required"`
+	Description     string           `yaml:"description" validate:"required"`
+	Command         string           `yaml:"command" validate:"required"`
+	AllowedCommands []string         `yaml:"allowedCommands" validate:"required"`
+	MaxOutputBytes  int64            `yaml:"maxOutputBytes"`
+	Timeout         string           `yaml:"timeout"`
+	AuthRequired    []string         `yaml:"authRequired"`
+	Parameters      tools.Parameters `yaml:"parameters"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(compatibleSource)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
+	}
+
+	tmpl, err := template.New(cfg.Name).Option("missingkey=error").Parse(cfg.Command)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse command: %w", err)
+	}
+	allowed, err := CompileAllowlist(cfg.AllowedCommands)
+	if err != nil {
+		return nil, err
+	}
+	timeout, err := time.ParseDuration(cfg.Timeout)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse Timeout string as time.Duration: %s", err)
+	}
+	maxOutputBytes := cfg.MaxOutputBytes
+	if maxOutputBytes <= 0 {
+		maxOutputBytes = defaultMaxOutputBytes
+	}
+
+	paramManifest := cfg.Parameters.Manifest()
+	if paramManifest == nil {
+		paramManifest = make([]tools.ParameterManifest, 0)
+	}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: cfg.Parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:            cfg.Name,
+		Kind:            kind,
+		Parameters:      cfg.Parameters,
+		AuthRequired:    cfg.AuthRequired,
+		Command:         tmpl,
+		AllowedCommands: allowed,
+		MaxOutputBytes:  maxOutputBytes,
+		Timeout:         timeout,
+		Client:          s.SSHClient(),
+		manifest:        tools.Manifest{Description: cfg.Description, Parameters: paramManifest, AuthRequired: cfg.AuthRequired},
+		mcpManifest:     mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+
+	Command         *template.Template
+	AllowedCommands []*regexp.Regexp
+	MaxOutputBytes  int64
+	Timeout         time.Duration
+	Client          *sshsrc.Client
+	manifest        tools.Manifest
+	mcpManifest     tools.McpManifest
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	command, err := BuildCommand(t.Command, t.AllowedCommands, params.AsMap())
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, t.Timeout)
+	defer cancel()
+	result, err := t.Client.Run(ctx, command, t.MaxOutputBytes)
+	if err != nil {
+		return nil, fmt.Errorf("unable to run command %q: %w", command, err)
+	}
+	if result.Truncated {
+		tools.AddWarning(ctx, "command output exceeded %d bytes and was truncated", t.MaxOutputBytes)
+		tools.SetTruncated(ctx)
+	}
+	return result, nil
+}
+
+// CompileAllowlist compiles the allowed command patterns. Each pattern must
+// match the whole command.
+func CompileAllowlist(patterns []string) ([]*regexp.Regexp, error) {
+	allowed := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		re, err := regexp.Compile("^(?:" + p + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("unable to compile allowed command %q: %w", p, err)
+		}
+		allowed = append(allowed, re)
+	}
+	return allowed, nil
+}
+
+// BuildCommand renders the command template with shell quoted parameter
+// values, and verifies the result matches one of the allowed commands.
+func BuildCommand(tmpl *template.Template, allowed []*regexp.Regexp, params map[string]any) (string, error) {
+	quoted := make(map[string]any, len(params))
+	for k, v := range params {
+		quoted[k] = quoteValue(v)
+	}
+
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, quoted); err != nil {
+		return "", fmt.Errorf("unable to render command: %w", err)
+	}
+	command := b.String()
+
+	for _, re := range allowed {
+		if re.MatchString(command) {
+			return command, nil
+		}
+	}
+	return "", fmt.Errorf("command %q is not in the allowed commands", command)
+}
+
+// quoteValue shell quotes a parameter value. Array values are quoted
+// element-wise so they can be ranged over.
+func quoteValue(v any) any {
+	switch val := v.(type) {
+	case nil:
+		return ShellQuote("")
+	case []any:
+		out := make([]any, 0, len(val))
+		for _, item := range val {
+			out = append(out, quoteValue(item))
+		}
+		return out
+	default:
+		return ShellQuote(fmt.Sprint(val))
+	}
+}
+
+var safeShellWord = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]+$`)
+
+// ShellQuote returns s as a single POSIX shell word. Values made only of safe
+// characters are returned as-is, others are wrapped in single quotes.
+func ShellQuote(s string) string {
+	if safeShellWord.MatchString(s) {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
@@ -0,0 +1,163 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sshcommand_test
+
+import (
+	"testing"
+	"text/template"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/ssh/sshcommand"
+)
+
+func TestParseFromYamlSSHCommand(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: ssh-command
+					source: my-ssh-instance
+					description: some description
+					command: journalctl -u {{.unit}} -n {{.lines}} --no-pager
+					allowedCommands:
+						- journalctl -u [a-z0-9@.-]+ -n [0-9]+ --no-pager
+					maxOutputBytes: 4096
+					timeout: 10s
+					parameters:
+						- name: unit
+						  type: string
+						  description: some description
+						- name: lines
+						  type: integer
+						  description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": sshcommand.Config{
+					Name:            "example_tool",
+					Kind:            "ssh-command",
+					Source:          "my-ssh-instance",
+					Description:     "some description",
+					Command:         "journalctl -u {{.unit}} -n {{.lines}} --no-pager",
+					AllowedCommands: []string{"journalctl -u [a-z0-9@.-]+ -n [0-9]+ --no-pager"},
+					MaxOutputBytes:  4096,
+					Timeout:         "10s",
+					AuthRequired:    []string{},
+					Parameters: []tools.Parameter{
+						tools.NewStringParameter("unit", "some description"),
+						tools.NewIntParameter("lines", "some description"),
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestBuildCommand(t *testing.T) {
+	tcs := []struct {
+		desc    string
+		command string
+		allowed []string
+		params  map[string]any
+		want    string
+		wantErr string
+	}{
+		{
+			desc:    "safe values",
+			command: "journalctl -u {{.unit}} -n {{.lines}} --no-pager",
+			allowed: []string{"journalctl -u [a-z0-9@.-]+ -n [0-9]+ --no-pager"},
+			params:  map[string]any{"unit": "nginx.service", "lines": 50},
+			want:    "journalctl -u nginx.service -n 50 --no-pager",
+		},
+		{
+			desc:    "quoted values",
+			command: "grep -c {{.pattern}} /var/log/app.log",
+			allowed: []string{"grep -c .+ /var/log/app.log"},
+			params:  map[string]any{"pattern": "it's $(reboot)"},
+			want:    `grep -c 'it'\''s $(reboot)' /var/log/app.log`,
+		},
+		{
+			desc:    "array values",
+			command: "df -h{{range .mounts}} {{.}}{{end}}",
+			allowed: []string{"df -h( /[a-z/]*)*"},
+			params:  map[string]any{"mounts": []any{"/", "/var"}},
+			want:    "df -h / /var",
+		},
+		{
+			desc:    "injection rejected by allowlist",
+			command: "systemctl status {{.unit}}",
+			allowed: []string{"systemctl status [a-z0-9@.-]+"},
+			params:  map[string]any{"unit": "nginx; rm -rf /"},
+			wantErr: `command "systemctl status 'nginx; rm -rf /'" is not in the allowed commands`,
+		},
+		{
+			desc:    "allowlist matches the whole command",
+			command: "uptime {{.flag}}",
+			allowed: []string{"uptime"},
+			params:  map[string]any{"flag": "-p"},
+			wantErr: `command "uptime -p" is not in the allowed commands`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			tmpl := template.Must(template.New(tc.desc).Parse(tc.command))
+			allowed, err := sshcommand.CompileAllowlist(tc.allowed)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			got, err := sshcommand.BuildCommand(tmpl, allowed, tc.params)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tc.want {
+				t.Fatalf("incorrect command: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package teradatasql_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/teradata"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/teradata/teradatasql"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestParseFromYamlTeradata(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: teradata-sql
+					source: my-teradata
+					description: some description
+					statement: |
+						SELECT * FROM sales.orders WHERE region = ?;
+					queryBand:
+						Team: finance
+					parameters:
+						- name: region
+						  type: string
+						  description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": teradatasql.Config{
+					Name:         "example_tool",
+					Kind:         "teradata-sql",
+					Source:       "my-teradata",
+					Description:  "some description",
+					Statement:    "SELECT * FROM sales.orders WHERE region = ?;\n",
+					AuthRequired: []string{},
+					QueryBand:    map[string]string{"Team": "finance"},
+					Parameters: []tools.Parameter{
+						tools.NewStringParameter("region", "some description"),
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInvokeSetsQueryBand(t *testing.T) {
+	var bands []map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			QueryBands map[string]any `json:"queryBands"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("unable to decode request: %s", err)
+		}
+		bands = append(bands, body.QueryBands)
+		w.Write([]byte(`{"results": [{"resultSet": true, "data": []}]}`))
+	}))
+	defer srv.Close()
+
+	srcCfg := teradata.Config{Name: "my-teradata", Kind: teradata.SourceKind, URL: srv.URL, System: "prod", User: "my_user", Password: "my_pass"}
+	src, err := srcCfg.Initialize(context.Background(), noop.NewTracerProvider().Tracer("test"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	cfg := teradatasql.Config{
+		Name:        "list_orders",
+		Kind:        "teradata-sql",
+		Source:      "my-teradata",
+		Description: "some description",
+		Statement:   "SELECT * FROM sales.orders",
+		QueryBand:   map[string]string{"Team": "finance"},
+	}
+	tool, err := cfg.Initialize(map[string]sources.Source{"my-teradata": src})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for range 2 {
+		if _, err := tool.Invoke(context.Background(), nil); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	if len(bands) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(bands))
+	}
+	for _, band := range bands {
+		if band["Team"] != "finance" || band[teradatasql.ToolNameQueryBand] != "list_orders" || band[teradatasql.InvocationIDQueryBand] == "" {
+			t.Errorf("unexpected query band: %v", band)
+		}
+	}
+	if bands[0][teradatasql.InvocationIDQueryBand] == bands[1][teradatasql.InvocationIDQueryBand] {
+		t.Errorf("expected a different invocation id for every invocation, got %v", bands)
+	}
+}
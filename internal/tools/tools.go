@@ -63,6 +63,22 @@ type ToolConfig interface {
 	Initialize(map[string]sources.Source) (Tool, error)
 }
 
+// ShellToolConfig is implemented by configs of tools that run commands on the
+// host Toolbox runs on. These tools are only initialized when shell tools are
+// explicitly enabled with the --enable-shell-tools flag.
+type ShellToolConfig interface {
+	ToolConfig
+	RunsShellCommands() bool
+}
+
+// StatementToolConfig is implemented by configs of tools running a fixed SQL
+// statement against a source.
+type StatementToolConfig interface {
+	ToolConfig
+	ToolSource() string
+	ToolStatement() string
+}
+
 type Tool interface {
 	Invoke(context.Context, ParamValues) (any, error)
 	ParseParams(map[string]any, map[string]map[string]any) (ParamValues, error)
@@ -76,6 +92,12 @@ type Manifest struct {
 	Description  string              `json:"description"`
 	Parameters   []ParameterManifest `json:"parameters"`
 	AuthRequired []string            `json:"authRequired"`
+	Examples     []map[string]any    `json:"examples,omitempty"`
+	Tags         []string            `json:"tags,omitempty"`
+	// Deprecated explains what to use instead of a deprecated tool.
+	Deprecated string `json:"deprecated,omitempty"`
+	// Descriptions are the localized descriptions of the tool by locale.
+	Descriptions map[string]string `json:"-"`
 }
 
 // Definition for a tool the MCP client can call.
@@ -86,6 +108,8 @@ type McpManifest struct {
 	Description string `json:"description,omitempty"`
 	// A JSON Schema object defining the expected parameters for the tool.
 	InputSchema McpToolsSchema `json:"inputSchema,omitempty"`
+	// Localized descriptions of the tool by locale.
+	Descriptions map[string]string `json:"-"`
 }
 
 // Helper function that returns if a tool invocation request is authorized
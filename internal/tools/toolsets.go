@@ -15,7 +15,10 @@
 package tools
 
 import (
+	"encoding/base64"
 	"fmt"
+	"slices"
+	"strings"
 )
 
 type ToolsetConfig struct {
@@ -33,6 +36,8 @@ type Toolset struct {
 type ToolsetManifest struct {
 	ServerVersion string              `json:"serverVersion"`
 	ToolsManifest map[string]Manifest `json:"tools"`
+	// NextCursor is the cursor of the next page of tools, if any.
+	NextCursor string `json:"nextCursor,omitempty"`
 }
 
 func (t ToolsetConfig) Initialize(serverVersion string, toolsMap map[string]Tool) (Toolset, error) {
@@ -60,3 +65,114 @@ func (t ToolsetConfig) Initialize(serverVersion string, toolsMap map[string]Tool
 
 	return toolset, nil
 }
+
+// ParseTags parses a comma separated list of tags, such as the value of the
+// tags query parameter.
+func ParseTags(s string) []string {
+	var tags []string
+	for _, tag := range strings.Split(s, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// FilterByTags returns the toolset with only the tools having all of tags.
+// The toolset is returned as is if tags is empty.
+func (t Toolset) FilterByTags(tags []string) Toolset {
+	if len(tags) == 0 {
+		return t
+	}
+	return t.Filter(func(_ string, m Manifest) bool {
+		for _, tag := range tags {
+			if !slices.Contains(m.Tags, tag) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// Filter returns the toolset with only the tools for which keep returns true.
+// The toolset is returned as is if keep returns true for every tool, so that
+// the manifests aren't copied for every request listing them.
+func (t Toolset) Filter(keep func(name string, m Manifest) bool) Toolset {
+	var toolsManifest map[string]Manifest
+	for name, m := range t.Manifest.ToolsManifest {
+		if keep(name, m) {
+			continue
+		}
+		// copy the kept tools on the first tool that isn't kept
+		toolsManifest = make(map[string]Manifest, len(t.Manifest.ToolsManifest))
+		for name, m := range t.Manifest.ToolsManifest {
+			if keep(name, m) {
+				toolsManifest[name] = m
+			}
+		}
+		break
+	}
+	if toolsManifest == nil {
+		return t
+	}
+	mcpManifest := make([]McpManifest, 0, len(toolsManifest))
+	for _, m := range t.McpManifest {
+		if _, ok := toolsManifest[m.Name]; ok {
+			mcpManifest = append(mcpManifest, m)
+		}
+	}
+	t.Manifest.ToolsManifest = toolsManifest
+	t.McpManifest = mcpManifest
+	return t
+}
+
+// Page returns a page of at most pageSize tools of the toolset, sorted by
+// name, along with the cursor of the next page. Tools are listed from the
+// start of the toolset if cursor is empty, or else from after the last tool of
+// the page cursor was returned with. The next cursor is empty on the last
+// page. A pageSize of zero or less returns all the remaining tools.
+//
+// Cursors are based on tool names, so they remain valid when tools are added
+// or removed between pages.
+func (t Toolset) Page(cursor string, pageSize int) (Toolset, string, error) {
+	if cursor == "" && pageSize <= 0 {
+		return t, "", nil
+	}
+	after := ""
+	if cursor != "" {
+		b, err := base64.RawURLEncoding.DecodeString(cursor)
+		if err != nil || len(b) == 0 {
+			return t, "", fmt.Errorf("invalid cursor %q", cursor)
+		}
+		after = string(b)
+	}
+
+	names := make([]string, 0, len(t.Manifest.ToolsManifest))
+	for name := range t.Manifest.ToolsManifest {
+		if name > after {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	next := ""
+	if pageSize > 0 && len(names) > pageSize {
+		names = names[:pageSize]
+		next = base64.RawURLEncoding.EncodeToString([]byte(names[len(names)-1]))
+	}
+
+	toolsManifest := make(map[string]Manifest, len(names))
+	for _, name := range names {
+		toolsManifest[name] = t.Manifest.ToolsManifest[name]
+	}
+	mcpManifest := make([]McpManifest, 0, len(names))
+	for _, m := range t.McpManifest {
+		if _, ok := toolsManifest[m.Name]; ok {
+			mcpManifest = append(mcpManifest, m)
+		}
+	}
+	slices.SortFunc(mcpManifest, func(a, b McpManifest) int { return strings.Compare(a.Name, b.Name) })
+	t.Manifest.ToolsManifest = toolsManifest
+	t.Manifest.NextCursor = next
+	t.McpManifest = mcpManifest
+	return t, next, nil
+}
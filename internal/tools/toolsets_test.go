@@ -0,0 +1,180 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+func TestParseTags(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		want []string
+	}{
+		{
+			desc: "empty",
+			in:   "",
+		},
+		{
+			desc: "multiple tags",
+			in:   "reporting, readonly,,",
+			want: []string{"reporting", "readonly"},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := tools.ParseTags(tc.in)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect tags: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestToolsetFilterByTags(t *testing.T) {
+	toolset := tools.Toolset{
+		Manifest: tools.ToolsetManifest{
+			ServerVersion: "0.0.0",
+			ToolsManifest: map[string]tools.Manifest{
+				"report":   {Description: "report", Tags: []string{"reporting", "readonly"}},
+				"update":   {Description: "update", Tags: []string{"reporting"}},
+				"untagged": {Description: "untagged"},
+			},
+		},
+		McpManifest: []tools.McpManifest{{Name: "report"}, {Name: "update"}, {Name: "untagged"}},
+	}
+
+	tcs := []struct {
+		desc string
+		tags []string
+		want []string
+	}{
+		{
+			desc: "no tags",
+			want: []string{"report", "update", "untagged"},
+		},
+		{
+			desc: "single tag",
+			tags: []string{"reporting"},
+			want: []string{"report", "update"},
+		},
+		{
+			desc: "all tags must match",
+			tags: []string{"reporting", "readonly"},
+			want: []string{"report"},
+		},
+		{
+			desc: "no match",
+			tags: []string{"admin"},
+			want: []string{},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := toolset.FilterByTags(tc.tags)
+			names := make([]string, 0, len(got.McpManifest))
+			for _, m := range got.McpManifest {
+				names = append(names, m.Name)
+				if _, ok := got.Manifest.ToolsManifest[m.Name]; !ok {
+					t.Fatalf("tool %q missing from the toolset manifest", m.Name)
+				}
+			}
+			if diff := cmp.Diff(tc.want, names); diff != "" {
+				t.Fatalf("incorrect tools: diff %v", diff)
+			}
+			if len(got.Manifest.ToolsManifest) != len(tc.want) {
+				t.Fatalf("unexpected number of tools in manifest: want %d, got %d", len(tc.want), len(got.Manifest.ToolsManifest))
+			}
+		})
+	}
+}
+
+func TestToolsetFilterKeepingAll(t *testing.T) {
+	toolset := tools.Toolset{
+		Manifest: tools.ToolsetManifest{
+			ToolsManifest: map[string]tools.Manifest{"a": {}, "b": {}},
+		},
+		McpManifest: []tools.McpManifest{{Name: "a"}, {Name: "b"}},
+	}
+	got := toolset.Filter(func(string, tools.Manifest) bool { return true })
+	// the manifests are returned as is, rather than copied
+	got.Manifest.ToolsManifest["c"] = tools.Manifest{}
+	if _, ok := toolset.Manifest.ToolsManifest["c"]; !ok {
+		t.Fatalf("expected the manifests of the toolset to be returned as is")
+	}
+
+	got = toolset.Filter(func(name string, _ tools.Manifest) bool { return name != "b" })
+	if diff := cmp.Diff([]tools.McpManifest{{Name: "a"}}, got.McpManifest); diff != "" {
+		t.Fatalf("incorrect MCP manifests: diff %v", diff)
+	}
+	if _, ok := toolset.Manifest.ToolsManifest["b"]; !ok {
+		t.Fatalf("filtering modified the manifests of the toolset")
+	}
+}
+
+func TestToolsetPage(t *testing.T) {
+	toolset := tools.Toolset{
+		Manifest: tools.ToolsetManifest{
+			ToolsManifest: map[string]tools.Manifest{"c": {}, "a": {}, "b": {}},
+		},
+		McpManifest: []tools.McpManifest{{Name: "c"}, {Name: "a"}, {Name: "b"}},
+	}
+
+	// walk through all the pages
+	var got [][]string
+	cursor := ""
+	for {
+		page, next, err := toolset.Page(cursor, 2)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if page.Manifest.NextCursor != next {
+			t.Fatalf("unexpected manifest cursor: want %q, got %q", next, page.Manifest.NextCursor)
+		}
+		names := make([]string, 0, len(page.McpManifest))
+		for _, m := range page.McpManifest {
+			names = append(names, m.Name)
+		}
+		if len(page.Manifest.ToolsManifest) != len(names) {
+			t.Fatalf("manifests have a different number of tools: %d and %d", len(page.Manifest.ToolsManifest), len(names))
+		}
+		got = append(got, names)
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+	want := [][]string{{"a", "b"}, {"c"}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect pages: diff %v", diff)
+	}
+
+	// no page size lists all the tools as is
+	page, next, err := toolset.Page("", 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if next != "" || len(page.McpManifest) != 3 {
+		t.Fatalf("expected all tools in a single page, got %d tools and cursor %q", len(page.McpManifest), next)
+	}
+
+	if _, _, err := toolset.Page("not a cursor!", 2); err == nil {
+		t.Fatalf("expected an error for an invalid cursor")
+	}
+}
@@ -0,0 +1,477 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crosssourcejoin
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+const kind string = "cross-source-join"
+
+// defaultMaxRows is the largest number of rows read from each query, and
+// returned by the join, when maxRows is not configured.
+const defaultMaxRows = 10000
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+	// maxRows bounds the inputs of the join rather than truncating its
+	// result, so it is kept in the config instead of read as an option.
+	tools.ReserveOptionKey(kind, "maxRows")
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name, JoinType: JoinTypeInner} // Default join type
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+// Query is the configuration of the tool that returns one side of the join.
+// It accepts the same fields as a standalone tool of the same kind, except
+// that the description is optional.
+type Query struct {
+	tools.ToolConfig
+}
+
+func (q *Query) UnmarshalYAML(ctx context.Context, unmarshal func(interface{}) error) error {
+	var v map[string]any
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	kindVal, ok := v["kind"]
+	if !ok {
+		return fmt.Errorf("missing 'kind' field for query")
+	}
+	kindStr, ok := kindVal.(string)
+	if !ok {
+		return fmt.Errorf("invalid 'kind' field for query (must be a string)")
+	}
+	if v["description"] == nil {
+		v["description"] = "A query of a cross-source-join tool."
+	}
+	if v["authRequired"] == nil {
+		v["authRequired"] = []string{}
+	}
+	name, _ := v["name"].(string)
+	if name == "" {
+		name = "query"
+	}
+
+	dec, err := util.NewStrictDecoder(v)
+	if err != nil {
+		return fmt.Errorf("error creating YAML decoder for query: %w", err)
+	}
+	cfg, err := tools.DecodeConfig(ctx, kindStr, name, dec)
+	if err != nil {
+		return err
+	}
+	// shell tools are only allowed with --enable-shell-tools, which can't be
+	// checked for tools nested in another one
+	if shellCfg, ok := cfg.(tools.ShellToolConfig); ok && shellCfg.RunsShellCommands() {
+		return fmt.Errorf("tool kind %q can't be used as a query", kindStr)
+	}
+	q.ToolConfig = cfg
+	return nil
+}
+
+// JoinType is the kind of join performed between the rows of both queries.
+type JoinType string
+
+const (
+	JoinTypeInner JoinType = "inner"
+	JoinTypeLeft  JoinType = "left"
+)
+
+func (j *JoinType) UnmarshalYAML(ctx context.Context, unmarshal func(interface{}) error) error {
+	var joinType string
+	if err := unmarshal(&joinType); err != nil {
+		return err
+	}
+	switch strings.ToLower(joinType) {
+	case string(JoinTypeInner), string(JoinTypeLeft):
+		*j = JoinType(strings.ToLower(joinType))
+		return nil
+	default:
+		return fmt.Errorf(`joinType invalid: must be one of "inner", or "left"`)
+	}
+}
+
+// JoinKey is a pair of columns whose values must be equal for rows to join.
+type JoinKey struct {
+	Left  string `yaml:"left" validate:"required"`
+	Right string `yaml:"right" validate:"required"`
+}
+
+type Config struct {
+	Name         string    `yaml:"name" validate:"required"`
+	Kind         string    `yaml:"kind" validate:"required"`
+	Description  string    `yaml:"description" validate:"required"`
+	AuthRequired []string  `yaml:"authRequired"`
+	Left         *Query    `yaml:"left" validate:"required"`
+	Right        *Query    `yaml:"right" validate:"required"`
+	On           []JoinKey `yaml:"on" validate:"required,min=1,dive"`
+	JoinType     JoinType  `yaml:"joinType" validate:"required"`
+	MaxRows      int       `yaml:"maxRows"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	left, err := cfg.Left.Initialize(srcs)
+	if err != nil {
+		return nil, fmt.Errorf("unable to initialize left query: %w", err)
+	}
+	right, err := cfg.Right.Initialize(srcs)
+	if err != nil {
+		return nil, fmt.Errorf("unable to initialize right query: %w", err)
+	}
+
+	// the parameters of the tool are the union of the parameters of both
+	// queries, and parameters used by both must have the same type
+	paramManifest := make([]tools.ParameterManifest, 0)
+	inputSchema := tools.McpToolsSchema{
+		Type:       "object",
+		Properties: make(map[string]tools.ParameterMcpManifest),
+		Required:   make([]string, 0),
+	}
+	seen := make(map[string]tools.ParameterManifest)
+	for _, q := range []tools.Tool{left, right} {
+		schema := q.McpManifest().InputSchema
+		for _, p := range q.Manifest().Parameters {
+			if prev, ok := seen[p.Name]; ok {
+				if prev.Type != p.Type {
+					return nil, fmt.Errorf("parameter %q has type %q in the left query and %q in the right query", p.Name, prev.Type, p.Type)
+				}
+				continue
+			}
+			seen[p.Name] = p
+			paramManifest = append(paramManifest, p)
+			if prop, ok := schema.Properties[p.Name]; ok {
+				inputSchema.Properties[p.Name] = prop
+			}
+			if p.Required {
+				inputSchema.Required = append(inputSchema.Required, p.Name)
+			}
+		}
+	}
+
+	maxRows := cfg.MaxRows
+	if maxRows <= 0 {
+		maxRows = defaultMaxRows
+	}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: inputSchema,
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		AuthRequired: cfg.AuthRequired,
+		On:           cfg.On,
+		JoinType:     cfg.JoinType,
+		MaxRows:      maxRows,
+		Left:         left,
+		Right:        right,
+		leftParams:   paramNames(left),
+		rightParams:  paramNames(right),
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: paramManifest, AuthRequired: cfg.AuthRequired},
+		mcpManifest:  mcpManifest,
+	}
+	return t, nil
+}
+
+func paramNames(t tools.Tool) []string {
+	params := t.Manifest().Parameters
+	names := make([]string, 0, len(params))
+	for _, p := range params {
+		names = append(names, p.Name)
+	}
+	return names
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string    `yaml:"name"`
+	Kind         string    `yaml:"kind"`
+	AuthRequired []string  `yaml:"authRequired"`
+	On           []JoinKey `yaml:"on"`
+	JoinType     JoinType  `yaml:"joinType"`
+	MaxRows      int       `yaml:"maxRows"`
+
+	Left        tools.Tool
+	Right       tools.Tool
+	leftParams  []string
+	rightParams []string
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	// both queries run concurrently since they use different sources
+	var wg sync.WaitGroup
+	var leftRows, rightRows []map[string]any
+	var leftErr, rightErr error
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		leftRows, leftErr = t.runQuery(ctx, t.Left, selectParams(params, t.leftParams))
+	}()
+	go func() {
+		defer wg.Done()
+		rightRows, rightErr = t.runQuery(ctx, t.Right, selectParams(params, t.rightParams))
+	}()
+	wg.Wait()
+	if leftErr != nil {
+		return nil, fmt.Errorf("left query failed: %w", leftErr)
+	}
+	if rightErr != nil {
+		return nil, fmt.Errorf("right query failed: %w", rightErr)
+	}
+
+	return HashJoin(leftRows, rightRows, t.On, t.JoinType, t.MaxRows)
+}
+
+// selectParams returns the values of the given parameters, in order.
+func selectParams(params tools.ParamValues, names []string) tools.ParamValues {
+	values := params.AsMap()
+	selected := make(tools.ParamValues, 0, len(names))
+	for _, name := range names {
+		selected = append(selected, tools.ParamValue{Name: name, Value: values[name]})
+	}
+	return selected
+}
+
+func (t Tool) runQuery(ctx context.Context, q tools.Tool, params tools.ParamValues) ([]map[string]any, error) {
+	res, err := q.Invoke(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := toRows(res)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) > t.MaxRows {
+		return nil, fmt.Errorf("query returned %d rows, which exceeds the limit of %d rows", len(rows), t.MaxRows)
+	}
+	return rows, nil
+}
+
+// toRows converts the result of a tool to a list of rows.
+func toRows(res any) ([]map[string]any, error) {
+	switch v := res.(type) {
+	case nil:
+		return nil, nil
+	case []map[string]any:
+		return v, nil
+	case []any:
+		rows := make([]map[string]any, 0, len(v))
+		for i, r := range v {
+			row, ok := r.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("row #%d of the result is a %T, not an object", i, r)
+			}
+			rows = append(rows, row)
+		}
+		return rows, nil
+	}
+	return nil, fmt.Errorf("result is a %T, not a list of rows", res)
+}
+
+// HashJoin joins two lists of rows on the given keys. A hash table is built
+// from the right rows, then probed with each left row, so the order of the
+// left rows is preserved. Columns of the right rows are added to the left
+// rows, except for the join keys; right columns with the same name as a left
+// column are suffixed with "_right". Rows with a NULL key never match.
+func HashJoin(left, right []map[string]any, on []JoinKey, joinType JoinType, maxRows int) ([]any, error) {
+	rightKeys := make(map[string]bool, len(on))
+	for _, k := range on {
+		rightKeys[k.Right] = true
+	}
+
+	index := make(map[string][]map[string]any, len(right))
+	for _, row := range right {
+		key, ok := joinKey(row, on, func(k JoinKey) string { return k.Right })
+		if !ok {
+			continue
+		}
+		index[key] = append(index[key], row)
+	}
+
+	out := make([]any, 0)
+	add := func(row map[string]any) error {
+		if len(out) == maxRows {
+			return fmt.Errorf("join produced more than %d rows", maxRows)
+		}
+		out = append(out, row)
+		return nil
+	}
+	for _, l := range left {
+		var matches []map[string]any
+		if key, ok := joinKey(l, on, func(k JoinKey) string { return k.Left }); ok {
+			matches = index[key]
+		}
+		if len(matches) == 0 {
+			if joinType == JoinTypeLeft {
+				if err := add(l); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+		for _, r := range matches {
+			joined := make(map[string]any, len(l)+len(r))
+			for col, v := range l {
+				joined[col] = v
+			}
+			for col, v := range r {
+				if rightKeys[col] {
+					continue
+				}
+				if _, ok := l[col]; ok {
+					col += "_right"
+				}
+				joined[col] = v
+			}
+			if err := add(joined); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return out, nil
+}
+
+// joinKey encodes the values of the key columns of a row, so that values
+// returned by different databases for the same number or string are equal.
+// It returns false if any of the values is NULL.
+func joinKey(row map[string]any, on []JoinKey, column func(JoinKey) string) (string, bool) {
+	var sb strings.Builder
+	for _, k := range on {
+		v := row[column(k)]
+		if v == nil {
+			return "", false
+		}
+		sb.WriteString(encodeKeyValue(v))
+		sb.WriteByte(0)
+	}
+	return sb.String(), true
+}
+
+func encodeKeyValue(v any) string {
+	switch v := v.(type) {
+	case string:
+		return "s:" + v
+	case []byte:
+		return "s:" + string(v)
+	case int:
+		return "n:" + strconv.FormatInt(int64(v), 10)
+	case int8:
+		return "n:" + strconv.FormatInt(int64(v), 10)
+	case int16:
+		return "n:" + strconv.FormatInt(int64(v), 10)
+	case int32:
+		return "n:" + strconv.FormatInt(int64(v), 10)
+	case int64:
+		return "n:" + strconv.FormatInt(v, 10)
+	case uint:
+		return "n:" + strconv.FormatUint(uint64(v), 10)
+	case uint8:
+		return "n:" + strconv.FormatUint(uint64(v), 10)
+	case uint16:
+		return "n:" + strconv.FormatUint(uint64(v), 10)
+	case uint32:
+		return "n:" + strconv.FormatUint(uint64(v), 10)
+	case uint64:
+		return "n:" + strconv.FormatUint(v, 10)
+	case float32:
+		return encodeFloat(float64(v))
+	case float64:
+		return encodeFloat(v)
+	case bool:
+		return "b:" + strconv.FormatBool(v)
+	case time.Time:
+		return "t:" + v.UTC().Format(time.RFC3339Nano)
+	}
+	return fmt.Sprintf("v:%v", v)
+}
+
+// encodeFloat encodes whole floats like integers, since some databases
+// return integer columns as floats.
+func encodeFloat(f float64) string {
+	if f == math.Trunc(f) && math.Abs(f) < 1<<53 {
+		return "n:" + strconv.FormatInt(int64(f), 10)
+	}
+	return "n:" + strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	leftValues, err := t.Left.ParseParams(data, claims)
+	if err != nil {
+		return nil, err
+	}
+	rightValues, err := t.Right.ParseParams(data, claims)
+	if err != nil {
+		return nil, err
+	}
+	values := leftValues
+	seen := make(map[string]bool, len(leftValues))
+	for _, v := range leftValues {
+		seen[v.Name] = true
+	}
+	for _, v := range rightValues {
+		if !seen[v.Name] {
+			values = append(values, v)
+		}
+	}
+	return values, nil
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices) &&
+		t.Left.Authorized(verifiedAuthServices) &&
+		t.Right.Authorized(verifiedAuthServices)
+}
@@ -0,0 +1,362 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crosssourcejoin_test
+
+import (
+	"strings"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/sqlite"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/sqlitesql"
+	"github.com/googleapis/genai-toolbox/internal/tools/utility/crosssourcejoin"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestParseFromYamlCrossSourceJoin(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: cross-source-join
+					description: some description
+					left:
+						kind: sqlite-sql
+						source: my-users
+						statement: SELECT id, name FROM users WHERE region = ?
+						parameters:
+							- name: region
+							  type: string
+							  description: some description
+					right:
+						kind: sqlite-sql
+						source: my-orders
+						description: orders per user
+						statement: SELECT user_id, count(*) AS orders FROM orders GROUP BY user_id
+					on:
+						- left: id
+						  right: user_id
+					joinType: left
+					maxRows: 100
+			`,
+			want: server.ToolConfigs{
+				"example_tool": crosssourcejoin.Config{
+					Name:         "example_tool",
+					Kind:         "cross-source-join",
+					Description:  "some description",
+					AuthRequired: []string{},
+					Left: &crosssourcejoin.Query{ToolConfig: sqlitesql.Config{
+						Name:         "query",
+						Kind:         "sqlite-sql",
+						Source:       "my-users",
+						Description:  "A query of a cross-source-join tool.",
+						Statement:    "SELECT id, name FROM users WHERE region = ?",
+						AuthRequired: []string{},
+						Parameters: []tools.Parameter{
+							tools.NewStringParameter("region", "some description"),
+						},
+					}},
+					Right: &crosssourcejoin.Query{ToolConfig: sqlitesql.Config{
+						Name:         "query",
+						Kind:         "sqlite-sql",
+						Source:       "my-orders",
+						Description:  "orders per user",
+						Statement:    "SELECT user_id, count(*) AS orders FROM orders GROUP BY user_id",
+						AuthRequired: []string{},
+					}},
+					On:       []crosssourcejoin.JoinKey{{Left: "id", Right: "user_id"}},
+					JoinType: crosssourcejoin.JoinTypeLeft,
+					MaxRows:  100,
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestFailParseFromYaml(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		err  string
+	}{
+		{
+			desc: "invalid join type",
+			in: `
+			tools:
+				example_tool:
+					kind: cross-source-join
+					description: some description
+					left:
+						kind: sqlite-sql
+						source: a
+						statement: SELECT 1 AS id
+					right:
+						kind: sqlite-sql
+						source: b
+						statement: SELECT 1 AS id
+					on:
+						- left: id
+						  right: id
+					joinType: outer
+			`,
+			err: `joinType invalid: must be one of "inner", or "left"`,
+		},
+		{
+			desc: "missing join keys",
+			in: `
+			tools:
+				example_tool:
+					kind: cross-source-join
+					description: some description
+					left:
+						kind: sqlite-sql
+						source: a
+						statement: SELECT 1 AS id
+					right:
+						kind: sqlite-sql
+						source: b
+						statement: SELECT 1 AS id
+			`,
+			err: "Key: 'Config.On' Error:Field validation for 'On' failed on the 'required' tag",
+		},
+		{
+			desc: "unknown query kind",
+			in: `
+			tools:
+				example_tool:
+					kind: cross-source-join
+					description: some description
+					left:
+						kind: foo
+					right:
+						kind: sqlite-sql
+						source: b
+						statement: SELECT 1 AS id
+					on:
+						- left: id
+						  right: id
+			`,
+			err: `unknown tool kind: "foo"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err == nil {
+				t.Fatalf("expect parsing to fail")
+			}
+			if !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("unexpected error: got %q, want it to contain %q", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestHashJoin(t *testing.T) {
+	left := []map[string]any{
+		{"id": int64(1), "name": "a"},
+		{"id": int64(2), "name": "b"},
+		{"id": nil, "name": "c"},
+		{"id": int64(3), "name": "d"},
+	}
+	right := []map[string]any{
+		{"user_id": float64(1), "name": "x", "total": 10},
+		{"user_id": int32(1), "name": "y", "total": 20},
+		{"user_id": "2", "name": "z", "total": 30},
+		{"user_id": int64(3), "name": "w", "total": 40},
+	}
+	on := []crosssourcejoin.JoinKey{{Left: "id", Right: "user_id"}}
+
+	tcs := []struct {
+		desc     string
+		joinType crosssourcejoin.JoinType
+		maxRows  int
+		want     []any
+		err      string
+	}{
+		{
+			desc:     "inner join",
+			joinType: crosssourcejoin.JoinTypeInner,
+			maxRows:  10,
+			want: []any{
+				map[string]any{"id": int64(1), "name": "a", "name_right": "x", "total": 10},
+				map[string]any{"id": int64(1), "name": "a", "name_right": "y", "total": 20},
+				map[string]any{"id": int64(3), "name": "d", "name_right": "w", "total": 40},
+			},
+		},
+		{
+			desc:     "left join",
+			joinType: crosssourcejoin.JoinTypeLeft,
+			maxRows:  10,
+			want: []any{
+				map[string]any{"id": int64(1), "name": "a", "name_right": "x", "total": 10},
+				map[string]any{"id": int64(1), "name": "a", "name_right": "y", "total": 20},
+				map[string]any{"id": int64(2), "name": "b"},
+				map[string]any{"id": nil, "name": "c"},
+				map[string]any{"id": int64(3), "name": "d", "name_right": "w", "total": 40},
+			},
+		},
+		{
+			desc:     "too many rows",
+			joinType: crosssourcejoin.JoinTypeInner,
+			maxRows:  2,
+			err:      "join produced more than 2 rows",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := crosssourcejoin.HashJoin(left, right, on, tc.joinType, tc.maxRows)
+			if tc.err != "" {
+				if err == nil || err.Error() != tc.err {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect result: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInvoke(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	newSource := func(t *testing.T, name string, statements ...string) sources.Source {
+		src, err := sqlite.Config{Name: name, Kind: sqlite.SourceKind, Database: ":memory:"}.Initialize(ctx, noop.NewTracerProvider().Tracer("test"))
+		if err != nil {
+			t.Fatalf("unable to initialize source: %s", err)
+		}
+		for _, s := range statements {
+			if _, err := src.(*sqlite.Source).SQLiteDB().ExecContext(ctx, s); err != nil {
+				t.Fatalf("unable to execute %q: %s", s, err)
+			}
+		}
+		return src
+	}
+	srcs := map[string]sources.Source{
+		"my-users": newSource(t, "my-users",
+			"CREATE TABLE users (id INTEGER, name TEXT, region TEXT)",
+			"INSERT INTO users VALUES (1, 'alice', 'eu'), (2, 'bob', 'eu'), (3, 'carol', 'us')",
+		),
+		"my-orders": newSource(t, "my-orders",
+			"CREATE TABLE orders (user_id INTEGER, region TEXT)",
+			"INSERT INTO orders VALUES (1, 'eu'), (1, 'eu'), (2, 'eu'), (3, 'us')",
+		),
+	}
+	regionParam := tools.NewStringParameter("region", "some description")
+	cfg := crosssourcejoin.Config{
+		Name:        "example_tool",
+		Kind:        "cross-source-join",
+		Description: "some description",
+		Left: &crosssourcejoin.Query{ToolConfig: sqlitesql.Config{
+			Name:        "query",
+			Kind:        "sqlite-sql",
+			Source:      "my-users",
+			Description: "users",
+			Statement:   "SELECT id, name FROM users WHERE region = ? ORDER BY id",
+			Parameters:  tools.Parameters{regionParam},
+		}},
+		Right: &crosssourcejoin.Query{ToolConfig: sqlitesql.Config{
+			Name:        "query",
+			Kind:        "sqlite-sql",
+			Source:      "my-orders",
+			Description: "orders",
+			Statement:   "SELECT user_id, count(*) AS orders FROM orders WHERE region = ? GROUP BY user_id",
+			Parameters:  tools.Parameters{regionParam},
+		}},
+		On:       []crosssourcejoin.JoinKey{{Left: "id", Right: "user_id"}},
+		JoinType: crosssourcejoin.JoinTypeInner,
+	}
+	tool, err := cfg.Initialize(srcs)
+	if err != nil {
+		t.Fatalf("unable to initialize tool: %s", err)
+	}
+
+	// a parameter used by both queries is only listed once
+	if got := len(tool.Manifest().Parameters); got != 1 {
+		t.Fatalf("unexpected number of parameters: got %d, want 1", got)
+	}
+
+	params, err := tool.ParseParams(map[string]any{"region": "eu"}, nil)
+	if err != nil {
+		t.Fatalf("unable to parse params: %s", err)
+	}
+	got, err := tool.Invoke(ctx, params)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := []any{
+		map[string]any{"id": int64(1), "name": "alice", "orders": int64(2)},
+		map[string]any{"id": int64(2), "name": "bob", "orders": int64(1)},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect result: diff %v", diff)
+	}
+
+	// queries returning more rows than maxRows are rejected
+	cfg.MaxRows = 1
+	tool, err = cfg.Initialize(srcs)
+	if err != nil {
+		t.Fatalf("unable to initialize tool: %s", err)
+	}
+	_, err = tool.Invoke(ctx, params)
+	if err == nil || !strings.Contains(err.Error(), "exceeds the limit of 1 rows") {
+		t.Fatalf("unexpected error: got %v", err)
+	}
+}
@@ -16,11 +16,13 @@ package valkey
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	valkeysrc "github.com/googleapis/genai-toolbox/internal/sources/valkey"
 	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/rediscommon"
 	"github.com/valkey-io/valkey-go"
 )
 
@@ -55,6 +57,7 @@ type Config struct {
 	Source       string           `yaml:"source" validate:"required"`
 	Description  string           `yaml:"description" validate:"required"`
 	Commands     [][]string       `yaml:"commands" validate:"required"`
+	KeyPrefixes  []string         `yaml:"keyPrefixes"`
 	AuthRequired []string         `yaml:"authRequired"`
 	Parameters   tools.Parameters `yaml:"parameters"`
 }
@@ -79,6 +82,18 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
 	}
 
+	if len(cfg.KeyPrefixes) > 0 {
+		if err := rediscommon.ValidateKeyPrefixes(cfg.KeyPrefixes); err != nil {
+			return nil, err
+		}
+		for _, cmd := range cfg.Commands {
+			// commands named by a parameter are checked when invoked
+			if len(cmd) > 0 && !strings.HasPrefix(cmd[0], "$") && !rediscommon.SupportsCommand(cmd[0]) {
+				return nil, fmt.Errorf("command %q is not allowed with keyPrefixes: its keys can't be checked", cmd[0])
+			}
+		}
+	}
+
 	mcpManifest := tools.McpManifest{
 		Name:        cfg.Name,
 		Description: cfg.Description,
@@ -91,6 +106,7 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		Kind:         kind,
 		Parameters:   cfg.Parameters,
 		Commands:     cfg.Commands,
+		KeyPrefixes:  cfg.KeyPrefixes,
 		AuthRequired: cfg.AuthRequired,
 		Client:       s.ValkeyClient(),
 		manifest:     tools.Manifest{Description: cfg.Description, Parameters: cfg.Parameters.Manifest(), AuthRequired: cfg.AuthRequired},
@@ -110,6 +126,7 @@ type Tool struct {
 
 	Client      valkey.Client
 	Commands    [][]string
+	KeyPrefixes []string
 	manifest    tools.Manifest
 	mcpManifest tools.McpManifest
 }
@@ -121,6 +138,15 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 		return nil, fmt.Errorf("error replacing commands' parameters: %s", err)
 	}
 
+	// Check the keys of every command before executing any
+	if len(t.KeyPrefixes) > 0 {
+		for i, cmd := range commands {
+			if err := rediscommon.CheckKeys(cmd, t.KeyPrefixes); err != nil {
+				return nil, fmt.Errorf("command at index %d was rejected: %w", i, err)
+			}
+		}
+	}
+
 	// Build commands
 	builtCmds := make(valkey.Commands, len(commands))
 
@@ -65,6 +65,31 @@ func TestParseFromYamlvalkey(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "with key prefixes",
+			in: `
+			tools:
+				valkey_tool:
+					kind: valkey
+					source: my-valkey-instance
+					description: some description
+					commands:
+						- [GET, agent:last]
+					keyPrefixes:
+						- "agent:"
+			`,
+			want: server.ToolConfigs{
+				"valkey_tool": valkey.Config{
+					Name:         "valkey_tool",
+					Kind:         "valkey",
+					Source:       "my-valkey-instance",
+					Description:  "some description",
+					AuthRequired: []string{},
+					Commands:     [][]string{{"GET", "agent:last"}},
+					KeyPrefixes:  []string{"agent:"},
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
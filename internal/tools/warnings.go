@@ -0,0 +1,128 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// warningCollector collects the warnings of a tool invocation, the cursor of
+// the next page of its result and whether its result was truncated.
+type warningCollector struct {
+	mu         sync.Mutex
+	warnings   []string
+	nextCursor string
+	truncated  bool
+}
+
+type warningsKey struct{}
+
+// WithWarnings returns a context collecting the warnings added during a tool
+// invocation, which are returned by Warnings.
+func WithWarnings(ctx context.Context) context.Context {
+	return context.WithValue(ctx, warningsKey{}, &warningCollector{})
+}
+
+// AddWarning records a non-fatal issue with a tool invocation, such as a
+// truncated result, to be returned to the client alongside the result. It
+// does nothing if the context doesn't collect warnings.
+func AddWarning(ctx context.Context, format string, args ...any) {
+	c, ok := ctx.Value(warningsKey{}).(*warningCollector)
+	if !ok {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.warnings = append(c.warnings, fmt.Sprintf(format, args...))
+}
+
+// Warnings returns the warnings added to the context.
+func Warnings(ctx context.Context) []string {
+	c, ok := ctx.Value(warningsKey{}).(*warningCollector)
+	if !ok {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.warnings...)
+}
+
+// SetNextCursor records the cursor of the next page of the result of a tool
+// invocation, to be returned to the client alongside the result. It does
+// nothing if the context doesn't collect warnings.
+func SetNextCursor(ctx context.Context, cursor string) {
+	c, ok := ctx.Value(warningsKey{}).(*warningCollector)
+	if !ok {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.nextCursor = cursor
+}
+
+// NextCursor returns the cursor of the next page set on the context, if any.
+func NextCursor(ctx context.Context) string {
+	c, ok := ctx.Value(warningsKey{}).(*warningCollector)
+	if !ok {
+		return ""
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.nextCursor
+}
+
+// SetTruncated records that the result of a tool invocation was truncated,
+// such as to its first rows or to a page of them. It does nothing if the
+// context doesn't collect warnings.
+func SetTruncated(ctx context.Context) {
+	c, ok := ctx.Value(warningsKey{}).(*warningCollector)
+	if !ok {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.truncated = true
+}
+
+// Truncated returns whether the result was truncated, according to the
+// context.
+func Truncated(ctx context.Context) bool {
+	c, ok := ctx.Value(warningsKey{}).(*warningCollector)
+	if !ok {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.truncated
+}
+
+// AddDefaultWarnings adds a warning for each parameter of the manifest that
+// wasn't provided in data and was set to its default value instead.
+func AddDefaultWarnings(ctx context.Context, m Manifest, data map[string]any, params ParamValues) {
+	values := params.AsMap()
+	for _, p := range m.Parameters {
+		if len(p.AuthServices) > 0 {
+			continue
+		}
+		if _, ok := data[p.Name]; ok {
+			continue
+		}
+		if v := values[p.Name]; v != nil {
+			AddWarning(ctx, "parameter %q was not provided, so its default value %v was used", p.Name, v)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+func TestWarnings(t *testing.T) {
+	// warnings are dropped when they aren't collected
+	tools.AddWarning(context.Background(), "dropped")
+	if got := tools.Warnings(context.Background()); got != nil {
+		t.Fatalf("expected no warnings, got %q", got)
+	}
+
+	ctx := tools.WithWarnings(context.Background())
+	tools.AddWarning(ctx, "result truncated to %d rows", 10)
+	want := []string{"result truncated to 10 rows"}
+	if diff := cmp.Diff(want, tools.Warnings(ctx)); diff != "" {
+		t.Fatalf("incorrect warnings: diff %v", diff)
+	}
+}
+
+func TestAddDefaultWarnings(t *testing.T) {
+	params := tools.Parameters{
+		tools.NewIntParameterWithDefault("limit", 10, "max rows"),
+		tools.NewStringParameterWithDefault("order", "asc", "sort order"),
+		tools.NewStringParameterWithRequired("filter", "a filter", false),
+	}
+	data := map[string]any{"order": "desc"}
+	values, err := tools.ParseParams(params, data, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	ctx := tools.WithWarnings(context.Background())
+	tools.AddDefaultWarnings(ctx, tools.Manifest{Parameters: params.Manifest()}, data, values)
+	want := []string{`parameter "limit" was not provided, so its default value 10 was used`}
+	if diff := cmp.Diff(want, tools.Warnings(ctx)); diff != "" {
+		t.Fatalf("incorrect warnings: diff %v", diff)
+	}
+}
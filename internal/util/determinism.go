@@ -0,0 +1,102 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"encoding/binary"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Clock returns the current time. The server gets the timestamps it returns
+// to clients from a Clock, so that tests can fix them.
+type Clock func() time.Time
+
+// IDGenerator returns a new unique ID. The server gets the IDs it returns to
+// clients, such as request and session IDs, from an IDGenerator, so that tests
+// can make them reproducible.
+type IDGenerator func() string
+
+// SystemClock is the Clock returning the time of the system.
+func SystemClock() time.Time {
+	return time.Now()
+}
+
+// RandomIDs is the IDGenerator returning random UUIDs.
+func RandomIDs() string {
+	return uuid.New().String()
+}
+
+// FixedClock returns a Clock always returning t.
+func FixedClock(t time.Time) Clock {
+	return func() time.Time { return t }
+}
+
+// SeededIDs returns an IDGenerator returning the same sequence of UUIDs for
+// the same seed. It is safe for concurrent use, although concurrent callers
+// get the IDs of the sequence in no particular order.
+func SeededIDs(seed uint64) IDGenerator {
+	var key [32]byte
+	binary.LittleEndian.PutUint64(key[:], seed)
+	source := rand.NewChaCha8(key)
+	var mu sync.Mutex
+	return func() string {
+		mu.Lock()
+		defer mu.Unlock()
+		// reading from a ChaCha8 never fails
+		id, _ := uuid.NewRandomFromReader(source)
+		return id.String()
+	}
+}
+
+// clockKey is the key used to store the Clock within context
+const clockKey contextKey = "clock"
+
+// WithClock adds the Clock of the server into the context as a value, so that
+// invocations, such as the checks of policy time windows, get the time from it.
+func WithClock(ctx context.Context, clock Clock) context.Context {
+	return context.WithValue(ctx, clockKey, clock)
+}
+
+// Now returns the current time from the Clock of the context, or the time of
+// the system if there is none.
+func Now(ctx context.Context) time.Time {
+	if clock, ok := ctx.Value(clockKey).(Clock); ok && clock != nil {
+		return clock()
+	}
+	return SystemClock()
+}
+
+// idGeneratorKey is the key used to store the IDGenerator within context
+const idGeneratorKey contextKey = "idGenerator"
+
+// WithIDGenerator adds the IDGenerator of the server into the context as a
+// value, so that tools generate IDs, such as job IDs, with it.
+func WithIDGenerator(ctx context.Context, ids IDGenerator) context.Context {
+	return context.WithValue(ctx, idGeneratorKey, ids)
+}
+
+// NewID returns a new ID from the IDGenerator of the context, or a random
+// UUID if there is none.
+func NewID(ctx context.Context) string {
+	if ids, ok := ctx.Value(idGeneratorKey).(IDGenerator); ok && ids != nil {
+		return ids()
+	}
+	return RandomIDs()
+}
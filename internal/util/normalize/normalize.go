@@ -0,0 +1,286 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package normalize converts the values returned by database drivers to
+// consistent JSON representations, so that tools return the same JSON for
+// the same data regardless of their source.
+package normalize
+
+import (
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// NumberFormat is how numbers JSON clients can't represent exactly, which are
+// exact decimals such as NUMERIC columns and integers outside of the range
+// JavaScript represents exactly, are returned.
+type NumberFormat string
+
+const (
+	// NumbersAsStrings returns such numbers as JSON strings, keeping every
+	// digit. It is the default.
+	NumbersAsStrings NumberFormat = "string"
+	// NumbersAsNumbers returns such numbers as JSON numbers. Clients parsing
+	// them as floating point numbers may lose precision.
+	NumbersAsNumbers NumberFormat = "number"
+)
+
+// String is used by both fmt.Print and by Cobra in help text
+func (f *NumberFormat) String() string {
+	if string(*f) != "" {
+		return string(*f)
+	}
+	return string(NumbersAsStrings)
+}
+
+// Set validates the number format flag
+func (f *NumberFormat) Set(v string) error {
+	switch NumberFormat(v) {
+	case NumbersAsStrings, NumbersAsNumbers:
+		*f = NumberFormat(v)
+		return nil
+	default:
+		return fmt.Errorf(`number format must be one of "string" or "number"`)
+	}
+}
+
+// Type is used in Cobra help text
+func (f *NumberFormat) Type() string {
+	return "numberFormat"
+}
+
+// NullColumns is whether NULL columns are included in the rows of results.
+type NullColumns string
+
+const (
+	// IncludeNullColumns returns NULL columns as explicit JSON nulls. It is
+	// the default.
+	IncludeNullColumns NullColumns = "include"
+	// OmitNullColumns omits NULL columns from rows.
+	OmitNullColumns NullColumns = "omit"
+)
+
+// maxSafeInteger is the largest integer JavaScript, and so many JSON
+// clients, represent exactly.
+const maxSafeInteger = 1<<53 - 1
+
+// Decimal is the text of an exact decimal number returned by a driver, such
+// as a DECIMAL column read by the MySQL driver.
+type Decimal string
+
+// maxRatDigits is the most fractional digits of a *big.Rat kept, which is
+// the scale of BigQuery's BIGNUMERIC.
+const maxRatDigits = 38
+
+// Value converts v to its normalized representation:
+//
+//   - Dates and times are RFC 3339 strings, and civil dates and times are
+//     ISO 8601 strings.
+//   - Decimals, and integers JavaScript can't represent exactly, are
+//     strings or numbers, depending on format.
+//   - Binary data is a base64 string, and UUIDs are hyphenated strings.
+//   - NaN and infinite floats, which JSON can't represent, are strings.
+//   - Nullable driver types are null or their value.
+//
+// Maps and slices are normalized recursively. Other values are returned as is.
+func Value(v any, format NumberFormat) any {
+	switch v := v.(type) {
+	case nil, bool, string, json.Number, int8, int16, int32, uint8, uint16, uint32:
+		return v
+	case int:
+		return integer(int64(v), format)
+	case int64:
+		return integer(v, format)
+	case uint:
+		return unsigned(uint64(v), format)
+	case uint64:
+		return unsigned(v, format)
+	case *big.Int:
+		if v == nil {
+			return nil
+		}
+		if v.IsInt64() {
+			return integer(v.Int64(), format)
+		}
+		return exact(v.String(), format)
+	case float32:
+		return float(float64(v))
+	case float64:
+		return float(v)
+	case []byte:
+		return base64.StdEncoding.EncodeToString(v)
+	case [16]byte:
+		return fmt.Sprintf("%x-%x-%x-%x-%x", v[0:4], v[4:6], v[6:8], v[8:10], v[10:16])
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case civil.Date:
+		return v.String()
+	case civil.Time:
+		return v.String()
+	case civil.DateTime:
+		return v.String()
+	case Decimal:
+		return exact(string(v), format)
+	case *big.Rat:
+		if v == nil {
+			return nil
+		}
+		return exact(ratString(v), format)
+	case pgtype.Numeric:
+		if !v.Valid {
+			return nil
+		}
+		dv, err := v.Value()
+		if err != nil {
+			return nil
+		}
+		s, _ := dv.(string)
+		return exact(s, format)
+	case *structpb.Value:
+		if v == nil {
+			return nil
+		}
+		return Value(v.AsInterface(), format)
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, item := range v {
+			out[k] = Value(item, format)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = Value(item, format)
+		}
+		return out
+	case []map[string]any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = Value(item, format)
+		}
+		return out
+	case driver.Valuer:
+		// nullable types such as sql.NullString and spanner.NullString
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Pointer && rv.IsNil() {
+			return nil
+		}
+		dv, err := v.Value()
+		if err != nil {
+			return v
+		}
+		return Value(dv, format)
+	default:
+		return v
+	}
+}
+
+// float returns f, or a string for floats JSON can't represent.
+func float(f float64) any {
+	switch {
+	case math.IsNaN(f):
+		return "NaN"
+	case math.IsInf(f, 1):
+		return "Infinity"
+	case math.IsInf(f, -1):
+		return "-Infinity"
+	}
+	return f
+}
+
+// integer returns i, or a string if it can't be represented exactly and
+// format is NumbersAsStrings.
+func integer(i int64, format NumberFormat) any {
+	if format != NumbersAsNumbers && (i > maxSafeInteger || i < -maxSafeInteger) {
+		return strconv.FormatInt(i, 10)
+	}
+	return i
+}
+
+// unsigned returns u, or a string if it can't be represented exactly and
+// format is NumbersAsStrings.
+func unsigned(u uint64, format NumberFormat) any {
+	if format != NumbersAsNumbers && u > maxSafeInteger {
+		return strconv.FormatUint(u, 10)
+	}
+	return u
+}
+
+// exact returns the decimal string s as a string or number, depending on
+// format.
+func exact(s string, format NumberFormat) any {
+	switch s {
+	case "NaN", "Infinity", "-Infinity":
+		return s
+	}
+	if format == NumbersAsNumbers {
+		return json.Number(s)
+	}
+	return s
+}
+
+// ratString returns the shortest decimal representation of r, rounded to
+// maxRatDigits fractional digits.
+func ratString(r *big.Rat) string {
+	if r.IsInt() {
+		return r.Num().String()
+	}
+	s := r.FloatString(maxRatDigits)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+// OmitNulls returns the normalized result v with the null columns of its
+// rows removed. Rows are v itself if it is a map, or the maps of v if it is a
+// slice. Nulls nested in column values, such as in JSON documents, are kept.
+func OmitNulls(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		return omitNullColumns(v)
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			if row, ok := item.(map[string]any); ok {
+				out[i] = omitNullColumns(row)
+				continue
+			}
+			out[i] = item
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// omitNullColumns returns a copy of row without its null columns.
+func omitNullColumns(row map[string]any) map[string]any {
+	out := make(map[string]any, len(row))
+	for k, v := range row {
+		if v != nil {
+			out[k] = v
+		}
+	}
+	return out
+}
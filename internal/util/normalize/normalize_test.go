@@ -0,0 +1,140 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package normalize_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"cloud.google.com/go/spanner"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/util/normalize"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestValue(t *testing.T) {
+	var numeric pgtype.Numeric
+	if err := numeric.Scan("12345678901234567890.123"); err != nil {
+		t.Fatalf("unable to scan numeric: %s", err)
+	}
+	tcs := []struct {
+		desc   string
+		in     any
+		format normalize.NumberFormat
+		want   any
+	}{
+		{desc: "string", in: "abc", want: "abc"},
+		{desc: "int", in: int64(1), want: int64(1)},
+		{desc: "unsafe int as string", in: int64(1 << 60), want: "1152921504606846976"},
+		{desc: "unsafe negative int as string", in: int64(-1 << 60), want: "-1152921504606846976"},
+		{desc: "unsafe int as number", in: int64(1 << 60), format: normalize.NumbersAsNumbers, want: int64(1 << 60)},
+		{desc: "unsafe uint as string", in: uint64(1 << 63), want: "9223372036854775808"},
+		{desc: "big int", in: new(big.Int).Lsh(big.NewInt(1), 70), format: normalize.NumbersAsNumbers, want: json.Number("1180591620717411303424")},
+		{desc: "pg numeric by default", in: numeric, want: "12345678901234567890.123"},
+		{desc: "nan", in: math.NaN(), want: "NaN"},
+		{desc: "infinity", in: math.Inf(-1), want: "-Infinity"},
+		{desc: "bytes", in: []byte("hi"), want: "aGk="},
+		{desc: "uuid", in: [16]byte{0x12, 0x34, 0x56, 0x78, 0x9a, 0xbc, 0xde, 0xf0, 0x12, 0x34, 0x56, 0x78, 0x9a, 0xbc, 0xde, 0xf0}, want: "12345678-9abc-def0-1234-56789abcdef0"},
+		{desc: "time", in: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC), want: "2025-01-02T03:04:05Z"},
+		{desc: "civil date", in: civil.Date{Year: 2025, Month: 1, Day: 2}, want: "2025-01-02"},
+		{desc: "pg numeric as float", in: numeric, format: normalize.NumbersAsNumbers, want: json.Number("12345678901234567890.123")},
+		{desc: "pg numeric as string", in: numeric, format: normalize.NumbersAsStrings, want: "12345678901234567890.123"},
+		{desc: "null pg numeric", in: pgtype.Numeric{}, want: nil},
+		{desc: "bigquery numeric", in: big.NewRat(1, 8), format: normalize.NumbersAsStrings, want: "0.125"},
+		{desc: "bigquery integer numeric", in: big.NewRat(10, 1), format: normalize.NumbersAsNumbers, want: json.Number("10")},
+		{desc: "driver decimal", in: normalize.Decimal("1.50"), format: normalize.NumbersAsStrings, want: "1.50"},
+		{desc: "spanner null string", in: spanner.NullString{}, want: nil},
+		{desc: "spanner string", in: spanner.NullString{StringVal: "a", Valid: true}, want: "a"},
+		{desc: "sql null int", in: sql.NullInt64{Int64: 3, Valid: true}, want: int64(3)},
+		{desc: "proto value", in: structpb.NewStringValue("1"), want: "1"},
+		{
+			desc:   "nested",
+			in:     []any{map[string]any{"d": civil.Date{Year: 2025, Month: 1, Day: 2}, "n": big.NewRat(3, 2)}},
+			format: normalize.NumbersAsStrings,
+			want:   []any{map[string]any{"d": "2025-01-02", "n": "1.5"}},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := normalize.Value(tc.in, tc.format)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect value: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestNumberFormatFlag(t *testing.T) {
+	var f normalize.NumberFormat
+	if got := f.String(); got != "string" {
+		t.Fatalf("incorrect default: got %q", got)
+	}
+	if err := f.Set("number"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if f != normalize.NumbersAsNumbers {
+		t.Fatalf("incorrect number format: got %q", f)
+	}
+	if err := f.Set("float"); err == nil {
+		t.Fatalf("expected an error for an invalid number format")
+	}
+}
+
+func TestOmitNulls(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   any
+		want any
+	}{
+		{
+			desc: "rows",
+			in:   []any{map[string]any{"id": int64(1), "name": nil}, map[string]any{"id": int64(2), "name": "b"}},
+			want: []any{map[string]any{"id": int64(1)}, map[string]any{"id": int64(2), "name": "b"}},
+		},
+		{
+			desc: "single row",
+			in:   map[string]any{"id": int64(1), "name": nil},
+			want: map[string]any{"id": int64(1)},
+		},
+		{
+			desc: "nested nulls are kept",
+			in:   []any{map[string]any{"doc": map[string]any{"a": nil}}},
+			want: []any{map[string]any{"doc": map[string]any{"a": nil}}},
+		},
+		{
+			desc: "non-row values",
+			in:   []any{"a", nil},
+			want: []any{"a", nil},
+		},
+		{
+			desc: "message",
+			in:   "The query returned 0 rows.",
+			want: "The query returned 0 rows.",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, normalize.OmitNulls(tc.in)); diff != "" {
+				t.Fatalf("incorrect result: diff %v", diff)
+			}
+		})
+	}
+}
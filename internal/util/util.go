@@ -162,3 +162,20 @@ func InstrumentationFromContext(ctx context.Context) (*telemetry.Instrumentation
 	}
 	return nil, fmt.Errorf("unable to retrieve instrumentation")
 }
+
+// sessionIDKey is the key used to store the MCP session ID within context
+const sessionIDKey contextKey = "sessionID"
+
+// WithSessionID adds the ID of the client session into the context as a value
+func WithSessionID(ctx context.Context, sessionID string) context.Context {
+	return context.WithValue(ctx, sessionIDKey, sessionID)
+}
+
+// SessionIDFromContext retrieves the ID of the client session, or an empty
+// string if the request isn't part of a session
+func SessionIDFromContext(ctx context.Context) string {
+	if sessionID, ok := ctx.Value(sessionIDKey).(string); ok {
+		return sessionID
+	}
+	return ""
+}
@@ -0,0 +1,354 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cassandra
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/googleapis/genai-toolbox/internal/sources/cassandra"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/tests"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+var (
+	CassandraSourceKind = "cassandra"
+	CassandraToolKind   = "cassandra-cql"
+	CassandraHost       = os.Getenv("CASSANDRA_HOST")
+	CassandraPort       = os.Getenv("CASSANDRA_PORT")
+	CassandraKeyspace   = os.Getenv("CASSANDRA_KEYSPACE")
+	CassandraUser       = os.Getenv("CASSANDRA_USER")
+	CassandraPass       = os.Getenv("CASSANDRA_PASS")
+)
+
+func getCassandraVars(t *testing.T) map[string]any {
+	switch "" {
+	case CassandraHost:
+		t.Fatal("'CASSANDRA_HOST' not set")
+	case CassandraKeyspace:
+		t.Fatal("'CASSANDRA_KEYSPACE' not set")
+	}
+
+	vars := map[string]any{
+		"kind":     CassandraSourceKind,
+		"hosts":    []string{CassandraHost},
+		"keyspace": CassandraKeyspace,
+	}
+	if CassandraPort != "" {
+		vars["port"] = CassandraPort
+	}
+	if CassandraUser != "" {
+		vars["user"] = CassandraUser
+		vars["password"] = CassandraPass
+	}
+	return vars
+}
+
+// initCassandraSource initializes a source to set up the test data with.
+func initCassandraSource(ctx context.Context) (*cassandra.Source, error) {
+	cfg := cassandra.Config{
+		Name:     "setup",
+		Kind:     CassandraSourceKind,
+		Hosts:    []string{CassandraHost},
+		Port:     CassandraPort,
+		Keyspace: CassandraKeyspace,
+		User:     CassandraUser,
+		Password: CassandraPass,
+	}
+	s, err := cfg.Initialize(ctx, noop.NewTracerProvider().Tracer(""))
+	if err != nil {
+		return nil, err
+	}
+	return s.(*cassandra.Source), nil
+}
+
+// setupCassandraTable creates a table partitioned by bucket and clustered by
+// id, so that every row lives in a single partition and comes back in id
+// order.
+func setupCassandraTable(t *testing.T, ctx context.Context, s *cassandra.Source, tableName string) func(*testing.T) {
+	session := s.CassandraSession()
+	createStatement := fmt.Sprintf("CREATE TABLE %s (bucket int, id int, name text, PRIMARY KEY (bucket, id))", tableName)
+	if err := session.Query(createStatement).WithContext(ctx).Exec(); err != nil {
+		t.Fatalf("unable to create test table %s: %s", tableName, err)
+	}
+
+	insertStatement := fmt.Sprintf("INSERT INTO %s (bucket, id, name) VALUES (0, ?, ?)", tableName)
+	rows := [][]any{{1, "Alice"}, {2, "Jane"}, {3, "Sid"}, {4, nil}}
+	for _, row := range rows {
+		if err := session.Query(insertStatement, row...).WithContext(ctx).Exec(); err != nil {
+			t.Fatalf("unable to insert test data: %s", err)
+		}
+	}
+
+	return func(t *testing.T) {
+		// tear down test
+		if err := session.Query(fmt.Sprintf("DROP TABLE IF EXISTS %s", tableName)).Exec(); err != nil {
+			t.Errorf("Teardown failed: %s", err)
+		}
+	}
+}
+
+func getCassandraToolsConfig(sourceConfig map[string]any, tableName string) map[string]any {
+	return map[string]any{
+		"sources": map[string]any{
+			"my-instance": sourceConfig,
+		},
+		"tools": map[string]any{
+			"my-simple-tool": map[string]any{
+				"kind":        CassandraToolKind,
+				"source":      "my-instance",
+				"description": "Simple tool to test end to end functionality.",
+				"statement":   "SELECT key FROM system.local",
+			},
+			"my-tool-by-id": map[string]any{
+				"kind":        CassandraToolKind,
+				"source":      "my-instance",
+				"description": "Tool to test invocation with params.",
+				"statement":   fmt.Sprintf("SELECT id, name FROM %s WHERE bucket = 0 AND id = :id", tableName),
+				"parameters": []any{
+					map[string]any{
+						"name":        "id",
+						"type":        "integer",
+						"description": "user ID",
+					},
+				},
+			},
+			"my-array-tool": map[string]any{
+				"kind":        CassandraToolKind,
+				"source":      "my-instance",
+				"description": "Tool to test invocation with array params.",
+				"statement":   fmt.Sprintf("SELECT id, name FROM %s WHERE bucket = 0 AND id IN ?", tableName),
+				"parameters": []any{
+					map[string]any{
+						"name":        "idArray",
+						"type":        "array",
+						"description": "ID array",
+						"items": map[string]any{
+							"name":        "id",
+							"type":        "integer",
+							"description": "ID",
+						},
+					},
+				},
+			},
+			"my-insert-tool": map[string]any{
+				"kind":        CassandraToolKind,
+				"source":      "my-instance",
+				"description": "Tool to test invocation of writes.",
+				"statement":   fmt.Sprintf("INSERT INTO %s (bucket, id, name) VALUES (0, ?, ?)", tableName),
+				"parameters": []any{
+					map[string]any{
+						"name":        "id",
+						"type":        "integer",
+						"description": "user ID",
+					},
+					map[string]any{
+						"name":        "name",
+						"type":        "string",
+						"description": "user name",
+					},
+				},
+			},
+			"my-template-tool": map[string]any{
+				"kind":        CassandraToolKind,
+				"source":      "my-instance",
+				"description": "Tool to test invocation with template params.",
+				"statement":   "SELECT id, name FROM {{.tableName}} WHERE bucket = 0 AND id = ?",
+				"templateParameters": []any{
+					map[string]any{
+						"name":        "tableName",
+						"type":        "string",
+						"description": "some description",
+					},
+				},
+				"parameters": []any{
+					map[string]any{
+						"name":        "id",
+						"type":        "integer",
+						"description": "user ID",
+					},
+				},
+			},
+			"my-fail-tool": map[string]any{
+				"kind":        CassandraToolKind,
+				"source":      "my-instance",
+				"description": "Tool to test statement with incorrect syntax.",
+				"statement":   "SELEC 1",
+			},
+		},
+	}
+}
+
+func TestCassandraToolEndpoints(t *testing.T) {
+	sourceConfig := getCassandraVars(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	var args []string
+
+	source, err := initCassandraSource(ctx)
+	if err != nil {
+		t.Fatalf("unable to create Cassandra session: %s", err)
+	}
+
+	// create table name with UUID
+	tableName := "param_table_" + strings.ReplaceAll(uuid.New().String(), "-", "")
+	teardownTable := setupCassandraTable(t, ctx, source, tableName)
+	defer teardownTable(t)
+
+	toolsFile := getCassandraToolsConfig(sourceConfig, tableName)
+
+	cmd, cleanup, err := tests.StartCmd(ctx, toolsFile, args...)
+	if err != nil {
+		t.Fatalf("command initialization returned an error: %s", err)
+	}
+	defer cleanup()
+
+	waitCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	out, err := testutils.WaitForString(waitCtx, regexp.MustCompile(`Server ready to serve`), cmd.Out)
+	if err != nil {
+		t.Logf("toolbox command logs: \n%s", out)
+		t.Fatalf("toolbox didn't start successfully: %s", err)
+	}
+
+	tests.RunToolGetTest(t)
+
+	invokeTcs := []struct {
+		name               string
+		api                string
+		requestBody        io.Reader
+		want               string
+		wantStatus         int
+		wantErrorSubstring string
+	}{
+		{
+			name:        "invoke my-simple-tool",
+			api:         "http://127.0.0.1:5000/api/tool/my-simple-tool/invoke",
+			requestBody: bytes.NewBuffer([]byte(`{}`)),
+			want:        `[{"key":"local"}]`,
+			wantStatus:  http.StatusOK,
+		},
+		{
+			name:        "invoke my-tool-by-id",
+			api:         "http://127.0.0.1:5000/api/tool/my-tool-by-id/invoke",
+			requestBody: bytes.NewBuffer([]byte(`{"id": 3}`)),
+			want:        `[{"id":3,"name":"Sid"}]`,
+			wantStatus:  http.StatusOK,
+		},
+		{
+			name:        "invoke my-tool-by-id with null column",
+			api:         "http://127.0.0.1:5000/api/tool/my-tool-by-id/invoke",
+			requestBody: bytes.NewBuffer([]byte(`{"id": 4}`)),
+			want:        `[{"id":4,"name":null}]`,
+			wantStatus:  http.StatusOK,
+		},
+		{
+			name:        "invoke my-tool-by-id with no rows",
+			api:         "http://127.0.0.1:5000/api/tool/my-tool-by-id/invoke",
+			requestBody: bytes.NewBuffer([]byte(`{"id": 99}`)),
+			want:        "null",
+			wantStatus:  http.StatusOK,
+		},
+		{
+			name:        "invoke my-array-tool",
+			api:         "http://127.0.0.1:5000/api/tool/my-array-tool/invoke",
+			requestBody: bytes.NewBuffer([]byte(`{"idArray": [1, 3]}`)),
+			want:        `[{"id":1,"name":"Alice"},{"id":3,"name":"Sid"}]`,
+			wantStatus:  http.StatusOK,
+		},
+		{
+			name:        "invoke my-insert-tool",
+			api:         "http://127.0.0.1:5000/api/tool/my-insert-tool/invoke",
+			requestBody: bytes.NewBuffer([]byte(`{"id": 5, "name": "Bob"}`)),
+			want:        "null",
+			wantStatus:  http.StatusOK,
+		},
+		{
+			name:        "invoke my-tool-by-id after insert",
+			api:         "http://127.0.0.1:5000/api/tool/my-tool-by-id/invoke",
+			requestBody: bytes.NewBuffer([]byte(`{"id": 5}`)),
+			want:        `[{"id":5,"name":"Bob"}]`,
+			wantStatus:  http.StatusOK,
+		},
+		{
+			name:        "invoke my-template-tool",
+			api:         "http://127.0.0.1:5000/api/tool/my-template-tool/invoke",
+			requestBody: bytes.NewBuffer([]byte(fmt.Sprintf(`{"tableName": %q, "id": 1}`, tableName))),
+			want:        `[{"id":1,"name":"Alice"}]`,
+			wantStatus:  http.StatusOK,
+		},
+		{
+			name:               "invoke my-tool-by-id without parameters",
+			api:                "http://127.0.0.1:5000/api/tool/my-tool-by-id/invoke",
+			requestBody:        bytes.NewBuffer([]byte(`{}`)),
+			wantStatus:         http.StatusBadRequest,
+			wantErrorSubstring: `parameter \"id\" is required`,
+		},
+		{
+			name:               "invoke my-fail-tool",
+			api:                "http://127.0.0.1:5000/api/tool/my-fail-tool/invoke",
+			requestBody:        bytes.NewBuffer([]byte(`{}`)),
+			wantStatus:         http.StatusBadRequest,
+			wantErrorSubstring: "unable to execute query",
+		},
+	}
+	for _, tc := range invokeTcs {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := http.Post(tc.api, "application/json", tc.requestBody)
+			if err != nil {
+				t.Fatalf("error when sending a request: %s", err)
+			}
+			defer resp.Body.Close()
+			bodyBytes, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("failed to read response body: %s", err)
+			}
+			if resp.StatusCode != tc.wantStatus {
+				t.Fatalf("response status code: got %d, want %d: %s", resp.StatusCode, tc.wantStatus, string(bodyBytes))
+			}
+
+			if tc.wantErrorSubstring != "" {
+				if !strings.Contains(string(bodyBytes), tc.wantErrorSubstring) {
+					t.Fatalf("expected error message to contain %q, but got %q", tc.wantErrorSubstring, string(bodyBytes))
+				}
+				return
+			}
+
+			var body map[string]any
+			if err := json.Unmarshal(bodyBytes, &body); err != nil {
+				t.Fatalf("error parsing response body: %s", err)
+			}
+			got, ok := body["result"].(string)
+			if !ok {
+				t.Fatalf("unable to find result in response body")
+			}
+			if got != tc.want {
+				t.Fatalf("unexpected value: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouse
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/googleapis/genai-toolbox/internal/sources/clickhouse"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/tests"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+var (
+	ClickHouseSourceKind = "clickhouse"
+	ClickHouseToolKind   = "clickhouse-sql"
+	ClickHouseDatabase   = os.Getenv("CLICKHOUSE_DATABASE")
+	ClickHouseHost       = os.Getenv("CLICKHOUSE_HOST")
+	ClickHousePort       = os.Getenv("CLICKHOUSE_PORT")
+	ClickHouseUser       = os.Getenv("CLICKHOUSE_USER")
+	ClickHousePass       = os.Getenv("CLICKHOUSE_PASSWORD")
+)
+
+func getClickHouseVars(t *testing.T) map[string]any {
+	switch "" {
+	case ClickHouseDatabase:
+		t.Fatal("'CLICKHOUSE_DATABASE' not set")
+	case ClickHouseHost:
+		t.Fatal("'CLICKHOUSE_HOST' not set")
+	case ClickHousePort:
+		t.Fatal("'CLICKHOUSE_PORT' not set")
+	case ClickHouseUser:
+		t.Fatal("'CLICKHOUSE_USER' not set")
+	case ClickHousePass:
+		t.Fatal("'CLICKHOUSE_PASSWORD' not set")
+	}
+
+	return map[string]any{
+		"kind":     ClickHouseSourceKind,
+		"host":     ClickHouseHost,
+		"port":     ClickHousePort,
+		"database": ClickHouseDatabase,
+		"user":     ClickHouseUser,
+		"password": ClickHousePass,
+	}
+}
+
+// initClickHouseSource initializes a source to set up the test data with.
+func initClickHouseSource(ctx context.Context) (*clickhouse.Source, error) {
+	cfg := clickhouse.Config{
+		Name:     "setup",
+		Kind:     ClickHouseSourceKind,
+		Host:     ClickHouseHost,
+		Port:     ClickHousePort,
+		Database: ClickHouseDatabase,
+		User:     ClickHouseUser,
+		Password: ClickHousePass,
+	}
+	s, err := cfg.Initialize(ctx, noop.NewTracerProvider().Tracer(""))
+	if err != nil {
+		return nil, err
+	}
+	return s.(*clickhouse.Source), nil
+}
+
+// getClickHouseParamToolInfo returns statements and params of my-tool for the
+// clickhouse-sql kind. Array parameters are bound as ClickHouse arrays.
+func getClickHouseParamToolInfo(tableName string) (string, string, string, string, string, string, []any) {
+	createStatement := fmt.Sprintf("CREATE TABLE %s (id Int32, name Nullable(String)) ENGINE = MergeTree ORDER BY id;", tableName)
+	insertStatement := fmt.Sprintf("INSERT INTO %s (id, name) VALUES (?, ?), (?, ?), (?, ?), (?, ?);", tableName)
+	toolStatement := fmt.Sprintf("SELECT * FROM %s WHERE id = ? OR name = ? ORDER BY id;", tableName)
+	idParamStatement := fmt.Sprintf("SELECT * FROM %s WHERE id = ?;", tableName)
+	nameParamStatement := fmt.Sprintf("SELECT * FROM %s WHERE name = ?;", tableName)
+	arrayToolStatement := fmt.Sprintf("SELECT * FROM %s WHERE has(?, id) AND has(?, name) ORDER BY id;", tableName)
+	params := []any{1, "Alice", 2, "Jane", 3, "Sid", 4, nil}
+	return createStatement, insertStatement, toolStatement, idParamStatement, nameParamStatement, arrayToolStatement, params
+}
+
+// getClickHouseAuthToolInfo returns statements and params of my-auth-tool for
+// the clickhouse-sql kind.
+func getClickHouseAuthToolInfo(tableName string) (string, string, string, []any) {
+	createStatement := fmt.Sprintf("CREATE TABLE %s (id Int32, name String, email String) ENGINE = MergeTree ORDER BY id;", tableName)
+	insertStatement := fmt.Sprintf("INSERT INTO %s (id, name, email) VALUES (?, ?, ?), (?, ?, ?)", tableName)
+	toolStatement := fmt.Sprintf("SELECT name FROM %s WHERE email = ?;", tableName)
+	params := []any{1, "Alice", tests.ServiceAccountEmail, 2, "Jane", "janedoe@gmail.com"}
+	return createStatement, insertStatement, toolStatement, params
+}
+
+// getClickHouseWants returns the expected wants for clickhouse
+func getClickHouseWants() (string, string) {
+	select1Want := "[{\"1\":1}]"
+	// Partial message; the full error message depends on the server version.
+	failInvocationWant := `{"jsonrpc":"2.0","id":"invoke-fail-tool","result":{"content":[{"type":"text","text":"unable to execute query: code: 62, message: Syntax error`
+	return select1Want, failInvocationWant
+}
+
+func setupClickHouseTable(t *testing.T, ctx context.Context, s *clickhouse.Source, createStatement, insertStatement, tableName string, params []any) func(*testing.T) {
+	conn := s.ClickHouseConn()
+	if err := conn.Exec(ctx, createStatement); err != nil {
+		t.Fatalf("unable to create test table %s: %s", tableName, err)
+	}
+	if insertStatement != "" {
+		if err := conn.Exec(ctx, insertStatement, params...); err != nil {
+			t.Fatalf("unable to insert test data: %s", err)
+		}
+	}
+
+	return func(t *testing.T) {
+		// tear down test
+		if err := conn.Exec(ctx, fmt.Sprintf("DROP TABLE IF EXISTS %s;", tableName)); err != nil {
+			t.Errorf("Teardown failed: %s", err)
+		}
+	}
+}
+
+func TestClickHouseToolEndpoints(t *testing.T) {
+	sourceConfig := getClickHouseVars(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	var args []string
+
+	source, err := initClickHouseSource(ctx)
+	if err != nil {
+		t.Fatalf("unable to create ClickHouse source: %s", err)
+	}
+
+	// create table name with UUID
+	tableNameParam := "param_table_" + strings.ReplaceAll(uuid.New().String(), "-", "")
+	tableNameAuth := "auth_table_" + strings.ReplaceAll(uuid.New().String(), "-", "")
+	tableNameTemplateParam := "template_param_table_" + strings.ReplaceAll(uuid.New().String(), "-", "")
+
+	// set up data for param tool
+	createParamTableStmt, insertParamTableStmt, paramToolStmt, idParamToolStmt, nameParamToolStmt, arrayToolStmt, paramTestParams := getClickHouseParamToolInfo(tableNameParam)
+	teardownTable1 := setupClickHouseTable(t, ctx, source, createParamTableStmt, insertParamTableStmt, tableNameParam, paramTestParams)
+	defer teardownTable1(t)
+
+	// set up data for auth tool
+	createAuthTableStmt, insertAuthTableStmt, authToolStmt, authTestParams := getClickHouseAuthToolInfo(tableNameAuth)
+	teardownTable2 := setupClickHouseTable(t, ctx, source, createAuthTableStmt, insertAuthTableStmt, tableNameAuth, authTestParams)
+	defer teardownTable2(t)
+
+	// the table of template parameter tests is created here, since ClickHouse
+	// tables require an engine
+	createTmplTableStmt := fmt.Sprintf("CREATE TABLE %s (id Int32, name String, age Int32) ENGINE = MergeTree ORDER BY id;", tableNameTemplateParam)
+	teardownTable3 := setupClickHouseTable(t, ctx, source, createTmplTableStmt, "", tableNameTemplateParam, nil)
+	defer teardownTable3(t)
+
+	// Write config into a file and pass it to command
+	toolsFile := tests.GetToolsConfig(sourceConfig, ClickHouseToolKind, paramToolStmt, idParamToolStmt, nameParamToolStmt, arrayToolStmt, authToolStmt)
+	tmplSelectCombined, tmplSelectFilterCombined := tests.GetMySQLTmplToolStatement()
+	toolsFile = tests.AddTemplateParamConfig(t, toolsFile, ClickHouseToolKind, tmplSelectCombined, tmplSelectFilterCombined, "")
+
+	cmd, cleanup, err := tests.StartCmd(ctx, toolsFile, args...)
+	if err != nil {
+		t.Fatalf("command initialization returned an error: %s", err)
+	}
+	defer cleanup()
+
+	waitCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	out, err := testutils.WaitForString(waitCtx, regexp.MustCompile(`Server ready to serve`), cmd.Out)
+	if err != nil {
+		t.Logf("toolbox command logs: \n%s", out)
+		t.Fatalf("toolbox didn't start successfully: %s", err)
+	}
+
+	tests.RunToolGetTest(t)
+
+	select1Want, failInvocationWant := getClickHouseWants()
+	invokeParamWant, invokeIdNullWant, nullWant, mcpInvokeParamWant := tests.GetNonSpannerInvokeParamWant()
+	tests.RunToolInvokeTest(t, select1Want, invokeParamWant, invokeIdNullWant, nullWant, true, true)
+	tests.RunMCPToolCallMethod(t, mcpInvokeParamWant, failInvocationWant)
+	tests.RunToolInvokeWithTemplateParameters(t, tableNameTemplateParam, tests.NewTemplateParameterTestConfig(tests.WithIgnoreDdl()))
+}
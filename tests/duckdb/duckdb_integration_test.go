@@ -0,0 +1,194 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package duckdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/googleapis/genai-toolbox/internal/sources/duckdb"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/tests"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+var (
+	DuckDBSourceKind = "duckdb"
+	DuckDBToolKind   = "duckdb-sql"
+	DuckDBBinary     = os.Getenv("DUCKDB_BINARY")
+)
+
+func getDuckDBVars(database string) map[string]any {
+	return map[string]any{
+		"kind":     DuckDBSourceKind,
+		"database": database,
+		"binary":   DuckDBBinary,
+	}
+}
+
+// initDuckDBSource initializes a source to set up the test data with.
+func initDuckDBSource(ctx context.Context, database string) (*duckdb.Source, error) {
+	cfg := duckdb.Config{
+		Name:     "setup",
+		Kind:     DuckDBSourceKind,
+		Database: database,
+		Binary:   DuckDBBinary,
+	}
+	s, err := cfg.Initialize(ctx, noop.NewTracerProvider().Tracer(""))
+	if err != nil {
+		return nil, err
+	}
+	return s.(*duckdb.Source), nil
+}
+
+func setupDuckDBTable(t *testing.T, ctx context.Context, s *duckdb.Source, createStatement, insertStatement, tableName string, params []any) {
+	if _, err := s.DuckDBQuery(ctx, createStatement, nil); err != nil {
+		t.Fatalf("unable to create test table %s: %s", tableName, err)
+	}
+	if _, err := s.DuckDBQuery(ctx, insertStatement, params); err != nil {
+		t.Fatalf("unable to insert test data: %s", err)
+	}
+}
+
+// getDuckDBParamToolInfo returns statements and params of my-tool for the
+// duckdb-sql kind. Array parameters are bound as lists.
+func getDuckDBParamToolInfo(tableName string) (string, string, string, string, string, string, []any) {
+	createStatement := fmt.Sprintf("CREATE TABLE %s (id INTEGER, name VARCHAR);", tableName)
+	insertStatement := fmt.Sprintf("INSERT INTO %s (id, name) VALUES (?, ?), (?, ?), (?, ?), (?, ?);", tableName)
+	toolStatement := fmt.Sprintf("SELECT * FROM %s WHERE id = ? OR name = ? ORDER BY id;", tableName)
+	idToolStatement := fmt.Sprintf("SELECT * FROM %s WHERE id = ?;", tableName)
+	nameToolStatement := fmt.Sprintf("SELECT * FROM %s WHERE name = ?;", tableName)
+	arrayToolStatement := fmt.Sprintf("SELECT * FROM %s WHERE list_contains(?, id) AND list_contains(?, name) ORDER BY id;", tableName)
+	params := []any{1, "Alice", 2, "Jane", 3, "Sid", 4, nil}
+	return createStatement, insertStatement, toolStatement, idToolStatement, nameToolStatement, arrayToolStatement, params
+}
+
+// getDuckDBAuthToolInfo returns statements and params of my-auth-tool for
+// the duckdb-sql kind.
+func getDuckDBAuthToolInfo(tableName string) (string, string, string, []any) {
+	createStatement := fmt.Sprintf("CREATE TABLE %s (id INTEGER, name VARCHAR NOT NULL, email VARCHAR);", tableName)
+	insertStatement := fmt.Sprintf("INSERT INTO %s (id, name, email) VALUES (?, ?, ?), (?, ?, ?);", tableName)
+	toolStatement := fmt.Sprintf("SELECT name FROM %s WHERE email = ?;", tableName)
+	params := []any{1, "Alice", tests.ServiceAccountEmail, 2, "Jane", "janedoe@gmail.com"}
+	return createStatement, insertStatement, toolStatement, params
+}
+
+func TestDuckDBToolEndpoints(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	var args []string
+
+	database := filepath.Join(t.TempDir(), "test.duckdb")
+	sourceConfig := getDuckDBVars(database)
+	source, err := initDuckDBSource(ctx, database)
+	if err != nil {
+		t.Fatalf("unable to create DuckDB source: %s", err)
+	}
+
+	// create table name with UUID
+	tableNameParam := "param_table_" + strings.ReplaceAll(uuid.New().String(), "-", "")
+	tableNameAuth := "auth_table_" + strings.ReplaceAll(uuid.New().String(), "-", "")
+	tableNameTemplateParam := "template_param_table_" + strings.ReplaceAll(uuid.New().String(), "-", "")
+
+	// set up data for param tool
+	createParamTableStmt, insertParamTableStmt, paramToolStmt, idParamToolStmt, nameParamToolStmt, arrayToolStmt, paramTestParams := getDuckDBParamToolInfo(tableNameParam)
+	setupDuckDBTable(t, ctx, source, createParamTableStmt, insertParamTableStmt, tableNameParam, paramTestParams)
+
+	// set up data for auth tool
+	createAuthTableStmt, insertAuthTableStmt, authToolStmt, authTestParams := getDuckDBAuthToolInfo(tableNameAuth)
+	setupDuckDBTable(t, ctx, source, createAuthTableStmt, insertAuthTableStmt, tableNameAuth, authTestParams)
+
+	// Write config into a file and pass it to command
+	toolsFile := tests.GetToolsConfig(sourceConfig, DuckDBToolKind, paramToolStmt, idParamToolStmt, nameParamToolStmt, arrayToolStmt, authToolStmt)
+	tmplSelectCombined, tmplSelectFilterCombined := tests.GetMySQLTmplToolStatement()
+	toolsFile = tests.AddTemplateParamConfig(t, toolsFile, DuckDBToolKind, tmplSelectCombined, tmplSelectFilterCombined, "")
+
+	cmd, cleanup, err := tests.StartCmd(ctx, toolsFile, args...)
+	if err != nil {
+		t.Fatalf("command initialization returned an error: %s", err)
+	}
+	defer cleanup()
+
+	waitCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	out, err := testutils.WaitForString(waitCtx, regexp.MustCompile(`Server ready to serve`), cmd.Out)
+	if err != nil {
+		t.Logf("toolbox command logs: \n%s", out)
+		t.Fatalf("toolbox didn't start successfully: %s", err)
+	}
+
+	tests.RunToolGetTest(t)
+
+	select1Want := "[{\"1\":1}]"
+	// Partial message; the full error message depends on the version of the CLI.
+	failInvocationWant := `{"jsonrpc":"2.0","id":"invoke-fail-tool","result":{"content":[{"type":"text","text":"unable to execute query: Parser Error: syntax error at or near \"SELEC\"`
+	invokeParamWant, invokeIdNullWant, nullWant, mcpInvokeParamWant := tests.GetNonSpannerInvokeParamWant()
+	tests.RunToolInvokeTest(t, select1Want, invokeParamWant, invokeIdNullWant, nullWant, true, true)
+	tests.RunMCPToolCallMethod(t, mcpInvokeParamWant, failInvocationWant)
+	tests.RunToolInvokeWithTemplateParameters(t, tableNameTemplateParam, tests.NewTemplateParameterTestConfig())
+}
+
+// TestDuckDBReadFiles queries CSV and Parquet files with an in-memory
+// database.
+func TestDuckDBReadFiles(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	dir := t.TempDir()
+	csvPath := filepath.Join(dir, "people.csv")
+	if err := os.WriteFile(csvPath, []byte("id,name\n1,Alice\n2,Jane\n"), 0o600); err != nil {
+		t.Fatalf("unable to write CSV file: %s", err)
+	}
+	parquetPath := filepath.Join(dir, "people.parquet")
+
+	source, err := initDuckDBSource(ctx, "")
+	if err != nil {
+		t.Fatalf("unable to create DuckDB source: %s", err)
+	}
+	if _, err := source.DuckDBQuery(ctx, fmt.Sprintf("COPY (SELECT * FROM read_csv('%s')) TO '%s' (FORMAT parquet);", csvPath, parquetPath), nil); err != nil {
+		t.Fatalf("unable to write Parquet file: %s", err)
+	}
+
+	want := `[{"id":1,"name":"Alice"},{"id":2,"name":"Jane"}]`
+	tcs := []struct {
+		statement string
+		path      string
+	}{
+		{statement: "SELECT id, name FROM read_csv($1) ORDER BY id;", path: csvPath},
+		{statement: "SELECT id, name FROM read_parquet($1) ORDER BY id;", path: parquetPath},
+	}
+	for _, tc := range tcs {
+		got, err := source.DuckDBQuery(ctx, tc.statement, []any{tc.path})
+		if err != nil {
+			t.Fatalf("unable to run %q: %s", tc.statement, err)
+		}
+		gotJSON, err := json.Marshal(got)
+		if err != nil {
+			t.Fatalf("unable to marshal results: %s", err)
+		}
+		if string(gotJSON) != want {
+			t.Fatalf("unexpected results of %q: got %s, want %s", tc.statement, gotJSON, want)
+		}
+	}
+}
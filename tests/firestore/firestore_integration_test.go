@@ -675,6 +675,13 @@ func runFirestoreGetDocumentsTest(t *testing.T, docPath1, docPath2 string) {
 			wantRegex:   `"exists":false`,
 			isErr:       false,
 		},
+		{
+			name:        "get documents with an invalid path",
+			api:         "http://127.0.0.1:5000/api/tool/firestore-get-docs/invoke",
+			requestBody: bytes.NewBuffer([]byte(fmt.Sprintf(`{"documentPaths": ["%s", "non-existent-collection"]}`, docPath1))),
+			wantRegex:   `"name":"Alice".*"error":"invalid document path`,
+			isErr:       false,
+		},
 		{
 			name:        "missing documentPaths parameter",
 			api:         "http://127.0.0.1:5000/api/tool/firestore-get-docs/invoke",
@@ -0,0 +1,305 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	kafkads "github.com/googleapis/genai-toolbox/internal/sources/kafka"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/tests"
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+var (
+	KafkaSourceKind    = "kafka"
+	KafkaBrokers       = os.Getenv("KAFKA_BROKERS")
+	KafkaSASLMechanism = os.Getenv("KAFKA_SASL_MECHANISM")
+	KafkaUser          = os.Getenv("KAFKA_USER")
+	KafkaPass          = os.Getenv("KAFKA_PASS")
+)
+
+func getKafkaVars(t *testing.T) map[string]any {
+	if KafkaBrokers == "" {
+		t.Fatal("'KAFKA_BROKERS' not set")
+	}
+
+	vars := map[string]any{
+		"kind":    KafkaSourceKind,
+		"brokers": strings.Split(KafkaBrokers, ","),
+	}
+	if KafkaSASLMechanism != "" {
+		vars["saslMechanism"] = KafkaSASLMechanism
+		vars["user"] = KafkaUser
+		vars["password"] = KafkaPass
+	}
+	return vars
+}
+
+// initKafkaSource initializes a source to set up the test topic with.
+func initKafkaSource(ctx context.Context) (*kafkads.Source, error) {
+	cfg := kafkads.Config{
+		Name:          "setup",
+		Kind:          KafkaSourceKind,
+		Brokers:       strings.Split(KafkaBrokers, ","),
+		SASLMechanism: KafkaSASLMechanism,
+		User:          KafkaUser,
+		Password:      KafkaPass,
+	}
+	s, err := cfg.Initialize(ctx, noop.NewTracerProvider().Tracer(""))
+	if err != nil {
+		return nil, err
+	}
+	return s.(*kafkads.Source), nil
+}
+
+// setupKafkaTopic creates a topic with a single partition, so that messages
+// are consumed in the order they are published.
+func setupKafkaTopic(t *testing.T, ctx context.Context, s *kafkads.Source, topic string) func(*testing.T) {
+	client := s.KafkaClient()
+	res, err := client.CreateTopics(ctx, &kafka.CreateTopicsRequest{
+		Topics: []kafka.TopicConfig{{Topic: topic, NumPartitions: 1, ReplicationFactor: 1}},
+	})
+	if err != nil {
+		t.Fatalf("unable to create test topic %s: %s", topic, err)
+	}
+	if err := res.Errors[topic]; err != nil {
+		t.Fatalf("unable to create test topic %s: %s", topic, err)
+	}
+
+	return func(t *testing.T) {
+		// tear down test
+		if _, err := client.DeleteTopics(context.Background(), &kafka.DeleteTopicsRequest{Topics: []string{topic}}); err != nil {
+			t.Errorf("Teardown failed: %s", err)
+		}
+	}
+}
+
+func getKafkaToolsConfig(sourceConfig map[string]any, topic string) map[string]any {
+	return map[string]any{
+		"sources": map[string]any{
+			"my-instance": sourceConfig,
+		},
+		"tools": map[string]any{
+			"my-publish-tool": map[string]any{
+				"kind":        "kafka-publish",
+				"source":      "my-instance",
+				"description": "Tool to test publishing messages.",
+				"topic":       topic,
+				"headers": map[string]any{
+					"origin": "toolbox",
+				},
+			},
+			"my-earliest-tool": map[string]any{
+				"kind":        "kafka-consume",
+				"source":      "my-instance",
+				"description": "Tool to test consuming the oldest messages.",
+				"topic":       topic,
+				"maxMessages": 5,
+				"startFrom":   "earliest",
+			},
+			"my-latest-tool": map[string]any{
+				"kind":        "kafka-consume",
+				"source":      "my-instance",
+				"description": "Tool to test consuming the most recent messages.",
+				"topic":       topic,
+				"maxMessages": 5,
+			},
+		},
+	}
+}
+
+func TestKafkaToolEndpoints(t *testing.T) {
+	sourceConfig := getKafkaVars(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	var args []string
+
+	source, err := initKafkaSource(ctx)
+	if err != nil {
+		t.Fatalf("unable to create Kafka client: %s", err)
+	}
+
+	// create topic name with UUID
+	topic := "toolbox_test_" + strings.ReplaceAll(uuid.New().String(), "-", "")
+	teardownTopic := setupKafkaTopic(t, ctx, source, topic)
+	defer teardownTopic(t)
+
+	toolsFile := getKafkaToolsConfig(sourceConfig, topic)
+
+	cmd, cleanup, err := tests.StartCmd(ctx, toolsFile, args...)
+	if err != nil {
+		t.Fatalf("command initialization returned an error: %s", err)
+	}
+	defer cleanup()
+
+	waitCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	out, err := testutils.WaitForString(waitCtx, regexp.MustCompile(`Server ready to serve`), cmd.Out)
+	if err != nil {
+		t.Logf("toolbox command logs: \n%s", out)
+		t.Fatalf("toolbox didn't start successfully: %s", err)
+	}
+
+	tests.RunToolGetTestByName(t, "my-latest-tool", map[string]any{
+		"my-latest-tool": map[string]any{
+			"description": "Tool to test consuming the most recent messages.",
+			"parameters": []any{
+				map[string]any{
+					"name":        "maxMessages",
+					"type":        "integer",
+					"required":    false,
+					"description": "The number of messages to read, at most 5.",
+					"authSources": []any{},
+					"minimum":     float64(1),
+					"maximum":     float64(5),
+				},
+			},
+			"authRequired": []any{},
+		},
+	})
+
+	invokeTcs := []struct {
+		name               string
+		api                string
+		requestBody        io.Reader
+		want               any
+		wantStatus         int
+		wantErrorSubstring string
+	}{
+		{
+			name:        "invoke my-earliest-tool on empty topic",
+			api:         "http://127.0.0.1:5000/api/tool/my-earliest-tool/invoke",
+			requestBody: bytes.NewBuffer([]byte(`{}`)),
+			want:        []any{},
+			wantStatus:  http.StatusOK,
+		},
+		{
+			name:        "invoke my-publish-tool with json value",
+			api:         "http://127.0.0.1:5000/api/tool/my-publish-tool/invoke",
+			requestBody: bytes.NewBuffer([]byte(`{"key": "order-1", "value": "{\"id\": 1}"}`)),
+			want:        map[string]any{"topic": topic, "partition": float64(0), "offset": float64(0)},
+			wantStatus:  http.StatusOK,
+		},
+		{
+			name:        "invoke my-publish-tool with text value",
+			api:         "http://127.0.0.1:5000/api/tool/my-publish-tool/invoke",
+			requestBody: bytes.NewBuffer([]byte(`{"value": "shipped"}`)),
+			want:        map[string]any{"topic": topic, "partition": float64(0), "offset": float64(1)},
+			wantStatus:  http.StatusOK,
+		},
+		{
+			name:        "invoke my-publish-tool with key",
+			api:         "http://127.0.0.1:5000/api/tool/my-publish-tool/invoke",
+			requestBody: bytes.NewBuffer([]byte(`{"key": "order-2", "value": "{\"id\": 2}"}`)),
+			want:        map[string]any{"topic": topic, "partition": float64(0), "offset": float64(2)},
+			wantStatus:  http.StatusOK,
+		},
+		{
+			name:        "invoke my-earliest-tool",
+			api:         "http://127.0.0.1:5000/api/tool/my-earliest-tool/invoke",
+			requestBody: bytes.NewBuffer([]byte(`{"maxMessages": 2}`)),
+			want: []any{
+				map[string]any{"partition": float64(0), "offset": float64(0), "key": "order-1", "value": map[string]any{"id": float64(1)}, "headers": map[string]any{"origin": "toolbox"}},
+				map[string]any{"partition": float64(0), "offset": float64(1), "key": nil, "value": "shipped", "headers": map[string]any{"origin": "toolbox"}},
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:        "invoke my-latest-tool",
+			api:         "http://127.0.0.1:5000/api/tool/my-latest-tool/invoke",
+			requestBody: bytes.NewBuffer([]byte(`{"maxMessages": 1}`)),
+			want: []any{
+				map[string]any{"partition": float64(0), "offset": float64(2), "key": "order-2", "value": map[string]any{"id": float64(2)}, "headers": map[string]any{"origin": "toolbox"}},
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:               "invoke my-publish-tool without value",
+			api:                "http://127.0.0.1:5000/api/tool/my-publish-tool/invoke",
+			requestBody:        bytes.NewBuffer([]byte(`{"key": "order-3"}`)),
+			wantStatus:         http.StatusBadRequest,
+			wantErrorSubstring: `parameter \"value\" is required`,
+		},
+		{
+			name:               "invoke my-latest-tool with too many messages",
+			api:                "http://127.0.0.1:5000/api/tool/my-latest-tool/invoke",
+			requestBody:        bytes.NewBuffer([]byte(`{"maxMessages": 6}`)),
+			wantStatus:         http.StatusBadRequest,
+			wantErrorSubstring: "6 is greater than the maximum 5",
+		},
+	}
+	for _, tc := range invokeTcs {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := http.Post(tc.api, "application/json", tc.requestBody)
+			if err != nil {
+				t.Fatalf("error when sending a request: %s", err)
+			}
+			defer resp.Body.Close()
+			bodyBytes, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("failed to read response body: %s", err)
+			}
+			if resp.StatusCode != tc.wantStatus {
+				t.Fatalf("response status code: got %d, want %d: %s", resp.StatusCode, tc.wantStatus, string(bodyBytes))
+			}
+
+			if tc.wantErrorSubstring != "" {
+				if !strings.Contains(string(bodyBytes), tc.wantErrorSubstring) {
+					t.Fatalf("expected error message to contain %q, but got %q", tc.wantErrorSubstring, string(bodyBytes))
+				}
+				return
+			}
+
+			var body map[string]any
+			if err := json.Unmarshal(bodyBytes, &body); err != nil {
+				t.Fatalf("error parsing response body: %s", err)
+			}
+			result, ok := body["result"].(string)
+			if !ok {
+				t.Fatalf("unable to find result in response body")
+			}
+			var got any
+			if err := json.Unmarshal([]byte(result), &got); err != nil {
+				t.Fatalf("error parsing result %q: %s", result, err)
+			}
+			// the timestamps of the messages are set by the brokers
+			if messages, ok := got.([]any); ok {
+				for _, m := range messages {
+					if _, ok := m.(map[string]any)["timestamp"]; !ok {
+						t.Fatalf("message has no timestamp: %v", m)
+					}
+					delete(m.(map[string]any), "timestamp")
+				}
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("unexpected result: diff %v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,298 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oracle
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/googleapis/genai-toolbox/internal/sources/oracle"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/tests"
+)
+
+var (
+	OracleSourceKind  = "oracle"
+	OracleToolKind    = "oracle-sql"
+	OracleHost        = os.Getenv("ORACLE_HOST")
+	OraclePort        = os.Getenv("ORACLE_PORT")
+	OracleServiceName = os.Getenv("ORACLE_SERVICE_NAME")
+	OracleUser        = os.Getenv("ORACLE_USER")
+	OraclePass        = os.Getenv("ORACLE_PASS")
+)
+
+func getOracleVars(t *testing.T) map[string]any {
+	switch "" {
+	case OracleHost:
+		t.Fatal("'ORACLE_HOST' not set")
+	case OraclePort:
+		t.Fatal("'ORACLE_PORT' not set")
+	case OracleServiceName:
+		t.Fatal("'ORACLE_SERVICE_NAME' not set")
+	case OracleUser:
+		t.Fatal("'ORACLE_USER' not set")
+	case OraclePass:
+		t.Fatal("'ORACLE_PASS' not set")
+	}
+
+	return map[string]any{
+		"kind":        OracleSourceKind,
+		"host":        OracleHost,
+		"port":        OraclePort,
+		"serviceName": OracleServiceName,
+		"user":        OracleUser,
+		"password":    OraclePass,
+	}
+}
+
+// initOracleConnection opens a connection pool to set up the test data with.
+func initOracleConnection() (*sql.DB, error) {
+	cfg := oracle.Config{
+		Host:        OracleHost,
+		Port:        OraclePort,
+		ServiceName: OracleServiceName,
+		User:        OracleUser,
+		Password:    OraclePass,
+	}
+	db, err := sql.Open("oracle", cfg.DSN())
+	if err != nil {
+		return nil, fmt.Errorf("sql.Open: %w", err)
+	}
+	return db, nil
+}
+
+// getOracleParamToolInfo returns statements and params of my-tool for the
+// oracle-sql kind. Oracle returns unquoted column names in uppercase, so they
+// are aliased to match the wants shared with other sources. Tool statements
+// bind their parameters by name.
+func getOracleParamToolInfo(tableName string) (string, string, string, string, string, string, []any) {
+	createStatement := fmt.Sprintf("CREATE TABLE %s (id NUMBER(10), name VARCHAR2(255))", tableName)
+	insertStatement := fmt.Sprintf("INSERT INTO %s (id, name) SELECT :1, :2 FROM DUAL UNION ALL SELECT :3, :4 FROM DUAL UNION ALL SELECT :5, :6 FROM DUAL UNION ALL SELECT :7, :8 FROM DUAL", tableName)
+	toolStatement := fmt.Sprintf(`SELECT id AS "id", name AS "name" FROM %s WHERE id = :id OR name = :name ORDER BY id`, tableName)
+	idParamStatement := fmt.Sprintf(`SELECT id AS "id", name AS "name" FROM %s WHERE id = :id`, tableName)
+	nameParamStatement := fmt.Sprintf(`SELECT id AS "id", name AS "name" FROM %s WHERE name = :name`, tableName)
+	// arrays can't be bound to Oracle statements
+	arrayToolStatement := fmt.Sprintf(`SELECT id AS "id", name AS "name" FROM %s WHERE id = :idArray AND name = :nameArray`, tableName)
+	params := []any{1, "Alice", 2, "Jane", 3, "Sid", 4, nil}
+	return createStatement, insertStatement, toolStatement, idParamStatement, nameParamStatement, arrayToolStatement, params
+}
+
+// getOracleAuthToolInfo returns statements and params of my-auth-tool for the
+// oracle-sql kind.
+func getOracleAuthToolInfo(tableName string) (string, string, string, []any) {
+	createStatement := fmt.Sprintf("CREATE TABLE %s (id NUMBER(10), name VARCHAR2(255), email VARCHAR2(255))", tableName)
+	insertStatement := fmt.Sprintf("INSERT INTO %s (id, name, email) SELECT :1, :2, :3 FROM DUAL UNION ALL SELECT :4, :5, :6 FROM DUAL", tableName)
+	toolStatement := fmt.Sprintf(`SELECT name AS "name" FROM %s WHERE email = :email`, tableName)
+	params := []any{1, "Alice", tests.ServiceAccountEmail, 2, "Jane", "janedoe@gmail.com"}
+	return createStatement, insertStatement, toolStatement, params
+}
+
+// getOracleTmplToolStatement returns statements for template parameter test
+// cases for the oracle-sql kind.
+func getOracleTmplToolStatement() (string, string) {
+	tmplSelectCombined := "SELECT * FROM {{.tableName}} WHERE id = :id ORDER BY id"
+	tmplSelectFilterCombined := "SELECT * FROM {{.tableName}} WHERE {{.columnFilter}} = :name ORDER BY id"
+	return tmplSelectCombined, tmplSelectFilterCombined
+}
+
+// getOracleWants returns the expected wants for oracle
+func getOracleWants() (string, string, string) {
+	select1Want := "[{\"1\":1}]"
+	// Partial message; the full error message depends on the version of the
+	// database.
+	failInvocationWant := `{"jsonrpc":"2.0","id":"invoke-fail-tool","result":{"content":[{"type":"text","text":"unable to execute query: ORA-00900`
+	createTableStatement := `"CREATE TABLE t (id NUMBER GENERATED ALWAYS AS IDENTITY PRIMARY KEY, name VARCHAR2(255))"`
+	return select1Want, failInvocationWant, createTableStatement
+}
+
+func setupOracleTable(t *testing.T, ctx context.Context, pool *sql.DB, createStatement, insertStatement, tableName string, params []any) func(*testing.T) {
+	if _, err := pool.ExecContext(ctx, createStatement); err != nil {
+		t.Fatalf("unable to create test table %s: %s", tableName, err)
+	}
+	if _, err := pool.ExecContext(ctx, insertStatement, params...); err != nil {
+		t.Fatalf("unable to insert test data: %s", err)
+	}
+
+	return func(t *testing.T) {
+		// tear down test
+		if _, err := pool.ExecContext(ctx, fmt.Sprintf("DROP TABLE IF EXISTS %s", tableName)); err != nil {
+			t.Errorf("Teardown failed: %s", err)
+		}
+	}
+}
+
+// addOracleExecuteSqlConfig gets the tools config for `oracle-execute-sql`
+func addOracleExecuteSqlConfig(t *testing.T, config map[string]any) map[string]any {
+	tools, ok := config["tools"].(map[string]any)
+	if !ok {
+		t.Fatalf("unable to get tools from config")
+	}
+	tools["my-exec-sql-tool"] = map[string]any{
+		"kind":        "oracle-execute-sql",
+		"source":      "my-instance",
+		"description": "Tool to execute sql",
+	}
+	tools["my-auth-exec-sql-tool"] = map[string]any{
+		"kind":        "oracle-execute-sql",
+		"source":      "my-instance",
+		"description": "Tool to execute sql",
+		"authRequired": []string{
+			"my-google-auth",
+		},
+	}
+	tools["my-read-only-exec-sql-tool"] = map[string]any{
+		"kind":        "oracle-execute-sql",
+		"source":      "my-instance",
+		"description": "Tool to execute read-only sql",
+		"readOnly":    true,
+	}
+	config["tools"] = tools
+	return config
+}
+
+func TestOracleToolEndpoints(t *testing.T) {
+	sourceConfig := getOracleVars(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	var args []string
+
+	pool, err := initOracleConnection()
+	if err != nil {
+		t.Fatalf("unable to create Oracle connection pool: %s", err)
+	}
+
+	// create table name with UUID
+	tableNameParam := "param_table_" + strings.ReplaceAll(uuid.New().String(), "-", "")
+	tableNameAuth := "auth_table_" + strings.ReplaceAll(uuid.New().String(), "-", "")
+	tableNameTemplateParam := "template_param_table_" + strings.ReplaceAll(uuid.New().String(), "-", "")
+
+	// set up data for param tool
+	createParamTableStmt, insertParamTableStmt, paramToolStmt, idParamToolStmt, nameParamToolStmt, arrayToolStmt, paramTestParams := getOracleParamToolInfo(tableNameParam)
+	teardownTable1 := setupOracleTable(t, ctx, pool, createParamTableStmt, insertParamTableStmt, tableNameParam, paramTestParams)
+	defer teardownTable1(t)
+
+	// set up data for auth tool
+	createAuthTableStmt, insertAuthTableStmt, authToolStmt, authTestParams := getOracleAuthToolInfo(tableNameAuth)
+	teardownTable2 := setupOracleTable(t, ctx, pool, createAuthTableStmt, insertAuthTableStmt, tableNameAuth, authTestParams)
+	defer teardownTable2(t)
+
+	// Write config into a file and pass it to command
+	toolsFile := tests.GetToolsConfig(sourceConfig, OracleToolKind, paramToolStmt, idParamToolStmt, nameParamToolStmt, arrayToolStmt, authToolStmt)
+	toolsFile = addOracleExecuteSqlConfig(t, toolsFile)
+	tmplSelectCombined, tmplSelectFilterCombined := getOracleTmplToolStatement()
+	toolsFile = tests.AddTemplateParamConfig(t, toolsFile, OracleToolKind, tmplSelectCombined, tmplSelectFilterCombined, "")
+
+	cmd, cleanup, err := tests.StartCmd(ctx, toolsFile, args...)
+	if err != nil {
+		t.Fatalf("command initialization returned an error: %s", err)
+	}
+	defer cleanup()
+
+	waitCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	out, err := testutils.WaitForString(waitCtx, regexp.MustCompile(`Server ready to serve`), cmd.Out)
+	if err != nil {
+		t.Logf("toolbox command logs: \n%s", out)
+		t.Fatalf("toolbox didn't start successfully: %s", err)
+	}
+
+	tests.RunToolGetTest(t)
+
+	select1Want, failInvocationWant, createTableStatement := getOracleWants()
+	invokeParamWant, invokeIdNullWant, nullWant, mcpInvokeParamWant := tests.GetNonSpannerInvokeParamWant()
+	tests.RunToolInvokeTest(t, select1Want, invokeParamWant, invokeIdNullWant, nullWant, true, false)
+	tests.RunExecuteSqlToolInvokeTest(t, createTableStatement, select1Want)
+	tests.RunMCPToolCallMethod(t, mcpInvokeParamWant, failInvocationWant)
+	templateParamTestConfig := tests.NewTemplateParameterTestConfig(
+		tests.WithSelectAllWant("[{\"AGE\":21,\"ID\":1,\"NAME\":\"Alex\"},{\"AGE\":100,\"ID\":2,\"NAME\":\"Alice\"}]"),
+		tests.WithSelect1Want("[{\"AGE\":21,\"ID\":1,\"NAME\":\"Alex\"}]"),
+		tests.WithReplaceNameFieldArray(`["name AS \"name\""]`),
+	)
+	tests.RunToolInvokeWithTemplateParameters(t, tableNameTemplateParam, templateParamTestConfig)
+
+	runOracleReadOnlyToolInvokeTest(t, select1Want, tableNameParam)
+}
+
+// runOracleReadOnlyToolInvokeTest checks that my-read-only-exec-sql-tool
+// runs queries and rejects writes.
+func runOracleReadOnlyToolInvokeTest(t *testing.T, select1Want, tableNameParam string) {
+	invokeTcs := []struct {
+		name        string
+		requestBody io.Reader
+		want        string
+		isErr       bool
+	}{
+		{
+			name:        "invoke my-read-only-exec-sql-tool",
+			requestBody: bytes.NewBuffer([]byte(`{"sql":"SELECT 1;"}`)),
+			want:        select1Want,
+		},
+		{
+			name:        "invoke my-read-only-exec-sql-tool with a write",
+			requestBody: bytes.NewBuffer([]byte(fmt.Sprintf(`{"sql":"DELETE FROM %s"}`, tableNameParam))),
+			isErr:       true,
+		},
+		{
+			name:        "invoke my-read-only-exec-sql-tool with a PL/SQL block",
+			requestBody: bytes.NewBuffer([]byte(fmt.Sprintf(`{"sql":"BEGIN DELETE FROM %s; END;"}`, tableNameParam))),
+			isErr:       true,
+		},
+	}
+	for _, tc := range invokeTcs {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := http.Post("http://127.0.0.1:5000/api/tool/my-read-only-exec-sql-tool/invoke", "application/json", tc.requestBody)
+			if err != nil {
+				t.Fatalf("unable to send request: %s", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != http.StatusOK {
+				if tc.isErr {
+					return
+				}
+				bodyBytes, _ := io.ReadAll(resp.Body)
+				t.Fatalf("response status code is not 200, got %d: %s", resp.StatusCode, string(bodyBytes))
+			}
+			if tc.isErr {
+				t.Fatalf("expected an error, got status %d", resp.StatusCode)
+			}
+
+			var body map[string]interface{}
+			err = json.NewDecoder(resp.Body).Decode(&body)
+			if err != nil {
+				t.Fatalf("error parsing response body")
+			}
+			got, ok := body["result"].(string)
+			if !ok {
+				t.Fatalf("unable to find result in response body")
+			}
+			if got != tc.want {
+				t.Fatalf("unexpected value: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,412 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snowflake
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/googleapis/genai-toolbox/internal/sources/snowflake"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools/snowflake/snowflakesql"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/googleapis/genai-toolbox/tests"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+var (
+	SnowflakeSourceKind = "snowflake"
+	SnowflakeToolKind   = "snowflake-sql"
+	SnowflakeAccount    = os.Getenv("SNOWFLAKE_ACCOUNT")
+	SnowflakeUser       = os.Getenv("SNOWFLAKE_USER")
+	SnowflakePrivateKey = os.Getenv("SNOWFLAKE_PRIVATE_KEY")
+	SnowflakeWarehouse  = os.Getenv("SNOWFLAKE_WAREHOUSE")
+	SnowflakeDatabase   = os.Getenv("SNOWFLAKE_DATABASE")
+	SnowflakeSchema     = os.Getenv("SNOWFLAKE_SCHEMA")
+)
+
+func getSnowflakeVars(t *testing.T) map[string]any {
+	switch "" {
+	case SnowflakeAccount:
+		t.Fatal("'SNOWFLAKE_ACCOUNT' not set")
+	case SnowflakeUser:
+		t.Fatal("'SNOWFLAKE_USER' not set")
+	case SnowflakePrivateKey:
+		t.Fatal("'SNOWFLAKE_PRIVATE_KEY' not set")
+	case SnowflakeWarehouse:
+		t.Fatal("'SNOWFLAKE_WAREHOUSE' not set")
+	case SnowflakeDatabase:
+		t.Fatal("'SNOWFLAKE_DATABASE' not set")
+	case SnowflakeSchema:
+		t.Fatal("'SNOWFLAKE_SCHEMA' not set")
+	}
+
+	return map[string]any{
+		"kind":       SnowflakeSourceKind,
+		"account":    SnowflakeAccount,
+		"user":       SnowflakeUser,
+		"privateKey": SnowflakePrivateKey,
+		"warehouse":  SnowflakeWarehouse,
+		"database":   SnowflakeDatabase,
+		"schema":     SnowflakeSchema,
+	}
+}
+
+// initSnowflakeSource initializes a source to set up the test data with.
+func initSnowflakeSource(ctx context.Context) (*snowflake.Source, error) {
+	cfg := snowflake.Config{
+		Name:       "setup",
+		Kind:       SnowflakeSourceKind,
+		Account:    SnowflakeAccount,
+		User:       SnowflakeUser,
+		PrivateKey: SnowflakePrivateKey,
+		Warehouse:  SnowflakeWarehouse,
+		Database:   SnowflakeDatabase,
+		Schema:     SnowflakeSchema,
+	}
+	s, err := cfg.Initialize(util.WithUserAgent(ctx, "test"), noop.NewTracerProvider().Tracer(""))
+	if err != nil {
+		return nil, err
+	}
+	return s.(*snowflake.Source), nil
+}
+
+// getSnowflakeParamToolInfo returns statements and params of my-tool for the
+// snowflake-sql kind. Snowflake returns unquoted column names in uppercase,
+// so they are aliased to match the wants shared with other sources.
+func getSnowflakeParamToolInfo(tableName string) (string, string, string, string, string, string, []any) {
+	createStatement := fmt.Sprintf("CREATE TABLE %s (id INT, name VARCHAR(255));", tableName)
+	insertStatement := fmt.Sprintf("INSERT INTO %s (id, name) VALUES (?, ?), (?, ?), (?, ?), (?, ?);", tableName)
+	toolStatement := fmt.Sprintf(`SELECT id AS "id", name AS "name" FROM %s WHERE id = ? OR name = ? ORDER BY id;`, tableName)
+	idParamStatement := fmt.Sprintf(`SELECT id AS "id", name AS "name" FROM %s WHERE id = ?;`, tableName)
+	nameParamStatement := fmt.Sprintf(`SELECT id AS "id", name AS "name" FROM %s WHERE name = ?;`, tableName)
+	arrayToolStatement := fmt.Sprintf(`SELECT id AS "id", name AS "name" FROM %s WHERE ARRAY_CONTAINS(id, ?) AND ARRAY_CONTAINS(name, ?);`, tableName)
+	params := []any{1, "Alice", 2, "Jane", 3, "Sid", 4, nil}
+	return createStatement, insertStatement, toolStatement, idParamStatement, nameParamStatement, arrayToolStatement, params
+}
+
+// getSnowflakeAuthToolInfo returns statements and params of my-auth-tool for
+// the snowflake-sql kind.
+func getSnowflakeAuthToolInfo(tableName string) (string, string, string, []any) {
+	createStatement := fmt.Sprintf("CREATE TABLE %s (id INT, name VARCHAR(255), email VARCHAR(255));", tableName)
+	insertStatement := fmt.Sprintf("INSERT INTO %s (id, name, email) VALUES (?, ?, ?), (?, ?, ?)", tableName)
+	toolStatement := fmt.Sprintf(`SELECT name AS "name" FROM %s WHERE email = ?;`, tableName)
+	params := []any{1, "Alice", tests.ServiceAccountEmail, 2, "Jane", "janedoe@gmail.com"}
+	return createStatement, insertStatement, toolStatement, params
+}
+
+// getSnowflakeTmplToolStatement returns statements for template parameter
+// test cases for the snowflake-sql kind.
+func getSnowflakeTmplToolStatement() (string, string) {
+	tmplSelectCombined := "SELECT * FROM {{.tableName}} WHERE id = ? ORDER BY id"
+	tmplSelectFilterCombined := "SELECT * FROM {{.tableName}} WHERE {{.columnFilter}} = ? ORDER BY id"
+	return tmplSelectCombined, tmplSelectFilterCombined
+}
+
+// getSnowflakeWants returns the expected wants for snowflake
+func getSnowflakeWants() (string, string, string) {
+	select1Want := "[{\"1\":1}]"
+	// Partial message; the full error message depends on the position of the
+	// syntax error.
+	failInvocationWant := `{"jsonrpc":"2.0","id":"invoke-fail-tool","result":{"content":[{"type":"text","text":"unable to execute query: 001003: SQL compilation error:`
+	insertWant := "[{\"number of rows inserted\":1}]"
+	return select1Want, failInvocationWant, insertWant
+}
+
+func setupSnowflakeTable(t *testing.T, ctx context.Context, s *snowflake.Source, createStatement, insertStatement, tableName string, params []any) func(*testing.T) {
+	if _, err := s.SnowflakeQuery(ctx, createStatement, nil); err != nil {
+		t.Fatalf("unable to create test table %s: %s", tableName, err)
+	}
+	bindings := make([]snowflake.Binding, 0, len(params))
+	for _, p := range params {
+		b, err := snowflakesql.Bind(p)
+		if err != nil {
+			t.Fatalf("unable to bind %v: %s", p, err)
+		}
+		bindings = append(bindings, b)
+	}
+	if _, err := s.SnowflakeQuery(ctx, insertStatement, bindings); err != nil {
+		t.Fatalf("unable to insert test data: %s", err)
+	}
+
+	return func(t *testing.T) {
+		// tear down test
+		if _, err := s.SnowflakeQuery(ctx, fmt.Sprintf("DROP TABLE IF EXISTS %s;", tableName), nil); err != nil {
+			t.Errorf("Teardown failed: %s", err)
+		}
+	}
+}
+
+// addSnowflakeExecuteSqlConfig gets the tools config for `snowflake-execute-sql`
+func addSnowflakeExecuteSqlConfig(t *testing.T, config map[string]any) map[string]any {
+	tools, ok := config["tools"].(map[string]any)
+	if !ok {
+		t.Fatalf("unable to get tools from config")
+	}
+	tools["my-exec-sql-tool"] = map[string]any{
+		"kind":        "snowflake-execute-sql",
+		"source":      "my-instance",
+		"description": "Tool to execute sql",
+	}
+	tools["my-auth-exec-sql-tool"] = map[string]any{
+		"kind":        "snowflake-execute-sql",
+		"source":      "my-instance",
+		"description": "Tool to execute sql",
+		"authRequired": []string{
+			"my-google-auth",
+		},
+	}
+	tools["my-read-only-exec-sql-tool"] = map[string]any{
+		"kind":        "snowflake-execute-sql",
+		"source":      "my-instance",
+		"description": "Tool to execute read-only sql",
+		"readOnly":    true,
+	}
+	config["tools"] = tools
+	return config
+}
+
+func TestSnowflakeToolEndpoints(t *testing.T) {
+	sourceConfig := getSnowflakeVars(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	var args []string
+
+	source, err := initSnowflakeSource(ctx)
+	if err != nil {
+		t.Fatalf("unable to create Snowflake source: %s", err)
+	}
+
+	// create table name with UUID
+	tableNameParam := "param_table_" + strings.ReplaceAll(uuid.New().String(), "-", "")
+	tableNameAuth := "auth_table_" + strings.ReplaceAll(uuid.New().String(), "-", "")
+	tableNameTemplateParam := "template_param_table_" + strings.ReplaceAll(uuid.New().String(), "-", "")
+
+	// set up data for param tool
+	createParamTableStmt, insertParamTableStmt, paramToolStmt, idParamToolStmt, nameParamToolStmt, arrayToolStmt, paramTestParams := getSnowflakeParamToolInfo(tableNameParam)
+	teardownTable1 := setupSnowflakeTable(t, ctx, source, createParamTableStmt, insertParamTableStmt, tableNameParam, paramTestParams)
+	defer teardownTable1(t)
+
+	// set up data for auth tool
+	createAuthTableStmt, insertAuthTableStmt, authToolStmt, authTestParams := getSnowflakeAuthToolInfo(tableNameAuth)
+	teardownTable2 := setupSnowflakeTable(t, ctx, source, createAuthTableStmt, insertAuthTableStmt, tableNameAuth, authTestParams)
+	defer teardownTable2(t)
+
+	// the table of template parameter tests is created here, since DDL
+	// statements return their status rather than no rows
+	createTmplTableStmt := fmt.Sprintf("CREATE TABLE %s (id INT, name VARCHAR(20), age INT);", tableNameTemplateParam)
+	if _, err := source.SnowflakeQuery(ctx, createTmplTableStmt, nil); err != nil {
+		t.Fatalf("unable to create test table %s: %s", tableNameTemplateParam, err)
+	}
+	defer func() {
+		if _, err := source.SnowflakeQuery(ctx, fmt.Sprintf("DROP TABLE IF EXISTS %s;", tableNameTemplateParam), nil); err != nil {
+			t.Errorf("Teardown failed: %s", err)
+		}
+	}()
+
+	// Write config into a file and pass it to command
+	toolsFile := tests.GetToolsConfig(sourceConfig, SnowflakeToolKind, paramToolStmt, idParamToolStmt, nameParamToolStmt, arrayToolStmt, authToolStmt)
+	toolsFile = addSnowflakeExecuteSqlConfig(t, toolsFile)
+	tmplSelectCombined, tmplSelectFilterCombined := getSnowflakeTmplToolStatement()
+	toolsFile = tests.AddTemplateParamConfig(t, toolsFile, SnowflakeToolKind, tmplSelectCombined, tmplSelectFilterCombined, "")
+
+	cmd, cleanup, err := tests.StartCmd(ctx, toolsFile, args...)
+	if err != nil {
+		t.Fatalf("command initialization returned an error: %s", err)
+	}
+	defer cleanup()
+
+	waitCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	out, err := testutils.WaitForString(waitCtx, regexp.MustCompile(`Server ready to serve`), cmd.Out)
+	if err != nil {
+		t.Logf("toolbox command logs: \n%s", out)
+		t.Fatalf("toolbox didn't start successfully: %s", err)
+	}
+
+	tests.RunToolGetTest(t)
+
+	select1Want, failInvocationWant, insertWant := getSnowflakeWants()
+	invokeParamWant, invokeIdNullWant, _, mcpInvokeParamWant := tests.GetNonSpannerInvokeParamWant()
+	// arrays can't be bound by the SQL API
+	tests.RunToolInvokeTest(t, select1Want, invokeParamWant, invokeIdNullWant, "[]", true, false)
+	tests.RunMCPToolCallMethod(t, mcpInvokeParamWant, failInvocationWant)
+	templateParamTestConfig := tests.NewTemplateParameterTestConfig(
+		tests.WithIgnoreDdl(),
+		tests.WithInsert1Want(insertWant),
+		tests.WithSelectAllWant("[{\"AGE\":21,\"ID\":1,\"NAME\":\"Alex\"},{\"AGE\":100,\"ID\":2,\"NAME\":\"Alice\"}]"),
+		tests.WithSelect1Want("[{\"AGE\":21,\"ID\":1,\"NAME\":\"Alex\"}]"),
+		tests.WithSelectEmptyWant("[]"),
+		tests.WithReplaceNameFieldArray(`["name AS \"name\""]`),
+	)
+	tests.RunToolInvokeWithTemplateParameters(t, tableNameTemplateParam, templateParamTestConfig)
+
+	runSnowflakeExecuteSqlToolInvokeTest(t, select1Want, invokeParamWant, tableNameParam, insertWant)
+}
+
+func runSnowflakeExecuteSqlToolInvokeTest(t *testing.T, select1Want, invokeParamWant, tableNameParam, insertWant string) {
+	// Get ID token
+	idToken, err := tests.GetGoogleIdToken(tests.ClientId)
+	if err != nil {
+		t.Fatalf("error getting Google ID token: %s", err)
+	}
+
+	// Test tool invoke endpoint
+	invokeTcs := []struct {
+		name          string
+		api           string
+		requestHeader map[string]string
+		requestBody   io.Reader
+		want          string
+		isErr         bool
+	}{
+		{
+			name:          "invoke my-exec-sql-tool",
+			api:           "http://127.0.0.1:5000/api/tool/my-exec-sql-tool/invoke",
+			requestHeader: map[string]string{},
+			requestBody:   bytes.NewBuffer([]byte(`{"sql":"SELECT 1"}`)),
+			want:          select1Want,
+			isErr:         false,
+		},
+		{
+			name:          "invoke my-exec-sql-tool with data present in table",
+			api:           "http://127.0.0.1:5000/api/tool/my-exec-sql-tool/invoke",
+			requestHeader: map[string]string{},
+			requestBody:   bytes.NewBuffer([]byte(fmt.Sprintf(`{"sql":"SELECT id AS \"id\", name AS \"name\" FROM %s WHERE id = 3 OR name = 'Alice' ORDER BY id"}`, tableNameParam))),
+			want:          invokeParamWant,
+			isErr:         false,
+		},
+		{
+			name:          "invoke my-exec-sql-tool with no matching rows",
+			api:           "http://127.0.0.1:5000/api/tool/my-exec-sql-tool/invoke",
+			requestHeader: map[string]string{},
+			requestBody:   bytes.NewBuffer([]byte(fmt.Sprintf(`{"sql":"SELECT * FROM %s WHERE id = 999"}`, tableNameParam))),
+			want:          "[]",
+			isErr:         false,
+		},
+		{
+			name:          "invoke my-exec-sql-tool insert entry",
+			api:           "http://127.0.0.1:5000/api/tool/my-exec-sql-tool/invoke",
+			requestHeader: map[string]string{},
+			requestBody:   bytes.NewBuffer([]byte(fmt.Sprintf(`{"sql":"INSERT INTO %s (id, name) VALUES (5, 'test_name')"}`, tableNameParam))),
+			want:          insertWant,
+			isErr:         false,
+		},
+		{
+			name:          "invoke my-exec-sql-tool with invalid sql",
+			api:           "http://127.0.0.1:5000/api/tool/my-exec-sql-tool/invoke",
+			requestHeader: map[string]string{},
+			requestBody:   bytes.NewBuffer([]byte(`{"sql":"SELEC 1"}`)),
+			isErr:         true,
+		},
+		{
+			name:          "invoke my-exec-sql-tool without body",
+			api:           "http://127.0.0.1:5000/api/tool/my-exec-sql-tool/invoke",
+			requestHeader: map[string]string{},
+			requestBody:   bytes.NewBuffer([]byte(`{}`)),
+			isErr:         true,
+		},
+		{
+			name:          "invoke my-read-only-exec-sql-tool",
+			api:           "http://127.0.0.1:5000/api/tool/my-read-only-exec-sql-tool/invoke",
+			requestHeader: map[string]string{},
+			requestBody:   bytes.NewBuffer([]byte(`{"sql":"SELECT 1"}`)),
+			want:          select1Want,
+			isErr:         false,
+		},
+		{
+			name:          "invoke my-read-only-exec-sql-tool with a write",
+			api:           "http://127.0.0.1:5000/api/tool/my-read-only-exec-sql-tool/invoke",
+			requestHeader: map[string]string{},
+			requestBody:   bytes.NewBuffer([]byte(fmt.Sprintf(`{"sql":"DELETE FROM %s"}`, tableNameParam))),
+			isErr:         true,
+		},
+		{
+			name:          "Invoke my-auth-exec-sql-tool with auth token",
+			api:           "http://127.0.0.1:5000/api/tool/my-auth-exec-sql-tool/invoke",
+			requestHeader: map[string]string{"my-google-auth_token": idToken},
+			requestBody:   bytes.NewBuffer([]byte(`{"sql":"SELECT 1"}`)),
+			isErr:         false,
+			want:          select1Want,
+		},
+		{
+			name:          "Invoke my-auth-exec-sql-tool with invalid auth token",
+			api:           "http://127.0.0.1:5000/api/tool/my-auth-exec-sql-tool/invoke",
+			requestHeader: map[string]string{"my-google-auth_token": "INVALID_TOKEN"},
+			requestBody:   bytes.NewBuffer([]byte(`{"sql":"SELECT 1"}`)),
+			isErr:         true,
+		},
+		{
+			name:          "Invoke my-auth-exec-sql-tool without auth token",
+			api:           "http://127.0.0.1:5000/api/tool/my-auth-exec-sql-tool/invoke",
+			requestHeader: map[string]string{},
+			requestBody:   bytes.NewBuffer([]byte(`{"sql":"SELECT 1"}`)),
+			isErr:         true,
+		},
+	}
+	for _, tc := range invokeTcs {
+		t.Run(tc.name, func(t *testing.T) {
+			// Send Tool invocation request
+			req, err := http.NewRequest(http.MethodPost, tc.api, tc.requestBody)
+			if err != nil {
+				t.Fatalf("unable to create request: %s", err)
+			}
+			req.Header.Add("Content-type", "application/json")
+			for k, v := range tc.requestHeader {
+				req.Header.Add(k, v)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("unable to send request: %s", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != http.StatusOK {
+				if tc.isErr {
+					return
+				}
+				bodyBytes, _ := io.ReadAll(resp.Body)
+				t.Fatalf("response status code is not 200, got %d: %s", resp.StatusCode, string(bodyBytes))
+			}
+
+			// Check response body
+			var body map[string]interface{}
+			err = json.NewDecoder(resp.Body).Decode(&body)
+			if err != nil {
+				t.Fatalf("error parsing response body")
+			}
+
+			got, ok := body["result"].(string)
+			if !ok {
+				t.Fatalf("unable to find result in response body")
+			}
+
+			if got != tc.want {
+				t.Fatalf("unexpected value: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}